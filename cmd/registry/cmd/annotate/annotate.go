@@ -25,6 +25,7 @@ import (
 	"github.com/apigee/registry/pkg/connection"
 	"github.com/apigee/registry/rpc"
 	"github.com/apigee/registry/server/registry/names"
+	"github.com/googleapis/gax-go/v2"
 	"github.com/spf13/cobra"
 	"google.golang.org/genproto/protobuf/field_mask"
 )
@@ -198,20 +199,25 @@ func (task *annotateApiTask) String() string {
 }
 
 func (task *annotateApiTask) Run(ctx context.Context) error {
-	var err error
-	task.api.Annotations, err = task.labeling.Apply(task.api.Annotations)
-	if err != nil {
-		log.FromContext(ctx).WithError(err).Errorf("Invalid annotation")
-		return nil
-	}
-	_, err = task.client.UpdateApi(ctx,
-		&rpc.UpdateApiRequest{
-			Api: task.api,
-			UpdateMask: &field_mask.FieldMask{
-				Paths: []string{"annotations"},
-			},
-		})
-	return err
+	return core.UpdateWithRetries(func(opts ...gax.CallOption) error {
+		annotations, err := task.labeling.Apply(task.api.Annotations)
+		if err != nil {
+			log.FromContext(ctx).WithError(err).Errorf("Invalid annotation")
+			return nil
+		}
+		task.api.Annotations = annotations
+		_, err = task.client.UpdateApi(ctx,
+			&rpc.UpdateApiRequest{
+				Api: task.api,
+				UpdateMask: &field_mask.FieldMask{
+					Paths: []string{"annotations"},
+				},
+			}, opts...)
+		return err
+	}, func() (err error) {
+		task.api, err = task.client.GetApi(ctx, &rpc.GetApiRequest{Name: task.api.Name})
+		return err
+	})
 }
 
 type annotateVersionTask struct {
@@ -225,20 +231,25 @@ func (task *annotateVersionTask) String() string {
 }
 
 func (task *annotateVersionTask) Run(ctx context.Context) error {
-	var err error
-	task.version.Annotations, err = task.labeling.Apply(task.version.Annotations)
-	if err != nil {
-		log.FromContext(ctx).WithError(err).Errorf("Invalid annotation")
-		return nil
-	}
-	_, err = task.client.UpdateApiVersion(ctx,
-		&rpc.UpdateApiVersionRequest{
-			ApiVersion: task.version,
-			UpdateMask: &field_mask.FieldMask{
-				Paths: []string{"annotations"},
-			},
-		})
-	return err
+	return core.UpdateWithRetries(func(opts ...gax.CallOption) error {
+		annotations, err := task.labeling.Apply(task.version.Annotations)
+		if err != nil {
+			log.FromContext(ctx).WithError(err).Errorf("Invalid annotation")
+			return nil
+		}
+		task.version.Annotations = annotations
+		_, err = task.client.UpdateApiVersion(ctx,
+			&rpc.UpdateApiVersionRequest{
+				ApiVersion: task.version,
+				UpdateMask: &field_mask.FieldMask{
+					Paths: []string{"annotations"},
+				},
+			}, opts...)
+		return err
+	}, func() (err error) {
+		task.version, err = task.client.GetApiVersion(ctx, &rpc.GetApiVersionRequest{Name: task.version.Name})
+		return err
+	})
 }
 
 type annotateSpecTask struct {
@@ -252,20 +263,25 @@ func (task *annotateSpecTask) String() string {
 }
 
 func (task *annotateSpecTask) Run(ctx context.Context) error {
-	var err error
-	task.spec.Annotations, err = task.labeling.Apply(task.spec.Annotations)
-	if err != nil {
-		log.FromContext(ctx).WithError(err).Errorf("Invalid annotation")
-		return nil
-	}
-	_, err = task.client.UpdateApiSpec(ctx,
-		&rpc.UpdateApiSpecRequest{
-			ApiSpec: task.spec,
-			UpdateMask: &field_mask.FieldMask{
-				Paths: []string{"annotations"},
-			},
-		})
-	return err
+	return core.UpdateWithRetries(func(opts ...gax.CallOption) error {
+		annotations, err := task.labeling.Apply(task.spec.Annotations)
+		if err != nil {
+			log.FromContext(ctx).WithError(err).Errorf("Invalid annotation")
+			return nil
+		}
+		task.spec.Annotations = annotations
+		_, err = task.client.UpdateApiSpec(ctx,
+			&rpc.UpdateApiSpecRequest{
+				ApiSpec: task.spec,
+				UpdateMask: &field_mask.FieldMask{
+					Paths: []string{"annotations"},
+				},
+			}, opts...)
+		return err
+	}, func() (err error) {
+		task.spec, err = task.client.GetApiSpec(ctx, &rpc.GetApiSpecRequest{Name: task.spec.Name})
+		return err
+	})
 }
 
 type annotateDeploymentTask struct {
@@ -279,18 +295,23 @@ func (task *annotateDeploymentTask) String() string {
 }
 
 func (task *annotateDeploymentTask) Run(ctx context.Context) error {
-	var err error
-	task.deployment.Annotations, err = task.labeling.Apply(task.deployment.Annotations)
-	if err != nil {
-		log.FromContext(ctx).WithError(err).Errorf("Invalid annotation")
-		return nil
-	}
-	_, err = task.client.UpdateApiDeployment(ctx,
-		&rpc.UpdateApiDeploymentRequest{
-			ApiDeployment: task.deployment,
-			UpdateMask: &field_mask.FieldMask{
-				Paths: []string{"annotations"},
-			},
-		})
-	return err
+	return core.UpdateWithRetries(func(opts ...gax.CallOption) error {
+		annotations, err := task.labeling.Apply(task.deployment.Annotations)
+		if err != nil {
+			log.FromContext(ctx).WithError(err).Errorf("Invalid annotation")
+			return nil
+		}
+		task.deployment.Annotations = annotations
+		_, err = task.client.UpdateApiDeployment(ctx,
+			&rpc.UpdateApiDeploymentRequest{
+				ApiDeployment: task.deployment,
+				UpdateMask: &field_mask.FieldMask{
+					Paths: []string{"annotations"},
+				},
+			}, opts...)
+		return err
+	}, func() (err error) {
+		task.deployment, err = task.client.GetApiDeployment(ctx, &rpc.GetApiDeploymentRequest{Name: task.deployment.Name})
+		return err
+	})
 }
@@ -26,6 +26,7 @@ func Command() *cobra.Command {
 
 	cmd.AddCommand(conformanceCommand())
 	cmd.AddCommand(complexityCommand())
+	cmd.AddCommand(indexCommand())
 	cmd.AddCommand(lintCommand())
 	cmd.AddCommand(lintStatsCommand())
 	cmd.AddCommand(scoreCommand())
@@ -0,0 +1,61 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute
+
+import (
+	"strings"
+
+	"github.com/apigee/registry/rpc"
+	discovery "github.com/google/gnostic/discovery"
+)
+
+// IndexDiscoveryDocument returns the methods and schemas of a Discovery document.
+// Paths include the service path of the document.
+func IndexDiscoveryDocument(document *discovery.Document) *rpc.SpecIndex {
+	index := &rpc.SpecIndex{}
+	prefix := "/" + strings.Trim(document.ServicePath, "/")
+	indexDiscoveryMethods(index, prefix, document.Methods)
+	indexDiscoveryResources(index, prefix, document.Resources)
+	if document.Schemas != nil {
+		for _, pair := range document.Schemas.AdditionalProperties {
+			index.Schemas = append(index.Schemas, pair.Name)
+		}
+	}
+	return index
+}
+
+func indexDiscoveryResources(index *rpc.SpecIndex, prefix string, resources *discovery.Resources) {
+	if resources == nil {
+		return
+	}
+	for _, pair := range resources.AdditionalProperties {
+		indexDiscoveryMethods(index, prefix, pair.Value.GetMethods())
+		indexDiscoveryResources(index, prefix, pair.Value.GetResources())
+	}
+}
+
+func indexDiscoveryMethods(index *rpc.SpecIndex, prefix string, methods *discovery.Methods) {
+	if methods == nil {
+		return
+	}
+	for _, pair := range methods.AdditionalProperties {
+		m := pair.Value
+		index.Operations = append(index.Operations, &rpc.SpecIndex_Operation{
+			Id:     m.Id,
+			Method: strings.ToUpper(m.HttpMethod),
+			Path:   strings.TrimSuffix(prefix, "/") + "/" + strings.TrimPrefix(m.Path, "/"),
+		})
+	}
+}
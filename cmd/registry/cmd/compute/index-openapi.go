@@ -0,0 +1,101 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute
+
+import (
+	"path"
+
+	"github.com/apigee/registry/rpc"
+	openapi_v2 "github.com/google/gnostic/openapiv2"
+	openapi_v3 "github.com/google/gnostic/openapiv3"
+)
+
+// IndexOpenAPIv2Document returns the operations and schemas of an OpenAPI v2 document.
+// Paths include the base path of the document.
+func IndexOpenAPIv2Document(document *openapi_v2.Document) *rpc.SpecIndex {
+	index := &rpc.SpecIndex{}
+	if document.Paths != nil {
+		for _, pair := range document.Paths.Path {
+			p := pair.Name
+			if document.BasePath != "" {
+				p = path.Join(document.BasePath, p)
+			}
+			v := pair.Value
+			for _, op := range []struct {
+				method    string
+				operation *openapi_v2.Operation
+			}{
+				{"GET", v.Get},
+				{"PUT", v.Put},
+				{"POST", v.Post},
+				{"DELETE", v.Delete},
+				{"OPTIONS", v.Options},
+				{"HEAD", v.Head},
+				{"PATCH", v.Patch},
+			} {
+				if op.operation != nil {
+					index.Operations = append(index.Operations, &rpc.SpecIndex_Operation{
+						Id:     op.operation.OperationId,
+						Method: op.method,
+						Path:   p,
+					})
+				}
+			}
+		}
+	}
+	if document.Definitions != nil {
+		for _, pair := range document.Definitions.AdditionalProperties {
+			index.Schemas = append(index.Schemas, pair.Name)
+		}
+	}
+	return index
+}
+
+// IndexOpenAPIv3Document returns the operations and schemas of an OpenAPI v3 document.
+func IndexOpenAPIv3Document(document *openapi_v3.Document) *rpc.SpecIndex {
+	index := &rpc.SpecIndex{}
+	if document.Paths != nil {
+		for _, pair := range document.Paths.Path {
+			v := pair.Value
+			for _, op := range []struct {
+				method    string
+				operation *openapi_v3.Operation
+			}{
+				{"GET", v.Get},
+				{"PUT", v.Put},
+				{"POST", v.Post},
+				{"DELETE", v.Delete},
+				{"OPTIONS", v.Options},
+				{"HEAD", v.Head},
+				{"PATCH", v.Patch},
+				{"TRACE", v.Trace},
+			} {
+				if op.operation != nil {
+					index.Operations = append(index.Operations, &rpc.SpecIndex_Operation{
+						Id:     op.operation.OperationId,
+						Method: op.method,
+						Path:   pair.Name,
+					})
+				}
+			}
+		}
+	}
+	if document.Components != nil && document.Components.Schemas != nil {
+		for _, pair := range document.Components.Schemas.AdditionalProperties {
+			index.Schemas = append(index.Schemas, pair.Name)
+		}
+	}
+	return index
+}
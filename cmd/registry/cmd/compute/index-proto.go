@@ -0,0 +1,124 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute
+
+import (
+	"archive/zip"
+	"bytes"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/yoheimuta/go-protoparser/v4/parser"
+
+	protoparser "github.com/yoheimuta/go-protoparser/v4"
+)
+
+// httpBinding matches the HTTP methods and paths of google.api.http options,
+// including those of additional bindings.
+var httpBinding = regexp.MustCompile(`\b(get|put|post|delete|patch)\s*:\s*"([^"]*)"`)
+
+// IndexZippedProtos returns the services, RPCs and messages of the protos in a zip archive.
+// RPCs are listed with the HTTP methods and paths of their google.api.http options.
+func IndexZippedProtos(b []byte) (*rpc.SpecIndex, error) {
+	r, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		return nil, err
+	}
+
+	index := &rpc.SpecIndex{}
+	for _, f := range r.File {
+		if !strings.HasSuffix(f.Name, ".proto") {
+			continue
+		}
+
+		r, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+
+		opts := []protoparser.Option{
+			protoparser.WithDebug(false),
+			protoparser.WithPermissive(true),
+			protoparser.WithFilename(filepath.Base(f.Name)),
+		}
+
+		p, err := protoparser.Parse(r, opts...)
+		r.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		prefix := ""
+		for _, x := range p.ProtoBody {
+			if pkg, ok := x.(*parser.Package); ok {
+				prefix = pkg.Name + "."
+			}
+		}
+		for _, x := range p.ProtoBody {
+			switch m := x.(type) {
+			case *parser.Message:
+				indexProtoMessage(index, prefix, m)
+			case *parser.Service:
+				indexProtoService(index, prefix, m)
+			}
+		}
+	}
+	return index, nil
+}
+
+func indexProtoMessage(index *rpc.SpecIndex, prefix string, m *parser.Message) {
+	name := prefix + m.MessageName
+	index.Messages = append(index.Messages, name)
+	for _, x := range m.MessageBody {
+		if nested, ok := x.(*parser.Message); ok {
+			indexProtoMessage(index, name+".", nested)
+		}
+	}
+}
+
+func indexProtoService(index *rpc.SpecIndex, prefix string, s *parser.Service) {
+	service := prefix + s.ServiceName
+	index.Services = append(index.Services, service)
+	for _, x := range s.ServiceBody {
+		v, ok := x.(*parser.RPC)
+		if !ok {
+			continue
+		}
+		id := service + "." + v.RPCName
+		bound := false
+		for _, option := range v.Options {
+			if option.OptionName != "(google.api.http)" {
+				continue
+			}
+			for _, binding := range httpBinding.FindAllStringSubmatch(option.Constant, -1) {
+				index.Operations = append(index.Operations, &rpc.SpecIndex_Operation{
+					Id:      id,
+					Method:  strings.ToUpper(binding[1]),
+					Path:    binding[2],
+					Service: service,
+				})
+				bound = true
+			}
+		}
+		if !bound {
+			index.Operations = append(index.Operations, &rpc.SpecIndex_Operation{
+				Id:      id,
+				Service: service,
+			})
+		}
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/apigee/registry/cmd/registry/core"
+	"github.com/apigee/registry/cmd/registry/types"
+	"github.com/apigee/registry/log"
+	"github.com/apigee/registry/pkg/connection"
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/names"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/proto"
+
+	discovery "github.com/google/gnostic/discovery"
+	oas2 "github.com/google/gnostic/openapiv2"
+	oas3 "github.com/google/gnostic/openapiv3"
+)
+
+func indexCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "index",
+		Short: "Compute indexes of the operations and schemas of API specs",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := cmd.Context()
+			c, err := connection.ActiveConfig()
+			if err != nil {
+				log.FromContext(ctx).WithError(err).Fatal("Failed to get config")
+			}
+			args[0] = c.FQName(args[0])
+
+			filter, err := cmd.Flags().GetString("filter")
+			if err != nil {
+				log.FromContext(ctx).WithError(err).Fatal("Failed to get filter from flags")
+			}
+			dryRun, err := cmd.Flags().GetBool("dry-run")
+			if err != nil {
+				log.FromContext(ctx).WithError(err).Fatal("Failed to get dry-run from flags")
+			}
+
+			client, err := connection.NewRegistryClientWithSettings(ctx, c)
+			if err != nil {
+				log.FromContext(ctx).WithError(err).Fatal("Failed to get client")
+			}
+			// Initialize task queue.
+			jobs, err := cmd.Flags().GetInt("jobs")
+			if err != nil {
+				log.FromContext(ctx).WithError(err).Fatal("Failed to get jobs from flags")
+			}
+			taskQueue, wait := core.WorkerPool(ctx, jobs)
+			defer wait()
+
+			parsed, err := names.ParseSpecRevision(args[0])
+			if err != nil {
+				log.FromContext(ctx).WithError(err).Fatal("Failed parse")
+			}
+
+			if parsed.RevisionID == "" {
+				err = core.ListSpecs(ctx, client, parsed.Spec(), filter, false, func(spec *rpc.ApiSpec) error {
+					taskQueue <- &computeIndexTask{
+						client:   client,
+						specName: spec.Name,
+						dryRun:   dryRun,
+					}
+					return nil
+				})
+			} else {
+				err = core.ListSpecRevisions(ctx, client, parsed, filter, false, func(spec *rpc.ApiSpec) error {
+					taskQueue <- &computeIndexTask{
+						client:   client,
+						specName: spec.Name,
+						dryRun:   dryRun,
+					}
+					return nil
+				})
+			}
+
+			if err != nil {
+				log.FromContext(ctx).WithError(err).Fatal("Failed to list specs")
+			}
+		},
+	}
+}
+
+type computeIndexTask struct {
+	client   connection.RegistryClient
+	specName string
+	dryRun   bool
+}
+
+func (task *computeIndexTask) String() string {
+	return "compute index " + task.specName
+}
+
+func (task *computeIndexTask) Run(ctx context.Context) error {
+	specName, err := names.ParseSpecRevision(task.specName)
+	if err != nil {
+		return err
+	}
+	var spec *rpc.ApiSpec
+	if err = core.GetSpecRevision(ctx, task.client, specName, true, func(s *rpc.ApiSpec) error {
+		spec = s
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	relation := "index"
+	log.Debugf(ctx, "Computing %s/artifacts/%s", task.specName, relation)
+	contents := spec.GetContents()
+	if strings.Contains(spec.GetMimeType(), "+gzip") {
+		if contents, err = core.GUnzippedBytes(contents); err != nil {
+			return err
+		}
+	}
+	var index *rpc.SpecIndex
+	if types.IsOpenAPIv2(spec.GetMimeType()) {
+		document, err := oas2.ParseDocument(contents)
+		if err != nil {
+			log.FromContext(ctx).WithError(err).Errorf("Invalid OpenAPI: %s", task.specName)
+			return nil
+		}
+		index = IndexOpenAPIv2Document(document)
+	} else if types.IsOpenAPIv3(spec.GetMimeType()) {
+		document, err := oas3.ParseDocument(contents)
+		if err != nil {
+			log.FromContext(ctx).WithError(err).Errorf("Invalid OpenAPI: %s", task.specName)
+			return nil
+		}
+		index = IndexOpenAPIv3Document(document)
+	} else if types.IsDiscovery(spec.GetMimeType()) {
+		document, err := discovery.ParseDocument(contents)
+		if err != nil {
+			log.FromContext(ctx).WithError(err).Errorf("Invalid Discovery: %s", task.specName)
+			return nil
+		}
+		index = IndexDiscoveryDocument(document)
+	} else if types.IsProto(spec.GetMimeType()) && types.IsZipArchive(spec.GetMimeType()) {
+		index, err = IndexZippedProtos(contents)
+		if err != nil {
+			log.FromContext(ctx).WithError(err).Errorf("Error processing protos: %s", task.specName)
+			return nil
+		}
+	} else {
+		return fmt.Errorf("we don't know how to index %s", task.specName)
+	}
+
+	if task.dryRun {
+		core.PrintMessage(index)
+		return nil
+	}
+	messageData, err := proto.Marshal(index)
+	if err != nil {
+		return err
+	}
+	return core.SetArtifact(ctx, task.client, &rpc.Artifact{
+		Name:     task.specName + "/artifacts/" + relation,
+		MimeType: types.MimeTypeForMessageType("google.cloud.apigeeregistry.v1.apihub.SpecIndex"),
+		Contents: messageData,
+	})
+}
@@ -0,0 +1,126 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	oas3 "github.com/google/gnostic/openapiv3"
+)
+
+func TestIndexOpenAPIv3Document(t *testing.T) {
+	contents, err := os.ReadFile("testdata/openapi.yaml")
+	if err != nil {
+		t.Fatalf("Setup: failed to read spec: %s", err)
+	}
+	document, err := oas3.ParseDocument(contents)
+	if err != nil {
+		t.Fatalf("Setup: failed to parse spec: %s", err)
+	}
+	want := &rpc.SpecIndex{
+		Operations: []*rpc.SpecIndex_Operation{
+			{Id: "listPets", Method: "GET", Path: "/pets"},
+			{Id: "createPets", Method: "POST", Path: "/pets"},
+			{Id: "showPetById", Method: "GET", Path: "/pets/{petId}"},
+		},
+		Schemas: []string{"Pet", "Pets", "Error"},
+	}
+	if diff := cmp.Diff(want, IndexOpenAPIv3Document(document), protocmp.Transform()); diff != "" {
+		t.Errorf("IndexOpenAPIv3Document() returned unexpected index (-want +got):\n%s", diff)
+	}
+}
+
+func TestIndexZippedProtos(t *testing.T) {
+	const library = `syntax = "proto3";
+
+package example.library.v1;
+
+import "google/api/annotations.proto";
+
+service Library {
+  rpc GetBook(GetBookRequest) returns (Book) {
+    option (google.api.http) = {
+      get: "/v1/{name=shelves/*/books/*}"
+      additional_bindings {
+        get: "/v1/{name=books/*}"
+      }
+    };
+  }
+  rpc MoveBook(MoveBookRequest) returns (Book);
+}
+
+message Book {
+  message Author {
+    string name = 1;
+  }
+  string name = 1;
+}
+
+message GetBookRequest {
+  string name = 1;
+}
+
+message MoveBookRequest {
+  string name = 1;
+}
+`
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	for name, contents := range map[string]string{
+		"example/library/v1/library.proto": library,
+		"README.md":                        "Not a proto.",
+	} {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("Setup: failed to create %s: %s", name, err)
+		}
+		if _, err := w.Write([]byte(contents)); err != nil {
+			t.Fatalf("Setup: failed to write %s: %s", name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("Setup: failed to close archive: %s", err)
+	}
+
+	got, err := IndexZippedProtos(buf.Bytes())
+	if err != nil {
+		t.Fatalf("IndexZippedProtos() returned error: %s", err)
+	}
+	const service = "example.library.v1.Library"
+	want := &rpc.SpecIndex{
+		Operations: []*rpc.SpecIndex_Operation{
+			{Id: service + ".GetBook", Method: "GET", Path: "/v1/{name=shelves/*/books/*}", Service: service},
+			{Id: service + ".GetBook", Method: "GET", Path: "/v1/{name=books/*}", Service: service},
+			{Id: service + ".MoveBook", Service: service},
+		},
+		Messages: []string{
+			"example.library.v1.Book",
+			"example.library.v1.Book.Author",
+			"example.library.v1.GetBookRequest",
+			"example.library.v1.MoveBookRequest",
+		},
+		Services: []string{service},
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("IndexZippedProtos() returned unexpected index (-want +got):\n%s", diff)
+	}
+}
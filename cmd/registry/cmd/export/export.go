@@ -138,6 +138,8 @@ func (h *exportHandler) traverse() error {
 			return errors.New("exports of specific revisions are not supported")
 		} else if artifact, err := names.ParseArtifact(pattern); err == nil {
 			return core.ListArtifacts(ctx, client, artifact, filter, false, h.artifactHandler())
+		} else if rev, err := names.ParseArtifactRevision(pattern); err == nil {
+			return core.ListArtifactRevisions(ctx, client, rev, filter, false, h.artifactHandler())
 		}
 		return fmt.Errorf("unsupported pattern %+v", pattern)
 	}
@@ -164,6 +166,8 @@ func (h *exportHandler) traverse() error {
 		return errors.New("exports of specific revisions are not supported")
 	} else if artifact, err := names.ParseArtifact(pattern); err == nil {
 		return core.GetArtifact(ctx, client, artifact, false, h.artifactHandler())
+	} else if artifact, err := names.ParseArtifactRevision(pattern); err == nil {
+		return core.GetArtifactRevision(ctx, client, artifact, false, h.artifactHandler())
 	} else {
 		return fmt.Errorf("unsupported pattern %+v", pattern)
 	}
@@ -227,10 +231,10 @@ func (h *exportHandler) apiSpecHandler() func(message *rpc.ApiSpec) error {
 func (h *exportHandler) artifactHandler() func(message *rpc.Artifact) error {
 	return func(message *rpc.Artifact) error {
 		h.count++
-		name, err := names.ParseArtifact(message.Name)
+		name, err := names.ParseArtifactRevision(message.Name)
 		if err != nil {
 			return err
 		}
-		return patch.ExportArtifact(h.ctx, h.client, name, h.root, h.taskQueue)
+		return patch.ExportArtifactRevision(h.ctx, h.client, name, h.root, h.taskQueue)
 	}
 }
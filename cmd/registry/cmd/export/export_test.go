@@ -17,6 +17,8 @@ package export
 import (
 	"context"
 	"io"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/apigee/registry/pkg/connection"
@@ -82,6 +84,7 @@ func TestExportYAML(t *testing.T) {
 		"projects/my-project/locations/global/artifacts",
 		"projects/my-project/locations/global/artifacts/-",
 		"projects/my-project/locations/global/artifacts/x",
+		"projects/my-project/locations/global/artifacts/x@-",
 		"projects/my-project/locations/global/apis/a/artifacts",
 		"projects/my-project/locations/global/apis/a/artifacts/-",
 		"projects/my-project/locations/global/apis/a/artifacts/x",
@@ -107,6 +110,27 @@ func TestExportYAML(t *testing.T) {
 		})
 	}
 
+	// Verify that revisions of artifacts are exported to files named with their revision IDs.
+	artifact, err := registryClient.GetArtifact(ctx, &rpc.GetArtifactRequest{Name: "projects/my-project/locations/global/artifacts/x"})
+	if err != nil {
+		t.Fatalf("Failed to prepare test data: %+v", err)
+	}
+	t.Run("artifact revision", func(t *testing.T) {
+		root := t.TempDir()
+		cmd := Command()
+		args := []string{"projects/my-project/locations/global/artifacts/x@" + artifact.RevisionId, "--root", root}
+		cmd.SetArgs(args)
+		cmd.SetOut(io.Discard)
+		cmd.SetErr(io.Discard)
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("Execute() with args %v returned error: %s", args, err)
+		}
+		filename := filepath.Join(root, "my-project", "artifacts", "x@"+artifact.RevisionId+".yaml")
+		if _, err := os.Stat(filename); err != nil {
+			t.Errorf("Execute() with args %v didn't write %s: %s", args, filename, err)
+		}
+	})
+
 	// Subsequent exports should all fail, so they share a common output directory.
 	root := t.TempDir()
 
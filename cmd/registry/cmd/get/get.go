@@ -132,6 +132,8 @@ func (h *getHandler) traverse() error {
 			return core.ListSpecRevisions(ctx, client, rev, filter, false, h.apiSpecHandler())
 		} else if artifact, err := names.ParseArtifact(name); err == nil {
 			return core.ListArtifacts(ctx, client, artifact, filter, false, h.artifactHandler())
+		} else if rev, err := names.ParseArtifactRevision(name); err == nil {
+			return core.ListArtifactRevisions(ctx, client, rev, filter, false, h.artifactHandler())
 		}
 		return fmt.Errorf("unsupported pattern %+v", name)
 	}
@@ -158,6 +160,8 @@ func (h *getHandler) traverse() error {
 		return core.GetSpecRevision(ctx, client, spec, false, h.apiSpecHandler())
 	} else if artifact, err := names.ParseArtifact(name); err == nil {
 		return core.GetArtifact(ctx, client, artifact, false, h.artifactHandler())
+	} else if artifact, err := names.ParseArtifactRevision(name); err == nil {
+		return core.GetArtifactRevision(ctx, client, artifact, false, h.artifactHandler())
 	} else {
 		return fmt.Errorf("unsupported pattern %+v", name)
 	}
@@ -82,6 +82,10 @@ func TestGetValidResources(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Failed to prepare test data: %+v", err)
 	}
+	artifact, err := registryClient.GetArtifact(ctx, &rpc.GetArtifactRequest{Name: "projects/my-project/locations/global/apis/a/artifacts/x"})
+	if err != nil {
+		t.Fatalf("Failed to prepare test data: %+v", err)
+	}
 	// Verify that get runs for each resource.
 	resources := []string{
 		"projects",
@@ -96,6 +100,8 @@ func TestGetValidResources(t *testing.T) {
 		"projects/my-project/locations/global/apis/a/artifacts",
 		"projects/my-project/locations/global/apis/a/artifacts/-",
 		"projects/my-project/locations/global/apis/a/artifacts/x",
+		"projects/my-project/locations/global/apis/a/artifacts/x@-",
+		"projects/my-project/locations/global/apis/a/artifacts/x@" + artifact.RevisionId,
 		"projects/my-project/locations/global/apis/a/versions",
 		"projects/my-project/locations/global/apis/a/versions/-",
 		"projects/my-project/locations/global/apis/a/versions/v",
@@ -159,6 +165,7 @@ func TestGetValidResources(t *testing.T) {
 		"projects/my-project/locations/global/apis/a/versions/v/specs/s",
 		"projects/my-project/locations/global/artifacts/x",
 		"projects/my-project/locations/global/apis/a/artifacts/x",
+		"projects/my-project/locations/global/apis/a/artifacts/x@" + artifact.RevisionId,
 		"projects/my-project/locations/global/apis/a/versions/v/artifacts/x",
 		"projects/my-project/locations/global/apis/a/versions/v/specs/s/artifacts/x",
 		"projects/my-project/locations/global/apis/a/deployments/d/artifacts/x",
@@ -25,6 +25,7 @@ import (
 	"github.com/apigee/registry/pkg/connection"
 	"github.com/apigee/registry/rpc"
 	"github.com/apigee/registry/server/registry/names"
+	"github.com/googleapis/gax-go/v2"
 	"github.com/spf13/cobra"
 	"google.golang.org/genproto/protobuf/field_mask"
 )
@@ -198,20 +199,25 @@ func (task *labelApiTask) String() string {
 }
 
 func (task *labelApiTask) Run(ctx context.Context) error {
-	var err error
-	task.api.Labels, err = task.labeling.Apply(task.api.Labels)
-	if err != nil {
-		log.FromContext(ctx).WithError(err).Errorf("Invalid labelling")
-		return nil
-	}
-	_, err = task.client.UpdateApi(ctx,
-		&rpc.UpdateApiRequest{
-			Api: task.api,
-			UpdateMask: &field_mask.FieldMask{
-				Paths: []string{"labels"},
-			},
-		})
-	return err
+	return core.UpdateWithRetries(func(opts ...gax.CallOption) error {
+		labels, err := task.labeling.Apply(task.api.Labels)
+		if err != nil {
+			log.FromContext(ctx).WithError(err).Errorf("Invalid labelling")
+			return nil
+		}
+		task.api.Labels = labels
+		_, err = task.client.UpdateApi(ctx,
+			&rpc.UpdateApiRequest{
+				Api: task.api,
+				UpdateMask: &field_mask.FieldMask{
+					Paths: []string{"labels"},
+				},
+			}, opts...)
+		return err
+	}, func() (err error) {
+		task.api, err = task.client.GetApi(ctx, &rpc.GetApiRequest{Name: task.api.Name})
+		return err
+	})
 }
 
 type labelVersionTask struct {
@@ -225,20 +231,25 @@ func (task *labelVersionTask) String() string {
 }
 
 func (task *labelVersionTask) Run(ctx context.Context) error {
-	var err error
-	task.version.Labels, err = task.labeling.Apply(task.version.Labels)
-	if err != nil {
-		log.FromContext(ctx).WithError(err).Errorf("Invalid labelling")
-		return nil
-	}
-	_, err = task.client.UpdateApiVersion(ctx,
-		&rpc.UpdateApiVersionRequest{
-			ApiVersion: task.version,
-			UpdateMask: &field_mask.FieldMask{
-				Paths: []string{"labels"},
-			},
-		})
-	return err
+	return core.UpdateWithRetries(func(opts ...gax.CallOption) error {
+		labels, err := task.labeling.Apply(task.version.Labels)
+		if err != nil {
+			log.FromContext(ctx).WithError(err).Errorf("Invalid labelling")
+			return nil
+		}
+		task.version.Labels = labels
+		_, err = task.client.UpdateApiVersion(ctx,
+			&rpc.UpdateApiVersionRequest{
+				ApiVersion: task.version,
+				UpdateMask: &field_mask.FieldMask{
+					Paths: []string{"labels"},
+				},
+			}, opts...)
+		return err
+	}, func() (err error) {
+		task.version, err = task.client.GetApiVersion(ctx, &rpc.GetApiVersionRequest{Name: task.version.Name})
+		return err
+	})
 }
 
 type labelSpecTask struct {
@@ -252,20 +263,25 @@ func (task *labelSpecTask) String() string {
 }
 
 func (task *labelSpecTask) Run(ctx context.Context) error {
-	var err error
-	task.spec.Labels, err = task.labeling.Apply(task.spec.Labels)
-	if err != nil {
-		log.FromContext(ctx).WithError(err).Errorf("Invalid labelling")
-		return nil
-	}
-	_, err = task.client.UpdateApiSpec(ctx,
-		&rpc.UpdateApiSpecRequest{
-			ApiSpec: task.spec,
-			UpdateMask: &field_mask.FieldMask{
-				Paths: []string{"labels"},
-			},
-		})
-	return err
+	return core.UpdateWithRetries(func(opts ...gax.CallOption) error {
+		labels, err := task.labeling.Apply(task.spec.Labels)
+		if err != nil {
+			log.FromContext(ctx).WithError(err).Errorf("Invalid labelling")
+			return nil
+		}
+		task.spec.Labels = labels
+		_, err = task.client.UpdateApiSpec(ctx,
+			&rpc.UpdateApiSpecRequest{
+				ApiSpec: task.spec,
+				UpdateMask: &field_mask.FieldMask{
+					Paths: []string{"labels"},
+				},
+			}, opts...)
+		return err
+	}, func() (err error) {
+		task.spec, err = task.client.GetApiSpec(ctx, &rpc.GetApiSpecRequest{Name: task.spec.Name})
+		return err
+	})
 }
 
 type labelDeploymentTask struct {
@@ -279,18 +295,23 @@ func (task *labelDeploymentTask) String() string {
 }
 
 func (task *labelDeploymentTask) Run(ctx context.Context) error {
-	var err error
-	task.deployment.Labels, err = task.labeling.Apply(task.deployment.Labels)
-	if err != nil {
-		log.FromContext(ctx).WithError(err).Errorf("Invalid labelling")
-		return nil
-	}
-	_, err = task.client.UpdateApiDeployment(ctx,
-		&rpc.UpdateApiDeploymentRequest{
-			ApiDeployment: task.deployment,
-			UpdateMask: &field_mask.FieldMask{
-				Paths: []string{"labels"},
-			},
-		})
-	return err
+	return core.UpdateWithRetries(func(opts ...gax.CallOption) error {
+		labels, err := task.labeling.Apply(task.deployment.Labels)
+		if err != nil {
+			log.FromContext(ctx).WithError(err).Errorf("Invalid labelling")
+			return nil
+		}
+		task.deployment.Labels = labels
+		_, err = task.client.UpdateApiDeployment(ctx,
+			&rpc.UpdateApiDeploymentRequest{
+				ApiDeployment: task.deployment,
+				UpdateMask: &field_mask.FieldMask{
+					Paths: []string{"labels"},
+				},
+			}, opts...)
+		return err
+	}, func() (err error) {
+		task.deployment, err = task.client.GetApiDeployment(ctx, &rpc.GetApiDeploymentRequest{Name: task.deployment.Name})
+		return err
+	})
 }
@@ -18,6 +18,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/apigee/registry/cmd/registry/core"
 	"github.com/apigee/registry/pkg/connection"
 	"github.com/apigee/registry/pkg/connection/grpctest"
 	"github.com/apigee/registry/rpc"
@@ -219,3 +220,72 @@ func TestLabel(t *testing.T) {
 		}
 	}
 }
+
+func TestLabelStaleResource(t *testing.T) {
+	const (
+		projectID   = "label-stale-test"
+		projectName = "projects/" + projectID
+		apiName     = projectName + "/locations/global/apis/sample"
+	)
+
+	ctx := context.Background()
+	registryClient, err := connection.NewRegistryClient(ctx)
+	if err != nil {
+		t.Fatalf("Error creating client: %+v", err)
+	}
+	defer registryClient.Close()
+	adminClient, err := connection.NewAdminClient(ctx)
+	if err != nil {
+		t.Fatalf("Error creating client: %+v", err)
+	}
+	defer adminClient.Close()
+	err = adminClient.DeleteProject(ctx, &rpc.DeleteProjectRequest{
+		Name:  projectName,
+		Force: true,
+	})
+	if err != nil && status.Code(err) != codes.NotFound {
+		t.Fatalf("Error deleting test project: %+v", err)
+	}
+	_, err = adminClient.CreateProject(ctx, &rpc.CreateProjectRequest{
+		ProjectId: projectID,
+		Project:   &rpc.Project{},
+	})
+	if err != nil {
+		t.Fatalf("Error creating project %s", err)
+	}
+	stale, err := registryClient.CreateApi(ctx, &rpc.CreateApiRequest{
+		Parent: projectName + "/locations/global",
+		ApiId:  "sample",
+		Api:    &rpc.Api{},
+	})
+	if err != nil {
+		t.Fatalf("Error creating api %s", err)
+	}
+
+	// Another client changes the api after the task has read it.
+	_, err = registryClient.UpdateApi(ctx, &rpc.UpdateApiRequest{
+		Api: &rpc.Api{
+			Name:   apiName,
+			Labels: map[string]string{"a": "1"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Error updating api %s", err)
+	}
+
+	task := &labelApiTask{
+		client:   registryClient,
+		api:      stale,
+		labeling: &core.Labeling{Set: map[string]string{"b": "2"}},
+	}
+	if err := task.Run(ctx); err != nil {
+		t.Fatalf("Run() returned error: %s", err)
+	}
+	api, err := registryClient.GetApi(ctx, &rpc.GetApiRequest{Name: apiName})
+	if err != nil {
+		t.Fatalf("Error getting api %s", err)
+	}
+	if diff := cmp.Diff(map[string]string{"a": "1", "b": "2"}, api.Labels); diff != "" {
+		t.Errorf("labels were incorrectly set (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operation
+
+import (
+	"github.com/spf13/cobra"
+	"google.golang.org/genproto/googleapis/longrunning"
+)
+
+func cancelCommand() *cobra.Command {
+	var follow bool
+	cmd := &cobra.Command{
+		Use:   "cancel OPERATION_NAME",
+		Short: "Ask a running operation to stop",
+		Long: "Ask a running operation to stop. Operations stop at the next point where they can " +
+			"stop safely, and operations that stop are done with a CANCELLED status.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			client, err := operationsClient(ctx)
+			if err != nil {
+				return err
+			}
+			if err := client.CancelOperation(ctx, &longrunning.CancelOperationRequest{Name: args[0]}); err != nil {
+				return err
+			}
+			if !follow {
+				cmd.Printf("Requested cancellation of %s.\n", args[0])
+				return nil
+			}
+			op, err := wait(ctx, client, &longrunning.Operation{Name: args[0]})
+			if err != nil {
+				return err
+			}
+			cmd.Println(format(op))
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&follow, "follow", false, "wait until the operation is done")
+	return cmd
+}
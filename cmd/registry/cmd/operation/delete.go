@@ -0,0 +1,41 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operation
+
+import (
+	"github.com/spf13/cobra"
+	"google.golang.org/genproto/googleapis/longrunning"
+)
+
+func deleteCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete OPERATION_NAME",
+		Short: "Delete the record of an operation that is done",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			client, err := operationsClient(ctx)
+			if err != nil {
+				return err
+			}
+			if err := client.DeleteOperation(ctx, &longrunning.DeleteOperationRequest{Name: args[0]}); err != nil {
+				return err
+			}
+			cmd.Printf("Deleted %s.\n", args[0])
+			return nil
+		},
+	}
+	return cmd
+}
@@ -0,0 +1,49 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operation
+
+import (
+	"github.com/spf13/cobra"
+	"google.golang.org/genproto/googleapis/longrunning"
+)
+
+func describeCommand() *cobra.Command {
+	var follow bool
+	cmd := &cobra.Command{
+		Use:   "describe OPERATION_NAME",
+		Short: "Describe the progress and result of an operation",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			client, err := operationsClient(ctx)
+			if err != nil {
+				return err
+			}
+			op, err := client.GetOperation(ctx, &longrunning.GetOperationRequest{Name: args[0]})
+			if err != nil {
+				return err
+			}
+			if follow {
+				if op, err = wait(ctx, client, op); err != nil {
+					return err
+				}
+			}
+			cmd.Println(format(op))
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&follow, "follow", false, "wait until the operation is done")
+	return cmd
+}
@@ -0,0 +1,60 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operation
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/api/iterator"
+	"google.golang.org/genproto/googleapis/longrunning"
+	"google.golang.org/grpc/codes"
+)
+
+func listCommand() *cobra.Command {
+	var filter string
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List operations",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			client, err := operationsClient(ctx)
+			if err != nil {
+				return err
+			}
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			defer w.Flush()
+			fmt.Fprintln(w, "NAME\tDONE\tSTATUS")
+			it := client.ListOperations(ctx, &longrunning.ListOperationsRequest{Name: "operations", Filter: filter})
+			for {
+				op, err := it.Next()
+				if err == iterator.Done {
+					return nil
+				} else if err != nil {
+					return err
+				}
+				result := ""
+				if op.GetDone() {
+					result = codes.Code(op.GetError().GetCode()).String()
+				}
+				fmt.Fprintf(w, "%s\t%t\t%s\n", op.GetName(), op.GetDone(), result)
+			}
+		},
+	}
+	cmd.Flags().StringVar(&filter, "filter", "", "filter selected resources")
+	return cmd
+}
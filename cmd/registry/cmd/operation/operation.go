@@ -0,0 +1,79 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operation
+
+import (
+	"context"
+	"time"
+
+	lroauto "cloud.google.com/go/longrunning/autogen"
+	"github.com/apigee/registry/pkg/connection"
+	"github.com/spf13/cobra"
+	"google.golang.org/genproto/googleapis/longrunning"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "operation",
+		Short: "Follow and control long-running operations (self-hosted installations only)",
+		Long: "Some Admin methods, such as database migrations, start long-running operations " +
+			"that run on the server after the methods return. These commands report the progress " +
+			"and results of operations and cancel operations that are running.",
+	}
+
+	cmd.AddCommand(cancelCommand())
+	cmd.AddCommand(deleteCommand())
+	cmd.AddCommand(describeCommand())
+	cmd.AddCommand(listCommand())
+	return cmd
+}
+
+// operationsClient returns a client of the operations service of the active registry.
+func operationsClient(ctx context.Context) (*lroauto.OperationsClient, error) {
+	c, err := connection.ActiveConfig()
+	if err != nil {
+		return nil, err
+	}
+	adminClient, err := connection.NewAdminClientWithSettings(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	return adminClient.LROClient, nil
+}
+
+// pollInterval is the time between checks of operations that are being waited for.
+var pollInterval = time.Second
+
+// wait polls an operation until it is done.
+func wait(ctx context.Context, client *lroauto.OperationsClient, op *longrunning.Operation) (*longrunning.Operation, error) {
+	for !op.GetDone() {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(pollInterval):
+		}
+		var err error
+		op, err = client.GetOperation(ctx, &longrunning.GetOperationRequest{Name: op.GetName()})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return op, nil
+}
+
+func format(op *longrunning.Operation) string {
+	return protojson.MarshalOptions{Multiline: true}.Format(op)
+}
@@ -0,0 +1,83 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operation
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/apigee/registry/pkg/connection"
+	"github.com/apigee/registry/pkg/connection/grpctest"
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry"
+)
+
+// TestMain will set up a local RegistryServer and grpc.Server for all
+// tests in this package if APG_REGISTRY_ADDRESS env var is not set
+// for the client.
+func TestMain(m *testing.M) {
+	grpctest.TestMain(m, registry.Config{})
+}
+
+func run(t *testing.T, args ...string) string {
+	t.Helper()
+	out := new(bytes.Buffer)
+	cmd := Command()
+	cmd.SetArgs(args)
+	cmd.SetOut(out)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() with args %v returned error: %s", args, err)
+	}
+	return out.String()
+}
+
+func TestOperation(t *testing.T) {
+	ctx := context.Background()
+	adminClient, err := connection.NewAdminClient(ctx)
+	if err != nil {
+		t.Fatalf("Setup: Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { adminClient.Close() })
+
+	// A migration that is only validated starts an operation without changing the database.
+	op, err := adminClient.MigrateDatabase(ctx, &rpc.MigrateDatabaseRequest{ValidateOnly: true})
+	if err != nil {
+		t.Skipf("MigrateDatabase isn't available: %s", err)
+	}
+	name := op.Name()
+
+	// The JSON printer varies its spacing, so fields are matched loosely.
+	if got := run(t, "describe", name, "--follow"); !regexp.MustCompile(`"done":\s+true`).MatchString(got) {
+		t.Errorf("describe %s --follow returned %q, want a finished operation", name, got)
+	}
+	if got := run(t, "list", "--filter", "done"); !strings.Contains(got, name+" ") {
+		t.Errorf("list returned %q, want it to include %s", got, name)
+	}
+	run(t, "delete", name)
+	if got := run(t, "list"); strings.Contains(got, name+" ") {
+		t.Errorf("list returned %q after deleting %s", got, name)
+	}
+
+	cmd := Command()
+	cmd.SetArgs([]string{"describe", name})
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+	if err := cmd.Execute(); err == nil {
+		t.Errorf("describe %s succeeded after the operation was deleted", name)
+	}
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/apigee/registry/cmd/registry/cmd/export"
 	"github.com/apigee/registry/cmd/registry/cmd/get"
 	"github.com/apigee/registry/cmd/registry/cmd/label"
+	"github.com/apigee/registry/cmd/registry/cmd/operation"
 	"github.com/apigee/registry/cmd/registry/cmd/resolve"
 	"github.com/apigee/registry/cmd/registry/cmd/rpc"
 	"github.com/apigee/registry/cmd/registry/cmd/upload"
@@ -60,6 +61,7 @@ func Command() *cobra.Command {
 	cmd.AddCommand(export.Command())
 	cmd.AddCommand(get.Command())
 	cmd.AddCommand(label.Command())
+	cmd.AddCommand(operation.Command())
 	cmd.AddCommand(upload.Command())
 	cmd.AddCommand(vocabulary.Command())
 	cmd.AddCommand(rpc.Command())
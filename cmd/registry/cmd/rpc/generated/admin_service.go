@@ -14,11 +14,26 @@ var AdminSubCommands []string = []string{
 	"get-status",
 	"get-storage",
 	"migrate-database",
-	"poll-migrate-database", "list-projects",
+	"poll-migrate-database", "compact-revisions",
+	"list-projects",
 	"get-project",
 	"create-project",
 	"update-project",
 	"delete-project",
+	"undelete-project",
+	"clone-project",
+	"copy-api",
+	"export-project",
+	"poll-export-project",
+	"import-project",
+	"poll-import-project",
+	"read-archive",
+	"write-archive",
+	"watch-resources",
+	"list-notification-events",
+	"replay-notification-events",
+	"list-audit-events",
+	"aggregate-resources",
 }
 
 func init() {
@@ -23,6 +23,8 @@ func init() {
 
 	DeleteApiSpecCmd.Flags().BoolVar(&DeleteApiSpecInput.Force, "force", false, "If set to true, any child resources will also be...")
 
+	DeleteApiSpecCmd.Flags().StringVar(&DeleteApiSpecInput.Etag, "etag", "", "The etag of the spec. If set, the request fails...")
+
 	DeleteApiSpecCmd.Flags().StringVar(&DeleteApiSpecFromFile, "from_file", "", "Absolute path to JSON file containing request payload")
 
 }
@@ -23,6 +23,8 @@ func init() {
 
 	DeleteApiVersionCmd.Flags().BoolVar(&DeleteApiVersionInput.Force, "force", false, "If set to true, any child resources will also be...")
 
+	DeleteApiVersionCmd.Flags().StringVar(&DeleteApiVersionInput.Etag, "etag", "", "The etag of the version. If set, the request fails...")
+
 	DeleteApiVersionCmd.Flags().StringVar(&DeleteApiVersionFromFile, "from_file", "", "Absolute path to JSON file containing request payload")
 
 }
@@ -23,6 +23,8 @@ func init() {
 
 	DeleteApiCmd.Flags().BoolVar(&DeleteApiInput.Force, "force", false, "If set to true, any child resources will also be...")
 
+	DeleteApiCmd.Flags().StringVar(&DeleteApiInput.Etag, "etag", "", "The etag of the API. If set, the request fails...")
+
 	DeleteApiCmd.Flags().StringVar(&DeleteApiFromFile, "from_file", "", "Absolute path to JSON file containing request payload")
 
 }
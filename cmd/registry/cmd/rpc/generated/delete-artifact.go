@@ -21,6 +21,8 @@ func init() {
 
 	DeleteArtifactCmd.Flags().StringVar(&DeleteArtifactInput.Name, "name", "", "Required. The name of the artifact to delete. ...")
 
+	DeleteArtifactCmd.Flags().StringVar(&DeleteArtifactInput.Etag, "etag", "", "The etag of the artifact. If set, the request fails...")
+
 	DeleteArtifactCmd.Flags().StringVar(&DeleteArtifactFromFile, "from_file", "", "Absolute path to JSON file containing request payload")
 
 }
@@ -33,6 +33,8 @@ func init() {
 
 	ListApisCmd.Flags().StringVar(&ListApisInput.OrderBy, "order_by", "", "A comma-separated list of fields, e.g. 'foo,bar' ...")
 
+	ListApisCmd.Flags().BoolVar(&ListApisInput.ShowDeleted, "show_deleted", false, "If true, deleted APIs that have not yet been...")
+
 	ListApisCmd.Flags().StringVar(&ListApisFromFile, "from_file", "", "Absolute path to JSON file containing request payload")
 
 }
@@ -33,6 +33,8 @@ func init() {
 
 	ListArtifactsCmd.Flags().StringVar(&ListArtifactsInput.OrderBy, "order_by", "", "A comma-separated list of fields, e.g. 'foo,bar' ...")
 
+	ListArtifactsCmd.Flags().BoolVar(&ListArtifactsInput.ShowDeleted, "show_deleted", false, "If true, deleted artifacts that have not yet been...")
+
 	ListArtifactsCmd.Flags().StringVar(&ListArtifactsFromFile, "from_file", "", "Absolute path to JSON file containing request payload")
 
 }
@@ -27,6 +27,10 @@ func init() {
 
 	MigrateDatabaseCmd.Flags().StringVar(&MigrateDatabaseInput.Kind, "kind", "", "A string describing the kind of migration to...")
 
+	MigrateDatabaseCmd.Flags().Int32Var(&MigrateDatabaseInput.Version, "version", 0, "The schema version to migrate to when `kind` is...")
+
+	MigrateDatabaseCmd.Flags().BoolVar(&MigrateDatabaseInput.ValidateOnly, "validate_only", false, "If true, the response lists the statements that...")
+
 	MigrateDatabaseCmd.Flags().StringVar(&MigrateDatabaseFromFile, "from_file", "", "Absolute path to JSON file containing request payload")
 
 	MigrateDatabaseCmd.Flags().BoolVar(&MigrateDatabaseFollow, "follow", false, "Block until the long running operation completes")
@@ -43,8 +47,8 @@ func init() {
 
 var MigrateDatabaseCmd = &cobra.Command{
 	Use:   "migrate-database",
-	Short: "MigrateDatabase attempts to migrate the database...",
-	Long:  "MigrateDatabase attempts to migrate the database to the current schema.",
+	Short: "MigrateDatabase migrates the database to the...",
+	Long:  "MigrateDatabase migrates the database to the current schema or to a  specified schema version. Progress is reported in the operation metadata.",
 	PreRun: func(cmd *cobra.Command, args []string) {
 
 		if MigrateDatabaseFromFile == "" {
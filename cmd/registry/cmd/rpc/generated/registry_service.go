@@ -16,17 +16,20 @@ var RegistrySubCommands []string = []string{
 	"create-api",
 	"update-api",
 	"delete-api",
+	"undelete-api",
 	"list-api-versions",
 	"get-api-version",
 	"create-api-version",
 	"update-api-version",
 	"delete-api-version",
+	"undelete-api-version",
 	"list-api-specs",
 	"get-api-spec",
 	"get-api-spec-contents",
 	"create-api-spec",
 	"update-api-spec",
 	"delete-api-spec",
+	"undelete-api-spec",
 	"tag-api-spec-revision",
 	"list-api-spec-revisions",
 	"rollback-api-spec",
@@ -36,6 +39,7 @@ var RegistrySubCommands []string = []string{
 	"create-api-deployment",
 	"update-api-deployment",
 	"delete-api-deployment",
+	"undelete-api-deployment",
 	"tag-api-deployment-revision",
 	"list-api-deployment-revisions",
 	"rollback-api-deployment",
@@ -46,6 +50,29 @@ var RegistrySubCommands []string = []string{
 	"create-artifact",
 	"replace-artifact",
 	"delete-artifact",
+	"undelete-artifact",
+	"tag-artifact-revision",
+	"list-artifact-revisions",
+	"rollback-artifact",
+	"batch-create-apis",
+	"batch-update-apis",
+	"batch-delete-apis",
+	"batch-create-api-versions",
+	"batch-update-api-versions",
+	"batch-delete-api-versions",
+	"batch-create-api-specs",
+	"batch-update-api-specs",
+	"batch-delete-api-specs",
+	"batch-create-api-deployments",
+	"batch-update-api-deployments",
+	"batch-delete-api-deployments",
+	"batch-create-artifacts",
+	"batch-replace-artifacts",
+	"batch-delete-artifacts",
+	"list-references",
+	"list-referrers",
+	"search-resources",
+	"list-spec-elements",
 }
 
 func init() {
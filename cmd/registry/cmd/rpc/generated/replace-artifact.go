@@ -39,6 +39,8 @@ func init() {
 
 	ReplaceArtifactCmd.Flags().StringArrayVar(&ReplaceArtifactInputArtifactAnnotations, "artifact.annotations", []string{}, "key=value pairs. Annotations attach non-identifying metadata to...")
 
+	ReplaceArtifactCmd.Flags().StringVar(&ReplaceArtifactInput.Artifact.Etag, "artifact.etag", "", "A checksum computed by the server based on the...")
+
 	ReplaceArtifactCmd.Flags().StringVar(&ReplaceArtifactFromFile, "from_file", "", "Absolute path to JSON file containing request payload")
 
 }
@@ -53,6 +53,8 @@ func init() {
 
 	UpdateApiDeploymentCmd.Flags().StringArrayVar(&UpdateApiDeploymentInputApiDeploymentAnnotations, "api_deployment.annotations", []string{}, "key=value pairs. Annotations attach non-identifying metadata to...")
 
+	UpdateApiDeploymentCmd.Flags().StringVar(&UpdateApiDeploymentInput.ApiDeployment.Etag, "api_deployment.etag", "", "A checksum computed by the server based on the...")
+
 	UpdateApiDeploymentCmd.Flags().StringSliceVar(&UpdateApiDeploymentInput.UpdateMask.Paths, "update_mask.paths", []string{}, "The set of field mask paths.")
 
 	UpdateApiDeploymentCmd.Flags().BoolVar(&UpdateApiDeploymentInput.AllowMissing, "allow_missing", false, "If set to true, and the deployment is not found,...")
@@ -25,8 +25,6 @@ import (
 	"github.com/apigee/registry/pkg/connection"
 	"github.com/apigee/registry/rpc"
 	"github.com/spf13/cobra"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 	"gopkg.in/yaml.v3"
 
 	"github.com/google/gnostic/compiler"
@@ -64,13 +62,13 @@ func discoveryCommand() *cobra.Command {
 			if err := core.VerifyLocation(ctx, client, parent); err != nil {
 				return fmt.Errorf("parent does not exist (%s)", err)
 			}
-			// create a queue for upload tasks and wait for the workers to finish after filling it.
+			// prepare the uploads with a queue of tasks and upload them after the workers finish.
 			jobs, err := cmd.Flags().GetInt("jobs")
 			if err != nil {
 				log.FromContext(ctx).WithError(err).Fatal("Failed to get jobs from flags")
 			}
+			uploads := &specUploads{}
 			taskQueue, wait := core.WorkerPool(ctx, jobs)
-			defer wait()
 
 			discoveryResponse, err := fetchDiscoveryList(service)
 			if err != nil {
@@ -80,7 +78,7 @@ func discoveryCommand() *cobra.Command {
 			// Create an upload job for each API.
 			for _, api := range discoveryResponse.APIs {
 				taskQueue <- &uploadDiscoveryTask{
-					client:    client,
+					uploads:   uploads,
 					path:      api.DiscoveryRestURL,
 					parent:    parent,
 					apiID:     sanitize(api.Name),
@@ -88,7 +86,8 @@ func discoveryCommand() *cobra.Command {
 					specID:    "discovery",
 				}
 			}
-			return nil
+			wait()
+			return uploads.upload(ctx, client, parent, jobs)
 		},
 	}
 	cmd.Flags().StringVar(&service, "service", "",
@@ -97,7 +96,7 @@ func discoveryCommand() *cobra.Command {
 }
 
 type uploadDiscoveryTask struct {
-	client    connection.RegistryClient
+	uploads   *specUploads
 	path      string
 	parent    string
 	apiID     string
@@ -119,97 +118,26 @@ func (task *uploadDiscoveryTask) Run(ctx context.Context) error {
 		log.FromContext(ctx).WithError(err).Error("Failed to download discovery doc")
 		return nil
 	}
-	// If the API does not exist, create it.
-	if err := task.createAPI(ctx); err != nil {
-		return err
-	}
-	// If the API version does not exist, create it.
-	if err := task.createVersion(ctx); err != nil {
-		return err
-	}
-	// Create or update the spec as needed.
-	if err := task.createOrUpdateSpec(ctx); err != nil {
+	gzippedContents, err := core.GZippedBytes(task.contents)
+	if err != nil {
 		return err
 	}
-	return nil
-}
-
-func (task *uploadDiscoveryTask) createAPI(ctx context.Context) error {
-	// Create an API if needed (or update an existing one)
-	response, err := task.client.UpdateApi(ctx, &rpc.UpdateApiRequest{
-		Api: &rpc.Api{
+	task.uploads.add(&specUpload{
+		api: &rpc.Api{
 			Name:        task.apiName(),
 			DisplayName: task.info.Title,
 			Description: task.info.Description,
 		},
-		AllowMissing: true,
-	})
-	if err == nil {
-		log.Debugf(ctx, "Updated %s", response.Name)
-	} else if status.Code(err) == codes.AlreadyExists {
-		log.Debugf(ctx, "Found %s", task.apiName())
-	} else {
-		log.FromContext(ctx).WithError(err).Debugf("Failed to create API %s", task.apiName())
-		// Returning this error ends all tasks, which seems appropriate to
-		// handle situations where all might fail due to a common problem
-		// (a missing project or incorrect project-id).
-		return fmt.Errorf("Failed to create %s, %s", task.apiName(), err)
-	}
-
-	return nil
-}
-
-func (task *uploadDiscoveryTask) createVersion(ctx context.Context) error {
-	// Create an API version if needed (or update an existing one)
-	response, err := task.client.UpdateApiVersion(ctx, &rpc.UpdateApiVersionRequest{
-		ApiVersion: &rpc.ApiVersion{
-			Name: task.versionName(),
-		},
-		AllowMissing: true,
-	})
-	if err != nil {
-		log.FromContext(ctx).WithError(err).Debugf("Failed to create version %s", task.versionName())
-	} else {
-		log.Debugf(ctx, "Updated %s", response.Name)
-	}
-
-	return nil
-}
-
-func (task *uploadDiscoveryTask) createOrUpdateSpec(ctx context.Context) error {
-	// Use the spec size and hash to avoid unnecessary uploads.
-	spec, err := task.client.GetApiSpec(ctx, &rpc.GetApiSpecRequest{
-		Name: task.specName(),
-	})
-
-	if err == nil && int(spec.GetSizeBytes()) == len(task.contents) && spec.GetHash() == hashForBytes(task.contents) {
-		log.Debugf(ctx, "Matched already uploaded spec %s", task.specName())
-		return nil
-	}
-
-	gzippedContents, err := core.GZippedBytes(task.contents)
-	if err != nil {
-		return err
-	}
-
-	request := &rpc.UpdateApiSpecRequest{
-		ApiSpec: &rpc.ApiSpec{
+		version: &rpc.ApiVersion{Name: task.versionName()},
+		spec: &rpc.ApiSpec{
 			Name:      task.specName(),
 			MimeType:  types.DiscoveryMimeType("+gzip"),
 			Filename:  "discovery.json",
 			Contents:  gzippedContents,
 			SourceUri: task.path,
 		},
-		AllowMissing: true,
-	}
-
-	response, err := task.client.UpdateApiSpec(ctx, request)
-	if err != nil {
-		log.FromContext(ctx).WithError(err).Debugf("Error %s [contents-length: %d]", task.specName(), len(task.contents))
-	} else {
-		log.Debugf(ctx, "Updated %s", response.Name)
-	}
-
+		contents: task.contents,
+	})
 	return nil
 }
 
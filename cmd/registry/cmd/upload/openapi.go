@@ -29,8 +29,6 @@ import (
 	"github.com/apigee/registry/pkg/connection"
 	"github.com/apigee/registry/rpc"
 	"github.com/spf13/cobra"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 	"gopkg.in/yaml.v3"
 )
 
@@ -55,22 +53,22 @@ func openAPICommand() *cobra.Command {
 			if err := core.VerifyLocation(ctx, client, parent); err != nil {
 				return fmt.Errorf("parent does not exist (%s)", err)
 			}
-			// create a queue for upload tasks and wait for the workers to finish after filling it.
+			// prepare the uploads with a queue of tasks and upload them after the workers finish.
 			jobs, err := cmd.Flags().GetInt("jobs")
 			if err != nil {
 				log.FromContext(ctx).WithError(err).Fatal("Failed to get jobs from flags")
 			}
+			uploads := &specUploads{}
 			taskQueue, wait := core.WorkerPool(ctx, jobs)
-			defer wait()
-
 			for _, arg := range args {
 				path, err := filepath.Abs(arg)
 				if err != nil {
 					log.FromContext(ctx).WithError(err).Fatal("Invalid path")
 				}
-				scanDirectoryForOpenAPI(ctx, client, parent, baseURI, path, taskQueue)
+				scanDirectoryForOpenAPI(ctx, uploads, parent, baseURI, path, taskQueue)
 			}
-			return nil
+			wait()
+			return uploads.upload(ctx, client, parent, jobs)
 		},
 	}
 
@@ -78,7 +76,7 @@ func openAPICommand() *cobra.Command {
 	return cmd
 }
 
-func scanDirectoryForOpenAPI(ctx context.Context, client connection.RegistryClient, parent, baseURI, directory string, taskQueue chan<- core.Task) {
+func scanDirectoryForOpenAPI(ctx context.Context, uploads *specUploads, parent, baseURI, directory string, taskQueue chan<- core.Task) {
 	// walk a directory hierarchy, uploading every API spec that matches a set of expected file names.
 	if err := filepath.Walk(directory, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
@@ -86,7 +84,7 @@ func scanDirectoryForOpenAPI(ctx context.Context, client connection.RegistryClie
 		}
 
 		task := &uploadOpenAPITask{
-			client:    client,
+			uploads:   uploads,
 			parent:    parent,
 			baseURI:   baseURI,
 			path:      path,
@@ -124,7 +122,7 @@ func sanitize(name string) string {
 }
 
 type uploadOpenAPITask struct {
-	client    connection.RegistryClient
+	uploads   *specUploads
 	baseURI   string
 	path      string
 	directory string
@@ -148,18 +146,29 @@ func (task *uploadOpenAPITask) Run(ctx context.Context) error {
 	}
 	log.Infof(ctx, "Uploading apis/%s/versions/%s/specs/%s", task.apiID, task.versionID, openAPISpecID)
 
-	// If the API does not exist, create it.
-	if err := task.createAPI(ctx); err != nil {
+	gzippedContents, err := core.GZippedBytes(task.contents)
+	if err != nil {
 		return err
 	}
-	// If the API version does not exist, create it.
-	if err := task.createVersion(ctx); err != nil {
-		return err
+	spec := &rpc.ApiSpec{
+		Name:     task.specName(),
+		MimeType: types.OpenAPIMimeType("+gzip", task.version),
+		Filename: task.fileName(),
+		Contents: gzippedContents,
 	}
-	// Create or update the spec as needed.
-	if err := task.createOrUpdateSpec(ctx); err != nil {
-		return err
+	if task.baseURI != "" {
+		spec.SourceUri = fmt.Sprintf("%s/%s", task.baseURI, task.apiPath())
 	}
+	task.uploads.add(&specUpload{
+		api: &rpc.Api{
+			Name:        task.apiName(),
+			DisplayName: task.apiID,
+			Description: task.document.Info.Title,
+		},
+		version:  &rpc.ApiVersion{Name: task.versionName()},
+		spec:     spec,
+		contents: task.contents,
+	})
 	return nil
 }
 
@@ -184,87 +193,6 @@ func (task *uploadOpenAPITask) populateFields() error {
 	return yaml.Unmarshal(task.contents, &(task.document))
 }
 
-func (task *uploadOpenAPITask) createAPI(ctx context.Context) error {
-	// Create an API if needed (or update an existing one)
-	response, err := task.client.UpdateApi(ctx, &rpc.UpdateApiRequest{
-		Api: &rpc.Api{
-			Name:        task.apiName(),
-			DisplayName: task.apiID,
-			Description: task.document.Info.Title,
-		},
-		AllowMissing: true,
-	})
-	if err == nil {
-		log.Debugf(ctx, "Updated %s", response.Name)
-	} else if status.Code(err) == codes.AlreadyExists {
-		log.Debugf(ctx, "Found %s", task.apiName())
-	} else {
-		log.FromContext(ctx).WithError(err).Debugf("Failed to create API %s", task.apiName())
-		// Returning this error ends all tasks, which seems appropriate to
-		// handle situations where all might fail due to a common problem
-		// (a missing project or incorrect project-id).
-		return fmt.Errorf("Failed to create %s, %s", task.apiName(), err)
-	}
-
-	return nil
-}
-
-func (task *uploadOpenAPITask) createVersion(ctx context.Context) error {
-	// Create an API version if needed (or update an existing one)
-	response, err := task.client.UpdateApiVersion(ctx, &rpc.UpdateApiVersionRequest{
-		ApiVersion: &rpc.ApiVersion{
-			Name: task.versionName(),
-		},
-		AllowMissing: true,
-	})
-	if err == nil {
-		log.Debugf(ctx, "Updated %s", response.Name)
-	} else {
-		log.FromContext(ctx).WithError(err).Debugf("Failed to create version %s", task.versionName())
-	}
-
-	return nil
-}
-
-func (task *uploadOpenAPITask) createOrUpdateSpec(ctx context.Context) error {
-	// Use the spec size and hash to avoid unnecessary uploads.
-	spec, err := task.client.GetApiSpec(ctx, &rpc.GetApiSpecRequest{
-		Name: task.specName(),
-	})
-
-	if err == nil && int(spec.GetSizeBytes()) == len(task.contents) && spec.GetHash() == hashForBytes(task.contents) {
-		log.Debugf(ctx, "Matched already uploaded spec %s", task.specName())
-		return nil
-	}
-
-	gzippedContents, err := core.GZippedBytes(task.contents)
-	if err != nil {
-		return err
-	}
-
-	request := &rpc.UpdateApiSpecRequest{
-		ApiSpec: &rpc.ApiSpec{
-			Name:     task.specName(),
-			MimeType: types.OpenAPIMimeType("+gzip", task.version),
-			Filename: task.fileName(),
-			Contents: gzippedContents,
-		},
-		AllowMissing: true,
-	}
-	if task.baseURI != "" {
-		request.ApiSpec.SourceUri = fmt.Sprintf("%s/%s", task.baseURI, task.apiPath())
-	}
-
-	response, err := task.client.UpdateApiSpec(ctx, request)
-	if err != nil {
-		log.FromContext(ctx).WithError(err).Debugf("Error %s [contents-length: %d]", task.specName(), len(task.contents))
-	} else {
-		log.Debugf(ctx, "Updated %s", response.Name)
-	}
-
-	return nil
-}
-
 func (task *uploadOpenAPITask) apiName() string {
 	return fmt.Sprintf("%s/apis/%s", task.parent, task.apiID)
 }
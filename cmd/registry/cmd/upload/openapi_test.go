@@ -20,6 +20,7 @@ import (
 
 	"github.com/apigee/registry/pkg/connection"
 	"github.com/apigee/registry/rpc"
+	"google.golang.org/api/iterator"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -101,6 +102,27 @@ func TestOpenAPI(t *testing.T) {
 			t.Errorf("Invalid mime type for %s: %s (wanted %s)", test.spec, result.ContentType, test.wantType)
 		}
 	}
+	// Uploading the same specs again doesn't create new revisions.
+	cmd = Command()
+	cmd.SetArgs(args)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() with args %+v returned error: %s", args, err)
+	}
+	for _, test := range tests {
+		it := registryClient.ListApiSpecRevisions(ctx, &rpc.ListApiSpecRevisionsRequest{
+			Name: "projects/" + projectID + "/locations/global/" + test.spec,
+		})
+		count := 0
+		for _, err := it.Next(); err != iterator.Done; _, err = it.Next() {
+			if err != nil {
+				t.Fatalf("unable to list revisions of %s: %s", test.spec, err)
+			}
+			count++
+		}
+		if count != 1 {
+			t.Errorf("Upload again created revisions of %s: got %d, wanted 1", test.spec, count)
+		}
+	}
 	// Delete the test project.
 	req := &rpc.DeleteProjectRequest{
 		Name:  projectName,
@@ -32,8 +32,6 @@ import (
 	"github.com/apigee/registry/pkg/connection"
 	"github.com/apigee/registry/rpc"
 	"github.com/spf13/cobra"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/descriptorpb"
 	"gopkg.in/yaml.v3"
@@ -72,13 +70,13 @@ func protosCommand() *cobra.Command {
 			if err := core.VerifyLocation(ctx, client, parent); err != nil {
 				return fmt.Errorf("parent does not exist (%s)", err)
 			}
-			// create a queue for upload tasks and wait for the workers to finish after filling it.
+			// prepare the uploads with a queue of tasks and upload them after the workers finish.
 			jobs, err := cmd.Flags().GetInt("jobs")
 			if err != nil {
 				log.FromContext(ctx).WithError(err).Fatal("Failed to get jobs from flags")
 			}
+			uploads := &specUploads{}
 			taskQueue, wait := core.WorkerPool(ctx, jobs)
-			defer wait()
 
 			for _, arg := range args {
 				path, err := filepath.Abs(arg)
@@ -94,11 +92,12 @@ func protosCommand() *cobra.Command {
 					log.FromContext(ctx).WithError(err).Fatal("Invalid path")
 				}
 
-				if err := scanDirectoryForProtos(client, parent, baseURI, path, root, taskQueue); err != nil {
+				if err := scanDirectoryForProtos(uploads, parent, baseURI, path, root, taskQueue); err != nil {
 					log.FromContext(ctx).WithError(err).Debug("Failed to walk directory")
 				}
 			}
-			return nil
+			wait()
+			return uploads.upload(ctx, client, parent, jobs)
 		},
 	}
 
@@ -107,7 +106,7 @@ func protosCommand() *cobra.Command {
 	return cmd
 }
 
-func scanDirectoryForProtos(client connection.RegistryClient, parent, baseURI, start, root string, taskQueue chan<- core.Task) error {
+func scanDirectoryForProtos(uploads *specUploads, parent, baseURI, start, root string, taskQueue chan<- core.Task) error {
 	return filepath.Walk(start, func(filepath string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
@@ -135,7 +134,7 @@ func scanDirectoryForProtos(client connection.RegistryClient, parent, baseURI, s
 		}
 
 		taskQueue <- &uploadProtoTask{
-			client:         client,
+			uploads:        uploads,
 			baseURI:        baseURI,
 			parent:         parent,
 			apiID:          strings.TrimSuffix(sc.Name, ".googleapis.com"),
@@ -151,7 +150,7 @@ func scanDirectoryForProtos(client connection.RegistryClient, parent, baseURI, s
 }
 
 type uploadProtoTask struct {
-	client         connection.RegistryClient
+	uploads        *specUploads
 	baseURI        string
 	parent         string
 	path           string
@@ -178,18 +177,25 @@ func (task *uploadProtoTask) Run(ctx context.Context) error {
 	if task.contents, err = task.zipContents(); err != nil {
 		return err
 	}
-	// If the API does not exist, create it.
-	if err := task.createAPI(ctx); err != nil {
-		return err
-	}
-	// If the API version does not exist, create it.
-	if err := task.createVersion(ctx); err != nil {
-		return err
+	spec := &rpc.ApiSpec{
+		Name:     task.specName(),
+		MimeType: types.ProtobufMimeType("+zip"),
+		Filename: task.fileName(),
+		Contents: task.contents,
 	}
-	// Create or update the spec as needed.
-	if err := task.createOrUpdateSpec(ctx); err != nil {
-		return err
+	if task.baseURI != "" {
+		spec.SourceUri = fmt.Sprintf("%s/%s", task.baseURI, task.apiPath())
 	}
+	task.uploads.add(&specUpload{
+		api: &rpc.Api{
+			Name:        task.apiName(),
+			DisplayName: task.apiTitle,
+			Description: task.apiDescription,
+		},
+		version:  &rpc.ApiVersion{Name: task.versionName()},
+		spec:     spec,
+		contents: task.contents,
+	})
 	return nil
 }
 
@@ -203,82 +209,6 @@ func (task *uploadProtoTask) populateFields() {
 	task.specID = sanitize(specPart)
 }
 
-func (task *uploadProtoTask) createAPI(ctx context.Context) error {
-	// Create an API if needed (or update an existing one)
-	response, err := task.client.UpdateApi(ctx, &rpc.UpdateApiRequest{
-		Api: &rpc.Api{
-			Name:        task.apiName(),
-			DisplayName: task.apiTitle,
-			Description: task.apiDescription,
-		},
-		AllowMissing: true,
-	})
-	if err == nil {
-		log.Debugf(ctx, "Updated %s", response.Name)
-	} else if status.Code(err) == codes.AlreadyExists {
-		log.Debugf(ctx, "Found %s", task.apiName())
-	} else {
-		log.FromContext(ctx).WithError(err).Debugf("Failed to create API %s", task.apiName())
-		// Returning this error ends all tasks, which seems appropriate to
-		// handle situations where all might fail due to a common problem
-		// (a missing project or incorrect project-id).
-		return fmt.Errorf("Failed to create %s, %s", task.apiName(), err)
-	}
-
-	return nil
-}
-
-func (task *uploadProtoTask) createVersion(ctx context.Context) error {
-	// Create an API version if needed (or update an existing one)
-	response, err := task.client.UpdateApiVersion(ctx, &rpc.UpdateApiVersionRequest{
-		ApiVersion: &rpc.ApiVersion{
-			Name: task.versionName(),
-		},
-		AllowMissing: true,
-	})
-	if err == nil {
-		log.Debugf(ctx, "Updated %s", response.Name)
-	} else {
-		log.FromContext(ctx).WithError(err).Debugf("Failed to create version %s", task.versionName())
-	}
-
-	return nil
-}
-
-func (task *uploadProtoTask) createOrUpdateSpec(ctx context.Context) error {
-	// Use the spec size and hash to avoid unnecessary uploads.
-	spec, err := task.client.GetApiSpec(ctx, &rpc.GetApiSpecRequest{
-		Name: task.specName(),
-	})
-
-	if err == nil && int(spec.GetSizeBytes()) == len(task.contents) && spec.GetHash() == hashForBytes(task.contents) {
-		log.Debugf(ctx, "Matched already uploaded spec %s", task.specName())
-		return nil
-	}
-
-	request := &rpc.UpdateApiSpecRequest{
-		ApiSpec: &rpc.ApiSpec{
-			Name:     task.specName(),
-			MimeType: types.ProtobufMimeType("+zip"),
-			Filename: task.fileName(),
-			Contents: task.contents,
-		},
-		AllowMissing: true,
-	}
-	if task.baseURI != "" {
-		request.ApiSpec.SourceUri = fmt.Sprintf("%s/%s", task.baseURI, task.apiPath())
-	}
-
-	response, err := task.client.UpdateApiSpec(ctx, request)
-	if err != nil {
-		log.FromContext(ctx).WithError(err).Errorf("Error %s [contents-length: %d]", task.specName(), len(task.contents))
-	} else {
-		log.Debugf(ctx, "Updated %s", response.Name)
-	}
-
-	return nil
-}
-
 func (task *uploadProtoTask) apiName() string {
 	return fmt.Sprintf("%s/apis/%s", task.parent, task.apiID)
 }
@@ -0,0 +1,187 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upload
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/apigee/registry/cmd/registry/core"
+	"github.com/apigee/registry/log"
+	"github.com/apigee/registry/pkg/connection"
+	"github.com/apigee/registry/rpc"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// A specUpload is a spec to upload with the API and version that contain it.
+type specUpload struct {
+	api     *rpc.Api
+	version *rpc.ApiVersion
+	spec    *rpc.ApiSpec
+	// The uncompressed contents of the spec, used to skip unchanged specs.
+	contents []byte
+}
+
+// specUploads collects the specs prepared by upload tasks so that they can
+// be uploaded with batch requests.
+type specUploads struct {
+	mu      sync.Mutex
+	uploads []*specUpload
+}
+
+func (s *specUploads) add(u *specUpload) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.uploads = append(s.uploads, u)
+}
+
+// upload creates or updates the APIs, versions and specs of the collected
+// uploads. Specs that match the size and hash of an uploaded spec are skipped
+// and reported as unchanged.
+func (s *specUploads) upload(ctx context.Context, client connection.RegistryClient, parent string, jobs int) error {
+	var apis []*rpc.UpdateApiRequest
+	var versions []*rpc.UpdateApiVersionRequest
+	seen := make(map[string]bool)
+	for _, u := range s.uploads {
+		if !seen[u.api.Name] {
+			seen[u.api.Name] = true
+			apis = append(apis, &rpc.UpdateApiRequest{Api: u.api, AllowMissing: true})
+		}
+		if !seen[u.version.Name] {
+			seen[u.version.Name] = true
+			versions = append(versions, &rpc.UpdateApiVersionRequest{ApiVersion: u.version, AllowMissing: true})
+		}
+	}
+
+	// Returning an error for a failed API ends the upload, which seems
+	// appropriate to handle situations where all might fail due to a
+	// common problem (a missing project or incorrect project-id).
+	if err := core.RunBatches(ctx, jobs, len(apis),
+		func(i int) int { return proto.Size(apis[i]) },
+		func(ctx context.Context, start, end int) error {
+			response, err := client.BatchUpdateApis(ctx, &rpc.BatchUpdateApisRequest{
+				Parent:              parent,
+				Requests:            apis[start:end],
+				AllowPartialSuccess: true,
+			})
+			if err != nil {
+				return err
+			}
+			for i, s := range response.GetStatuses() {
+				if err := logUpdate(ctx, apis[start+i].Api.Name, status.ErrorProto(s)); err != nil {
+					return fmt.Errorf("Failed to create %s, %s", apis[start+i].Api.Name, err)
+				}
+			}
+			return nil
+		},
+		func(ctx context.Context, i int) error {
+			_, err := client.UpdateApi(ctx, apis[i])
+			if err := logUpdate(ctx, apis[i].Api.Name, err); err != nil {
+				return fmt.Errorf("Failed to create %s, %s", apis[i].Api.Name, err)
+			}
+			return nil
+		}); err != nil {
+		return err
+	}
+
+	if err := core.RunBatches(ctx, jobs, len(versions),
+		func(i int) int { return proto.Size(versions[i]) },
+		func(ctx context.Context, start, end int) error {
+			response, err := client.BatchUpdateApiVersions(ctx, &rpc.BatchUpdateApiVersionsRequest{
+				Parent:              parent,
+				Requests:            versions[start:end],
+				AllowPartialSuccess: true,
+			})
+			if err != nil {
+				return err
+			}
+			for i, s := range response.GetStatuses() {
+				_ = logUpdate(ctx, versions[start+i].ApiVersion.Name, status.ErrorProto(s))
+			}
+			return nil
+		},
+		func(ctx context.Context, i int) error {
+			_, err := client.UpdateApiVersion(ctx, versions[i])
+			_ = logUpdate(ctx, versions[i].ApiVersion.Name, err)
+			return nil
+		}); err != nil {
+		return err
+	}
+
+	// Use the spec size and hash to avoid unnecessary uploads.
+	uploaded := make(map[string]*rpc.ApiSpec)
+	it := client.ListApiSpecs(ctx, &rpc.ListApiSpecsRequest{
+		Parent:   parent + "/apis/-/versions/-",
+		PageSize: 1000,
+	})
+	for spec, err := it.Next(); err != iterator.Done; spec, err = it.Next() {
+		if err != nil {
+			return err
+		}
+		uploaded[spec.GetName()] = spec
+	}
+	var specs []*rpc.UpdateApiSpecRequest
+	for _, u := range s.uploads {
+		spec, ok := uploaded[u.spec.Name]
+		if ok && int(spec.GetSizeBytes()) == len(u.contents) && spec.GetHash() == hashForBytes(u.contents) {
+			log.Infof(ctx, "Unchanged %s", u.spec.Name)
+			continue
+		}
+		specs = append(specs, &rpc.UpdateApiSpecRequest{ApiSpec: u.spec, AllowMissing: true})
+	}
+
+	return core.RunBatches(ctx, jobs, len(specs),
+		func(i int) int { return proto.Size(specs[i]) },
+		func(ctx context.Context, start, end int) error {
+			response, err := client.BatchUpdateApiSpecs(ctx, &rpc.BatchUpdateApiSpecsRequest{
+				Parent:              parent,
+				Requests:            specs[start:end],
+				AllowPartialSuccess: true,
+			})
+			if err != nil {
+				return err
+			}
+			for i, s := range response.GetStatuses() {
+				logSpecUpdate(ctx, specs[start+i].ApiSpec, status.ErrorProto(s))
+			}
+			return nil
+		},
+		func(ctx context.Context, i int) error {
+			_, err := client.UpdateApiSpec(ctx, specs[i])
+			logSpecUpdate(ctx, specs[i].ApiSpec, err)
+			return nil
+		})
+}
+
+// logUpdate logs the result of updating a resource and returns its error.
+func logUpdate(ctx context.Context, name string, err error) error {
+	if err != nil {
+		log.FromContext(ctx).WithError(err).Debugf("Failed to update %s", name)
+		return err
+	}
+	log.Debugf(ctx, "Updated %s", name)
+	return nil
+}
+
+func logSpecUpdate(ctx context.Context, spec *rpc.ApiSpec, err error) {
+	if err != nil {
+		log.FromContext(ctx).WithError(err).Errorf("Error %s [contents-length: %d]", spec.Name, len(spec.Contents))
+	} else {
+		log.Debugf(ctx, "Updated %s", spec.Name)
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"sync"
+
+	"github.com/apigee/registry/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// BatchSize is the largest number of requests sent in a batch.
+const BatchSize = 1000
+
+// BatchBytes is the largest total size of the requests sent in a batch.
+// Larger requests are sent in batches of their own.
+const BatchBytes = 2 << 20
+
+// RunBatches applies n requests with batch requests, running up to jobs
+// batches concurrently. batch is called with the bounds of each batch and
+// size is called with the index of each request to find its size in bytes.
+// Registries that don't support batch requests reply to the first batch with
+// codes.Unimplemented; then single is called for each request instead.
+// The first error returned by batch or single is returned.
+func RunBatches(ctx context.Context, jobs, n int,
+	size func(i int) int,
+	batch func(ctx context.Context, start, end int) error,
+	single func(ctx context.Context, i int) error) error {
+	if n == 0 {
+		return nil
+	}
+
+	var bounds []int
+	count, bytes := 0, 0
+	for i := 0; i < n; i++ {
+		s := size(i)
+		if count == BatchSize || (count > 0 && bytes+s > BatchBytes) {
+			bounds = append(bounds, i)
+			count, bytes = 0, 0
+		}
+		count++
+		bytes += s
+	}
+	bounds = append(bounds, n)
+
+	err := batch(ctx, 0, bounds[0])
+	if status.Code(err) == codes.Unimplemented {
+		log.Debugf(ctx, "Batch requests are unsupported, sending %d requests individually", n)
+		return runConcurrently(ctx, jobs, n, single)
+	} else if err != nil {
+		return err
+	}
+	return runConcurrently(ctx, jobs, len(bounds)-1, func(ctx context.Context, i int) error {
+		return batch(ctx, bounds[i], bounds[i+1])
+	})
+}
+
+// runConcurrently calls fn with each index below n, making up to jobs
+// calls at a time, and returns the first error returned by fn.
+func runConcurrently(ctx context.Context, jobs, n int, fn func(ctx context.Context, i int) error) error {
+	if jobs < 1 {
+		jobs = 1
+	}
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		first error
+	)
+	sem := make(chan struct{}, jobs)
+	for i := 0; i < n; i++ {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			if err := fn(ctx, i); err != nil {
+				mu.Lock()
+				if first == nil {
+					first = err
+				}
+				mu.Unlock()
+			}
+		}(i)
+	}
+	wg.Wait()
+	return first
+}
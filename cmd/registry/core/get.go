@@ -155,6 +155,26 @@ func GetArtifact(ctx context.Context,
 	return handler(artifact)
 }
 
+func GetArtifactRevision(ctx context.Context,
+	client *gapic.RegistryClient,
+	name names.ArtifactRevision,
+	getContents bool,
+	handler ArtifactHandler) error {
+	artifact, err := client.GetArtifact(ctx, &rpc.GetArtifactRequest{
+		Name: name.String(),
+	})
+	if err != nil {
+		return err
+	}
+	if getContents {
+		if err = FetchArtifactContents(ctx, client, artifact); err != nil {
+			return err
+		}
+	}
+
+	return handler(artifact)
+}
+
 func FetchSpecContents(ctx context.Context, client *gapic.RegistryClient, spec *rpc.ApiSpec) error {
 	if spec.Contents != nil {
 		return nil
@@ -16,6 +16,11 @@ package core
 
 import (
 	"fmt"
+	"time"
+
+	"github.com/googleapis/gax-go/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // Labeling represents a user-specified change to a set of labels or annotations.
@@ -47,3 +52,36 @@ func (l *Labeling) Apply(m map[string]string) (map[string]string, error) {
 	}
 	return m, nil
 }
+
+// maxLabelingAttempts limits the number of times that a labeling is applied to
+// a resource that is being changed concurrently by other clients.
+const maxLabelingAttempts = 5
+
+// noAbortedRetries replaces the default retry settings of update calls, which
+// retry ABORTED errors. A stale etag fails the same way every time it is sent.
+var noAbortedRetries = gax.WithRetry(func() gax.Retryer {
+	return gax.OnCodes([]codes.Code{
+		codes.DeadlineExceeded,
+		codes.Unavailable,
+	}, gax.Backoff{
+		Initial:    200 * time.Millisecond,
+		Max:        10000 * time.Millisecond,
+		Multiplier: 1.30,
+	})
+})
+
+// UpdateWithRetries calls update to save a labeling of a resource. Updates carry
+// the etag of the resource that was read, so if the resource changed after it
+// was read, the update fails with ABORTED; refresh is then called to read the
+// resource again and the update is retried.
+func UpdateWithRetries(update func(opts ...gax.CallOption) error, refresh func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := update(noAbortedRetries)
+		if status.Code(err) != codes.Aborted || attempt == maxLabelingAttempts {
+			return err
+		}
+		if err := refresh(); err != nil {
+			return err
+		}
+	}
+}
@@ -262,3 +262,35 @@ func ListArtifacts(ctx context.Context,
 	}
 	return nil
 }
+
+func ListArtifactRevisions(ctx context.Context,
+	client *gapic.RegistryClient,
+	name names.ArtifactRevision,
+	filter string,
+	getContents bool,
+	handler ArtifactHandler) error {
+	it := client.ListArtifactRevisions(ctx, &rpc.ListArtifactRevisionsRequest{
+		Name:   name.String(),
+		Filter: filter,
+	})
+	for r, err := it.Next(); err != iterator.Done; r, err = it.Next() {
+		if err != nil {
+			return err
+		}
+
+		if getContents {
+			resp, err := client.GetArtifactContents(ctx, &rpc.GetArtifactContentsRequest{
+				Name: r.GetName(),
+			})
+			if err != nil {
+				return err
+			}
+			r.Contents = resp.GetData()
+		}
+
+		if err := handler(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
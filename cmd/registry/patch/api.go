@@ -189,6 +189,12 @@ func optionalDeploymentName(apiName names.Api, deploymentID string) string {
 }
 
 func applyApiPatchBytes(ctx context.Context, client connection.RegistryClient, bytes []byte, parent string) error {
+	return applyBatch(ctx, client, parent, func(b *batch) error {
+		return batchApiPatchBytes(ctx, b, bytes, parent)
+	})
+}
+
+func batchApiPatchBytes(ctx context.Context, b *batch, bytes []byte, parent string) error {
 	var api models.Api
 	err := yaml.Unmarshal(bytes, &api)
 	if err != nil {
@@ -212,24 +218,21 @@ func applyApiPatchBytes(ctx context.Context, client connection.RegistryClient, b
 		},
 		AllowMissing: true,
 	}
-	_, err = client.UpdateApi(ctx, req)
-	if err != nil {
-		return err
-	}
+	b.updateApi(req)
 	for _, versionPatch := range api.Data.ApiVersions {
-		err := applyApiVersionPatch(ctx, client, versionPatch, apiName.String())
+		err := applyApiVersionPatch(ctx, b, versionPatch, apiName.String())
 		if err != nil {
 			return err
 		}
 	}
 	for _, deploymentPatch := range api.Data.ApiDeployments {
-		err := applyApiDeploymentPatch(ctx, client, deploymentPatch, apiName.String())
+		err := applyApiDeploymentPatch(ctx, b, deploymentPatch, apiName.String())
 		if err != nil {
 			return err
 		}
 	}
 	for _, artifactPatch := range api.Data.Artifacts {
-		err = applyArtifactPatch(ctx, client, artifactPatch, apiName.String())
+		err = applyArtifactPatch(ctx, b, artifactPatch, apiName.String())
 		if err != nil {
 			return err
 		}
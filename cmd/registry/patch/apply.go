@@ -28,6 +28,7 @@ import (
 )
 
 func Apply(ctx context.Context, client connection.RegistryClient, path, parent string, recursive bool, jobs int) error {
+	b := &batch{}
 	patches := &patchGroup{}
 	err := filepath.WalkDir(path,
 		func(fileName string, entry fs.DirEntry, err error) error {
@@ -65,7 +66,7 @@ func Apply(ctx context.Context, client connection.RegistryClient, path, parent s
 						continue
 					}
 					patches.add(&applyBytesTask{
-						client: client,
+						batch:  b,
 						path:   fileName,
 						parent: parent,
 						kind:   itemHeader.Kind,
@@ -75,7 +76,7 @@ func Apply(ctx context.Context, client connection.RegistryClient, path, parent s
 				return nil
 			}
 			patches.add(&applyBytesTask{
-				client: client,
+				batch:  b,
 				path:   fileName,
 				parent: parent,
 				kind:   header.Kind,
@@ -86,7 +87,8 @@ func Apply(ctx context.Context, client connection.RegistryClient, path, parent s
 	if err != nil {
 		return err
 	}
-	return patches.run(ctx, jobs)
+	patches.run(ctx, jobs)
+	return b.apply(ctx, client, parent, jobs)
 }
 
 type patchGroup struct {
@@ -112,8 +114,8 @@ func (p *patchGroup) add(task *applyBytesTask) {
 	}
 }
 
-func (p *patchGroup) run(ctx context.Context, jobs int) error {
-	// Apply each resource type independently in order of ownership (parents first).
+// run adds the patches to a batch, which applies them in order of ownership.
+func (p *patchGroup) run(ctx context.Context, jobs int) {
 	for _, tasks := range [][]core.Task{
 		p.apiTasks,
 		p.versionTasks,
@@ -127,11 +129,10 @@ func (p *patchGroup) run(ctx context.Context, jobs int) error {
 		}
 		wait()
 	}
-	return nil
 }
 
 type applyBytesTask struct {
-	client connection.RegistryClient
+	batch  *batch
 	path   string
 	parent string
 	kind   string
@@ -155,16 +156,18 @@ func (task *applyBytesTask) Run(ctx context.Context) error {
 		name = header.Metadata.Parent + "/" + name
 	}
 	log.FromContext(ctx).Infof("Applying %s %s", task.path, name)
-	switch header.Kind {
-	case "API":
-		return applyApiPatchBytes(ctx, task.client, task.bytes, task.parent)
-	case "Version":
-		return applyApiVersionPatchBytes(ctx, task.client, task.bytes, task.parent)
-	case "Spec":
-		return applyApiSpecPatchBytes(ctx, task.client, task.bytes, task.parent, task.path)
-	case "Deployment":
-		return applyApiDeploymentPatchBytes(ctx, task.client, task.bytes, task.parent)
-	default: // for everything else, try an artifact type
-		return applyArtifactPatchBytes(ctx, task.client, task.bytes, task.parent)
-	}
+	return task.batch.addFile(task.path, func(b *batch) error {
+		switch header.Kind {
+		case "API":
+			return batchApiPatchBytes(ctx, b, task.bytes, task.parent)
+		case "Version":
+			return batchApiVersionPatchBytes(ctx, b, task.bytes, task.parent)
+		case "Spec":
+			return batchApiSpecPatchBytes(ctx, b, task.bytes, task.parent, task.path)
+		case "Deployment":
+			return batchApiDeploymentPatchBytes(ctx, b, task.bytes, task.parent)
+		default: // for everything else, try an artifact type
+			return batchArtifactPatchBytes(ctx, b, task.bytes, task.parent)
+		}
+	})
 }
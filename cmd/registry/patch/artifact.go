@@ -87,10 +87,12 @@ func NewArtifact(ctx context.Context, client *gapic.RegistryClient, message *rpc
 		}
 		message.Contents = body.Data
 	}
-	artifactName, err := names.ParseArtifact(message.Name)
+	// Revisions of artifacts are exported with the ID of their artifact.
+	revisionName, err := names.ParseArtifactRevision(message.Name)
 	if err != nil {
 		return nil, err
 	}
+	artifactName := revisionName.Artifact()
 	var node *yaml.Node
 	if strings.HasPrefix(message.MimeType, "application/yaml") {
 		var doc yaml.Node
@@ -150,12 +152,18 @@ func NewArtifact(ctx context.Context, client *gapic.RegistryClient, message *rpc
 }
 
 func applyArtifactPatchBytes(ctx context.Context, client connection.RegistryClient, bytes []byte, parent string) error {
+	return applyBatch(ctx, client, parent, func(b *batch) error {
+		return batchArtifactPatchBytes(ctx, b, bytes, parent)
+	})
+}
+
+func batchArtifactPatchBytes(ctx context.Context, b *batch, bytes []byte, parent string) error {
 	var artifact models.Artifact
 	err := yaml.Unmarshal(bytes, &artifact)
 	if err != nil {
 		return err
 	}
-	return applyArtifactPatch(ctx, client, &artifact, parent)
+	return applyArtifactPatch(ctx, b, &artifact, parent)
 }
 
 func artifactName(parent string, metadata models.Metadata) (names.Artifact, error) {
@@ -165,7 +173,7 @@ func artifactName(parent string, metadata models.Metadata) (names.Artifact, erro
 	return names.ParseArtifact(parent + "/artifacts/" + metadata.Name)
 }
 
-func applyArtifactPatch(ctx context.Context, client connection.RegistryClient, content *models.Artifact, parent string) error {
+func applyArtifactPatch(ctx context.Context, b *batch, content *models.Artifact, parent string) error {
 	// Restyle the YAML representation so that yaml.Marshal will marshal it as JSON.
 	styleForJSON(&content.Data)
 	// Marshal the YAML representation into the JSON serialization.
@@ -210,26 +218,14 @@ func applyArtifactPatch(ctx context.Context, client connection.RegistryClient, c
 	if err != nil {
 		return err
 	}
-	artifact := &rpc.Artifact{
+	b.createOrReplaceArtifact(&rpc.Artifact{
 		Name:        name.String(),
 		MimeType:    types.MimeTypeForKind(content.Kind),
 		Contents:    bytes,
 		Labels:      content.Metadata.Labels,
 		Annotations: content.Metadata.Annotations,
-	}
-	req := &rpc.CreateArtifactRequest{
-		Parent:     name.Parent(),
-		ArtifactId: name.ArtifactID(),
-		Artifact:   artifact,
-	}
-	_, err = client.CreateArtifact(ctx, req)
-	if err != nil {
-		req := &rpc.ReplaceArtifactRequest{
-			Artifact: artifact,
-		}
-		_, err = client.ReplaceArtifact(ctx, req)
-	}
-	return err
+	})
+	return nil
 }
 
 // populateIdAndKind inserts the "id" and "kind" fields in the supplied json bytes.
@@ -0,0 +1,303 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package patch
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/apigee/registry/cmd/registry/core"
+	"github.com/apigee/registry/log"
+	"github.com/apigee/registry/pkg/connection"
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/names"
+	statuspb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// A batch collects the changes made by patches so that they can be applied
+// with batch requests. Patches may be added concurrently.
+type batch struct {
+	mu          sync.Mutex
+	apis        []*rpc.UpdateApiRequest
+	versions    []*rpc.UpdateApiVersionRequest
+	specs       []*rpc.UpdateApiSpecRequest
+	deployments []*rpc.UpdateApiDeploymentRequest
+	artifacts   []*rpc.Artifact
+	// files are the names of the files that define resources, by resource name.
+	files map[string]string
+}
+
+func (b *batch) updateApi(req *rpc.UpdateApiRequest) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.apis = append(b.apis, req)
+}
+
+func (b *batch) updateApiVersion(req *rpc.UpdateApiVersionRequest) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.versions = append(b.versions, req)
+}
+
+func (b *batch) updateApiSpec(req *rpc.UpdateApiSpecRequest) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.specs = append(b.specs, req)
+}
+
+func (b *batch) updateApiDeployment(req *rpc.UpdateApiDeploymentRequest) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.deployments = append(b.deployments, req)
+}
+
+// createOrReplaceArtifact creates an artifact or replaces an existing one.
+func (b *batch) createOrReplaceArtifact(artifact *rpc.Artifact) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.artifacts = append(b.artifacts, artifact)
+}
+
+// addFile adds the changes added to a batch by fn, which reads the named file,
+// so that failures to apply them can be reported with the file.
+func (b *batch) addFile(fileName string, fn func(b *batch) error) error {
+	f := &batch{}
+	if err := fn(f); err != nil {
+		return err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.files == nil {
+		b.files = make(map[string]string)
+	}
+	for _, r := range f.apis {
+		b.files[r.GetApi().GetName()] = fileName
+	}
+	for _, r := range f.versions {
+		b.files[r.GetApiVersion().GetName()] = fileName
+	}
+	for _, r := range f.specs {
+		b.files[r.GetApiSpec().GetName()] = fileName
+	}
+	for _, r := range f.deployments {
+		b.files[r.GetApiDeployment().GetName()] = fileName
+	}
+	for _, a := range f.artifacts {
+		b.files[a.GetName()] = fileName
+	}
+	b.apis = append(b.apis, f.apis...)
+	b.versions = append(b.versions, f.versions...)
+	b.specs = append(b.specs, f.specs...)
+	b.deployments = append(b.deployments, f.deployments...)
+	b.artifacts = append(b.artifacts, f.artifacts...)
+	return nil
+}
+
+// applyBatch applies the changes added to a batch by fn.
+func applyBatch(ctx context.Context, client connection.RegistryClient, parent string, fn func(b *batch) error) error {
+	b := &batch{}
+	if err := fn(b); err != nil {
+		return err
+	}
+	return b.apply(ctx, client, parent, 1)
+}
+
+// failures counts the resources that couldn't be changed and logs each of them
+// with the file that defines it.
+type failures struct {
+	mu    sync.Mutex
+	files map[string]string
+	count int
+}
+
+// add records the result of a change to the named resource and returns nil.
+func (f *failures) add(ctx context.Context, name string, err error) error {
+	if err == nil {
+		return nil
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.count++
+	if fileName, ok := f.files[name]; ok {
+		log.FromContext(ctx).WithError(err).Errorf("Failed to apply %s %s", fileName, name)
+	} else {
+		log.FromContext(ctx).WithError(err).Errorf("Failed to apply %s", name)
+	}
+	return nil
+}
+
+// addStatuses records the results of a batch request whose requests change
+// the resources named by name.
+func (f *failures) addStatuses(ctx context.Context, statuses []*statuspb.Status, name func(i int) string) {
+	for i, s := range statuses {
+		_ = f.add(ctx, name(i), status.ErrorProto(s))
+	}
+}
+
+// apply makes the collected changes to resources in parent, a project location.
+// Each resource type is applied in order of ownership (parents first). The
+// requests in each batch are applied independently, so a change that fails,
+// such as one with invalid contents, doesn't prevent the others from being
+// made. Failed changes are logged and an error is returned if there were any.
+func (b *batch) apply(ctx context.Context, client connection.RegistryClient, parent string, jobs int) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	failed := &failures{files: b.files}
+
+	if err := core.RunBatches(ctx, jobs, len(b.apis),
+		func(i int) int { return proto.Size(b.apis[i]) },
+		func(ctx context.Context, start, end int) error {
+			response, err := client.BatchUpdateApis(ctx, &rpc.BatchUpdateApisRequest{
+				Parent:              parent,
+				Requests:            b.apis[start:end],
+				AllowPartialSuccess: true,
+			})
+			failed.addStatuses(ctx, response.GetStatuses(), func(i int) string { return b.apis[start+i].GetApi().GetName() })
+			return err
+		},
+		func(ctx context.Context, i int) error {
+			_, err := client.UpdateApi(ctx, b.apis[i])
+			return failed.add(ctx, b.apis[i].GetApi().GetName(), err)
+		}); err != nil {
+		return err
+	}
+
+	if err := core.RunBatches(ctx, jobs, len(b.versions),
+		func(i int) int { return proto.Size(b.versions[i]) },
+		func(ctx context.Context, start, end int) error {
+			response, err := client.BatchUpdateApiVersions(ctx, &rpc.BatchUpdateApiVersionsRequest{
+				Parent:              parent,
+				Requests:            b.versions[start:end],
+				AllowPartialSuccess: true,
+			})
+			failed.addStatuses(ctx, response.GetStatuses(), func(i int) string { return b.versions[start+i].GetApiVersion().GetName() })
+			return err
+		},
+		func(ctx context.Context, i int) error {
+			_, err := client.UpdateApiVersion(ctx, b.versions[i])
+			return failed.add(ctx, b.versions[i].GetApiVersion().GetName(), err)
+		}); err != nil {
+		return err
+	}
+
+	if err := core.RunBatches(ctx, jobs, len(b.specs),
+		func(i int) int { return proto.Size(b.specs[i]) },
+		func(ctx context.Context, start, end int) error {
+			response, err := client.BatchUpdateApiSpecs(ctx, &rpc.BatchUpdateApiSpecsRequest{
+				Parent:              parent,
+				Requests:            b.specs[start:end],
+				AllowPartialSuccess: true,
+			})
+			failed.addStatuses(ctx, response.GetStatuses(), func(i int) string { return b.specs[start+i].GetApiSpec().GetName() })
+			return err
+		},
+		func(ctx context.Context, i int) error {
+			_, err := client.UpdateApiSpec(ctx, b.specs[i])
+			return failed.add(ctx, b.specs[i].GetApiSpec().GetName(), err)
+		}); err != nil {
+		return err
+	}
+
+	if err := core.RunBatches(ctx, jobs, len(b.deployments),
+		func(i int) int { return proto.Size(b.deployments[i]) },
+		func(ctx context.Context, start, end int) error {
+			response, err := client.BatchUpdateApiDeployments(ctx, &rpc.BatchUpdateApiDeploymentsRequest{
+				Parent:              parent,
+				Requests:            b.deployments[start:end],
+				AllowPartialSuccess: true,
+			})
+			failed.addStatuses(ctx, response.GetStatuses(), func(i int) string { return b.deployments[start+i].GetApiDeployment().GetName() })
+			return err
+		},
+		func(ctx context.Context, i int) error {
+			_, err := client.UpdateApiDeployment(ctx, b.deployments[i])
+			return failed.add(ctx, b.deployments[i].GetApiDeployment().GetName(), err)
+		}); err != nil {
+		return err
+	}
+
+	if err := core.RunBatches(ctx, jobs, len(b.artifacts),
+		func(i int) int { return proto.Size(b.artifacts[i]) },
+		func(ctx context.Context, start, end int) error {
+			return setArtifacts(ctx, client, parent, b.artifacts[start:end], failed)
+		},
+		func(ctx context.Context, i int) error {
+			return failed.add(ctx, b.artifacts[i].GetName(), setArtifact(ctx, client, b.artifacts[i]))
+		}); err != nil {
+		return err
+	}
+
+	if failed.count > 0 {
+		return fmt.Errorf("failed to apply %d resources", failed.count)
+	}
+	return nil
+}
+
+// setArtifacts creates artifacts with a batch request and replaces the
+// artifacts that already exist with another.
+func setArtifacts(ctx context.Context, client connection.RegistryClient, parent string, artifacts []*rpc.Artifact, failed *failures) error {
+	creates := make([]*rpc.CreateArtifactRequest, len(artifacts))
+	for i, artifact := range artifacts {
+		creates[i] = createArtifactRequest(artifact)
+	}
+	response, err := client.BatchCreateArtifacts(ctx, &rpc.BatchCreateArtifactsRequest{
+		Parent:              parent,
+		Requests:            creates,
+		AllowPartialSuccess: true,
+	})
+	if err != nil {
+		return err
+	}
+	var replaces []*rpc.ReplaceArtifactRequest
+	for i, s := range response.GetStatuses() {
+		if codes.Code(s.GetCode()) == codes.AlreadyExists {
+			replaces = append(replaces, &rpc.ReplaceArtifactRequest{Artifact: artifacts[i]})
+		} else {
+			_ = failed.add(ctx, artifacts[i].GetName(), status.ErrorProto(s))
+		}
+	}
+	if len(replaces) == 0 {
+		return nil
+	}
+	replaced, err := client.BatchReplaceArtifacts(ctx, &rpc.BatchReplaceArtifactsRequest{
+		Parent:              parent,
+		Requests:            replaces,
+		AllowPartialSuccess: true,
+	})
+	failed.addStatuses(ctx, replaced.GetStatuses(), func(i int) string { return replaces[i].GetArtifact().GetName() })
+	return err
+}
+
+// setArtifact creates an artifact or replaces it if it already exists.
+func setArtifact(ctx context.Context, client connection.RegistryClient, artifact *rpc.Artifact) error {
+	_, err := client.CreateArtifact(ctx, createArtifactRequest(artifact))
+	if status.Code(err) == codes.AlreadyExists {
+		_, err = client.ReplaceArtifact(ctx, &rpc.ReplaceArtifactRequest{Artifact: artifact})
+	}
+	return err
+}
+
+func createArtifactRequest(artifact *rpc.Artifact) *rpc.CreateArtifactRequest {
+	name, _ := names.ParseArtifact(artifact.GetName())
+	return &rpc.CreateArtifactRequest{
+		Parent:     name.Parent(),
+		ArtifactId: name.ArtifactID(),
+		Artifact:   artifact,
+	}
+}
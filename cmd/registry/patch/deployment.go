@@ -84,12 +84,18 @@ func NewApiDeployment(ctx context.Context, client *gapic.RegistryClient, message
 }
 
 func applyApiDeploymentPatchBytes(ctx context.Context, client connection.RegistryClient, bytes []byte, parent string) error {
+	return applyBatch(ctx, client, parent, func(b *batch) error {
+		return batchApiDeploymentPatchBytes(ctx, b, bytes, parent)
+	})
+}
+
+func batchApiDeploymentPatchBytes(ctx context.Context, b *batch, bytes []byte, parent string) error {
 	var deployment models.ApiDeployment
 	err := yaml.Unmarshal(bytes, &deployment)
 	if err != nil {
 		return err
 	}
-	return applyApiDeploymentPatch(ctx, client, &deployment, parent)
+	return applyApiDeploymentPatch(ctx, b, &deployment, parent)
 }
 
 func deploymentName(parent string, metadata models.Metadata) (names.Deployment, error) {
@@ -105,7 +111,7 @@ func deploymentName(parent string, metadata models.Metadata) (names.Deployment,
 
 func applyApiDeploymentPatch(
 	ctx context.Context,
-	client connection.RegistryClient,
+	b *batch,
 	deployment *models.ApiDeployment,
 	parent string) error {
 	name, err := deploymentName(parent, deployment.Metadata)
@@ -130,12 +136,9 @@ func applyApiDeploymentPatch(
 	if err != nil {
 		return err
 	}
-	_, err = client.UpdateApiDeployment(ctx, req)
-	if err != nil {
-		return err
-	}
+	b.updateApiDeployment(req)
 	for _, artifactPatch := range deployment.Data.Artifacts {
-		err = applyArtifactPatch(ctx, client, artifactPatch, name.String())
+		err = applyArtifactPatch(ctx, b, artifactPatch, name.String())
 		if err != nil {
 			return err
 		}
@@ -17,6 +17,7 @@ package patch
 import (
 	"context"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/apigee/registry/cmd/registry/core"
@@ -210,7 +211,7 @@ func TestApiPatches(t *testing.T) {
 			}
 			opts := cmp.Options{
 				protocmp.Transform(),
-				protocmp.IgnoreFields(new(rpc.Api), "create_time", "update_time"),
+				protocmp.IgnoreFields(new(rpc.Api), "create_time", "update_time", "etag"),
 			}
 			err = core.GetAPI(ctx, registryClient, apiName,
 				func(api *rpc.Api) error {
@@ -313,7 +314,7 @@ func TestVersionPatches(t *testing.T) {
 			}
 			opts := cmp.Options{
 				protocmp.Transform(),
-				protocmp.IgnoreFields(new(rpc.ApiVersion), "create_time", "update_time"),
+				protocmp.IgnoreFields(new(rpc.ApiVersion), "create_time", "update_time", "etag"),
 			}
 			err = core.GetVersion(ctx, registryClient, versionName,
 				func(version *rpc.ApiVersion) error {
@@ -416,7 +417,7 @@ func TestSpecPatches(t *testing.T) {
 			}
 			opts := cmp.Options{
 				protocmp.Transform(),
-				protocmp.IgnoreFields(new(rpc.ApiSpec), "hash", "size_bytes", "revision_id", "create_time", "revision_create_time", "revision_update_time"),
+				protocmp.IgnoreFields(new(rpc.ApiSpec), "hash", "size_bytes", "revision_id", "create_time", "revision_create_time", "revision_update_time", "etag"),
 			}
 			err = core.GetSpec(ctx, registryClient, specName, false,
 				func(spec *rpc.ApiSpec) error {
@@ -522,7 +523,7 @@ func TestDeploymentPatches(t *testing.T) {
 			}
 			opts := cmp.Options{
 				protocmp.Transform(),
-				protocmp.IgnoreFields(new(rpc.ApiDeployment), "revision_id", "create_time", "revision_create_time", "revision_update_time"),
+				protocmp.IgnoreFields(new(rpc.ApiDeployment), "revision_id", "create_time", "revision_create_time", "revision_update_time", "etag"),
 			}
 			err = core.GetDeployment(ctx, registryClient, deploymentName,
 				func(deployment *rpc.ApiDeployment) error {
@@ -1253,3 +1254,42 @@ func TestInvalidArtifactPatches(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyReportsFailedFiles(t *testing.T) {
+	root := "projects/patch-failed-files-test/locations/global"
+	ctx := context.Background()
+	adminClient, err := connection.NewAdminClient(ctx)
+	if err != nil {
+		t.Fatalf("Setup: failed to create client: %+v", err)
+	}
+	defer adminClient.Close()
+	registryClient, err := connection.NewRegistryClient(ctx)
+	if err != nil {
+		t.Fatalf("Setup: Failed to create registry client: %s", err)
+	}
+	defer registryClient.Close()
+	client := seeder.Client{
+		RegistryClient: registryClient,
+		AdminClient:    adminClient,
+	}
+	if err := seeder.SeedApis(ctx, client, &rpc.Api{Name: root + "/apis/a"}); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+
+	// The API of the second version doesn't exist, so it can't be created.
+	dir := t.TempDir()
+	for file, parent := range map[string]string{"v1.yaml": "apis/a", "orphan.yaml": "apis/missing"} {
+		version := "apiVersion: apigeeregistry/v1\nkind: Version\nmetadata:\n  name: v1\n  parent: " + parent + "\n"
+		if err := os.WriteFile(filepath.Join(dir, file), []byte(version), 0644); err != nil {
+			t.Fatalf("Setup: %s", err)
+		}
+	}
+
+	// The failure doesn't prevent the other version in the same batch from being created.
+	if err := Apply(ctx, registryClient, dir, root, false, 1); err == nil {
+		t.Errorf("Apply() returned no error, want failure of %s", filepath.Join(dir, "orphan.yaml"))
+	}
+	if _, err := registryClient.GetApiVersion(ctx, &rpc.GetApiVersionRequest{Name: root + "/apis/a/versions/v1"}); err != nil {
+		t.Errorf("GetApiVersion() returned error: %s", err)
+	}
+}
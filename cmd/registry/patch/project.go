@@ -362,6 +362,21 @@ func ExportArtifact(ctx context.Context, client *gapic.RegistryClient, artifactN
 	})
 }
 
+// ExportArtifactRevision writes a revision of an artifact to a file named with
+// its revision ID. Names without revision IDs refer to the current revision,
+// which is exported like ExportArtifact.
+func ExportArtifactRevision(ctx context.Context, client *gapic.RegistryClient, name names.ArtifactRevision, root string, taskQueue chan<- core.Task) error {
+	root = filepath.Join(root, name.Artifact().ProjectID())
+	return core.GetArtifactRevision(ctx, client, name, false, func(message *rpc.Artifact) error {
+		taskQueue <- &exportArtifactTask{
+			client:  client,
+			message: message,
+			dir:     root,
+		}
+		return nil
+	})
+}
+
 type exportAPITask struct {
 	client  connection.RegistryClient
 	message *rpc.Api
@@ -415,11 +430,15 @@ func (task *exportArtifactTask) Run(ctx context.Context) error {
 		return err
 	}
 	log.FromContext(ctx).Infof("Exported %s", task.message.Name)
+	id := artifact.Header.Metadata.Name
+	if rev, err := names.ParseArtifactRevision(task.message.Name); err == nil && rev.RevisionID != "" {
+		id += "@" + rev.RevisionID
+	}
 	var filename string
 	if artifact.Header.Metadata.Parent == "" {
-		filename = fmt.Sprintf("%s/artifacts/%s.yaml", task.dir, artifact.Header.Metadata.Name)
+		filename = fmt.Sprintf("%s/artifacts/%s.yaml", task.dir, id)
 	} else {
-		filename = fmt.Sprintf("%s/%s/artifacts/%s.yaml", task.dir, artifact.Header.Metadata.Parent, artifact.Header.Metadata.Name)
+		filename = fmt.Sprintf("%s/%s/artifacts/%s.yaml", task.dir, artifact.Header.Metadata.Parent, id)
 	}
 	parentDir := filepath.Dir(filename)
 	if err := os.MkdirAll(parentDir, 0777); err != nil {
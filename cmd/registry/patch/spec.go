@@ -73,12 +73,23 @@ func applyApiSpecPatchBytes(
 	bytes []byte,
 	parent string,
 	filename string) error {
+	return applyBatch(ctx, client, parent, func(b *batch) error {
+		return batchApiSpecPatchBytes(ctx, b, bytes, parent, filename)
+	})
+}
+
+func batchApiSpecPatchBytes(
+	ctx context.Context,
+	b *batch,
+	bytes []byte,
+	parent string,
+	filename string) error {
 	var spec models.ApiSpec
 	err := yaml.Unmarshal(bytes, &spec)
 	if err != nil {
 		return err
 	}
-	return applyApiSpecPatch(ctx, client, &spec, parent, filename)
+	return applyApiSpecPatch(ctx, b, &spec, parent, filename)
 }
 
 func specName(parent string, metadata models.Metadata) (names.Spec, error) {
@@ -94,7 +105,7 @@ func specName(parent string, metadata models.Metadata) (names.Spec, error) {
 
 func applyApiSpecPatch(
 	ctx context.Context,
-	client connection.RegistryClient,
+	b *batch,
 	spec *models.ApiSpec,
 	parent string,
 	filename string) error {
@@ -188,12 +199,9 @@ func applyApiSpecPatch(
 			}
 		}
 	}
-	_, err = client.UpdateApiSpec(ctx, req)
-	if err != nil {
-		return err
-	}
+	b.updateApiSpec(req)
 	for _, artifactPatch := range spec.Data.Artifacts {
-		err = applyArtifactPatch(ctx, client, artifactPatch, name.String())
+		err = applyArtifactPatch(ctx, b, artifactPatch, name.String())
 		if err != nil {
 			return err
 		}
@@ -83,12 +83,22 @@ func applyApiVersionPatchBytes(
 	client connection.RegistryClient,
 	bytes []byte,
 	parent string) error {
+	return applyBatch(ctx, client, parent, func(b *batch) error {
+		return batchApiVersionPatchBytes(ctx, b, bytes, parent)
+	})
+}
+
+func batchApiVersionPatchBytes(
+	ctx context.Context,
+	b *batch,
+	bytes []byte,
+	parent string) error {
 	var version models.ApiVersion
 	err := yaml.Unmarshal(bytes, &version)
 	if err != nil {
 		return err
 	}
-	return applyApiVersionPatch(ctx, client, &version, parent)
+	return applyApiVersionPatch(ctx, b, &version, parent)
 }
 
 func versionName(parent string, metadata models.Metadata) (names.Version, error) {
@@ -104,7 +114,7 @@ func versionName(parent string, metadata models.Metadata) (names.Version, error)
 
 func applyApiVersionPatch(
 	ctx context.Context,
-	client connection.RegistryClient,
+	b *batch,
 	version *models.ApiVersion,
 	parent string) error {
 	name, err := versionName(parent, version.Metadata)
@@ -123,18 +133,15 @@ func applyApiVersionPatch(
 		},
 		AllowMissing: true,
 	}
-	_, err = client.UpdateApiVersion(ctx, req)
-	if err != nil {
-		return err
-	}
+	b.updateApiVersion(req)
 	for _, specPatch := range version.Data.ApiSpecs {
-		err := applyApiSpecPatch(ctx, client, specPatch, name.String(), "")
+		err := applyApiSpecPatch(ctx, b, specPatch, name.String(), "")
 		if err != nil {
 			return err
 		}
 	}
 	for _, artifactPatch := range version.Data.Artifacts {
-		err = applyArtifactPatch(ctx, client, artifactPatch, name.String())
+		err = applyArtifactPatch(ctx, b, artifactPatch, name.String())
 		if err != nil {
 			return err
 		}
@@ -73,6 +73,8 @@ func getMap(contents []byte, mimeType string) (map[string]interface{}, error) {
 		return unmarshalAndMap(contents, &rpc.Lint{})
 	case "google.cloud.apigeeregistry.v1.apihub.ReferenceList":
 		return unmarshalAndMap(contents, &rpc.ReferenceList{})
+	case "google.cloud.apigeeregistry.v1.apihub.SpecIndex":
+		return unmarshalAndMap(contents, &rpc.SpecIndex{})
 	case "google.cloud.apigeeregistry.v1.controller.Receipt":
 		return unmarshalAndMap(contents, &rpc.Receipt{})
 	case "google.cloud.apigeeregistry.v1.scoring.Score":
@@ -42,14 +42,27 @@ var newAdminClientHook clientHook
 
 // AdminCallOptions contains the retry settings for each method of AdminClient.
 type AdminCallOptions struct {
-	GetStatus       []gax.CallOption
-	GetStorage      []gax.CallOption
-	MigrateDatabase []gax.CallOption
-	ListProjects    []gax.CallOption
-	GetProject      []gax.CallOption
-	CreateProject   []gax.CallOption
-	UpdateProject   []gax.CallOption
-	DeleteProject   []gax.CallOption
+	GetStatus                []gax.CallOption
+	GetStorage               []gax.CallOption
+	MigrateDatabase          []gax.CallOption
+	CompactRevisions         []gax.CallOption
+	ListProjects             []gax.CallOption
+	GetProject               []gax.CallOption
+	CreateProject            []gax.CallOption
+	UpdateProject            []gax.CallOption
+	DeleteProject            []gax.CallOption
+	UndeleteProject          []gax.CallOption
+	CloneProject             []gax.CallOption
+	CopyApi                  []gax.CallOption
+	ExportProject            []gax.CallOption
+	ImportProject            []gax.CallOption
+	ReadArchive              []gax.CallOption
+	WriteArchive             []gax.CallOption
+	WatchResources           []gax.CallOption
+	ListNotificationEvents   []gax.CallOption
+	ReplayNotificationEvents []gax.CallOption
+	ListAuditEvents          []gax.CallOption
+	AggregateResources       []gax.CallOption
 }
 
 func defaultAdminGRPCClientOptions() []option.ClientOption {
@@ -66,14 +79,27 @@ func defaultAdminGRPCClientOptions() []option.ClientOption {
 
 func defaultAdminCallOptions() *AdminCallOptions {
 	return &AdminCallOptions{
-		GetStatus:       []gax.CallOption{},
-		GetStorage:      []gax.CallOption{},
-		MigrateDatabase: []gax.CallOption{},
-		ListProjects:    []gax.CallOption{},
-		GetProject:      []gax.CallOption{},
-		CreateProject:   []gax.CallOption{},
-		UpdateProject:   []gax.CallOption{},
-		DeleteProject:   []gax.CallOption{},
+		GetStatus:                []gax.CallOption{},
+		GetStorage:               []gax.CallOption{},
+		MigrateDatabase:          []gax.CallOption{},
+		CompactRevisions:         []gax.CallOption{},
+		ListProjects:             []gax.CallOption{},
+		GetProject:               []gax.CallOption{},
+		CreateProject:            []gax.CallOption{},
+		UpdateProject:            []gax.CallOption{},
+		DeleteProject:            []gax.CallOption{},
+		UndeleteProject:          []gax.CallOption{},
+		CloneProject:             []gax.CallOption{},
+		CopyApi:                  []gax.CallOption{},
+		ExportProject:            []gax.CallOption{},
+		ImportProject:            []gax.CallOption{},
+		ReadArchive:              []gax.CallOption{},
+		WriteArchive:             []gax.CallOption{},
+		WatchResources:           []gax.CallOption{},
+		ListNotificationEvents:   []gax.CallOption{},
+		ReplayNotificationEvents: []gax.CallOption{},
+		ListAuditEvents:          []gax.CallOption{},
+		AggregateResources:       []gax.CallOption{},
 	}
 }
 
@@ -86,11 +112,26 @@ type internalAdminClient interface {
 	GetStorage(context.Context, *emptypb.Empty, ...gax.CallOption) (*rpcpb.Storage, error)
 	MigrateDatabase(context.Context, *rpcpb.MigrateDatabaseRequest, ...gax.CallOption) (*MigrateDatabaseOperation, error)
 	MigrateDatabaseOperation(name string) *MigrateDatabaseOperation
+	CompactRevisions(context.Context, *rpcpb.CompactRevisionsRequest, ...gax.CallOption) (*rpcpb.CompactRevisionsResponse, error)
 	ListProjects(context.Context, *rpcpb.ListProjectsRequest, ...gax.CallOption) *ProjectIterator
 	GetProject(context.Context, *rpcpb.GetProjectRequest, ...gax.CallOption) (*rpcpb.Project, error)
 	CreateProject(context.Context, *rpcpb.CreateProjectRequest, ...gax.CallOption) (*rpcpb.Project, error)
 	UpdateProject(context.Context, *rpcpb.UpdateProjectRequest, ...gax.CallOption) (*rpcpb.Project, error)
 	DeleteProject(context.Context, *rpcpb.DeleteProjectRequest, ...gax.CallOption) error
+	UndeleteProject(context.Context, *rpcpb.UndeleteProjectRequest, ...gax.CallOption) (*rpcpb.Project, error)
+	CloneProject(context.Context, *rpcpb.CloneProjectRequest, ...gax.CallOption) (*rpcpb.Project, error)
+	CopyApi(context.Context, *rpcpb.CopyApiRequest, ...gax.CallOption) (*rpcpb.Api, error)
+	ExportProject(context.Context, *rpcpb.ExportProjectRequest, ...gax.CallOption) (*ExportProjectOperation, error)
+	ExportProjectOperation(name string) *ExportProjectOperation
+	ImportProject(context.Context, *rpcpb.ImportProjectRequest, ...gax.CallOption) (*ImportProjectOperation, error)
+	ImportProjectOperation(name string) *ImportProjectOperation
+	ReadArchive(context.Context, *rpcpb.ReadArchiveRequest, ...gax.CallOption) (rpcpb.Admin_ReadArchiveClient, error)
+	WriteArchive(context.Context, ...gax.CallOption) (rpcpb.Admin_WriteArchiveClient, error)
+	WatchResources(context.Context, *rpcpb.WatchResourcesRequest, ...gax.CallOption) (rpcpb.Admin_WatchResourcesClient, error)
+	ListNotificationEvents(context.Context, *rpcpb.ListNotificationEventsRequest, ...gax.CallOption) *NotificationEventIterator
+	ReplayNotificationEvents(context.Context, *rpcpb.ReplayNotificationEventsRequest, ...gax.CallOption) (*rpcpb.ReplayNotificationEventsResponse, error)
+	ListAuditEvents(context.Context, *rpcpb.ListAuditEventsRequest, ...gax.CallOption) *AuditEventIterator
+	AggregateResources(context.Context, *rpcpb.AggregateResourcesRequest, ...gax.CallOption) (*rpcpb.AggregateResourcesResponse, error)
 }
 
 // AdminClient is a client for interacting with .
@@ -156,7 +197,8 @@ func (c *AdminClient) GetStorage(ctx context.Context, req *emptypb.Empty, opts .
 	return c.internalClient.GetStorage(ctx, req, opts...)
 }
 
-// MigrateDatabase migrateDatabase attempts to migrate the database to the current schema.
+// MigrateDatabase migrateDatabase migrates the database to the current schema or to a
+// specified schema version. Progress is reported in the operation metadata.
 func (c *AdminClient) MigrateDatabase(ctx context.Context, req *rpcpb.MigrateDatabaseRequest, opts ...gax.CallOption) (*MigrateDatabaseOperation, error) {
 	return c.internalClient.MigrateDatabase(ctx, req, opts...)
 }
@@ -167,6 +209,16 @@ func (c *AdminClient) MigrateDatabaseOperation(name string) *MigrateDatabaseOper
 	return c.internalClient.MigrateDatabaseOperation(name)
 }
 
+// CompactRevisions compactRevisions removes revisions of specs and deployments that are not
+// kept by a retention policy. The latest revision, tagged revisions and
+// revisions that are referenced by versions, deployments or APIs are always
+// kept. Revisions are removed with their contents and artifacts.
+// (– api-linter: core::0136::http-uri-suffix=disabled
+// aip.dev/not-precedent (at http://aip.dev/not-precedent): Not in the official API. –)
+func (c *AdminClient) CompactRevisions(ctx context.Context, req *rpcpb.CompactRevisionsRequest, opts ...gax.CallOption) (*rpcpb.CompactRevisionsResponse, error) {
+	return c.internalClient.CompactRevisions(ctx, req, opts...)
+}
+
 // ListProjects listProjects returns matching projects.
 // (– api-linter: standard-methods=disabled –)
 // (– api-linter: core::0132::method-signature=disabled
@@ -201,6 +253,125 @@ func (c *AdminClient) DeleteProject(ctx context.Context, req *rpcpb.DeleteProjec
 	return c.internalClient.DeleteProject(ctx, req, opts...)
 }
 
+// UndeleteProject undeleteProject restores a deleted project and the resources that were
+// deleted with it. Deleted resources can be restored until they are purged.
+func (c *AdminClient) UndeleteProject(ctx context.Context, req *rpcpb.UndeleteProjectRequest, opts ...gax.CallOption) (*rpcpb.Project, error) {
+	return c.internalClient.UndeleteProject(ctx, req, opts...)
+}
+
+// CloneProject cloneProject creates a project with copies of all of the resources of a
+// specified project. Copies keep the revision IDs, tags and timestamps of
+// the resources they copy, and references between the resources are changed
+// to refer to the copies. The copy is made in a single transaction.
+// (– api-linter: core::0136::http-uri-suffix=disabled
+// aip.dev/not-precedent (at http://aip.dev/not-precedent): Not in the official API. –)
+func (c *AdminClient) CloneProject(ctx context.Context, req *rpcpb.CloneProjectRequest, opts ...gax.CallOption) (*rpcpb.Project, error) {
+	return c.internalClient.CloneProject(ctx, req, opts...)
+}
+
+// CopyApi copyApi copies an API with all of its versions, spec revisions,
+// deployment revisions, tags and artifacts into a project. Copies keep the
+// revision IDs, tags and timestamps of the resources they copy, and
+// references between the resources are changed to refer to the copies.
+// The copy is made in a single transaction.
+// (– api-linter: core::0136::http-uri-suffix=disabled
+// aip.dev/not-precedent (at http://aip.dev/not-precedent): Not in the official API. –)
+func (c *AdminClient) CopyApi(ctx context.Context, req *rpcpb.CopyApiRequest, opts ...gax.CallOption) (*rpcpb.Api, error) {
+	return c.internalClient.CopyApi(ctx, req, opts...)
+}
+
+// ExportProject exportProject writes an archive of a project and all of its resources,
+// including every revision of its specs and deployments with their tags and
+// the contents of specs and artifacts. The archive can be read with
+// ReadArchive and imported into this or another registry with ImportProject.
+// (– api-linter: core::0136::http-uri-suffix=disabled
+// aip.dev/not-precedent (at http://aip.dev/not-precedent): Not in the official API. –)
+func (c *AdminClient) ExportProject(ctx context.Context, req *rpcpb.ExportProjectRequest, opts ...gax.CallOption) (*ExportProjectOperation, error) {
+	return c.internalClient.ExportProject(ctx, req, opts...)
+}
+
+// ExportProjectOperation returns a new ExportProjectOperation from a given name.
+// The name must be that of a previously created ExportProjectOperation, possibly from a different process.
+func (c *AdminClient) ExportProjectOperation(name string) *ExportProjectOperation {
+	return c.internalClient.ExportProjectOperation(name)
+}
+
+// ImportProject importProject creates or updates a project and its resources from an
+// archive. Resources keep the names, revisions and creation times that they
+// had when they were exported, so importing an archive more than once has
+// the same effect as importing it once.
+// (– api-linter: core::0136::http-uri-suffix=disabled
+// aip.dev/not-precedent (at http://aip.dev/not-precedent): Not in the official API. –)
+func (c *AdminClient) ImportProject(ctx context.Context, req *rpcpb.ImportProjectRequest, opts ...gax.CallOption) (*ImportProjectOperation, error) {
+	return c.internalClient.ImportProject(ctx, req, opts...)
+}
+
+// ImportProjectOperation returns a new ImportProjectOperation from a given name.
+// The name must be that of a previously created ImportProjectOperation, possibly from a different process.
+func (c *AdminClient) ImportProjectOperation(name string) *ImportProjectOperation {
+	return c.internalClient.ImportProjectOperation(name)
+}
+
+// ReadArchive readArchive streams the contents of an archive.
+// (– api-linter: core::0136::http-uri-suffix=disabled
+// aip.dev/not-precedent (at http://aip.dev/not-precedent): Not in the official API. –)
+// (– api-linter: core::0136::http-method=disabled
+// aip.dev/not-precedent (at http://aip.dev/not-precedent): Not in the official API. –)
+func (c *AdminClient) ReadArchive(ctx context.Context, req *rpcpb.ReadArchiveRequest, opts ...gax.CallOption) (rpcpb.Admin_ReadArchiveClient, error) {
+	return c.internalClient.ReadArchive(ctx, req, opts...)
+}
+
+// WriteArchive writeArchive stores an archive streamed by the client so that it can be
+// imported with ImportProject.
+// (– api-linter: core::0136::http-uri-suffix=disabled
+// aip.dev/not-precedent (at http://aip.dev/not-precedent): Not in the official API. –)
+func (c *AdminClient) WriteArchive(ctx context.Context, opts ...gax.CallOption) (rpcpb.Admin_WriteArchiveClient, error) {
+	return c.internalClient.WriteArchive(ctx, opts...)
+}
+
+// WatchResources watchResources streams notifications of changes to resources under a
+// specified parent as they are made.
+// (– api-linter: core::0131::http-method=disabled
+// aip.dev/not-precedent (at http://aip.dev/not-precedent): Not in the official API. –)
+func (c *AdminClient) WatchResources(ctx context.Context, req *rpcpb.WatchResourcesRequest, opts ...gax.CallOption) (rpcpb.Admin_WatchResourcesClient, error) {
+	return c.internalClient.WatchResources(ctx, req, opts...)
+}
+
+// ListNotificationEvents listNotificationEvents returns notifications that have not yet been
+// delivered to their sinks.
+// (– api-linter: core::0132::method-signature=disabled
+// aip.dev/not-precedent (at http://aip.dev/not-precedent): Not in the official API. –)
+// (– api-linter: core::0132::http-uri-parent=disabled
+// aip.dev/not-precedent (at http://aip.dev/not-precedent): Not in the official API. –)
+func (c *AdminClient) ListNotificationEvents(ctx context.Context, req *rpcpb.ListNotificationEventsRequest, opts ...gax.CallOption) *NotificationEventIterator {
+	return c.internalClient.ListNotificationEvents(ctx, req, opts...)
+}
+
+// ReplayNotificationEvents replayNotificationEvents schedules matching undelivered notifications for
+// immediate redelivery, including notifications whose delivery has failed.
+// (– api-linter: core::0136::http-uri-suffix=disabled
+// aip.dev/not-precedent (at http://aip.dev/not-precedent): Not in the official API. –)
+func (c *AdminClient) ReplayNotificationEvents(ctx context.Context, req *rpcpb.ReplayNotificationEventsRequest, opts ...gax.CallOption) (*rpcpb.ReplayNotificationEventsResponse, error) {
+	return c.internalClient.ReplayNotificationEvents(ctx, req, opts...)
+}
+
+// ListAuditEvents listAuditEvents returns records of changes made to resources.
+// (– api-linter: core::0132::method-signature=disabled
+// aip.dev/not-precedent (at http://aip.dev/not-precedent): Not in the official API. –)
+// (– api-linter: core::0132::http-uri-parent=disabled
+// aip.dev/not-precedent (at http://aip.dev/not-precedent): Not in the official API. –)
+func (c *AdminClient) ListAuditEvents(ctx context.Context, req *rpcpb.ListAuditEventsRequest, opts ...gax.CallOption) *AuditEventIterator {
+	return c.internalClient.ListAuditEvents(ctx, req, opts...)
+}
+
+// AggregateResources aggregateResources counts the resources in a collection, optionally
+// grouped by the values of a field.
+// (– api-linter: core::0136::http-uri-suffix=disabled
+// aip.dev/not-precedent (at http://aip.dev/not-precedent): Not in the official API. –)
+func (c *AdminClient) AggregateResources(ctx context.Context, req *rpcpb.AggregateResourcesRequest, opts ...gax.CallOption) (*rpcpb.AggregateResourcesResponse, error) {
+	return c.internalClient.AggregateResources(ctx, req, opts...)
+}
+
 // adminGRPCClient is a client for interacting with  over gRPC transport.
 //
 // Methods, except Close, may be called concurrently. However, fields must not be modified concurrently with method calls.
@@ -346,6 +517,21 @@ func (c *adminGRPCClient) MigrateDatabase(ctx context.Context, req *rpcpb.Migrat
 	}, nil
 }
 
+func (c *adminGRPCClient) CompactRevisions(ctx context.Context, req *rpcpb.CompactRevisionsRequest, opts ...gax.CallOption) (*rpcpb.CompactRevisionsResponse, error) {
+	ctx = insertMetadata(ctx, c.xGoogMetadata)
+	opts = append((*c.CallOptions).CompactRevisions[0:len((*c.CallOptions).CompactRevisions):len((*c.CallOptions).CompactRevisions)], opts...)
+	var resp *rpcpb.CompactRevisionsResponse
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.adminClient.CompactRevisions(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 func (c *adminGRPCClient) ListProjects(ctx context.Context, req *rpcpb.ListProjectsRequest, opts ...gax.CallOption) *ProjectIterator {
 	ctx = insertMetadata(ctx, c.xGoogMetadata)
 	opts = append((*c.CallOptions).ListProjects[0:len((*c.CallOptions).ListProjects):len((*c.CallOptions).ListProjects)], opts...)
@@ -451,6 +637,398 @@ func (c *adminGRPCClient) DeleteProject(ctx context.Context, req *rpcpb.DeletePr
 	return err
 }
 
+func (c *adminGRPCClient) UndeleteProject(ctx context.Context, req *rpcpb.UndeleteProjectRequest, opts ...gax.CallOption) (*rpcpb.Project, error) {
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
+
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).UndeleteProject[0:len((*c.CallOptions).UndeleteProject):len((*c.CallOptions).UndeleteProject)], opts...)
+	var resp *rpcpb.Project
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.adminClient.UndeleteProject(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *adminGRPCClient) CloneProject(ctx context.Context, req *rpcpb.CloneProjectRequest, opts ...gax.CallOption) (*rpcpb.Project, error) {
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
+
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).CloneProject[0:len((*c.CallOptions).CloneProject):len((*c.CallOptions).CloneProject)], opts...)
+	var resp *rpcpb.Project
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.adminClient.CloneProject(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *adminGRPCClient) CopyApi(ctx context.Context, req *rpcpb.CopyApiRequest, opts ...gax.CallOption) (*rpcpb.Api, error) {
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
+
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).CopyApi[0:len((*c.CallOptions).CopyApi):len((*c.CallOptions).CopyApi)], opts...)
+	var resp *rpcpb.Api
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.adminClient.CopyApi(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *adminGRPCClient) ExportProject(ctx context.Context, req *rpcpb.ExportProjectRequest, opts ...gax.CallOption) (*ExportProjectOperation, error) {
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
+
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).ExportProject[0:len((*c.CallOptions).ExportProject):len((*c.CallOptions).ExportProject)], opts...)
+	var resp *longrunningpb.Operation
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.adminClient.ExportProject(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ExportProjectOperation{
+		lro: longrunning.InternalNewOperation(*c.LROClient, resp),
+	}, nil
+}
+
+func (c *adminGRPCClient) ImportProject(ctx context.Context, req *rpcpb.ImportProjectRequest, opts ...gax.CallOption) (*ImportProjectOperation, error) {
+	ctx = insertMetadata(ctx, c.xGoogMetadata)
+	opts = append((*c.CallOptions).ImportProject[0:len((*c.CallOptions).ImportProject):len((*c.CallOptions).ImportProject)], opts...)
+	var resp *longrunningpb.Operation
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.adminClient.ImportProject(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ImportProjectOperation{
+		lro: longrunning.InternalNewOperation(*c.LROClient, resp),
+	}, nil
+}
+
+func (c *adminGRPCClient) ReadArchive(ctx context.Context, req *rpcpb.ReadArchiveRequest, opts ...gax.CallOption) (rpcpb.Admin_ReadArchiveClient, error) {
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
+
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	var resp rpcpb.Admin_ReadArchiveClient
+	opts = append((*c.CallOptions).ReadArchive[0:len((*c.CallOptions).ReadArchive):len((*c.CallOptions).ReadArchive)], opts...)
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.adminClient.ReadArchive(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *adminGRPCClient) WriteArchive(ctx context.Context, opts ...gax.CallOption) (rpcpb.Admin_WriteArchiveClient, error) {
+	ctx = insertMetadata(ctx, c.xGoogMetadata)
+	var resp rpcpb.Admin_WriteArchiveClient
+	opts = append((*c.CallOptions).WriteArchive[0:len((*c.CallOptions).WriteArchive):len((*c.CallOptions).WriteArchive)], opts...)
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.adminClient.WriteArchive(ctx, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *adminGRPCClient) WatchResources(ctx context.Context, req *rpcpb.WatchResourcesRequest, opts ...gax.CallOption) (rpcpb.Admin_WatchResourcesClient, error) {
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "parent", url.QueryEscape(req.GetParent())))
+
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	var resp rpcpb.Admin_WatchResourcesClient
+	opts = append((*c.CallOptions).WatchResources[0:len((*c.CallOptions).WatchResources):len((*c.CallOptions).WatchResources)], opts...)
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.adminClient.WatchResources(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *adminGRPCClient) ListNotificationEvents(ctx context.Context, req *rpcpb.ListNotificationEventsRequest, opts ...gax.CallOption) *NotificationEventIterator {
+	ctx = insertMetadata(ctx, c.xGoogMetadata)
+	opts = append((*c.CallOptions).ListNotificationEvents[0:len((*c.CallOptions).ListNotificationEvents):len((*c.CallOptions).ListNotificationEvents)], opts...)
+	it := &NotificationEventIterator{}
+	req = proto.Clone(req).(*rpcpb.ListNotificationEventsRequest)
+	it.InternalFetch = func(pageSize int, pageToken string) ([]*rpcpb.NotificationEvent, string, error) {
+		resp := &rpcpb.ListNotificationEventsResponse{}
+		if pageToken != "" {
+			req.PageToken = pageToken
+		}
+		if pageSize > math.MaxInt32 {
+			req.PageSize = math.MaxInt32
+		} else if pageSize != 0 {
+			req.PageSize = int32(pageSize)
+		}
+		err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+			var err error
+			resp, err = c.adminClient.ListNotificationEvents(ctx, req, settings.GRPC...)
+			return err
+		}, opts...)
+		if err != nil {
+			return nil, "", err
+		}
+
+		it.Response = resp
+		return resp.GetNotificationEvents(), resp.GetNextPageToken(), nil
+	}
+	fetch := func(pageSize int, pageToken string) (string, error) {
+		items, nextPageToken, err := it.InternalFetch(pageSize, pageToken)
+		if err != nil {
+			return "", err
+		}
+		it.items = append(it.items, items...)
+		return nextPageToken, nil
+	}
+
+	it.pageInfo, it.nextFunc = iterator.NewPageInfo(fetch, it.bufLen, it.takeBuf)
+	it.pageInfo.MaxSize = int(req.GetPageSize())
+	it.pageInfo.Token = req.GetPageToken()
+
+	return it
+}
+
+func (c *adminGRPCClient) ReplayNotificationEvents(ctx context.Context, req *rpcpb.ReplayNotificationEventsRequest, opts ...gax.CallOption) (*rpcpb.ReplayNotificationEventsResponse, error) {
+	ctx = insertMetadata(ctx, c.xGoogMetadata)
+	opts = append((*c.CallOptions).ReplayNotificationEvents[0:len((*c.CallOptions).ReplayNotificationEvents):len((*c.CallOptions).ReplayNotificationEvents)], opts...)
+	var resp *rpcpb.ReplayNotificationEventsResponse
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.adminClient.ReplayNotificationEvents(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *adminGRPCClient) ListAuditEvents(ctx context.Context, req *rpcpb.ListAuditEventsRequest, opts ...gax.CallOption) *AuditEventIterator {
+	ctx = insertMetadata(ctx, c.xGoogMetadata)
+	opts = append((*c.CallOptions).ListAuditEvents[0:len((*c.CallOptions).ListAuditEvents):len((*c.CallOptions).ListAuditEvents)], opts...)
+	it := &AuditEventIterator{}
+	req = proto.Clone(req).(*rpcpb.ListAuditEventsRequest)
+	it.InternalFetch = func(pageSize int, pageToken string) ([]*rpcpb.AuditEvent, string, error) {
+		resp := &rpcpb.ListAuditEventsResponse{}
+		if pageToken != "" {
+			req.PageToken = pageToken
+		}
+		if pageSize > math.MaxInt32 {
+			req.PageSize = math.MaxInt32
+		} else if pageSize != 0 {
+			req.PageSize = int32(pageSize)
+		}
+		err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+			var err error
+			resp, err = c.adminClient.ListAuditEvents(ctx, req, settings.GRPC...)
+			return err
+		}, opts...)
+		if err != nil {
+			return nil, "", err
+		}
+
+		it.Response = resp
+		return resp.GetAuditEvents(), resp.GetNextPageToken(), nil
+	}
+	fetch := func(pageSize int, pageToken string) (string, error) {
+		items, nextPageToken, err := it.InternalFetch(pageSize, pageToken)
+		if err != nil {
+			return "", err
+		}
+		it.items = append(it.items, items...)
+		return nextPageToken, nil
+	}
+
+	it.pageInfo, it.nextFunc = iterator.NewPageInfo(fetch, it.bufLen, it.takeBuf)
+	it.pageInfo.MaxSize = int(req.GetPageSize())
+	it.pageInfo.Token = req.GetPageToken()
+
+	return it
+}
+
+func (c *adminGRPCClient) AggregateResources(ctx context.Context, req *rpcpb.AggregateResourcesRequest, opts ...gax.CallOption) (*rpcpb.AggregateResourcesResponse, error) {
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "collection", url.QueryEscape(req.GetCollection())))
+
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).AggregateResources[0:len((*c.CallOptions).AggregateResources):len((*c.CallOptions).AggregateResources)], opts...)
+	var resp *rpcpb.AggregateResourcesResponse
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.adminClient.AggregateResources(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ExportProjectOperation manages a long-running operation from ExportProject.
+type ExportProjectOperation struct {
+	lro *longrunning.Operation
+}
+
+// ExportProjectOperation returns a new ExportProjectOperation from a given name.
+// The name must be that of a previously created ExportProjectOperation, possibly from a different process.
+func (c *adminGRPCClient) ExportProjectOperation(name string) *ExportProjectOperation {
+	return &ExportProjectOperation{
+		lro: longrunning.InternalNewOperation(*c.LROClient, &longrunningpb.Operation{Name: name}),
+	}
+}
+
+// Wait blocks until the long-running operation is completed, returning the response and any errors encountered.
+//
+// See documentation of Poll for error-handling information.
+func (op *ExportProjectOperation) Wait(ctx context.Context, opts ...gax.CallOption) (*rpcpb.ExportProjectResponse, error) {
+	var resp rpcpb.ExportProjectResponse
+	if err := op.lro.WaitWithInterval(ctx, &resp, time.Minute, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Poll fetches the latest state of the long-running operation.
+//
+// Poll also fetches the latest metadata, which can be retrieved by Metadata.
+//
+// If Poll fails, the error is returned and op is unmodified. If Poll succeeds and
+// the operation has completed with failure, the error is returned and op.Done will return true.
+// If Poll succeeds and the operation has completed successfully,
+// op.Done will return true, and the response of the operation is returned.
+// If Poll succeeds and the operation has not completed, the returned response and error are both nil.
+func (op *ExportProjectOperation) Poll(ctx context.Context, opts ...gax.CallOption) (*rpcpb.ExportProjectResponse, error) {
+	var resp rpcpb.ExportProjectResponse
+	if err := op.lro.Poll(ctx, &resp, opts...); err != nil {
+		return nil, err
+	}
+	if !op.Done() {
+		return nil, nil
+	}
+	return &resp, nil
+}
+
+// Metadata returns metadata associated with the long-running operation.
+// Metadata itself does not contact the server, but Poll does.
+// To get the latest metadata, call this method after a successful call to Poll.
+// If the metadata is not available, the returned metadata and error are both nil.
+func (op *ExportProjectOperation) Metadata() (*rpcpb.ArchiveMetadata, error) {
+	var meta rpcpb.ArchiveMetadata
+	if err := op.lro.Metadata(&meta); err == longrunning.ErrNoMetadata {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &meta, nil
+}
+
+// Done reports whether the long-running operation has completed.
+func (op *ExportProjectOperation) Done() bool {
+	return op.lro.Done()
+}
+
+// Name returns the name of the long-running operation.
+// The name is assigned by the server and is unique within the service from which the operation is created.
+func (op *ExportProjectOperation) Name() string {
+	return op.lro.Name()
+}
+
+// ImportProjectOperation manages a long-running operation from ImportProject.
+type ImportProjectOperation struct {
+	lro *longrunning.Operation
+}
+
+// ImportProjectOperation returns a new ImportProjectOperation from a given name.
+// The name must be that of a previously created ImportProjectOperation, possibly from a different process.
+func (c *adminGRPCClient) ImportProjectOperation(name string) *ImportProjectOperation {
+	return &ImportProjectOperation{
+		lro: longrunning.InternalNewOperation(*c.LROClient, &longrunningpb.Operation{Name: name}),
+	}
+}
+
+// Wait blocks until the long-running operation is completed, returning the response and any errors encountered.
+//
+// See documentation of Poll for error-handling information.
+func (op *ImportProjectOperation) Wait(ctx context.Context, opts ...gax.CallOption) (*rpcpb.ImportProjectResponse, error) {
+	var resp rpcpb.ImportProjectResponse
+	if err := op.lro.WaitWithInterval(ctx, &resp, time.Minute, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Poll fetches the latest state of the long-running operation.
+//
+// Poll also fetches the latest metadata, which can be retrieved by Metadata.
+//
+// If Poll fails, the error is returned and op is unmodified. If Poll succeeds and
+// the operation has completed with failure, the error is returned and op.Done will return true.
+// If Poll succeeds and the operation has completed successfully,
+// op.Done will return true, and the response of the operation is returned.
+// If Poll succeeds and the operation has not completed, the returned response and error are both nil.
+func (op *ImportProjectOperation) Poll(ctx context.Context, opts ...gax.CallOption) (*rpcpb.ImportProjectResponse, error) {
+	var resp rpcpb.ImportProjectResponse
+	if err := op.lro.Poll(ctx, &resp, opts...); err != nil {
+		return nil, err
+	}
+	if !op.Done() {
+		return nil, nil
+	}
+	return &resp, nil
+}
+
+// Metadata returns metadata associated with the long-running operation.
+// Metadata itself does not contact the server, but Poll does.
+// To get the latest metadata, call this method after a successful call to Poll.
+// If the metadata is not available, the returned metadata and error are both nil.
+func (op *ImportProjectOperation) Metadata() (*rpcpb.ArchiveMetadata, error) {
+	var meta rpcpb.ArchiveMetadata
+	if err := op.lro.Metadata(&meta); err == longrunning.ErrNoMetadata {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &meta, nil
+}
+
+// Done reports whether the long-running operation has completed.
+func (op *ImportProjectOperation) Done() bool {
+	return op.lro.Done()
+}
+
+// Name returns the name of the long-running operation.
+// The name is assigned by the server and is unique within the service from which the operation is created.
+func (op *ImportProjectOperation) Name() string {
+	return op.lro.Name()
+}
+
 // MigrateDatabaseOperation manages a long-running operation from MigrateDatabase.
 type MigrateDatabaseOperation struct {
 	lro *longrunning.Operation
@@ -520,6 +1098,100 @@ func (op *MigrateDatabaseOperation) Name() string {
 	return op.lro.Name()
 }
 
+// AuditEventIterator manages a stream of *rpcpb.AuditEvent.
+type AuditEventIterator struct {
+	items    []*rpcpb.AuditEvent
+	pageInfo *iterator.PageInfo
+	nextFunc func() error
+
+	// Response is the raw response for the current page.
+	// It must be cast to the RPC response type.
+	// Calling Next() or InternalFetch() updates this value.
+	Response interface{}
+
+	// InternalFetch is for use by the Google Cloud Libraries only.
+	// It is not part of the stable interface of this package.
+	//
+	// InternalFetch returns results from a single call to the underlying RPC.
+	// The number of results is no greater than pageSize.
+	// If there are no more results, nextPageToken is empty and err is nil.
+	InternalFetch func(pageSize int, pageToken string) (results []*rpcpb.AuditEvent, nextPageToken string, err error)
+}
+
+// PageInfo supports pagination. See the google.golang.org/api/iterator package for details.
+func (it *AuditEventIterator) PageInfo() *iterator.PageInfo {
+	return it.pageInfo
+}
+
+// Next returns the next result. Its second return value is iterator.Done if there are no more
+// results. Once Next returns Done, all subsequent calls will return Done.
+func (it *AuditEventIterator) Next() (*rpcpb.AuditEvent, error) {
+	var item *rpcpb.AuditEvent
+	if err := it.nextFunc(); err != nil {
+		return item, err
+	}
+	item = it.items[0]
+	it.items = it.items[1:]
+	return item, nil
+}
+
+func (it *AuditEventIterator) bufLen() int {
+	return len(it.items)
+}
+
+func (it *AuditEventIterator) takeBuf() interface{} {
+	b := it.items
+	it.items = nil
+	return b
+}
+
+// NotificationEventIterator manages a stream of *rpcpb.NotificationEvent.
+type NotificationEventIterator struct {
+	items    []*rpcpb.NotificationEvent
+	pageInfo *iterator.PageInfo
+	nextFunc func() error
+
+	// Response is the raw response for the current page.
+	// It must be cast to the RPC response type.
+	// Calling Next() or InternalFetch() updates this value.
+	Response interface{}
+
+	// InternalFetch is for use by the Google Cloud Libraries only.
+	// It is not part of the stable interface of this package.
+	//
+	// InternalFetch returns results from a single call to the underlying RPC.
+	// The number of results is no greater than pageSize.
+	// If there are no more results, nextPageToken is empty and err is nil.
+	InternalFetch func(pageSize int, pageToken string) (results []*rpcpb.NotificationEvent, nextPageToken string, err error)
+}
+
+// PageInfo supports pagination. See the google.golang.org/api/iterator package for details.
+func (it *NotificationEventIterator) PageInfo() *iterator.PageInfo {
+	return it.pageInfo
+}
+
+// Next returns the next result. Its second return value is iterator.Done if there are no more
+// results. Once Next returns Done, all subsequent calls will return Done.
+func (it *NotificationEventIterator) Next() (*rpcpb.NotificationEvent, error) {
+	var item *rpcpb.NotificationEvent
+	if err := it.nextFunc(); err != nil {
+		return item, err
+	}
+	item = it.items[0]
+	it.items = it.items[1:]
+	return item, nil
+}
+
+func (it *NotificationEventIterator) bufLen() int {
+	return len(it.items)
+}
+
+func (it *NotificationEventIterator) takeBuf() interface{} {
+	b := it.items
+	it.items = nil
+	return b
+}
+
 // ProjectIterator manages a stream of *rpcpb.Project.
 type ProjectIterator struct {
 	items    []*rpcpb.Project
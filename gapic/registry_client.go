@@ -45,17 +45,20 @@ type RegistryCallOptions struct {
 	CreateApi                   []gax.CallOption
 	UpdateApi                   []gax.CallOption
 	DeleteApi                   []gax.CallOption
+	UndeleteApi                 []gax.CallOption
 	ListApiVersions             []gax.CallOption
 	GetApiVersion               []gax.CallOption
 	CreateApiVersion            []gax.CallOption
 	UpdateApiVersion            []gax.CallOption
 	DeleteApiVersion            []gax.CallOption
+	UndeleteApiVersion          []gax.CallOption
 	ListApiSpecs                []gax.CallOption
 	GetApiSpec                  []gax.CallOption
 	GetApiSpecContents          []gax.CallOption
 	CreateApiSpec               []gax.CallOption
 	UpdateApiSpec               []gax.CallOption
 	DeleteApiSpec               []gax.CallOption
+	UndeleteApiSpec             []gax.CallOption
 	TagApiSpecRevision          []gax.CallOption
 	ListApiSpecRevisions        []gax.CallOption
 	RollbackApiSpec             []gax.CallOption
@@ -65,6 +68,7 @@ type RegistryCallOptions struct {
 	CreateApiDeployment         []gax.CallOption
 	UpdateApiDeployment         []gax.CallOption
 	DeleteApiDeployment         []gax.CallOption
+	UndeleteApiDeployment       []gax.CallOption
 	TagApiDeploymentRevision    []gax.CallOption
 	ListApiDeploymentRevisions  []gax.CallOption
 	RollbackApiDeployment       []gax.CallOption
@@ -75,6 +79,29 @@ type RegistryCallOptions struct {
 	CreateArtifact              []gax.CallOption
 	ReplaceArtifact             []gax.CallOption
 	DeleteArtifact              []gax.CallOption
+	UndeleteArtifact            []gax.CallOption
+	TagArtifactRevision         []gax.CallOption
+	ListArtifactRevisions       []gax.CallOption
+	RollbackArtifact            []gax.CallOption
+	BatchCreateApis             []gax.CallOption
+	BatchUpdateApis             []gax.CallOption
+	BatchDeleteApis             []gax.CallOption
+	BatchCreateApiVersions      []gax.CallOption
+	BatchUpdateApiVersions      []gax.CallOption
+	BatchDeleteApiVersions      []gax.CallOption
+	BatchCreateApiSpecs         []gax.CallOption
+	BatchUpdateApiSpecs         []gax.CallOption
+	BatchDeleteApiSpecs         []gax.CallOption
+	BatchCreateApiDeployments   []gax.CallOption
+	BatchUpdateApiDeployments   []gax.CallOption
+	BatchDeleteApiDeployments   []gax.CallOption
+	BatchCreateArtifacts        []gax.CallOption
+	BatchReplaceArtifacts       []gax.CallOption
+	BatchDeleteArtifacts        []gax.CallOption
+	ListReferences              []gax.CallOption
+	ListReferrers               []gax.CallOption
+	SearchResources             []gax.CallOption
+	ListSpecElements            []gax.CallOption
 }
 
 func defaultRegistryGRPCClientOptions() []option.ClientOption {
@@ -161,6 +188,20 @@ func defaultRegistryCallOptions() *RegistryCallOptions {
 				})
 			}),
 		},
+		UndeleteApi: []gax.CallOption{
+			gax.WithRetry(func() gax.Retryer {
+				return gax.OnCodes([]codes.Code{
+					codes.Aborted,
+					codes.Canceled,
+					codes.DeadlineExceeded,
+					codes.Unavailable,
+				}, gax.Backoff{
+					Initial:    200 * time.Millisecond,
+					Max:        10000 * time.Millisecond,
+					Multiplier: 1.30,
+				})
+			}),
+		},
 		ListApiVersions: []gax.CallOption{
 			gax.WithRetry(func() gax.Retryer {
 				return gax.OnCodes([]codes.Code{
@@ -231,6 +272,20 @@ func defaultRegistryCallOptions() *RegistryCallOptions {
 				})
 			}),
 		},
+		UndeleteApiVersion: []gax.CallOption{
+			gax.WithRetry(func() gax.Retryer {
+				return gax.OnCodes([]codes.Code{
+					codes.Aborted,
+					codes.Canceled,
+					codes.DeadlineExceeded,
+					codes.Unavailable,
+				}, gax.Backoff{
+					Initial:    200 * time.Millisecond,
+					Max:        10000 * time.Millisecond,
+					Multiplier: 1.30,
+				})
+			}),
+		},
 		ListApiSpecs: []gax.CallOption{
 			gax.WithRetry(func() gax.Retryer {
 				return gax.OnCodes([]codes.Code{
@@ -315,6 +370,20 @@ func defaultRegistryCallOptions() *RegistryCallOptions {
 				})
 			}),
 		},
+		UndeleteApiSpec: []gax.CallOption{
+			gax.WithRetry(func() gax.Retryer {
+				return gax.OnCodes([]codes.Code{
+					codes.Aborted,
+					codes.Canceled,
+					codes.DeadlineExceeded,
+					codes.Unavailable,
+				}, gax.Backoff{
+					Initial:    200 * time.Millisecond,
+					Max:        10000 * time.Millisecond,
+					Multiplier: 1.30,
+				})
+			}),
+		},
 		TagApiSpecRevision: []gax.CallOption{
 			gax.WithRetry(func() gax.Retryer {
 				return gax.OnCodes([]codes.Code{
@@ -441,6 +510,20 @@ func defaultRegistryCallOptions() *RegistryCallOptions {
 				})
 			}),
 		},
+		UndeleteApiDeployment: []gax.CallOption{
+			gax.WithRetry(func() gax.Retryer {
+				return gax.OnCodes([]codes.Code{
+					codes.Aborted,
+					codes.Canceled,
+					codes.DeadlineExceeded,
+					codes.Unavailable,
+				}, gax.Backoff{
+					Initial:    200 * time.Millisecond,
+					Max:        10000 * time.Millisecond,
+					Multiplier: 1.30,
+				})
+			}),
+		},
 		TagApiDeploymentRevision: []gax.CallOption{
 			gax.WithRetry(func() gax.Retryer {
 				return gax.OnCodes([]codes.Code{
@@ -581,366 +664,1639 @@ func defaultRegistryCallOptions() *RegistryCallOptions {
 				})
 			}),
 		},
-	}
-}
-
-// internalRegistryClient is an interface that defines the methods available from .
-type internalRegistryClient interface {
-	Close() error
-	setGoogleClientInfo(...string)
-	Connection() *grpc.ClientConn
-	ListApis(context.Context, *rpcpb.ListApisRequest, ...gax.CallOption) *ApiIterator
-	GetApi(context.Context, *rpcpb.GetApiRequest, ...gax.CallOption) (*rpcpb.Api, error)
-	CreateApi(context.Context, *rpcpb.CreateApiRequest, ...gax.CallOption) (*rpcpb.Api, error)
-	UpdateApi(context.Context, *rpcpb.UpdateApiRequest, ...gax.CallOption) (*rpcpb.Api, error)
-	DeleteApi(context.Context, *rpcpb.DeleteApiRequest, ...gax.CallOption) error
-	ListApiVersions(context.Context, *rpcpb.ListApiVersionsRequest, ...gax.CallOption) *ApiVersionIterator
-	GetApiVersion(context.Context, *rpcpb.GetApiVersionRequest, ...gax.CallOption) (*rpcpb.ApiVersion, error)
-	CreateApiVersion(context.Context, *rpcpb.CreateApiVersionRequest, ...gax.CallOption) (*rpcpb.ApiVersion, error)
-	UpdateApiVersion(context.Context, *rpcpb.UpdateApiVersionRequest, ...gax.CallOption) (*rpcpb.ApiVersion, error)
-	DeleteApiVersion(context.Context, *rpcpb.DeleteApiVersionRequest, ...gax.CallOption) error
-	ListApiSpecs(context.Context, *rpcpb.ListApiSpecsRequest, ...gax.CallOption) *ApiSpecIterator
-	GetApiSpec(context.Context, *rpcpb.GetApiSpecRequest, ...gax.CallOption) (*rpcpb.ApiSpec, error)
-	GetApiSpecContents(context.Context, *rpcpb.GetApiSpecContentsRequest, ...gax.CallOption) (*httpbodypb.HttpBody, error)
-	CreateApiSpec(context.Context, *rpcpb.CreateApiSpecRequest, ...gax.CallOption) (*rpcpb.ApiSpec, error)
-	UpdateApiSpec(context.Context, *rpcpb.UpdateApiSpecRequest, ...gax.CallOption) (*rpcpb.ApiSpec, error)
-	DeleteApiSpec(context.Context, *rpcpb.DeleteApiSpecRequest, ...gax.CallOption) error
-	TagApiSpecRevision(context.Context, *rpcpb.TagApiSpecRevisionRequest, ...gax.CallOption) (*rpcpb.ApiSpec, error)
-	ListApiSpecRevisions(context.Context, *rpcpb.ListApiSpecRevisionsRequest, ...gax.CallOption) *ApiSpecIterator
-	RollbackApiSpec(context.Context, *rpcpb.RollbackApiSpecRequest, ...gax.CallOption) (*rpcpb.ApiSpec, error)
-	DeleteApiSpecRevision(context.Context, *rpcpb.DeleteApiSpecRevisionRequest, ...gax.CallOption) (*rpcpb.ApiSpec, error)
-	ListApiDeployments(context.Context, *rpcpb.ListApiDeploymentsRequest, ...gax.CallOption) *ApiDeploymentIterator
-	GetApiDeployment(context.Context, *rpcpb.GetApiDeploymentRequest, ...gax.CallOption) (*rpcpb.ApiDeployment, error)
-	CreateApiDeployment(context.Context, *rpcpb.CreateApiDeploymentRequest, ...gax.CallOption) (*rpcpb.ApiDeployment, error)
-	UpdateApiDeployment(context.Context, *rpcpb.UpdateApiDeploymentRequest, ...gax.CallOption) (*rpcpb.ApiDeployment, error)
-	DeleteApiDeployment(context.Context, *rpcpb.DeleteApiDeploymentRequest, ...gax.CallOption) error
-	TagApiDeploymentRevision(context.Context, *rpcpb.TagApiDeploymentRevisionRequest, ...gax.CallOption) (*rpcpb.ApiDeployment, error)
-	ListApiDeploymentRevisions(context.Context, *rpcpb.ListApiDeploymentRevisionsRequest, ...gax.CallOption) *ApiDeploymentIterator
-	RollbackApiDeployment(context.Context, *rpcpb.RollbackApiDeploymentRequest, ...gax.CallOption) (*rpcpb.ApiDeployment, error)
-	DeleteApiDeploymentRevision(context.Context, *rpcpb.DeleteApiDeploymentRevisionRequest, ...gax.CallOption) (*rpcpb.ApiDeployment, error)
-	ListArtifacts(context.Context, *rpcpb.ListArtifactsRequest, ...gax.CallOption) *ArtifactIterator
-	GetArtifact(context.Context, *rpcpb.GetArtifactRequest, ...gax.CallOption) (*rpcpb.Artifact, error)
-	GetArtifactContents(context.Context, *rpcpb.GetArtifactContentsRequest, ...gax.CallOption) (*httpbodypb.HttpBody, error)
-	CreateArtifact(context.Context, *rpcpb.CreateArtifactRequest, ...gax.CallOption) (*rpcpb.Artifact, error)
-	ReplaceArtifact(context.Context, *rpcpb.ReplaceArtifactRequest, ...gax.CallOption) (*rpcpb.Artifact, error)
-	DeleteArtifact(context.Context, *rpcpb.DeleteArtifactRequest, ...gax.CallOption) error
-}
-
-// RegistryClient is a client for interacting with .
-// Methods, except Close, may be called concurrently. However, fields must not be modified concurrently with method calls.
-//
-// The Registry service allows teams to manage descriptions of APIs.
-type RegistryClient struct {
-	// The internal transport-dependent client.
-	internalClient internalRegistryClient
-
-	// The call options for this service.
-	CallOptions *RegistryCallOptions
-}
-
-// Wrapper methods routed to the internal client.
-
-// Close closes the connection to the API service. The user should invoke this when
-// the client is no longer required.
-func (c *RegistryClient) Close() error {
-	return c.internalClient.Close()
-}
-
-// setGoogleClientInfo sets the name and version of the application in
-// the `x-goog-api-client` header passed on each request. Intended for
-// use by Google-written clients.
-func (c *RegistryClient) setGoogleClientInfo(keyval ...string) {
-	c.internalClient.setGoogleClientInfo(keyval...)
-}
-
-// Connection returns a connection to the API service.
-//
-// Deprecated: Connections are now pooled so this method does not always
-// return the same resource.
-func (c *RegistryClient) Connection() *grpc.ClientConn {
-	return c.internalClient.Connection()
-}
-
-// ListApis listApis returns matching APIs.
-func (c *RegistryClient) ListApis(ctx context.Context, req *rpcpb.ListApisRequest, opts ...gax.CallOption) *ApiIterator {
-	return c.internalClient.ListApis(ctx, req, opts...)
-}
-
-// GetApi getApi returns a specified API.
-func (c *RegistryClient) GetApi(ctx context.Context, req *rpcpb.GetApiRequest, opts ...gax.CallOption) (*rpcpb.Api, error) {
-	return c.internalClient.GetApi(ctx, req, opts...)
-}
-
-// CreateApi createApi creates a specified API.
-func (c *RegistryClient) CreateApi(ctx context.Context, req *rpcpb.CreateApiRequest, opts ...gax.CallOption) (*rpcpb.Api, error) {
-	return c.internalClient.CreateApi(ctx, req, opts...)
-}
-
-// UpdateApi updateApi can be used to modify a specified API.
-func (c *RegistryClient) UpdateApi(ctx context.Context, req *rpcpb.UpdateApiRequest, opts ...gax.CallOption) (*rpcpb.Api, error) {
-	return c.internalClient.UpdateApi(ctx, req, opts...)
-}
-
-// DeleteApi deleteApi removes a specified API and all of the resources that it
-// owns.
-func (c *RegistryClient) DeleteApi(ctx context.Context, req *rpcpb.DeleteApiRequest, opts ...gax.CallOption) error {
-	return c.internalClient.DeleteApi(ctx, req, opts...)
-}
-
-// ListApiVersions listApiVersions returns matching versions.
-func (c *RegistryClient) ListApiVersions(ctx context.Context, req *rpcpb.ListApiVersionsRequest, opts ...gax.CallOption) *ApiVersionIterator {
-	return c.internalClient.ListApiVersions(ctx, req, opts...)
-}
-
-// GetApiVersion getApiVersion returns a specified version.
-func (c *RegistryClient) GetApiVersion(ctx context.Context, req *rpcpb.GetApiVersionRequest, opts ...gax.CallOption) (*rpcpb.ApiVersion, error) {
-	return c.internalClient.GetApiVersion(ctx, req, opts...)
-}
-
-// CreateApiVersion createApiVersion creates a specified version.
-func (c *RegistryClient) CreateApiVersion(ctx context.Context, req *rpcpb.CreateApiVersionRequest, opts ...gax.CallOption) (*rpcpb.ApiVersion, error) {
-	return c.internalClient.CreateApiVersion(ctx, req, opts...)
-}
-
-// UpdateApiVersion updateApiVersion can be used to modify a specified version.
-func (c *RegistryClient) UpdateApiVersion(ctx context.Context, req *rpcpb.UpdateApiVersionRequest, opts ...gax.CallOption) (*rpcpb.ApiVersion, error) {
-	return c.internalClient.UpdateApiVersion(ctx, req, opts...)
-}
-
-// DeleteApiVersion deleteApiVersion removes a specified version and all of the resources that
-// it owns.
-func (c *RegistryClient) DeleteApiVersion(ctx context.Context, req *rpcpb.DeleteApiVersionRequest, opts ...gax.CallOption) error {
-	return c.internalClient.DeleteApiVersion(ctx, req, opts...)
-}
-
-// ListApiSpecs listApiSpecs returns matching specs.
-func (c *RegistryClient) ListApiSpecs(ctx context.Context, req *rpcpb.ListApiSpecsRequest, opts ...gax.CallOption) *ApiSpecIterator {
-	return c.internalClient.ListApiSpecs(ctx, req, opts...)
-}
-
-// GetApiSpec getApiSpec returns a specified spec.
-func (c *RegistryClient) GetApiSpec(ctx context.Context, req *rpcpb.GetApiSpecRequest, opts ...gax.CallOption) (*rpcpb.ApiSpec, error) {
-	return c.internalClient.GetApiSpec(ctx, req, opts...)
-}
-
-// GetApiSpecContents getApiSpecContents returns the contents of a specified spec.
-// If specs are stored with GZip compression, the default behavior
-// is to return the spec uncompressed (the mime_type response field
-// indicates the exact format returned).
-func (c *RegistryClient) GetApiSpecContents(ctx context.Context, req *rpcpb.GetApiSpecContentsRequest, opts ...gax.CallOption) (*httpbodypb.HttpBody, error) {
-	return c.internalClient.GetApiSpecContents(ctx, req, opts...)
-}
-
-// CreateApiSpec createApiSpec creates a specified spec.
-func (c *RegistryClient) CreateApiSpec(ctx context.Context, req *rpcpb.CreateApiSpecRequest, opts ...gax.CallOption) (*rpcpb.ApiSpec, error) {
-	return c.internalClient.CreateApiSpec(ctx, req, opts...)
-}
-
-// UpdateApiSpec updateApiSpec can be used to modify a specified spec.
-func (c *RegistryClient) UpdateApiSpec(ctx context.Context, req *rpcpb.UpdateApiSpecRequest, opts ...gax.CallOption) (*rpcpb.ApiSpec, error) {
-	return c.internalClient.UpdateApiSpec(ctx, req, opts...)
-}
-
-// DeleteApiSpec deleteApiSpec removes a specified spec, all revisions, and all child
-// resources (e.g. artifacts).
-func (c *RegistryClient) DeleteApiSpec(ctx context.Context, req *rpcpb.DeleteApiSpecRequest, opts ...gax.CallOption) error {
-	return c.internalClient.DeleteApiSpec(ctx, req, opts...)
-}
-
-// TagApiSpecRevision tagApiSpecRevision adds a tag to a specified revision of a spec.
-func (c *RegistryClient) TagApiSpecRevision(ctx context.Context, req *rpcpb.TagApiSpecRevisionRequest, opts ...gax.CallOption) (*rpcpb.ApiSpec, error) {
-	return c.internalClient.TagApiSpecRevision(ctx, req, opts...)
-}
-
-// ListApiSpecRevisions listApiSpecRevisions lists all revisions of a spec.
-// Revisions are returned in descending order of revision creation time.
-func (c *RegistryClient) ListApiSpecRevisions(ctx context.Context, req *rpcpb.ListApiSpecRevisionsRequest, opts ...gax.CallOption) *ApiSpecIterator {
-	return c.internalClient.ListApiSpecRevisions(ctx, req, opts...)
-}
-
-// RollbackApiSpec rollbackApiSpec sets the current revision to a specified prior revision.
-// Note that this creates a new revision with a new revision ID.
-func (c *RegistryClient) RollbackApiSpec(ctx context.Context, req *rpcpb.RollbackApiSpecRequest, opts ...gax.CallOption) (*rpcpb.ApiSpec, error) {
-	return c.internalClient.RollbackApiSpec(ctx, req, opts...)
-}
-
-// DeleteApiSpecRevision deleteApiSpecRevision deletes a revision of a spec.
-func (c *RegistryClient) DeleteApiSpecRevision(ctx context.Context, req *rpcpb.DeleteApiSpecRevisionRequest, opts ...gax.CallOption) (*rpcpb.ApiSpec, error) {
-	return c.internalClient.DeleteApiSpecRevision(ctx, req, opts...)
-}
-
-// ListApiDeployments listApiDeployments returns matching deployments.
-func (c *RegistryClient) ListApiDeployments(ctx context.Context, req *rpcpb.ListApiDeploymentsRequest, opts ...gax.CallOption) *ApiDeploymentIterator {
-	return c.internalClient.ListApiDeployments(ctx, req, opts...)
-}
-
-// GetApiDeployment getApiDeployment returns a specified deployment.
-func (c *RegistryClient) GetApiDeployment(ctx context.Context, req *rpcpb.GetApiDeploymentRequest, opts ...gax.CallOption) (*rpcpb.ApiDeployment, error) {
-	return c.internalClient.GetApiDeployment(ctx, req, opts...)
-}
-
-// CreateApiDeployment createApiDeployment creates a specified deployment.
-func (c *RegistryClient) CreateApiDeployment(ctx context.Context, req *rpcpb.CreateApiDeploymentRequest, opts ...gax.CallOption) (*rpcpb.ApiDeployment, error) {
-	return c.internalClient.CreateApiDeployment(ctx, req, opts...)
-}
-
-// UpdateApiDeployment updateApiDeployment can be used to modify a specified deployment.
+		UndeleteArtifact: []gax.CallOption{
+			gax.WithRetry(func() gax.Retryer {
+				return gax.OnCodes([]codes.Code{
+					codes.Aborted,
+					codes.Canceled,
+					codes.DeadlineExceeded,
+					codes.Unavailable,
+				}, gax.Backoff{
+					Initial:    200 * time.Millisecond,
+					Max:        10000 * time.Millisecond,
+					Multiplier: 1.30,
+				})
+			}),
+		},
+		TagArtifactRevision: []gax.CallOption{
+			gax.WithRetry(func() gax.Retryer {
+				return gax.OnCodes([]codes.Code{
+					codes.Aborted,
+					codes.Canceled,
+					codes.DeadlineExceeded,
+					codes.Unavailable,
+				}, gax.Backoff{
+					Initial:    200 * time.Millisecond,
+					Max:        10000 * time.Millisecond,
+					Multiplier: 1.30,
+				})
+			}),
+		},
+		ListArtifactRevisions: []gax.CallOption{
+			gax.WithRetry(func() gax.Retryer {
+				return gax.OnCodes([]codes.Code{
+					codes.Aborted,
+					codes.Canceled,
+					codes.DeadlineExceeded,
+					codes.Unavailable,
+				}, gax.Backoff{
+					Initial:    200 * time.Millisecond,
+					Max:        10000 * time.Millisecond,
+					Multiplier: 1.30,
+				})
+			}),
+		},
+		RollbackArtifact: []gax.CallOption{
+			gax.WithRetry(func() gax.Retryer {
+				return gax.OnCodes([]codes.Code{
+					codes.Aborted,
+					codes.Canceled,
+					codes.DeadlineExceeded,
+					codes.Unavailable,
+				}, gax.Backoff{
+					Initial:    200 * time.Millisecond,
+					Max:        10000 * time.Millisecond,
+					Multiplier: 1.30,
+				})
+			}),
+		},
+		BatchCreateApis: []gax.CallOption{
+			gax.WithRetry(func() gax.Retryer {
+				return gax.OnCodes([]codes.Code{
+					codes.Aborted,
+					codes.Canceled,
+					codes.DeadlineExceeded,
+					codes.Unavailable,
+				}, gax.Backoff{
+					Initial:    200 * time.Millisecond,
+					Max:        10000 * time.Millisecond,
+					Multiplier: 1.30,
+				})
+			}),
+		},
+		BatchUpdateApis: []gax.CallOption{
+			gax.WithRetry(func() gax.Retryer {
+				return gax.OnCodes([]codes.Code{
+					codes.Aborted,
+					codes.Canceled,
+					codes.DeadlineExceeded,
+					codes.Unavailable,
+				}, gax.Backoff{
+					Initial:    200 * time.Millisecond,
+					Max:        10000 * time.Millisecond,
+					Multiplier: 1.30,
+				})
+			}),
+		},
+		BatchDeleteApis: []gax.CallOption{
+			gax.WithRetry(func() gax.Retryer {
+				return gax.OnCodes([]codes.Code{
+					codes.Aborted,
+					codes.Canceled,
+					codes.DeadlineExceeded,
+					codes.Unavailable,
+				}, gax.Backoff{
+					Initial:    200 * time.Millisecond,
+					Max:        10000 * time.Millisecond,
+					Multiplier: 1.30,
+				})
+			}),
+		},
+		BatchCreateApiVersions: []gax.CallOption{
+			gax.WithRetry(func() gax.Retryer {
+				return gax.OnCodes([]codes.Code{
+					codes.Aborted,
+					codes.Canceled,
+					codes.DeadlineExceeded,
+					codes.Unavailable,
+				}, gax.Backoff{
+					Initial:    200 * time.Millisecond,
+					Max:        10000 * time.Millisecond,
+					Multiplier: 1.30,
+				})
+			}),
+		},
+		BatchUpdateApiVersions: []gax.CallOption{
+			gax.WithRetry(func() gax.Retryer {
+				return gax.OnCodes([]codes.Code{
+					codes.Aborted,
+					codes.Canceled,
+					codes.DeadlineExceeded,
+					codes.Unavailable,
+				}, gax.Backoff{
+					Initial:    200 * time.Millisecond,
+					Max:        10000 * time.Millisecond,
+					Multiplier: 1.30,
+				})
+			}),
+		},
+		BatchDeleteApiVersions: []gax.CallOption{
+			gax.WithRetry(func() gax.Retryer {
+				return gax.OnCodes([]codes.Code{
+					codes.Aborted,
+					codes.Canceled,
+					codes.DeadlineExceeded,
+					codes.Unavailable,
+				}, gax.Backoff{
+					Initial:    200 * time.Millisecond,
+					Max:        10000 * time.Millisecond,
+					Multiplier: 1.30,
+				})
+			}),
+		},
+		BatchCreateApiSpecs: []gax.CallOption{
+			gax.WithRetry(func() gax.Retryer {
+				return gax.OnCodes([]codes.Code{
+					codes.Aborted,
+					codes.Canceled,
+					codes.DeadlineExceeded,
+					codes.Unavailable,
+				}, gax.Backoff{
+					Initial:    200 * time.Millisecond,
+					Max:        10000 * time.Millisecond,
+					Multiplier: 1.30,
+				})
+			}),
+		},
+		BatchUpdateApiSpecs: []gax.CallOption{
+			gax.WithRetry(func() gax.Retryer {
+				return gax.OnCodes([]codes.Code{
+					codes.Aborted,
+					codes.Canceled,
+					codes.DeadlineExceeded,
+					codes.Unavailable,
+				}, gax.Backoff{
+					Initial:    200 * time.Millisecond,
+					Max:        10000 * time.Millisecond,
+					Multiplier: 1.30,
+				})
+			}),
+		},
+		BatchDeleteApiSpecs: []gax.CallOption{
+			gax.WithRetry(func() gax.Retryer {
+				return gax.OnCodes([]codes.Code{
+					codes.Aborted,
+					codes.Canceled,
+					codes.DeadlineExceeded,
+					codes.Unavailable,
+				}, gax.Backoff{
+					Initial:    200 * time.Millisecond,
+					Max:        10000 * time.Millisecond,
+					Multiplier: 1.30,
+				})
+			}),
+		},
+		BatchCreateApiDeployments: []gax.CallOption{
+			gax.WithRetry(func() gax.Retryer {
+				return gax.OnCodes([]codes.Code{
+					codes.Aborted,
+					codes.Canceled,
+					codes.DeadlineExceeded,
+					codes.Unavailable,
+				}, gax.Backoff{
+					Initial:    200 * time.Millisecond,
+					Max:        10000 * time.Millisecond,
+					Multiplier: 1.30,
+				})
+			}),
+		},
+		BatchUpdateApiDeployments: []gax.CallOption{
+			gax.WithRetry(func() gax.Retryer {
+				return gax.OnCodes([]codes.Code{
+					codes.Aborted,
+					codes.Canceled,
+					codes.DeadlineExceeded,
+					codes.Unavailable,
+				}, gax.Backoff{
+					Initial:    200 * time.Millisecond,
+					Max:        10000 * time.Millisecond,
+					Multiplier: 1.30,
+				})
+			}),
+		},
+		BatchDeleteApiDeployments: []gax.CallOption{
+			gax.WithRetry(func() gax.Retryer {
+				return gax.OnCodes([]codes.Code{
+					codes.Aborted,
+					codes.Canceled,
+					codes.DeadlineExceeded,
+					codes.Unavailable,
+				}, gax.Backoff{
+					Initial:    200 * time.Millisecond,
+					Max:        10000 * time.Millisecond,
+					Multiplier: 1.30,
+				})
+			}),
+		},
+		BatchCreateArtifacts: []gax.CallOption{
+			gax.WithRetry(func() gax.Retryer {
+				return gax.OnCodes([]codes.Code{
+					codes.Aborted,
+					codes.Canceled,
+					codes.DeadlineExceeded,
+					codes.Unavailable,
+				}, gax.Backoff{
+					Initial:    200 * time.Millisecond,
+					Max:        10000 * time.Millisecond,
+					Multiplier: 1.30,
+				})
+			}),
+		},
+		BatchReplaceArtifacts: []gax.CallOption{
+			gax.WithRetry(func() gax.Retryer {
+				return gax.OnCodes([]codes.Code{
+					codes.Aborted,
+					codes.Canceled,
+					codes.DeadlineExceeded,
+					codes.Unavailable,
+				}, gax.Backoff{
+					Initial:    200 * time.Millisecond,
+					Max:        10000 * time.Millisecond,
+					Multiplier: 1.30,
+				})
+			}),
+		},
+		BatchDeleteArtifacts: []gax.CallOption{
+			gax.WithRetry(func() gax.Retryer {
+				return gax.OnCodes([]codes.Code{
+					codes.Aborted,
+					codes.Canceled,
+					codes.DeadlineExceeded,
+					codes.Unavailable,
+				}, gax.Backoff{
+					Initial:    200 * time.Millisecond,
+					Max:        10000 * time.Millisecond,
+					Multiplier: 1.30,
+				})
+			}),
+		},
+		ListReferences: []gax.CallOption{
+			gax.WithRetry(func() gax.Retryer {
+				return gax.OnCodes([]codes.Code{
+					codes.Aborted,
+					codes.Canceled,
+					codes.DeadlineExceeded,
+					codes.Unavailable,
+				}, gax.Backoff{
+					Initial:    200 * time.Millisecond,
+					Max:        10000 * time.Millisecond,
+					Multiplier: 1.30,
+				})
+			}),
+		},
+		ListReferrers: []gax.CallOption{
+			gax.WithRetry(func() gax.Retryer {
+				return gax.OnCodes([]codes.Code{
+					codes.Aborted,
+					codes.Canceled,
+					codes.DeadlineExceeded,
+					codes.Unavailable,
+				}, gax.Backoff{
+					Initial:    200 * time.Millisecond,
+					Max:        10000 * time.Millisecond,
+					Multiplier: 1.30,
+				})
+			}),
+		},
+		SearchResources: []gax.CallOption{
+			gax.WithRetry(func() gax.Retryer {
+				return gax.OnCodes([]codes.Code{
+					codes.Aborted,
+					codes.Canceled,
+					codes.DeadlineExceeded,
+					codes.Unavailable,
+				}, gax.Backoff{
+					Initial:    200 * time.Millisecond,
+					Max:        10000 * time.Millisecond,
+					Multiplier: 1.30,
+				})
+			}),
+		},
+		ListSpecElements: []gax.CallOption{
+			gax.WithRetry(func() gax.Retryer {
+				return gax.OnCodes([]codes.Code{
+					codes.Aborted,
+					codes.Canceled,
+					codes.DeadlineExceeded,
+					codes.Unavailable,
+				}, gax.Backoff{
+					Initial:    200 * time.Millisecond,
+					Max:        10000 * time.Millisecond,
+					Multiplier: 1.30,
+				})
+			}),
+		},
+	}
+}
+
+// internalRegistryClient is an interface that defines the methods available from .
+type internalRegistryClient interface {
+	Close() error
+	setGoogleClientInfo(...string)
+	Connection() *grpc.ClientConn
+	ListApis(context.Context, *rpcpb.ListApisRequest, ...gax.CallOption) *ApiIterator
+	GetApi(context.Context, *rpcpb.GetApiRequest, ...gax.CallOption) (*rpcpb.Api, error)
+	CreateApi(context.Context, *rpcpb.CreateApiRequest, ...gax.CallOption) (*rpcpb.Api, error)
+	UpdateApi(context.Context, *rpcpb.UpdateApiRequest, ...gax.CallOption) (*rpcpb.Api, error)
+	DeleteApi(context.Context, *rpcpb.DeleteApiRequest, ...gax.CallOption) error
+	UndeleteApi(context.Context, *rpcpb.UndeleteApiRequest, ...gax.CallOption) (*rpcpb.Api, error)
+	ListApiVersions(context.Context, *rpcpb.ListApiVersionsRequest, ...gax.CallOption) *ApiVersionIterator
+	GetApiVersion(context.Context, *rpcpb.GetApiVersionRequest, ...gax.CallOption) (*rpcpb.ApiVersion, error)
+	CreateApiVersion(context.Context, *rpcpb.CreateApiVersionRequest, ...gax.CallOption) (*rpcpb.ApiVersion, error)
+	UpdateApiVersion(context.Context, *rpcpb.UpdateApiVersionRequest, ...gax.CallOption) (*rpcpb.ApiVersion, error)
+	DeleteApiVersion(context.Context, *rpcpb.DeleteApiVersionRequest, ...gax.CallOption) error
+	UndeleteApiVersion(context.Context, *rpcpb.UndeleteApiVersionRequest, ...gax.CallOption) (*rpcpb.ApiVersion, error)
+	ListApiSpecs(context.Context, *rpcpb.ListApiSpecsRequest, ...gax.CallOption) *ApiSpecIterator
+	GetApiSpec(context.Context, *rpcpb.GetApiSpecRequest, ...gax.CallOption) (*rpcpb.ApiSpec, error)
+	GetApiSpecContents(context.Context, *rpcpb.GetApiSpecContentsRequest, ...gax.CallOption) (*httpbodypb.HttpBody, error)
+	CreateApiSpec(context.Context, *rpcpb.CreateApiSpecRequest, ...gax.CallOption) (*rpcpb.ApiSpec, error)
+	UpdateApiSpec(context.Context, *rpcpb.UpdateApiSpecRequest, ...gax.CallOption) (*rpcpb.ApiSpec, error)
+	DeleteApiSpec(context.Context, *rpcpb.DeleteApiSpecRequest, ...gax.CallOption) error
+	UndeleteApiSpec(context.Context, *rpcpb.UndeleteApiSpecRequest, ...gax.CallOption) (*rpcpb.ApiSpec, error)
+	TagApiSpecRevision(context.Context, *rpcpb.TagApiSpecRevisionRequest, ...gax.CallOption) (*rpcpb.ApiSpec, error)
+	ListApiSpecRevisions(context.Context, *rpcpb.ListApiSpecRevisionsRequest, ...gax.CallOption) *ApiSpecIterator
+	RollbackApiSpec(context.Context, *rpcpb.RollbackApiSpecRequest, ...gax.CallOption) (*rpcpb.ApiSpec, error)
+	DeleteApiSpecRevision(context.Context, *rpcpb.DeleteApiSpecRevisionRequest, ...gax.CallOption) (*rpcpb.ApiSpec, error)
+	ListApiDeployments(context.Context, *rpcpb.ListApiDeploymentsRequest, ...gax.CallOption) *ApiDeploymentIterator
+	GetApiDeployment(context.Context, *rpcpb.GetApiDeploymentRequest, ...gax.CallOption) (*rpcpb.ApiDeployment, error)
+	CreateApiDeployment(context.Context, *rpcpb.CreateApiDeploymentRequest, ...gax.CallOption) (*rpcpb.ApiDeployment, error)
+	UpdateApiDeployment(context.Context, *rpcpb.UpdateApiDeploymentRequest, ...gax.CallOption) (*rpcpb.ApiDeployment, error)
+	DeleteApiDeployment(context.Context, *rpcpb.DeleteApiDeploymentRequest, ...gax.CallOption) error
+	UndeleteApiDeployment(context.Context, *rpcpb.UndeleteApiDeploymentRequest, ...gax.CallOption) (*rpcpb.ApiDeployment, error)
+	TagApiDeploymentRevision(context.Context, *rpcpb.TagApiDeploymentRevisionRequest, ...gax.CallOption) (*rpcpb.ApiDeployment, error)
+	ListApiDeploymentRevisions(context.Context, *rpcpb.ListApiDeploymentRevisionsRequest, ...gax.CallOption) *ApiDeploymentIterator
+	RollbackApiDeployment(context.Context, *rpcpb.RollbackApiDeploymentRequest, ...gax.CallOption) (*rpcpb.ApiDeployment, error)
+	DeleteApiDeploymentRevision(context.Context, *rpcpb.DeleteApiDeploymentRevisionRequest, ...gax.CallOption) (*rpcpb.ApiDeployment, error)
+	ListArtifacts(context.Context, *rpcpb.ListArtifactsRequest, ...gax.CallOption) *ArtifactIterator
+	GetArtifact(context.Context, *rpcpb.GetArtifactRequest, ...gax.CallOption) (*rpcpb.Artifact, error)
+	GetArtifactContents(context.Context, *rpcpb.GetArtifactContentsRequest, ...gax.CallOption) (*httpbodypb.HttpBody, error)
+	CreateArtifact(context.Context, *rpcpb.CreateArtifactRequest, ...gax.CallOption) (*rpcpb.Artifact, error)
+	ReplaceArtifact(context.Context, *rpcpb.ReplaceArtifactRequest, ...gax.CallOption) (*rpcpb.Artifact, error)
+	DeleteArtifact(context.Context, *rpcpb.DeleteArtifactRequest, ...gax.CallOption) error
+	UndeleteArtifact(context.Context, *rpcpb.UndeleteArtifactRequest, ...gax.CallOption) (*rpcpb.Artifact, error)
+	TagArtifactRevision(context.Context, *rpcpb.TagArtifactRevisionRequest, ...gax.CallOption) (*rpcpb.Artifact, error)
+	ListArtifactRevisions(context.Context, *rpcpb.ListArtifactRevisionsRequest, ...gax.CallOption) *ArtifactIterator
+	RollbackArtifact(context.Context, *rpcpb.RollbackArtifactRequest, ...gax.CallOption) (*rpcpb.Artifact, error)
+	BatchCreateApis(context.Context, *rpcpb.BatchCreateApisRequest, ...gax.CallOption) (*rpcpb.BatchCreateApisResponse, error)
+	BatchUpdateApis(context.Context, *rpcpb.BatchUpdateApisRequest, ...gax.CallOption) (*rpcpb.BatchUpdateApisResponse, error)
+	BatchDeleteApis(context.Context, *rpcpb.BatchDeleteApisRequest, ...gax.CallOption) (*rpcpb.BatchDeleteApisResponse, error)
+	BatchCreateApiVersions(context.Context, *rpcpb.BatchCreateApiVersionsRequest, ...gax.CallOption) (*rpcpb.BatchCreateApiVersionsResponse, error)
+	BatchUpdateApiVersions(context.Context, *rpcpb.BatchUpdateApiVersionsRequest, ...gax.CallOption) (*rpcpb.BatchUpdateApiVersionsResponse, error)
+	BatchDeleteApiVersions(context.Context, *rpcpb.BatchDeleteApiVersionsRequest, ...gax.CallOption) (*rpcpb.BatchDeleteApiVersionsResponse, error)
+	BatchCreateApiSpecs(context.Context, *rpcpb.BatchCreateApiSpecsRequest, ...gax.CallOption) (*rpcpb.BatchCreateApiSpecsResponse, error)
+	BatchUpdateApiSpecs(context.Context, *rpcpb.BatchUpdateApiSpecsRequest, ...gax.CallOption) (*rpcpb.BatchUpdateApiSpecsResponse, error)
+	BatchDeleteApiSpecs(context.Context, *rpcpb.BatchDeleteApiSpecsRequest, ...gax.CallOption) (*rpcpb.BatchDeleteApiSpecsResponse, error)
+	BatchCreateApiDeployments(context.Context, *rpcpb.BatchCreateApiDeploymentsRequest, ...gax.CallOption) (*rpcpb.BatchCreateApiDeploymentsResponse, error)
+	BatchUpdateApiDeployments(context.Context, *rpcpb.BatchUpdateApiDeploymentsRequest, ...gax.CallOption) (*rpcpb.BatchUpdateApiDeploymentsResponse, error)
+	BatchDeleteApiDeployments(context.Context, *rpcpb.BatchDeleteApiDeploymentsRequest, ...gax.CallOption) (*rpcpb.BatchDeleteApiDeploymentsResponse, error)
+	BatchCreateArtifacts(context.Context, *rpcpb.BatchCreateArtifactsRequest, ...gax.CallOption) (*rpcpb.BatchCreateArtifactsResponse, error)
+	BatchReplaceArtifacts(context.Context, *rpcpb.BatchReplaceArtifactsRequest, ...gax.CallOption) (*rpcpb.BatchReplaceArtifactsResponse, error)
+	BatchDeleteArtifacts(context.Context, *rpcpb.BatchDeleteArtifactsRequest, ...gax.CallOption) (*rpcpb.BatchDeleteArtifactsResponse, error)
+	ListReferences(context.Context, *rpcpb.ListReferencesRequest, ...gax.CallOption) *ReferenceIterator
+	ListReferrers(context.Context, *rpcpb.ListReferrersRequest, ...gax.CallOption) *ReferenceIterator
+	SearchResources(context.Context, *rpcpb.SearchResourcesRequest, ...gax.CallOption) *SearchResultIterator
+	ListSpecElements(context.Context, *rpcpb.ListSpecElementsRequest, ...gax.CallOption) *SpecElementIterator
+}
+
+// RegistryClient is a client for interacting with .
+// Methods, except Close, may be called concurrently. However, fields must not be modified concurrently with method calls.
+//
+// The Registry service allows teams to manage descriptions of APIs.
+type RegistryClient struct {
+	// The internal transport-dependent client.
+	internalClient internalRegistryClient
+
+	// The call options for this service.
+	CallOptions *RegistryCallOptions
+}
+
+// Wrapper methods routed to the internal client.
+
+// Close closes the connection to the API service. The user should invoke this when
+// the client is no longer required.
+func (c *RegistryClient) Close() error {
+	return c.internalClient.Close()
+}
+
+// setGoogleClientInfo sets the name and version of the application in
+// the `x-goog-api-client` header passed on each request. Intended for
+// use by Google-written clients.
+func (c *RegistryClient) setGoogleClientInfo(keyval ...string) {
+	c.internalClient.setGoogleClientInfo(keyval...)
+}
+
+// Connection returns a connection to the API service.
+//
+// Deprecated: Connections are now pooled so this method does not always
+// return the same resource.
+func (c *RegistryClient) Connection() *grpc.ClientConn {
+	return c.internalClient.Connection()
+}
+
+// ListApis listApis returns matching APIs.
+func (c *RegistryClient) ListApis(ctx context.Context, req *rpcpb.ListApisRequest, opts ...gax.CallOption) *ApiIterator {
+	return c.internalClient.ListApis(ctx, req, opts...)
+}
+
+// GetApi getApi returns a specified API.
+func (c *RegistryClient) GetApi(ctx context.Context, req *rpcpb.GetApiRequest, opts ...gax.CallOption) (*rpcpb.Api, error) {
+	return c.internalClient.GetApi(ctx, req, opts...)
+}
+
+// CreateApi createApi creates a specified API.
+func (c *RegistryClient) CreateApi(ctx context.Context, req *rpcpb.CreateApiRequest, opts ...gax.CallOption) (*rpcpb.Api, error) {
+	return c.internalClient.CreateApi(ctx, req, opts...)
+}
+
+// UpdateApi updateApi can be used to modify a specified API.
+func (c *RegistryClient) UpdateApi(ctx context.Context, req *rpcpb.UpdateApiRequest, opts ...gax.CallOption) (*rpcpb.Api, error) {
+	return c.internalClient.UpdateApi(ctx, req, opts...)
+}
+
+// DeleteApi deleteApi removes a specified API and all of the resources that it
+// owns.
+func (c *RegistryClient) DeleteApi(ctx context.Context, req *rpcpb.DeleteApiRequest, opts ...gax.CallOption) error {
+	return c.internalClient.DeleteApi(ctx, req, opts...)
+}
+
+// UndeleteApi undeleteApi restores a deleted API and the resources that were
+// deleted with it. Deleted resources can be restored until they are purged.
+func (c *RegistryClient) UndeleteApi(ctx context.Context, req *rpcpb.UndeleteApiRequest, opts ...gax.CallOption) (*rpcpb.Api, error) {
+	return c.internalClient.UndeleteApi(ctx, req, opts...)
+}
+
+// ListApiVersions listApiVersions returns matching versions.
+func (c *RegistryClient) ListApiVersions(ctx context.Context, req *rpcpb.ListApiVersionsRequest, opts ...gax.CallOption) *ApiVersionIterator {
+	return c.internalClient.ListApiVersions(ctx, req, opts...)
+}
+
+// GetApiVersion getApiVersion returns a specified version.
+func (c *RegistryClient) GetApiVersion(ctx context.Context, req *rpcpb.GetApiVersionRequest, opts ...gax.CallOption) (*rpcpb.ApiVersion, error) {
+	return c.internalClient.GetApiVersion(ctx, req, opts...)
+}
+
+// CreateApiVersion createApiVersion creates a specified version.
+func (c *RegistryClient) CreateApiVersion(ctx context.Context, req *rpcpb.CreateApiVersionRequest, opts ...gax.CallOption) (*rpcpb.ApiVersion, error) {
+	return c.internalClient.CreateApiVersion(ctx, req, opts...)
+}
+
+// UpdateApiVersion updateApiVersion can be used to modify a specified version.
+func (c *RegistryClient) UpdateApiVersion(ctx context.Context, req *rpcpb.UpdateApiVersionRequest, opts ...gax.CallOption) (*rpcpb.ApiVersion, error) {
+	return c.internalClient.UpdateApiVersion(ctx, req, opts...)
+}
+
+// DeleteApiVersion deleteApiVersion removes a specified version and all of the resources that
+// it owns.
+func (c *RegistryClient) DeleteApiVersion(ctx context.Context, req *rpcpb.DeleteApiVersionRequest, opts ...gax.CallOption) error {
+	return c.internalClient.DeleteApiVersion(ctx, req, opts...)
+}
+
+// UndeleteApiVersion undeleteApiVersion restores a deleted version and the resources that were
+// deleted with it. Deleted resources can be restored until they are purged.
+func (c *RegistryClient) UndeleteApiVersion(ctx context.Context, req *rpcpb.UndeleteApiVersionRequest, opts ...gax.CallOption) (*rpcpb.ApiVersion, error) {
+	return c.internalClient.UndeleteApiVersion(ctx, req, opts...)
+}
+
+// ListApiSpecs listApiSpecs returns matching specs.
+func (c *RegistryClient) ListApiSpecs(ctx context.Context, req *rpcpb.ListApiSpecsRequest, opts ...gax.CallOption) *ApiSpecIterator {
+	return c.internalClient.ListApiSpecs(ctx, req, opts...)
+}
+
+// GetApiSpec getApiSpec returns a specified spec.
+func (c *RegistryClient) GetApiSpec(ctx context.Context, req *rpcpb.GetApiSpecRequest, opts ...gax.CallOption) (*rpcpb.ApiSpec, error) {
+	return c.internalClient.GetApiSpec(ctx, req, opts...)
+}
+
+// GetApiSpecContents getApiSpecContents returns the contents of a specified spec.
+// If specs are stored with GZip compression, the default behavior
+// is to return the spec uncompressed (the mime_type response field
+// indicates the exact format returned).
+func (c *RegistryClient) GetApiSpecContents(ctx context.Context, req *rpcpb.GetApiSpecContentsRequest, opts ...gax.CallOption) (*httpbodypb.HttpBody, error) {
+	return c.internalClient.GetApiSpecContents(ctx, req, opts...)
+}
+
+// CreateApiSpec createApiSpec creates a specified spec.
+func (c *RegistryClient) CreateApiSpec(ctx context.Context, req *rpcpb.CreateApiSpecRequest, opts ...gax.CallOption) (*rpcpb.ApiSpec, error) {
+	return c.internalClient.CreateApiSpec(ctx, req, opts...)
+}
+
+// UpdateApiSpec updateApiSpec can be used to modify a specified spec.
+func (c *RegistryClient) UpdateApiSpec(ctx context.Context, req *rpcpb.UpdateApiSpecRequest, opts ...gax.CallOption) (*rpcpb.ApiSpec, error) {
+	return c.internalClient.UpdateApiSpec(ctx, req, opts...)
+}
+
+// DeleteApiSpec deleteApiSpec removes a specified spec, all revisions, and all child
+// resources (e.g. artifacts).
+func (c *RegistryClient) DeleteApiSpec(ctx context.Context, req *rpcpb.DeleteApiSpecRequest, opts ...gax.CallOption) error {
+	return c.internalClient.DeleteApiSpec(ctx, req, opts...)
+}
+
+// UndeleteApiSpec undeleteApiSpec restores a deleted spec and the resources that were
+// deleted with it. Deleted resources can be restored until they are purged.
+// A revision that was deleted on its own is restored by naming the revision.
+func (c *RegistryClient) UndeleteApiSpec(ctx context.Context, req *rpcpb.UndeleteApiSpecRequest, opts ...gax.CallOption) (*rpcpb.ApiSpec, error) {
+	return c.internalClient.UndeleteApiSpec(ctx, req, opts...)
+}
+
+// TagApiSpecRevision tagApiSpecRevision adds a tag to a specified revision of a spec.
+func (c *RegistryClient) TagApiSpecRevision(ctx context.Context, req *rpcpb.TagApiSpecRevisionRequest, opts ...gax.CallOption) (*rpcpb.ApiSpec, error) {
+	return c.internalClient.TagApiSpecRevision(ctx, req, opts...)
+}
+
+// ListApiSpecRevisions listApiSpecRevisions lists all revisions of a spec.
+// Revisions are returned in descending order of revision creation time.
+func (c *RegistryClient) ListApiSpecRevisions(ctx context.Context, req *rpcpb.ListApiSpecRevisionsRequest, opts ...gax.CallOption) *ApiSpecIterator {
+	return c.internalClient.ListApiSpecRevisions(ctx, req, opts...)
+}
+
+// RollbackApiSpec rollbackApiSpec sets the current revision to a specified prior revision.
+// Note that this creates a new revision with a new revision ID.
+func (c *RegistryClient) RollbackApiSpec(ctx context.Context, req *rpcpb.RollbackApiSpecRequest, opts ...gax.CallOption) (*rpcpb.ApiSpec, error) {
+	return c.internalClient.RollbackApiSpec(ctx, req, opts...)
+}
+
+// DeleteApiSpecRevision deleteApiSpecRevision deletes a revision of a spec.
+func (c *RegistryClient) DeleteApiSpecRevision(ctx context.Context, req *rpcpb.DeleteApiSpecRevisionRequest, opts ...gax.CallOption) (*rpcpb.ApiSpec, error) {
+	return c.internalClient.DeleteApiSpecRevision(ctx, req, opts...)
+}
+
+// ListApiDeployments listApiDeployments returns matching deployments.
+func (c *RegistryClient) ListApiDeployments(ctx context.Context, req *rpcpb.ListApiDeploymentsRequest, opts ...gax.CallOption) *ApiDeploymentIterator {
+	return c.internalClient.ListApiDeployments(ctx, req, opts...)
+}
+
+// GetApiDeployment getApiDeployment returns a specified deployment.
+func (c *RegistryClient) GetApiDeployment(ctx context.Context, req *rpcpb.GetApiDeploymentRequest, opts ...gax.CallOption) (*rpcpb.ApiDeployment, error) {
+	return c.internalClient.GetApiDeployment(ctx, req, opts...)
+}
+
+// CreateApiDeployment createApiDeployment creates a specified deployment.
+func (c *RegistryClient) CreateApiDeployment(ctx context.Context, req *rpcpb.CreateApiDeploymentRequest, opts ...gax.CallOption) (*rpcpb.ApiDeployment, error) {
+	return c.internalClient.CreateApiDeployment(ctx, req, opts...)
+}
+
+// UpdateApiDeployment updateApiDeployment can be used to modify a specified deployment.
 func (c *RegistryClient) UpdateApiDeployment(ctx context.Context, req *rpcpb.UpdateApiDeploymentRequest, opts ...gax.CallOption) (*rpcpb.ApiDeployment, error) {
 	return c.internalClient.UpdateApiDeployment(ctx, req, opts...)
 }
 
-// DeleteApiDeployment deleteApiDeployment removes a specified deployment, all revisions, and all
-// child resources (e.g. artifacts).
-func (c *RegistryClient) DeleteApiDeployment(ctx context.Context, req *rpcpb.DeleteApiDeploymentRequest, opts ...gax.CallOption) error {
-	return c.internalClient.DeleteApiDeployment(ctx, req, opts...)
+// DeleteApiDeployment deleteApiDeployment removes a specified deployment, all revisions, and all
+// child resources (e.g. artifacts).
+func (c *RegistryClient) DeleteApiDeployment(ctx context.Context, req *rpcpb.DeleteApiDeploymentRequest, opts ...gax.CallOption) error {
+	return c.internalClient.DeleteApiDeployment(ctx, req, opts...)
+}
+
+// UndeleteApiDeployment undeleteApiDeployment restores a deleted deployment and the resources that were
+// deleted with it. Deleted resources can be restored until they are purged.
+// A revision that was deleted on its own is restored by naming the revision.
+func (c *RegistryClient) UndeleteApiDeployment(ctx context.Context, req *rpcpb.UndeleteApiDeploymentRequest, opts ...gax.CallOption) (*rpcpb.ApiDeployment, error) {
+	return c.internalClient.UndeleteApiDeployment(ctx, req, opts...)
+}
+
+// TagApiDeploymentRevision tagApiDeploymentRevision adds a tag to a specified revision of a
+// deployment.
+func (c *RegistryClient) TagApiDeploymentRevision(ctx context.Context, req *rpcpb.TagApiDeploymentRevisionRequest, opts ...gax.CallOption) (*rpcpb.ApiDeployment, error) {
+	return c.internalClient.TagApiDeploymentRevision(ctx, req, opts...)
+}
+
+// ListApiDeploymentRevisions listApiDeploymentRevisions lists all revisions of a deployment.
+// Revisions are returned in descending order of revision creation time.
+func (c *RegistryClient) ListApiDeploymentRevisions(ctx context.Context, req *rpcpb.ListApiDeploymentRevisionsRequest, opts ...gax.CallOption) *ApiDeploymentIterator {
+	return c.internalClient.ListApiDeploymentRevisions(ctx, req, opts...)
+}
+
+// RollbackApiDeployment rollbackApiDeployment sets the current revision to a specified prior
+// revision. Note that this creates a new revision with a new revision ID.
+func (c *RegistryClient) RollbackApiDeployment(ctx context.Context, req *rpcpb.RollbackApiDeploymentRequest, opts ...gax.CallOption) (*rpcpb.ApiDeployment, error) {
+	return c.internalClient.RollbackApiDeployment(ctx, req, opts...)
+}
+
+// DeleteApiDeploymentRevision deleteApiDeploymentRevision deletes a revision of a deployment.
+func (c *RegistryClient) DeleteApiDeploymentRevision(ctx context.Context, req *rpcpb.DeleteApiDeploymentRevisionRequest, opts ...gax.CallOption) (*rpcpb.ApiDeployment, error) {
+	return c.internalClient.DeleteApiDeploymentRevision(ctx, req, opts...)
+}
+
+// ListArtifacts listArtifacts returns matching artifacts.
+func (c *RegistryClient) ListArtifacts(ctx context.Context, req *rpcpb.ListArtifactsRequest, opts ...gax.CallOption) *ArtifactIterator {
+	return c.internalClient.ListArtifacts(ctx, req, opts...)
+}
+
+// GetArtifact getArtifact returns a specified artifact.
+func (c *RegistryClient) GetArtifact(ctx context.Context, req *rpcpb.GetArtifactRequest, opts ...gax.CallOption) (*rpcpb.Artifact, error) {
+	return c.internalClient.GetArtifact(ctx, req, opts...)
+}
+
+// GetArtifactContents getArtifactContents returns the contents of a specified artifact.
+// If artifacts are stored with GZip compression, the default behavior
+// is to return the artifact uncompressed (the mime_type response field
+// indicates the exact format returned).
+func (c *RegistryClient) GetArtifactContents(ctx context.Context, req *rpcpb.GetArtifactContentsRequest, opts ...gax.CallOption) (*httpbodypb.HttpBody, error) {
+	return c.internalClient.GetArtifactContents(ctx, req, opts...)
+}
+
+// CreateArtifact createArtifact creates a specified artifact.
+func (c *RegistryClient) CreateArtifact(ctx context.Context, req *rpcpb.CreateArtifactRequest, opts ...gax.CallOption) (*rpcpb.Artifact, error) {
+	return c.internalClient.CreateArtifact(ctx, req, opts...)
+}
+
+// ReplaceArtifact replaceArtifact can be used to replace a specified artifact.
+func (c *RegistryClient) ReplaceArtifact(ctx context.Context, req *rpcpb.ReplaceArtifactRequest, opts ...gax.CallOption) (*rpcpb.Artifact, error) {
+	return c.internalClient.ReplaceArtifact(ctx, req, opts...)
+}
+
+// DeleteArtifact deleteArtifact removes a specified artifact.
+func (c *RegistryClient) DeleteArtifact(ctx context.Context, req *rpcpb.DeleteArtifactRequest, opts ...gax.CallOption) error {
+	return c.internalClient.DeleteArtifact(ctx, req, opts...)
+}
+
+// UndeleteArtifact undeleteArtifact restores a deleted artifact. Deleted artifacts can be
+// restored until they are purged.
+func (c *RegistryClient) UndeleteArtifact(ctx context.Context, req *rpcpb.UndeleteArtifactRequest, opts ...gax.CallOption) (*rpcpb.Artifact, error) {
+	return c.internalClient.UndeleteArtifact(ctx, req, opts...)
+}
+
+// TagArtifactRevision tagArtifactRevision adds a tag to a specified revision of an artifact.
+func (c *RegistryClient) TagArtifactRevision(ctx context.Context, req *rpcpb.TagArtifactRevisionRequest, opts ...gax.CallOption) (*rpcpb.Artifact, error) {
+	return c.internalClient.TagArtifactRevision(ctx, req, opts...)
+}
+
+// ListArtifactRevisions listArtifactRevisions lists all revisions of an artifact.
+// Revisions are returned in descending order of revision creation time.
+func (c *RegistryClient) ListArtifactRevisions(ctx context.Context, req *rpcpb.ListArtifactRevisionsRequest, opts ...gax.CallOption) *ArtifactIterator {
+	return c.internalClient.ListArtifactRevisions(ctx, req, opts...)
+}
+
+// RollbackArtifact rollbackArtifact sets the current revision to a specified prior
+// revision. Note that this creates a new revision with a new revision ID.
+func (c *RegistryClient) RollbackArtifact(ctx context.Context, req *rpcpb.RollbackArtifactRequest, opts ...gax.CallOption) (*rpcpb.Artifact, error) {
+	return c.internalClient.RollbackArtifact(ctx, req, opts...)
+}
+
+// BatchCreateApis batchCreateApis creates APIs in a single request. Unless partial
+// success is allowed, either all of the APIs are created or none are.
+func (c *RegistryClient) BatchCreateApis(ctx context.Context, req *rpcpb.BatchCreateApisRequest, opts ...gax.CallOption) (*rpcpb.BatchCreateApisResponse, error) {
+	return c.internalClient.BatchCreateApis(ctx, req, opts...)
+}
+
+// BatchUpdateApis batchUpdateApis updates APIs in a single request. Unless partial
+// success is allowed, either all of the APIs are updated or none are.
+func (c *RegistryClient) BatchUpdateApis(ctx context.Context, req *rpcpb.BatchUpdateApisRequest, opts ...gax.CallOption) (*rpcpb.BatchUpdateApisResponse, error) {
+	return c.internalClient.BatchUpdateApis(ctx, req, opts...)
+}
+
+// BatchDeleteApis batchDeleteApis deletes APIs in a single request. Unless partial
+// success is allowed, either all of the APIs are deleted or none are.
+func (c *RegistryClient) BatchDeleteApis(ctx context.Context, req *rpcpb.BatchDeleteApisRequest, opts ...gax.CallOption) (*rpcpb.BatchDeleteApisResponse, error) {
+	return c.internalClient.BatchDeleteApis(ctx, req, opts...)
+}
+
+// BatchCreateApiVersions batchCreateApiVersions creates versions in a single request. Unless partial
+// success is allowed, either all of the versions are created or none are.
+func (c *RegistryClient) BatchCreateApiVersions(ctx context.Context, req *rpcpb.BatchCreateApiVersionsRequest, opts ...gax.CallOption) (*rpcpb.BatchCreateApiVersionsResponse, error) {
+	return c.internalClient.BatchCreateApiVersions(ctx, req, opts...)
+}
+
+// BatchUpdateApiVersions batchUpdateApiVersions updates versions in a single request. Unless partial
+// success is allowed, either all of the versions are updated or none are.
+func (c *RegistryClient) BatchUpdateApiVersions(ctx context.Context, req *rpcpb.BatchUpdateApiVersionsRequest, opts ...gax.CallOption) (*rpcpb.BatchUpdateApiVersionsResponse, error) {
+	return c.internalClient.BatchUpdateApiVersions(ctx, req, opts...)
+}
+
+// BatchDeleteApiVersions batchDeleteApiVersions deletes versions in a single request. Unless partial
+// success is allowed, either all of the versions are deleted or none are.
+func (c *RegistryClient) BatchDeleteApiVersions(ctx context.Context, req *rpcpb.BatchDeleteApiVersionsRequest, opts ...gax.CallOption) (*rpcpb.BatchDeleteApiVersionsResponse, error) {
+	return c.internalClient.BatchDeleteApiVersions(ctx, req, opts...)
+}
+
+// BatchCreateApiSpecs batchCreateApiSpecs creates specs in a single request. Unless partial
+// success is allowed, either all of the specs are created or none are.
+func (c *RegistryClient) BatchCreateApiSpecs(ctx context.Context, req *rpcpb.BatchCreateApiSpecsRequest, opts ...gax.CallOption) (*rpcpb.BatchCreateApiSpecsResponse, error) {
+	return c.internalClient.BatchCreateApiSpecs(ctx, req, opts...)
+}
+
+// BatchUpdateApiSpecs batchUpdateApiSpecs updates specs in a single request. Unless partial
+// success is allowed, either all of the specs are updated or none are.
+func (c *RegistryClient) BatchUpdateApiSpecs(ctx context.Context, req *rpcpb.BatchUpdateApiSpecsRequest, opts ...gax.CallOption) (*rpcpb.BatchUpdateApiSpecsResponse, error) {
+	return c.internalClient.BatchUpdateApiSpecs(ctx, req, opts...)
+}
+
+// BatchDeleteApiSpecs batchDeleteApiSpecs deletes specs in a single request. Unless partial
+// success is allowed, either all of the specs are deleted or none are.
+func (c *RegistryClient) BatchDeleteApiSpecs(ctx context.Context, req *rpcpb.BatchDeleteApiSpecsRequest, opts ...gax.CallOption) (*rpcpb.BatchDeleteApiSpecsResponse, error) {
+	return c.internalClient.BatchDeleteApiSpecs(ctx, req, opts...)
+}
+
+// BatchCreateApiDeployments batchCreateApiDeployments creates deployments in a single request. Unless partial
+// success is allowed, either all of the deployments are created or none are.
+func (c *RegistryClient) BatchCreateApiDeployments(ctx context.Context, req *rpcpb.BatchCreateApiDeploymentsRequest, opts ...gax.CallOption) (*rpcpb.BatchCreateApiDeploymentsResponse, error) {
+	return c.internalClient.BatchCreateApiDeployments(ctx, req, opts...)
+}
+
+// BatchUpdateApiDeployments batchUpdateApiDeployments updates deployments in a single request. Unless partial
+// success is allowed, either all of the deployments are updated or none are.
+func (c *RegistryClient) BatchUpdateApiDeployments(ctx context.Context, req *rpcpb.BatchUpdateApiDeploymentsRequest, opts ...gax.CallOption) (*rpcpb.BatchUpdateApiDeploymentsResponse, error) {
+	return c.internalClient.BatchUpdateApiDeployments(ctx, req, opts...)
+}
+
+// BatchDeleteApiDeployments batchDeleteApiDeployments deletes deployments in a single request. Unless partial
+// success is allowed, either all of the deployments are deleted or none are.
+func (c *RegistryClient) BatchDeleteApiDeployments(ctx context.Context, req *rpcpb.BatchDeleteApiDeploymentsRequest, opts ...gax.CallOption) (*rpcpb.BatchDeleteApiDeploymentsResponse, error) {
+	return c.internalClient.BatchDeleteApiDeployments(ctx, req, opts...)
+}
+
+// BatchCreateArtifacts batchCreateArtifacts creates artifacts in a single request. Unless partial
+// success is allowed, either all of the artifacts are created or none are.
+func (c *RegistryClient) BatchCreateArtifacts(ctx context.Context, req *rpcpb.BatchCreateArtifactsRequest, opts ...gax.CallOption) (*rpcpb.BatchCreateArtifactsResponse, error) {
+	return c.internalClient.BatchCreateArtifacts(ctx, req, opts...)
+}
+
+// BatchReplaceArtifacts batchReplaceArtifacts replaces artifacts in a single request. Unless partial
+// success is allowed, either all of the artifacts are replaced or none are.
+func (c *RegistryClient) BatchReplaceArtifacts(ctx context.Context, req *rpcpb.BatchReplaceArtifactsRequest, opts ...gax.CallOption) (*rpcpb.BatchReplaceArtifactsResponse, error) {
+	return c.internalClient.BatchReplaceArtifacts(ctx, req, opts...)
+}
+
+// BatchDeleteArtifacts batchDeleteArtifacts deletes artifacts in a single request. Unless partial
+// success is allowed, either all of the artifacts are deleted or none are.
+func (c *RegistryClient) BatchDeleteArtifacts(ctx context.Context, req *rpcpb.BatchDeleteArtifactsRequest, opts ...gax.CallOption) (*rpcpb.BatchDeleteArtifactsResponse, error) {
+	return c.internalClient.BatchDeleteArtifacts(ctx, req, opts...)
+}
+
+// ListReferences listReferences lists the references from a resource to other resources.
+// References from the artifacts of the resource are included.
+func (c *RegistryClient) ListReferences(ctx context.Context, req *rpcpb.ListReferencesRequest, opts ...gax.CallOption) *ReferenceIterator {
+	return c.internalClient.ListReferences(ctx, req, opts...)
+}
+
+// ListReferrers listReferrers lists the references to a resource from other resources.
+// References to the revisions and children of the resource are included.
+func (c *RegistryClient) ListReferrers(ctx context.Context, req *rpcpb.ListReferrersRequest, opts ...gax.CallOption) *ReferenceIterator {
+	return c.internalClient.ListReferrers(ctx, req, opts...)
+}
+
+// SearchResources searchResources searches the names, display names, descriptions, labels
+// and annotations of the APIs, versions, specs and deployments of a project
+// and the contents of its specs.
+func (c *RegistryClient) SearchResources(ctx context.Context, req *rpcpb.SearchResourcesRequest, opts ...gax.CallOption) *SearchResultIterator {
+	return c.internalClient.SearchResources(ctx, req, opts...)
+}
+
+// ListSpecElements listSpecElements lists the operations, schemas, messages and services
+// that are defined by the current revisions of specs, as recorded in the
+// SpecIndex artifacts of the specs.
+func (c *RegistryClient) ListSpecElements(ctx context.Context, req *rpcpb.ListSpecElementsRequest, opts ...gax.CallOption) *SpecElementIterator {
+	return c.internalClient.ListSpecElements(ctx, req, opts...)
+}
+
+// registryGRPCClient is a client for interacting with  over gRPC transport.
+//
+// Methods, except Close, may be called concurrently. However, fields must not be modified concurrently with method calls.
+type registryGRPCClient struct {
+	// Connection pool of gRPC connections to the service.
+	connPool gtransport.ConnPool
+
+	// flag to opt out of default deadlines via GOOGLE_API_GO_EXPERIMENTAL_DISABLE_DEFAULT_DEADLINE
+	disableDeadlines bool
+
+	// Points back to the CallOptions field of the containing RegistryClient
+	CallOptions **RegistryCallOptions
+
+	// The gRPC API client.
+	registryClient rpcpb.RegistryClient
+
+	// The x-goog-* metadata to be sent with each request.
+	xGoogMetadata metadata.MD
+}
+
+// NewRegistryClient creates a new registry client based on gRPC.
+// The returned client must be Closed when it is done being used to clean up its underlying connections.
+//
+// The Registry service allows teams to manage descriptions of APIs.
+func NewRegistryClient(ctx context.Context, opts ...option.ClientOption) (*RegistryClient, error) {
+	clientOpts := defaultRegistryGRPCClientOptions()
+	if newRegistryClientHook != nil {
+		hookOpts, err := newRegistryClientHook(ctx, clientHookParams{})
+		if err != nil {
+			return nil, err
+		}
+		clientOpts = append(clientOpts, hookOpts...)
+	}
+
+	disableDeadlines, err := checkDisableDeadlines()
+	if err != nil {
+		return nil, err
+	}
+
+	connPool, err := gtransport.DialPool(ctx, append(clientOpts, opts...)...)
+	if err != nil {
+		return nil, err
+	}
+	client := RegistryClient{CallOptions: defaultRegistryCallOptions()}
+
+	c := &registryGRPCClient{
+		connPool:         connPool,
+		disableDeadlines: disableDeadlines,
+		registryClient:   rpcpb.NewRegistryClient(connPool),
+		CallOptions:      &client.CallOptions,
+	}
+	c.setGoogleClientInfo()
+
+	client.internalClient = c
+
+	return &client, nil
+}
+
+// Connection returns a connection to the API service.
+//
+// Deprecated: Connections are now pooled so this method does not always
+// return the same resource.
+func (c *registryGRPCClient) Connection() *grpc.ClientConn {
+	return c.connPool.Conn()
+}
+
+// setGoogleClientInfo sets the name and version of the application in
+// the `x-goog-api-client` header passed on each request. Intended for
+// use by Google-written clients.
+func (c *registryGRPCClient) setGoogleClientInfo(keyval ...string) {
+	kv := append([]string{"gl-go", versionGo()}, keyval...)
+	kv = append(kv, "gapic", getVersionClient(), "gax", gax.Version, "grpc", grpc.Version)
+	c.xGoogMetadata = metadata.Pairs("x-goog-api-client", gax.XGoogHeader(kv...))
+}
+
+// Close closes the connection to the API service. The user should invoke this when
+// the client is no longer required.
+func (c *registryGRPCClient) Close() error {
+	return c.connPool.Close()
+}
+
+func (c *registryGRPCClient) ListApis(ctx context.Context, req *rpcpb.ListApisRequest, opts ...gax.CallOption) *ApiIterator {
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "parent", url.QueryEscape(req.GetParent())))
+
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).ListApis[0:len((*c.CallOptions).ListApis):len((*c.CallOptions).ListApis)], opts...)
+	it := &ApiIterator{}
+	req = proto.Clone(req).(*rpcpb.ListApisRequest)
+	it.InternalFetch = func(pageSize int, pageToken string) ([]*rpcpb.Api, string, error) {
+		resp := &rpcpb.ListApisResponse{}
+		if pageToken != "" {
+			req.PageToken = pageToken
+		}
+		if pageSize > math.MaxInt32 {
+			req.PageSize = math.MaxInt32
+		} else if pageSize != 0 {
+			req.PageSize = int32(pageSize)
+		}
+		err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+			var err error
+			resp, err = c.registryClient.ListApis(ctx, req, settings.GRPC...)
+			return err
+		}, opts...)
+		if err != nil {
+			return nil, "", err
+		}
+
+		it.Response = resp
+		return resp.GetApis(), resp.GetNextPageToken(), nil
+	}
+	fetch := func(pageSize int, pageToken string) (string, error) {
+		items, nextPageToken, err := it.InternalFetch(pageSize, pageToken)
+		if err != nil {
+			return "", err
+		}
+		it.items = append(it.items, items...)
+		return nextPageToken, nil
+	}
+
+	it.pageInfo, it.nextFunc = iterator.NewPageInfo(fetch, it.bufLen, it.takeBuf)
+	it.pageInfo.MaxSize = int(req.GetPageSize())
+	it.pageInfo.Token = req.GetPageToken()
+
+	return it
+}
+
+func (c *registryGRPCClient) GetApi(ctx context.Context, req *rpcpb.GetApiRequest, opts ...gax.CallOption) (*rpcpb.Api, error) {
+	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
+		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
+		defer cancel()
+		ctx = cctx
+	}
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
+
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).GetApi[0:len((*c.CallOptions).GetApi):len((*c.CallOptions).GetApi)], opts...)
+	var resp *rpcpb.Api
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.registryClient.GetApi(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *registryGRPCClient) CreateApi(ctx context.Context, req *rpcpb.CreateApiRequest, opts ...gax.CallOption) (*rpcpb.Api, error) {
+	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
+		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
+		defer cancel()
+		ctx = cctx
+	}
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "parent", url.QueryEscape(req.GetParent())))
+
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).CreateApi[0:len((*c.CallOptions).CreateApi):len((*c.CallOptions).CreateApi)], opts...)
+	var resp *rpcpb.Api
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.registryClient.CreateApi(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *registryGRPCClient) UpdateApi(ctx context.Context, req *rpcpb.UpdateApiRequest, opts ...gax.CallOption) (*rpcpb.Api, error) {
+	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
+		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
+		defer cancel()
+		ctx = cctx
+	}
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "api.name", url.QueryEscape(req.GetApi().GetName())))
+
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).UpdateApi[0:len((*c.CallOptions).UpdateApi):len((*c.CallOptions).UpdateApi)], opts...)
+	var resp *rpcpb.Api
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.registryClient.UpdateApi(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *registryGRPCClient) DeleteApi(ctx context.Context, req *rpcpb.DeleteApiRequest, opts ...gax.CallOption) error {
+	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
+		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
+		defer cancel()
+		ctx = cctx
+	}
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
+
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).DeleteApi[0:len((*c.CallOptions).DeleteApi):len((*c.CallOptions).DeleteApi)], opts...)
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		_, err = c.registryClient.DeleteApi(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	return err
+}
+
+func (c *registryGRPCClient) UndeleteApi(ctx context.Context, req *rpcpb.UndeleteApiRequest, opts ...gax.CallOption) (*rpcpb.Api, error) {
+	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
+		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
+		defer cancel()
+		ctx = cctx
+	}
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
+
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).UndeleteApi[0:len((*c.CallOptions).UndeleteApi):len((*c.CallOptions).UndeleteApi)], opts...)
+	var resp *rpcpb.Api
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.registryClient.UndeleteApi(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *registryGRPCClient) ListApiVersions(ctx context.Context, req *rpcpb.ListApiVersionsRequest, opts ...gax.CallOption) *ApiVersionIterator {
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "parent", url.QueryEscape(req.GetParent())))
+
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).ListApiVersions[0:len((*c.CallOptions).ListApiVersions):len((*c.CallOptions).ListApiVersions)], opts...)
+	it := &ApiVersionIterator{}
+	req = proto.Clone(req).(*rpcpb.ListApiVersionsRequest)
+	it.InternalFetch = func(pageSize int, pageToken string) ([]*rpcpb.ApiVersion, string, error) {
+		resp := &rpcpb.ListApiVersionsResponse{}
+		if pageToken != "" {
+			req.PageToken = pageToken
+		}
+		if pageSize > math.MaxInt32 {
+			req.PageSize = math.MaxInt32
+		} else if pageSize != 0 {
+			req.PageSize = int32(pageSize)
+		}
+		err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+			var err error
+			resp, err = c.registryClient.ListApiVersions(ctx, req, settings.GRPC...)
+			return err
+		}, opts...)
+		if err != nil {
+			return nil, "", err
+		}
+
+		it.Response = resp
+		return resp.GetApiVersions(), resp.GetNextPageToken(), nil
+	}
+	fetch := func(pageSize int, pageToken string) (string, error) {
+		items, nextPageToken, err := it.InternalFetch(pageSize, pageToken)
+		if err != nil {
+			return "", err
+		}
+		it.items = append(it.items, items...)
+		return nextPageToken, nil
+	}
+
+	it.pageInfo, it.nextFunc = iterator.NewPageInfo(fetch, it.bufLen, it.takeBuf)
+	it.pageInfo.MaxSize = int(req.GetPageSize())
+	it.pageInfo.Token = req.GetPageToken()
+
+	return it
+}
+
+func (c *registryGRPCClient) GetApiVersion(ctx context.Context, req *rpcpb.GetApiVersionRequest, opts ...gax.CallOption) (*rpcpb.ApiVersion, error) {
+	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
+		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
+		defer cancel()
+		ctx = cctx
+	}
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
+
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).GetApiVersion[0:len((*c.CallOptions).GetApiVersion):len((*c.CallOptions).GetApiVersion)], opts...)
+	var resp *rpcpb.ApiVersion
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.registryClient.GetApiVersion(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *registryGRPCClient) CreateApiVersion(ctx context.Context, req *rpcpb.CreateApiVersionRequest, opts ...gax.CallOption) (*rpcpb.ApiVersion, error) {
+	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
+		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
+		defer cancel()
+		ctx = cctx
+	}
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "parent", url.QueryEscape(req.GetParent())))
+
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).CreateApiVersion[0:len((*c.CallOptions).CreateApiVersion):len((*c.CallOptions).CreateApiVersion)], opts...)
+	var resp *rpcpb.ApiVersion
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.registryClient.CreateApiVersion(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *registryGRPCClient) UpdateApiVersion(ctx context.Context, req *rpcpb.UpdateApiVersionRequest, opts ...gax.CallOption) (*rpcpb.ApiVersion, error) {
+	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
+		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
+		defer cancel()
+		ctx = cctx
+	}
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "api_version.name", url.QueryEscape(req.GetApiVersion().GetName())))
+
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).UpdateApiVersion[0:len((*c.CallOptions).UpdateApiVersion):len((*c.CallOptions).UpdateApiVersion)], opts...)
+	var resp *rpcpb.ApiVersion
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.registryClient.UpdateApiVersion(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *registryGRPCClient) DeleteApiVersion(ctx context.Context, req *rpcpb.DeleteApiVersionRequest, opts ...gax.CallOption) error {
+	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
+		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
+		defer cancel()
+		ctx = cctx
+	}
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
+
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).DeleteApiVersion[0:len((*c.CallOptions).DeleteApiVersion):len((*c.CallOptions).DeleteApiVersion)], opts...)
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		_, err = c.registryClient.DeleteApiVersion(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	return err
+}
+
+func (c *registryGRPCClient) UndeleteApiVersion(ctx context.Context, req *rpcpb.UndeleteApiVersionRequest, opts ...gax.CallOption) (*rpcpb.ApiVersion, error) {
+	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
+		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
+		defer cancel()
+		ctx = cctx
+	}
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
+
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).UndeleteApiVersion[0:len((*c.CallOptions).UndeleteApiVersion):len((*c.CallOptions).UndeleteApiVersion)], opts...)
+	var resp *rpcpb.ApiVersion
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.registryClient.UndeleteApiVersion(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
 }
 
-// TagApiDeploymentRevision tagApiDeploymentRevision adds a tag to a specified revision of a
-// deployment.
-func (c *RegistryClient) TagApiDeploymentRevision(ctx context.Context, req *rpcpb.TagApiDeploymentRevisionRequest, opts ...gax.CallOption) (*rpcpb.ApiDeployment, error) {
-	return c.internalClient.TagApiDeploymentRevision(ctx, req, opts...)
+func (c *registryGRPCClient) ListApiSpecs(ctx context.Context, req *rpcpb.ListApiSpecsRequest, opts ...gax.CallOption) *ApiSpecIterator {
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "parent", url.QueryEscape(req.GetParent())))
+
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).ListApiSpecs[0:len((*c.CallOptions).ListApiSpecs):len((*c.CallOptions).ListApiSpecs)], opts...)
+	it := &ApiSpecIterator{}
+	req = proto.Clone(req).(*rpcpb.ListApiSpecsRequest)
+	it.InternalFetch = func(pageSize int, pageToken string) ([]*rpcpb.ApiSpec, string, error) {
+		resp := &rpcpb.ListApiSpecsResponse{}
+		if pageToken != "" {
+			req.PageToken = pageToken
+		}
+		if pageSize > math.MaxInt32 {
+			req.PageSize = math.MaxInt32
+		} else if pageSize != 0 {
+			req.PageSize = int32(pageSize)
+		}
+		err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+			var err error
+			resp, err = c.registryClient.ListApiSpecs(ctx, req, settings.GRPC...)
+			return err
+		}, opts...)
+		if err != nil {
+			return nil, "", err
+		}
+
+		it.Response = resp
+		return resp.GetApiSpecs(), resp.GetNextPageToken(), nil
+	}
+	fetch := func(pageSize int, pageToken string) (string, error) {
+		items, nextPageToken, err := it.InternalFetch(pageSize, pageToken)
+		if err != nil {
+			return "", err
+		}
+		it.items = append(it.items, items...)
+		return nextPageToken, nil
+	}
+
+	it.pageInfo, it.nextFunc = iterator.NewPageInfo(fetch, it.bufLen, it.takeBuf)
+	it.pageInfo.MaxSize = int(req.GetPageSize())
+	it.pageInfo.Token = req.GetPageToken()
+
+	return it
+}
+
+func (c *registryGRPCClient) GetApiSpec(ctx context.Context, req *rpcpb.GetApiSpecRequest, opts ...gax.CallOption) (*rpcpb.ApiSpec, error) {
+	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
+		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
+		defer cancel()
+		ctx = cctx
+	}
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
+
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).GetApiSpec[0:len((*c.CallOptions).GetApiSpec):len((*c.CallOptions).GetApiSpec)], opts...)
+	var resp *rpcpb.ApiSpec
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.registryClient.GetApiSpec(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *registryGRPCClient) GetApiSpecContents(ctx context.Context, req *rpcpb.GetApiSpecContentsRequest, opts ...gax.CallOption) (*httpbodypb.HttpBody, error) {
+	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
+		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
+		defer cancel()
+		ctx = cctx
+	}
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
+
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).GetApiSpecContents[0:len((*c.CallOptions).GetApiSpecContents):len((*c.CallOptions).GetApiSpecContents)], opts...)
+	var resp *httpbodypb.HttpBody
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.registryClient.GetApiSpecContents(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *registryGRPCClient) CreateApiSpec(ctx context.Context, req *rpcpb.CreateApiSpecRequest, opts ...gax.CallOption) (*rpcpb.ApiSpec, error) {
+	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
+		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
+		defer cancel()
+		ctx = cctx
+	}
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "parent", url.QueryEscape(req.GetParent())))
+
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).CreateApiSpec[0:len((*c.CallOptions).CreateApiSpec):len((*c.CallOptions).CreateApiSpec)], opts...)
+	var resp *rpcpb.ApiSpec
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.registryClient.CreateApiSpec(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *registryGRPCClient) UpdateApiSpec(ctx context.Context, req *rpcpb.UpdateApiSpecRequest, opts ...gax.CallOption) (*rpcpb.ApiSpec, error) {
+	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
+		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
+		defer cancel()
+		ctx = cctx
+	}
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "api_spec.name", url.QueryEscape(req.GetApiSpec().GetName())))
+
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).UpdateApiSpec[0:len((*c.CallOptions).UpdateApiSpec):len((*c.CallOptions).UpdateApiSpec)], opts...)
+	var resp *rpcpb.ApiSpec
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.registryClient.UpdateApiSpec(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *registryGRPCClient) DeleteApiSpec(ctx context.Context, req *rpcpb.DeleteApiSpecRequest, opts ...gax.CallOption) error {
+	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
+		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
+		defer cancel()
+		ctx = cctx
+	}
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
+
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).DeleteApiSpec[0:len((*c.CallOptions).DeleteApiSpec):len((*c.CallOptions).DeleteApiSpec)], opts...)
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		_, err = c.registryClient.DeleteApiSpec(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	return err
+}
+
+func (c *registryGRPCClient) UndeleteApiSpec(ctx context.Context, req *rpcpb.UndeleteApiSpecRequest, opts ...gax.CallOption) (*rpcpb.ApiSpec, error) {
+	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
+		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
+		defer cancel()
+		ctx = cctx
+	}
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
+
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).UndeleteApiSpec[0:len((*c.CallOptions).UndeleteApiSpec):len((*c.CallOptions).UndeleteApiSpec)], opts...)
+	var resp *rpcpb.ApiSpec
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.registryClient.UndeleteApiSpec(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *registryGRPCClient) TagApiSpecRevision(ctx context.Context, req *rpcpb.TagApiSpecRevisionRequest, opts ...gax.CallOption) (*rpcpb.ApiSpec, error) {
+	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
+		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
+		defer cancel()
+		ctx = cctx
+	}
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
+
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).TagApiSpecRevision[0:len((*c.CallOptions).TagApiSpecRevision):len((*c.CallOptions).TagApiSpecRevision)], opts...)
+	var resp *rpcpb.ApiSpec
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.registryClient.TagApiSpecRevision(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
 }
 
-// ListApiDeploymentRevisions listApiDeploymentRevisions lists all revisions of a deployment.
-// Revisions are returned in descending order of revision creation time.
-func (c *RegistryClient) ListApiDeploymentRevisions(ctx context.Context, req *rpcpb.ListApiDeploymentRevisionsRequest, opts ...gax.CallOption) *ApiDeploymentIterator {
-	return c.internalClient.ListApiDeploymentRevisions(ctx, req, opts...)
-}
+func (c *registryGRPCClient) ListApiSpecRevisions(ctx context.Context, req *rpcpb.ListApiSpecRevisionsRequest, opts ...gax.CallOption) *ApiSpecIterator {
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
+
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).ListApiSpecRevisions[0:len((*c.CallOptions).ListApiSpecRevisions):len((*c.CallOptions).ListApiSpecRevisions)], opts...)
+	it := &ApiSpecIterator{}
+	req = proto.Clone(req).(*rpcpb.ListApiSpecRevisionsRequest)
+	it.InternalFetch = func(pageSize int, pageToken string) ([]*rpcpb.ApiSpec, string, error) {
+		resp := &rpcpb.ListApiSpecRevisionsResponse{}
+		if pageToken != "" {
+			req.PageToken = pageToken
+		}
+		if pageSize > math.MaxInt32 {
+			req.PageSize = math.MaxInt32
+		} else if pageSize != 0 {
+			req.PageSize = int32(pageSize)
+		}
+		err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+			var err error
+			resp, err = c.registryClient.ListApiSpecRevisions(ctx, req, settings.GRPC...)
+			return err
+		}, opts...)
+		if err != nil {
+			return nil, "", err
+		}
+
+		it.Response = resp
+		return resp.GetApiSpecs(), resp.GetNextPageToken(), nil
+	}
+	fetch := func(pageSize int, pageToken string) (string, error) {
+		items, nextPageToken, err := it.InternalFetch(pageSize, pageToken)
+		if err != nil {
+			return "", err
+		}
+		it.items = append(it.items, items...)
+		return nextPageToken, nil
+	}
 
-// RollbackApiDeployment rollbackApiDeployment sets the current revision to a specified prior
-// revision. Note that this creates a new revision with a new revision ID.
-func (c *RegistryClient) RollbackApiDeployment(ctx context.Context, req *rpcpb.RollbackApiDeploymentRequest, opts ...gax.CallOption) (*rpcpb.ApiDeployment, error) {
-	return c.internalClient.RollbackApiDeployment(ctx, req, opts...)
-}
+	it.pageInfo, it.nextFunc = iterator.NewPageInfo(fetch, it.bufLen, it.takeBuf)
+	it.pageInfo.MaxSize = int(req.GetPageSize())
+	it.pageInfo.Token = req.GetPageToken()
 
-// DeleteApiDeploymentRevision deleteApiDeploymentRevision deletes a revision of a deployment.
-func (c *RegistryClient) DeleteApiDeploymentRevision(ctx context.Context, req *rpcpb.DeleteApiDeploymentRevisionRequest, opts ...gax.CallOption) (*rpcpb.ApiDeployment, error) {
-	return c.internalClient.DeleteApiDeploymentRevision(ctx, req, opts...)
+	return it
 }
 
-// ListArtifacts listArtifacts returns matching artifacts.
-func (c *RegistryClient) ListArtifacts(ctx context.Context, req *rpcpb.ListArtifactsRequest, opts ...gax.CallOption) *ArtifactIterator {
-	return c.internalClient.ListArtifacts(ctx, req, opts...)
-}
+func (c *registryGRPCClient) RollbackApiSpec(ctx context.Context, req *rpcpb.RollbackApiSpecRequest, opts ...gax.CallOption) (*rpcpb.ApiSpec, error) {
+	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
+		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
+		defer cancel()
+		ctx = cctx
+	}
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
 
-// GetArtifact getArtifact returns a specified artifact.
-func (c *RegistryClient) GetArtifact(ctx context.Context, req *rpcpb.GetArtifactRequest, opts ...gax.CallOption) (*rpcpb.Artifact, error) {
-	return c.internalClient.GetArtifact(ctx, req, opts...)
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).RollbackApiSpec[0:len((*c.CallOptions).RollbackApiSpec):len((*c.CallOptions).RollbackApiSpec)], opts...)
+	var resp *rpcpb.ApiSpec
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.registryClient.RollbackApiSpec(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
 }
 
-// GetArtifactContents getArtifactContents returns the contents of a specified artifact.
-// If artifacts are stored with GZip compression, the default behavior
-// is to return the artifact uncompressed (the mime_type response field
-// indicates the exact format returned).
-func (c *RegistryClient) GetArtifactContents(ctx context.Context, req *rpcpb.GetArtifactContentsRequest, opts ...gax.CallOption) (*httpbodypb.HttpBody, error) {
-	return c.internalClient.GetArtifactContents(ctx, req, opts...)
-}
+func (c *registryGRPCClient) DeleteApiSpecRevision(ctx context.Context, req *rpcpb.DeleteApiSpecRevisionRequest, opts ...gax.CallOption) (*rpcpb.ApiSpec, error) {
+	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
+		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
+		defer cancel()
+		ctx = cctx
+	}
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
 
-// CreateArtifact createArtifact creates a specified artifact.
-func (c *RegistryClient) CreateArtifact(ctx context.Context, req *rpcpb.CreateArtifactRequest, opts ...gax.CallOption) (*rpcpb.Artifact, error) {
-	return c.internalClient.CreateArtifact(ctx, req, opts...)
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).DeleteApiSpecRevision[0:len((*c.CallOptions).DeleteApiSpecRevision):len((*c.CallOptions).DeleteApiSpecRevision)], opts...)
+	var resp *rpcpb.ApiSpec
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.registryClient.DeleteApiSpecRevision(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
 }
 
-// ReplaceArtifact replaceArtifact can be used to replace a specified artifact.
-func (c *RegistryClient) ReplaceArtifact(ctx context.Context, req *rpcpb.ReplaceArtifactRequest, opts ...gax.CallOption) (*rpcpb.Artifact, error) {
-	return c.internalClient.ReplaceArtifact(ctx, req, opts...)
-}
+func (c *registryGRPCClient) ListApiDeployments(ctx context.Context, req *rpcpb.ListApiDeploymentsRequest, opts ...gax.CallOption) *ApiDeploymentIterator {
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "parent", url.QueryEscape(req.GetParent())))
 
-// DeleteArtifact deleteArtifact removes a specified artifact.
-func (c *RegistryClient) DeleteArtifact(ctx context.Context, req *rpcpb.DeleteArtifactRequest, opts ...gax.CallOption) error {
-	return c.internalClient.DeleteArtifact(ctx, req, opts...)
-}
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).ListApiDeployments[0:len((*c.CallOptions).ListApiDeployments):len((*c.CallOptions).ListApiDeployments)], opts...)
+	it := &ApiDeploymentIterator{}
+	req = proto.Clone(req).(*rpcpb.ListApiDeploymentsRequest)
+	it.InternalFetch = func(pageSize int, pageToken string) ([]*rpcpb.ApiDeployment, string, error) {
+		resp := &rpcpb.ListApiDeploymentsResponse{}
+		if pageToken != "" {
+			req.PageToken = pageToken
+		}
+		if pageSize > math.MaxInt32 {
+			req.PageSize = math.MaxInt32
+		} else if pageSize != 0 {
+			req.PageSize = int32(pageSize)
+		}
+		err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+			var err error
+			resp, err = c.registryClient.ListApiDeployments(ctx, req, settings.GRPC...)
+			return err
+		}, opts...)
+		if err != nil {
+			return nil, "", err
+		}
 
-// registryGRPCClient is a client for interacting with  over gRPC transport.
-//
-// Methods, except Close, may be called concurrently. However, fields must not be modified concurrently with method calls.
-type registryGRPCClient struct {
-	// Connection pool of gRPC connections to the service.
-	connPool gtransport.ConnPool
+		it.Response = resp
+		return resp.GetApiDeployments(), resp.GetNextPageToken(), nil
+	}
+	fetch := func(pageSize int, pageToken string) (string, error) {
+		items, nextPageToken, err := it.InternalFetch(pageSize, pageToken)
+		if err != nil {
+			return "", err
+		}
+		it.items = append(it.items, items...)
+		return nextPageToken, nil
+	}
 
-	// flag to opt out of default deadlines via GOOGLE_API_GO_EXPERIMENTAL_DISABLE_DEFAULT_DEADLINE
-	disableDeadlines bool
+	it.pageInfo, it.nextFunc = iterator.NewPageInfo(fetch, it.bufLen, it.takeBuf)
+	it.pageInfo.MaxSize = int(req.GetPageSize())
+	it.pageInfo.Token = req.GetPageToken()
 
-	// Points back to the CallOptions field of the containing RegistryClient
-	CallOptions **RegistryCallOptions
+	return it
+}
 
-	// The gRPC API client.
-	registryClient rpcpb.RegistryClient
+func (c *registryGRPCClient) GetApiDeployment(ctx context.Context, req *rpcpb.GetApiDeploymentRequest, opts ...gax.CallOption) (*rpcpb.ApiDeployment, error) {
+	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
+		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
+		defer cancel()
+		ctx = cctx
+	}
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
 
-	// The x-goog-* metadata to be sent with each request.
-	xGoogMetadata metadata.MD
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).GetApiDeployment[0:len((*c.CallOptions).GetApiDeployment):len((*c.CallOptions).GetApiDeployment)], opts...)
+	var resp *rpcpb.ApiDeployment
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.registryClient.GetApiDeployment(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
 }
 
-// NewRegistryClient creates a new registry client based on gRPC.
-// The returned client must be Closed when it is done being used to clean up its underlying connections.
-//
-// The Registry service allows teams to manage descriptions of APIs.
-func NewRegistryClient(ctx context.Context, opts ...option.ClientOption) (*RegistryClient, error) {
-	clientOpts := defaultRegistryGRPCClientOptions()
-	if newRegistryClientHook != nil {
-		hookOpts, err := newRegistryClientHook(ctx, clientHookParams{})
-		if err != nil {
-			return nil, err
-		}
-		clientOpts = append(clientOpts, hookOpts...)
+func (c *registryGRPCClient) CreateApiDeployment(ctx context.Context, req *rpcpb.CreateApiDeploymentRequest, opts ...gax.CallOption) (*rpcpb.ApiDeployment, error) {
+	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
+		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
+		defer cancel()
+		ctx = cctx
 	}
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "parent", url.QueryEscape(req.GetParent())))
 
-	disableDeadlines, err := checkDisableDeadlines()
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).CreateApiDeployment[0:len((*c.CallOptions).CreateApiDeployment):len((*c.CallOptions).CreateApiDeployment)], opts...)
+	var resp *rpcpb.ApiDeployment
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.registryClient.CreateApiDeployment(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
 	if err != nil {
 		return nil, err
 	}
+	return resp, nil
+}
 
-	connPool, err := gtransport.DialPool(ctx, append(clientOpts, opts...)...)
+func (c *registryGRPCClient) UpdateApiDeployment(ctx context.Context, req *rpcpb.UpdateApiDeploymentRequest, opts ...gax.CallOption) (*rpcpb.ApiDeployment, error) {
+	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
+		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
+		defer cancel()
+		ctx = cctx
+	}
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "api_deployment.name", url.QueryEscape(req.GetApiDeployment().GetName())))
+
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).UpdateApiDeployment[0:len((*c.CallOptions).UpdateApiDeployment):len((*c.CallOptions).UpdateApiDeployment)], opts...)
+	var resp *rpcpb.ApiDeployment
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.registryClient.UpdateApiDeployment(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
 	if err != nil {
 		return nil, err
 	}
-	client := RegistryClient{CallOptions: defaultRegistryCallOptions()}
+	return resp, nil
+}
 
-	c := &registryGRPCClient{
-		connPool:         connPool,
-		disableDeadlines: disableDeadlines,
-		registryClient:   rpcpb.NewRegistryClient(connPool),
-		CallOptions:      &client.CallOptions,
+func (c *registryGRPCClient) DeleteApiDeployment(ctx context.Context, req *rpcpb.DeleteApiDeploymentRequest, opts ...gax.CallOption) error {
+	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
+		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
+		defer cancel()
+		ctx = cctx
 	}
-	c.setGoogleClientInfo()
-
-	client.internalClient = c
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
 
-	return &client, nil
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).DeleteApiDeployment[0:len((*c.CallOptions).DeleteApiDeployment):len((*c.CallOptions).DeleteApiDeployment)], opts...)
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		_, err = c.registryClient.DeleteApiDeployment(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	return err
 }
 
-// Connection returns a connection to the API service.
-//
-// Deprecated: Connections are now pooled so this method does not always
-// return the same resource.
-func (c *registryGRPCClient) Connection() *grpc.ClientConn {
-	return c.connPool.Conn()
+func (c *registryGRPCClient) UndeleteApiDeployment(ctx context.Context, req *rpcpb.UndeleteApiDeploymentRequest, opts ...gax.CallOption) (*rpcpb.ApiDeployment, error) {
+	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
+		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
+		defer cancel()
+		ctx = cctx
+	}
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
+
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).UndeleteApiDeployment[0:len((*c.CallOptions).UndeleteApiDeployment):len((*c.CallOptions).UndeleteApiDeployment)], opts...)
+	var resp *rpcpb.ApiDeployment
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.registryClient.UndeleteApiDeployment(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
 }
 
-// setGoogleClientInfo sets the name and version of the application in
-// the `x-goog-api-client` header passed on each request. Intended for
-// use by Google-written clients.
-func (c *registryGRPCClient) setGoogleClientInfo(keyval ...string) {
-	kv := append([]string{"gl-go", versionGo()}, keyval...)
-	kv = append(kv, "gapic", getVersionClient(), "gax", gax.Version, "grpc", grpc.Version)
-	c.xGoogMetadata = metadata.Pairs("x-goog-api-client", gax.XGoogHeader(kv...))
-}
+func (c *registryGRPCClient) TagApiDeploymentRevision(ctx context.Context, req *rpcpb.TagApiDeploymentRevisionRequest, opts ...gax.CallOption) (*rpcpb.ApiDeployment, error) {
+	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
+		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
+		defer cancel()
+		ctx = cctx
+	}
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
 
-// Close closes the connection to the API service. The user should invoke this when
-// the client is no longer required.
-func (c *registryGRPCClient) Close() error {
-	return c.connPool.Close()
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).TagApiDeploymentRevision[0:len((*c.CallOptions).TagApiDeploymentRevision):len((*c.CallOptions).TagApiDeploymentRevision)], opts...)
+	var resp *rpcpb.ApiDeployment
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.registryClient.TagApiDeploymentRevision(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
 }
 
-func (c *registryGRPCClient) ListApis(ctx context.Context, req *rpcpb.ListApisRequest, opts ...gax.CallOption) *ApiIterator {
-	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "parent", url.QueryEscape(req.GetParent())))
+func (c *registryGRPCClient) ListApiDeploymentRevisions(ctx context.Context, req *rpcpb.ListApiDeploymentRevisionsRequest, opts ...gax.CallOption) *ApiDeploymentIterator {
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
 
 	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
-	opts = append((*c.CallOptions).ListApis[0:len((*c.CallOptions).ListApis):len((*c.CallOptions).ListApis)], opts...)
-	it := &ApiIterator{}
-	req = proto.Clone(req).(*rpcpb.ListApisRequest)
-	it.InternalFetch = func(pageSize int, pageToken string) ([]*rpcpb.Api, string, error) {
-		resp := &rpcpb.ListApisResponse{}
+	opts = append((*c.CallOptions).ListApiDeploymentRevisions[0:len((*c.CallOptions).ListApiDeploymentRevisions):len((*c.CallOptions).ListApiDeploymentRevisions)], opts...)
+	it := &ApiDeploymentIterator{}
+	req = proto.Clone(req).(*rpcpb.ListApiDeploymentRevisionsRequest)
+	it.InternalFetch = func(pageSize int, pageToken string) ([]*rpcpb.ApiDeployment, string, error) {
+		resp := &rpcpb.ListApiDeploymentRevisionsResponse{}
 		if pageToken != "" {
 			req.PageToken = pageToken
 		}
@@ -951,7 +2307,7 @@ func (c *registryGRPCClient) ListApis(ctx context.Context, req *rpcpb.ListApisRe
 		}
 		err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
 			var err error
-			resp, err = c.registryClient.ListApis(ctx, req, settings.GRPC...)
+			resp, err = c.registryClient.ListApiDeploymentRevisions(ctx, req, settings.GRPC...)
 			return err
 		}, opts...)
 		if err != nil {
@@ -959,7 +2315,7 @@ func (c *registryGRPCClient) ListApis(ctx context.Context, req *rpcpb.ListApisRe
 		}
 
 		it.Response = resp
-		return resp.GetApis(), resp.GetNextPageToken(), nil
+		return resp.GetApiDeployments(), resp.GetNextPageToken(), nil
 	}
 	fetch := func(pageSize int, pageToken string) (string, error) {
 		items, nextPageToken, err := it.InternalFetch(pageSize, pageToken)
@@ -977,7 +2333,7 @@ func (c *registryGRPCClient) ListApis(ctx context.Context, req *rpcpb.ListApisRe
 	return it
 }
 
-func (c *registryGRPCClient) GetApi(ctx context.Context, req *rpcpb.GetApiRequest, opts ...gax.CallOption) (*rpcpb.Api, error) {
+func (c *registryGRPCClient) RollbackApiDeployment(ctx context.Context, req *rpcpb.RollbackApiDeploymentRequest, opts ...gax.CallOption) (*rpcpb.ApiDeployment, error) {
 	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
 		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
 		defer cancel()
@@ -986,33 +2342,11 @@ func (c *registryGRPCClient) GetApi(ctx context.Context, req *rpcpb.GetApiReques
 	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
 
 	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
-	opts = append((*c.CallOptions).GetApi[0:len((*c.CallOptions).GetApi):len((*c.CallOptions).GetApi)], opts...)
-	var resp *rpcpb.Api
-	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
-		var err error
-		resp, err = c.registryClient.GetApi(ctx, req, settings.GRPC...)
-		return err
-	}, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return resp, nil
-}
-
-func (c *registryGRPCClient) CreateApi(ctx context.Context, req *rpcpb.CreateApiRequest, opts ...gax.CallOption) (*rpcpb.Api, error) {
-	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
-		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
-		defer cancel()
-		ctx = cctx
-	}
-	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "parent", url.QueryEscape(req.GetParent())))
-
-	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
-	opts = append((*c.CallOptions).CreateApi[0:len((*c.CallOptions).CreateApi):len((*c.CallOptions).CreateApi)], opts...)
-	var resp *rpcpb.Api
+	opts = append((*c.CallOptions).RollbackApiDeployment[0:len((*c.CallOptions).RollbackApiDeployment):len((*c.CallOptions).RollbackApiDeployment)], opts...)
+	var resp *rpcpb.ApiDeployment
 	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
 		var err error
-		resp, err = c.registryClient.CreateApi(ctx, req, settings.GRPC...)
+		resp, err = c.registryClient.RollbackApiDeployment(ctx, req, settings.GRPC...)
 		return err
 	}, opts...)
 	if err != nil {
@@ -1021,20 +2355,20 @@ func (c *registryGRPCClient) CreateApi(ctx context.Context, req *rpcpb.CreateApi
 	return resp, nil
 }
 
-func (c *registryGRPCClient) UpdateApi(ctx context.Context, req *rpcpb.UpdateApiRequest, opts ...gax.CallOption) (*rpcpb.Api, error) {
+func (c *registryGRPCClient) DeleteApiDeploymentRevision(ctx context.Context, req *rpcpb.DeleteApiDeploymentRevisionRequest, opts ...gax.CallOption) (*rpcpb.ApiDeployment, error) {
 	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
 		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
 		defer cancel()
 		ctx = cctx
 	}
-	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "api.name", url.QueryEscape(req.GetApi().GetName())))
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
 
 	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
-	opts = append((*c.CallOptions).UpdateApi[0:len((*c.CallOptions).UpdateApi):len((*c.CallOptions).UpdateApi)], opts...)
-	var resp *rpcpb.Api
+	opts = append((*c.CallOptions).DeleteApiDeploymentRevision[0:len((*c.CallOptions).DeleteApiDeploymentRevision):len((*c.CallOptions).DeleteApiDeploymentRevision)], opts...)
+	var resp *rpcpb.ApiDeployment
 	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
 		var err error
-		resp, err = c.registryClient.UpdateApi(ctx, req, settings.GRPC...)
+		resp, err = c.registryClient.DeleteApiDeploymentRevision(ctx, req, settings.GRPC...)
 		return err
 	}, opts...)
 	if err != nil {
@@ -1043,33 +2377,15 @@ func (c *registryGRPCClient) UpdateApi(ctx context.Context, req *rpcpb.UpdateApi
 	return resp, nil
 }
 
-func (c *registryGRPCClient) DeleteApi(ctx context.Context, req *rpcpb.DeleteApiRequest, opts ...gax.CallOption) error {
-	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
-		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
-		defer cancel()
-		ctx = cctx
-	}
-	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
-
-	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
-	opts = append((*c.CallOptions).DeleteApi[0:len((*c.CallOptions).DeleteApi):len((*c.CallOptions).DeleteApi)], opts...)
-	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
-		var err error
-		_, err = c.registryClient.DeleteApi(ctx, req, settings.GRPC...)
-		return err
-	}, opts...)
-	return err
-}
-
-func (c *registryGRPCClient) ListApiVersions(ctx context.Context, req *rpcpb.ListApiVersionsRequest, opts ...gax.CallOption) *ApiVersionIterator {
+func (c *registryGRPCClient) ListArtifacts(ctx context.Context, req *rpcpb.ListArtifactsRequest, opts ...gax.CallOption) *ArtifactIterator {
 	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "parent", url.QueryEscape(req.GetParent())))
 
 	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
-	opts = append((*c.CallOptions).ListApiVersions[0:len((*c.CallOptions).ListApiVersions):len((*c.CallOptions).ListApiVersions)], opts...)
-	it := &ApiVersionIterator{}
-	req = proto.Clone(req).(*rpcpb.ListApiVersionsRequest)
-	it.InternalFetch = func(pageSize int, pageToken string) ([]*rpcpb.ApiVersion, string, error) {
-		resp := &rpcpb.ListApiVersionsResponse{}
+	opts = append((*c.CallOptions).ListArtifacts[0:len((*c.CallOptions).ListArtifacts):len((*c.CallOptions).ListArtifacts)], opts...)
+	it := &ArtifactIterator{}
+	req = proto.Clone(req).(*rpcpb.ListArtifactsRequest)
+	it.InternalFetch = func(pageSize int, pageToken string) ([]*rpcpb.Artifact, string, error) {
+		resp := &rpcpb.ListArtifactsResponse{}
 		if pageToken != "" {
 			req.PageToken = pageToken
 		}
@@ -1080,7 +2396,7 @@ func (c *registryGRPCClient) ListApiVersions(ctx context.Context, req *rpcpb.Lis
 		}
 		err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
 			var err error
-			resp, err = c.registryClient.ListApiVersions(ctx, req, settings.GRPC...)
+			resp, err = c.registryClient.ListArtifacts(ctx, req, settings.GRPC...)
 			return err
 		}, opts...)
 		if err != nil {
@@ -1088,7 +2404,7 @@ func (c *registryGRPCClient) ListApiVersions(ctx context.Context, req *rpcpb.Lis
 		}
 
 		it.Response = resp
-		return resp.GetApiVersions(), resp.GetNextPageToken(), nil
+		return resp.GetArtifacts(), resp.GetNextPageToken(), nil
 	}
 	fetch := func(pageSize int, pageToken string) (string, error) {
 		items, nextPageToken, err := it.InternalFetch(pageSize, pageToken)
@@ -1106,7 +2422,7 @@ func (c *registryGRPCClient) ListApiVersions(ctx context.Context, req *rpcpb.Lis
 	return it
 }
 
-func (c *registryGRPCClient) GetApiVersion(ctx context.Context, req *rpcpb.GetApiVersionRequest, opts ...gax.CallOption) (*rpcpb.ApiVersion, error) {
+func (c *registryGRPCClient) GetArtifact(ctx context.Context, req *rpcpb.GetArtifactRequest, opts ...gax.CallOption) (*rpcpb.Artifact, error) {
 	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
 		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
 		defer cancel()
@@ -1115,11 +2431,11 @@ func (c *registryGRPCClient) GetApiVersion(ctx context.Context, req *rpcpb.GetAp
 	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
 
 	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
-	opts = append((*c.CallOptions).GetApiVersion[0:len((*c.CallOptions).GetApiVersion):len((*c.CallOptions).GetApiVersion)], opts...)
-	var resp *rpcpb.ApiVersion
+	opts = append((*c.CallOptions).GetArtifact[0:len((*c.CallOptions).GetArtifact):len((*c.CallOptions).GetArtifact)], opts...)
+	var resp *rpcpb.Artifact
 	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
 		var err error
-		resp, err = c.registryClient.GetApiVersion(ctx, req, settings.GRPC...)
+		resp, err = c.registryClient.GetArtifact(ctx, req, settings.GRPC...)
 		return err
 	}, opts...)
 	if err != nil {
@@ -1128,7 +2444,29 @@ func (c *registryGRPCClient) GetApiVersion(ctx context.Context, req *rpcpb.GetAp
 	return resp, nil
 }
 
-func (c *registryGRPCClient) CreateApiVersion(ctx context.Context, req *rpcpb.CreateApiVersionRequest, opts ...gax.CallOption) (*rpcpb.ApiVersion, error) {
+func (c *registryGRPCClient) GetArtifactContents(ctx context.Context, req *rpcpb.GetArtifactContentsRequest, opts ...gax.CallOption) (*httpbodypb.HttpBody, error) {
+	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
+		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
+		defer cancel()
+		ctx = cctx
+	}
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
+
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).GetArtifactContents[0:len((*c.CallOptions).GetArtifactContents):len((*c.CallOptions).GetArtifactContents)], opts...)
+	var resp *httpbodypb.HttpBody
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.registryClient.GetArtifactContents(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *registryGRPCClient) CreateArtifact(ctx context.Context, req *rpcpb.CreateArtifactRequest, opts ...gax.CallOption) (*rpcpb.Artifact, error) {
 	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
 		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
 		defer cancel()
@@ -1137,11 +2475,11 @@ func (c *registryGRPCClient) CreateApiVersion(ctx context.Context, req *rpcpb.Cr
 	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "parent", url.QueryEscape(req.GetParent())))
 
 	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
-	opts = append((*c.CallOptions).CreateApiVersion[0:len((*c.CallOptions).CreateApiVersion):len((*c.CallOptions).CreateApiVersion)], opts...)
-	var resp *rpcpb.ApiVersion
+	opts = append((*c.CallOptions).CreateArtifact[0:len((*c.CallOptions).CreateArtifact):len((*c.CallOptions).CreateArtifact)], opts...)
+	var resp *rpcpb.Artifact
 	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
 		var err error
-		resp, err = c.registryClient.CreateApiVersion(ctx, req, settings.GRPC...)
+		resp, err = c.registryClient.CreateArtifact(ctx, req, settings.GRPC...)
 		return err
 	}, opts...)
 	if err != nil {
@@ -1150,20 +2488,20 @@ func (c *registryGRPCClient) CreateApiVersion(ctx context.Context, req *rpcpb.Cr
 	return resp, nil
 }
 
-func (c *registryGRPCClient) UpdateApiVersion(ctx context.Context, req *rpcpb.UpdateApiVersionRequest, opts ...gax.CallOption) (*rpcpb.ApiVersion, error) {
+func (c *registryGRPCClient) ReplaceArtifact(ctx context.Context, req *rpcpb.ReplaceArtifactRequest, opts ...gax.CallOption) (*rpcpb.Artifact, error) {
 	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
 		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
 		defer cancel()
 		ctx = cctx
 	}
-	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "api_version.name", url.QueryEscape(req.GetApiVersion().GetName())))
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "artifact.name", url.QueryEscape(req.GetArtifact().GetName())))
 
 	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
-	opts = append((*c.CallOptions).UpdateApiVersion[0:len((*c.CallOptions).UpdateApiVersion):len((*c.CallOptions).UpdateApiVersion)], opts...)
-	var resp *rpcpb.ApiVersion
+	opts = append((*c.CallOptions).ReplaceArtifact[0:len((*c.CallOptions).ReplaceArtifact):len((*c.CallOptions).ReplaceArtifact)], opts...)
+	var resp *rpcpb.Artifact
 	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
 		var err error
-		resp, err = c.registryClient.UpdateApiVersion(ctx, req, settings.GRPC...)
+		resp, err = c.registryClient.ReplaceArtifact(ctx, req, settings.GRPC...)
 		return err
 	}, opts...)
 	if err != nil {
@@ -1172,7 +2510,7 @@ func (c *registryGRPCClient) UpdateApiVersion(ctx context.Context, req *rpcpb.Up
 	return resp, nil
 }
 
-func (c *registryGRPCClient) DeleteApiVersion(ctx context.Context, req *rpcpb.DeleteApiVersionRequest, opts ...gax.CallOption) error {
+func (c *registryGRPCClient) DeleteArtifact(ctx context.Context, req *rpcpb.DeleteArtifactRequest, opts ...gax.CallOption) error {
 	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
 		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
 		defer cancel()
@@ -1181,24 +2519,68 @@ func (c *registryGRPCClient) DeleteApiVersion(ctx context.Context, req *rpcpb.De
 	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
 
 	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
-	opts = append((*c.CallOptions).DeleteApiVersion[0:len((*c.CallOptions).DeleteApiVersion):len((*c.CallOptions).DeleteApiVersion)], opts...)
+	opts = append((*c.CallOptions).DeleteArtifact[0:len((*c.CallOptions).DeleteArtifact):len((*c.CallOptions).DeleteArtifact)], opts...)
 	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
 		var err error
-		_, err = c.registryClient.DeleteApiVersion(ctx, req, settings.GRPC...)
+		_, err = c.registryClient.DeleteArtifact(ctx, req, settings.GRPC...)
 		return err
 	}, opts...)
 	return err
 }
 
-func (c *registryGRPCClient) ListApiSpecs(ctx context.Context, req *rpcpb.ListApiSpecsRequest, opts ...gax.CallOption) *ApiSpecIterator {
-	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "parent", url.QueryEscape(req.GetParent())))
+func (c *registryGRPCClient) UndeleteArtifact(ctx context.Context, req *rpcpb.UndeleteArtifactRequest, opts ...gax.CallOption) (*rpcpb.Artifact, error) {
+	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
+		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
+		defer cancel()
+		ctx = cctx
+	}
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
 
 	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
-	opts = append((*c.CallOptions).ListApiSpecs[0:len((*c.CallOptions).ListApiSpecs):len((*c.CallOptions).ListApiSpecs)], opts...)
-	it := &ApiSpecIterator{}
-	req = proto.Clone(req).(*rpcpb.ListApiSpecsRequest)
-	it.InternalFetch = func(pageSize int, pageToken string) ([]*rpcpb.ApiSpec, string, error) {
-		resp := &rpcpb.ListApiSpecsResponse{}
+	opts = append((*c.CallOptions).UndeleteArtifact[0:len((*c.CallOptions).UndeleteArtifact):len((*c.CallOptions).UndeleteArtifact)], opts...)
+	var resp *rpcpb.Artifact
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.registryClient.UndeleteArtifact(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *registryGRPCClient) TagArtifactRevision(ctx context.Context, req *rpcpb.TagArtifactRevisionRequest, opts ...gax.CallOption) (*rpcpb.Artifact, error) {
+	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
+		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
+		defer cancel()
+		ctx = cctx
+	}
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
+
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).TagArtifactRevision[0:len((*c.CallOptions).TagArtifactRevision):len((*c.CallOptions).TagArtifactRevision)], opts...)
+	var resp *rpcpb.Artifact
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.registryClient.TagArtifactRevision(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *registryGRPCClient) ListArtifactRevisions(ctx context.Context, req *rpcpb.ListArtifactRevisionsRequest, opts ...gax.CallOption) *ArtifactIterator {
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
+
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).ListArtifactRevisions[0:len((*c.CallOptions).ListArtifactRevisions):len((*c.CallOptions).ListArtifactRevisions)], opts...)
+	it := &ArtifactIterator{}
+	req = proto.Clone(req).(*rpcpb.ListArtifactRevisionsRequest)
+	it.InternalFetch = func(pageSize int, pageToken string) ([]*rpcpb.Artifact, string, error) {
+		resp := &rpcpb.ListArtifactRevisionsResponse{}
 		if pageToken != "" {
 			req.PageToken = pageToken
 		}
@@ -1209,7 +2591,7 @@ func (c *registryGRPCClient) ListApiSpecs(ctx context.Context, req *rpcpb.ListAp
 		}
 		err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
 			var err error
-			resp, err = c.registryClient.ListApiSpecs(ctx, req, settings.GRPC...)
+			resp, err = c.registryClient.ListArtifactRevisions(ctx, req, settings.GRPC...)
 			return err
 		}, opts...)
 		if err != nil {
@@ -1217,7 +2599,7 @@ func (c *registryGRPCClient) ListApiSpecs(ctx context.Context, req *rpcpb.ListAp
 		}
 
 		it.Response = resp
-		return resp.GetApiSpecs(), resp.GetNextPageToken(), nil
+		return resp.GetArtifacts(), resp.GetNextPageToken(), nil
 	}
 	fetch := func(pageSize int, pageToken string) (string, error) {
 		items, nextPageToken, err := it.InternalFetch(pageSize, pageToken)
@@ -1227,28 +2609,50 @@ func (c *registryGRPCClient) ListApiSpecs(ctx context.Context, req *rpcpb.ListAp
 		it.items = append(it.items, items...)
 		return nextPageToken, nil
 	}
-
-	it.pageInfo, it.nextFunc = iterator.NewPageInfo(fetch, it.bufLen, it.takeBuf)
-	it.pageInfo.MaxSize = int(req.GetPageSize())
-	it.pageInfo.Token = req.GetPageToken()
-
-	return it
+
+	it.pageInfo, it.nextFunc = iterator.NewPageInfo(fetch, it.bufLen, it.takeBuf)
+	it.pageInfo.MaxSize = int(req.GetPageSize())
+	it.pageInfo.Token = req.GetPageToken()
+
+	return it
+}
+
+func (c *registryGRPCClient) RollbackArtifact(ctx context.Context, req *rpcpb.RollbackArtifactRequest, opts ...gax.CallOption) (*rpcpb.Artifact, error) {
+	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
+		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
+		defer cancel()
+		ctx = cctx
+	}
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
+
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).RollbackArtifact[0:len((*c.CallOptions).RollbackArtifact):len((*c.CallOptions).RollbackArtifact)], opts...)
+	var resp *rpcpb.Artifact
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.registryClient.RollbackArtifact(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
 }
 
-func (c *registryGRPCClient) GetApiSpec(ctx context.Context, req *rpcpb.GetApiSpecRequest, opts ...gax.CallOption) (*rpcpb.ApiSpec, error) {
+func (c *registryGRPCClient) BatchCreateApis(ctx context.Context, req *rpcpb.BatchCreateApisRequest, opts ...gax.CallOption) (*rpcpb.BatchCreateApisResponse, error) {
 	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
 		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
 		defer cancel()
 		ctx = cctx
 	}
-	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "parent", url.QueryEscape(req.GetParent())))
 
 	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
-	opts = append((*c.CallOptions).GetApiSpec[0:len((*c.CallOptions).GetApiSpec):len((*c.CallOptions).GetApiSpec)], opts...)
-	var resp *rpcpb.ApiSpec
+	opts = append((*c.CallOptions).BatchCreateApis[0:len((*c.CallOptions).BatchCreateApis):len((*c.CallOptions).BatchCreateApis)], opts...)
+	var resp *rpcpb.BatchCreateApisResponse
 	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
 		var err error
-		resp, err = c.registryClient.GetApiSpec(ctx, req, settings.GRPC...)
+		resp, err = c.registryClient.BatchCreateApis(ctx, req, settings.GRPC...)
 		return err
 	}, opts...)
 	if err != nil {
@@ -1257,20 +2661,20 @@ func (c *registryGRPCClient) GetApiSpec(ctx context.Context, req *rpcpb.GetApiSp
 	return resp, nil
 }
 
-func (c *registryGRPCClient) GetApiSpecContents(ctx context.Context, req *rpcpb.GetApiSpecContentsRequest, opts ...gax.CallOption) (*httpbodypb.HttpBody, error) {
+func (c *registryGRPCClient) BatchUpdateApis(ctx context.Context, req *rpcpb.BatchUpdateApisRequest, opts ...gax.CallOption) (*rpcpb.BatchUpdateApisResponse, error) {
 	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
 		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
 		defer cancel()
 		ctx = cctx
 	}
-	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "parent", url.QueryEscape(req.GetParent())))
 
 	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
-	opts = append((*c.CallOptions).GetApiSpecContents[0:len((*c.CallOptions).GetApiSpecContents):len((*c.CallOptions).GetApiSpecContents)], opts...)
-	var resp *httpbodypb.HttpBody
+	opts = append((*c.CallOptions).BatchUpdateApis[0:len((*c.CallOptions).BatchUpdateApis):len((*c.CallOptions).BatchUpdateApis)], opts...)
+	var resp *rpcpb.BatchUpdateApisResponse
 	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
 		var err error
-		resp, err = c.registryClient.GetApiSpecContents(ctx, req, settings.GRPC...)
+		resp, err = c.registryClient.BatchUpdateApis(ctx, req, settings.GRPC...)
 		return err
 	}, opts...)
 	if err != nil {
@@ -1279,7 +2683,7 @@ func (c *registryGRPCClient) GetApiSpecContents(ctx context.Context, req *rpcpb.
 	return resp, nil
 }
 
-func (c *registryGRPCClient) CreateApiSpec(ctx context.Context, req *rpcpb.CreateApiSpecRequest, opts ...gax.CallOption) (*rpcpb.ApiSpec, error) {
+func (c *registryGRPCClient) BatchDeleteApis(ctx context.Context, req *rpcpb.BatchDeleteApisRequest, opts ...gax.CallOption) (*rpcpb.BatchDeleteApisResponse, error) {
 	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
 		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
 		defer cancel()
@@ -1288,11 +2692,11 @@ func (c *registryGRPCClient) CreateApiSpec(ctx context.Context, req *rpcpb.Creat
 	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "parent", url.QueryEscape(req.GetParent())))
 
 	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
-	opts = append((*c.CallOptions).CreateApiSpec[0:len((*c.CallOptions).CreateApiSpec):len((*c.CallOptions).CreateApiSpec)], opts...)
-	var resp *rpcpb.ApiSpec
+	opts = append((*c.CallOptions).BatchDeleteApis[0:len((*c.CallOptions).BatchDeleteApis):len((*c.CallOptions).BatchDeleteApis)], opts...)
+	var resp *rpcpb.BatchDeleteApisResponse
 	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
 		var err error
-		resp, err = c.registryClient.CreateApiSpec(ctx, req, settings.GRPC...)
+		resp, err = c.registryClient.BatchDeleteApis(ctx, req, settings.GRPC...)
 		return err
 	}, opts...)
 	if err != nil {
@@ -1301,20 +2705,20 @@ func (c *registryGRPCClient) CreateApiSpec(ctx context.Context, req *rpcpb.Creat
 	return resp, nil
 }
 
-func (c *registryGRPCClient) UpdateApiSpec(ctx context.Context, req *rpcpb.UpdateApiSpecRequest, opts ...gax.CallOption) (*rpcpb.ApiSpec, error) {
+func (c *registryGRPCClient) BatchCreateApiVersions(ctx context.Context, req *rpcpb.BatchCreateApiVersionsRequest, opts ...gax.CallOption) (*rpcpb.BatchCreateApiVersionsResponse, error) {
 	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
 		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
 		defer cancel()
 		ctx = cctx
 	}
-	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "api_spec.name", url.QueryEscape(req.GetApiSpec().GetName())))
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "parent", url.QueryEscape(req.GetParent())))
 
 	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
-	opts = append((*c.CallOptions).UpdateApiSpec[0:len((*c.CallOptions).UpdateApiSpec):len((*c.CallOptions).UpdateApiSpec)], opts...)
-	var resp *rpcpb.ApiSpec
+	opts = append((*c.CallOptions).BatchCreateApiVersions[0:len((*c.CallOptions).BatchCreateApiVersions):len((*c.CallOptions).BatchCreateApiVersions)], opts...)
+	var resp *rpcpb.BatchCreateApiVersionsResponse
 	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
 		var err error
-		resp, err = c.registryClient.UpdateApiSpec(ctx, req, settings.GRPC...)
+		resp, err = c.registryClient.BatchCreateApiVersions(ctx, req, settings.GRPC...)
 		return err
 	}, opts...)
 	if err != nil {
@@ -1323,38 +2727,42 @@ func (c *registryGRPCClient) UpdateApiSpec(ctx context.Context, req *rpcpb.Updat
 	return resp, nil
 }
 
-func (c *registryGRPCClient) DeleteApiSpec(ctx context.Context, req *rpcpb.DeleteApiSpecRequest, opts ...gax.CallOption) error {
+func (c *registryGRPCClient) BatchUpdateApiVersions(ctx context.Context, req *rpcpb.BatchUpdateApiVersionsRequest, opts ...gax.CallOption) (*rpcpb.BatchUpdateApiVersionsResponse, error) {
 	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
 		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
 		defer cancel()
 		ctx = cctx
 	}
-	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "parent", url.QueryEscape(req.GetParent())))
 
 	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
-	opts = append((*c.CallOptions).DeleteApiSpec[0:len((*c.CallOptions).DeleteApiSpec):len((*c.CallOptions).DeleteApiSpec)], opts...)
+	opts = append((*c.CallOptions).BatchUpdateApiVersions[0:len((*c.CallOptions).BatchUpdateApiVersions):len((*c.CallOptions).BatchUpdateApiVersions)], opts...)
+	var resp *rpcpb.BatchUpdateApiVersionsResponse
 	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
 		var err error
-		_, err = c.registryClient.DeleteApiSpec(ctx, req, settings.GRPC...)
+		resp, err = c.registryClient.BatchUpdateApiVersions(ctx, req, settings.GRPC...)
 		return err
 	}, opts...)
-	return err
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
 }
 
-func (c *registryGRPCClient) TagApiSpecRevision(ctx context.Context, req *rpcpb.TagApiSpecRevisionRequest, opts ...gax.CallOption) (*rpcpb.ApiSpec, error) {
+func (c *registryGRPCClient) BatchDeleteApiVersions(ctx context.Context, req *rpcpb.BatchDeleteApiVersionsRequest, opts ...gax.CallOption) (*rpcpb.BatchDeleteApiVersionsResponse, error) {
 	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
 		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
 		defer cancel()
 		ctx = cctx
 	}
-	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "parent", url.QueryEscape(req.GetParent())))
 
 	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
-	opts = append((*c.CallOptions).TagApiSpecRevision[0:len((*c.CallOptions).TagApiSpecRevision):len((*c.CallOptions).TagApiSpecRevision)], opts...)
-	var resp *rpcpb.ApiSpec
+	opts = append((*c.CallOptions).BatchDeleteApiVersions[0:len((*c.CallOptions).BatchDeleteApiVersions):len((*c.CallOptions).BatchDeleteApiVersions)], opts...)
+	var resp *rpcpb.BatchDeleteApiVersionsResponse
 	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
 		var err error
-		resp, err = c.registryClient.TagApiSpecRevision(ctx, req, settings.GRPC...)
+		resp, err = c.registryClient.BatchDeleteApiVersions(ctx, req, settings.GRPC...)
 		return err
 	}, opts...)
 	if err != nil {
@@ -1363,65 +2771,42 @@ func (c *registryGRPCClient) TagApiSpecRevision(ctx context.Context, req *rpcpb.
 	return resp, nil
 }
 
-func (c *registryGRPCClient) ListApiSpecRevisions(ctx context.Context, req *rpcpb.ListApiSpecRevisionsRequest, opts ...gax.CallOption) *ApiSpecIterator {
-	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
+func (c *registryGRPCClient) BatchCreateApiSpecs(ctx context.Context, req *rpcpb.BatchCreateApiSpecsRequest, opts ...gax.CallOption) (*rpcpb.BatchCreateApiSpecsResponse, error) {
+	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
+		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
+		defer cancel()
+		ctx = cctx
+	}
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "parent", url.QueryEscape(req.GetParent())))
 
 	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
-	opts = append((*c.CallOptions).ListApiSpecRevisions[0:len((*c.CallOptions).ListApiSpecRevisions):len((*c.CallOptions).ListApiSpecRevisions)], opts...)
-	it := &ApiSpecIterator{}
-	req = proto.Clone(req).(*rpcpb.ListApiSpecRevisionsRequest)
-	it.InternalFetch = func(pageSize int, pageToken string) ([]*rpcpb.ApiSpec, string, error) {
-		resp := &rpcpb.ListApiSpecRevisionsResponse{}
-		if pageToken != "" {
-			req.PageToken = pageToken
-		}
-		if pageSize > math.MaxInt32 {
-			req.PageSize = math.MaxInt32
-		} else if pageSize != 0 {
-			req.PageSize = int32(pageSize)
-		}
-		err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
-			var err error
-			resp, err = c.registryClient.ListApiSpecRevisions(ctx, req, settings.GRPC...)
-			return err
-		}, opts...)
-		if err != nil {
-			return nil, "", err
-		}
-
-		it.Response = resp
-		return resp.GetApiSpecs(), resp.GetNextPageToken(), nil
-	}
-	fetch := func(pageSize int, pageToken string) (string, error) {
-		items, nextPageToken, err := it.InternalFetch(pageSize, pageToken)
-		if err != nil {
-			return "", err
-		}
-		it.items = append(it.items, items...)
-		return nextPageToken, nil
+	opts = append((*c.CallOptions).BatchCreateApiSpecs[0:len((*c.CallOptions).BatchCreateApiSpecs):len((*c.CallOptions).BatchCreateApiSpecs)], opts...)
+	var resp *rpcpb.BatchCreateApiSpecsResponse
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.registryClient.BatchCreateApiSpecs(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
 	}
-
-	it.pageInfo, it.nextFunc = iterator.NewPageInfo(fetch, it.bufLen, it.takeBuf)
-	it.pageInfo.MaxSize = int(req.GetPageSize())
-	it.pageInfo.Token = req.GetPageToken()
-
-	return it
+	return resp, nil
 }
 
-func (c *registryGRPCClient) RollbackApiSpec(ctx context.Context, req *rpcpb.RollbackApiSpecRequest, opts ...gax.CallOption) (*rpcpb.ApiSpec, error) {
+func (c *registryGRPCClient) BatchUpdateApiSpecs(ctx context.Context, req *rpcpb.BatchUpdateApiSpecsRequest, opts ...gax.CallOption) (*rpcpb.BatchUpdateApiSpecsResponse, error) {
 	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
 		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
 		defer cancel()
 		ctx = cctx
 	}
-	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "parent", url.QueryEscape(req.GetParent())))
 
 	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
-	opts = append((*c.CallOptions).RollbackApiSpec[0:len((*c.CallOptions).RollbackApiSpec):len((*c.CallOptions).RollbackApiSpec)], opts...)
-	var resp *rpcpb.ApiSpec
+	opts = append((*c.CallOptions).BatchUpdateApiSpecs[0:len((*c.CallOptions).BatchUpdateApiSpecs):len((*c.CallOptions).BatchUpdateApiSpecs)], opts...)
+	var resp *rpcpb.BatchUpdateApiSpecsResponse
 	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
 		var err error
-		resp, err = c.registryClient.RollbackApiSpec(ctx, req, settings.GRPC...)
+		resp, err = c.registryClient.BatchUpdateApiSpecs(ctx, req, settings.GRPC...)
 		return err
 	}, opts...)
 	if err != nil {
@@ -1430,20 +2815,20 @@ func (c *registryGRPCClient) RollbackApiSpec(ctx context.Context, req *rpcpb.Rol
 	return resp, nil
 }
 
-func (c *registryGRPCClient) DeleteApiSpecRevision(ctx context.Context, req *rpcpb.DeleteApiSpecRevisionRequest, opts ...gax.CallOption) (*rpcpb.ApiSpec, error) {
+func (c *registryGRPCClient) BatchDeleteApiSpecs(ctx context.Context, req *rpcpb.BatchDeleteApiSpecsRequest, opts ...gax.CallOption) (*rpcpb.BatchDeleteApiSpecsResponse, error) {
 	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
 		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
 		defer cancel()
 		ctx = cctx
 	}
-	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "parent", url.QueryEscape(req.GetParent())))
 
 	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
-	opts = append((*c.CallOptions).DeleteApiSpecRevision[0:len((*c.CallOptions).DeleteApiSpecRevision):len((*c.CallOptions).DeleteApiSpecRevision)], opts...)
-	var resp *rpcpb.ApiSpec
+	opts = append((*c.CallOptions).BatchDeleteApiSpecs[0:len((*c.CallOptions).BatchDeleteApiSpecs):len((*c.CallOptions).BatchDeleteApiSpecs)], opts...)
+	var resp *rpcpb.BatchDeleteApiSpecsResponse
 	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
 		var err error
-		resp, err = c.registryClient.DeleteApiSpecRevision(ctx, req, settings.GRPC...)
+		resp, err = c.registryClient.BatchDeleteApiSpecs(ctx, req, settings.GRPC...)
 		return err
 	}, opts...)
 	if err != nil {
@@ -1452,65 +2837,42 @@ func (c *registryGRPCClient) DeleteApiSpecRevision(ctx context.Context, req *rpc
 	return resp, nil
 }
 
-func (c *registryGRPCClient) ListApiDeployments(ctx context.Context, req *rpcpb.ListApiDeploymentsRequest, opts ...gax.CallOption) *ApiDeploymentIterator {
+func (c *registryGRPCClient) BatchCreateApiDeployments(ctx context.Context, req *rpcpb.BatchCreateApiDeploymentsRequest, opts ...gax.CallOption) (*rpcpb.BatchCreateApiDeploymentsResponse, error) {
+	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
+		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
+		defer cancel()
+		ctx = cctx
+	}
 	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "parent", url.QueryEscape(req.GetParent())))
 
 	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
-	opts = append((*c.CallOptions).ListApiDeployments[0:len((*c.CallOptions).ListApiDeployments):len((*c.CallOptions).ListApiDeployments)], opts...)
-	it := &ApiDeploymentIterator{}
-	req = proto.Clone(req).(*rpcpb.ListApiDeploymentsRequest)
-	it.InternalFetch = func(pageSize int, pageToken string) ([]*rpcpb.ApiDeployment, string, error) {
-		resp := &rpcpb.ListApiDeploymentsResponse{}
-		if pageToken != "" {
-			req.PageToken = pageToken
-		}
-		if pageSize > math.MaxInt32 {
-			req.PageSize = math.MaxInt32
-		} else if pageSize != 0 {
-			req.PageSize = int32(pageSize)
-		}
-		err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
-			var err error
-			resp, err = c.registryClient.ListApiDeployments(ctx, req, settings.GRPC...)
-			return err
-		}, opts...)
-		if err != nil {
-			return nil, "", err
-		}
-
-		it.Response = resp
-		return resp.GetApiDeployments(), resp.GetNextPageToken(), nil
-	}
-	fetch := func(pageSize int, pageToken string) (string, error) {
-		items, nextPageToken, err := it.InternalFetch(pageSize, pageToken)
-		if err != nil {
-			return "", err
-		}
-		it.items = append(it.items, items...)
-		return nextPageToken, nil
+	opts = append((*c.CallOptions).BatchCreateApiDeployments[0:len((*c.CallOptions).BatchCreateApiDeployments):len((*c.CallOptions).BatchCreateApiDeployments)], opts...)
+	var resp *rpcpb.BatchCreateApiDeploymentsResponse
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.registryClient.BatchCreateApiDeployments(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
 	}
-
-	it.pageInfo, it.nextFunc = iterator.NewPageInfo(fetch, it.bufLen, it.takeBuf)
-	it.pageInfo.MaxSize = int(req.GetPageSize())
-	it.pageInfo.Token = req.GetPageToken()
-
-	return it
+	return resp, nil
 }
 
-func (c *registryGRPCClient) GetApiDeployment(ctx context.Context, req *rpcpb.GetApiDeploymentRequest, opts ...gax.CallOption) (*rpcpb.ApiDeployment, error) {
+func (c *registryGRPCClient) BatchUpdateApiDeployments(ctx context.Context, req *rpcpb.BatchUpdateApiDeploymentsRequest, opts ...gax.CallOption) (*rpcpb.BatchUpdateApiDeploymentsResponse, error) {
 	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
 		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
 		defer cancel()
 		ctx = cctx
 	}
-	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "parent", url.QueryEscape(req.GetParent())))
 
 	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
-	opts = append((*c.CallOptions).GetApiDeployment[0:len((*c.CallOptions).GetApiDeployment):len((*c.CallOptions).GetApiDeployment)], opts...)
-	var resp *rpcpb.ApiDeployment
+	opts = append((*c.CallOptions).BatchUpdateApiDeployments[0:len((*c.CallOptions).BatchUpdateApiDeployments):len((*c.CallOptions).BatchUpdateApiDeployments)], opts...)
+	var resp *rpcpb.BatchUpdateApiDeploymentsResponse
 	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
 		var err error
-		resp, err = c.registryClient.GetApiDeployment(ctx, req, settings.GRPC...)
+		resp, err = c.registryClient.BatchUpdateApiDeployments(ctx, req, settings.GRPC...)
 		return err
 	}, opts...)
 	if err != nil {
@@ -1519,7 +2881,7 @@ func (c *registryGRPCClient) GetApiDeployment(ctx context.Context, req *rpcpb.Ge
 	return resp, nil
 }
 
-func (c *registryGRPCClient) CreateApiDeployment(ctx context.Context, req *rpcpb.CreateApiDeploymentRequest, opts ...gax.CallOption) (*rpcpb.ApiDeployment, error) {
+func (c *registryGRPCClient) BatchDeleteApiDeployments(ctx context.Context, req *rpcpb.BatchDeleteApiDeploymentsRequest, opts ...gax.CallOption) (*rpcpb.BatchDeleteApiDeploymentsResponse, error) {
 	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
 		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
 		defer cancel()
@@ -1528,11 +2890,11 @@ func (c *registryGRPCClient) CreateApiDeployment(ctx context.Context, req *rpcpb
 	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "parent", url.QueryEscape(req.GetParent())))
 
 	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
-	opts = append((*c.CallOptions).CreateApiDeployment[0:len((*c.CallOptions).CreateApiDeployment):len((*c.CallOptions).CreateApiDeployment)], opts...)
-	var resp *rpcpb.ApiDeployment
+	opts = append((*c.CallOptions).BatchDeleteApiDeployments[0:len((*c.CallOptions).BatchDeleteApiDeployments):len((*c.CallOptions).BatchDeleteApiDeployments)], opts...)
+	var resp *rpcpb.BatchDeleteApiDeploymentsResponse
 	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
 		var err error
-		resp, err = c.registryClient.CreateApiDeployment(ctx, req, settings.GRPC...)
+		resp, err = c.registryClient.BatchDeleteApiDeployments(ctx, req, settings.GRPC...)
 		return err
 	}, opts...)
 	if err != nil {
@@ -1541,20 +2903,20 @@ func (c *registryGRPCClient) CreateApiDeployment(ctx context.Context, req *rpcpb
 	return resp, nil
 }
 
-func (c *registryGRPCClient) UpdateApiDeployment(ctx context.Context, req *rpcpb.UpdateApiDeploymentRequest, opts ...gax.CallOption) (*rpcpb.ApiDeployment, error) {
+func (c *registryGRPCClient) BatchCreateArtifacts(ctx context.Context, req *rpcpb.BatchCreateArtifactsRequest, opts ...gax.CallOption) (*rpcpb.BatchCreateArtifactsResponse, error) {
 	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
 		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
 		defer cancel()
 		ctx = cctx
 	}
-	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "api_deployment.name", url.QueryEscape(req.GetApiDeployment().GetName())))
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "parent", url.QueryEscape(req.GetParent())))
 
 	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
-	opts = append((*c.CallOptions).UpdateApiDeployment[0:len((*c.CallOptions).UpdateApiDeployment):len((*c.CallOptions).UpdateApiDeployment)], opts...)
-	var resp *rpcpb.ApiDeployment
+	opts = append((*c.CallOptions).BatchCreateArtifacts[0:len((*c.CallOptions).BatchCreateArtifacts):len((*c.CallOptions).BatchCreateArtifacts)], opts...)
+	var resp *rpcpb.BatchCreateArtifactsResponse
 	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
 		var err error
-		resp, err = c.registryClient.UpdateApiDeployment(ctx, req, settings.GRPC...)
+		resp, err = c.registryClient.BatchCreateArtifacts(ctx, req, settings.GRPC...)
 		return err
 	}, opts...)
 	if err != nil {
@@ -1563,38 +2925,42 @@ func (c *registryGRPCClient) UpdateApiDeployment(ctx context.Context, req *rpcpb
 	return resp, nil
 }
 
-func (c *registryGRPCClient) DeleteApiDeployment(ctx context.Context, req *rpcpb.DeleteApiDeploymentRequest, opts ...gax.CallOption) error {
+func (c *registryGRPCClient) BatchReplaceArtifacts(ctx context.Context, req *rpcpb.BatchReplaceArtifactsRequest, opts ...gax.CallOption) (*rpcpb.BatchReplaceArtifactsResponse, error) {
 	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
 		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
 		defer cancel()
 		ctx = cctx
 	}
-	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "parent", url.QueryEscape(req.GetParent())))
 
 	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
-	opts = append((*c.CallOptions).DeleteApiDeployment[0:len((*c.CallOptions).DeleteApiDeployment):len((*c.CallOptions).DeleteApiDeployment)], opts...)
+	opts = append((*c.CallOptions).BatchReplaceArtifacts[0:len((*c.CallOptions).BatchReplaceArtifacts):len((*c.CallOptions).BatchReplaceArtifacts)], opts...)
+	var resp *rpcpb.BatchReplaceArtifactsResponse
 	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
 		var err error
-		_, err = c.registryClient.DeleteApiDeployment(ctx, req, settings.GRPC...)
+		resp, err = c.registryClient.BatchReplaceArtifacts(ctx, req, settings.GRPC...)
 		return err
 	}, opts...)
-	return err
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
 }
 
-func (c *registryGRPCClient) TagApiDeploymentRevision(ctx context.Context, req *rpcpb.TagApiDeploymentRevisionRequest, opts ...gax.CallOption) (*rpcpb.ApiDeployment, error) {
+func (c *registryGRPCClient) BatchDeleteArtifacts(ctx context.Context, req *rpcpb.BatchDeleteArtifactsRequest, opts ...gax.CallOption) (*rpcpb.BatchDeleteArtifactsResponse, error) {
 	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
 		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
 		defer cancel()
 		ctx = cctx
 	}
-	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "parent", url.QueryEscape(req.GetParent())))
 
 	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
-	opts = append((*c.CallOptions).TagApiDeploymentRevision[0:len((*c.CallOptions).TagApiDeploymentRevision):len((*c.CallOptions).TagApiDeploymentRevision)], opts...)
-	var resp *rpcpb.ApiDeployment
+	opts = append((*c.CallOptions).BatchDeleteArtifacts[0:len((*c.CallOptions).BatchDeleteArtifacts):len((*c.CallOptions).BatchDeleteArtifacts)], opts...)
+	var resp *rpcpb.BatchDeleteArtifactsResponse
 	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
 		var err error
-		resp, err = c.registryClient.TagApiDeploymentRevision(ctx, req, settings.GRPC...)
+		resp, err = c.registryClient.BatchDeleteArtifacts(ctx, req, settings.GRPC...)
 		return err
 	}, opts...)
 	if err != nil {
@@ -1603,15 +2969,15 @@ func (c *registryGRPCClient) TagApiDeploymentRevision(ctx context.Context, req *
 	return resp, nil
 }
 
-func (c *registryGRPCClient) ListApiDeploymentRevisions(ctx context.Context, req *rpcpb.ListApiDeploymentRevisionsRequest, opts ...gax.CallOption) *ApiDeploymentIterator {
+func (c *registryGRPCClient) ListReferences(ctx context.Context, req *rpcpb.ListReferencesRequest, opts ...gax.CallOption) *ReferenceIterator {
 	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
 
 	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
-	opts = append((*c.CallOptions).ListApiDeploymentRevisions[0:len((*c.CallOptions).ListApiDeploymentRevisions):len((*c.CallOptions).ListApiDeploymentRevisions)], opts...)
-	it := &ApiDeploymentIterator{}
-	req = proto.Clone(req).(*rpcpb.ListApiDeploymentRevisionsRequest)
-	it.InternalFetch = func(pageSize int, pageToken string) ([]*rpcpb.ApiDeployment, string, error) {
-		resp := &rpcpb.ListApiDeploymentRevisionsResponse{}
+	opts = append((*c.CallOptions).ListReferences[0:len((*c.CallOptions).ListReferences):len((*c.CallOptions).ListReferences)], opts...)
+	it := &ReferenceIterator{}
+	req = proto.Clone(req).(*rpcpb.ListReferencesRequest)
+	it.InternalFetch = func(pageSize int, pageToken string) ([]*rpcpb.Reference, string, error) {
+		resp := &rpcpb.ListReferencesResponse{}
 		if pageToken != "" {
 			req.PageToken = pageToken
 		}
@@ -1622,7 +2988,7 @@ func (c *registryGRPCClient) ListApiDeploymentRevisions(ctx context.Context, req
 		}
 		err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
 			var err error
-			resp, err = c.registryClient.ListApiDeploymentRevisions(ctx, req, settings.GRPC...)
+			resp, err = c.registryClient.ListReferences(ctx, req, settings.GRPC...)
 			return err
 		}, opts...)
 		if err != nil {
@@ -1630,7 +2996,7 @@ func (c *registryGRPCClient) ListApiDeploymentRevisions(ctx context.Context, req
 		}
 
 		it.Response = resp
-		return resp.GetApiDeployments(), resp.GetNextPageToken(), nil
+		return resp.GetReferences(), resp.GetNextPageToken(), nil
 	}
 	fetch := func(pageSize int, pageToken string) (string, error) {
 		items, nextPageToken, err := it.InternalFetch(pageSize, pageToken)
@@ -1648,59 +3014,60 @@ func (c *registryGRPCClient) ListApiDeploymentRevisions(ctx context.Context, req
 	return it
 }
 
-func (c *registryGRPCClient) RollbackApiDeployment(ctx context.Context, req *rpcpb.RollbackApiDeploymentRequest, opts ...gax.CallOption) (*rpcpb.ApiDeployment, error) {
-	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
-		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
-		defer cancel()
-		ctx = cctx
-	}
+func (c *registryGRPCClient) ListReferrers(ctx context.Context, req *rpcpb.ListReferrersRequest, opts ...gax.CallOption) *ReferenceIterator {
 	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
 
 	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
-	opts = append((*c.CallOptions).RollbackApiDeployment[0:len((*c.CallOptions).RollbackApiDeployment):len((*c.CallOptions).RollbackApiDeployment)], opts...)
-	var resp *rpcpb.ApiDeployment
-	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
-		var err error
-		resp, err = c.registryClient.RollbackApiDeployment(ctx, req, settings.GRPC...)
-		return err
-	}, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return resp, nil
-}
+	opts = append((*c.CallOptions).ListReferrers[0:len((*c.CallOptions).ListReferrers):len((*c.CallOptions).ListReferrers)], opts...)
+	it := &ReferenceIterator{}
+	req = proto.Clone(req).(*rpcpb.ListReferrersRequest)
+	it.InternalFetch = func(pageSize int, pageToken string) ([]*rpcpb.Reference, string, error) {
+		resp := &rpcpb.ListReferrersResponse{}
+		if pageToken != "" {
+			req.PageToken = pageToken
+		}
+		if pageSize > math.MaxInt32 {
+			req.PageSize = math.MaxInt32
+		} else if pageSize != 0 {
+			req.PageSize = int32(pageSize)
+		}
+		err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+			var err error
+			resp, err = c.registryClient.ListReferrers(ctx, req, settings.GRPC...)
+			return err
+		}, opts...)
+		if err != nil {
+			return nil, "", err
+		}
 
-func (c *registryGRPCClient) DeleteApiDeploymentRevision(ctx context.Context, req *rpcpb.DeleteApiDeploymentRevisionRequest, opts ...gax.CallOption) (*rpcpb.ApiDeployment, error) {
-	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
-		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
-		defer cancel()
-		ctx = cctx
+		it.Response = resp
+		return resp.GetReferences(), resp.GetNextPageToken(), nil
 	}
-	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
-
-	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
-	opts = append((*c.CallOptions).DeleteApiDeploymentRevision[0:len((*c.CallOptions).DeleteApiDeploymentRevision):len((*c.CallOptions).DeleteApiDeploymentRevision)], opts...)
-	var resp *rpcpb.ApiDeployment
-	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
-		var err error
-		resp, err = c.registryClient.DeleteApiDeploymentRevision(ctx, req, settings.GRPC...)
-		return err
-	}, opts...)
-	if err != nil {
-		return nil, err
+	fetch := func(pageSize int, pageToken string) (string, error) {
+		items, nextPageToken, err := it.InternalFetch(pageSize, pageToken)
+		if err != nil {
+			return "", err
+		}
+		it.items = append(it.items, items...)
+		return nextPageToken, nil
 	}
-	return resp, nil
+
+	it.pageInfo, it.nextFunc = iterator.NewPageInfo(fetch, it.bufLen, it.takeBuf)
+	it.pageInfo.MaxSize = int(req.GetPageSize())
+	it.pageInfo.Token = req.GetPageToken()
+
+	return it
 }
 
-func (c *registryGRPCClient) ListArtifacts(ctx context.Context, req *rpcpb.ListArtifactsRequest, opts ...gax.CallOption) *ArtifactIterator {
+func (c *registryGRPCClient) SearchResources(ctx context.Context, req *rpcpb.SearchResourcesRequest, opts ...gax.CallOption) *SearchResultIterator {
 	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "parent", url.QueryEscape(req.GetParent())))
 
 	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
-	opts = append((*c.CallOptions).ListArtifacts[0:len((*c.CallOptions).ListArtifacts):len((*c.CallOptions).ListArtifacts)], opts...)
-	it := &ArtifactIterator{}
-	req = proto.Clone(req).(*rpcpb.ListArtifactsRequest)
-	it.InternalFetch = func(pageSize int, pageToken string) ([]*rpcpb.Artifact, string, error) {
-		resp := &rpcpb.ListArtifactsResponse{}
+	opts = append((*c.CallOptions).SearchResources[0:len((*c.CallOptions).SearchResources):len((*c.CallOptions).SearchResources)], opts...)
+	it := &SearchResultIterator{}
+	req = proto.Clone(req).(*rpcpb.SearchResourcesRequest)
+	it.InternalFetch = func(pageSize int, pageToken string) ([]*rpcpb.SearchResult, string, error) {
+		resp := &rpcpb.SearchResourcesResponse{}
 		if pageToken != "" {
 			req.PageToken = pageToken
 		}
@@ -1711,7 +3078,7 @@ func (c *registryGRPCClient) ListArtifacts(ctx context.Context, req *rpcpb.ListA
 		}
 		err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
 			var err error
-			resp, err = c.registryClient.ListArtifacts(ctx, req, settings.GRPC...)
+			resp, err = c.registryClient.SearchResources(ctx, req, settings.GRPC...)
 			return err
 		}, opts...)
 		if err != nil {
@@ -1719,7 +3086,7 @@ func (c *registryGRPCClient) ListArtifacts(ctx context.Context, req *rpcpb.ListA
 		}
 
 		it.Response = resp
-		return resp.GetArtifacts(), resp.GetNextPageToken(), nil
+		return resp.GetResults(), resp.GetNextPageToken(), nil
 	}
 	fetch := func(pageSize int, pageToken string) (string, error) {
 		items, nextPageToken, err := it.InternalFetch(pageSize, pageToken)
@@ -1737,110 +3104,49 @@ func (c *registryGRPCClient) ListArtifacts(ctx context.Context, req *rpcpb.ListA
 	return it
 }
 
-func (c *registryGRPCClient) GetArtifact(ctx context.Context, req *rpcpb.GetArtifactRequest, opts ...gax.CallOption) (*rpcpb.Artifact, error) {
-	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
-		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
-		defer cancel()
-		ctx = cctx
-	}
-	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
-
-	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
-	opts = append((*c.CallOptions).GetArtifact[0:len((*c.CallOptions).GetArtifact):len((*c.CallOptions).GetArtifact)], opts...)
-	var resp *rpcpb.Artifact
-	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
-		var err error
-		resp, err = c.registryClient.GetArtifact(ctx, req, settings.GRPC...)
-		return err
-	}, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return resp, nil
-}
-
-func (c *registryGRPCClient) GetArtifactContents(ctx context.Context, req *rpcpb.GetArtifactContentsRequest, opts ...gax.CallOption) (*httpbodypb.HttpBody, error) {
-	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
-		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
-		defer cancel()
-		ctx = cctx
-	}
-	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
-
-	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
-	opts = append((*c.CallOptions).GetArtifactContents[0:len((*c.CallOptions).GetArtifactContents):len((*c.CallOptions).GetArtifactContents)], opts...)
-	var resp *httpbodypb.HttpBody
-	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
-		var err error
-		resp, err = c.registryClient.GetArtifactContents(ctx, req, settings.GRPC...)
-		return err
-	}, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return resp, nil
-}
-
-func (c *registryGRPCClient) CreateArtifact(ctx context.Context, req *rpcpb.CreateArtifactRequest, opts ...gax.CallOption) (*rpcpb.Artifact, error) {
-	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
-		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
-		defer cancel()
-		ctx = cctx
-	}
+func (c *registryGRPCClient) ListSpecElements(ctx context.Context, req *rpcpb.ListSpecElementsRequest, opts ...gax.CallOption) *SpecElementIterator {
 	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "parent", url.QueryEscape(req.GetParent())))
 
 	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
-	opts = append((*c.CallOptions).CreateArtifact[0:len((*c.CallOptions).CreateArtifact):len((*c.CallOptions).CreateArtifact)], opts...)
-	var resp *rpcpb.Artifact
-	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
-		var err error
-		resp, err = c.registryClient.CreateArtifact(ctx, req, settings.GRPC...)
-		return err
-	}, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return resp, nil
-}
+	opts = append((*c.CallOptions).ListSpecElements[0:len((*c.CallOptions).ListSpecElements):len((*c.CallOptions).ListSpecElements)], opts...)
+	it := &SpecElementIterator{}
+	req = proto.Clone(req).(*rpcpb.ListSpecElementsRequest)
+	it.InternalFetch = func(pageSize int, pageToken string) ([]*rpcpb.SpecElement, string, error) {
+		resp := &rpcpb.ListSpecElementsResponse{}
+		if pageToken != "" {
+			req.PageToken = pageToken
+		}
+		if pageSize > math.MaxInt32 {
+			req.PageSize = math.MaxInt32
+		} else if pageSize != 0 {
+			req.PageSize = int32(pageSize)
+		}
+		err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+			var err error
+			resp, err = c.registryClient.ListSpecElements(ctx, req, settings.GRPC...)
+			return err
+		}, opts...)
+		if err != nil {
+			return nil, "", err
+		}
 
-func (c *registryGRPCClient) ReplaceArtifact(ctx context.Context, req *rpcpb.ReplaceArtifactRequest, opts ...gax.CallOption) (*rpcpb.Artifact, error) {
-	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
-		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
-		defer cancel()
-		ctx = cctx
+		it.Response = resp
+		return resp.GetElements(), resp.GetNextPageToken(), nil
 	}
-	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "artifact.name", url.QueryEscape(req.GetArtifact().GetName())))
-
-	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
-	opts = append((*c.CallOptions).ReplaceArtifact[0:len((*c.CallOptions).ReplaceArtifact):len((*c.CallOptions).ReplaceArtifact)], opts...)
-	var resp *rpcpb.Artifact
-	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
-		var err error
-		resp, err = c.registryClient.ReplaceArtifact(ctx, req, settings.GRPC...)
-		return err
-	}, opts...)
-	if err != nil {
-		return nil, err
+	fetch := func(pageSize int, pageToken string) (string, error) {
+		items, nextPageToken, err := it.InternalFetch(pageSize, pageToken)
+		if err != nil {
+			return "", err
+		}
+		it.items = append(it.items, items...)
+		return nextPageToken, nil
 	}
-	return resp, nil
-}
 
-func (c *registryGRPCClient) DeleteArtifact(ctx context.Context, req *rpcpb.DeleteArtifactRequest, opts ...gax.CallOption) error {
-	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
-		cctx, cancel := context.WithTimeout(ctx, 60000*time.Millisecond)
-		defer cancel()
-		ctx = cctx
-	}
-	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
+	it.pageInfo, it.nextFunc = iterator.NewPageInfo(fetch, it.bufLen, it.takeBuf)
+	it.pageInfo.MaxSize = int(req.GetPageSize())
+	it.pageInfo.Token = req.GetPageToken()
 
-	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
-	opts = append((*c.CallOptions).DeleteArtifact[0:len((*c.CallOptions).DeleteArtifact):len((*c.CallOptions).DeleteArtifact)], opts...)
-	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
-		var err error
-		_, err = c.registryClient.DeleteArtifact(ctx, req, settings.GRPC...)
-		return err
-	}, opts...)
-	return err
+	return it
 }
 
 // ApiDeploymentIterator manages a stream of *rpcpb.ApiDeployment.
@@ -2078,6 +3384,147 @@ func (it *ArtifactIterator) takeBuf() interface{} {
 	return b
 }
 
+// ReferenceIterator manages a stream of *rpcpb.Reference.
+type ReferenceIterator struct {
+	items    []*rpcpb.Reference
+	pageInfo *iterator.PageInfo
+	nextFunc func() error
+
+	// Response is the raw response for the current page.
+	// It must be cast to the RPC response type.
+	// Calling Next() or InternalFetch() updates this value.
+	Response interface{}
+
+	// InternalFetch is for use by the Google Cloud Libraries only.
+	// It is not part of the stable interface of this package.
+	//
+	// InternalFetch returns results from a single call to the underlying RPC.
+	// The number of results is no greater than pageSize.
+	// If there are no more results, nextPageToken is empty and err is nil.
+	InternalFetch func(pageSize int, pageToken string) (results []*rpcpb.Reference, nextPageToken string, err error)
+}
+
+// PageInfo supports pagination. See the google.golang.org/api/iterator package for details.
+func (it *ReferenceIterator) PageInfo() *iterator.PageInfo {
+	return it.pageInfo
+}
+
+// Next returns the next result. Its second return value is iterator.Done if there are no more
+// results. Once Next returns Done, all subsequent calls will return Done.
+func (it *ReferenceIterator) Next() (*rpcpb.Reference, error) {
+	var item *rpcpb.Reference
+	if err := it.nextFunc(); err != nil {
+		return item, err
+	}
+	item = it.items[0]
+	it.items = it.items[1:]
+	return item, nil
+}
+
+func (it *ReferenceIterator) bufLen() int {
+	return len(it.items)
+}
+
+func (it *ReferenceIterator) takeBuf() interface{} {
+	b := it.items
+	it.items = nil
+	return b
+}
+
+// SearchResultIterator manages a stream of *rpcpb.SearchResult.
+type SearchResultIterator struct {
+	items    []*rpcpb.SearchResult
+	pageInfo *iterator.PageInfo
+	nextFunc func() error
+
+	// Response is the raw response for the current page.
+	// It must be cast to the RPC response type.
+	// Calling Next() or InternalFetch() updates this value.
+	Response interface{}
+
+	// InternalFetch is for use by the Google Cloud Libraries only.
+	// It is not part of the stable interface of this package.
+	//
+	// InternalFetch returns results from a single call to the underlying RPC.
+	// The number of results is no greater than pageSize.
+	// If there are no more results, nextPageToken is empty and err is nil.
+	InternalFetch func(pageSize int, pageToken string) (results []*rpcpb.SearchResult, nextPageToken string, err error)
+}
+
+// PageInfo supports pagination. See the google.golang.org/api/iterator package for details.
+func (it *SearchResultIterator) PageInfo() *iterator.PageInfo {
+	return it.pageInfo
+}
+
+// Next returns the next result. Its second return value is iterator.Done if there are no more
+// results. Once Next returns Done, all subsequent calls will return Done.
+func (it *SearchResultIterator) Next() (*rpcpb.SearchResult, error) {
+	var item *rpcpb.SearchResult
+	if err := it.nextFunc(); err != nil {
+		return item, err
+	}
+	item = it.items[0]
+	it.items = it.items[1:]
+	return item, nil
+}
+
+func (it *SearchResultIterator) bufLen() int {
+	return len(it.items)
+}
+
+func (it *SearchResultIterator) takeBuf() interface{} {
+	b := it.items
+	it.items = nil
+	return b
+}
+
+// SpecElementIterator manages a stream of *rpcpb.SpecElement.
+type SpecElementIterator struct {
+	items    []*rpcpb.SpecElement
+	pageInfo *iterator.PageInfo
+	nextFunc func() error
+
+	// Response is the raw response for the current page.
+	// It must be cast to the RPC response type.
+	// Calling Next() or InternalFetch() updates this value.
+	Response interface{}
+
+	// InternalFetch is for use by the Google Cloud Libraries only.
+	// It is not part of the stable interface of this package.
+	//
+	// InternalFetch returns results from a single call to the underlying RPC.
+	// The number of results is no greater than pageSize.
+	// If there are no more results, nextPageToken is empty and err is nil.
+	InternalFetch func(pageSize int, pageToken string) (results []*rpcpb.SpecElement, nextPageToken string, err error)
+}
+
+// PageInfo supports pagination. See the google.golang.org/api/iterator package for details.
+func (it *SpecElementIterator) PageInfo() *iterator.PageInfo {
+	return it.pageInfo
+}
+
+// Next returns the next result. Its second return value is iterator.Done if there are no more
+// results. Once Next returns Done, all subsequent calls will return Done.
+func (it *SpecElementIterator) Next() (*rpcpb.SpecElement, error) {
+	var item *rpcpb.SpecElement
+	if err := it.nextFunc(); err != nil {
+		return item, err
+	}
+	item = it.items[0]
+	it.items = it.items[1:]
+	return item, nil
+}
+
+func (it *SpecElementIterator) bufLen() int {
+	return len(it.items)
+}
+
+func (it *SpecElementIterator) takeBuf() interface{} {
+	b := it.items
+	it.items = nil
+	return b
+}
+
 func (c *RegistryClient) GrpcClient() rpcpb.RegistryClient {
 	return c.internalClient.(*registryGRPCClient).registryClient
 }
@@ -32,7 +32,12 @@ func clientOptions(config Config) ([]option.ClientOption, error) {
 	}
 	opts = append(opts, option.WithEndpoint(config.Address))
 	if config.Insecure {
-		conn, err := grpc.Dial(config.Address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		dialOpts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+		if config.Token != "" {
+			// Token sources are ignored when a connection is provided.
+			dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(bearerToken(config.Token)))
+		}
+		conn, err := grpc.Dial(config.Address, dialOpts...)
 		if err != nil {
 			return nil, err
 		}
@@ -48,6 +53,18 @@ func clientOptions(config Config) ([]option.ClientOption, error) {
 	return opts, nil
 }
 
+// bearerToken sends a token with each request, including requests made
+// over insecure connections to local servers.
+type bearerToken string
+
+func (t bearerToken) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(t)}, nil
+}
+
+func (t bearerToken) RequireTransportSecurity() bool {
+	return false
+}
+
 // RegistryClient is a client of the Registry API
 type RegistryClient = *gapic.RegistryClient
 
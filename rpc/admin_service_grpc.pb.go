@@ -50,8 +50,17 @@ type AdminClient interface {
 	//
 	//	aip.dev/not-precedent: Not in the official API. --)
 	GetStorage(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Storage, error)
-	// MigrateDatabase attempts to migrate the database to the current schema.
+	// MigrateDatabase migrates the database to the current schema or to a
+	// specified schema version. Progress is reported in the operation metadata.
 	MigrateDatabase(ctx context.Context, in *MigrateDatabaseRequest, opts ...grpc.CallOption) (*longrunning.Operation, error)
+	// CompactRevisions removes revisions of specs and deployments that are not
+	// kept by a retention policy. The latest revision, tagged revisions and
+	// revisions that are referenced by versions, deployments or APIs are always
+	// kept. Revisions are removed with their contents and artifacts.
+	// (-- api-linter: core::0136::http-uri-suffix=disabled
+	//
+	//	aip.dev/not-precedent: Not in the official API. --)
+	CompactRevisions(ctx context.Context, in *CompactRevisionsRequest, opts ...grpc.CallOption) (*CompactRevisionsResponse, error)
 	// ListProjects returns matching projects.
 	// (-- api-linter: standard-methods=disabled --)
 	// (-- api-linter: core::0132::method-signature=disabled
@@ -75,6 +84,96 @@ type AdminClient interface {
 	// DeleteProject removes a specified project and all of the resources that it
 	// owns.
 	DeleteProject(ctx context.Context, in *DeleteProjectRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// UndeleteProject restores a deleted project and the resources that were
+	// deleted with it. Deleted resources can be restored until they are purged.
+	UndeleteProject(ctx context.Context, in *UndeleteProjectRequest, opts ...grpc.CallOption) (*Project, error)
+	// CloneProject creates a project with copies of all of the resources of a
+	// specified project. Copies keep the revision IDs, tags and timestamps of
+	// the resources they copy, and references between the resources are changed
+	// to refer to the copies. The copy is made in a single transaction.
+	// (-- api-linter: core::0136::http-uri-suffix=disabled
+	//
+	//	aip.dev/not-precedent: Not in the official API. --)
+	CloneProject(ctx context.Context, in *CloneProjectRequest, opts ...grpc.CallOption) (*Project, error)
+	// CopyApi copies an API with all of its versions, spec revisions,
+	// deployment revisions, tags and artifacts into a project. Copies keep the
+	// revision IDs, tags and timestamps of the resources they copy, and
+	// references between the resources are changed to refer to the copies.
+	// The copy is made in a single transaction.
+	// (-- api-linter: core::0136::http-uri-suffix=disabled
+	//
+	//	aip.dev/not-precedent: Not in the official API. --)
+	CopyApi(ctx context.Context, in *CopyApiRequest, opts ...grpc.CallOption) (*Api, error)
+	// ExportProject writes an archive of a project and all of its resources,
+	// including every revision of its specs and deployments with their tags and
+	// the contents of specs and artifacts. The archive can be read with
+	// ReadArchive and imported into this or another registry with ImportProject.
+	// (-- api-linter: core::0136::http-uri-suffix=disabled
+	//
+	//	aip.dev/not-precedent: Not in the official API. --)
+	ExportProject(ctx context.Context, in *ExportProjectRequest, opts ...grpc.CallOption) (*longrunning.Operation, error)
+	// ImportProject creates or updates a project and its resources from an
+	// archive. Resources keep the names, revisions and creation times that they
+	// had when they were exported, so importing an archive more than once has
+	// the same effect as importing it once.
+	// (-- api-linter: core::0136::http-uri-suffix=disabled
+	//
+	//	aip.dev/not-precedent: Not in the official API. --)
+	ImportProject(ctx context.Context, in *ImportProjectRequest, opts ...grpc.CallOption) (*longrunning.Operation, error)
+	// ReadArchive streams the contents of an archive.
+	// (-- api-linter: core::0136::http-uri-suffix=disabled
+	//
+	//	aip.dev/not-precedent: Not in the official API. --)
+	//
+	// (-- api-linter: core::0136::http-method=disabled
+	//
+	//	aip.dev/not-precedent: Not in the official API. --)
+	ReadArchive(ctx context.Context, in *ReadArchiveRequest, opts ...grpc.CallOption) (Admin_ReadArchiveClient, error)
+	// WriteArchive stores an archive streamed by the client so that it can be
+	// imported with ImportProject.
+	// (-- api-linter: core::0136::http-uri-suffix=disabled
+	//
+	//	aip.dev/not-precedent: Not in the official API. --)
+	WriteArchive(ctx context.Context, opts ...grpc.CallOption) (Admin_WriteArchiveClient, error)
+	// WatchResources streams notifications of changes to resources under a
+	// specified parent as they are made.
+	// (-- api-linter: core::0131::http-method=disabled
+	//
+	//	aip.dev/not-precedent: Not in the official API. --)
+	WatchResources(ctx context.Context, in *WatchResourcesRequest, opts ...grpc.CallOption) (Admin_WatchResourcesClient, error)
+	// ListNotificationEvents returns notifications that have not yet been
+	// delivered to their sinks.
+	// (-- api-linter: core::0132::method-signature=disabled
+	//
+	//	aip.dev/not-precedent: Not in the official API. --)
+	//
+	// (-- api-linter: core::0132::http-uri-parent=disabled
+	//
+	//	aip.dev/not-precedent: Not in the official API. --)
+	ListNotificationEvents(ctx context.Context, in *ListNotificationEventsRequest, opts ...grpc.CallOption) (*ListNotificationEventsResponse, error)
+	// ReplayNotificationEvents schedules matching undelivered notifications for
+	// immediate redelivery, including notifications whose delivery has failed.
+	// Pending notifications that are being delivered or are waiting to be
+	// retried aren't changed.
+	// (-- api-linter: core::0136::http-uri-suffix=disabled
+	//
+	//	aip.dev/not-precedent: Not in the official API. --)
+	ReplayNotificationEvents(ctx context.Context, in *ReplayNotificationEventsRequest, opts ...grpc.CallOption) (*ReplayNotificationEventsResponse, error)
+	// ListAuditEvents returns records of changes made to resources.
+	// (-- api-linter: core::0132::method-signature=disabled
+	//
+	//	aip.dev/not-precedent: Not in the official API. --)
+	//
+	// (-- api-linter: core::0132::http-uri-parent=disabled
+	//
+	//	aip.dev/not-precedent: Not in the official API. --)
+	ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error)
+	// AggregateResources counts the resources in a collection, optionally
+	// grouped by the values of a field.
+	// (-- api-linter: core::0136::http-uri-suffix=disabled
+	//
+	//	aip.dev/not-precedent: Not in the official API. --)
+	AggregateResources(ctx context.Context, in *AggregateResourcesRequest, opts ...grpc.CallOption) (*AggregateResourcesResponse, error)
 }
 
 type adminClient struct {
@@ -112,6 +211,15 @@ func (c *adminClient) MigrateDatabase(ctx context.Context, in *MigrateDatabaseRe
 	return out, nil
 }
 
+func (c *adminClient) CompactRevisions(ctx context.Context, in *CompactRevisionsRequest, opts ...grpc.CallOption) (*CompactRevisionsResponse, error) {
+	out := new(CompactRevisionsResponse)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Admin/CompactRevisions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *adminClient) ListProjects(ctx context.Context, in *ListProjectsRequest, opts ...grpc.CallOption) (*ListProjectsResponse, error) {
 	out := new(ListProjectsResponse)
 	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Admin/ListProjects", in, out, opts...)
@@ -157,6 +265,185 @@ func (c *adminClient) DeleteProject(ctx context.Context, in *DeleteProjectReques
 	return out, nil
 }
 
+func (c *adminClient) UndeleteProject(ctx context.Context, in *UndeleteProjectRequest, opts ...grpc.CallOption) (*Project, error) {
+	out := new(Project)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Admin/UndeleteProject", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) CloneProject(ctx context.Context, in *CloneProjectRequest, opts ...grpc.CallOption) (*Project, error) {
+	out := new(Project)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Admin/CloneProject", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) CopyApi(ctx context.Context, in *CopyApiRequest, opts ...grpc.CallOption) (*Api, error) {
+	out := new(Api)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Admin/CopyApi", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ExportProject(ctx context.Context, in *ExportProjectRequest, opts ...grpc.CallOption) (*longrunning.Operation, error) {
+	out := new(longrunning.Operation)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Admin/ExportProject", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ImportProject(ctx context.Context, in *ImportProjectRequest, opts ...grpc.CallOption) (*longrunning.Operation, error) {
+	out := new(longrunning.Operation)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Admin/ImportProject", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ReadArchive(ctx context.Context, in *ReadArchiveRequest, opts ...grpc.CallOption) (Admin_ReadArchiveClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Admin_ServiceDesc.Streams[0], "/google.cloud.apigeeregistry.v1.Admin/ReadArchive", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &adminReadArchiveClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Admin_ReadArchiveClient interface {
+	Recv() (*ArchiveChunk, error)
+	grpc.ClientStream
+}
+
+type adminReadArchiveClient struct {
+	grpc.ClientStream
+}
+
+func (x *adminReadArchiveClient) Recv() (*ArchiveChunk, error) {
+	m := new(ArchiveChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *adminClient) WriteArchive(ctx context.Context, opts ...grpc.CallOption) (Admin_WriteArchiveClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Admin_ServiceDesc.Streams[1], "/google.cloud.apigeeregistry.v1.Admin/WriteArchive", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &adminWriteArchiveClient{stream}
+	return x, nil
+}
+
+type Admin_WriteArchiveClient interface {
+	Send(*ArchiveChunk) error
+	CloseAndRecv() (*Archive, error)
+	grpc.ClientStream
+}
+
+type adminWriteArchiveClient struct {
+	grpc.ClientStream
+}
+
+func (x *adminWriteArchiveClient) Send(m *ArchiveChunk) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *adminWriteArchiveClient) CloseAndRecv() (*Archive, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(Archive)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *adminClient) WatchResources(ctx context.Context, in *WatchResourcesRequest, opts ...grpc.CallOption) (Admin_WatchResourcesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Admin_ServiceDesc.Streams[2], "/google.cloud.apigeeregistry.v1.Admin/WatchResources", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &adminWatchResourcesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Admin_WatchResourcesClient interface {
+	Recv() (*Notification, error)
+	grpc.ClientStream
+}
+
+type adminWatchResourcesClient struct {
+	grpc.ClientStream
+}
+
+func (x *adminWatchResourcesClient) Recv() (*Notification, error) {
+	m := new(Notification)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *adminClient) ListNotificationEvents(ctx context.Context, in *ListNotificationEventsRequest, opts ...grpc.CallOption) (*ListNotificationEventsResponse, error) {
+	out := new(ListNotificationEventsResponse)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Admin/ListNotificationEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ReplayNotificationEvents(ctx context.Context, in *ReplayNotificationEventsRequest, opts ...grpc.CallOption) (*ReplayNotificationEventsResponse, error) {
+	out := new(ReplayNotificationEventsResponse)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Admin/ReplayNotificationEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error) {
+	out := new(ListAuditEventsResponse)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Admin/ListAuditEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) AggregateResources(ctx context.Context, in *AggregateResourcesRequest, opts ...grpc.CallOption) (*AggregateResourcesResponse, error) {
+	out := new(AggregateResourcesResponse)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Admin/AggregateResources", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AdminServer is the server API for Admin service.
 // All implementations must embed UnimplementedAdminServer
 // for forward compatibility
@@ -187,8 +474,17 @@ type AdminServer interface {
 	//
 	//	aip.dev/not-precedent: Not in the official API. --)
 	GetStorage(context.Context, *emptypb.Empty) (*Storage, error)
-	// MigrateDatabase attempts to migrate the database to the current schema.
+	// MigrateDatabase migrates the database to the current schema or to a
+	// specified schema version. Progress is reported in the operation metadata.
 	MigrateDatabase(context.Context, *MigrateDatabaseRequest) (*longrunning.Operation, error)
+	// CompactRevisions removes revisions of specs and deployments that are not
+	// kept by a retention policy. The latest revision, tagged revisions and
+	// revisions that are referenced by versions, deployments or APIs are always
+	// kept. Revisions are removed with their contents and artifacts.
+	// (-- api-linter: core::0136::http-uri-suffix=disabled
+	//
+	//	aip.dev/not-precedent: Not in the official API. --)
+	CompactRevisions(context.Context, *CompactRevisionsRequest) (*CompactRevisionsResponse, error)
 	// ListProjects returns matching projects.
 	// (-- api-linter: standard-methods=disabled --)
 	// (-- api-linter: core::0132::method-signature=disabled
@@ -212,6 +508,96 @@ type AdminServer interface {
 	// DeleteProject removes a specified project and all of the resources that it
 	// owns.
 	DeleteProject(context.Context, *DeleteProjectRequest) (*emptypb.Empty, error)
+	// UndeleteProject restores a deleted project and the resources that were
+	// deleted with it. Deleted resources can be restored until they are purged.
+	UndeleteProject(context.Context, *UndeleteProjectRequest) (*Project, error)
+	// CloneProject creates a project with copies of all of the resources of a
+	// specified project. Copies keep the revision IDs, tags and timestamps of
+	// the resources they copy, and references between the resources are changed
+	// to refer to the copies. The copy is made in a single transaction.
+	// (-- api-linter: core::0136::http-uri-suffix=disabled
+	//
+	//	aip.dev/not-precedent: Not in the official API. --)
+	CloneProject(context.Context, *CloneProjectRequest) (*Project, error)
+	// CopyApi copies an API with all of its versions, spec revisions,
+	// deployment revisions, tags and artifacts into a project. Copies keep the
+	// revision IDs, tags and timestamps of the resources they copy, and
+	// references between the resources are changed to refer to the copies.
+	// The copy is made in a single transaction.
+	// (-- api-linter: core::0136::http-uri-suffix=disabled
+	//
+	//	aip.dev/not-precedent: Not in the official API. --)
+	CopyApi(context.Context, *CopyApiRequest) (*Api, error)
+	// ExportProject writes an archive of a project and all of its resources,
+	// including every revision of its specs and deployments with their tags and
+	// the contents of specs and artifacts. The archive can be read with
+	// ReadArchive and imported into this or another registry with ImportProject.
+	// (-- api-linter: core::0136::http-uri-suffix=disabled
+	//
+	//	aip.dev/not-precedent: Not in the official API. --)
+	ExportProject(context.Context, *ExportProjectRequest) (*longrunning.Operation, error)
+	// ImportProject creates or updates a project and its resources from an
+	// archive. Resources keep the names, revisions and creation times that they
+	// had when they were exported, so importing an archive more than once has
+	// the same effect as importing it once.
+	// (-- api-linter: core::0136::http-uri-suffix=disabled
+	//
+	//	aip.dev/not-precedent: Not in the official API. --)
+	ImportProject(context.Context, *ImportProjectRequest) (*longrunning.Operation, error)
+	// ReadArchive streams the contents of an archive.
+	// (-- api-linter: core::0136::http-uri-suffix=disabled
+	//
+	//	aip.dev/not-precedent: Not in the official API. --)
+	//
+	// (-- api-linter: core::0136::http-method=disabled
+	//
+	//	aip.dev/not-precedent: Not in the official API. --)
+	ReadArchive(*ReadArchiveRequest, Admin_ReadArchiveServer) error
+	// WriteArchive stores an archive streamed by the client so that it can be
+	// imported with ImportProject.
+	// (-- api-linter: core::0136::http-uri-suffix=disabled
+	//
+	//	aip.dev/not-precedent: Not in the official API. --)
+	WriteArchive(Admin_WriteArchiveServer) error
+	// WatchResources streams notifications of changes to resources under a
+	// specified parent as they are made.
+	// (-- api-linter: core::0131::http-method=disabled
+	//
+	//	aip.dev/not-precedent: Not in the official API. --)
+	WatchResources(*WatchResourcesRequest, Admin_WatchResourcesServer) error
+	// ListNotificationEvents returns notifications that have not yet been
+	// delivered to their sinks.
+	// (-- api-linter: core::0132::method-signature=disabled
+	//
+	//	aip.dev/not-precedent: Not in the official API. --)
+	//
+	// (-- api-linter: core::0132::http-uri-parent=disabled
+	//
+	//	aip.dev/not-precedent: Not in the official API. --)
+	ListNotificationEvents(context.Context, *ListNotificationEventsRequest) (*ListNotificationEventsResponse, error)
+	// ReplayNotificationEvents schedules matching undelivered notifications for
+	// immediate redelivery, including notifications whose delivery has failed.
+	// Pending notifications that are being delivered or are waiting to be
+	// retried aren't changed.
+	// (-- api-linter: core::0136::http-uri-suffix=disabled
+	//
+	//	aip.dev/not-precedent: Not in the official API. --)
+	ReplayNotificationEvents(context.Context, *ReplayNotificationEventsRequest) (*ReplayNotificationEventsResponse, error)
+	// ListAuditEvents returns records of changes made to resources.
+	// (-- api-linter: core::0132::method-signature=disabled
+	//
+	//	aip.dev/not-precedent: Not in the official API. --)
+	//
+	// (-- api-linter: core::0132::http-uri-parent=disabled
+	//
+	//	aip.dev/not-precedent: Not in the official API. --)
+	ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error)
+	// AggregateResources counts the resources in a collection, optionally
+	// grouped by the values of a field.
+	// (-- api-linter: core::0136::http-uri-suffix=disabled
+	//
+	//	aip.dev/not-precedent: Not in the official API. --)
+	AggregateResources(context.Context, *AggregateResourcesRequest) (*AggregateResourcesResponse, error)
 	mustEmbedUnimplementedAdminServer()
 }
 
@@ -228,6 +614,9 @@ func (UnimplementedAdminServer) GetStorage(context.Context, *emptypb.Empty) (*St
 func (UnimplementedAdminServer) MigrateDatabase(context.Context, *MigrateDatabaseRequest) (*longrunning.Operation, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method MigrateDatabase not implemented")
 }
+func (UnimplementedAdminServer) CompactRevisions(context.Context, *CompactRevisionsRequest) (*CompactRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompactRevisions not implemented")
+}
 func (UnimplementedAdminServer) ListProjects(context.Context, *ListProjectsRequest) (*ListProjectsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListProjects not implemented")
 }
@@ -243,6 +632,42 @@ func (UnimplementedAdminServer) UpdateProject(context.Context, *UpdateProjectReq
 func (UnimplementedAdminServer) DeleteProject(context.Context, *DeleteProjectRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteProject not implemented")
 }
+func (UnimplementedAdminServer) UndeleteProject(context.Context, *UndeleteProjectRequest) (*Project, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UndeleteProject not implemented")
+}
+func (UnimplementedAdminServer) CloneProject(context.Context, *CloneProjectRequest) (*Project, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloneProject not implemented")
+}
+func (UnimplementedAdminServer) CopyApi(context.Context, *CopyApiRequest) (*Api, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CopyApi not implemented")
+}
+func (UnimplementedAdminServer) ExportProject(context.Context, *ExportProjectRequest) (*longrunning.Operation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportProject not implemented")
+}
+func (UnimplementedAdminServer) ImportProject(context.Context, *ImportProjectRequest) (*longrunning.Operation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportProject not implemented")
+}
+func (UnimplementedAdminServer) ReadArchive(*ReadArchiveRequest, Admin_ReadArchiveServer) error {
+	return status.Errorf(codes.Unimplemented, "method ReadArchive not implemented")
+}
+func (UnimplementedAdminServer) WriteArchive(Admin_WriteArchiveServer) error {
+	return status.Errorf(codes.Unimplemented, "method WriteArchive not implemented")
+}
+func (UnimplementedAdminServer) WatchResources(*WatchResourcesRequest, Admin_WatchResourcesServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchResources not implemented")
+}
+func (UnimplementedAdminServer) ListNotificationEvents(context.Context, *ListNotificationEventsRequest) (*ListNotificationEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListNotificationEvents not implemented")
+}
+func (UnimplementedAdminServer) ReplayNotificationEvents(context.Context, *ReplayNotificationEventsRequest) (*ReplayNotificationEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplayNotificationEvents not implemented")
+}
+func (UnimplementedAdminServer) ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditEvents not implemented")
+}
+func (UnimplementedAdminServer) AggregateResources(context.Context, *AggregateResourcesRequest) (*AggregateResourcesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AggregateResources not implemented")
+}
 func (UnimplementedAdminServer) mustEmbedUnimplementedAdminServer() {}
 
 // UnsafeAdminServer may be embedded to opt out of forward compatibility for this service.
@@ -310,6 +735,24 @@ func _Admin_MigrateDatabase_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Admin_CompactRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompactRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).CompactRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Admin/CompactRevisions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).CompactRevisions(ctx, req.(*CompactRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Admin_ListProjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListProjectsRequest)
 	if err := dec(in); err != nil {
@@ -400,6 +843,236 @@ func _Admin_DeleteProject_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Admin_UndeleteProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UndeleteProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).UndeleteProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Admin/UndeleteProject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).UndeleteProject(ctx, req.(*UndeleteProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_CloneProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloneProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).CloneProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Admin/CloneProject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).CloneProject(ctx, req.(*CloneProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_CopyApi_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CopyApiRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).CopyApi(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Admin/CopyApi",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).CopyApi(ctx, req.(*CopyApiRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ExportProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ExportProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Admin/ExportProject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ExportProject(ctx, req.(*ExportProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ImportProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ImportProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Admin/ImportProject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ImportProject(ctx, req.(*ImportProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ReadArchive_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ReadArchiveRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AdminServer).ReadArchive(m, &adminReadArchiveServer{stream})
+}
+
+type Admin_ReadArchiveServer interface {
+	Send(*ArchiveChunk) error
+	grpc.ServerStream
+}
+
+type adminReadArchiveServer struct {
+	grpc.ServerStream
+}
+
+func (x *adminReadArchiveServer) Send(m *ArchiveChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Admin_WriteArchive_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(AdminServer).WriteArchive(&adminWriteArchiveServer{stream})
+}
+
+type Admin_WriteArchiveServer interface {
+	SendAndClose(*Archive) error
+	Recv() (*ArchiveChunk, error)
+	grpc.ServerStream
+}
+
+type adminWriteArchiveServer struct {
+	grpc.ServerStream
+}
+
+func (x *adminWriteArchiveServer) SendAndClose(m *Archive) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *adminWriteArchiveServer) Recv() (*ArchiveChunk, error) {
+	m := new(ArchiveChunk)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Admin_WatchResources_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchResourcesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AdminServer).WatchResources(m, &adminWatchResourcesServer{stream})
+}
+
+type Admin_WatchResourcesServer interface {
+	Send(*Notification) error
+	grpc.ServerStream
+}
+
+type adminWatchResourcesServer struct {
+	grpc.ServerStream
+}
+
+func (x *adminWatchResourcesServer) Send(m *Notification) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Admin_ListNotificationEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNotificationEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ListNotificationEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Admin/ListNotificationEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ListNotificationEvents(ctx, req.(*ListNotificationEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ReplayNotificationEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplayNotificationEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ReplayNotificationEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Admin/ReplayNotificationEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ReplayNotificationEvents(ctx, req.(*ReplayNotificationEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ListAuditEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ListAuditEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Admin/ListAuditEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ListAuditEvents(ctx, req.(*ListAuditEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_AggregateResources_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AggregateResourcesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).AggregateResources(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Admin/AggregateResources",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).AggregateResources(ctx, req.(*AggregateResourcesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Admin_ServiceDesc is the grpc.ServiceDesc for Admin service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -419,6 +1092,10 @@ var Admin_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "MigrateDatabase",
 			Handler:    _Admin_MigrateDatabase_Handler,
 		},
+		{
+			MethodName: "CompactRevisions",
+			Handler:    _Admin_CompactRevisions_Handler,
+		},
 		{
 			MethodName: "ListProjects",
 			Handler:    _Admin_ListProjects_Handler,
@@ -439,7 +1116,59 @@ var Admin_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteProject",
 			Handler:    _Admin_DeleteProject_Handler,
 		},
+		{
+			MethodName: "UndeleteProject",
+			Handler:    _Admin_UndeleteProject_Handler,
+		},
+		{
+			MethodName: "CloneProject",
+			Handler:    _Admin_CloneProject_Handler,
+		},
+		{
+			MethodName: "CopyApi",
+			Handler:    _Admin_CopyApi_Handler,
+		},
+		{
+			MethodName: "ExportProject",
+			Handler:    _Admin_ExportProject_Handler,
+		},
+		{
+			MethodName: "ImportProject",
+			Handler:    _Admin_ImportProject_Handler,
+		},
+		{
+			MethodName: "ListNotificationEvents",
+			Handler:    _Admin_ListNotificationEvents_Handler,
+		},
+		{
+			MethodName: "ReplayNotificationEvents",
+			Handler:    _Admin_ReplayNotificationEvents_Handler,
+		},
+		{
+			MethodName: "ListAuditEvents",
+			Handler:    _Admin_ListAuditEvents_Handler,
+		},
+		{
+			MethodName: "AggregateResources",
+			Handler:    _Admin_AggregateResources_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ReadArchive",
+			Handler:       _Admin_ReadArchive_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WriteArchive",
+			Handler:       _Admin_WriteArchive_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "WatchResources",
+			Handler:       _Admin_WatchResources_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "google/cloud/apigeeregistry/v1/admin_service.proto",
 }
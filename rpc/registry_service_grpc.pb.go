@@ -35,6 +35,9 @@ type RegistryClient interface {
 	// DeleteApi removes a specified API and all of the resources that it
 	// owns.
 	DeleteApi(ctx context.Context, in *DeleteApiRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// UndeleteApi restores a deleted API and the resources that were
+	// deleted with it. Deleted resources can be restored until they are purged.
+	UndeleteApi(ctx context.Context, in *UndeleteApiRequest, opts ...grpc.CallOption) (*Api, error)
 	// ListApiVersions returns matching versions.
 	ListApiVersions(ctx context.Context, in *ListApiVersionsRequest, opts ...grpc.CallOption) (*ListApiVersionsResponse, error)
 	// GetApiVersion returns a specified version.
@@ -46,6 +49,9 @@ type RegistryClient interface {
 	// DeleteApiVersion removes a specified version and all of the resources that
 	// it owns.
 	DeleteApiVersion(ctx context.Context, in *DeleteApiVersionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// UndeleteApiVersion restores a deleted version and the resources that were
+	// deleted with it. Deleted resources can be restored until they are purged.
+	UndeleteApiVersion(ctx context.Context, in *UndeleteApiVersionRequest, opts ...grpc.CallOption) (*ApiVersion, error)
 	// ListApiSpecs returns matching specs.
 	ListApiSpecs(ctx context.Context, in *ListApiSpecsRequest, opts ...grpc.CallOption) (*ListApiSpecsResponse, error)
 	// GetApiSpec returns a specified spec.
@@ -62,6 +68,10 @@ type RegistryClient interface {
 	// DeleteApiSpec removes a specified spec, all revisions, and all child
 	// resources (e.g. artifacts).
 	DeleteApiSpec(ctx context.Context, in *DeleteApiSpecRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// UndeleteApiSpec restores a deleted spec and the resources that were
+	// deleted with it. Deleted resources can be restored until they are purged.
+	// A revision that was deleted on its own is restored by naming the revision.
+	UndeleteApiSpec(ctx context.Context, in *UndeleteApiSpecRequest, opts ...grpc.CallOption) (*ApiSpec, error)
 	// TagApiSpecRevision adds a tag to a specified revision of a spec.
 	TagApiSpecRevision(ctx context.Context, in *TagApiSpecRevisionRequest, opts ...grpc.CallOption) (*ApiSpec, error)
 	// ListApiSpecRevisions lists all revisions of a spec.
@@ -83,6 +93,10 @@ type RegistryClient interface {
 	// DeleteApiDeployment removes a specified deployment, all revisions, and all
 	// child resources (e.g. artifacts).
 	DeleteApiDeployment(ctx context.Context, in *DeleteApiDeploymentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// UndeleteApiDeployment restores a deleted deployment and the resources that were
+	// deleted with it. Deleted resources can be restored until they are purged.
+	// A revision that was deleted on its own is restored by naming the revision.
+	UndeleteApiDeployment(ctx context.Context, in *UndeleteApiDeploymentRequest, opts ...grpc.CallOption) (*ApiDeployment, error)
 	// TagApiDeploymentRevision adds a tag to a specified revision of a
 	// deployment.
 	TagApiDeploymentRevision(ctx context.Context, in *TagApiDeploymentRevisionRequest, opts ...grpc.CallOption) (*ApiDeployment, error)
@@ -109,6 +123,76 @@ type RegistryClient interface {
 	ReplaceArtifact(ctx context.Context, in *ReplaceArtifactRequest, opts ...grpc.CallOption) (*Artifact, error)
 	// DeleteArtifact removes a specified artifact.
 	DeleteArtifact(ctx context.Context, in *DeleteArtifactRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// UndeleteArtifact restores a deleted artifact. Deleted artifacts can be
+	// restored until they are purged.
+	UndeleteArtifact(ctx context.Context, in *UndeleteArtifactRequest, opts ...grpc.CallOption) (*Artifact, error)
+	// TagArtifactRevision adds a tag to a specified revision of an artifact.
+	TagArtifactRevision(ctx context.Context, in *TagArtifactRevisionRequest, opts ...grpc.CallOption) (*Artifact, error)
+	// ListArtifactRevisions lists all revisions of an artifact.
+	// Revisions are returned in descending order of revision creation time.
+	ListArtifactRevisions(ctx context.Context, in *ListArtifactRevisionsRequest, opts ...grpc.CallOption) (*ListArtifactRevisionsResponse, error)
+	// RollbackArtifact sets the current revision to a specified prior
+	// revision. Note that this creates a new revision with a new revision ID.
+	RollbackArtifact(ctx context.Context, in *RollbackArtifactRequest, opts ...grpc.CallOption) (*Artifact, error)
+	// BatchCreateApis creates APIs in a single request. Unless partial
+	// success is allowed, either all of the APIs are created or none are.
+	BatchCreateApis(ctx context.Context, in *BatchCreateApisRequest, opts ...grpc.CallOption) (*BatchCreateApisResponse, error)
+	// BatchUpdateApis updates APIs in a single request. Unless partial
+	// success is allowed, either all of the APIs are updated or none are.
+	BatchUpdateApis(ctx context.Context, in *BatchUpdateApisRequest, opts ...grpc.CallOption) (*BatchUpdateApisResponse, error)
+	// BatchDeleteApis deletes APIs in a single request. Unless partial
+	// success is allowed, either all of the APIs are deleted or none are.
+	BatchDeleteApis(ctx context.Context, in *BatchDeleteApisRequest, opts ...grpc.CallOption) (*BatchDeleteApisResponse, error)
+	// BatchCreateApiVersions creates versions in a single request. Unless partial
+	// success is allowed, either all of the versions are created or none are.
+	BatchCreateApiVersions(ctx context.Context, in *BatchCreateApiVersionsRequest, opts ...grpc.CallOption) (*BatchCreateApiVersionsResponse, error)
+	// BatchUpdateApiVersions updates versions in a single request. Unless partial
+	// success is allowed, either all of the versions are updated or none are.
+	BatchUpdateApiVersions(ctx context.Context, in *BatchUpdateApiVersionsRequest, opts ...grpc.CallOption) (*BatchUpdateApiVersionsResponse, error)
+	// BatchDeleteApiVersions deletes versions in a single request. Unless partial
+	// success is allowed, either all of the versions are deleted or none are.
+	BatchDeleteApiVersions(ctx context.Context, in *BatchDeleteApiVersionsRequest, opts ...grpc.CallOption) (*BatchDeleteApiVersionsResponse, error)
+	// BatchCreateApiSpecs creates specs in a single request. Unless partial
+	// success is allowed, either all of the specs are created or none are.
+	BatchCreateApiSpecs(ctx context.Context, in *BatchCreateApiSpecsRequest, opts ...grpc.CallOption) (*BatchCreateApiSpecsResponse, error)
+	// BatchUpdateApiSpecs updates specs in a single request. Unless partial
+	// success is allowed, either all of the specs are updated or none are.
+	BatchUpdateApiSpecs(ctx context.Context, in *BatchUpdateApiSpecsRequest, opts ...grpc.CallOption) (*BatchUpdateApiSpecsResponse, error)
+	// BatchDeleteApiSpecs deletes specs in a single request. Unless partial
+	// success is allowed, either all of the specs are deleted or none are.
+	BatchDeleteApiSpecs(ctx context.Context, in *BatchDeleteApiSpecsRequest, opts ...grpc.CallOption) (*BatchDeleteApiSpecsResponse, error)
+	// BatchCreateApiDeployments creates deployments in a single request. Unless partial
+	// success is allowed, either all of the deployments are created or none are.
+	BatchCreateApiDeployments(ctx context.Context, in *BatchCreateApiDeploymentsRequest, opts ...grpc.CallOption) (*BatchCreateApiDeploymentsResponse, error)
+	// BatchUpdateApiDeployments updates deployments in a single request. Unless partial
+	// success is allowed, either all of the deployments are updated or none are.
+	BatchUpdateApiDeployments(ctx context.Context, in *BatchUpdateApiDeploymentsRequest, opts ...grpc.CallOption) (*BatchUpdateApiDeploymentsResponse, error)
+	// BatchDeleteApiDeployments deletes deployments in a single request. Unless partial
+	// success is allowed, either all of the deployments are deleted or none are.
+	BatchDeleteApiDeployments(ctx context.Context, in *BatchDeleteApiDeploymentsRequest, opts ...grpc.CallOption) (*BatchDeleteApiDeploymentsResponse, error)
+	// BatchCreateArtifacts creates artifacts in a single request. Unless partial
+	// success is allowed, either all of the artifacts are created or none are.
+	BatchCreateArtifacts(ctx context.Context, in *BatchCreateArtifactsRequest, opts ...grpc.CallOption) (*BatchCreateArtifactsResponse, error)
+	// BatchReplaceArtifacts replaces artifacts in a single request. Unless partial
+	// success is allowed, either all of the artifacts are replaced or none are.
+	BatchReplaceArtifacts(ctx context.Context, in *BatchReplaceArtifactsRequest, opts ...grpc.CallOption) (*BatchReplaceArtifactsResponse, error)
+	// BatchDeleteArtifacts deletes artifacts in a single request. Unless partial
+	// success is allowed, either all of the artifacts are deleted or none are.
+	BatchDeleteArtifacts(ctx context.Context, in *BatchDeleteArtifactsRequest, opts ...grpc.CallOption) (*BatchDeleteArtifactsResponse, error)
+	// ListReferences lists the references from a resource to other resources.
+	// References from the artifacts of the resource are included.
+	ListReferences(ctx context.Context, in *ListReferencesRequest, opts ...grpc.CallOption) (*ListReferencesResponse, error)
+	// ListReferrers lists the references to a resource from other resources.
+	// References to the revisions and children of the resource are included.
+	ListReferrers(ctx context.Context, in *ListReferrersRequest, opts ...grpc.CallOption) (*ListReferrersResponse, error)
+	// SearchResources searches the names, display names, descriptions, labels
+	// and annotations of the APIs, versions, specs and deployments of a project
+	// and the contents of its specs.
+	SearchResources(ctx context.Context, in *SearchResourcesRequest, opts ...grpc.CallOption) (*SearchResourcesResponse, error)
+	// ListSpecElements lists the operations, schemas, messages and services
+	// that are defined by the current revisions of specs, as recorded in the
+	// SpecIndex artifacts of the specs.
+	ListSpecElements(ctx context.Context, in *ListSpecElementsRequest, opts ...grpc.CallOption) (*ListSpecElementsResponse, error)
 }
 
 type registryClient struct {
@@ -164,6 +248,15 @@ func (c *registryClient) DeleteApi(ctx context.Context, in *DeleteApiRequest, op
 	return out, nil
 }
 
+func (c *registryClient) UndeleteApi(ctx context.Context, in *UndeleteApiRequest, opts ...grpc.CallOption) (*Api, error) {
+	out := new(Api)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Registry/UndeleteApi", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *registryClient) ListApiVersions(ctx context.Context, in *ListApiVersionsRequest, opts ...grpc.CallOption) (*ListApiVersionsResponse, error) {
 	out := new(ListApiVersionsResponse)
 	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Registry/ListApiVersions", in, out, opts...)
@@ -209,6 +302,15 @@ func (c *registryClient) DeleteApiVersion(ctx context.Context, in *DeleteApiVers
 	return out, nil
 }
 
+func (c *registryClient) UndeleteApiVersion(ctx context.Context, in *UndeleteApiVersionRequest, opts ...grpc.CallOption) (*ApiVersion, error) {
+	out := new(ApiVersion)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Registry/UndeleteApiVersion", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *registryClient) ListApiSpecs(ctx context.Context, in *ListApiSpecsRequest, opts ...grpc.CallOption) (*ListApiSpecsResponse, error) {
 	out := new(ListApiSpecsResponse)
 	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Registry/ListApiSpecs", in, out, opts...)
@@ -263,6 +365,15 @@ func (c *registryClient) DeleteApiSpec(ctx context.Context, in *DeleteApiSpecReq
 	return out, nil
 }
 
+func (c *registryClient) UndeleteApiSpec(ctx context.Context, in *UndeleteApiSpecRequest, opts ...grpc.CallOption) (*ApiSpec, error) {
+	out := new(ApiSpec)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Registry/UndeleteApiSpec", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *registryClient) TagApiSpecRevision(ctx context.Context, in *TagApiSpecRevisionRequest, opts ...grpc.CallOption) (*ApiSpec, error) {
 	out := new(ApiSpec)
 	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Registry/TagApiSpecRevision", in, out, opts...)
@@ -344,6 +455,15 @@ func (c *registryClient) DeleteApiDeployment(ctx context.Context, in *DeleteApiD
 	return out, nil
 }
 
+func (c *registryClient) UndeleteApiDeployment(ctx context.Context, in *UndeleteApiDeploymentRequest, opts ...grpc.CallOption) (*ApiDeployment, error) {
+	out := new(ApiDeployment)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Registry/UndeleteApiDeployment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *registryClient) TagApiDeploymentRevision(ctx context.Context, in *TagApiDeploymentRevisionRequest, opts ...grpc.CallOption) (*ApiDeployment, error) {
 	out := new(ApiDeployment)
 	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Registry/TagApiDeploymentRevision", in, out, opts...)
@@ -434,6 +554,213 @@ func (c *registryClient) DeleteArtifact(ctx context.Context, in *DeleteArtifactR
 	return out, nil
 }
 
+func (c *registryClient) UndeleteArtifact(ctx context.Context, in *UndeleteArtifactRequest, opts ...grpc.CallOption) (*Artifact, error) {
+	out := new(Artifact)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Registry/UndeleteArtifact", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) TagArtifactRevision(ctx context.Context, in *TagArtifactRevisionRequest, opts ...grpc.CallOption) (*Artifact, error) {
+	out := new(Artifact)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Registry/TagArtifactRevision", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) ListArtifactRevisions(ctx context.Context, in *ListArtifactRevisionsRequest, opts ...grpc.CallOption) (*ListArtifactRevisionsResponse, error) {
+	out := new(ListArtifactRevisionsResponse)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Registry/ListArtifactRevisions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) RollbackArtifact(ctx context.Context, in *RollbackArtifactRequest, opts ...grpc.CallOption) (*Artifact, error) {
+	out := new(Artifact)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Registry/RollbackArtifact", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) BatchCreateApis(ctx context.Context, in *BatchCreateApisRequest, opts ...grpc.CallOption) (*BatchCreateApisResponse, error) {
+	out := new(BatchCreateApisResponse)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Registry/BatchCreateApis", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) BatchUpdateApis(ctx context.Context, in *BatchUpdateApisRequest, opts ...grpc.CallOption) (*BatchUpdateApisResponse, error) {
+	out := new(BatchUpdateApisResponse)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Registry/BatchUpdateApis", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) BatchDeleteApis(ctx context.Context, in *BatchDeleteApisRequest, opts ...grpc.CallOption) (*BatchDeleteApisResponse, error) {
+	out := new(BatchDeleteApisResponse)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Registry/BatchDeleteApis", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) BatchCreateApiVersions(ctx context.Context, in *BatchCreateApiVersionsRequest, opts ...grpc.CallOption) (*BatchCreateApiVersionsResponse, error) {
+	out := new(BatchCreateApiVersionsResponse)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Registry/BatchCreateApiVersions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) BatchUpdateApiVersions(ctx context.Context, in *BatchUpdateApiVersionsRequest, opts ...grpc.CallOption) (*BatchUpdateApiVersionsResponse, error) {
+	out := new(BatchUpdateApiVersionsResponse)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Registry/BatchUpdateApiVersions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) BatchDeleteApiVersions(ctx context.Context, in *BatchDeleteApiVersionsRequest, opts ...grpc.CallOption) (*BatchDeleteApiVersionsResponse, error) {
+	out := new(BatchDeleteApiVersionsResponse)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Registry/BatchDeleteApiVersions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) BatchCreateApiSpecs(ctx context.Context, in *BatchCreateApiSpecsRequest, opts ...grpc.CallOption) (*BatchCreateApiSpecsResponse, error) {
+	out := new(BatchCreateApiSpecsResponse)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Registry/BatchCreateApiSpecs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) BatchUpdateApiSpecs(ctx context.Context, in *BatchUpdateApiSpecsRequest, opts ...grpc.CallOption) (*BatchUpdateApiSpecsResponse, error) {
+	out := new(BatchUpdateApiSpecsResponse)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Registry/BatchUpdateApiSpecs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) BatchDeleteApiSpecs(ctx context.Context, in *BatchDeleteApiSpecsRequest, opts ...grpc.CallOption) (*BatchDeleteApiSpecsResponse, error) {
+	out := new(BatchDeleteApiSpecsResponse)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Registry/BatchDeleteApiSpecs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) BatchCreateApiDeployments(ctx context.Context, in *BatchCreateApiDeploymentsRequest, opts ...grpc.CallOption) (*BatchCreateApiDeploymentsResponse, error) {
+	out := new(BatchCreateApiDeploymentsResponse)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Registry/BatchCreateApiDeployments", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) BatchUpdateApiDeployments(ctx context.Context, in *BatchUpdateApiDeploymentsRequest, opts ...grpc.CallOption) (*BatchUpdateApiDeploymentsResponse, error) {
+	out := new(BatchUpdateApiDeploymentsResponse)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Registry/BatchUpdateApiDeployments", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) BatchDeleteApiDeployments(ctx context.Context, in *BatchDeleteApiDeploymentsRequest, opts ...grpc.CallOption) (*BatchDeleteApiDeploymentsResponse, error) {
+	out := new(BatchDeleteApiDeploymentsResponse)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Registry/BatchDeleteApiDeployments", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) BatchCreateArtifacts(ctx context.Context, in *BatchCreateArtifactsRequest, opts ...grpc.CallOption) (*BatchCreateArtifactsResponse, error) {
+	out := new(BatchCreateArtifactsResponse)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Registry/BatchCreateArtifacts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) BatchReplaceArtifacts(ctx context.Context, in *BatchReplaceArtifactsRequest, opts ...grpc.CallOption) (*BatchReplaceArtifactsResponse, error) {
+	out := new(BatchReplaceArtifactsResponse)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Registry/BatchReplaceArtifacts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) BatchDeleteArtifacts(ctx context.Context, in *BatchDeleteArtifactsRequest, opts ...grpc.CallOption) (*BatchDeleteArtifactsResponse, error) {
+	out := new(BatchDeleteArtifactsResponse)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Registry/BatchDeleteArtifacts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) ListReferences(ctx context.Context, in *ListReferencesRequest, opts ...grpc.CallOption) (*ListReferencesResponse, error) {
+	out := new(ListReferencesResponse)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Registry/ListReferences", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) ListReferrers(ctx context.Context, in *ListReferrersRequest, opts ...grpc.CallOption) (*ListReferrersResponse, error) {
+	out := new(ListReferrersResponse)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Registry/ListReferrers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) SearchResources(ctx context.Context, in *SearchResourcesRequest, opts ...grpc.CallOption) (*SearchResourcesResponse, error) {
+	out := new(SearchResourcesResponse)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Registry/SearchResources", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) ListSpecElements(ctx context.Context, in *ListSpecElementsRequest, opts ...grpc.CallOption) (*ListSpecElementsResponse, error) {
+	out := new(ListSpecElementsResponse)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Registry/ListSpecElements", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RegistryServer is the server API for Registry service.
 // All implementations must embed UnimplementedRegistryServer
 // for forward compatibility
@@ -449,6 +776,9 @@ type RegistryServer interface {
 	// DeleteApi removes a specified API and all of the resources that it
 	// owns.
 	DeleteApi(context.Context, *DeleteApiRequest) (*emptypb.Empty, error)
+	// UndeleteApi restores a deleted API and the resources that were
+	// deleted with it. Deleted resources can be restored until they are purged.
+	UndeleteApi(context.Context, *UndeleteApiRequest) (*Api, error)
 	// ListApiVersions returns matching versions.
 	ListApiVersions(context.Context, *ListApiVersionsRequest) (*ListApiVersionsResponse, error)
 	// GetApiVersion returns a specified version.
@@ -460,6 +790,9 @@ type RegistryServer interface {
 	// DeleteApiVersion removes a specified version and all of the resources that
 	// it owns.
 	DeleteApiVersion(context.Context, *DeleteApiVersionRequest) (*emptypb.Empty, error)
+	// UndeleteApiVersion restores a deleted version and the resources that were
+	// deleted with it. Deleted resources can be restored until they are purged.
+	UndeleteApiVersion(context.Context, *UndeleteApiVersionRequest) (*ApiVersion, error)
 	// ListApiSpecs returns matching specs.
 	ListApiSpecs(context.Context, *ListApiSpecsRequest) (*ListApiSpecsResponse, error)
 	// GetApiSpec returns a specified spec.
@@ -476,6 +809,10 @@ type RegistryServer interface {
 	// DeleteApiSpec removes a specified spec, all revisions, and all child
 	// resources (e.g. artifacts).
 	DeleteApiSpec(context.Context, *DeleteApiSpecRequest) (*emptypb.Empty, error)
+	// UndeleteApiSpec restores a deleted spec and the resources that were
+	// deleted with it. Deleted resources can be restored until they are purged.
+	// A revision that was deleted on its own is restored by naming the revision.
+	UndeleteApiSpec(context.Context, *UndeleteApiSpecRequest) (*ApiSpec, error)
 	// TagApiSpecRevision adds a tag to a specified revision of a spec.
 	TagApiSpecRevision(context.Context, *TagApiSpecRevisionRequest) (*ApiSpec, error)
 	// ListApiSpecRevisions lists all revisions of a spec.
@@ -497,6 +834,10 @@ type RegistryServer interface {
 	// DeleteApiDeployment removes a specified deployment, all revisions, and all
 	// child resources (e.g. artifacts).
 	DeleteApiDeployment(context.Context, *DeleteApiDeploymentRequest) (*emptypb.Empty, error)
+	// UndeleteApiDeployment restores a deleted deployment and the resources that were
+	// deleted with it. Deleted resources can be restored until they are purged.
+	// A revision that was deleted on its own is restored by naming the revision.
+	UndeleteApiDeployment(context.Context, *UndeleteApiDeploymentRequest) (*ApiDeployment, error)
 	// TagApiDeploymentRevision adds a tag to a specified revision of a
 	// deployment.
 	TagApiDeploymentRevision(context.Context, *TagApiDeploymentRevisionRequest) (*ApiDeployment, error)
@@ -523,6 +864,76 @@ type RegistryServer interface {
 	ReplaceArtifact(context.Context, *ReplaceArtifactRequest) (*Artifact, error)
 	// DeleteArtifact removes a specified artifact.
 	DeleteArtifact(context.Context, *DeleteArtifactRequest) (*emptypb.Empty, error)
+	// UndeleteArtifact restores a deleted artifact. Deleted artifacts can be
+	// restored until they are purged.
+	UndeleteArtifact(context.Context, *UndeleteArtifactRequest) (*Artifact, error)
+	// TagArtifactRevision adds a tag to a specified revision of an artifact.
+	TagArtifactRevision(context.Context, *TagArtifactRevisionRequest) (*Artifact, error)
+	// ListArtifactRevisions lists all revisions of an artifact.
+	// Revisions are returned in descending order of revision creation time.
+	ListArtifactRevisions(context.Context, *ListArtifactRevisionsRequest) (*ListArtifactRevisionsResponse, error)
+	// RollbackArtifact sets the current revision to a specified prior
+	// revision. Note that this creates a new revision with a new revision ID.
+	RollbackArtifact(context.Context, *RollbackArtifactRequest) (*Artifact, error)
+	// BatchCreateApis creates APIs in a single request. Unless partial
+	// success is allowed, either all of the APIs are created or none are.
+	BatchCreateApis(context.Context, *BatchCreateApisRequest) (*BatchCreateApisResponse, error)
+	// BatchUpdateApis updates APIs in a single request. Unless partial
+	// success is allowed, either all of the APIs are updated or none are.
+	BatchUpdateApis(context.Context, *BatchUpdateApisRequest) (*BatchUpdateApisResponse, error)
+	// BatchDeleteApis deletes APIs in a single request. Unless partial
+	// success is allowed, either all of the APIs are deleted or none are.
+	BatchDeleteApis(context.Context, *BatchDeleteApisRequest) (*BatchDeleteApisResponse, error)
+	// BatchCreateApiVersions creates versions in a single request. Unless partial
+	// success is allowed, either all of the versions are created or none are.
+	BatchCreateApiVersions(context.Context, *BatchCreateApiVersionsRequest) (*BatchCreateApiVersionsResponse, error)
+	// BatchUpdateApiVersions updates versions in a single request. Unless partial
+	// success is allowed, either all of the versions are updated or none are.
+	BatchUpdateApiVersions(context.Context, *BatchUpdateApiVersionsRequest) (*BatchUpdateApiVersionsResponse, error)
+	// BatchDeleteApiVersions deletes versions in a single request. Unless partial
+	// success is allowed, either all of the versions are deleted or none are.
+	BatchDeleteApiVersions(context.Context, *BatchDeleteApiVersionsRequest) (*BatchDeleteApiVersionsResponse, error)
+	// BatchCreateApiSpecs creates specs in a single request. Unless partial
+	// success is allowed, either all of the specs are created or none are.
+	BatchCreateApiSpecs(context.Context, *BatchCreateApiSpecsRequest) (*BatchCreateApiSpecsResponse, error)
+	// BatchUpdateApiSpecs updates specs in a single request. Unless partial
+	// success is allowed, either all of the specs are updated or none are.
+	BatchUpdateApiSpecs(context.Context, *BatchUpdateApiSpecsRequest) (*BatchUpdateApiSpecsResponse, error)
+	// BatchDeleteApiSpecs deletes specs in a single request. Unless partial
+	// success is allowed, either all of the specs are deleted or none are.
+	BatchDeleteApiSpecs(context.Context, *BatchDeleteApiSpecsRequest) (*BatchDeleteApiSpecsResponse, error)
+	// BatchCreateApiDeployments creates deployments in a single request. Unless partial
+	// success is allowed, either all of the deployments are created or none are.
+	BatchCreateApiDeployments(context.Context, *BatchCreateApiDeploymentsRequest) (*BatchCreateApiDeploymentsResponse, error)
+	// BatchUpdateApiDeployments updates deployments in a single request. Unless partial
+	// success is allowed, either all of the deployments are updated or none are.
+	BatchUpdateApiDeployments(context.Context, *BatchUpdateApiDeploymentsRequest) (*BatchUpdateApiDeploymentsResponse, error)
+	// BatchDeleteApiDeployments deletes deployments in a single request. Unless partial
+	// success is allowed, either all of the deployments are deleted or none are.
+	BatchDeleteApiDeployments(context.Context, *BatchDeleteApiDeploymentsRequest) (*BatchDeleteApiDeploymentsResponse, error)
+	// BatchCreateArtifacts creates artifacts in a single request. Unless partial
+	// success is allowed, either all of the artifacts are created or none are.
+	BatchCreateArtifacts(context.Context, *BatchCreateArtifactsRequest) (*BatchCreateArtifactsResponse, error)
+	// BatchReplaceArtifacts replaces artifacts in a single request. Unless partial
+	// success is allowed, either all of the artifacts are replaced or none are.
+	BatchReplaceArtifacts(context.Context, *BatchReplaceArtifactsRequest) (*BatchReplaceArtifactsResponse, error)
+	// BatchDeleteArtifacts deletes artifacts in a single request. Unless partial
+	// success is allowed, either all of the artifacts are deleted or none are.
+	BatchDeleteArtifacts(context.Context, *BatchDeleteArtifactsRequest) (*BatchDeleteArtifactsResponse, error)
+	// ListReferences lists the references from a resource to other resources.
+	// References from the artifacts of the resource are included.
+	ListReferences(context.Context, *ListReferencesRequest) (*ListReferencesResponse, error)
+	// ListReferrers lists the references to a resource from other resources.
+	// References to the revisions and children of the resource are included.
+	ListReferrers(context.Context, *ListReferrersRequest) (*ListReferrersResponse, error)
+	// SearchResources searches the names, display names, descriptions, labels
+	// and annotations of the APIs, versions, specs and deployments of a project
+	// and the contents of its specs.
+	SearchResources(context.Context, *SearchResourcesRequest) (*SearchResourcesResponse, error)
+	// ListSpecElements lists the operations, schemas, messages and services
+	// that are defined by the current revisions of specs, as recorded in the
+	// SpecIndex artifacts of the specs.
+	ListSpecElements(context.Context, *ListSpecElementsRequest) (*ListSpecElementsResponse, error)
 	mustEmbedUnimplementedRegistryServer()
 }
 
@@ -545,6 +956,9 @@ func (UnimplementedRegistryServer) UpdateApi(context.Context, *UpdateApiRequest)
 func (UnimplementedRegistryServer) DeleteApi(context.Context, *DeleteApiRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteApi not implemented")
 }
+func (UnimplementedRegistryServer) UndeleteApi(context.Context, *UndeleteApiRequest) (*Api, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UndeleteApi not implemented")
+}
 func (UnimplementedRegistryServer) ListApiVersions(context.Context, *ListApiVersionsRequest) (*ListApiVersionsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListApiVersions not implemented")
 }
@@ -560,6 +974,9 @@ func (UnimplementedRegistryServer) UpdateApiVersion(context.Context, *UpdateApiV
 func (UnimplementedRegistryServer) DeleteApiVersion(context.Context, *DeleteApiVersionRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteApiVersion not implemented")
 }
+func (UnimplementedRegistryServer) UndeleteApiVersion(context.Context, *UndeleteApiVersionRequest) (*ApiVersion, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UndeleteApiVersion not implemented")
+}
 func (UnimplementedRegistryServer) ListApiSpecs(context.Context, *ListApiSpecsRequest) (*ListApiSpecsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListApiSpecs not implemented")
 }
@@ -578,6 +995,9 @@ func (UnimplementedRegistryServer) UpdateApiSpec(context.Context, *UpdateApiSpec
 func (UnimplementedRegistryServer) DeleteApiSpec(context.Context, *DeleteApiSpecRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteApiSpec not implemented")
 }
+func (UnimplementedRegistryServer) UndeleteApiSpec(context.Context, *UndeleteApiSpecRequest) (*ApiSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UndeleteApiSpec not implemented")
+}
 func (UnimplementedRegistryServer) TagApiSpecRevision(context.Context, *TagApiSpecRevisionRequest) (*ApiSpec, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method TagApiSpecRevision not implemented")
 }
@@ -605,6 +1025,9 @@ func (UnimplementedRegistryServer) UpdateApiDeployment(context.Context, *UpdateA
 func (UnimplementedRegistryServer) DeleteApiDeployment(context.Context, *DeleteApiDeploymentRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteApiDeployment not implemented")
 }
+func (UnimplementedRegistryServer) UndeleteApiDeployment(context.Context, *UndeleteApiDeploymentRequest) (*ApiDeployment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UndeleteApiDeployment not implemented")
+}
 func (UnimplementedRegistryServer) TagApiDeploymentRevision(context.Context, *TagApiDeploymentRevisionRequest) (*ApiDeployment, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method TagApiDeploymentRevision not implemented")
 }
@@ -635,6 +1058,75 @@ func (UnimplementedRegistryServer) ReplaceArtifact(context.Context, *ReplaceArti
 func (UnimplementedRegistryServer) DeleteArtifact(context.Context, *DeleteArtifactRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteArtifact not implemented")
 }
+func (UnimplementedRegistryServer) UndeleteArtifact(context.Context, *UndeleteArtifactRequest) (*Artifact, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UndeleteArtifact not implemented")
+}
+func (UnimplementedRegistryServer) TagArtifactRevision(context.Context, *TagArtifactRevisionRequest) (*Artifact, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TagArtifactRevision not implemented")
+}
+func (UnimplementedRegistryServer) ListArtifactRevisions(context.Context, *ListArtifactRevisionsRequest) (*ListArtifactRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListArtifactRevisions not implemented")
+}
+func (UnimplementedRegistryServer) RollbackArtifact(context.Context, *RollbackArtifactRequest) (*Artifact, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RollbackArtifact not implemented")
+}
+func (UnimplementedRegistryServer) BatchCreateApis(context.Context, *BatchCreateApisRequest) (*BatchCreateApisResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchCreateApis not implemented")
+}
+func (UnimplementedRegistryServer) BatchUpdateApis(context.Context, *BatchUpdateApisRequest) (*BatchUpdateApisResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchUpdateApis not implemented")
+}
+func (UnimplementedRegistryServer) BatchDeleteApis(context.Context, *BatchDeleteApisRequest) (*BatchDeleteApisResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchDeleteApis not implemented")
+}
+func (UnimplementedRegistryServer) BatchCreateApiVersions(context.Context, *BatchCreateApiVersionsRequest) (*BatchCreateApiVersionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchCreateApiVersions not implemented")
+}
+func (UnimplementedRegistryServer) BatchUpdateApiVersions(context.Context, *BatchUpdateApiVersionsRequest) (*BatchUpdateApiVersionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchUpdateApiVersions not implemented")
+}
+func (UnimplementedRegistryServer) BatchDeleteApiVersions(context.Context, *BatchDeleteApiVersionsRequest) (*BatchDeleteApiVersionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchDeleteApiVersions not implemented")
+}
+func (UnimplementedRegistryServer) BatchCreateApiSpecs(context.Context, *BatchCreateApiSpecsRequest) (*BatchCreateApiSpecsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchCreateApiSpecs not implemented")
+}
+func (UnimplementedRegistryServer) BatchUpdateApiSpecs(context.Context, *BatchUpdateApiSpecsRequest) (*BatchUpdateApiSpecsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchUpdateApiSpecs not implemented")
+}
+func (UnimplementedRegistryServer) BatchDeleteApiSpecs(context.Context, *BatchDeleteApiSpecsRequest) (*BatchDeleteApiSpecsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchDeleteApiSpecs not implemented")
+}
+func (UnimplementedRegistryServer) BatchCreateApiDeployments(context.Context, *BatchCreateApiDeploymentsRequest) (*BatchCreateApiDeploymentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchCreateApiDeployments not implemented")
+}
+func (UnimplementedRegistryServer) BatchUpdateApiDeployments(context.Context, *BatchUpdateApiDeploymentsRequest) (*BatchUpdateApiDeploymentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchUpdateApiDeployments not implemented")
+}
+func (UnimplementedRegistryServer) BatchDeleteApiDeployments(context.Context, *BatchDeleteApiDeploymentsRequest) (*BatchDeleteApiDeploymentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchDeleteApiDeployments not implemented")
+}
+func (UnimplementedRegistryServer) BatchCreateArtifacts(context.Context, *BatchCreateArtifactsRequest) (*BatchCreateArtifactsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchCreateArtifacts not implemented")
+}
+func (UnimplementedRegistryServer) BatchReplaceArtifacts(context.Context, *BatchReplaceArtifactsRequest) (*BatchReplaceArtifactsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchReplaceArtifacts not implemented")
+}
+func (UnimplementedRegistryServer) BatchDeleteArtifacts(context.Context, *BatchDeleteArtifactsRequest) (*BatchDeleteArtifactsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchDeleteArtifacts not implemented")
+}
+func (UnimplementedRegistryServer) ListReferences(context.Context, *ListReferencesRequest) (*ListReferencesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReferences not implemented")
+}
+func (UnimplementedRegistryServer) ListReferrers(context.Context, *ListReferrersRequest) (*ListReferrersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReferrers not implemented")
+}
+func (UnimplementedRegistryServer) SearchResources(context.Context, *SearchResourcesRequest) (*SearchResourcesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchResources not implemented")
+}
+func (UnimplementedRegistryServer) ListSpecElements(context.Context, *ListSpecElementsRequest) (*ListSpecElementsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSpecElements not implemented")
+}
 func (UnimplementedRegistryServer) mustEmbedUnimplementedRegistryServer() {}
 
 // UnsafeRegistryServer may be embedded to opt out of forward compatibility for this service.
@@ -738,6 +1230,24 @@ func _Registry_DeleteApi_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Registry_UndeleteApi_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UndeleteApiRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).UndeleteApi(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Registry/UndeleteApi",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).UndeleteApi(ctx, req.(*UndeleteApiRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Registry_ListApiVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListApiVersionsRequest)
 	if err := dec(in); err != nil {
@@ -828,6 +1338,24 @@ func _Registry_DeleteApiVersion_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Registry_UndeleteApiVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UndeleteApiVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).UndeleteApiVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Registry/UndeleteApiVersion",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).UndeleteApiVersion(ctx, req.(*UndeleteApiVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Registry_ListApiSpecs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListApiSpecsRequest)
 	if err := dec(in); err != nil {
@@ -936,6 +1464,24 @@ func _Registry_DeleteApiSpec_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Registry_UndeleteApiSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UndeleteApiSpecRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).UndeleteApiSpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Registry/UndeleteApiSpec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).UndeleteApiSpec(ctx, req.(*UndeleteApiSpecRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Registry_TagApiSpecRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(TagApiSpecRevisionRequest)
 	if err := dec(in); err != nil {
@@ -1098,6 +1644,24 @@ func _Registry_DeleteApiDeployment_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Registry_UndeleteApiDeployment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UndeleteApiDeploymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).UndeleteApiDeployment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Registry/UndeleteApiDeployment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).UndeleteApiDeployment(ctx, req.(*UndeleteApiDeploymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Registry_TagApiDeploymentRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(TagApiDeploymentRevisionRequest)
 	if err := dec(in); err != nil {
@@ -1278,10 +1842,424 @@ func _Registry_DeleteArtifact_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
-// Registry_ServiceDesc is the grpc.ServiceDesc for Registry service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var Registry_ServiceDesc = grpc.ServiceDesc{
+func _Registry_UndeleteArtifact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UndeleteArtifactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).UndeleteArtifact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Registry/UndeleteArtifact",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).UndeleteArtifact(ctx, req.(*UndeleteArtifactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_TagArtifactRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TagArtifactRevisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).TagArtifactRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Registry/TagArtifactRevision",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).TagArtifactRevision(ctx, req.(*TagArtifactRevisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_ListArtifactRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListArtifactRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).ListArtifactRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Registry/ListArtifactRevisions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).ListArtifactRevisions(ctx, req.(*ListArtifactRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_RollbackArtifact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RollbackArtifactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).RollbackArtifact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Registry/RollbackArtifact",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).RollbackArtifact(ctx, req.(*RollbackArtifactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_BatchCreateApis_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCreateApisRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).BatchCreateApis(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Registry/BatchCreateApis",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).BatchCreateApis(ctx, req.(*BatchCreateApisRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_BatchUpdateApis_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchUpdateApisRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).BatchUpdateApis(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Registry/BatchUpdateApis",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).BatchUpdateApis(ctx, req.(*BatchUpdateApisRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_BatchDeleteApis_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchDeleteApisRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).BatchDeleteApis(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Registry/BatchDeleteApis",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).BatchDeleteApis(ctx, req.(*BatchDeleteApisRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_BatchCreateApiVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCreateApiVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).BatchCreateApiVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Registry/BatchCreateApiVersions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).BatchCreateApiVersions(ctx, req.(*BatchCreateApiVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_BatchUpdateApiVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchUpdateApiVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).BatchUpdateApiVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Registry/BatchUpdateApiVersions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).BatchUpdateApiVersions(ctx, req.(*BatchUpdateApiVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_BatchDeleteApiVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchDeleteApiVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).BatchDeleteApiVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Registry/BatchDeleteApiVersions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).BatchDeleteApiVersions(ctx, req.(*BatchDeleteApiVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_BatchCreateApiSpecs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCreateApiSpecsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).BatchCreateApiSpecs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Registry/BatchCreateApiSpecs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).BatchCreateApiSpecs(ctx, req.(*BatchCreateApiSpecsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_BatchUpdateApiSpecs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchUpdateApiSpecsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).BatchUpdateApiSpecs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Registry/BatchUpdateApiSpecs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).BatchUpdateApiSpecs(ctx, req.(*BatchUpdateApiSpecsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_BatchDeleteApiSpecs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchDeleteApiSpecsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).BatchDeleteApiSpecs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Registry/BatchDeleteApiSpecs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).BatchDeleteApiSpecs(ctx, req.(*BatchDeleteApiSpecsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_BatchCreateApiDeployments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCreateApiDeploymentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).BatchCreateApiDeployments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Registry/BatchCreateApiDeployments",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).BatchCreateApiDeployments(ctx, req.(*BatchCreateApiDeploymentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_BatchUpdateApiDeployments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchUpdateApiDeploymentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).BatchUpdateApiDeployments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Registry/BatchUpdateApiDeployments",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).BatchUpdateApiDeployments(ctx, req.(*BatchUpdateApiDeploymentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_BatchDeleteApiDeployments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchDeleteApiDeploymentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).BatchDeleteApiDeployments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Registry/BatchDeleteApiDeployments",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).BatchDeleteApiDeployments(ctx, req.(*BatchDeleteApiDeploymentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_BatchCreateArtifacts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCreateArtifactsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).BatchCreateArtifacts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Registry/BatchCreateArtifacts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).BatchCreateArtifacts(ctx, req.(*BatchCreateArtifactsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_BatchReplaceArtifacts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchReplaceArtifactsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).BatchReplaceArtifacts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Registry/BatchReplaceArtifacts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).BatchReplaceArtifacts(ctx, req.(*BatchReplaceArtifactsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_BatchDeleteArtifacts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchDeleteArtifactsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).BatchDeleteArtifacts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Registry/BatchDeleteArtifacts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).BatchDeleteArtifacts(ctx, req.(*BatchDeleteArtifactsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_ListReferences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReferencesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).ListReferences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Registry/ListReferences",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).ListReferences(ctx, req.(*ListReferencesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_ListReferrers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReferrersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).ListReferrers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Registry/ListReferrers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).ListReferrers(ctx, req.(*ListReferrersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_SearchResources_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchResourcesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).SearchResources(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Registry/SearchResources",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).SearchResources(ctx, req.(*SearchResourcesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_ListSpecElements_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSpecElementsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).ListSpecElements(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Registry/ListSpecElements",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).ListSpecElements(ctx, req.(*ListSpecElementsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Registry_ServiceDesc is the grpc.ServiceDesc for Registry service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Registry_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "google.cloud.apigeeregistry.v1.Registry",
 	HandlerType: (*RegistryServer)(nil),
 	Methods: []grpc.MethodDesc{
@@ -1305,6 +2283,10 @@ var Registry_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteApi",
 			Handler:    _Registry_DeleteApi_Handler,
 		},
+		{
+			MethodName: "UndeleteApi",
+			Handler:    _Registry_UndeleteApi_Handler,
+		},
 		{
 			MethodName: "ListApiVersions",
 			Handler:    _Registry_ListApiVersions_Handler,
@@ -1325,6 +2307,10 @@ var Registry_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteApiVersion",
 			Handler:    _Registry_DeleteApiVersion_Handler,
 		},
+		{
+			MethodName: "UndeleteApiVersion",
+			Handler:    _Registry_UndeleteApiVersion_Handler,
+		},
 		{
 			MethodName: "ListApiSpecs",
 			Handler:    _Registry_ListApiSpecs_Handler,
@@ -1349,6 +2335,10 @@ var Registry_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteApiSpec",
 			Handler:    _Registry_DeleteApiSpec_Handler,
 		},
+		{
+			MethodName: "UndeleteApiSpec",
+			Handler:    _Registry_UndeleteApiSpec_Handler,
+		},
 		{
 			MethodName: "TagApiSpecRevision",
 			Handler:    _Registry_TagApiSpecRevision_Handler,
@@ -1385,6 +2375,10 @@ var Registry_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteApiDeployment",
 			Handler:    _Registry_DeleteApiDeployment_Handler,
 		},
+		{
+			MethodName: "UndeleteApiDeployment",
+			Handler:    _Registry_UndeleteApiDeployment_Handler,
+		},
 		{
 			MethodName: "TagApiDeploymentRevision",
 			Handler:    _Registry_TagApiDeploymentRevision_Handler,
@@ -1425,6 +2419,98 @@ var Registry_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteArtifact",
 			Handler:    _Registry_DeleteArtifact_Handler,
 		},
+		{
+			MethodName: "UndeleteArtifact",
+			Handler:    _Registry_UndeleteArtifact_Handler,
+		},
+		{
+			MethodName: "TagArtifactRevision",
+			Handler:    _Registry_TagArtifactRevision_Handler,
+		},
+		{
+			MethodName: "ListArtifactRevisions",
+			Handler:    _Registry_ListArtifactRevisions_Handler,
+		},
+		{
+			MethodName: "RollbackArtifact",
+			Handler:    _Registry_RollbackArtifact_Handler,
+		},
+		{
+			MethodName: "BatchCreateApis",
+			Handler:    _Registry_BatchCreateApis_Handler,
+		},
+		{
+			MethodName: "BatchUpdateApis",
+			Handler:    _Registry_BatchUpdateApis_Handler,
+		},
+		{
+			MethodName: "BatchDeleteApis",
+			Handler:    _Registry_BatchDeleteApis_Handler,
+		},
+		{
+			MethodName: "BatchCreateApiVersions",
+			Handler:    _Registry_BatchCreateApiVersions_Handler,
+		},
+		{
+			MethodName: "BatchUpdateApiVersions",
+			Handler:    _Registry_BatchUpdateApiVersions_Handler,
+		},
+		{
+			MethodName: "BatchDeleteApiVersions",
+			Handler:    _Registry_BatchDeleteApiVersions_Handler,
+		},
+		{
+			MethodName: "BatchCreateApiSpecs",
+			Handler:    _Registry_BatchCreateApiSpecs_Handler,
+		},
+		{
+			MethodName: "BatchUpdateApiSpecs",
+			Handler:    _Registry_BatchUpdateApiSpecs_Handler,
+		},
+		{
+			MethodName: "BatchDeleteApiSpecs",
+			Handler:    _Registry_BatchDeleteApiSpecs_Handler,
+		},
+		{
+			MethodName: "BatchCreateApiDeployments",
+			Handler:    _Registry_BatchCreateApiDeployments_Handler,
+		},
+		{
+			MethodName: "BatchUpdateApiDeployments",
+			Handler:    _Registry_BatchUpdateApiDeployments_Handler,
+		},
+		{
+			MethodName: "BatchDeleteApiDeployments",
+			Handler:    _Registry_BatchDeleteApiDeployments_Handler,
+		},
+		{
+			MethodName: "BatchCreateArtifacts",
+			Handler:    _Registry_BatchCreateArtifacts_Handler,
+		},
+		{
+			MethodName: "BatchReplaceArtifacts",
+			Handler:    _Registry_BatchReplaceArtifacts_Handler,
+		},
+		{
+			MethodName: "BatchDeleteArtifacts",
+			Handler:    _Registry_BatchDeleteArtifacts_Handler,
+		},
+		{
+			MethodName: "ListReferences",
+			Handler:    _Registry_ListReferences_Handler,
+		},
+		{
+			MethodName: "ListReferrers",
+			Handler:    _Registry_ListReferrers_Handler,
+		},
+		{
+			MethodName: "SearchResources",
+			Handler:    _Registry_SearchResources_Handler,
+		},
+		{
+			MethodName: "ListSpecElements",
+			Handler:    _Registry_ListSpecElements_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "google/cloud/apigeeregistry/v1/registry_service.proto",
@@ -0,0 +1,76 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/storage"
+	"github.com/apigee/registry/server/registry/names"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AggregateResources handles the corresponding API request.
+func (s *RegistryServer) AggregateResources(ctx context.Context, req *rpc.AggregateResourcesRequest) (*rpc.AggregateResourcesResponse, error) {
+	db, err := s.getStorageClient(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+
+	collection, err := names.ParseResourceCollection(req.GetCollection())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid collection %q", req.GetCollection())
+	}
+
+	opts := storage.AggregateOptions{
+		Filter:  req.GetFilter(),
+		GroupBy: req.GetGroupBy(),
+	}
+	var aggregate storage.Aggregate
+	switch collection := collection.(type) {
+	case names.Project:
+		aggregate, err = db.AggregateProjects(ctx, opts)
+	case names.Api:
+		aggregate, err = db.AggregateApis(ctx, collection.Project(), opts)
+	case names.Version:
+		aggregate, err = db.AggregateVersions(ctx, collection.Api(), opts)
+	case names.Spec:
+		aggregate, err = db.AggregateSpecs(ctx, collection.Version(), opts)
+	case names.SpecRevision:
+		aggregate, err = db.AggregateSpecRevisions(ctx, collection, opts)
+	case names.Deployment:
+		aggregate, err = db.AggregateDeployments(ctx, collection.Api(), opts)
+	case names.DeploymentRevision:
+		aggregate, err = db.AggregateDeploymentRevisions(ctx, collection, opts)
+	case names.Artifact:
+		aggregate, err = db.AggregateArtifacts(ctx, collection, opts)
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported collection %q", req.GetCollection())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	response := &rpc.AggregateResourcesResponse{
+		TotalCount: aggregate.Count,
+		Groups:     make([]*rpc.AggregateResourcesResponse_Group, len(aggregate.Groups)),
+	}
+	for i, g := range aggregate.Groups {
+		response.Groups[i] = &rpc.AggregateResourcesResponse_Group{Value: g.Value, Count: g.Count}
+	}
+	return response, nil
+}
@@ -0,0 +1,213 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/test/seeder"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestAggregateResources(t *testing.T) {
+	if adminServiceUnavailable() {
+		t.Skip(testRequiresAdminService)
+	}
+	ctx := context.Background()
+	server := defaultTestServer(t)
+
+	const (
+		a = "projects/p1/locations/global/apis/a"
+		b = "projects/p1/locations/global/apis/b"
+	)
+	if err := seeder.SeedRegistry(ctx, server,
+		&rpc.Api{Name: a, Availability: "GA", Labels: map[string]string{"owner": "alice"}},
+		&rpc.Api{Name: b, Availability: "Beta", Labels: map[string]string{"owner": "bob"}},
+		&rpc.Api{Name: "projects/p2/locations/global/apis/c", Availability: "GA", Labels: map[string]string{"owner": "alice"}},
+		&rpc.Api{Name: "projects/p2/locations/global/apis/d", Availability: "GA"},
+		&rpc.ApiVersion{Name: a + "/versions/v1", State: "production"},
+		&rpc.ApiVersion{Name: a + "/versions/v2", State: "design"},
+		&rpc.ApiVersion{Name: b + "/versions/v1", State: "production"},
+		&rpc.ApiSpec{Name: a + "/versions/v1/specs/openapi", MimeType: "application/x.openapi"},
+		&rpc.ApiSpec{Name: a + "/versions/v2/specs/proto", MimeType: "application/x.protobuf"},
+		&rpc.ApiDeployment{Name: a + "/deployments/prod"},
+		&rpc.Artifact{Name: a + "/versions/v1/artifacts/x", MimeType: "text/plain"},
+		&rpc.Artifact{Name: a + "/versions/v2/specs/proto/artifacts/y", MimeType: "text/plain"},
+		&rpc.Artifact{Name: "projects/p1/locations/global/artifacts/z", MimeType: "application/json"},
+	); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+	// A new revision of a spec replaces it in counts of specs, but not of revisions.
+	if _, err := server.UpdateApiSpec(ctx, &rpc.UpdateApiSpecRequest{
+		ApiSpec: &rpc.ApiSpec{Name: a + "/versions/v1/specs/openapi", MimeType: "application/x.openapi;version=3", Contents: []byte("openapi: 3.0.0")},
+	}); err != nil {
+		t.Fatalf("Setup: UpdateApiSpec() returned error: %s", err)
+	}
+
+	type group = rpc.AggregateResourcesResponse_Group
+	tests := []struct {
+		desc string
+		req  *rpc.AggregateResourcesRequest
+		want *rpc.AggregateResourcesResponse
+	}{
+		{
+			desc: "projects",
+			req:  &rpc.AggregateResourcesRequest{Collection: "projects"},
+			want: &rpc.AggregateResourcesResponse{TotalCount: 2},
+		},
+		{
+			desc: "apis in all projects grouped by label",
+			req:  &rpc.AggregateResourcesRequest{Collection: "projects/-/locations/global/apis", GroupBy: "labels.owner"},
+			want: &rpc.AggregateResourcesResponse{
+				TotalCount: 4,
+				Groups:     []*group{{Value: "", Count: 1}, {Value: "alice", Count: 2}, {Value: "bob", Count: 1}},
+			},
+		},
+		{
+			desc: "filtered apis grouped by availability",
+			req: &rpc.AggregateResourcesRequest{
+				Collection: "projects/-/locations/global/apis",
+				Filter:     `has(labels.owner)`,
+				GroupBy:    "availability",
+			},
+			want: &rpc.AggregateResourcesResponse{
+				TotalCount: 3,
+				Groups:     []*group{{Value: "Beta", Count: 1}, {Value: "GA", Count: 2}},
+			},
+		},
+		{
+			desc: "apis filtered in memory",
+			req: &rpc.AggregateResourcesRequest{
+				Collection: "projects/-/locations/global/apis",
+				Filter:     `api_id.upperAscii() != "A"`,
+				GroupBy:    "labels.owner",
+			},
+			want: &rpc.AggregateResourcesResponse{
+				TotalCount: 3,
+				Groups:     []*group{{Value: "", Count: 1}, {Value: "alice", Count: 1}, {Value: "bob", Count: 1}},
+			},
+		},
+		{
+			desc: "versions grouped by state",
+			req:  &rpc.AggregateResourcesRequest{Collection: "projects/p1/locations/global/apis/-/versions", GroupBy: "state"},
+			want: &rpc.AggregateResourcesResponse{
+				TotalCount: 3,
+				Groups:     []*group{{Value: "design", Count: 1}, {Value: "production", Count: 2}},
+			},
+		},
+		{
+			desc: "specs grouped by mime type",
+			req:  &rpc.AggregateResourcesRequest{Collection: a + "/versions/-/specs", GroupBy: "mime_type"},
+			want: &rpc.AggregateResourcesResponse{
+				TotalCount: 2,
+				Groups:     []*group{{Value: "application/x.openapi;version=3", Count: 1}, {Value: "application/x.protobuf", Count: 1}},
+			},
+		},
+		{
+			desc: "spec revisions",
+			req:  &rpc.AggregateResourcesRequest{Collection: a + "/versions/v1/specs/openapi@"},
+			want: &rpc.AggregateResourcesResponse{TotalCount: 2},
+		},
+		{
+			desc: "deployments",
+			req:  &rpc.AggregateResourcesRequest{Collection: "projects/-/locations/global/apis/-/deployments"},
+			want: &rpc.AggregateResourcesResponse{TotalCount: 1},
+		},
+		{
+			desc: "version artifacts",
+			req:  &rpc.AggregateResourcesRequest{Collection: a + "/versions/-/artifacts", GroupBy: "mime_type"},
+			want: &rpc.AggregateResourcesResponse{
+				TotalCount: 1,
+				Groups:     []*group{{Value: "text/plain", Count: 1}},
+			},
+		},
+		{
+			desc: "spec artifacts",
+			req:  &rpc.AggregateResourcesRequest{Collection: a + "/versions/-/specs/-/artifacts"},
+			want: &rpc.AggregateResourcesResponse{TotalCount: 1},
+		},
+		{
+			desc: "project artifacts",
+			req:  &rpc.AggregateResourcesRequest{Collection: "projects/p1/locations/global/artifacts"},
+			want: &rpc.AggregateResourcesResponse{TotalCount: 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			got, err := server.AggregateResources(ctx, test.req)
+			if err != nil {
+				t.Fatalf("AggregateResources(%+v) returned error: %s", test.req, err)
+			}
+			if diff := cmp.Diff(test.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("AggregateResources(%+v) returned unexpected diff (-want +got):\n%s", test.req, diff)
+			}
+		})
+	}
+}
+
+func TestAggregateResourcesErrors(t *testing.T) {
+	if adminServiceUnavailable() {
+		t.Skip(testRequiresAdminService)
+	}
+	tests := []struct {
+		desc string
+		req  *rpc.AggregateResourcesRequest
+	}{
+		{
+			desc: "missing collection",
+			req:  &rpc.AggregateResourcesRequest{},
+		},
+		{
+			desc: "resource instead of collection",
+			req:  &rpc.AggregateResourcesRequest{Collection: "projects/p1/locations/global/apis/a"},
+		},
+		{
+			desc: "invalid filter",
+			req:  &rpc.AggregateResourcesRequest{Collection: "projects/p1/locations/global/apis", Filter: "invalid filter"},
+		},
+		{
+			desc: "unknown group field",
+			req:  &rpc.AggregateResourcesRequest{Collection: "projects/p1/locations/global/apis", GroupBy: "mime_type"},
+		},
+		{
+			desc: "map without key",
+			req:  &rpc.AggregateResourcesRequest{Collection: "projects/p1/locations/global/apis", GroupBy: "labels"},
+		},
+		{
+			desc: "key of a string",
+			req:  &rpc.AggregateResourcesRequest{Collection: "projects/p1/locations/global/apis", GroupBy: "display_name.x"},
+		},
+		{
+			desc: "timestamp",
+			req:  &rpc.AggregateResourcesRequest{Collection: "projects/p1/locations/global/apis", GroupBy: "create_time"},
+		},
+	}
+
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			if _, err := server.AggregateResources(ctx, test.req); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("AggregateResources(%+v) returned status code %q, want %q: %v", test.req, status.Code(err), codes.InvalidArgument, err)
+			}
+		})
+	}
+}
@@ -44,13 +44,18 @@ func (s *RegistryServer) CreateApi(ctx context.Context, req *rpc.CreateApiReques
 	}
 	var response *rpc.Api
 	if err := s.runInTransaction(ctx, func(ctx context.Context, db *storage.Client) error {
+		db.LockApi(ctx, name)
 		var err error
 		response, err = s.createApi(ctx, db, name, req.GetApi())
-		return err
+		if err != nil {
+			return err
+		}
+		s.notify(ctx, rpc.Notification_CREATED, response.GetName())
+		s.audit(ctx, "CreateApi", response.GetName(), nil, response)
+		return nil
 	}); err != nil {
 		return nil, err
 	}
-	s.notify(ctx, rpc.Notification_CREATED, response.GetName())
 	return response, nil
 }
 
@@ -75,14 +80,64 @@ func (s *RegistryServer) DeleteApi(ctx context.Context, req *rpc.DeleteApiReques
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 	if err := s.runInTransaction(ctx, func(ctx context.Context, db *storage.Client) error {
-		return db.LockApis(ctx).DeleteApi(ctx, name, req.GetForce())
+		db.LockApi(ctx, name)
+		if req.GetEtag() != "" {
+			api, err := db.GetApi(ctx, name)
+			if err != nil {
+				return err
+			}
+			if err := checkEtag(req.GetName(), req.GetEtag(), api.Etag()); err != nil {
+				return err
+			}
+		}
+		if err := s.checkReferrers(ctx, db, name.String()); err != nil {
+			return err
+		}
+		if err := db.DeleteApi(ctx, name, req.GetForce()); err != nil {
+			return err
+		}
+		if err := s.purgeDeleted(ctx, db); err != nil {
+			return err
+		}
+		s.notify(ctx, rpc.Notification_DELETED, req.GetName())
+		s.audit(ctx, "DeleteApi", req.GetName(), nil, nil)
+		return nil
 	}); err != nil {
 		return nil, err
 	}
-	s.notify(ctx, rpc.Notification_DELETED, req.GetName())
 	return &emptypb.Empty{}, nil
 }
 
+// UndeleteApi handles the corresponding API request.
+func (s *RegistryServer) UndeleteApi(ctx context.Context, req *rpc.UndeleteApiRequest) (*rpc.Api, error) {
+	// API name must be valid.
+	name, err := names.ParseApi(req.GetName())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var response *rpc.Api
+	if err := s.runInTransaction(ctx, func(ctx context.Context, db *storage.Client) error {
+		if err := db.LockApi(ctx, name).UndeleteApi(ctx, name); err != nil {
+			return err
+		}
+		api, err := db.GetApi(ctx, name)
+		if err != nil {
+			return err
+		}
+		response, err = api.Message()
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		s.notify(ctx, rpc.Notification_CREATED, response.GetName())
+		s.audit(ctx, "UndeleteApi", response.GetName(), nil, response)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
 // GetApi handles the corresponding API request.
 func (s *RegistryServer) GetApi(ctx context.Context, req *rpc.GetApiRequest) (*rpc.Api, error) {
 	db, err := s.getStorageClient(ctx)
@@ -129,10 +184,11 @@ func (s *RegistryServer) ListApis(ctx context.Context, req *rpc.ListApisRequest)
 	}
 
 	listing, err := db.ListApis(ctx, parent, storage.PageOptions{
-		Size:   req.GetPageSize(),
-		Filter: req.GetFilter(),
-		Order:  req.GetOrderBy(),
-		Token:  req.GetPageToken(),
+		Size:        req.GetPageSize(),
+		Filter:      req.GetFilter(),
+		Order:       req.GetOrderBy(),
+		Token:       req.GetPageToken(),
+		ShowDeleted: req.GetShowDeleted(),
 	})
 	if err != nil {
 		return nil, err
@@ -170,9 +226,16 @@ func (s *RegistryServer) UpdateApi(ctx context.Context, req *rpc.UpdateApiReques
 	}
 	var response *rpc.Api
 	if err := s.runInTransaction(ctx, func(ctx context.Context, db *storage.Client) error {
-		db.LockApis(ctx)
+		db.LockApi(ctx, name)
+		var before *rpc.Api
 		api, err := db.GetApi(ctx, name)
 		if err == nil {
+			if before, err = api.Message(); err != nil {
+				return err
+			}
+			if err := checkEtag(name.String(), req.GetApi().GetEtag(), api.Etag()); err != nil {
+				return err
+			}
 			if err := api.Update(req.GetApi(), models.ExpandMask(req.GetApi(), req.GetUpdateMask())); err != nil {
 				return err
 			}
@@ -180,16 +243,20 @@ func (s *RegistryServer) UpdateApi(ctx context.Context, req *rpc.UpdateApiReques
 				return err
 			}
 			response, err = api.Message()
-			return err
 		} else if status.Code(err) == codes.NotFound && req.GetAllowMissing() {
+			if err := missingEtag(name.String(), req.GetApi().GetEtag()); err != nil {
+				return err
+			}
 			response, err = s.createApi(ctx, db, name, req.GetApi())
-			return err
-		} else {
+		}
+		if err != nil {
 			return err
 		}
+		s.notify(ctx, rpc.Notification_UPDATED, response.GetName())
+		s.audit(ctx, "UpdateApi", response.GetName(), before, response)
+		return nil
 	}); err != nil {
 		return nil, err
 	}
-	s.notify(ctx, rpc.Notification_UPDATED, response.GetName())
 	return response, nil
 }
@@ -91,7 +91,7 @@ func TestCreateApi(t *testing.T) {
 
 			opts := cmp.Options{
 				protocmp.Transform(),
-				protocmp.IgnoreFields(new(rpc.Api), "create_time", "update_time"),
+				protocmp.IgnoreFields(new(rpc.Api), "create_time", "update_time", "etag"),
 			}
 
 			if !cmp.Equal(test.want, created, opts) {
@@ -329,7 +329,7 @@ func TestGetApi(t *testing.T) {
 
 			opts := cmp.Options{
 				protocmp.Transform(),
-				protocmp.IgnoreFields(new(rpc.Api), "create_time", "update_time"),
+				protocmp.IgnoreFields(new(rpc.Api), "create_time", "update_time", "etag"),
 			}
 
 			if !cmp.Equal(test.want, got, opts) {
@@ -622,7 +622,7 @@ func TestListApis(t *testing.T) {
 			opts := cmp.Options{
 				protocmp.Transform(),
 				protocmp.IgnoreFields(new(rpc.ListApisResponse), "next_page_token"),
-				protocmp.IgnoreFields(new(rpc.Api), "create_time", "update_time"),
+				protocmp.IgnoreFields(new(rpc.Api), "create_time", "update_time", "etag"),
 				test.extraOpts,
 			}
 
@@ -831,7 +831,7 @@ func TestListApisSequence(t *testing.T) {
 
 	opts := cmp.Options{
 		protocmp.Transform(),
-		protocmp.IgnoreFields(new(rpc.Api), "create_time", "update_time"),
+		protocmp.IgnoreFields(new(rpc.Api), "create_time", "update_time", "etag"),
 		cmpopts.SortSlices(func(a, b *rpc.Api) bool {
 			return a.GetName() < b.GetName()
 		}),
@@ -1074,7 +1074,7 @@ func TestUpdateApi(t *testing.T) {
 
 			opts := cmp.Options{
 				protocmp.Transform(),
-				protocmp.IgnoreFields(new(rpc.Api), "create_time", "update_time"),
+				protocmp.IgnoreFields(new(rpc.Api), "create_time", "update_time", "etag"),
 			}
 
 			if !cmp.Equal(test.want, updated, opts) {
@@ -1376,3 +1376,164 @@ func TestDeleteApiCascading(t *testing.T) {
 		t.Errorf("GetApiSpec(%q) returned status code %q, want %q: %s", spec.GetName(), status.Code(err), codes.NotFound, err)
 	}
 }
+
+func TestUndeleteApi(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		server = serverWithPurgeWindow(t)
+		v1     = &rpc.ApiVersion{
+			Name: "projects/my-project/locations/global/apis/my-api/versions/v1",
+		}
+		v2 = &rpc.ApiVersion{
+			Name: "projects/my-project/locations/global/apis/my-api/versions/v2",
+		}
+	)
+	if err := seeder.SeedVersions(ctx, server, v1, v2); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+
+	// v1 is deleted before its API, so it stays deleted when the API is undeleted.
+	if _, err := server.DeleteApiVersion(ctx, &rpc.DeleteApiVersionRequest{Name: v1.GetName()}); err != nil {
+		t.Fatalf("DeleteApiVersion(%q) returned error: %s", v1.GetName(), err)
+	}
+	name := "projects/my-project/locations/global/apis/my-api"
+	if _, err := server.DeleteApi(ctx, &rpc.DeleteApiRequest{Name: name, Force: true}); err != nil {
+		t.Fatalf("DeleteApi(%q) returned error: %s", name, err)
+	}
+
+	got, err := server.ListApis(ctx, &rpc.ListApisRequest{Parent: "projects/my-project/locations/global", ShowDeleted: true})
+	if err != nil {
+		t.Fatalf("ListApis(show_deleted) returned error: %s", err)
+	}
+	if len(got.GetApis()) != 1 || got.GetApis()[0].GetDeleteTime() == nil {
+		t.Errorf("ListApis(show_deleted) returned %v, want %q with a delete_time", got.GetApis(), name)
+	}
+
+	if _, err := server.UndeleteApi(ctx, &rpc.UndeleteApiRequest{Name: name}); err != nil {
+		t.Fatalf("UndeleteApi(%q) returned error: %s", name, err)
+	}
+	if _, err := server.GetApiVersion(ctx, &rpc.GetApiVersionRequest{Name: v2.GetName()}); err != nil {
+		t.Errorf("GetApiVersion(%q) returned error: %s", v2.GetName(), err)
+	}
+	if _, err := server.GetApiVersion(ctx, &rpc.GetApiVersionRequest{Name: v1.GetName()}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetApiVersion(%q) returned status code %q, want %q: %v", v1.GetName(), status.Code(err), codes.NotFound, err)
+	}
+}
+
+func TestUndeleteApiResponseCodes(t *testing.T) {
+	tests := []struct {
+		desc         string
+		deleteParent bool
+		req          *rpc.UndeleteApiRequest
+		want         codes.Code
+	}{
+		{
+			desc: "resource not found",
+			req: &rpc.UndeleteApiRequest{
+				Name: "projects/my-project/locations/global/apis/doesnt-exist",
+			},
+			want: codes.NotFound,
+		},
+		{
+			desc: "resource not deleted",
+			req: &rpc.UndeleteApiRequest{
+				Name: "projects/my-project/locations/global/apis/my-api",
+			},
+			want: codes.AlreadyExists,
+		},
+		{
+			desc:         "parent deleted",
+			deleteParent: true,
+			req: &rpc.UndeleteApiRequest{
+				Name: "projects/my-project/locations/global/apis/my-api",
+			},
+			want: codes.FailedPrecondition,
+		},
+		{
+			desc: "invalid name",
+			req: &rpc.UndeleteApiRequest{
+				Name: "projects/my-project/locations/global/apis/my-api/versions/v1",
+			},
+			want: codes.InvalidArgument,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			ctx := context.Background()
+			server := serverWithPurgeWindow(t)
+			if err := seeder.SeedApis(ctx, server, &rpc.Api{Name: "projects/my-project/locations/global/apis/my-api"}); err != nil {
+				t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+			}
+			if test.deleteParent {
+				if _, err := server.DeleteApi(ctx, &rpc.DeleteApiRequest{Name: test.req.GetName()}); err != nil {
+					t.Fatalf("Setup: DeleteApi(%q) returned error: %s", test.req.GetName(), err)
+				}
+				if _, err := server.DeleteProject(ctx, &rpc.DeleteProjectRequest{Name: "projects/my-project"}); err != nil {
+					t.Fatalf("Setup: DeleteProject() returned error: %s", err)
+				}
+			}
+
+			if _, err := server.UndeleteApi(ctx, test.req); status.Code(err) != test.want {
+				t.Errorf("UndeleteApi(%+v) returned status code %q, want %q: %v", test.req, status.Code(err), test.want, err)
+			}
+		})
+	}
+}
+
+func TestCreateApiAfterDelete(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		server  = serverWithPurgeWindow(t)
+		version = &rpc.ApiVersion{
+			Name: "projects/my-project/locations/global/apis/my-api/versions/v1",
+		}
+		name = "projects/my-project/locations/global/apis/my-api"
+	)
+	if err := seeder.SeedVersions(ctx, server, version); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+
+	tests := []struct {
+		desc   string
+		create func() error
+	}{
+		{
+			desc: "create",
+			create: func() error {
+				_, err := server.CreateApi(ctx, &rpc.CreateApiRequest{
+					Parent: "projects/my-project/locations/global",
+					ApiId:  "my-api",
+					Api:    &rpc.Api{},
+				})
+				return err
+			},
+		},
+		{
+			desc: "update with allow_missing",
+			create: func() error {
+				_, err := server.UpdateApi(ctx, &rpc.UpdateApiRequest{
+					Api:          &rpc.Api{Name: name},
+					AllowMissing: true,
+				})
+				return err
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			if _, err := server.DeleteApi(ctx, &rpc.DeleteApiRequest{Name: name, Force: true}); err != nil {
+				t.Fatalf("DeleteApi(%q) returned error: %s", name, err)
+			}
+			if err := test.create(); err != nil {
+				t.Fatalf("creating %q after deleting it returned error: %s", name, err)
+			}
+			list := &rpc.ListApiVersionsRequest{Parent: name, ShowDeleted: true}
+			if got, err := server.ListApiVersions(ctx, list); err != nil {
+				t.Fatalf("ListApiVersions(%+v) returned error: %s", list, err)
+			} else if len(got.GetApiVersions()) != 0 {
+				t.Errorf("ListApiVersions(%+v) returned %v, want the deleted versions to be purged", list, got.GetApiVersions())
+			}
+		})
+	}
+}
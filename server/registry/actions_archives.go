@@ -0,0 +1,236 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/storage"
+	"github.com/apigee/registry/server/registry/internal/storage/models"
+	"github.com/apigee/registry/server/registry/names"
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/longrunning"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// ExportProject handles the corresponding API request.
+func (s *RegistryServer) ExportProject(ctx context.Context, req *rpc.ExportProjectRequest) (*longrunning.Operation, error) {
+	name, err := names.ParseProject(req.GetName())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	db, err := s.getStorageClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.GetProject(ctx, name); err != nil {
+		return nil, err
+	}
+
+	metadata := &rpc.ArchiveMetadata{Project: name.String()}
+	return s.operations.submit(ctx, "ExportProject", metadata, func(ctx context.Context, update func(proto.Message)) (proto.Message, error) {
+		f, err := newArchiveFile(s.maxArchiveSize)
+		if err != nil {
+			return nil, err
+		}
+		defer f.remove()
+		count, err := exportProject(ctx, db, name, f, func(completed, total int) {
+			m := proto.Clone(metadata).(*rpc.ArchiveMetadata)
+			m.CompletedCount, m.TotalCount = int32(completed), int32(total)
+			update(m)
+		})
+		if err != nil {
+			return nil, err
+		}
+		archive, err := s.createArchive(ctx, name.ProjectID, f)
+		if err != nil {
+			return nil, err
+		}
+		return &rpc.ExportProjectResponse{
+			Archive:       archive.Message(),
+			ResourceCount: int32(count),
+		}, nil
+	})
+}
+
+// ImportProject handles the corresponding API request.
+func (s *RegistryServer) ImportProject(ctx context.Context, req *rpc.ImportProjectRequest) (*longrunning.Operation, error) {
+	if !strings.HasPrefix(req.GetArchive(), "archives/") {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid archive %q", req.GetArchive())
+	}
+	db, err := s.getStorageClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	archive, err := db.GetArchive(ctx, req.GetArchive())
+	if err != nil {
+		return nil, err
+	}
+	// The header is checked before the operation starts, which reads the archive again.
+	header, err := readArchiveHeader(ctx, db, archive)
+	if err != nil {
+		return nil, err
+	}
+	// Archives are imported into the projects they were exported from unless another project is specified.
+	project := names.Project{ProjectID: req.GetProjectId()}
+	if project.ProjectID == "" {
+		project.ProjectID = archive.ProjectID
+	}
+	if err := project.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	metadata := &rpc.ArchiveMetadata{
+		Project:    project.String(),
+		Archive:    archive.Key,
+		TotalCount: header.GetResourceCount(),
+	}
+	return s.operations.submit(ctx, "ImportProject", metadata, func(ctx context.Context, update func(proto.Message)) (proto.Message, error) {
+		db, err := s.getStorageClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		contents, err := db.OpenArchive(ctx, archive)
+		if err != nil {
+			return nil, err
+		}
+		defer contents.Close()
+		r, err := newArchiveReader(contents)
+		if err != nil {
+			return nil, err
+		}
+		count, err := s.importProject(ctx, r, project, func(completed, total int) {
+			m := proto.Clone(metadata).(*rpc.ArchiveMetadata)
+			m.CompletedCount, m.TotalCount = int32(completed), int32(total)
+			update(m)
+		})
+		if err != nil {
+			return nil, err
+		}
+		v, err := db.GetProject(ctx, project)
+		if err != nil {
+			return nil, err
+		}
+		return &rpc.ImportProjectResponse{
+			Project:       v.Message(),
+			ResourceCount: int32(count),
+		}, nil
+	})
+}
+
+// ReadArchive handles the corresponding API request.
+func (s *RegistryServer) ReadArchive(req *rpc.ReadArchiveRequest, stream rpc.Admin_ReadArchiveServer) error {
+	if !strings.HasPrefix(req.GetName(), "archives/") {
+		return status.Errorf(codes.InvalidArgument, "invalid archive %q", req.GetName())
+	}
+	ctx := stream.Context()
+	db, err := s.getStorageClient(ctx)
+	if err != nil {
+		return err
+	}
+	archive, err := db.GetArchive(ctx, req.GetName())
+	if err != nil {
+		return err
+	}
+	contents, err := db.OpenArchive(ctx, archive)
+	if err != nil {
+		return err
+	}
+	defer contents.Close()
+	buf := make([]byte, archiveChunkSize)
+	for {
+		n, err := io.ReadFull(contents, buf)
+		if n > 0 {
+			if err := stream.Send(&rpc.ArchiveChunk{Data: buf[:n]}); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil
+		} else if err != nil {
+			return status.Errorf(codes.Unavailable, "failed to read contents of %s: %s", archive.Key, err)
+		}
+	}
+}
+
+// WriteArchive handles the corresponding API request. Uploads are written to
+// a temporary file as they are received and are limited to the maximum size of archives.
+func (s *RegistryServer) WriteArchive(stream rpc.Admin_WriteArchiveServer) error {
+	f, err := newArchiveFile(s.maxArchiveSize)
+	if err != nil {
+		return err
+	}
+	defer f.remove()
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		if _, err := f.Write(chunk.GetData()); err != nil {
+			return err
+		}
+	}
+	contents, err := f.rewind()
+	if err != nil {
+		return err
+	}
+	r, err := newArchiveReader(contents)
+	if err != nil {
+		return err
+	}
+	name, _ := names.ParseProject(r.header.GetProject())
+	archive, err := s.createArchive(stream.Context(), name.ProjectID, f)
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(archive.Message())
+}
+
+// readArchiveHeader returns the header of an archive.
+func readArchiveHeader(ctx context.Context, db *storage.Client, archive *models.Archive) (*rpc.ArchiveEntry_Header, error) {
+	contents, err := db.OpenArchive(ctx, archive)
+	if err != nil {
+		return nil, err
+	}
+	defer contents.Close()
+	r, err := newArchiveReader(contents)
+	if err != nil {
+		return nil, err
+	}
+	return r.header, nil
+}
+
+// createArchive stores the contents of an archive of a project from a file.
+func (s *RegistryServer) createArchive(ctx context.Context, projectID string, f *archiveFile) (*models.Archive, error) {
+	v := models.NewArchive("archives/"+uuid.New().String(), projectID, f.size, f.sum(), archiveLifetime)
+	if err := s.runInTransaction(ctx, func(ctx context.Context, db *storage.Client) error {
+		// The file is read from its start in each attempt of the transaction.
+		contents, err := f.rewind()
+		if err != nil {
+			return err
+		}
+		return db.CreateArchive(ctx, v, contents)
+	}); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
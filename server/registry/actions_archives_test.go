@@ -0,0 +1,554 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/storage/blobstore"
+	"github.com/apigee/registry/server/registry/test/seeder"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// readArchiveStream collects the chunks sent by ReadArchive.
+type readArchiveStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	data bytes.Buffer
+}
+
+func (s *readArchiveStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *readArchiveStream) Send(chunk *rpc.ArchiveChunk) error {
+	s.data.Write(chunk.GetData())
+	return nil
+}
+
+// writeArchiveStream sends archive contents to WriteArchive in small chunks.
+type writeArchiveStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	contents []byte
+	archive  *rpc.Archive
+}
+
+func (s *writeArchiveStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *writeArchiveStream) Recv() (*rpc.ArchiveChunk, error) {
+	if len(s.contents) == 0 {
+		return nil, io.EOF
+	}
+	n := 10
+	if n > len(s.contents) {
+		n = len(s.contents)
+	}
+	chunk := &rpc.ArchiveChunk{Data: s.contents[:n]}
+	s.contents = s.contents[n:]
+	return chunk, nil
+}
+
+func (s *writeArchiveStream) SendAndClose(archive *rpc.Archive) error {
+	s.archive = archive
+	return nil
+}
+
+func exportArchive(ctx context.Context, t *testing.T, server TestServer, project string) *rpc.ExportProjectResponse {
+	t.Helper()
+	op, err := server.ExportProject(ctx, &rpc.ExportProjectRequest{Name: project})
+	if err != nil {
+		t.Fatalf("ExportProject(%q) returned error: %s", project, err)
+	}
+	op = waitForOperation(ctx, t, server, op)
+	if err := op.GetError(); err != nil {
+		t.Fatalf("ExportProject(%q) failed: %s", project, err)
+	}
+	response := &rpc.ExportProjectResponse{}
+	if err := op.GetResponse().UnmarshalTo(response); err != nil {
+		t.Fatalf("ExportProject(%q) returned unexpected response: %s", project, err)
+	}
+	return response
+}
+
+func importArchive(ctx context.Context, t *testing.T, server TestServer, req *rpc.ImportProjectRequest) *rpc.ImportProjectResponse {
+	t.Helper()
+	op, err := server.ImportProject(ctx, req)
+	if err != nil {
+		t.Fatalf("ImportProject(%+v) returned error: %s", req, err)
+	}
+	op = waitForOperation(ctx, t, server, op)
+	if err := op.GetError(); err != nil {
+		t.Fatalf("ImportProject(%+v) failed: %s", req, err)
+	}
+	response := &rpc.ImportProjectResponse{}
+	if err := op.GetResponse().UnmarshalTo(response); err != nil {
+		t.Fatalf("ImportProject(%+v) returned unexpected response: %s", req, err)
+	}
+	return response
+}
+
+func readArchive(ctx context.Context, t *testing.T, server TestServer, name string) []byte {
+	t.Helper()
+	stream := &readArchiveStream{ctx: ctx}
+	if err := server.ReadArchive(&rpc.ReadArchiveRequest{Name: name}, stream); err != nil {
+		t.Fatalf("ReadArchive(%q) returned error: %s", name, err)
+	}
+	return stream.data.Bytes()
+}
+
+// archiveEntryStrings returns the entries of an archive other than its header
+// as JSON with the names of a project replaced by the names of another.
+func archiveEntryStrings(t *testing.T, contents []byte, from, to string) []string {
+	t.Helper()
+	r, err := newArchiveReader(bytes.NewReader(contents))
+	if err != nil {
+		t.Fatalf("newArchiveReader() returned error: %s", err)
+	}
+	var entries []string
+	for {
+		entry, err := r.next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("next() returned error: %s", err)
+		}
+		b, err := protojson.Marshal(entry)
+		if err != nil {
+			t.Fatalf("Marshal() returned error: %s", err)
+		}
+		entries = append(entries, strings.ReplaceAll(string(b), `"`+from, `"`+to))
+	}
+	if len(entries) != int(r.header.GetResourceCount()) {
+		t.Errorf("archive has %d entries, header counts %d", len(entries), r.header.GetResourceCount())
+	}
+	return entries
+}
+
+func TestExportImportProject(t *testing.T) {
+	if adminServiceUnavailable() {
+		t.Skip(testRequiresAdminService)
+	}
+	ctx := context.Background()
+	server := defaultTestServer(t)
+
+	const (
+		api      = "projects/p/locations/global/apis/a"
+		version  = api + "/versions/v"
+		spec     = version + "/specs/s"
+		artifact = "projects/p/locations/global/artifacts/x"
+	)
+	if err := seeder.SeedRegistry(ctx, server,
+		&rpc.ApiSpec{Name: spec, MimeType: "text/plain", Contents: []byte("first")},
+		&rpc.ApiDeployment{Name: api + "/deployments/d", EndpointUri: "https://example.com"},
+		&rpc.Artifact{Name: artifact, MimeType: "text/plain", Contents: []byte("project artifact")},
+	); err != nil {
+		t.Fatalf("Setup: failed to seed registry: %s", err)
+	}
+	s, err := server.GetApiSpec(ctx, &rpc.GetApiSpecRequest{Name: spec})
+	if err != nil {
+		t.Fatalf("Setup: GetApiSpec() returned error: %s", err)
+	}
+	first := spec + "@" + s.GetRevisionId()
+	if _, err := server.TagApiSpecRevision(ctx, &rpc.TagApiSpecRevisionRequest{Name: first, Tag: "first"}); err != nil {
+		t.Fatalf("Setup: TagApiSpecRevision() returned error: %s", err)
+	}
+	if _, err := server.UpdateApiSpec(ctx, &rpc.UpdateApiSpecRequest{
+		ApiSpec:    &rpc.ApiSpec{Name: spec, Contents: []byte("second")},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"contents"}},
+	}); err != nil {
+		t.Fatalf("Setup: UpdateApiSpec() returned error: %s", err)
+	}
+	if _, err := server.UpdateApiDeployment(ctx, &rpc.UpdateApiDeploymentRequest{
+		ApiDeployment: &rpc.ApiDeployment{Name: api + "/deployments/d", ApiSpecRevision: first},
+		UpdateMask:    &fieldmaskpb.FieldMask{Paths: []string{"api_spec_revision"}},
+	}); err != nil {
+		t.Fatalf("Setup: UpdateApiDeployment() returned error: %s", err)
+	}
+	if _, err := server.UpdateApi(ctx, &rpc.UpdateApiRequest{
+		Api:        &rpc.Api{Name: api, RecommendedVersion: version},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"recommended_version"}},
+	}); err != nil {
+		t.Fatalf("Setup: UpdateApi() returned error: %s", err)
+	}
+	if _, err := server.CreateArtifact(ctx, &rpc.CreateArtifactRequest{
+		Parent:     first,
+		ArtifactId: "y",
+		Artifact:   &rpc.Artifact{MimeType: "text/plain", Contents: []byte("revision artifact")},
+	}); err != nil {
+		t.Fatalf("Setup: CreateArtifact() returned error: %s", err)
+	}
+	if _, err := server.TagArtifactRevision(ctx, &rpc.TagArtifactRevisionRequest{Name: artifact, Tag: "first"}); err != nil {
+		t.Fatalf("Setup: TagArtifactRevision() returned error: %s", err)
+	}
+	if _, err := server.ReplaceArtifact(ctx, &rpc.ReplaceArtifactRequest{
+		Artifact: &rpc.Artifact{Name: artifact, MimeType: "text/plain", Contents: []byte("replaced artifact")},
+	}); err != nil {
+		t.Fatalf("Setup: ReplaceArtifact() returned error: %s", err)
+	}
+
+	exported := exportArchive(ctx, t, server, "projects/p")
+	// The project, api, version, two spec revisions and a tag, two deployment
+	// revisions, two artifacts, and an earlier artifact revision and its tag.
+	if got, want := exported.GetResourceCount(), int32(12); got != want {
+		t.Errorf("ExportProject() returned %d resources, want %d", got, want)
+	}
+	if got := exported.GetArchive(); got.GetProject() != "projects/p" || got.GetSizeBytes() == 0 || got.GetHash() == "" {
+		t.Errorf("ExportProject() returned unexpected archive %+v", got)
+	}
+	contents := readArchive(ctx, t, server, exported.GetArchive().GetName())
+	if int64(len(contents)) != exported.GetArchive().GetSizeBytes() {
+		t.Errorf("ReadArchive() returned %d bytes, want %d", len(contents), exported.GetArchive().GetSizeBytes())
+	}
+
+	// Archives can be written back and imported into another project.
+	stream := &writeArchiveStream{ctx: ctx, contents: contents}
+	if err := server.WriteArchive(stream); err != nil {
+		t.Fatalf("WriteArchive() returned error: %s", err)
+	}
+	if stream.archive.GetProject() != "projects/p" || stream.archive.GetHash() != exported.GetArchive().GetHash() {
+		t.Errorf("WriteArchive() returned unexpected archive %+v", stream.archive)
+	}
+	req := &rpc.ImportProjectRequest{Archive: stream.archive.GetName(), ProjectId: "q"}
+	imported := importArchive(ctx, t, server, req)
+	if imported.GetProject().GetName() != "projects/q" || imported.GetResourceCount() != exported.GetResourceCount() {
+		t.Errorf("ImportProject(%+v) returned unexpected response %+v", req, imported)
+	}
+	got, err := server.GetApiSpecContents(ctx, &rpc.GetApiSpecContentsRequest{Name: "projects/q/locations/global/apis/a/versions/v/specs/s@first"})
+	if err != nil {
+		t.Fatalf("GetApiSpecContents() returned error: %s", err)
+	}
+	if string(got.GetData()) != "first" {
+		t.Errorf("GetApiSpecContents() returned %q, want %q", got.GetData(), "first")
+	}
+	got, err = server.GetArtifactContents(ctx, &rpc.GetArtifactContentsRequest{Name: "projects/q/locations/global/artifacts/x@first"})
+	if err != nil {
+		t.Fatalf("GetArtifactContents() returned error: %s", err)
+	}
+	if string(got.GetData()) != "project artifact" {
+		t.Errorf("GetArtifactContents() returned %q, want %q", got.GetData(), "project artifact")
+	}
+	sources, err := server.ListArtifactRevisions(ctx, &rpc.ListArtifactRevisionsRequest{Name: artifact})
+	if err != nil {
+		t.Fatalf("ListArtifactRevisions() returned error: %s", err)
+	}
+	copies, err := server.ListArtifactRevisions(ctx, &rpc.ListArtifactRevisionsRequest{Name: "projects/q/locations/global/artifacts/x"})
+	if err != nil {
+		t.Fatalf("ListArtifactRevisions() returned error: %s", err)
+	}
+	if len(sources.GetArtifacts()) != 2 || len(copies.GetArtifacts()) != len(sources.GetArtifacts()) {
+		t.Fatalf("ListArtifactRevisions() returned %d imported revisions of %d, want 2", len(copies.GetArtifacts()), len(sources.GetArtifacts()))
+	}
+	for i, c := range copies.GetArtifacts() {
+		if c.GetRevisionId() != sources.GetArtifacts()[i].GetRevisionId() {
+			t.Errorf("imported revision %q, want revision ID %q", c.GetName(), sources.GetArtifacts()[i].GetRevisionId())
+		}
+	}
+
+	// Imported resources keep their revisions, times and contents.
+	want := archiveEntryStrings(t, contents, "projects/p", "projects/p")
+	copied := readArchive(ctx, t, server, exportArchive(ctx, t, server, "projects/q").GetArchive().GetName())
+	if diff := cmp.Diff(want, archiveEntryStrings(t, copied, "projects/q", "projects/p")); diff != "" {
+		t.Errorf("imported project differs from exported project (-want +got):\n%s", diff)
+	}
+
+	// Importing an archive again has no further effect.
+	importArchive(ctx, t, server, req)
+	copied = readArchive(ctx, t, server, exportArchive(ctx, t, server, "projects/q").GetArchive().GetName())
+	if diff := cmp.Diff(want, archiveEntryStrings(t, copied, "projects/q", "projects/p")); diff != "" {
+		t.Errorf("project imported twice differs from exported project (-want +got):\n%s", diff)
+	}
+}
+
+func TestArchiveErrors(t *testing.T) {
+	if adminServiceUnavailable() {
+		t.Skip(testRequiresAdminService)
+	}
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	if err := seeder.SeedProjects(ctx, server, &rpc.Project{Name: "projects/p"}); err != nil {
+		t.Fatalf("Setup: failed to seed registry: %s", err)
+	}
+	archive := exportArchive(ctx, t, server, "projects/p").GetArchive().GetName()
+
+	tests := []struct {
+		desc string
+		call func() error
+		want codes.Code
+	}{
+		{
+			desc: "export missing project",
+			call: func() error {
+				_, err := server.ExportProject(ctx, &rpc.ExportProjectRequest{Name: "projects/missing"})
+				return err
+			},
+			want: codes.NotFound,
+		},
+		{
+			desc: "export invalid project",
+			call: func() error {
+				_, err := server.ExportProject(ctx, &rpc.ExportProjectRequest{Name: "invalid"})
+				return err
+			},
+			want: codes.InvalidArgument,
+		},
+		{
+			desc: "import missing archive",
+			call: func() error {
+				_, err := server.ImportProject(ctx, &rpc.ImportProjectRequest{Archive: "archives/missing"})
+				return err
+			},
+			want: codes.NotFound,
+		},
+		{
+			desc: "import invalid archive name",
+			call: func() error {
+				_, err := server.ImportProject(ctx, &rpc.ImportProjectRequest{Archive: "invalid"})
+				return err
+			},
+			want: codes.InvalidArgument,
+		},
+		{
+			desc: "import into invalid project",
+			call: func() error {
+				_, err := server.ImportProject(ctx, &rpc.ImportProjectRequest{Archive: archive, ProjectId: "Invalid!"})
+				return err
+			},
+			want: codes.InvalidArgument,
+		},
+		{
+			desc: "read missing archive",
+			call: func() error {
+				return server.ReadArchive(&rpc.ReadArchiveRequest{Name: "archives/missing"}, &readArchiveStream{ctx: ctx})
+			},
+			want: codes.NotFound,
+		},
+		{
+			desc: "write invalid archive",
+			call: func() error {
+				return server.WriteArchive(&writeArchiveStream{ctx: ctx, contents: []byte("not an archive")})
+			},
+			want: codes.InvalidArgument,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			if err := test.call(); status.Code(err) != test.want {
+				t.Errorf("returned status code %q, want %q: %v", status.Code(err), test.want, err)
+			}
+		})
+	}
+}
+
+func TestImportTamperedArchive(t *testing.T) {
+	if adminServiceUnavailable() {
+		t.Skip(testRequiresAdminService)
+	}
+	ctx := context.Background()
+	server := defaultTestServer(t)
+
+	const (
+		spec     = "projects/p/locations/global/apis/a/versions/v/specs/s"
+		artifact = "projects/p/locations/global/artifacts/x"
+	)
+	if err := seeder.SeedRegistry(ctx, server,
+		&rpc.ApiSpec{Name: spec, MimeType: "text/plain", Contents: []byte("spec")},
+		&rpc.Artifact{Name: artifact, MimeType: "text/plain", Contents: []byte("artifact")},
+	); err != nil {
+		t.Fatalf("Setup: failed to seed registry: %s", err)
+	}
+	contents := readArchive(ctx, t, server, exportArchive(ctx, t, server, "projects/p").GetArchive().GetName())
+
+	tests := []struct {
+		desc   string
+		tamper func(*rpc.ArchiveEntry)
+	}{
+		{
+			desc: "spec contents",
+			tamper: func(entry *rpc.ArchiveEntry) {
+				if m := entry.GetSpec(); m != nil {
+					m.Contents = []byte("tampered")
+				}
+			},
+		},
+		{
+			desc: "artifact contents",
+			tamper: func(entry *rpc.ArchiveEntry) {
+				if m := entry.GetArtifact(); m != nil {
+					m.Contents = []byte("tampered")
+				}
+			},
+		},
+		{
+			desc: "artifact size",
+			tamper: func(entry *rpc.ArchiveEntry) {
+				if m := entry.GetArtifact(); m != nil {
+					m.SizeBytes++
+				}
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			r, err := newArchiveReader(bytes.NewReader(contents))
+			if err != nil {
+				t.Fatalf("newArchiveReader() returned error: %s", err)
+			}
+			var tampered bytes.Buffer
+			w := newArchiveWriter(&tampered)
+			if err := w.write(&rpc.ArchiveEntry{Entry: &rpc.ArchiveEntry_Header_{Header: r.header}}); err != nil {
+				t.Fatalf("write() returned error: %s", err)
+			}
+			for {
+				entry, err := r.next()
+				if err == io.EOF {
+					break
+				} else if err != nil {
+					t.Fatalf("next() returned error: %s", err)
+				}
+				test.tamper(entry)
+				if err := w.write(entry); err != nil {
+					t.Fatalf("write() returned error: %s", err)
+				}
+			}
+			if err := w.close(); err != nil {
+				t.Fatalf("close() returned error: %s", err)
+			}
+
+			stream := &writeArchiveStream{ctx: ctx, contents: tampered.Bytes()}
+			if err := server.WriteArchive(stream); err != nil {
+				t.Fatalf("WriteArchive() returned error: %s", err)
+			}
+			req := &rpc.ImportProjectRequest{Archive: stream.archive.GetName(), ProjectId: "q"}
+			op, err := server.ImportProject(ctx, req)
+			if err != nil {
+				t.Fatalf("ImportProject(%+v) returned error: %s", req, err)
+			}
+			op = waitForOperation(ctx, t, server, op)
+			if got := codes.Code(op.GetError().GetCode()); got != codes.InvalidArgument {
+				t.Errorf("ImportProject(%+v) failed with status code %q, want %q: %v", req, got, codes.InvalidArgument, op.GetError())
+			}
+		})
+	}
+
+	// Contents with the same hash are unchanged.
+	spc, err := server.GetApiSpecContents(ctx, &rpc.GetApiSpecContentsRequest{Name: spec})
+	if err != nil {
+		t.Fatalf("GetApiSpecContents() returned error: %s", err)
+	}
+	if string(spc.GetData()) != "spec" {
+		t.Errorf("GetApiSpecContents() returned %q, want %q", spc.GetData(), "spec")
+	}
+	art, err := server.GetArtifactContents(ctx, &rpc.GetArtifactContentsRequest{Name: artifact})
+	if err != nil {
+		t.Fatalf("GetArtifactContents() returned error: %s", err)
+	}
+	if string(art.GetData()) != "artifact" {
+		t.Errorf("GetArtifactContents() returned %q, want %q", art.GetData(), "artifact")
+	}
+}
+
+func TestArchivesInBlobStore(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	server, err := New(Config{
+		Database:  "sqlite3",
+		DBConfig:  fmt.Sprintf("%s/registry.db", t.TempDir()),
+		BlobStore: BlobStoreConfig{Type: "filesystem", Path: dir},
+	})
+	if err != nil {
+		t.Fatalf("Setup: failed to get server with a blob store: %s", err)
+	}
+	t.Cleanup(server.Close)
+	store, err := blobstore.NewFilesystem(dir)
+	if err != nil {
+		t.Fatalf("Setup: failed to open blob store: %s", err)
+	}
+	const spec = "projects/p/locations/global/apis/a/versions/v/specs/s"
+	if err := seeder.SeedSpecs(ctx, server, &rpc.ApiSpec{Name: spec, MimeType: "text/plain", Contents: []byte("contents")}); err != nil {
+		t.Fatalf("Setup: failed to seed registry: %s", err)
+	}
+
+	// Archives are stored in the blob store.
+	exported := exportArchive(ctx, t, server, "projects/p")
+	contents := readArchive(ctx, t, server, exported.GetArchive().GetName())
+	stored, err := store.Get(ctx, exported.GetArchive().GetHash()+".archive")
+	if err != nil {
+		t.Fatalf("Archive is missing from the blob store: %s", err)
+	}
+	if !bytes.Equal(stored, contents) {
+		t.Errorf("ReadArchive() returned %d bytes that differ from the %d stored bytes", len(contents), len(stored))
+	}
+
+	stream := &writeArchiveStream{ctx: ctx, contents: contents}
+	if err := server.WriteArchive(stream); err != nil {
+		t.Fatalf("WriteArchive() returned error: %s", err)
+	}
+	importArchive(ctx, t, server, &rpc.ImportProjectRequest{Archive: stream.archive.GetName(), ProjectId: "q"})
+	got, err := server.GetApiSpecContents(ctx, &rpc.GetApiSpecContentsRequest{Name: "projects/q/locations/global/apis/a/versions/v/specs/s"})
+	if err != nil {
+		t.Fatalf("GetApiSpecContents() returned error: %s", err)
+	}
+	if string(got.GetData()) != "contents" {
+		t.Errorf("GetApiSpecContents() returned %q, want %q", got.GetData(), "contents")
+	}
+}
+
+func TestArchiveSizeLimit(t *testing.T) {
+	ctx := context.Background()
+	server, err := New(Config{
+		Database:       "sqlite3",
+		DBConfig:       fmt.Sprintf("%s/registry.db", t.TempDir()),
+		MaxArchiveSize: 100,
+	})
+	if err != nil {
+		t.Fatalf("Setup: failed to get server: %s", err)
+	}
+	t.Cleanup(server.Close)
+	// Random contents aren't compressed to fit in the archive.
+	contents := make([]byte, 1000)
+	if _, err := rand.Read(contents); err != nil {
+		t.Fatalf("Setup: failed to generate contents: %s", err)
+	}
+	if err := seeder.SeedArtifacts(ctx, server, &rpc.Artifact{Name: "projects/p/locations/global/artifacts/x", MimeType: "text/plain", Contents: contents}); err != nil {
+		t.Fatalf("Setup: failed to seed registry: %s", err)
+	}
+
+	op, err := server.ExportProject(ctx, &rpc.ExportProjectRequest{Name: "projects/p"})
+	if err != nil {
+		t.Fatalf("ExportProject() returned error: %s", err)
+	}
+	op = waitForOperation(ctx, t, server, op)
+	if got, want := codes.Code(op.GetError().GetCode()), codes.ResourceExhausted; got != want {
+		t.Errorf("ExportProject() of a large project failed with status code %q, want %q: %v", got, want, op.GetError())
+	}
+	if err := server.WriteArchive(&writeArchiveStream{ctx: ctx, contents: contents}); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("WriteArchive() of a large archive returned status code %q, want %q: %v", status.Code(err), codes.ResourceExhausted, err)
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/storage"
+	"github.com/apigee/registry/server/registry/internal/storage/models"
+	"github.com/apigee/registry/server/registry/names"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListArtifactRevisions handles the corresponding API request.
+func (s *RegistryServer) ListArtifactRevisions(ctx context.Context, req *rpc.ListArtifactRevisionsRequest) (*rpc.ListArtifactRevisionsResponse, error) {
+	db, err := s.getStorageClient(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+
+	if req.GetPageSize() < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid page_size %d: must not be negative", req.GetPageSize())
+	} else if req.GetPageSize() > 1000 {
+		req.PageSize = 1000
+	} else if req.GetPageSize() == 0 {
+		req.PageSize = 50
+	}
+
+	parent, err := names.ParseArtifactRevision(req.GetName())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	listing, err := db.ListArtifactRevisions(ctx, parent, storage.PageOptions{
+		Size:   req.GetPageSize(),
+		Token:  req.GetPageToken(),
+		Filter: req.GetFilter(),
+		// order_by is disallowed by AIP-162: https://google.aip.dev/162
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := &rpc.ListArtifactRevisionsResponse{
+		Artifacts:     make([]*rpc.Artifact, len(listing.Artifacts)),
+		NextPageToken: listing.Token,
+	}
+
+	for i := range listing.Artifacts {
+		artifact := &listing.Artifacts[i]
+		response.Artifacts[i], err = artifactMessage(artifact, artifact.RevisionName())
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	return response, nil
+}
+
+// TagArtifactRevision handles the corresponding API request.
+func (s *RegistryServer) TagArtifactRevision(ctx context.Context, req *rpc.TagArtifactRevisionRequest) (*rpc.Artifact, error) {
+	// The tag must be nonempty.
+	if req.GetTag() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid tag %q, must not be empty", req.GetTag())
+	}
+	// The tag length must be valid.
+	if len(req.GetTag()) > 40 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid tag %q, must be 40 characters or less", req.GetTag())
+	}
+	// The tag must match the required format.
+	if err := names.ValidateRevisionTag(req.GetTag()); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", err)
+	}
+	// The requested artifact revision name must be valid. It may include a tag name.
+	name, err := names.ParseArtifactRevision(req.GetName())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	var response *rpc.Artifact
+	if err := s.runInTransaction(ctx, func(ctx context.Context, db *storage.Client) error {
+		// The revision to be tagged must exist. Tags refer to its revision ID, not to another tag.
+		revision, err := db.LockArtifact(ctx, name.Artifact()).GetArtifactRevision(ctx, name)
+		if err != nil {
+			return err
+		}
+		tag := models.NewArtifactRevisionTag(revision, req.GetTag())
+		if err := db.SaveArtifactRevisionTag(ctx, tag); err != nil {
+			return err
+		}
+		response, err = artifactMessage(revision, tag.String())
+		if err != nil {
+			return err
+		}
+		s.notify(ctx, rpc.Notification_UPDATED, revision.RevisionName())
+		s.audit(ctx, "TagArtifactRevision", tag.String(), nil, nil)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// RollbackArtifact handles the corresponding API request.
+func (s *RegistryServer) RollbackArtifact(ctx context.Context, req *rpc.RollbackArtifactRequest) (*rpc.Artifact, error) {
+	// Revision ID must be nonempty.
+	if req.GetRevisionId() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid revision ID %q, must not be empty", req.GetRevisionId())
+	}
+	// Artifact name must be valid.
+	parent, err := names.ParseArtifact(req.GetName())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	var response *rpc.Artifact
+	if err := s.runInTransaction(ctx, func(ctx context.Context, db *storage.Client) error {
+		// Get the target revision to use as a base for the new rollback revision.
+		name := parent.Revision(req.GetRevisionId())
+		target, err := db.LockArtifact(ctx, parent).GetArtifactRevision(ctx, name)
+		if err != nil {
+			return err
+		}
+		blob, err := db.GetArtifactRevisionContents(ctx, name)
+		if err != nil {
+			return err
+		}
+		// Save a new rollback revision based on the target revision.
+		rollback := target.NewRevision()
+		if err := db.SaveArtifact(ctx, rollback); err != nil {
+			return err
+		}
+		if err := db.SaveArtifactContents(ctx, rollback, blob.Contents); err != nil {
+			return err
+		}
+		if err := s.pruneArtifactRevisions(ctx, db, rollback); err != nil {
+			return err
+		}
+		response, err = artifactMessage(rollback, rollback.RevisionName())
+		if err != nil {
+			return err
+		}
+		s.notify(ctx, rpc.Notification_UPDATED, rollback.Name())
+		s.audit(ctx, "RollbackArtifact", rollback.RevisionName(), nil, response)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// pruneArtifactRevisions removes the earlier revisions of an artifact that
+// aren't tagged, unless the server keeps earlier revisions of artifacts.
+func (s *RegistryServer) pruneArtifactRevisions(ctx context.Context, db *storage.Client, artifact *models.Artifact) error {
+	if s.artifactRevisions {
+		return nil
+	}
+	name, err := names.ParseArtifact(artifact.Name())
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	revisions, err := db.ExpiredArtifactRevisions(ctx, name, storage.RetentionPolicy{KeepRevisions: 1})
+	if err != nil || len(revisions) == 0 {
+		return err
+	}
+	return db.RemoveArtifactRevisions(ctx, name, revisionIDs(revisions))
+}
+
+// artifactMessage returns an RPC message representing an artifact with the specified name,
+// which can be the name of one of its revisions.
+func artifactMessage(artifact *models.Artifact, name string) (*rpc.Artifact, error) {
+	message, err := artifact.Message()
+	if err != nil {
+		return nil, err
+	}
+	message.Name = name
+	return message, nil
+}
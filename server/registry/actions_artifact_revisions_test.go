@@ -0,0 +1,263 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/test/seeder"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const testArtifactName = "projects/my-project/locations/global/artifacts/a"
+
+// seedArtifactRevisions creates an artifact with one revision for each of the provided contents.
+func seedArtifactRevisions(ctx context.Context, t *testing.T, server TestServer, contents ...string) []*rpc.Artifact {
+	t.Helper()
+	if err := seeder.SeedProjects(ctx, server, &rpc.Project{Name: "projects/my-project"}); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+	revisions := make([]*rpc.Artifact, 0, len(contents))
+	for _, c := range contents {
+		req := &rpc.ReplaceArtifactRequest{
+			Artifact: &rpc.Artifact{
+				Name:     testArtifactName,
+				MimeType: "text/plain",
+				Contents: []byte(c),
+			},
+		}
+		var revision *rpc.Artifact
+		var err error
+		if len(revisions) == 0 {
+			revision, err = server.CreateArtifact(ctx, &rpc.CreateArtifactRequest{
+				Parent:     "projects/my-project/locations/global",
+				ArtifactId: "a",
+				Artifact:   req.Artifact,
+			})
+		} else {
+			revision, err = server.ReplaceArtifact(ctx, req)
+		}
+		if err != nil {
+			t.Fatalf("Setup: failed to save artifact with contents %q: %s", c, err)
+		}
+		revisions = append(revisions, revision)
+	}
+	return revisions
+}
+
+func listArtifactRevisionIDs(ctx context.Context, t *testing.T, server TestServer, name string) []string {
+	t.Helper()
+	req := &rpc.ListArtifactRevisionsRequest{Name: name}
+	resp, err := server.ListArtifactRevisions(ctx, req)
+	if err != nil {
+		t.Fatalf("ListArtifactRevisions(%+v) returned error: %s", req, err)
+	}
+	ids := make([]string, 0, len(resp.GetArtifacts()))
+	for _, a := range resp.GetArtifacts() {
+		if want := fmt.Sprintf("%s@%s", testArtifactName, a.GetRevisionId()); a.GetName() != want {
+			t.Errorf("ListArtifactRevisions(%+v) returned name %q, want %q", req, a.GetName(), want)
+		}
+		ids = append(ids, a.GetRevisionId())
+	}
+	return ids
+}
+
+func getArtifactRevisionContents(ctx context.Context, t *testing.T, server TestServer, name string) string {
+	t.Helper()
+	req := &rpc.GetArtifactContentsRequest{Name: name}
+	body, err := server.GetArtifactContents(ctx, req)
+	if err != nil {
+		t.Fatalf("GetArtifactContents(%+v) returned error: %s", req, err)
+	}
+	return string(body.GetData())
+}
+
+func TestArtifactRevisions(t *testing.T) {
+	ctx := context.Background()
+	server, err := New(Config{
+		Database:          "sqlite3",
+		DBConfig:          fmt.Sprintf("%s/registry.db", t.TempDir()),
+		ArtifactRevisions: true,
+	})
+	if err != nil {
+		t.Fatalf("Setup: failed to get server with SQLite: %s", err)
+	}
+	t.Cleanup(server.Close)
+	revisions := seedArtifactRevisions(ctx, t, server, "one", "two", "three")
+
+	// Unchanged replacements don't create revisions.
+	if _, err := server.ReplaceArtifact(ctx, &rpc.ReplaceArtifactRequest{
+		Artifact: &rpc.Artifact{Name: testArtifactName, MimeType: "text/plain", Contents: []byte("three")},
+	}); err != nil {
+		t.Fatalf("ReplaceArtifact() returned error: %s", err)
+	}
+
+	want := []string{revisions[2].GetRevisionId(), revisions[1].GetRevisionId(), revisions[0].GetRevisionId()}
+	if got := listArtifactRevisionIDs(ctx, t, server, testArtifactName+"@-"); !cmp.Equal(want, got) {
+		t.Errorf("ListArtifactRevisions() returned unexpected revisions (-want +got):\n%s", cmp.Diff(want, got))
+	}
+
+	first := fmt.Sprintf("%s@%s", testArtifactName, revisions[0].GetRevisionId())
+	got, err := server.GetArtifact(ctx, &rpc.GetArtifactRequest{Name: first})
+	if err != nil {
+		t.Fatalf("GetArtifact(%q) returned error: %s", first, err)
+	}
+	if got.GetName() != first || got.GetRevisionId() != revisions[0].GetRevisionId() {
+		t.Errorf("GetArtifact(%q) returned %q with revision %q", first, got.GetName(), got.GetRevisionId())
+	}
+	if contents := getArtifactRevisionContents(ctx, t, server, first); contents != "one" {
+		t.Errorf("GetArtifactContents(%q) returned %q, want %q", first, contents, "one")
+	}
+
+	tagged, err := server.TagArtifactRevision(ctx, &rpc.TagArtifactRevisionRequest{Name: first, Tag: "stable"})
+	if err != nil {
+		t.Fatalf("TagArtifactRevision(%q) returned error: %s", first, err)
+	}
+	if want := testArtifactName + "@stable"; tagged.GetName() != want {
+		t.Errorf("TagArtifactRevision(%q) returned name %q, want %q", first, tagged.GetName(), want)
+	}
+	if contents := getArtifactRevisionContents(ctx, t, server, testArtifactName+"@stable"); contents != "one" {
+		t.Errorf("GetArtifactContents(%q) returned %q, want %q", testArtifactName+"@stable", contents, "one")
+	}
+	if got := listArtifactRevisionIDs(ctx, t, server, testArtifactName+"@stable"); !cmp.Equal(want[2:], got) {
+		t.Errorf("ListArtifactRevisions() of tag returned unexpected revisions (-want +got):\n%s", cmp.Diff(want[2:], got))
+	}
+
+	rollback, err := server.RollbackArtifact(ctx, &rpc.RollbackArtifactRequest{
+		Name:       testArtifactName,
+		RevisionId: "stable",
+	})
+	if err != nil {
+		t.Fatalf("RollbackArtifact() returned error: %s", err)
+	}
+	if contents := getArtifactRevisionContents(ctx, t, server, testArtifactName); contents != "one" {
+		t.Errorf("GetArtifactContents(%q) after rollback returned %q, want %q", testArtifactName, contents, "one")
+	}
+	want = append([]string{rollback.GetRevisionId()}, want...)
+	if got := listArtifactRevisionIDs(ctx, t, server, testArtifactName+"@-"); !cmp.Equal(want, got) {
+		t.Errorf("ListArtifactRevisions() after rollback returned unexpected revisions (-want +got):\n%s", cmp.Diff(want, got))
+	}
+
+	// Deleting the artifact deletes its revisions.
+	if _, err := server.DeleteArtifact(ctx, &rpc.DeleteArtifactRequest{Name: testArtifactName}); err != nil {
+		t.Fatalf("DeleteArtifact() returned error: %s", err)
+	}
+	if _, err := server.GetArtifact(ctx, &rpc.GetArtifactRequest{Name: first}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetArtifact(%q) after delete returned status code %q, want %q", first, status.Code(err), codes.NotFound)
+	}
+}
+
+func TestArtifactRevisionsNotKept(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	revisions := seedArtifactRevisions(ctx, t, server, "one", "two")
+
+	want := []string{revisions[1].GetRevisionId()}
+	if got := listArtifactRevisionIDs(ctx, t, server, testArtifactName+"@-"); !cmp.Equal(want, got) {
+		t.Errorf("ListArtifactRevisions() returned unexpected revisions (-want +got):\n%s", cmp.Diff(want, got))
+	}
+
+	// Tagged revisions are kept.
+	current := fmt.Sprintf("%s@%s", testArtifactName, revisions[1].GetRevisionId())
+	if _, err := server.TagArtifactRevision(ctx, &rpc.TagArtifactRevisionRequest{Name: current, Tag: "stable"}); err != nil {
+		t.Fatalf("TagArtifactRevision(%q) returned error: %s", current, err)
+	}
+	third, err := server.ReplaceArtifact(ctx, &rpc.ReplaceArtifactRequest{
+		Artifact: &rpc.Artifact{Name: testArtifactName, MimeType: "text/plain", Contents: []byte("three")},
+	})
+	if err != nil {
+		t.Fatalf("ReplaceArtifact() returned error: %s", err)
+	}
+	want = []string{third.GetRevisionId(), revisions[1].GetRevisionId()}
+	if got := listArtifactRevisionIDs(ctx, t, server, testArtifactName+"@-"); !cmp.Equal(want, got) {
+		t.Errorf("ListArtifactRevisions() returned unexpected revisions (-want +got):\n%s", cmp.Diff(want, got))
+	}
+	if contents := getArtifactRevisionContents(ctx, t, server, testArtifactName+"@stable"); contents != "two" {
+		t.Errorf("GetArtifactContents(%q) returned %q, want %q", testArtifactName+"@stable", contents, "two")
+	}
+}
+
+func TestArtifactRevisionErrors(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	seedArtifactRevisions(ctx, t, server, "one")
+
+	tests := []struct {
+		desc string
+		call func() error
+		want codes.Code
+	}{
+		{
+			desc: "get missing revision",
+			call: func() error {
+				_, err := server.GetArtifact(ctx, &rpc.GetArtifactRequest{Name: testArtifactName + "@missing"})
+				return err
+			},
+			want: codes.NotFound,
+		},
+		{
+			desc: "list revisions of missing artifact",
+			call: func() error {
+				_, err := server.ListArtifactRevisions(ctx, &rpc.ListArtifactRevisionsRequest{Name: "projects/my-project/locations/global/artifacts/missing@-"})
+				return err
+			},
+			want: codes.NotFound,
+		},
+		{
+			desc: "tag without tag",
+			call: func() error {
+				_, err := server.TagArtifactRevision(ctx, &rpc.TagArtifactRevisionRequest{Name: testArtifactName})
+				return err
+			},
+			want: codes.InvalidArgument,
+		},
+		{
+			desc: "tag with invalid tag",
+			call: func() error {
+				_, err := server.TagArtifactRevision(ctx, &rpc.TagArtifactRevisionRequest{Name: testArtifactName, Tag: "Invalid"})
+				return err
+			},
+			want: codes.InvalidArgument,
+		},
+		{
+			desc: "rollback without revision",
+			call: func() error {
+				_, err := server.RollbackArtifact(ctx, &rpc.RollbackArtifactRequest{Name: testArtifactName})
+				return err
+			},
+			want: codes.InvalidArgument,
+		},
+		{
+			desc: "rollback to missing revision",
+			call: func() error {
+				_, err := server.RollbackArtifact(ctx, &rpc.RollbackArtifactRequest{Name: testArtifactName, RevisionId: "missing"})
+				return err
+			},
+			want: codes.NotFound,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			if err := test.call(); status.Code(err) != test.want {
+				t.Errorf("returned status code %q, want %q: %v", status.Code(err), test.want, err)
+			}
+		})
+	}
+}
@@ -66,28 +66,29 @@ func (s *RegistryServer) CreateArtifact(ctx context.Context, req *rpc.CreateArti
 	}
 	var response *rpc.Artifact
 	if err := s.runInTransaction(ctx, func(ctx context.Context, db *storage.Client) error {
+		db.LockArtifact(ctx, parent.Artifact(req.GetArtifactId()))
 		// Creation should only succeed when the parent exists.
 		var err error
 		switch typedParent := parent.(type) {
 		case names.Project:
-			_, err = db.LockProjects(ctx).GetProject(ctx, typedParent)
+			_, err = db.GetProject(ctx, typedParent)
 		case names.Api:
-			_, err = db.LockApis(ctx).GetApi(ctx, typedParent)
+			_, err = db.GetApi(ctx, typedParent)
 		case names.Version:
-			_, err = db.LockVersions(ctx).GetVersion(ctx, typedParent)
+			_, err = db.GetVersion(ctx, typedParent)
 		case names.Spec:
 			// assign to latest revision
 			var spec *models.Spec
-			spec, err = db.LockSpecs(ctx).GetSpec(ctx, typedParent)
+			spec, err = db.GetSpec(ctx, typedParent)
 			if err == nil {
 				parent = parent.(names.Spec).Revision(spec.RevisionID)
 			}
 		case names.SpecRevision:
-			_, err = db.LockSpecs(ctx).GetSpecRevision(ctx, typedParent)
+			_, err = db.GetSpecRevision(ctx, typedParent)
 		case names.Deployment:
 			// assign to latest revision
 			var deployment *models.Deployment
-			deployment, err = db.LockDeployments(ctx).GetDeployment(ctx, typedParent)
+			deployment, err = db.GetDeployment(ctx, typedParent)
 			if err == nil {
 				parent = parent.(names.Deployment).Revision(deployment.RevisionID)
 			}
@@ -101,6 +102,9 @@ func (s *RegistryServer) CreateArtifact(ctx context.Context, req *rpc.CreateArti
 			return status.Error(codes.InvalidArgument, err.Error())
 		}
 
+		if err := validateArtifactContents(ctx, db, name, req.GetArtifact()); err != nil {
+			return err
+		}
 		artifact, err := models.NewArtifact(name, req.GetArtifact())
 		if err != nil {
 			return err
@@ -112,12 +116,16 @@ func (s *RegistryServer) CreateArtifact(ctx context.Context, req *rpc.CreateArti
 			return err
 		}
 		response, err = artifact.Message()
-		return err
+		if err != nil {
+			return err
+		}
+		s.notify(ctx, rpc.Notification_CREATED, response.GetName())
+		s.audit(ctx, "CreateArtifact", response.GetName(), nil, response)
+		return nil
 	}); err != nil {
 		return nil, err
 	}
 
-	s.notify(ctx, rpc.Notification_CREATED, response.GetName())
 	return response, nil
 }
 
@@ -129,14 +137,61 @@ func (s *RegistryServer) DeleteArtifact(ctx context.Context, req *rpc.DeleteArti
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 	if err := s.runInTransaction(ctx, func(ctx context.Context, db *storage.Client) error {
-		return db.DeleteArtifact(ctx, name)
+		db.LockArtifact(ctx, name)
+		if req.GetEtag() != "" {
+			artifact, err := db.GetArtifact(ctx, name, true)
+			if err != nil {
+				return err
+			}
+			if err := checkEtag(req.GetName(), req.GetEtag(), artifact.Etag()); err != nil {
+				return err
+			}
+		}
+		if err := s.checkReferrers(ctx, db, name.String()); err != nil {
+			return err
+		}
+		if err := db.DeleteArtifact(ctx, name); err != nil {
+			return err
+		}
+		if err := s.purgeDeleted(ctx, db); err != nil {
+			return err
+		}
+		s.notify(ctx, rpc.Notification_DELETED, req.GetName())
+		s.audit(ctx, "DeleteArtifact", req.GetName(), nil, nil)
+		return nil
 	}); err != nil {
 		return nil, err
 	}
-	s.notify(ctx, rpc.Notification_DELETED, req.GetName())
 	return &emptypb.Empty{}, nil
 }
 
+// UndeleteArtifact handles the corresponding API request.
+func (s *RegistryServer) UndeleteArtifact(ctx context.Context, req *rpc.UndeleteArtifactRequest) (*rpc.Artifact, error) {
+	// Artifact name must be valid.
+	name, err := names.ParseArtifact(req.GetName())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var response *rpc.Artifact
+	if err := s.runInTransaction(ctx, func(ctx context.Context, db *storage.Client) error {
+		artifact, err := db.LockArtifact(ctx, name).UndeleteArtifact(ctx, name)
+		if err != nil {
+			return err
+		}
+		response, err = artifact.Message()
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		s.notify(ctx, rpc.Notification_CREATED, response.GetName())
+		s.audit(ctx, "UndeleteArtifact", response.GetName(), nil, response)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
 // GetArtifact handles the corresponding API request.
 func (s *RegistryServer) GetArtifact(ctx context.Context, req *rpc.GetArtifactRequest) (*rpc.Artifact, error) {
 	db, err := s.getStorageClient(ctx)
@@ -144,12 +199,13 @@ func (s *RegistryServer) GetArtifact(ctx context.Context, req *rpc.GetArtifactRe
 		return nil, status.Error(codes.Unavailable, err.Error())
 	}
 
-	name, err := names.ParseArtifact(req.GetName())
+	// Artifacts can be requested by revision.
+	name, err := names.ParseArtifactRevision(req.GetName())
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	artifact, err := db.GetArtifact(ctx, name, false)
+	artifact, err := db.GetArtifactRevision(ctx, name)
 	if err != nil {
 		return nil, err
 	}
@@ -158,6 +214,9 @@ func (s *RegistryServer) GetArtifact(ctx context.Context, req *rpc.GetArtifactRe
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
+	if name.RevisionID != "" {
+		message.Name = artifact.RevisionName()
+	}
 
 	return message, nil
 }
@@ -169,17 +228,17 @@ func (s *RegistryServer) GetArtifactContents(ctx context.Context, req *rpc.GetAr
 		return nil, status.Error(codes.Unavailable, err.Error())
 	}
 
-	name, err := names.ParseArtifact(req.GetName())
+	name, err := names.ParseArtifactRevision(req.GetName())
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	artifact, err := db.GetArtifact(ctx, name, false)
+	artifact, err := db.GetArtifactRevision(ctx, name)
 	if err != nil {
 		return nil, err
 	}
 
-	blob, err := db.GetArtifactContents(ctx, name)
+	blob, err := db.GetArtifactRevisionContents(ctx, name)
 	if err != nil {
 		return nil, err
 	}
@@ -222,52 +281,59 @@ func (s *RegistryServer) ListArtifacts(ctx context.Context, req *rpc.ListArtifac
 	switch parent := parent.(type) {
 	case names.Project:
 		listing, err = db.ListProjectArtifacts(ctx, parent, storage.PageOptions{
-			Size:   req.GetPageSize(),
-			Filter: req.GetFilter(),
-			Order:  req.GetOrderBy(),
-			Token:  req.GetPageToken(),
+			Size:        req.GetPageSize(),
+			Filter:      req.GetFilter(),
+			Order:       req.GetOrderBy(),
+			Token:       req.GetPageToken(),
+			ShowDeleted: req.GetShowDeleted(),
 		})
 	case names.Api:
 		listing, err = db.ListApiArtifacts(ctx, parent, storage.PageOptions{
-			Size:   req.GetPageSize(),
-			Filter: req.GetFilter(),
-			Order:  req.GetOrderBy(),
-			Token:  req.GetPageToken(),
+			Size:        req.GetPageSize(),
+			Filter:      req.GetFilter(),
+			Order:       req.GetOrderBy(),
+			Token:       req.GetPageToken(),
+			ShowDeleted: req.GetShowDeleted(),
 		})
 	case names.Version:
 		listing, err = db.ListVersionArtifacts(ctx, parent, storage.PageOptions{
-			Size:   req.GetPageSize(),
-			Filter: req.GetFilter(),
-			Order:  req.GetOrderBy(),
-			Token:  req.GetPageToken(),
+			Size:        req.GetPageSize(),
+			Filter:      req.GetFilter(),
+			Order:       req.GetOrderBy(),
+			Token:       req.GetPageToken(),
+			ShowDeleted: req.GetShowDeleted(),
 		})
 	case names.Spec:
 		listing, err = db.ListSpecArtifacts(ctx, parent, storage.PageOptions{
-			Size:   req.GetPageSize(),
-			Filter: req.GetFilter(),
-			Order:  req.GetOrderBy(),
-			Token:  req.GetPageToken(),
+			Size:        req.GetPageSize(),
+			Filter:      req.GetFilter(),
+			Order:       req.GetOrderBy(),
+			Token:       req.GetPageToken(),
+			ShowDeleted: req.GetShowDeleted(),
 		})
 	case names.SpecRevision:
 		listing, err = db.ListSpecRevisionArtifacts(ctx, parent, storage.PageOptions{
-			Size:   req.GetPageSize(),
-			Filter: req.GetFilter(),
-			Order:  req.GetOrderBy(),
-			Token:  req.GetPageToken(),
+			Size:        req.GetPageSize(),
+			Filter:      req.GetFilter(),
+			Order:       req.GetOrderBy(),
+			Token:       req.GetPageToken(),
+			ShowDeleted: req.GetShowDeleted(),
 		})
 	case names.Deployment:
 		listing, err = db.ListDeploymentArtifacts(ctx, parent, storage.PageOptions{
-			Size:   req.GetPageSize(),
-			Filter: req.GetFilter(),
-			Order:  req.GetOrderBy(),
-			Token:  req.GetPageToken(),
+			Size:        req.GetPageSize(),
+			Filter:      req.GetFilter(),
+			Order:       req.GetOrderBy(),
+			Token:       req.GetPageToken(),
+			ShowDeleted: req.GetShowDeleted(),
 		})
 	case names.DeploymentRevision:
 		listing, err = db.ListDeploymentRevisionArtifacts(ctx, parent, storage.PageOptions{
-			Size:   req.GetPageSize(),
-			Filter: req.GetFilter(),
-			Order:  req.GetOrderBy(),
-			Token:  req.GetPageToken(),
+			Size:        req.GetPageSize(),
+			Filter:      req.GetFilter(),
+			Order:       req.GetOrderBy(),
+			Token:       req.GetPageToken(),
+			ShowDeleted: req.GetShowDeleted(),
 		})
 	}
 	if err != nil {
@@ -291,38 +357,63 @@ func (s *RegistryServer) ListArtifacts(ctx context.Context, req *rpc.ListArtifac
 
 // ReplaceArtifact handles the corresponding API request.
 func (s *RegistryServer) ReplaceArtifact(ctx context.Context, req *rpc.ReplaceArtifactRequest) (*rpc.Artifact, error) {
-	db, err := s.getStorageClient(ctx)
-	if err != nil {
-		return nil, status.Error(codes.Unavailable, err.Error())
-	}
-
 	name, err := names.ParseArtifact(req.Artifact.GetName())
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
 	var artifact *models.Artifact
-	err = db.Transaction(ctx, func(ctx context.Context, db *storage.Client) error {
+	err = s.runInTransaction(ctx, func(ctx context.Context, db *storage.Client) error {
 		// Replacement should only succeed on artifacts that currently exist.
-		art, err := db.GetArtifact(ctx, name, true)
+		art, err := db.LockArtifact(ctx, name).GetArtifact(ctx, name, true)
 		if err != nil {
 			return err
 		}
+		if err := checkEtag(name.String(), req.Artifact.GetEtag(), art.Etag()); err != nil {
+			return err
+		}
+		if err := validateArtifactContents(ctx, db, name, req.GetArtifact()); err != nil {
+			return err
+		}
 		artifact, err = models.NewArtifact(name, req.GetArtifact())
 		if err != nil {
 			return err
 		}
-		artifact.CreateTime = art.CreateTime // preserve creation time
-		artifact.RevisionID = art.RevisionID // revision is optional in request
+		artifact.CreateTime = art.CreateTime                 // preserve creation time
+		artifact.RevisionID = art.RevisionID                 // revision is optional in request
+		artifact.ArtifactRevisionID = art.ArtifactRevisionID // compared with the current revision
+		before, err := art.Message()
+		if err != nil {
+			return err
+		}
+		after, err := artifact.Message()
+		if err != nil {
+			return err
+		}
+		// Replacements that don't change the artifact return the current artifact.
+		if unchanged(before, after) {
+			artifact = art
+			return nil
+		}
+		// Other replacements create a new revision of the artifact.
+		artifact = artifact.NewRevision()
+		after.RevisionId = artifact.ArtifactRevisionID
 		if err := db.SaveArtifact(ctx, artifact); err != nil {
 			return err
 		}
-		return db.SaveArtifactContents(ctx, artifact, req.Artifact.GetContents())
+		if err := db.SaveArtifactContents(ctx, artifact, req.Artifact.GetContents()); err != nil {
+			return err
+		}
+		if err := s.pruneArtifactRevisions(ctx, db, artifact); err != nil {
+			return err
+		}
+		s.notify(ctx, rpc.Notification_UPDATED, name.String())
+		s.audit(ctx, "ReplaceArtifact", name.String(), before, after)
+		return nil
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	s.notify(ctx, rpc.Notification_UPDATED, name.String())
 	return artifact.Message()
 }
@@ -153,7 +153,7 @@ func TestCreateArtifact(t *testing.T) {
 
 			opts := cmp.Options{
 				protocmp.Transform(),
-				protocmp.IgnoreFields(new(rpc.Artifact), "create_time", "update_time"),
+				protocmp.IgnoreFields(new(rpc.Artifact), "revision_id", "create_time", "update_time", "etag"),
 			}
 
 			if !cmp.Equal(test.want, created, opts) {
@@ -422,7 +422,7 @@ func TestGetArtifact(t *testing.T) {
 
 			opts := cmp.Options{
 				protocmp.Transform(),
-				protocmp.IgnoreFields(new(rpc.Artifact), "create_time", "update_time"),
+				protocmp.IgnoreFields(new(rpc.Artifact), "revision_id", "create_time", "update_time", "etag"),
 			}
 
 			if !cmp.Equal(test.want, got, opts) {
@@ -923,7 +923,7 @@ func TestListArtifacts(t *testing.T) {
 			opts := cmp.Options{
 				protocmp.Transform(),
 				protocmp.IgnoreFields(new(rpc.ListArtifactsResponse), "next_page_token"),
-				protocmp.IgnoreFields(new(rpc.Artifact), "create_time", "update_time"),
+				protocmp.IgnoreFields(new(rpc.Artifact), "revision_id", "create_time", "update_time", "etag"),
 				test.extraOpts,
 			}
 
@@ -1174,7 +1174,7 @@ func TestListArtifactsSequence(t *testing.T) {
 
 	opts := cmp.Options{
 		protocmp.Transform(),
-		protocmp.IgnoreFields(new(rpc.Artifact), "create_time", "update_time"),
+		protocmp.IgnoreFields(new(rpc.Artifact), "revision_id", "create_time", "update_time", "etag"),
 		cmpopts.SortSlices(func(a, b *rpc.Artifact) bool {
 			return a.GetName() < b.GetName()
 		}),
@@ -1266,7 +1266,7 @@ func TestReplaceArtifact(t *testing.T) {
 
 			opts := cmp.Options{
 				protocmp.Transform(),
-				protocmp.IgnoreFields(new(rpc.Artifact), "create_time", "update_time"),
+				protocmp.IgnoreFields(new(rpc.Artifact), "revision_id", "create_time", "update_time", "etag"),
 			}
 
 			if !cmp.Equal(test.want, updated, opts) {
@@ -1340,6 +1340,32 @@ func TestReplaceArtifactResponseCodes(t *testing.T) {
 	}
 }
 
+func TestReplaceArtifactUnchanged(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	seed := &rpc.Artifact{
+		Name:     "projects/my-project/locations/global/artifacts/a",
+		MimeType: "text/plain",
+		Contents: []byte("contents"),
+	}
+	if err := seeder.SeedArtifacts(ctx, server, seed); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+	before, err := server.GetArtifact(ctx, &rpc.GetArtifactRequest{Name: seed.Name})
+	if err != nil {
+		t.Fatalf("Setup/Seeding: Failed to get seeded artifact: %s", err)
+	}
+
+	req := &rpc.ReplaceArtifactRequest{Artifact: seed}
+	got, err := server.ReplaceArtifact(ctx, req)
+	if err != nil {
+		t.Fatalf("ReplaceArtifact(%+v) returned error: %s", req, err)
+	}
+	if !cmp.Equal(before, got, protocmp.Transform()) {
+		t.Errorf("ReplaceArtifact(%+v) changed the artifact, expected no change (-want +got):\n%s", req, cmp.Diff(before, got, protocmp.Transform()))
+	}
+}
+
 func TestReplaceArtifactSequence(t *testing.T) {
 	tests := []struct {
 		desc string
@@ -1350,7 +1376,8 @@ func TestReplaceArtifactSequence(t *testing.T) {
 			desc: "first replacement",
 			req: &rpc.ReplaceArtifactRequest{
 				Artifact: &rpc.Artifact{
-					Name: "projects/my-project/locations/global/artifacts/a",
+					Name:     "projects/my-project/locations/global/artifacts/a",
+					Contents: []byte("first"),
 				},
 			},
 			want: codes.OK,
@@ -1359,7 +1386,8 @@ func TestReplaceArtifactSequence(t *testing.T) {
 			desc: "second replacement",
 			req: &rpc.ReplaceArtifactRequest{
 				Artifact: &rpc.Artifact{
-					Name: "projects/my-project/locations/global/artifacts/a",
+					Name:     "projects/my-project/locations/global/artifacts/a",
+					Contents: []byte("second"),
 				},
 			},
 			want: codes.OK,
@@ -1471,6 +1499,162 @@ func TestDeleteArtifactResponseCodes(t *testing.T) {
 	}
 }
 
+func TestUndeleteArtifact(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		server   = serverWithPurgeWindow(t)
+		artifact = &rpc.Artifact{
+			Name:     "projects/my-project/locations/global/apis/my-api/artifacts/my-artifact",
+			MimeType: "text/plain",
+			Contents: []byte("contents"),
+		}
+	)
+	if err := seeder.SeedArtifacts(ctx, server, artifact); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+	if _, err := server.DeleteArtifact(ctx, &rpc.DeleteArtifactRequest{Name: artifact.GetName()}); err != nil {
+		t.Fatalf("DeleteArtifact(%q) returned error: %s", artifact.GetName(), err)
+	}
+
+	t.Run("ListArtifacts", func(t *testing.T) {
+		req := &rpc.ListArtifactsRequest{Parent: "projects/my-project/locations/global/apis/my-api"}
+		got, err := server.ListArtifacts(ctx, req)
+		if err != nil {
+			t.Fatalf("ListArtifacts(%+v) returned error: %s", req, err)
+		}
+		if len(got.GetArtifacts()) != 0 {
+			t.Errorf("ListArtifacts(%+v) returned deleted artifacts: %v", req, got.GetArtifacts())
+		}
+
+		req.ShowDeleted = true
+		got, err = server.ListArtifacts(ctx, req)
+		if err != nil {
+			t.Fatalf("ListArtifacts(%+v) returned error: %s", req, err)
+		}
+		if len(got.GetArtifacts()) != 1 || got.GetArtifacts()[0].GetDeleteTime() == nil {
+			t.Errorf("ListArtifacts(%+v) returned %v, want %q with a delete_time", req, got.GetArtifacts(), artifact.GetName())
+		}
+	})
+
+	got, err := server.UndeleteArtifact(ctx, &rpc.UndeleteArtifactRequest{Name: artifact.GetName()})
+	if err != nil {
+		t.Fatalf("UndeleteArtifact(%q) returned error: %s", artifact.GetName(), err)
+	}
+	if got.GetName() != artifact.GetName() || got.GetDeleteTime() != nil {
+		t.Errorf("UndeleteArtifact(%q) returned %v, want an undeleted artifact", artifact.GetName(), got)
+	}
+	contents, err := server.GetArtifactContents(ctx, &rpc.GetArtifactContentsRequest{Name: artifact.GetName()})
+	if err != nil {
+		t.Fatalf("GetArtifactContents(%q) returned error: %s", artifact.GetName(), err)
+	}
+	if string(contents.GetData()) != "contents" {
+		t.Errorf("GetArtifactContents(%q) returned %q, want %q", artifact.GetName(), contents.GetData(), "contents")
+	}
+}
+
+func TestUndeleteArtifactResponseCodes(t *testing.T) {
+	tests := []struct {
+		desc         string
+		delete       bool
+		deleteParent bool
+		req          *rpc.UndeleteArtifactRequest
+		want         codes.Code
+	}{
+		{
+			desc: "resource not found",
+			req: &rpc.UndeleteArtifactRequest{
+				Name: "projects/my-project/locations/global/apis/my-api/artifacts/doesnt-exist",
+			},
+			want: codes.NotFound,
+		},
+		{
+			desc: "resource not deleted",
+			req: &rpc.UndeleteArtifactRequest{
+				Name: "projects/my-project/locations/global/apis/my-api/artifacts/my-artifact",
+			},
+			want: codes.AlreadyExists,
+		},
+		{
+			desc:         "parent deleted",
+			delete:       true,
+			deleteParent: true,
+			req: &rpc.UndeleteArtifactRequest{
+				Name: "projects/my-project/locations/global/apis/my-api/artifacts/my-artifact",
+			},
+			want: codes.FailedPrecondition,
+		},
+		{
+			desc: "invalid name",
+			req: &rpc.UndeleteArtifactRequest{
+				Name: "projects/my-project/locations/global/apis/my-api",
+			},
+			want: codes.InvalidArgument,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			ctx := context.Background()
+			server := serverWithPurgeWindow(t)
+			artifact := &rpc.Artifact{Name: "projects/my-project/locations/global/apis/my-api/artifacts/my-artifact"}
+			if err := seeder.SeedArtifacts(ctx, server, artifact); err != nil {
+				t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+			}
+			if test.delete {
+				if _, err := server.DeleteArtifact(ctx, &rpc.DeleteArtifactRequest{Name: artifact.GetName()}); err != nil {
+					t.Fatalf("Setup: DeleteArtifact(%q) returned error: %s", artifact.GetName(), err)
+				}
+			}
+			if test.deleteParent {
+				if _, err := server.DeleteApi(ctx, &rpc.DeleteApiRequest{Name: "projects/my-project/locations/global/apis/my-api"}); err != nil {
+					t.Fatalf("Setup: DeleteApi() returned error: %s", err)
+				}
+			}
+
+			if _, err := server.UndeleteArtifact(ctx, test.req); status.Code(err) != test.want {
+				t.Errorf("UndeleteArtifact(%+v) returned status code %q, want %q: %v", test.req, status.Code(err), test.want, err)
+			}
+		})
+	}
+}
+
+func TestCreateArtifactAfterDelete(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		server   = serverWithPurgeWindow(t)
+		artifact = &rpc.Artifact{
+			Name:     "projects/my-project/locations/global/apis/my-api/versions/v1/specs/my-spec/artifacts/my-artifact",
+			MimeType: "text/plain",
+			Contents: []byte("old contents"),
+		}
+	)
+	if err := seeder.SeedArtifacts(ctx, server, artifact); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+	if _, err := server.DeleteArtifact(ctx, &rpc.DeleteArtifactRequest{Name: artifact.GetName()}); err != nil {
+		t.Fatalf("DeleteArtifact(%q) returned error: %s", artifact.GetName(), err)
+	}
+
+	req := &rpc.CreateArtifactRequest{
+		Parent:     "projects/my-project/locations/global/apis/my-api/versions/v1/specs/my-spec",
+		ArtifactId: "my-artifact",
+		Artifact:   &rpc.Artifact{MimeType: "text/plain", Contents: []byte("new contents")},
+	}
+	if _, err := server.CreateArtifact(ctx, req); err != nil {
+		t.Fatalf("CreateArtifact(%+v) returned error: %s", req, err)
+	}
+	contents, err := server.GetArtifactContents(ctx, &rpc.GetArtifactContentsRequest{Name: artifact.GetName()})
+	if err != nil {
+		t.Fatalf("GetArtifactContents(%q) returned error: %s", artifact.GetName(), err)
+	}
+	if string(contents.GetData()) != "new contents" {
+		t.Errorf("GetArtifactContents(%q) returned %q, want %q", artifact.GetName(), contents.GetData(), "new contents")
+	}
+	if _, err := server.UndeleteArtifact(ctx, &rpc.UndeleteArtifactRequest{Name: artifact.GetName()}); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("UndeleteArtifact(%q) returned status code %q, want %q: %v", artifact.GetName(), status.Code(err), codes.AlreadyExists, err)
+	}
+}
+
 func TestSpecRevisionArtifacts(t *testing.T) {
 	ctx := context.Background()
 	server := defaultTestServer(t)
@@ -1558,7 +1742,7 @@ func TestSpecRevisionArtifacts(t *testing.T) {
 
 		opts := cmp.Options{
 			protocmp.Transform(),
-			protocmp.IgnoreFields(new(rpc.Artifact), "create_time", "update_time"),
+			protocmp.IgnoreFields(new(rpc.Artifact), "revision_id", "create_time", "update_time", "etag"),
 		}
 
 		if !cmp.Equal(want, artifact, opts) {
@@ -1591,7 +1775,7 @@ func TestSpecRevisionArtifacts(t *testing.T) {
 
 		opts := cmp.Options{
 			protocmp.Transform(),
-			protocmp.IgnoreFields(new(rpc.Artifact), "create_time", "update_time"),
+			protocmp.IgnoreFields(new(rpc.Artifact), "revision_id", "create_time", "update_time", "etag"),
 		}
 
 		if !cmp.Equal(want, artifact, opts) {
@@ -1634,7 +1818,7 @@ func TestSpecRevisionArtifacts(t *testing.T) {
 
 				opts := cmp.Options{
 					protocmp.Transform(),
-					protocmp.IgnoreFields(new(rpc.Artifact), "create_time", "update_time", "hash", "mime_type", "size_bytes"),
+					protocmp.IgnoreFields(new(rpc.Artifact), "revision_id", "create_time", "update_time", "hash", "mime_type", "size_bytes", "etag"),
 				}
 
 				if !cmp.Equal(test.want, got, opts) {
@@ -1771,7 +1955,7 @@ func TestSpecRevisionArtifacts(t *testing.T) {
 				opts := cmp.Options{
 					protocmp.Transform(),
 					protocmp.IgnoreFields(new(rpc.ListArtifactsResponse), "next_page_token"),
-					protocmp.IgnoreFields(new(rpc.Artifact), "create_time", "update_time", "hash", "mime_type", "size_bytes"),
+					protocmp.IgnoreFields(new(rpc.Artifact), "revision_id", "create_time", "update_time", "hash", "mime_type", "size_bytes", "etag"),
 				}
 
 				if !cmp.Equal(test.want, got, opts) {
@@ -1905,7 +2089,7 @@ func TestDeploymentRevisionArtifacts(t *testing.T) {
 
 		opts := cmp.Options{
 			protocmp.Transform(),
-			protocmp.IgnoreFields(new(rpc.Artifact), "create_time", "update_time"),
+			protocmp.IgnoreFields(new(rpc.Artifact), "revision_id", "create_time", "update_time", "etag"),
 		}
 
 		if !cmp.Equal(want, artifact, opts) {
@@ -1938,7 +2122,7 @@ func TestDeploymentRevisionArtifacts(t *testing.T) {
 
 		opts := cmp.Options{
 			protocmp.Transform(),
-			protocmp.IgnoreFields(new(rpc.Artifact), "create_time", "update_time"),
+			protocmp.IgnoreFields(new(rpc.Artifact), "revision_id", "create_time", "update_time", "etag"),
 		}
 
 		if !cmp.Equal(want, artifact, opts) {
@@ -1981,7 +2165,7 @@ func TestDeploymentRevisionArtifacts(t *testing.T) {
 
 				opts := cmp.Options{
 					protocmp.Transform(),
-					protocmp.IgnoreFields(new(rpc.Artifact), "create_time", "update_time", "hash", "mime_type", "size_bytes"),
+					protocmp.IgnoreFields(new(rpc.Artifact), "revision_id", "create_time", "update_time", "hash", "mime_type", "size_bytes", "etag"),
 				}
 
 				if !cmp.Equal(test.want, got, opts) {
@@ -2104,7 +2288,7 @@ func TestDeploymentRevisionArtifacts(t *testing.T) {
 				opts := cmp.Options{
 					protocmp.Transform(),
 					protocmp.IgnoreFields(new(rpc.ListArtifactsResponse), "next_page_token"),
-					protocmp.IgnoreFields(new(rpc.Artifact), "create_time", "update_time", "hash", "mime_type", "size_bytes"),
+					protocmp.IgnoreFields(new(rpc.Artifact), "revision_id", "create_time", "update_time", "hash", "mime_type", "size_bytes", "etag"),
 				}
 
 				if !cmp.Equal(test.want, got, opts) {
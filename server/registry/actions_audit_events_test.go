@@ -0,0 +1,270 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/auth"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// serverWithTrustedProxy returns a server that identifies callers by proxy metadata.
+func serverWithTrustedProxy(t *testing.T) *RegistryServer {
+	t.Helper()
+	server, err := New(Config{
+		Database:     "sqlite3",
+		DBConfig:     fmt.Sprintf("%s/registry.db", t.TempDir()),
+		TrustedProxy: true,
+	})
+	if err != nil {
+		t.Fatalf("Setup: failed to create server: %s", err)
+	}
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestAuditEvents(t *testing.T) {
+	server := serverWithTrustedProxy(t)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-forwarded-email", "user@example.com"))
+
+	if _, err := server.CreateProject(ctx, &rpc.CreateProjectRequest{
+		ProjectId: "my-project",
+		Project:   &rpc.Project{DisplayName: "My Project"},
+	}); err != nil {
+		t.Fatalf("Setup: CreateProject() returned error: %s", err)
+	}
+	if _, err := server.UpdateProject(ctx, &rpc.UpdateProjectRequest{
+		Project: &rpc.Project{Name: "projects/my-project", DisplayName: "My Project", Description: "Updated"},
+	}); err != nil {
+		t.Fatalf("Setup: UpdateProject() returned error: %s", err)
+	}
+	if _, err := server.CreateProject(ctx, &rpc.CreateProjectRequest{ProjectId: "my-project", Project: &rpc.Project{}}); status.Code(err) != codes.AlreadyExists {
+		t.Fatalf("Setup: CreateProject() returned status %s, want %s: %v", status.Code(err), codes.AlreadyExists, err)
+	}
+	if _, err := server.DeleteProject(ctx, &rpc.DeleteProjectRequest{Name: "projects/my-project"}); err != nil {
+		t.Fatalf("Setup: DeleteProject() returned error: %s", err)
+	}
+
+	resp, err := server.ListAuditEvents(ctx, &rpc.ListAuditEventsRequest{})
+	if err != nil {
+		t.Fatalf("ListAuditEvents() returned error: %s", err)
+	}
+	want := []*rpc.AuditEvent{
+		{
+			Resource:   "projects/my-project",
+			Method:     "CreateProject",
+			Caller:     "user@example.com",
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"name", "display_name"}},
+		},
+		{
+			Resource:   "projects/my-project",
+			Method:     "UpdateProject",
+			Caller:     "user@example.com",
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"description"}},
+		},
+		{
+			Resource:   "projects/my-project",
+			Method:     "DeleteProject",
+			Caller:     "user@example.com",
+			UpdateMask: &fieldmaskpb.FieldMask{},
+		},
+	}
+	opts := cmp.Options{
+		protocmp.Transform(),
+		protocmp.IgnoreFields(&rpc.AuditEvent{}, "id", "create_time"),
+	}
+	if diff := cmp.Diff(want, resp.GetAuditEvents(), opts); diff != "" {
+		t.Errorf("ListAuditEvents() returned unexpected diff (-want +got):\n%s", diff)
+	}
+	for _, event := range resp.GetAuditEvents() {
+		if event.GetCreateTime() == nil {
+			t.Errorf("ListAuditEvents() returned event %d without create_time", event.GetId())
+		}
+	}
+
+	t.Run("filter", func(t *testing.T) {
+		resp, err := server.ListAuditEvents(ctx, &rpc.ListAuditEventsRequest{Filter: `method == "DeleteProject"`})
+		if err != nil {
+			t.Fatalf("ListAuditEvents() returned error: %s", err)
+		}
+		if diff := cmp.Diff(want[2:], resp.GetAuditEvents(), opts); diff != "" {
+			t.Errorf("ListAuditEvents() returned unexpected diff (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		got := make([]*rpc.AuditEvent, 0)
+		req := &rpc.ListAuditEventsRequest{PageSize: 2}
+		for {
+			resp, err := server.ListAuditEvents(ctx, req)
+			if err != nil {
+				t.Fatalf("ListAuditEvents(%+v) returned error: %s", req, err)
+			}
+			got = append(got, resp.GetAuditEvents()...)
+			if resp.GetNextPageToken() == "" {
+				break
+			}
+			req.PageToken = resp.GetNextPageToken()
+		}
+		if diff := cmp.Diff(want, got, opts); diff != "" {
+			t.Errorf("ListAuditEvents() returned unexpected diff (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestAuditEventCaller(t *testing.T) {
+	server := serverWithTrustedProxy(t)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-forwarded-email", "proxy@example.com"))
+	ctx = auth.NewContext(ctx, "user@example.com")
+
+	if _, err := server.CreateProject(ctx, &rpc.CreateProjectRequest{ProjectId: "my-project", Project: &rpc.Project{}}); err != nil {
+		t.Fatalf("Setup: CreateProject() returned error: %s", err)
+	}
+	resp, err := server.ListAuditEvents(ctx, &rpc.ListAuditEventsRequest{})
+	if err != nil {
+		t.Fatalf("ListAuditEvents() returned error: %s", err)
+	}
+	if len(resp.GetAuditEvents()) != 1 {
+		t.Fatalf("ListAuditEvents() returned %d events, want 1", len(resp.GetAuditEvents()))
+	}
+	if got, want := resp.GetAuditEvents()[0].GetCaller(), "user@example.com"; got != want {
+		t.Errorf("ListAuditEvents() returned event with caller %q, want authenticated principal %q", got, want)
+	}
+}
+
+func TestAuditEventUntrustedCaller(t *testing.T) {
+	server, err := serverWithSQLite(t)
+	if err != nil {
+		t.Fatalf("Setup: failed to create server: %s", err)
+	}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-forwarded-email", "admin@example.com"))
+	ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(192, 0, 2, 1), Port: 1234}})
+
+	if _, err := server.CreateProject(ctx, &rpc.CreateProjectRequest{ProjectId: "my-project", Project: &rpc.Project{}}); err != nil {
+		t.Fatalf("Setup: CreateProject() returned error: %s", err)
+	}
+	resp, err := server.ListAuditEvents(ctx, &rpc.ListAuditEventsRequest{})
+	if err != nil {
+		t.Fatalf("ListAuditEvents() returned error: %s", err)
+	}
+	if len(resp.GetAuditEvents()) != 1 {
+		t.Fatalf("ListAuditEvents() returned %d events, want 1", len(resp.GetAuditEvents()))
+	}
+	if got, want := resp.GetAuditEvents()[0].GetCaller(), "unauthenticated:192.0.2.1:1234"; got != want {
+		t.Errorf("ListAuditEvents() returned event with caller %q, want unauthenticated address %q", got, want)
+	}
+}
+
+func TestAuditEventsErrors(t *testing.T) {
+	server, err := serverWithSQLite(t)
+	if err != nil {
+		t.Fatalf("Setup: failed to create server: %s", err)
+	}
+	ctx := context.Background()
+
+	tests := []struct {
+		desc string
+		req  *rpc.ListAuditEventsRequest
+		want codes.Code
+	}{
+		{
+			desc: "negative page size",
+			req:  &rpc.ListAuditEventsRequest{PageSize: -1},
+			want: codes.InvalidArgument,
+		},
+		{
+			desc: "invalid filter",
+			req:  &rpc.ListAuditEventsRequest{Filter: "this filter is not valid"},
+			want: codes.InvalidArgument,
+		},
+		{
+			desc: "unknown field in filter",
+			req:  &rpc.ListAuditEventsRequest{Filter: "sink == 'x'"},
+			want: codes.InvalidArgument,
+		},
+		{
+			desc: "invalid order",
+			req:  &rpc.ListAuditEventsRequest{OrderBy: "unknown_field"},
+			want: codes.InvalidArgument,
+		},
+		{
+			desc: "invalid page token",
+			req:  &rpc.ListAuditEventsRequest{PageToken: "invalid-token"},
+			want: codes.InvalidArgument,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			if _, err := server.ListAuditEvents(ctx, test.req); status.Code(err) != test.want {
+				t.Errorf("ListAuditEvents(%+v) returned status code %q, want %q: %v", test.req, status.Code(err), test.want, err)
+			}
+		})
+	}
+}
+
+func TestChangedFields(t *testing.T) {
+	tests := []struct {
+		desc   string
+		before proto.Message
+		after  proto.Message
+		want   []string
+	}{
+		{
+			desc:  "created",
+			after: &rpc.Api{Name: "projects/p/locations/global/apis/a", DisplayName: "A"},
+			want:  []string{"name", "display_name"},
+		},
+		{
+			desc:   "unchanged",
+			before: &rpc.Api{Name: "projects/p/locations/global/apis/a", DisplayName: "A"},
+			after:  &rpc.Api{Name: "projects/p/locations/global/apis/a", DisplayName: "A"},
+		},
+		{
+			desc:   "labels changed",
+			before: &rpc.Api{Name: "projects/p/locations/global/apis/a", Labels: map[string]string{"a": "1"}},
+			after:  &rpc.Api{Name: "projects/p/locations/global/apis/a", Labels: map[string]string{"a": "2"}},
+			want:   []string{"labels"},
+		},
+		{
+			desc:   "field cleared",
+			before: &rpc.Api{Name: "projects/p/locations/global/apis/a", Description: "d"},
+			after:  &rpc.Api{Name: "projects/p/locations/global/apis/a"},
+			want:   []string{"description"},
+		},
+		{
+			desc: "deleted",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			got := changedFields(test.before, test.after).GetPaths()
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("changedFields() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
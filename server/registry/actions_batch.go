@@ -0,0 +1,429 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"strings"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/storage"
+	"github.com/apigee/registry/server/registry/names"
+	statuspb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxBatchSize is the largest number of requests accepted in a batch.
+const maxBatchSize = 1000
+
+// runBatch applies n requests to resources in the project location named by
+// parent by calling fn with the index of each request. Unless partial success
+// is allowed, the requests are applied in a single transaction that fails
+// with the first failing request. Otherwise each request is applied on its
+// own and its result is returned in the corresponding status.
+func (s *RegistryServer) runBatch(ctx context.Context, parent string, n int, partial bool, fn func(ctx context.Context, i int) error) ([]*statuspb.Status, error) {
+	if _, err := names.ParseProjectWithLocation(parent); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if n == 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid requests: at least one request must be provided")
+	} else if n > maxBatchSize {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid requests: at most %d requests can be made in a batch", maxBatchSize)
+	}
+
+	if partial {
+		statuses := make([]*statuspb.Status, n)
+		for i := range statuses {
+			statuses[i] = status.Convert(fn(ctx, i)).Proto()
+		}
+		return statuses, nil
+	}
+
+	return nil, s.runInTransaction(ctx, func(ctx context.Context, _ *storage.Client) error {
+		for i := 0; i < n; i++ {
+			if err := fn(ctx, i); err != nil {
+				st := status.Convert(err)
+				return status.Errorf(st.Code(), "requests[%d]: %s", i, st.Message())
+			}
+		}
+		return nil
+	})
+}
+
+// checkBatchName returns an error if name is not in the project location of
+// a batch. Batches are authorized by their parent, so requests must not
+// refer to resources outside of it.
+func checkBatchName(parent, name string) error {
+	if name != parent && !strings.HasPrefix(name, parent+"/") {
+		return status.Errorf(codes.InvalidArgument, "invalid name %q: must be in %q", name, parent)
+	}
+	return nil
+}
+
+// BatchCreateApis handles the corresponding API request.
+func (s *RegistryServer) BatchCreateApis(ctx context.Context, req *rpc.BatchCreateApisRequest) (*rpc.BatchCreateApisResponse, error) {
+	response := &rpc.BatchCreateApisResponse{
+		Apis: make([]*rpc.Api, len(req.GetRequests())),
+	}
+	statuses, err := s.runBatch(ctx, req.GetParent(), len(req.GetRequests()), req.GetAllowPartialSuccess(), func(ctx context.Context, i int) error {
+		r := req.Requests[i]
+		if r.GetParent() == "" {
+			r.Parent = req.GetParent()
+		}
+		if err := checkBatchName(req.GetParent(), r.GetParent()); err != nil {
+			response.Apis[i] = &rpc.Api{}
+			return err
+		}
+		api, err := s.CreateApi(ctx, r)
+		if err != nil {
+			response.Apis[i] = &rpc.Api{}
+			return err
+		}
+		response.Apis[i] = api
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	response.Statuses = statuses
+	return response, nil
+}
+
+// BatchUpdateApis handles the corresponding API request.
+func (s *RegistryServer) BatchUpdateApis(ctx context.Context, req *rpc.BatchUpdateApisRequest) (*rpc.BatchUpdateApisResponse, error) {
+	response := &rpc.BatchUpdateApisResponse{
+		Apis: make([]*rpc.Api, len(req.GetRequests())),
+	}
+	statuses, err := s.runBatch(ctx, req.GetParent(), len(req.GetRequests()), req.GetAllowPartialSuccess(), func(ctx context.Context, i int) error {
+		r := req.Requests[i]
+		if err := checkBatchName(req.GetParent(), r.GetApi().GetName()); err != nil {
+			response.Apis[i] = &rpc.Api{}
+			return err
+		}
+		api, err := s.UpdateApi(ctx, r)
+		if err != nil {
+			response.Apis[i] = &rpc.Api{}
+			return err
+		}
+		response.Apis[i] = api
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	response.Statuses = statuses
+	return response, nil
+}
+
+// BatchDeleteApis handles the corresponding API request.
+func (s *RegistryServer) BatchDeleteApis(ctx context.Context, req *rpc.BatchDeleteApisRequest) (*rpc.BatchDeleteApisResponse, error) {
+	statuses, err := s.runBatch(ctx, req.GetParent(), len(req.GetRequests()), req.GetAllowPartialSuccess(), func(ctx context.Context, i int) error {
+		r := req.Requests[i]
+		if err := checkBatchName(req.GetParent(), r.GetName()); err != nil {
+			return err
+		}
+		_, err := s.DeleteApi(ctx, r)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &rpc.BatchDeleteApisResponse{Statuses: statuses}, nil
+}
+
+// BatchCreateApiVersions handles the corresponding API request.
+func (s *RegistryServer) BatchCreateApiVersions(ctx context.Context, req *rpc.BatchCreateApiVersionsRequest) (*rpc.BatchCreateApiVersionsResponse, error) {
+	response := &rpc.BatchCreateApiVersionsResponse{
+		ApiVersions: make([]*rpc.ApiVersion, len(req.GetRequests())),
+	}
+	statuses, err := s.runBatch(ctx, req.GetParent(), len(req.GetRequests()), req.GetAllowPartialSuccess(), func(ctx context.Context, i int) error {
+		r := req.Requests[i]
+		if r.GetParent() == "" {
+			r.Parent = req.GetParent()
+		}
+		if err := checkBatchName(req.GetParent(), r.GetParent()); err != nil {
+			response.ApiVersions[i] = &rpc.ApiVersion{}
+			return err
+		}
+		version, err := s.CreateApiVersion(ctx, r)
+		if err != nil {
+			response.ApiVersions[i] = &rpc.ApiVersion{}
+			return err
+		}
+		response.ApiVersions[i] = version
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	response.Statuses = statuses
+	return response, nil
+}
+
+// BatchUpdateApiVersions handles the corresponding API request.
+func (s *RegistryServer) BatchUpdateApiVersions(ctx context.Context, req *rpc.BatchUpdateApiVersionsRequest) (*rpc.BatchUpdateApiVersionsResponse, error) {
+	response := &rpc.BatchUpdateApiVersionsResponse{
+		ApiVersions: make([]*rpc.ApiVersion, len(req.GetRequests())),
+	}
+	statuses, err := s.runBatch(ctx, req.GetParent(), len(req.GetRequests()), req.GetAllowPartialSuccess(), func(ctx context.Context, i int) error {
+		r := req.Requests[i]
+		if err := checkBatchName(req.GetParent(), r.GetApiVersion().GetName()); err != nil {
+			response.ApiVersions[i] = &rpc.ApiVersion{}
+			return err
+		}
+		version, err := s.UpdateApiVersion(ctx, r)
+		if err != nil {
+			response.ApiVersions[i] = &rpc.ApiVersion{}
+			return err
+		}
+		response.ApiVersions[i] = version
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	response.Statuses = statuses
+	return response, nil
+}
+
+// BatchDeleteApiVersions handles the corresponding API request.
+func (s *RegistryServer) BatchDeleteApiVersions(ctx context.Context, req *rpc.BatchDeleteApiVersionsRequest) (*rpc.BatchDeleteApiVersionsResponse, error) {
+	statuses, err := s.runBatch(ctx, req.GetParent(), len(req.GetRequests()), req.GetAllowPartialSuccess(), func(ctx context.Context, i int) error {
+		r := req.Requests[i]
+		if err := checkBatchName(req.GetParent(), r.GetName()); err != nil {
+			return err
+		}
+		_, err := s.DeleteApiVersion(ctx, r)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &rpc.BatchDeleteApiVersionsResponse{Statuses: statuses}, nil
+}
+
+// BatchCreateApiSpecs handles the corresponding API request.
+func (s *RegistryServer) BatchCreateApiSpecs(ctx context.Context, req *rpc.BatchCreateApiSpecsRequest) (*rpc.BatchCreateApiSpecsResponse, error) {
+	response := &rpc.BatchCreateApiSpecsResponse{
+		ApiSpecs: make([]*rpc.ApiSpec, len(req.GetRequests())),
+	}
+	statuses, err := s.runBatch(ctx, req.GetParent(), len(req.GetRequests()), req.GetAllowPartialSuccess(), func(ctx context.Context, i int) error {
+		r := req.Requests[i]
+		if r.GetParent() == "" {
+			r.Parent = req.GetParent()
+		}
+		if err := checkBatchName(req.GetParent(), r.GetParent()); err != nil {
+			response.ApiSpecs[i] = &rpc.ApiSpec{}
+			return err
+		}
+		spec, err := s.CreateApiSpec(ctx, r)
+		if err != nil {
+			response.ApiSpecs[i] = &rpc.ApiSpec{}
+			return err
+		}
+		response.ApiSpecs[i] = spec
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	response.Statuses = statuses
+	return response, nil
+}
+
+// BatchUpdateApiSpecs handles the corresponding API request.
+func (s *RegistryServer) BatchUpdateApiSpecs(ctx context.Context, req *rpc.BatchUpdateApiSpecsRequest) (*rpc.BatchUpdateApiSpecsResponse, error) {
+	response := &rpc.BatchUpdateApiSpecsResponse{
+		ApiSpecs: make([]*rpc.ApiSpec, len(req.GetRequests())),
+	}
+	statuses, err := s.runBatch(ctx, req.GetParent(), len(req.GetRequests()), req.GetAllowPartialSuccess(), func(ctx context.Context, i int) error {
+		r := req.Requests[i]
+		if err := checkBatchName(req.GetParent(), r.GetApiSpec().GetName()); err != nil {
+			response.ApiSpecs[i] = &rpc.ApiSpec{}
+			return err
+		}
+		spec, err := s.UpdateApiSpec(ctx, r)
+		if err != nil {
+			response.ApiSpecs[i] = &rpc.ApiSpec{}
+			return err
+		}
+		response.ApiSpecs[i] = spec
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	response.Statuses = statuses
+	return response, nil
+}
+
+// BatchDeleteApiSpecs handles the corresponding API request.
+func (s *RegistryServer) BatchDeleteApiSpecs(ctx context.Context, req *rpc.BatchDeleteApiSpecsRequest) (*rpc.BatchDeleteApiSpecsResponse, error) {
+	statuses, err := s.runBatch(ctx, req.GetParent(), len(req.GetRequests()), req.GetAllowPartialSuccess(), func(ctx context.Context, i int) error {
+		r := req.Requests[i]
+		if err := checkBatchName(req.GetParent(), r.GetName()); err != nil {
+			return err
+		}
+		_, err := s.DeleteApiSpec(ctx, r)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &rpc.BatchDeleteApiSpecsResponse{Statuses: statuses}, nil
+}
+
+// BatchCreateApiDeployments handles the corresponding API request.
+func (s *RegistryServer) BatchCreateApiDeployments(ctx context.Context, req *rpc.BatchCreateApiDeploymentsRequest) (*rpc.BatchCreateApiDeploymentsResponse, error) {
+	response := &rpc.BatchCreateApiDeploymentsResponse{
+		ApiDeployments: make([]*rpc.ApiDeployment, len(req.GetRequests())),
+	}
+	statuses, err := s.runBatch(ctx, req.GetParent(), len(req.GetRequests()), req.GetAllowPartialSuccess(), func(ctx context.Context, i int) error {
+		r := req.Requests[i]
+		if r.GetParent() == "" {
+			r.Parent = req.GetParent()
+		}
+		if err := checkBatchName(req.GetParent(), r.GetParent()); err != nil {
+			response.ApiDeployments[i] = &rpc.ApiDeployment{}
+			return err
+		}
+		deployment, err := s.CreateApiDeployment(ctx, r)
+		if err != nil {
+			response.ApiDeployments[i] = &rpc.ApiDeployment{}
+			return err
+		}
+		response.ApiDeployments[i] = deployment
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	response.Statuses = statuses
+	return response, nil
+}
+
+// BatchUpdateApiDeployments handles the corresponding API request.
+func (s *RegistryServer) BatchUpdateApiDeployments(ctx context.Context, req *rpc.BatchUpdateApiDeploymentsRequest) (*rpc.BatchUpdateApiDeploymentsResponse, error) {
+	response := &rpc.BatchUpdateApiDeploymentsResponse{
+		ApiDeployments: make([]*rpc.ApiDeployment, len(req.GetRequests())),
+	}
+	statuses, err := s.runBatch(ctx, req.GetParent(), len(req.GetRequests()), req.GetAllowPartialSuccess(), func(ctx context.Context, i int) error {
+		r := req.Requests[i]
+		if err := checkBatchName(req.GetParent(), r.GetApiDeployment().GetName()); err != nil {
+			response.ApiDeployments[i] = &rpc.ApiDeployment{}
+			return err
+		}
+		deployment, err := s.UpdateApiDeployment(ctx, r)
+		if err != nil {
+			response.ApiDeployments[i] = &rpc.ApiDeployment{}
+			return err
+		}
+		response.ApiDeployments[i] = deployment
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	response.Statuses = statuses
+	return response, nil
+}
+
+// BatchDeleteApiDeployments handles the corresponding API request.
+func (s *RegistryServer) BatchDeleteApiDeployments(ctx context.Context, req *rpc.BatchDeleteApiDeploymentsRequest) (*rpc.BatchDeleteApiDeploymentsResponse, error) {
+	statuses, err := s.runBatch(ctx, req.GetParent(), len(req.GetRequests()), req.GetAllowPartialSuccess(), func(ctx context.Context, i int) error {
+		r := req.Requests[i]
+		if err := checkBatchName(req.GetParent(), r.GetName()); err != nil {
+			return err
+		}
+		_, err := s.DeleteApiDeployment(ctx, r)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &rpc.BatchDeleteApiDeploymentsResponse{Statuses: statuses}, nil
+}
+
+// BatchCreateArtifacts handles the corresponding API request.
+func (s *RegistryServer) BatchCreateArtifacts(ctx context.Context, req *rpc.BatchCreateArtifactsRequest) (*rpc.BatchCreateArtifactsResponse, error) {
+	response := &rpc.BatchCreateArtifactsResponse{
+		Artifacts: make([]*rpc.Artifact, len(req.GetRequests())),
+	}
+	statuses, err := s.runBatch(ctx, req.GetParent(), len(req.GetRequests()), req.GetAllowPartialSuccess(), func(ctx context.Context, i int) error {
+		r := req.Requests[i]
+		if r.GetParent() == "" {
+			r.Parent = req.GetParent()
+		}
+		if err := checkBatchName(req.GetParent(), r.GetParent()); err != nil {
+			response.Artifacts[i] = &rpc.Artifact{}
+			return err
+		}
+		artifact, err := s.CreateArtifact(ctx, r)
+		if err != nil {
+			response.Artifacts[i] = &rpc.Artifact{}
+			return err
+		}
+		response.Artifacts[i] = artifact
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	response.Statuses = statuses
+	return response, nil
+}
+
+// BatchReplaceArtifacts handles the corresponding API request.
+func (s *RegistryServer) BatchReplaceArtifacts(ctx context.Context, req *rpc.BatchReplaceArtifactsRequest) (*rpc.BatchReplaceArtifactsResponse, error) {
+	response := &rpc.BatchReplaceArtifactsResponse{
+		Artifacts: make([]*rpc.Artifact, len(req.GetRequests())),
+	}
+	statuses, err := s.runBatch(ctx, req.GetParent(), len(req.GetRequests()), req.GetAllowPartialSuccess(), func(ctx context.Context, i int) error {
+		r := req.Requests[i]
+		if err := checkBatchName(req.GetParent(), r.GetArtifact().GetName()); err != nil {
+			response.Artifacts[i] = &rpc.Artifact{}
+			return err
+		}
+		artifact, err := s.ReplaceArtifact(ctx, r)
+		if err != nil {
+			response.Artifacts[i] = &rpc.Artifact{}
+			return err
+		}
+		response.Artifacts[i] = artifact
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	response.Statuses = statuses
+	return response, nil
+}
+
+// BatchDeleteArtifacts handles the corresponding API request.
+func (s *RegistryServer) BatchDeleteArtifacts(ctx context.Context, req *rpc.BatchDeleteArtifactsRequest) (*rpc.BatchDeleteArtifactsResponse, error) {
+	statuses, err := s.runBatch(ctx, req.GetParent(), len(req.GetRequests()), req.GetAllowPartialSuccess(), func(ctx context.Context, i int) error {
+		r := req.Requests[i]
+		if err := checkBatchName(req.GetParent(), r.GetName()); err != nil {
+			return err
+		}
+		_, err := s.DeleteArtifact(ctx, r)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &rpc.BatchDeleteArtifactsResponse{Statuses: statuses}, nil
+}
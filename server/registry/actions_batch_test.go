@@ -0,0 +1,319 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/test/seeder"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+const batchParent = "projects/my-project/locations/global"
+
+func TestBatchCreate(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	if err := seeder.SeedProjects(ctx, server, &rpc.Project{Name: "projects/my-project"}); err != nil {
+		t.Fatalf("Setup: failed to seed registry: %s", err)
+	}
+
+	const api = batchParent + "/apis/a"
+	apis, err := server.BatchCreateApis(ctx, &rpc.BatchCreateApisRequest{
+		Parent: batchParent,
+		Requests: []*rpc.CreateApiRequest{
+			{ApiId: "a", Api: &rpc.Api{DisplayName: "A"}},
+			{Parent: batchParent, ApiId: "b", Api: &rpc.Api{}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("BatchCreateApis() returned error: %s", err)
+	}
+	if len(apis.GetApis()) != 2 || apis.Apis[0].GetName() != api || apis.Apis[0].GetDisplayName() != "A" || apis.Apis[1].GetName() != batchParent+"/apis/b" {
+		t.Errorf("BatchCreateApis() returned unexpected apis %+v", apis.GetApis())
+	}
+	if len(apis.GetStatuses()) != 0 {
+		t.Errorf("BatchCreateApis() returned %d statuses, want none", len(apis.GetStatuses()))
+	}
+
+	if _, err := server.BatchCreateApiVersions(ctx, &rpc.BatchCreateApiVersionsRequest{
+		Parent:   batchParent,
+		Requests: []*rpc.CreateApiVersionRequest{{Parent: api, ApiVersionId: "v", ApiVersion: &rpc.ApiVersion{}}},
+	}); err != nil {
+		t.Fatalf("BatchCreateApiVersions() returned error: %s", err)
+	}
+	specs, err := server.BatchCreateApiSpecs(ctx, &rpc.BatchCreateApiSpecsRequest{
+		Parent: batchParent,
+		Requests: []*rpc.CreateApiSpecRequest{
+			{Parent: api + "/versions/v", ApiSpecId: "s1", ApiSpec: &rpc.ApiSpec{Contents: []byte("one")}},
+			{Parent: api + "/versions/v", ApiSpecId: "s2", ApiSpec: &rpc.ApiSpec{Contents: []byte("two")}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("BatchCreateApiSpecs() returned error: %s", err)
+	}
+	if len(specs.GetApiSpecs()) != 2 || specs.ApiSpecs[1].GetSizeBytes() != 3 {
+		t.Errorf("BatchCreateApiSpecs() returned unexpected specs %+v", specs.GetApiSpecs())
+	}
+	if _, err := server.BatchCreateApiDeployments(ctx, &rpc.BatchCreateApiDeploymentsRequest{
+		Parent:   batchParent,
+		Requests: []*rpc.CreateApiDeploymentRequest{{Parent: api, ApiDeploymentId: "d", ApiDeployment: &rpc.ApiDeployment{}}},
+	}); err != nil {
+		t.Fatalf("BatchCreateApiDeployments() returned error: %s", err)
+	}
+	if _, err := server.BatchCreateArtifacts(ctx, &rpc.BatchCreateArtifactsRequest{
+		Parent: batchParent,
+		Requests: []*rpc.CreateArtifactRequest{
+			{ArtifactId: "x", Artifact: &rpc.Artifact{}},
+			{Parent: api + "/deployments/d", ArtifactId: "x", Artifact: &rpc.Artifact{}},
+		},
+	}); err != nil {
+		t.Fatalf("BatchCreateArtifacts() returned error: %s", err)
+	}
+	for _, name := range []string{batchParent + "/artifacts/x", api + "/deployments/d/artifacts/x"} {
+		if _, err := server.GetArtifact(ctx, &rpc.GetArtifactRequest{Name: name}); err != nil {
+			t.Errorf("GetArtifact(%q) returned error: %s", name, err)
+		}
+	}
+}
+
+func TestBatchAtomic(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	if err := seeder.SeedApis(ctx, server, &rpc.Api{Name: batchParent + "/apis/a"}); err != nil {
+		t.Fatalf("Setup: failed to seed registry: %s", err)
+	}
+
+	req := &rpc.BatchCreateApisRequest{
+		Parent: batchParent,
+		Requests: []*rpc.CreateApiRequest{
+			{ApiId: "b", Api: &rpc.Api{}},
+			{ApiId: "a", Api: &rpc.Api{}},
+		},
+	}
+	if _, err := server.BatchCreateApis(ctx, req); status.Code(err) != codes.AlreadyExists {
+		t.Fatalf("BatchCreateApis() returned status code %q, want %q: %v", status.Code(err), codes.AlreadyExists, err)
+	}
+	// Failed batches don't make any changes.
+	if _, err := server.GetApi(ctx, &rpc.GetApiRequest{Name: batchParent + "/apis/b"}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetApi() returned status code %q, want %q: %v", status.Code(err), codes.NotFound, err)
+	}
+
+	req.AllowPartialSuccess = true
+	response, err := server.BatchCreateApis(ctx, req)
+	if err != nil {
+		t.Fatalf("BatchCreateApis() returned error: %s", err)
+	}
+	if len(response.GetStatuses()) != 2 {
+		t.Fatalf("BatchCreateApis() returned %d statuses, want 2", len(response.GetStatuses()))
+	}
+	if codes.Code(response.Statuses[0].GetCode()) != codes.OK || response.Apis[0].GetName() != batchParent+"/apis/b" {
+		t.Errorf("BatchCreateApis() returned %v for %q, want success", response.Statuses[0], response.Apis[0].GetName())
+	}
+	if codes.Code(response.Statuses[1].GetCode()) != codes.AlreadyExists || response.Apis[1].GetName() != "" {
+		t.Errorf("BatchCreateApis() returned %v for %q, want %q", response.Statuses[1], response.Apis[1].GetName(), codes.AlreadyExists)
+	}
+}
+
+func TestBatchUpdateAndDelete(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	const version = batchParent + "/apis/a/versions/v"
+	if err := seeder.SeedRegistry(ctx, server,
+		&rpc.ApiSpec{Name: version + "/specs/s", Contents: []byte("old")},
+		&rpc.Artifact{Name: version + "/artifacts/x", Contents: []byte("old")},
+	); err != nil {
+		t.Fatalf("Setup: failed to seed registry: %s", err)
+	}
+
+	if _, err := server.BatchUpdateApis(ctx, &rpc.BatchUpdateApisRequest{
+		Parent: batchParent,
+		Requests: []*rpc.UpdateApiRequest{
+			{Api: &rpc.Api{Name: batchParent + "/apis/a", DisplayName: "A"}},
+			{Api: &rpc.Api{Name: batchParent + "/apis/b"}, AllowMissing: true},
+		},
+	}); err != nil {
+		t.Fatalf("BatchUpdateApis() returned error: %s", err)
+	}
+	api, err := server.GetApi(ctx, &rpc.GetApiRequest{Name: batchParent + "/apis/a"})
+	if err != nil {
+		t.Fatalf("GetApi() returned error: %s", err)
+	}
+	if api.GetDisplayName() != "A" {
+		t.Errorf("GetApi() returned display name %q, want %q", api.GetDisplayName(), "A")
+	}
+
+	specs, err := server.BatchUpdateApiSpecs(ctx, &rpc.BatchUpdateApiSpecsRequest{
+		Parent: batchParent,
+		Requests: []*rpc.UpdateApiSpecRequest{
+			{
+				ApiSpec:    &rpc.ApiSpec{Name: version + "/specs/s", Contents: []byte("new")},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"contents"}},
+			},
+			{ApiSpec: &rpc.ApiSpec{Name: version + "/specs/t", Contents: []byte("new")}, AllowMissing: true},
+		},
+	})
+	if err != nil {
+		t.Fatalf("BatchUpdateApiSpecs() returned error: %s", err)
+	}
+	revisions, err := server.ListApiSpecRevisions(ctx, &rpc.ListApiSpecRevisionsRequest{Name: version + "/specs/s"})
+	if err != nil {
+		t.Fatalf("ListApiSpecRevisions() returned error: %s", err)
+	}
+	if len(revisions.GetApiSpecs()) != 2 || revisions.ApiSpecs[0].GetRevisionId() != specs.ApiSpecs[0].GetRevisionId() {
+		t.Errorf("ListApiSpecRevisions() returned %d revisions, want a new revision %q", len(revisions.GetApiSpecs()), specs.ApiSpecs[0].GetRevisionId())
+	}
+
+	if _, err := server.BatchReplaceArtifacts(ctx, &rpc.BatchReplaceArtifactsRequest{
+		Parent:   batchParent,
+		Requests: []*rpc.ReplaceArtifactRequest{{Artifact: &rpc.Artifact{Name: version + "/artifacts/x", Contents: []byte("new")}}},
+	}); err != nil {
+		t.Fatalf("BatchReplaceArtifacts() returned error: %s", err)
+	}
+	contents, err := server.GetArtifactContents(ctx, &rpc.GetArtifactContentsRequest{Name: version + "/artifacts/x"})
+	if err != nil {
+		t.Fatalf("GetArtifactContents() returned error: %s", err)
+	}
+	if string(contents.GetData()) != "new" {
+		t.Errorf("GetArtifactContents() returned %q, want %q", contents.GetData(), "new")
+	}
+
+	deleted, err := server.BatchDeleteApiSpecs(ctx, &rpc.BatchDeleteApiSpecsRequest{
+		Parent: batchParent,
+		Requests: []*rpc.DeleteApiSpecRequest{
+			{Name: version + "/specs/s", Force: true},
+			{Name: version + "/specs/missing"},
+		},
+		AllowPartialSuccess: true,
+	})
+	if err != nil {
+		t.Fatalf("BatchDeleteApiSpecs() returned error: %s", err)
+	}
+	if got := deleted.GetStatuses(); len(got) != 2 || codes.Code(got[0].GetCode()) != codes.OK || codes.Code(got[1].GetCode()) != codes.NotFound {
+		t.Errorf("BatchDeleteApiSpecs() returned statuses %v, want [OK, NotFound]", got)
+	}
+	if _, err := server.GetApiSpec(ctx, &rpc.GetApiSpecRequest{Name: version + "/specs/s"}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetApiSpec() returned status code %q, want %q: %v", status.Code(err), codes.NotFound, err)
+	}
+	if _, err := server.BatchDeleteApis(ctx, &rpc.BatchDeleteApisRequest{
+		Parent:   batchParent,
+		Requests: []*rpc.DeleteApiRequest{{Name: batchParent + "/apis/a", Force: true}, {Name: batchParent + "/apis/b"}},
+	}); err != nil {
+		t.Fatalf("BatchDeleteApis() returned error: %s", err)
+	}
+	list, err := server.ListApis(ctx, &rpc.ListApisRequest{Parent: batchParent})
+	if err != nil {
+		t.Fatalf("ListApis() returned error: %s", err)
+	}
+	if len(list.GetApis()) != 0 {
+		t.Errorf("ListApis() returned %d apis, want none", len(list.GetApis()))
+	}
+}
+
+func TestBatchErrors(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	if err := seeder.SeedApis(ctx, server, &rpc.Api{Name: batchParent + "/apis/a"}); err != nil {
+		t.Fatalf("Setup: failed to seed registry: %s", err)
+	}
+
+	tooMany := make([]*rpc.DeleteApiRequest, 1001)
+	for i := range tooMany {
+		tooMany[i] = &rpc.DeleteApiRequest{Name: fmt.Sprintf("%s/apis/a%d", batchParent, i)}
+	}
+	tests := []struct {
+		desc string
+		call func() error
+		want codes.Code
+	}{
+		{
+			desc: "invalid parent",
+			call: func() error {
+				_, err := server.BatchDeleteApis(ctx, &rpc.BatchDeleteApisRequest{
+					Parent:   "projects/my-project",
+					Requests: []*rpc.DeleteApiRequest{{Name: batchParent + "/apis/a"}},
+				})
+				return err
+			},
+			want: codes.InvalidArgument,
+		},
+		{
+			desc: "no requests",
+			call: func() error {
+				_, err := server.BatchDeleteApis(ctx, &rpc.BatchDeleteApisRequest{Parent: batchParent})
+				return err
+			},
+			want: codes.InvalidArgument,
+		},
+		{
+			desc: "too many requests",
+			call: func() error {
+				_, err := server.BatchDeleteApis(ctx, &rpc.BatchDeleteApisRequest{Parent: batchParent, Requests: tooMany})
+				return err
+			},
+			want: codes.InvalidArgument,
+		},
+		{
+			desc: "request outside of parent",
+			call: func() error {
+				_, err := server.BatchUpdateApis(ctx, &rpc.BatchUpdateApisRequest{
+					Parent:   batchParent,
+					Requests: []*rpc.UpdateApiRequest{{Api: &rpc.Api{Name: "projects/other/locations/global/apis/a"}, AllowMissing: true}},
+				})
+				return err
+			},
+			want: codes.InvalidArgument,
+		},
+		{
+			desc: "create outside of parent",
+			call: func() error {
+				_, err := server.BatchCreateApiVersions(ctx, &rpc.BatchCreateApiVersionsRequest{
+					Parent:   batchParent,
+					Requests: []*rpc.CreateApiVersionRequest{{Parent: "projects/my-project-2/locations/global/apis/a", ApiVersionId: "v", ApiVersion: &rpc.ApiVersion{}}},
+				})
+				return err
+			},
+			want: codes.InvalidArgument,
+		},
+		{
+			desc: "missing resource",
+			call: func() error {
+				_, err := server.BatchDeleteApis(ctx, &rpc.BatchDeleteApisRequest{
+					Parent:   batchParent,
+					Requests: []*rpc.DeleteApiRequest{{Name: batchParent + "/apis/a"}, {Name: batchParent + "/apis/missing"}},
+				})
+				return err
+			},
+			want: codes.NotFound,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			if err := test.call(); status.Code(err) != test.want {
+				t.Errorf("returned status code %q, want %q: %v", status.Code(err), test.want, err)
+			}
+		})
+	}
+
+	// The API deleted by the failed batch is still there.
+	if _, err := server.GetApi(ctx, &rpc.GetApiRequest{Name: batchParent + "/apis/a"}); err != nil {
+		t.Errorf("GetApi() returned error: %s", err)
+	}
+}
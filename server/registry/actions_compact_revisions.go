@@ -0,0 +1,76 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"time"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/names"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxReportedRevisions is the largest number of revisions named in a CompactRevisions response.
+const maxReportedRevisions = 1000
+
+// CompactRevisions handles the corresponding API request.
+func (s *RegistryServer) CompactRevisions(ctx context.Context, req *rpc.CompactRevisionsRequest) (*rpc.CompactRevisionsResponse, error) {
+	parent := names.Project{ProjectID: "-"}
+	if req.GetParent() != "" {
+		var err error
+		parent, err = names.ParseProject(req.GetParent())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	config := s.retention
+	if policy := req.GetPolicy(); policy != nil {
+		if policy.GetKeepRevisions() < 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid keep_revisions %d: must be non-negative", policy.GetKeepRevisions())
+		}
+		if d := policy.GetKeepDuration(); d != nil && (!d.IsValid() || d.AsDuration() < 0) {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid keep_duration %s: must be non-negative", d.AsDuration())
+		}
+		requested := RetentionConfig{
+			KeepRevisions: int(policy.GetKeepRevisions()),
+			KeepDuration:  policy.GetKeepDuration().AsDuration(),
+		}
+		// Policies without rules don't override the configured policy.
+		if requested.enabled() {
+			config = requested
+		}
+	}
+	if !config.enabled() {
+		return nil, status.Error(codes.FailedPrecondition, "no retention policy is configured, so all revisions are kept")
+	}
+
+	removed, err := s.compactRevisions(ctx, parent, config, time.Now(), req.GetValidateOnly())
+	if err != nil {
+		return nil, err
+	}
+	response := &rpc.CompactRevisionsResponse{
+		RevisionCount: int64(len(removed)),
+	}
+	for i, r := range removed {
+		if i < maxReportedRevisions {
+			response.Revisions = append(response.Revisions, r.Name)
+		}
+		response.SizeBytes += r.SizeBytes
+	}
+	return response, nil
+}
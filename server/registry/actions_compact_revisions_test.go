@@ -0,0 +1,248 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/test/seeder"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestCompactRevisions(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	const (
+		specName       = "projects/my-project/locations/global/apis/my-api/versions/v1/specs/my-spec"
+		deploymentName = "projects/my-project/locations/global/apis/my-api/deployments/my-deployment"
+	)
+	if err := seeder.SeedRegistry(ctx, server,
+		&rpc.ApiSpec{Name: specName},
+		&rpc.ApiDeployment{Name: deploymentName},
+	); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+
+	// Spec revisions are listed from the earliest to the latest.
+	specRevisions := []string{specName + "@" + getSpecRevisionID(ctx, t, server, specName)}
+	for i := 1; i < 8; i++ {
+		req := &rpc.UpdateApiSpecRequest{
+			ApiSpec: &rpc.ApiSpec{
+				Name:     specName,
+				Contents: []byte(fmt.Sprintf("contents of revision %d", i)),
+			},
+		}
+		spec, err := server.UpdateApiSpec(ctx, req)
+		if err != nil {
+			t.Fatalf("Setup: UpdateApiSpec(%+v) returned error: %s", req, err)
+		}
+		specRevisions = append(specRevisions, specName+"@"+spec.GetRevisionId())
+	}
+	deployment, err := server.GetApiDeployment(ctx, &rpc.GetApiDeploymentRequest{Name: deploymentName})
+	if err != nil {
+		t.Fatalf("Setup: GetApiDeployment(%s) returned error: %s", deploymentName, err)
+	}
+	deploymentRevisions := []string{deploymentName + "@" + deployment.GetRevisionId()}
+	for i := 1; i < 4; i++ {
+		req := &rpc.UpdateApiDeploymentRequest{
+			ApiDeployment: &rpc.ApiDeployment{
+				Name:        deploymentName,
+				EndpointUri: fmt.Sprintf("https://%d.example.com", i),
+			},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"endpoint_uri"}},
+		}
+		deployment, err = server.UpdateApiDeployment(ctx, req)
+		if err != nil {
+			t.Fatalf("Setup: UpdateApiDeployment(%+v) returned error: %s", req, err)
+		}
+		deploymentRevisions = append(deploymentRevisions, deploymentName+"@"+deployment.GetRevisionId())
+	}
+
+	// Revisions that are tagged or referenced are kept.
+	if _, err := server.TagApiSpecRevision(ctx, &rpc.TagApiSpecRevisionRequest{Name: specRevisions[1], Tag: "stable"}); err != nil {
+		t.Fatalf("Setup: TagApiSpecRevision() returned error: %s", err)
+	}
+	if _, err := server.UpdateApiVersion(ctx, &rpc.UpdateApiVersionRequest{
+		ApiVersion: &rpc.ApiVersion{
+			Name:        "projects/my-project/locations/global/apis/my-api/versions/v1",
+			PrimarySpec: specRevisions[2],
+		},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"primary_spec"}},
+	}); err != nil {
+		t.Fatalf("Setup: UpdateApiVersion() returned error: %s", err)
+	}
+	if _, err := server.CreateApiDeployment(ctx, &rpc.CreateApiDeploymentRequest{
+		Parent:          "projects/my-project/locations/global/apis/my-api",
+		ApiDeploymentId: "other",
+		ApiDeployment:   &rpc.ApiDeployment{ApiSpecRevision: specRevisions[3]},
+	}); err != nil {
+		t.Fatalf("Setup: CreateApiDeployment() returned error: %s", err)
+	}
+	if _, err := server.UpdateApi(ctx, &rpc.UpdateApiRequest{
+		Api: &rpc.Api{
+			Name:                  "projects/my-project/locations/global/apis/my-api",
+			RecommendedDeployment: deploymentRevisions[1],
+		},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"recommended_deployment"}},
+	}); err != nil {
+		t.Fatalf("Setup: UpdateApi() returned error: %s", err)
+	}
+	// Artifacts of removed revisions are removed with them.
+	artifact := specRevisions[0] + "/artifacts/my-artifact"
+	if _, err := server.CreateArtifact(ctx, &rpc.CreateArtifactRequest{
+		Parent:     specRevisions[0],
+		ArtifactId: "my-artifact",
+		Artifact:   &rpc.Artifact{Contents: []byte("contents")},
+	}); err != nil {
+		t.Fatalf("Setup: CreateArtifact() returned error: %s", err)
+	}
+
+	req := &rpc.CompactRevisionsRequest{
+		Parent:       "projects/my-project",
+		Policy:       &rpc.RetentionPolicy{KeepRevisions: 2},
+		ValidateOnly: true,
+	}
+	want := []string{
+		specRevisions[0],
+		specRevisions[4],
+		specRevisions[5],
+		deploymentRevisions[0],
+	}
+	sort.Strings(want)
+
+	got, err := server.CompactRevisions(ctx, req)
+	if err != nil {
+		t.Fatalf("CompactRevisions(%+v) returned error: %s", req, err)
+	}
+	sort.Strings(got.Revisions)
+	if !cmp.Equal(want, got.Revisions) {
+		t.Errorf("CompactRevisions(%+v) returned unexpected revisions (-want +got):\n%s", req, cmp.Diff(want, got.Revisions))
+	}
+	if got.GetRevisionCount() != int64(len(want)) {
+		t.Errorf("CompactRevisions(%+v) returned a count of %d revisions, want %d", req, got.GetRevisionCount(), len(want))
+	}
+	if got.GetSizeBytes() == 0 {
+		t.Errorf("CompactRevisions(%+v) returned no size, want the size of the removed specs", req)
+	}
+	if n := countSpecRevisions(ctx, t, server, specName); n != 8 {
+		t.Errorf("CompactRevisions(%+v) changed the revisions of %s in validation, got %d want 8", req, specName, n)
+	}
+
+	req.ValidateOnly = false
+	removed, err := server.CompactRevisions(ctx, req)
+	if err != nil {
+		t.Fatalf("CompactRevisions(%+v) returned error: %s", req, err)
+	}
+	sort.Strings(removed.Revisions)
+	if !cmp.Equal(want, removed.Revisions) {
+		t.Errorf("CompactRevisions(%+v) removed unexpected revisions (-want +got):\n%s", req, cmp.Diff(want, removed.Revisions))
+	}
+	if n := countSpecRevisions(ctx, t, server, specName); n != 5 {
+		t.Errorf("CompactRevisions(%+v) left %d revisions of %s, want 5", req, n, specName)
+	}
+	kept := []string{specRevisions[1], specRevisions[2], specRevisions[3], specRevisions[6], specRevisions[7]}
+	for _, name := range kept {
+		if _, err := server.GetApiSpec(ctx, &rpc.GetApiSpecRequest{Name: name}); err != nil {
+			t.Errorf("GetApiSpec(%s) returned error for a kept revision: %s", name, err)
+		}
+	}
+	if _, err := server.GetApiSpec(ctx, &rpc.GetApiSpecRequest{Name: specRevisions[0]}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetApiSpec(%s) returned status code %q, want %q: %v", specRevisions[0], status.Code(err), codes.NotFound, err)
+	}
+	if _, err := server.GetArtifact(ctx, &rpc.GetArtifactRequest{Name: artifact}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetArtifact(%s) returned status code %q, want %q: %v", artifact, status.Code(err), codes.NotFound, err)
+	}
+	if _, err := server.GetApiDeployment(ctx, &rpc.GetApiDeploymentRequest{Name: deploymentRevisions[1]}); err != nil {
+		t.Errorf("GetApiDeployment(%s) returned error for a referenced revision: %s", deploymentRevisions[1], err)
+	}
+
+	again, err := server.CompactRevisions(ctx, req)
+	if err != nil {
+		t.Fatalf("CompactRevisions(%+v) returned error: %s", req, err)
+	}
+	if again.GetRevisionCount() != 0 {
+		t.Errorf("CompactRevisions(%+v) removed %d revisions after compaction, want 0", req, again.GetRevisionCount())
+	}
+}
+
+func TestCompactRevisionsErrors(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	tests := []struct {
+		desc string
+		req  *rpc.CompactRevisionsRequest
+		want codes.Code
+	}{
+		{
+			desc: "invalid parent",
+			req: &rpc.CompactRevisionsRequest{
+				Parent: "projects/my-project/locations/global",
+				Policy: &rpc.RetentionPolicy{KeepRevisions: 1},
+			},
+			want: codes.InvalidArgument,
+		},
+		{
+			desc: "negative revisions",
+			req: &rpc.CompactRevisionsRequest{
+				Policy: &rpc.RetentionPolicy{KeepRevisions: -1},
+			},
+			want: codes.InvalidArgument,
+		},
+		{
+			desc: "no policy",
+			req:  &rpc.CompactRevisionsRequest{},
+			want: codes.FailedPrecondition,
+		},
+		{
+			desc: "empty policy",
+			req: &rpc.CompactRevisionsRequest{
+				Policy: &rpc.RetentionPolicy{},
+			},
+			want: codes.FailedPrecondition,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			if _, err := server.CompactRevisions(ctx, test.req); status.Code(err) != test.want {
+				t.Errorf("CompactRevisions(%+v) returned status code %q, want %q: %v", test.req, status.Code(err), test.want, err)
+			}
+		})
+	}
+}
+
+func getSpecRevisionID(ctx context.Context, t *testing.T, server TestServer, name string) string {
+	t.Helper()
+	spec, err := server.GetApiSpec(ctx, &rpc.GetApiSpecRequest{Name: name})
+	if err != nil {
+		t.Fatalf("GetApiSpec(%s) returned error: %s", name, err)
+	}
+	return spec.GetRevisionId()
+}
+
+func countSpecRevisions(ctx context.Context, t *testing.T, server TestServer, name string) int {
+	t.Helper()
+	list, err := server.ListApiSpecRevisions(ctx, &rpc.ListApiSpecRevisionsRequest{Name: name, PageSize: 100})
+	if err != nil {
+		t.Fatalf("ListApiSpecRevisions(%s) returned error: %s", name, err)
+	}
+	return len(list.GetApiSpecs())
+}
@@ -0,0 +1,148 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"strings"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/storage"
+	"github.com/apigee/registry/server/registry/names"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CloneProject handles the corresponding API request.
+func (s *RegistryServer) CloneProject(ctx context.Context, req *rpc.CloneProjectRequest) (*rpc.Project, error) {
+	source, err := names.ParseProject(req.GetName())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	target := names.Project{ProjectID: req.GetProjectId()}
+	if err := target.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var response *rpc.Project
+	if err := s.runInTransaction(ctx, func(ctx context.Context, db *storage.Client) error {
+		db.LockProject(ctx, target)
+		if _, err := db.GetProject(ctx, target); err == nil {
+			return status.Errorf(codes.AlreadyExists, "%s already exists", target)
+		} else if status.Code(err) != codes.NotFound {
+			return err
+		}
+		resources, err := db.GetProjectResources(ctx, source)
+		if err != nil {
+			return err
+		}
+		entries, err := archiveEntries(resources)
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		if err := s.copyEntries(ctx, db, entries, source.String(), target.String(), "CloneProject"); err != nil {
+			return err
+		}
+		project, err := db.GetProject(ctx, target)
+		if err != nil {
+			return err
+		}
+		response = project.Message()
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// CopyApi handles the corresponding API request.
+func (s *RegistryServer) CopyApi(ctx context.Context, req *rpc.CopyApiRequest) (*rpc.Api, error) {
+	source, err := names.ParseApi(req.GetName())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	parent, err := names.ParseProjectWithLocation(req.GetParent())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	// Copies have the IDs of the APIs they copy unless another ID is specified.
+	id := req.GetApiId()
+	if id == "" {
+		id = source.ApiID
+	}
+	target := parent.Api(id)
+	if err := target.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var response *rpc.Api
+	if err := s.runInTransaction(ctx, func(ctx context.Context, db *storage.Client) error {
+		db.LockApi(ctx, target)
+		if _, err := db.GetProject(ctx, parent); err != nil {
+			return err
+		}
+		if _, err := db.GetApi(ctx, target); err == nil {
+			return status.Errorf(codes.AlreadyExists, "%s already exists", target)
+		} else if status.Code(err) != codes.NotFound {
+			return err
+		}
+		resources, err := db.GetApiResources(ctx, source)
+		if err != nil {
+			return err
+		}
+		entries, err := archiveEntries(resources)
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		// The first entry is the project of the API, which isn't copied.
+		if err := s.copyEntries(ctx, db, entries[1:], source.String(), target.String(), "CopyApi"); err != nil {
+			return err
+		}
+		api, err := db.GetApi(ctx, target)
+		if err != nil {
+			return err
+		}
+		response, err = api.Message()
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// copyEntries saves copies of the resources of archive entries, replacing the
+// prefix "from" of their names with "to". References to resources that aren't
+// copied are left unchanged.
+func (s *RegistryServer) copyEntries(ctx context.Context, db *storage.Client, entries []*rpc.ArchiveEntry, from, to, method string) error {
+	rename := func(name string) (string, error) {
+		if name != from && !strings.HasPrefix(name, from+"/") {
+			return name, nil
+		}
+		return to + strings.TrimPrefix(name, from), nil
+	}
+	for _, entry := range entries {
+		if err := readEntryContents(ctx, db, entry); err != nil {
+			return err
+		}
+		if err := s.importEntry(ctx, db, entry, rename, method, rpc.Notification_CREATED); err != nil {
+			return err
+		}
+		clearEntryContents(entry)
+	}
+	return nil
+}
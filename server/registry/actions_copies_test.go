@@ -0,0 +1,299 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/test/seeder"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// seedCopySource seeds an API with revisions, tags, artifacts and references
+// between its resources, and another API that refers to it.
+func seedCopySource(ctx context.Context, t *testing.T, server TestServer) {
+	t.Helper()
+	const (
+		api        = "projects/p/locations/global/apis/a"
+		spec       = api + "/versions/v/specs/s"
+		deployment = api + "/deployments/d"
+		artifact   = api + "/artifacts/x"
+	)
+	if err := seeder.SeedRegistry(ctx, server,
+		&rpc.ApiSpec{Name: spec, MimeType: "text/plain", Contents: []byte("first")},
+		&rpc.ApiDeployment{Name: deployment},
+		&rpc.Artifact{Name: artifact, MimeType: "text/plain", Contents: []byte("api artifact")},
+		&rpc.Api{Name: "projects/p/locations/global/apis/b"},
+	); err != nil {
+		t.Fatalf("Setup: failed to seed registry: %s", err)
+	}
+	first, err := server.TagApiSpecRevision(ctx, &rpc.TagApiSpecRevisionRequest{Name: spec, Tag: "first"})
+	if err != nil {
+		t.Fatalf("Setup: TagApiSpecRevision() returned error: %s", err)
+	}
+	if _, err := server.UpdateApiSpec(ctx, &rpc.UpdateApiSpecRequest{
+		ApiSpec:    &rpc.ApiSpec{Name: spec, Contents: []byte("second")},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"contents"}},
+	}); err != nil {
+		t.Fatalf("Setup: UpdateApiSpec() returned error: %s", err)
+	}
+	if _, err := server.TagArtifactRevision(ctx, &rpc.TagArtifactRevisionRequest{Name: artifact, Tag: "first"}); err != nil {
+		t.Fatalf("Setup: TagArtifactRevision() returned error: %s", err)
+	}
+	if _, err := server.ReplaceArtifact(ctx, &rpc.ReplaceArtifactRequest{
+		Artifact: &rpc.Artifact{Name: artifact, MimeType: "text/plain", Contents: []byte("replaced artifact")},
+	}); err != nil {
+		t.Fatalf("Setup: ReplaceArtifact() returned error: %s", err)
+	}
+	if _, err := server.UpdateApiDeployment(ctx, &rpc.UpdateApiDeploymentRequest{
+		ApiDeployment: &rpc.ApiDeployment{Name: deployment, ApiSpecRevision: spec + "@" + first.GetRevisionId()},
+		UpdateMask:    &fieldmaskpb.FieldMask{Paths: []string{"api_spec_revision"}},
+	}); err != nil {
+		t.Fatalf("Setup: UpdateApiDeployment() returned error: %s", err)
+	}
+	if _, err := server.UpdateApiVersion(ctx, &rpc.UpdateApiVersionRequest{
+		ApiVersion: &rpc.ApiVersion{Name: api + "/versions/v", PrimarySpec: spec},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"primary_spec"}},
+	}); err != nil {
+		t.Fatalf("Setup: UpdateApiVersion() returned error: %s", err)
+	}
+	if _, err := server.UpdateApi(ctx, &rpc.UpdateApiRequest{
+		Api:        &rpc.Api{Name: api, RecommendedVersion: api + "/versions/v", RecommendedDeployment: deployment},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"recommended_version", "recommended_deployment"}},
+	}); err != nil {
+		t.Fatalf("Setup: UpdateApi() returned error: %s", err)
+	}
+	if _, err := server.UpdateApi(ctx, &rpc.UpdateApiRequest{
+		Api:        &rpc.Api{Name: "projects/p/locations/global/apis/b", RecommendedVersion: api + "/versions/v"},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"recommended_version"}},
+	}); err != nil {
+		t.Fatalf("Setup: UpdateApi() returned error: %s", err)
+	}
+}
+
+func TestCloneProject(t *testing.T) {
+	if adminServiceUnavailable() {
+		t.Skip(testRequiresAdminService)
+	}
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	seedCopySource(ctx, t, server)
+
+	req := &rpc.CloneProjectRequest{Name: "projects/p", ProjectId: "q"}
+	project, err := server.CloneProject(ctx, req)
+	if err != nil {
+		t.Fatalf("CloneProject(%+v) returned error: %s", req, err)
+	}
+	if project.GetName() != "projects/q" {
+		t.Errorf("CloneProject(%+v) returned project %q, want %q", req, project.GetName(), "projects/q")
+	}
+
+	// Clones have the same resources as their sources, with references to the clones.
+	want := archiveEntryStrings(t, readArchive(ctx, t, server, exportArchive(ctx, t, server, "projects/p").GetArchive().GetName()), "projects/p", "projects/p")
+	got := archiveEntryStrings(t, readArchive(ctx, t, server, exportArchive(ctx, t, server, "projects/q").GetArchive().GetName()), "projects/q", "projects/p")
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("CloneProject(%+v) made an unexpected copy (-want +got):\n%s", req, diff)
+	}
+
+	if _, err := server.CloneProject(ctx, req); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("CloneProject(%+v) again returned status code %q, want %q: %v", req, status.Code(err), codes.AlreadyExists, err)
+	}
+}
+
+func TestCopyApi(t *testing.T) {
+	if adminServiceUnavailable() {
+		t.Skip(testRequiresAdminService)
+	}
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	seedCopySource(ctx, t, server)
+	if err := seeder.SeedProjects(ctx, server, &rpc.Project{Name: "projects/q"}); err != nil {
+		t.Fatalf("Setup: failed to seed registry: %s", err)
+	}
+
+	const copied = "projects/q/locations/global/apis/c"
+	req := &rpc.CopyApiRequest{Name: "projects/p/locations/global/apis/a", Parent: "projects/q/locations/global", ApiId: "c"}
+	api, err := server.CopyApi(ctx, req)
+	if err != nil {
+		t.Fatalf("CopyApi(%+v) returned error: %s", req, err)
+	}
+	if api.GetName() != copied || api.GetRecommendedVersion() != copied+"/versions/v" || api.GetRecommendedDeployment() != copied+"/deployments/d" {
+		t.Errorf("CopyApi(%+v) returned unexpected api %+v", req, api)
+	}
+
+	// Other APIs of the project aren't copied.
+	apis, err := server.ListApis(ctx, &rpc.ListApisRequest{Parent: "projects/q/locations/global"})
+	if err != nil {
+		t.Fatalf("ListApis() returned error: %s", err)
+	}
+	if len(apis.GetApis()) != 1 {
+		t.Errorf("ListApis() returned %d apis, want 1", len(apis.GetApis()))
+	}
+
+	// Copies keep revisions and tags, and refer to other copies.
+	sources, err := server.ListApiSpecRevisions(ctx, &rpc.ListApiSpecRevisionsRequest{Name: "projects/p/locations/global/apis/a/versions/v/specs/s"})
+	if err != nil {
+		t.Fatalf("ListApiSpecRevisions() returned error: %s", err)
+	}
+	copies, err := server.ListApiSpecRevisions(ctx, &rpc.ListApiSpecRevisionsRequest{Name: copied + "/versions/v/specs/s"})
+	if err != nil {
+		t.Fatalf("ListApiSpecRevisions() returned error: %s", err)
+	}
+	if len(copies.GetApiSpecs()) != 2 || len(sources.GetApiSpecs()) != 2 {
+		t.Fatalf("ListApiSpecRevisions() returned %d copies of %d revisions, want 2", len(copies.GetApiSpecs()), len(sources.GetApiSpecs()))
+	}
+	for i, c := range copies.GetApiSpecs() {
+		if c.GetRevisionId() != sources.GetApiSpecs()[i].GetRevisionId() {
+			t.Errorf("copied revision %q, want revision ID %q", c.GetName(), sources.GetApiSpecs()[i].GetRevisionId())
+		}
+	}
+	contents, err := server.GetApiSpecContents(ctx, &rpc.GetApiSpecContentsRequest{Name: copied + "/versions/v/specs/s@first"})
+	if err != nil {
+		t.Fatalf("GetApiSpecContents() returned error: %s", err)
+	}
+	if string(contents.GetData()) != "first" {
+		t.Errorf("GetApiSpecContents() returned %q, want %q", contents.GetData(), "first")
+	}
+	version, err := server.GetApiVersion(ctx, &rpc.GetApiVersionRequest{Name: copied + "/versions/v"})
+	if err != nil {
+		t.Fatalf("GetApiVersion() returned error: %s", err)
+	}
+	if version.GetPrimarySpec() != copied+"/versions/v/specs/s" {
+		t.Errorf("GetApiVersion() returned primary spec %q, want %q", version.GetPrimarySpec(), copied+"/versions/v/specs/s")
+	}
+	deployment, err := server.GetApiDeployment(ctx, &rpc.GetApiDeploymentRequest{Name: copied + "/deployments/d"})
+	if err != nil {
+		t.Fatalf("GetApiDeployment() returned error: %s", err)
+	}
+	if want := copied + "/versions/v/specs/s@" + sources.GetApiSpecs()[1].GetRevisionId(); deployment.GetApiSpecRevision() != want {
+		t.Errorf("GetApiDeployment() returned spec revision %q, want %q", deployment.GetApiSpecRevision(), want)
+	}
+	artifact, err := server.GetArtifactContents(ctx, &rpc.GetArtifactContentsRequest{Name: copied + "/artifacts/x"})
+	if err != nil {
+		t.Fatalf("GetArtifactContents() returned error: %s", err)
+	}
+	if string(artifact.GetData()) != "replaced artifact" {
+		t.Errorf("GetArtifactContents() returned %q, want %q", artifact.GetData(), "replaced artifact")
+	}
+	artifact, err = server.GetArtifactContents(ctx, &rpc.GetArtifactContentsRequest{Name: copied + "/artifacts/x@first"})
+	if err != nil {
+		t.Fatalf("GetArtifactContents() returned error: %s", err)
+	}
+	if string(artifact.GetData()) != "api artifact" {
+		t.Errorf("GetArtifactContents() returned %q, want %q", artifact.GetData(), "api artifact")
+	}
+	artifactSources, err := server.ListArtifactRevisions(ctx, &rpc.ListArtifactRevisionsRequest{Name: "projects/p/locations/global/apis/a/artifacts/x"})
+	if err != nil {
+		t.Fatalf("ListArtifactRevisions() returned error: %s", err)
+	}
+	artifactCopies, err := server.ListArtifactRevisions(ctx, &rpc.ListArtifactRevisionsRequest{Name: copied + "/artifacts/x"})
+	if err != nil {
+		t.Fatalf("ListArtifactRevisions() returned error: %s", err)
+	}
+	if len(artifactCopies.GetArtifacts()) != 2 || len(artifactSources.GetArtifacts()) != 2 {
+		t.Fatalf("ListArtifactRevisions() returned %d copies of %d revisions, want 2", len(artifactCopies.GetArtifacts()), len(artifactSources.GetArtifacts()))
+	}
+	for i, c := range artifactCopies.GetArtifacts() {
+		if c.GetRevisionId() != artifactSources.GetArtifacts()[i].GetRevisionId() {
+			t.Errorf("copied revision %q, want revision ID %q", c.GetName(), artifactSources.GetArtifacts()[i].GetRevisionId())
+		}
+	}
+}
+
+func TestCopyErrors(t *testing.T) {
+	if adminServiceUnavailable() {
+		t.Skip(testRequiresAdminService)
+	}
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	if err := seeder.SeedApis(ctx, server, &rpc.Api{Name: "projects/p/locations/global/apis/a"}); err != nil {
+		t.Fatalf("Setup: failed to seed registry: %s", err)
+	}
+
+	tests := []struct {
+		desc string
+		call func() error
+		want codes.Code
+	}{
+		{
+			desc: "clone missing project",
+			call: func() error {
+				_, err := server.CloneProject(ctx, &rpc.CloneProjectRequest{Name: "projects/missing", ProjectId: "q"})
+				return err
+			},
+			want: codes.NotFound,
+		},
+		{
+			desc: "clone into invalid project",
+			call: func() error {
+				_, err := server.CloneProject(ctx, &rpc.CloneProjectRequest{Name: "projects/p", ProjectId: "Invalid!"})
+				return err
+			},
+			want: codes.InvalidArgument,
+		},
+		{
+			desc: "clone into existing project",
+			call: func() error {
+				_, err := server.CloneProject(ctx, &rpc.CloneProjectRequest{Name: "projects/p", ProjectId: "p"})
+				return err
+			},
+			want: codes.AlreadyExists,
+		},
+		{
+			desc: "copy missing api",
+			call: func() error {
+				_, err := server.CopyApi(ctx, &rpc.CopyApiRequest{Name: "projects/p/locations/global/apis/missing", Parent: "projects/p/locations/global", ApiId: "b"})
+				return err
+			},
+			want: codes.NotFound,
+		},
+		{
+			desc: "copy into missing project",
+			call: func() error {
+				_, err := server.CopyApi(ctx, &rpc.CopyApiRequest{Name: "projects/p/locations/global/apis/a", Parent: "projects/missing/locations/global"})
+				return err
+			},
+			want: codes.NotFound,
+		},
+		{
+			desc: "copy into invalid parent",
+			call: func() error {
+				_, err := server.CopyApi(ctx, &rpc.CopyApiRequest{Name: "projects/p/locations/global/apis/a", Parent: "invalid"})
+				return err
+			},
+			want: codes.InvalidArgument,
+		},
+		{
+			desc: "copy onto existing api",
+			call: func() error {
+				_, err := server.CopyApi(ctx, &rpc.CopyApiRequest{Name: "projects/p/locations/global/apis/a", Parent: "projects/p/locations/global"})
+				return err
+			},
+			want: codes.AlreadyExists,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			if err := test.call(); status.Code(err) != test.want {
+				t.Errorf("returned status code %q, want %q: %v", status.Code(err), test.want, err)
+			}
+		})
+	}
+}
@@ -79,9 +79,17 @@ func (s *RegistryServer) DeleteApiDeploymentRevision(ctx context.Context, req *r
 	}
 	var response *rpc.ApiDeployment
 	if err := s.runInTransaction(ctx, func(ctx context.Context, db *storage.Client) error {
+		if err := s.checkReferrers(ctx, db.LockDeployment(ctx, name.Deployment()), name.String()); err != nil {
+			return err
+		}
 		if err := db.DeleteDeploymentRevision(ctx, name); err != nil {
 			return err
 		}
+		if err := s.purgeDeleted(ctx, db); err != nil {
+			return err
+		}
+		s.notify(ctx, rpc.Notification_DELETED, name.String())
+		s.audit(ctx, "DeleteApiDeploymentRevision", name.String(), nil, nil)
 		return nil
 	}); err != nil {
 		return nil, err
@@ -92,7 +100,6 @@ func (s *RegistryServer) DeleteApiDeploymentRevision(ctx context.Context, req *r
 		// The get will fail if we are deleting the only revision.
 		return nil, status.Error(codes.Internal, err.Error())
 	}
-	s.notify(ctx, rpc.Notification_DELETED, name.String())
 	return response, nil
 }
 
@@ -116,10 +123,9 @@ func (s *RegistryServer) TagApiDeploymentRevision(ctx context.Context, req *rpc.
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 	var response *rpc.ApiDeployment
-	var revisionName string
 	if err := s.runInTransaction(ctx, func(ctx context.Context, db *storage.Client) error {
 		// The revision to be tagged must exist.
-		revision, err := db.GetDeploymentRevision(ctx, name)
+		revision, err := db.LockDeployment(ctx, name.Deployment()).GetDeploymentRevision(ctx, name)
 		if err != nil {
 			return err
 		}
@@ -137,12 +143,12 @@ func (s *RegistryServer) TagApiDeploymentRevision(ctx context.Context, req *rpc.
 		if err != nil {
 			return err
 		}
-		revisionName = name.String()
+		s.notify(ctx, rpc.Notification_UPDATED, name.String())
+		s.audit(ctx, "TagApiDeploymentRevision", tag.String(), nil, nil)
 		return nil
 	}); err != nil {
 		return nil, err
 	}
-	s.notify(ctx, rpc.Notification_UPDATED, revisionName)
 	return response, nil
 }
 
@@ -158,11 +164,10 @@ func (s *RegistryServer) RollbackApiDeployment(ctx context.Context, req *rpc.Rol
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 	var response *rpc.ApiDeployment
-	var revisionName string
 	if err := s.runInTransaction(ctx, func(ctx context.Context, db *storage.Client) error {
 		// Get the target deployment revision to use as a base for the new rollback revision.
 		name := parent.Revision(req.GetRevisionId())
-		target, err := db.GetDeploymentRevision(ctx, name)
+		target, err := db.LockDeployment(ctx, parent).GetDeploymentRevision(ctx, name)
 		if err != nil {
 			return err
 		}
@@ -175,11 +180,11 @@ func (s *RegistryServer) RollbackApiDeployment(ctx context.Context, req *rpc.Rol
 		if err != nil {
 			return err
 		}
-		revisionName = rollback.RevisionName()
+		s.notify(ctx, rpc.Notification_CREATED, rollback.RevisionName())
+		s.audit(ctx, "RollbackApiDeployment", rollback.RevisionName(), nil, response)
 		return nil
 	}); err != nil {
 		return nil, err
 	}
-	s.notify(ctx, rpc.Notification_CREATED, revisionName)
 	return response, nil
 }
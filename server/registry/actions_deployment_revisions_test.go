@@ -262,7 +262,7 @@ func TestRollbackApiDeployment(t *testing.T) {
 
 	opts := cmp.Options{
 		protocmp.Transform(),
-		protocmp.IgnoreFields(new(rpc.ApiDeployment), "revision_id", "revision_create_time", "revision_update_time"),
+		protocmp.IgnoreFields(new(rpc.ApiDeployment), "revision_id", "revision_create_time", "revision_update_time", "etag"),
 	}
 
 	if !cmp.Equal(want, rollback, opts) {
@@ -336,6 +336,60 @@ func TestDeleteApiDeploymentRevision(t *testing.T) {
 	})
 }
 
+func TestUndeleteApiDeploymentRevision(t *testing.T) {
+	var (
+		ctx        = context.Background()
+		server     = serverWithPurgeWindow(t)
+		deployment = &rpc.ApiDeployment{
+			Name:        "projects/my-project/locations/global/apis/my-api/deployments/d",
+			EndpointUri: "https://first.example.com",
+		}
+	)
+	if err := seeder.SeedDeployments(ctx, server, deployment); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+	first, err := server.GetApiDeployment(ctx, &rpc.GetApiDeploymentRequest{Name: deployment.GetName()})
+	if err != nil {
+		t.Fatalf("Setup: GetApiDeployment(%q) returned error: %s", deployment.GetName(), err)
+	}
+	update := &rpc.UpdateApiDeploymentRequest{
+		ApiDeployment: &rpc.ApiDeployment{Name: deployment.GetName(), EndpointUri: "https://second.example.com"},
+	}
+	second, err := server.UpdateApiDeployment(ctx, update)
+	if err != nil {
+		t.Fatalf("Setup: UpdateApiDeployment(%+v) returned error: %s", update, err)
+	}
+	name := deployment.GetName() + "@" + second.GetRevisionId()
+	if _, err := server.DeleteApiDeploymentRevision(ctx, &rpc.DeleteApiDeploymentRevisionRequest{Name: name}); err != nil {
+		t.Fatalf("DeleteApiDeploymentRevision(%q) returned error: %s", name, err)
+	}
+
+	t.Run("ListApiDeployments", func(t *testing.T) {
+		req := &rpc.ListApiDeploymentsRequest{Parent: "projects/my-project/locations/global/apis/my-api", ShowDeleted: true}
+		got, err := server.ListApiDeployments(ctx, req)
+		if err != nil {
+			t.Fatalf("ListApiDeployments(%+v) returned error: %s", req, err)
+		}
+		if len(got.GetApiDeployments()) != 1 || got.GetApiDeployments()[0].GetRevisionId() != first.GetRevisionId() || got.GetApiDeployments()[0].GetDeleteTime() != nil {
+			t.Errorf("ListApiDeployments(%+v) returned %v, want the live revision %q", req, got.GetApiDeployments(), first.GetRevisionId())
+		}
+	})
+
+	if _, err := server.UndeleteApiDeployment(ctx, &rpc.UndeleteApiDeploymentRequest{Name: deployment.GetName()}); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("UndeleteApiDeployment(%q) returned status code %q, want %q: %v", deployment.GetName(), status.Code(err), codes.AlreadyExists, err)
+	}
+	if _, err := server.UndeleteApiDeployment(ctx, &rpc.UndeleteApiDeploymentRequest{Name: name}); err != nil {
+		t.Fatalf("UndeleteApiDeployment(%q) returned error: %s", name, err)
+	}
+	got, err := server.GetApiDeployment(ctx, &rpc.GetApiDeploymentRequest{Name: deployment.GetName()})
+	if err != nil {
+		t.Fatalf("GetApiDeployment(%q) returned error: %s", deployment.GetName(), err)
+	}
+	if got.GetRevisionId() != second.GetRevisionId() || got.GetEndpointUri() != "https://second.example.com" {
+		t.Errorf("GetApiDeployment(%q) returned %v, want the undeleted revision %q", deployment.GetName(), got, second.GetRevisionId())
+	}
+}
+
 func TestListApiDeploymentRevisions(t *testing.T) {
 	tests := []struct {
 		admin     bool
@@ -492,7 +546,7 @@ func TestListApiDeploymentRevisions(t *testing.T) {
 			opts := cmp.Options{
 				protocmp.Transform(),
 				protocmp.IgnoreFields(new(rpc.ListApiDeploymentRevisionsResponse), "next_page_token"),
-				protocmp.IgnoreFields(new(rpc.ApiDeployment), "name", "revision_id", "create_time", "revision_create_time", "revision_update_time"),
+				protocmp.IgnoreFields(new(rpc.ApiDeployment), "name", "revision_id", "create_time", "revision_create_time", "revision_update_time", "etag"),
 			}
 
 			if !cmp.Equal(test.want, got, opts) {
@@ -542,6 +596,7 @@ func TestListApiDeploymentRevisionsSequence(t *testing.T) {
 		RevisionCreateTime: firstRevision.GetRevisionCreateTime(),
 		RevisionUpdateTime: firstRevision.GetRevisionUpdateTime(),
 		RevisionId:         firstRevision.GetRevisionId(),
+		Etag:               firstRevision.GetEtag(),
 	}
 
 	updateReq := &rpc.UpdateApiDeploymentRequest{
@@ -563,6 +618,7 @@ func TestListApiDeploymentRevisionsSequence(t *testing.T) {
 		RevisionCreateTime: secondRevision.GetRevisionCreateTime(),
 		RevisionUpdateTime: secondRevision.GetRevisionUpdateTime(),
 		RevisionId:         secondRevision.GetRevisionId(),
+		Etag:               secondRevision.GetEtag(),
 	}
 
 	opts := cmp.Options{
@@ -651,13 +707,14 @@ func TestUpdateApiDeploymentRevisions(t *testing.T) {
 
 	opts := cmp.Options{
 		protocmp.Transform(),
-		protocmp.IgnoreFields(new(rpc.ApiDeployment), "revision_id", "create_time", "revision_create_time", "revision_update_time"),
+		protocmp.IgnoreFields(new(rpc.ApiDeployment), "revision_id", "create_time", "revision_create_time", "revision_update_time", "etag"),
 	}
 
 	t.Run("modify revision without content changes", func(t *testing.T) {
 		req := &rpc.UpdateApiDeploymentRequest{
 			ApiDeployment: &rpc.ApiDeployment{
-				Name: created.GetName(),
+				Name:        created.GetName(),
+				Description: "Modified First Revision",
 			},
 		}
 
@@ -673,6 +730,25 @@ func TestUpdateApiDeploymentRevisions(t *testing.T) {
 		if ct, ut := got.GetRevisionCreateTime().AsTime(), got.GetRevisionUpdateTime().AsTime(); !ct.Before(ut) {
 			t.Errorf("UpdateApiDeployment(%+v) returned unexpected timestamps, expected revision_update_time %v > revision_create_time %v", req, ut, ct)
 		}
+		created = got
+	})
+
+	t.Run("modify revision without changes", func(t *testing.T) {
+		req := &rpc.UpdateApiDeploymentRequest{
+			ApiDeployment: &rpc.ApiDeployment{
+				Name:        created.GetName(),
+				Description: created.GetDescription(),
+			},
+		}
+
+		got, err := server.UpdateApiDeployment(ctx, req)
+		if err != nil {
+			t.Fatalf("UpdateApiDeployment(%+v) returned error: %s", req, err)
+		}
+
+		if !cmp.Equal(created, got, protocmp.Transform()) {
+			t.Errorf("UpdateApiDeployment(%+v) changed the revision, expected no change (-want +got):\n%s", req, cmp.Diff(created, got, protocmp.Transform()))
+		}
 	})
 
 	t.Run("modify revision with api_spec_revision changes", func(t *testing.T) {
@@ -44,13 +44,18 @@ func (s *RegistryServer) CreateApiDeployment(ctx context.Context, req *rpc.Creat
 	}
 	var response *rpc.ApiDeployment
 	if err := s.runInTransaction(ctx, func(ctx context.Context, db *storage.Client) error {
+		db.LockDeployment(ctx, name)
 		var err error
 		response, err = s.createDeployment(ctx, db, name, req.GetApiDeployment())
-		return err
+		if err != nil {
+			return err
+		}
+		s.notify(ctx, rpc.Notification_CREATED, response.GetName())
+		s.audit(ctx, "CreateApiDeployment", response.GetName(), nil, response)
+		return nil
 	}); err != nil {
 		return nil, err
 	}
-	s.notify(ctx, rpc.Notification_CREATED, response.GetName())
 	return response, nil
 }
 
@@ -83,14 +88,91 @@ func (s *RegistryServer) DeleteApiDeployment(ctx context.Context, req *rpc.Delet
 	}
 
 	if err := s.runInTransaction(ctx, func(ctx context.Context, db *storage.Client) error {
-		return db.LockDeployments(ctx).DeleteDeployment(ctx, name, req.GetForce())
+		db.LockDeployment(ctx, name)
+		if req.GetEtag() != "" {
+			deployment, err := db.GetDeployment(ctx, name)
+			if err != nil {
+				return err
+			}
+			if err := checkEtag(req.GetName(), req.GetEtag(), deployment.Etag()); err != nil {
+				return err
+			}
+		}
+		if err := s.checkReferrers(ctx, db, name.String()); err != nil {
+			return err
+		}
+		if err := db.DeleteDeployment(ctx, name, req.GetForce()); err != nil {
+			return err
+		}
+		if err := s.purgeDeleted(ctx, db); err != nil {
+			return err
+		}
+		s.notify(ctx, rpc.Notification_DELETED, req.GetName())
+		s.audit(ctx, "DeleteApiDeployment", req.GetName(), nil, nil)
+		return nil
 	}); err != nil {
 		return nil, err
 	}
-	s.notify(ctx, rpc.Notification_DELETED, req.GetName())
 	return &emptypb.Empty{}, nil
 }
 
+// UndeleteApiDeployment handles the corresponding API request.
+func (s *RegistryServer) UndeleteApiDeployment(ctx context.Context, req *rpc.UndeleteApiDeploymentRequest) (*rpc.ApiDeployment, error) {
+	if name, err := names.ParseDeployment(req.GetName()); err == nil {
+		return s.undeleteApiDeployment(ctx, name)
+	} else if name, err := names.ParseDeploymentRevision(req.GetName()); err == nil {
+		return s.undeleteApiDeploymentRevision(ctx, name)
+	}
+
+	return nil, status.Errorf(codes.InvalidArgument, "invalid resource name %q, must be an API deployment or revision", req.GetName())
+}
+
+func (s *RegistryServer) undeleteApiDeployment(ctx context.Context, name names.Deployment) (*rpc.ApiDeployment, error) {
+	var response *rpc.ApiDeployment
+	if err := s.runInTransaction(ctx, func(ctx context.Context, db *storage.Client) error {
+		if err := db.LockDeployment(ctx, name).UndeleteDeployment(ctx, name); err != nil {
+			return err
+		}
+		deployment, err := db.GetDeployment(ctx, name)
+		if err != nil {
+			return err
+		}
+		response, err = deployment.BasicMessage(name.String())
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		s.notify(ctx, rpc.Notification_CREATED, response.GetName())
+		s.audit(ctx, "UndeleteApiDeployment", response.GetName(), nil, response)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s *RegistryServer) undeleteApiDeploymentRevision(ctx context.Context, name names.DeploymentRevision) (*rpc.ApiDeployment, error) {
+	var response *rpc.ApiDeployment
+	if err := s.runInTransaction(ctx, func(ctx context.Context, db *storage.Client) error {
+		if err := db.LockDeployment(ctx, name.Deployment()).UndeleteDeploymentRevision(ctx, name); err != nil {
+			return err
+		}
+		revision, err := db.GetDeploymentRevision(ctx, name)
+		if err != nil {
+			return err
+		}
+		response, err = revision.BasicMessage(name.String())
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		s.notify(ctx, rpc.Notification_CREATED, response.GetName())
+		s.audit(ctx, "UndeleteApiDeployment", response.GetName(), nil, response)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
 // GetApiDeployment handles the corresponding API request.
 func (s *RegistryServer) GetApiDeployment(ctx context.Context, req *rpc.GetApiDeploymentRequest) (*rpc.ApiDeployment, error) {
 	if name, err := names.ParseDeployment(req.GetName()); err == nil {
@@ -161,10 +243,11 @@ func (s *RegistryServer) ListApiDeployments(ctx context.Context, req *rpc.ListAp
 	}
 
 	listing, err := db.ListDeployments(ctx, parent, storage.PageOptions{
-		Size:   req.GetPageSize(),
-		Filter: req.GetFilter(),
-		Order:  req.GetOrderBy(),
-		Token:  req.GetPageToken(),
+		Size:        req.GetPageSize(),
+		Filter:      req.GetFilter(),
+		Order:       req.GetOrderBy(),
+		Token:       req.GetPageToken(),
+		ShowDeleted: req.GetShowDeleted(),
 	})
 	if err != nil {
 		return nil, err
@@ -202,31 +285,52 @@ func (s *RegistryServer) UpdateApiDeployment(ctx context.Context, req *rpc.Updat
 	}
 	var response *rpc.ApiDeployment
 	if err = s.runInTransaction(ctx, func(ctx context.Context, db *storage.Client) error {
+		db.LockDeployment(ctx, name)
+		var before *rpc.ApiDeployment
 		deployment, err := db.GetDeployment(ctx, name)
 		if err == nil {
+			if before, err = deployment.BasicMessage(name.String()); err != nil {
+				return err
+			}
+			if err := checkEtag(name.String(), req.GetApiDeployment().GetEtag(), deployment.Etag()); err != nil {
+				return err
+			}
 			// Apply the update to the deployment - possibly changing the revision ID.
 			maskExpansion := models.ExpandMask(req.GetApiDeployment(), req.GetUpdateMask())
 			if err := deployment.Update(req.GetApiDeployment(), maskExpansion); err != nil {
 				return status.Error(codes.Internal, err.Error())
 			}
+			after, err := deployment.BasicMessage(name.String())
+			if err != nil {
+				return err
+			}
+			// Updates that don't change the deployment return the current revision.
+			if unchanged(before, after) {
+				response = before
+				return nil
+			}
 			// Save the updated/current deployment. This creates a new revision or updates the previous one.
 			if err := db.SaveDeploymentRevision(ctx, deployment); err != nil {
 				return err
 			}
-			response, err = deployment.BasicMessage(name.String())
-			return err
+			response = after
 		} else if status.Code(err) == codes.NotFound && req.GetAllowMissing() {
+			if err := missingEtag(name.String(), req.GetApiDeployment().GetEtag()); err != nil {
+				return err
+			}
 			response, err = s.createDeployment(ctx, db, name, req.GetApiDeployment())
 			if status.Code(err) == codes.AlreadyExists {
 				err = status.Error(codes.Aborted, err.Error())
 			}
-			return err
-		} else {
+		}
+		if err != nil {
 			return err
 		}
+		s.notify(ctx, rpc.Notification_UPDATED, response.GetName())
+		s.audit(ctx, "UpdateApiDeployment", response.GetName(), before, response)
+		return nil
 	}); err != nil {
 		return nil, err
 	}
-	s.notify(ctx, rpc.Notification_UPDATED, response.GetName())
 	return response, nil
 }
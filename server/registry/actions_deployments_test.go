@@ -81,7 +81,7 @@ func TestCreateApiDeployment(t *testing.T) {
 
 			opts := cmp.Options{
 				protocmp.Transform(),
-				protocmp.IgnoreFields(new(rpc.ApiDeployment), "revision_id", "create_time", "revision_create_time", "revision_update_time"),
+				protocmp.IgnoreFields(new(rpc.ApiDeployment), "revision_id", "create_time", "revision_create_time", "revision_update_time", "etag"),
 			}
 
 			if !cmp.Equal(test.want, created, opts) {
@@ -327,7 +327,7 @@ func TestGetApiDeployment(t *testing.T) {
 
 			opts := cmp.Options{
 				protocmp.Transform(),
-				protocmp.IgnoreFields(new(rpc.ApiDeployment), "revision_id", "create_time", "revision_create_time", "revision_update_time"),
+				protocmp.IgnoreFields(new(rpc.ApiDeployment), "revision_id", "create_time", "revision_create_time", "revision_update_time", "etag"),
 			}
 
 			if !cmp.Equal(test.want, got, opts) {
@@ -680,7 +680,7 @@ func TestListApiDeployments(t *testing.T) {
 			opts := cmp.Options{
 				protocmp.Transform(),
 				protocmp.IgnoreFields(new(rpc.ListApiDeploymentsResponse), "next_page_token"),
-				protocmp.IgnoreFields(new(rpc.ApiDeployment), "revision_id", "create_time", "revision_create_time", "revision_update_time"),
+				protocmp.IgnoreFields(new(rpc.ApiDeployment), "revision_id", "create_time", "revision_create_time", "revision_update_time", "etag"),
 				test.extraOpts,
 			}
 
@@ -896,7 +896,7 @@ func TestListApiDeploymentsSequence(t *testing.T) {
 
 	opts := cmp.Options{
 		protocmp.Transform(),
-		protocmp.IgnoreFields(new(rpc.ApiDeployment), "revision_id", "create_time", "revision_create_time", "revision_update_time"),
+		protocmp.IgnoreFields(new(rpc.ApiDeployment), "revision_id", "create_time", "revision_create_time", "revision_update_time", "etag"),
 		cmpopts.SortSlices(func(a, b *rpc.ApiDeployment) bool {
 			return a.GetName() < b.GetName()
 		}),
@@ -1083,7 +1083,7 @@ func TestUpdateApiDeployment(t *testing.T) {
 
 			opts := cmp.Options{
 				protocmp.Transform(),
-				protocmp.IgnoreFields(new(rpc.ApiDeployment), "revision_id", "create_time", "revision_create_time", "revision_update_time"),
+				protocmp.IgnoreFields(new(rpc.ApiDeployment), "revision_id", "create_time", "revision_create_time", "revision_update_time", "etag"),
 			}
 
 			if !cmp.Equal(test.want, updated, opts) {
@@ -1208,7 +1208,8 @@ func TestUpdateApiDeploymentSequence(t *testing.T) {
 			desc: "update existing resource with allow_missing=true",
 			req: &rpc.UpdateApiDeploymentRequest{
 				ApiDeployment: &rpc.ApiDeployment{
-					Name: "projects/my-project/locations/global/apis/a/deployments/d",
+					Name:        "projects/my-project/locations/global/apis/a/deployments/d",
+					Description: "First update",
 				},
 				AllowMissing: true,
 			},
@@ -1218,7 +1219,8 @@ func TestUpdateApiDeploymentSequence(t *testing.T) {
 			desc: "update existing resource with allow_missing=false",
 			req: &rpc.UpdateApiDeploymentRequest{
 				ApiDeployment: &rpc.ApiDeployment{
-					Name: "projects/my-project/locations/global/apis/a/deployments/d",
+					Name:        "projects/my-project/locations/global/apis/a/deployments/d",
+					Description: "Second update",
 				},
 				AllowMissing: false,
 			},
@@ -1386,3 +1388,70 @@ func TestDeleteApiDeploymentCascading(t *testing.T) {
 		t.Errorf("GetArtifact(%q) returned status code %q, want %q: %s", artifact.GetName(), status.Code(err), codes.NotFound, err)
 	}
 }
+
+func TestUndeleteApiDeployment(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		server   = serverWithPurgeWindow(t)
+		artifact = &rpc.Artifact{
+			Name: "projects/my-project/locations/global/apis/my-api/deployments/my-deployment/artifacts/my-artifact",
+		}
+		name = "projects/my-project/locations/global/apis/my-api/deployments/my-deployment"
+	)
+	if err := seeder.SeedArtifacts(ctx, server, artifact); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+
+	if _, err := server.DeleteApiDeployment(ctx, &rpc.DeleteApiDeploymentRequest{Name: name, Force: true}); err != nil {
+		t.Fatalf("DeleteApiDeployment(%q) returned error: %s", name, err)
+	}
+	if _, err := server.GetArtifact(ctx, &rpc.GetArtifactRequest{Name: artifact.GetName()}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetArtifact(%q) returned status code %q, want %q: %v", artifact.GetName(), status.Code(err), codes.NotFound, err)
+	}
+
+	got, err := server.UndeleteApiDeployment(ctx, &rpc.UndeleteApiDeploymentRequest{Name: name})
+	if err != nil {
+		t.Fatalf("UndeleteApiDeployment(%q) returned error: %s", name, err)
+	}
+	if got.GetName() != name {
+		t.Errorf("UndeleteApiDeployment(%q) returned %q", name, got.GetName())
+	}
+	if _, err := server.GetArtifact(ctx, &rpc.GetArtifactRequest{Name: artifact.GetName()}); err != nil {
+		t.Errorf("GetArtifact(%q) returned error: %s", artifact.GetName(), err)
+	}
+}
+
+func TestCreateApiDeploymentAfterDelete(t *testing.T) {
+	var (
+		ctx        = context.Background()
+		server     = serverWithPurgeWindow(t)
+		deployment = &rpc.ApiDeployment{
+			Name:        "projects/my-project/locations/global/apis/my-api/deployments/my-deployment",
+			EndpointUri: "https://old.example.com",
+		}
+	)
+	if err := seeder.SeedDeployments(ctx, server, deployment); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+	if _, err := server.DeleteApiDeployment(ctx, &rpc.DeleteApiDeploymentRequest{Name: deployment.GetName()}); err != nil {
+		t.Fatalf("DeleteApiDeployment(%q) returned error: %s", deployment.GetName(), err)
+	}
+
+	req := &rpc.CreateApiDeploymentRequest{
+		Parent:          "projects/my-project/locations/global/apis/my-api",
+		ApiDeploymentId: "my-deployment",
+		ApiDeployment:   &rpc.ApiDeployment{EndpointUri: "https://new.example.com"},
+	}
+	if _, err := server.CreateApiDeployment(ctx, req); err != nil {
+		t.Fatalf("CreateApiDeployment(%+v) returned error: %s", req, err)
+	}
+	revisions := &rpc.ListApiDeploymentRevisionsRequest{Name: deployment.GetName() + "@-"}
+	if got, err := server.ListApiDeploymentRevisions(ctx, revisions); err != nil {
+		t.Fatalf("ListApiDeploymentRevisions(%+v) returned error: %s", revisions, err)
+	} else if len(got.GetApiDeployments()) != 1 {
+		t.Errorf("ListApiDeploymentRevisions(%+v) returned %d revisions, want 1", revisions, len(got.GetApiDeployments()))
+	}
+	if _, err := server.UndeleteApiDeployment(ctx, &rpc.UndeleteApiDeploymentRequest{Name: deployment.GetName()}); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("UndeleteApiDeployment(%q) returned status code %q, want %q: %v", deployment.GetName(), status.Code(err), codes.AlreadyExists, err)
+	}
+}
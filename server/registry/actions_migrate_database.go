@@ -16,43 +16,98 @@ package registry
 
 import (
 	"context"
+	"fmt"
+	"sync/atomic"
 
 	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/storage"
 	"google.golang.org/genproto/googleapis/longrunning"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
-	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/proto"
 )
 
 // MigrateDatabase handles the corresponding API request.
 func (s *RegistryServer) MigrateDatabase(ctx context.Context, req *rpc.MigrateDatabaseRequest) (*longrunning.Operation, error) {
-	if req.Kind != "" && req.Kind != "auto" {
+	target := storage.LatestSchemaVersion()
+	switch req.Kind {
+	case "", "auto":
+	case "version":
+		target = int(req.Version)
+		if target < 0 || target > storage.LatestSchemaVersion() {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown schema version %d, the latest is %d", target, storage.LatestSchemaVersion())
+		}
+	default:
 		return nil, status.Errorf(codes.InvalidArgument, "unsupported migration kind %q", req.Kind)
 	}
-	db, err := s.getStorageClient(ctx)
-	if err != nil {
-		return nil, status.Error(codes.Unavailable, err.Error())
+	// Migrations change the database, so they don't use the read-only client.
+	db := s.storageClient
+	if db == nil {
+		return nil, status.Error(codes.Unavailable, "no storageClient")
 	}
 
-	err = db.Migrate(ctx)
-	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+	if !atomic.CompareAndSwapInt32(&s.migrating, 0, 1) {
+		return nil, status.Error(codes.Aborted, "a database migration is already running")
 	}
-
-	metadata, err := anypb.New(&rpc.MigrateDatabaseMetadata{})
+	current, err := db.SchemaVersion(ctx)
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		atomic.StoreInt32(&s.migrating, 0)
+		return nil, err
+	}
+	metadata := &rpc.MigrateDatabaseMetadata{
+		StartVersion:   int32(current),
+		TargetVersion:  int32(target),
+		CurrentVersion: int32(current),
+		TotalSteps:     int32(abs(target - current)),
 	}
-	response, err := anypb.New(&rpc.MigrateDatabaseResponse{
-		Message: "OK",
+	op, err := s.operations.submit(ctx, "MigrateDatabase", metadata, func(ctx context.Context, update func(proto.Message)) (proto.Message, error) {
+		defer atomic.StoreInt32(&s.migrating, 0)
+		steps, err := db.Migrate(ctx, storage.MigrateOptions{
+			Version: target,
+			DryRun:  req.ValidateOnly,
+			Progress: func(step storage.Migration, completed, total int) {
+				m := proto.Clone(metadata).(*rpc.MigrateDatabaseMetadata)
+				m.CurrentVersion = int32(step.Version)
+				if step.Revert {
+					m.CurrentVersion--
+				}
+				m.CompletedSteps = int32(completed)
+				m.TotalSteps = int32(total)
+				update(m)
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		response := &rpc.MigrateDatabaseResponse{
+			Message: "OK",
+			Version: int32(target),
+		}
+		if req.ValidateOnly {
+			response.Message = fmt.Sprintf("Validated migration from version %d to %d", current, target)
+			response.Version = int32(current)
+		}
+		for _, step := range steps {
+			response.Steps = append(response.Steps, &rpc.MigrateDatabaseResponse_Step{
+				Version:     int32(step.Version),
+				Description: step.Description,
+				Revert:      step.Revert,
+				Statements:  step.Statements,
+			})
+		}
+		return response, nil
 	})
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		atomic.StoreInt32(&s.migrating, 0)
+		return nil, err
+	}
+	return op, nil
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
 	}
-	return &longrunning.Operation{
-		Name:     "migrate",
-		Metadata: metadata,
-		Done:     true,
-		Result:   &longrunning.Operation_Response{Response: response},
-	}, nil
+	return n
 }
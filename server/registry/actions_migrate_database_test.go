@@ -17,6 +17,7 @@ package registry
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/apigee/registry/rpc"
 	"github.com/google/go-cmp/cmp"
@@ -24,9 +25,48 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/testing/protocmp"
-	"google.golang.org/protobuf/types/known/anypb"
 )
 
+// waitForOperation polls an operation until it is done.
+func waitForOperation(ctx context.Context, t *testing.T, server TestServer, op *longrunning.Operation) *longrunning.Operation {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for !op.GetDone() {
+		if time.Now().After(deadline) {
+			t.Fatalf("Operation %s did not finish", op.GetName())
+		}
+		time.Sleep(10 * time.Millisecond)
+		var err error
+		op, err = server.GetOperation(ctx, &longrunning.GetOperationRequest{Name: op.GetName()})
+		if err != nil {
+			t.Fatalf("GetOperation(%q) returned error: %s", op.GetName(), err)
+		}
+	}
+	return op
+}
+
+// migrateDatabase runs a migration and returns its response and final metadata.
+func migrateDatabase(ctx context.Context, t *testing.T, server TestServer, req *rpc.MigrateDatabaseRequest) (*rpc.MigrateDatabaseResponse, *rpc.MigrateDatabaseMetadata) {
+	t.Helper()
+	op, err := server.MigrateDatabase(ctx, req)
+	if err != nil {
+		t.Fatalf("MigrateDatabase(%+v) returned error: %s", req, err)
+	}
+	op = waitForOperation(ctx, t, server, op)
+	if err := op.GetError(); err != nil {
+		t.Fatalf("MigrateDatabase(%+v) failed: %s", req, err)
+	}
+	response := &rpc.MigrateDatabaseResponse{}
+	if err := op.GetResponse().UnmarshalTo(response); err != nil {
+		t.Fatalf("MigrateDatabase(%+v) returned unexpected response: %s", req, err)
+	}
+	metadata := &rpc.MigrateDatabaseMetadata{}
+	if err := op.GetMetadata().UnmarshalTo(metadata); err != nil {
+		t.Fatalf("MigrateDatabase(%+v) returned unexpected metadata: %s", req, err)
+	}
+	return response, metadata
+}
+
 func TestMigrateDatabase(t *testing.T) {
 	if adminServiceUnavailable() {
 		t.Skip(testRequiresAdminService)
@@ -34,35 +74,60 @@ func TestMigrateDatabase(t *testing.T) {
 	ctx := context.Background()
 	server := defaultTestServer(t)
 
+	// New databases have the latest schema, so there is nothing to migrate.
 	req := &rpc.MigrateDatabaseRequest{}
-	metadata, err := anypb.New(&rpc.MigrateDatabaseMetadata{})
-	if err != nil {
-		t.Fatalf("MigrateDatabase(%+v) test failed to build expected response metadata: %s", req, err)
-	}
-	response, err := anypb.New(&rpc.MigrateDatabaseResponse{
-		Message: "OK",
-	})
-	if err != nil {
-		t.Fatalf("MigrateDatabase(%+v) test failed to build expected response message: %s", req, err)
+	response, metadata := migrateDatabase(ctx, t, server, req)
+	latest := response.GetVersion()
+	if latest == 0 || len(response.GetSteps()) != 0 {
+		t.Fatalf("MigrateDatabase(%+v) returned unexpected response %+v", req, response)
 	}
-	want := &longrunning.Operation{
-		Name:     "migrate",
-		Done:     true,
-		Metadata: metadata,
-		Result:   &longrunning.Operation_Response{Response: response},
+	want := &rpc.MigrateDatabaseMetadata{StartVersion: latest, TargetVersion: latest, CurrentVersion: latest}
+	if diff := cmp.Diff(want, metadata, protocmp.Transform()); diff != "" {
+		t.Errorf("MigrateDatabase(%+v) returned unexpected metadata (-want +got):\n%s", req, diff)
 	}
 
-	got, err := server.MigrateDatabase(ctx, req)
-	if err != nil {
-		t.Fatalf("MigrateDatabase(%+v) returned error: %s", req, err)
+	// Validation lists the statements of the migration without running them.
+	req = &rpc.MigrateDatabaseRequest{Kind: "version", Version: 0, ValidateOnly: true}
+	response, _ = migrateDatabase(ctx, t, server, req)
+	if response.GetVersion() != latest || len(response.GetSteps()) != int(latest) {
+		t.Fatalf("MigrateDatabase(%+v) returned unexpected response %+v", req, response)
+	}
+	if step := response.Steps[len(response.Steps)-1]; step.GetVersion() != 1 || !step.GetRevert() || len(step.GetStatements()) == 0 {
+		t.Errorf("MigrateDatabase(%+v) returned unexpected last step %+v", req, step)
+	}
+	if _, err := server.CreateProject(ctx, &rpc.CreateProjectRequest{ProjectId: "p", Project: &rpc.Project{}}); err != nil {
+		t.Fatalf("CreateProject() after validating a migration returned error: %s", err)
 	}
 
-	opts := cmp.Options{
-		protocmp.Transform(),
+	// Reverting the latest migration and migrating again restores the schema version.
+	req = &rpc.MigrateDatabaseRequest{Kind: "version", Version: latest - 1}
+	response, metadata = migrateDatabase(ctx, t, server, req)
+	want = &rpc.MigrateDatabaseMetadata{StartVersion: latest, TargetVersion: latest - 1, CurrentVersion: latest - 1, CompletedSteps: 1, TotalSteps: 1}
+	if diff := cmp.Diff(want, metadata, protocmp.Transform()); diff != "" {
+		t.Errorf("MigrateDatabase(%+v) returned unexpected metadata (-want +got):\n%s", req, diff)
+	}
+	if response.GetVersion() != latest-1 {
+		t.Errorf("MigrateDatabase(%+v) returned version %d, want %d", req, response.GetVersion(), latest-1)
+	}
+	req = &rpc.MigrateDatabaseRequest{Kind: "auto"}
+	response, _ = migrateDatabase(ctx, t, server, req)
+	if response.GetVersion() != latest || len(response.GetSteps()) != 1 || response.Steps[0].GetRevert() {
+		t.Errorf("MigrateDatabase(%+v) returned unexpected response %+v", req, response)
 	}
+	if _, err := server.GetProject(ctx, &rpc.GetProjectRequest{Name: "projects/p"}); err != nil {
+		t.Errorf("GetProject() after migrations returned error: %s", err)
+	}
+}
 
-	if !cmp.Equal(want, got, opts) {
-		t.Errorf("MigrateDatabase(%+v) returned unexpected diff (-want +got):\n%s", req, cmp.Diff(want, got, opts))
+func TestGetOperationNotFound(t *testing.T) {
+	if adminServiceUnavailable() {
+		t.Skip(testRequiresAdminService)
+	}
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	req := &longrunning.GetOperationRequest{Name: "operations/missing"}
+	if _, err := server.GetOperation(ctx, req); status.Code(err) != codes.NotFound {
+		t.Errorf("GetOperation(%+v) returned status code %q, want %q: %v", req, status.Code(err), codes.NotFound, err)
 	}
 }
 
@@ -98,12 +163,27 @@ func TestMigrateDatabaseKinds(t *testing.T) {
 			req:  &rpc.MigrateDatabaseRequest{Kind: "invalid"},
 			want: codes.InvalidArgument,
 		},
+		{
+			desc: "migrate with kind version",
+			req:  &rpc.MigrateDatabaseRequest{Kind: "version", Version: 1, ValidateOnly: true},
+			want: codes.OK,
+		},
+		{
+			desc: "migrate with unknown version",
+			req:  &rpc.MigrateDatabaseRequest{Kind: "version", Version: 1000},
+			want: codes.InvalidArgument,
+		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.desc, func(t *testing.T) {
-			if _, err := server.MigrateDatabase(ctx, test.req); status.Code(err) != test.want {
-				t.Errorf("MigrateDatabase(%+v) returned status code %q, want %q: %v", test.req, status.Code(err), test.want, err)
+			op, err := server.MigrateDatabase(ctx, test.req)
+			if status.Code(err) != test.want {
+				t.Fatalf("MigrateDatabase(%+v) returned status code %q, want %q: %v", test.req, status.Code(err), test.want, err)
+			}
+			// Only one migration runs at a time.
+			if err == nil {
+				waitForOperation(ctx, t, server, op)
 			}
 		})
 	}
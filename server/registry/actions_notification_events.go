@@ -0,0 +1,77 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"time"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListNotificationEvents handles the corresponding API request.
+func (s *RegistryServer) ListNotificationEvents(ctx context.Context, req *rpc.ListNotificationEventsRequest) (*rpc.ListNotificationEventsResponse, error) {
+	db, err := s.getStorageClient(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+
+	if req.GetPageSize() < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid page_size %d: must not be negative", req.GetPageSize())
+	} else if req.GetPageSize() > 1000 {
+		req.PageSize = 1000
+	} else if req.GetPageSize() == 0 {
+		req.PageSize = 50
+	}
+
+	listing, err := db.ListNotificationEvents(ctx, storage.PageOptions{
+		Size:   req.GetPageSize(),
+		Filter: req.GetFilter(),
+		Order:  req.GetOrderBy(),
+		Token:  req.GetPageToken(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := &rpc.ListNotificationEventsResponse{
+		NotificationEvents: make([]*rpc.NotificationEvent, len(listing.NotificationEvents)),
+		NextPageToken:      listing.Token,
+	}
+
+	for i, event := range listing.NotificationEvents {
+		response.NotificationEvents[i] = event.Message()
+	}
+
+	return response, nil
+}
+
+// ReplayNotificationEvents handles the corresponding API request.
+func (s *RegistryServer) ReplayNotificationEvents(ctx context.Context, req *rpc.ReplayNotificationEventsRequest) (*rpc.ReplayNotificationEventsResponse, error) {
+	var count int64
+	if err := s.runInTransaction(ctx, func(ctx context.Context, db *storage.Client) (err error) {
+		count, err = db.ReplayNotificationEvents(ctx, req.GetFilter(), time.Now())
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	if count > 0 && s.dispatcher != nil {
+		s.dispatcher.wake()
+	}
+	return &rpc.ReplayNotificationEventsResponse{ReplayedCount: count}, nil
+}
@@ -39,13 +39,18 @@ func (s *RegistryServer) CreateProject(ctx context.Context, req *rpc.CreateProje
 	}
 	var response *rpc.Project
 	if err := s.runInTransaction(ctx, func(ctx context.Context, db *storage.Client) error {
+		db.LockProject(ctx, name)
 		var err error
 		response, err = s.createProject(ctx, db, name, req.GetProject())
-		return err
+		if err != nil {
+			return err
+		}
+		s.notify(ctx, rpc.Notification_CREATED, response.GetName())
+		s.audit(ctx, "CreateProject", response.GetName(), nil, response)
+		return nil
 	}); err != nil {
 		return nil, err
 	}
-	s.notify(ctx, rpc.Notification_CREATED, response.GetName())
 	return response, nil
 }
 
@@ -67,14 +72,51 @@ func (s *RegistryServer) DeleteProject(ctx context.Context, req *rpc.DeleteProje
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 	if err := s.runInTransaction(ctx, func(ctx context.Context, db *storage.Client) error {
-		return db.LockProjects(ctx).DeleteProject(ctx, name, req.GetForce())
+		if err := s.checkReferrers(ctx, db.LockProject(ctx, name), name.String()); err != nil {
+			return err
+		}
+		if err := db.DeleteProject(ctx, name, req.GetForce()); err != nil {
+			return err
+		}
+		if err := s.purgeDeleted(ctx, db); err != nil {
+			return err
+		}
+		s.notify(ctx, rpc.Notification_DELETED, req.GetName())
+		s.audit(ctx, "DeleteProject", req.GetName(), nil, nil)
+		return nil
 	}); err != nil {
 		return nil, err
 	}
-	s.notify(ctx, rpc.Notification_DELETED, req.GetName())
 	return &emptypb.Empty{}, nil
 }
 
+// UndeleteProject handles the corresponding API request.
+func (s *RegistryServer) UndeleteProject(ctx context.Context, req *rpc.UndeleteProjectRequest) (*rpc.Project, error) {
+	// Project name must be valid.
+	name, err := names.ParseProject(req.GetName())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var response *rpc.Project
+	if err := s.runInTransaction(ctx, func(ctx context.Context, db *storage.Client) error {
+		if err := db.LockProject(ctx, name).UndeleteProject(ctx, name); err != nil {
+			return err
+		}
+		project, err := db.GetProject(ctx, name)
+		if err != nil {
+			return err
+		}
+		response = project.Message()
+		s.notify(ctx, rpc.Notification_CREATED, response.GetName())
+		s.audit(ctx, "UndeleteProject", response.GetName(), nil, response)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
 // GetProject handles the corresponding API request.
 func (s *RegistryServer) GetProject(ctx context.Context, req *rpc.GetProjectRequest) (*rpc.Project, error) {
 	db, err := s.getStorageClient(ctx)
@@ -111,10 +153,11 @@ func (s *RegistryServer) ListProjects(ctx context.Context, req *rpc.ListProjects
 	}
 
 	listing, err := db.ListProjects(ctx, storage.PageOptions{
-		Size:   req.GetPageSize(),
-		Filter: req.GetFilter(),
-		Order:  req.GetOrderBy(),
-		Token:  req.GetPageToken(),
+		Size:        req.GetPageSize(),
+		Filter:      req.GetFilter(),
+		Order:       req.GetOrderBy(),
+		Token:       req.GetPageToken(),
+		ShowDeleted: req.GetShowDeleted(),
 	})
 	if err != nil {
 		return nil, err
@@ -149,24 +192,27 @@ func (s *RegistryServer) UpdateProject(ctx context.Context, req *rpc.UpdateProje
 	}
 	var response *rpc.Project
 	if err := s.runInTransaction(ctx, func(ctx context.Context, db *storage.Client) error {
-		db.LockProjects(ctx)
+		db.LockProject(ctx, name)
+		var before *rpc.Project
 		project, err := db.GetProject(ctx, name)
 		if err == nil {
+			before = project.Message()
 			project.Update(req.GetProject(), models.ExpandMask(req.GetProject(), req.GetUpdateMask()))
 			if err := db.SaveProject(ctx, project); err != nil {
 				return err
 			}
 			response = project.Message()
-			return nil
 		} else if status.Code(err) == codes.NotFound && req.GetAllowMissing() {
 			response, err = s.createProject(ctx, db, name, req.GetProject())
-			return err
-		} else {
+		}
+		if err != nil {
 			return err
 		}
+		s.notify(ctx, rpc.Notification_UPDATED, response.GetName())
+		s.audit(ctx, "UpdateProject", response.GetName(), before, response)
+		return nil
 	}); err != nil {
 		return nil, err
 	}
-	s.notify(ctx, rpc.Notification_UPDATED, response.GetName())
 	return response, nil
 }
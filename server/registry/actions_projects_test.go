@@ -1206,3 +1206,158 @@ func TestDeleteProjectCascading(t *testing.T) {
 		t.Errorf("GetArtifact(%q) returned status code %q, want %q: %s", artifact.GetName(), status.Code(err), codes.NotFound, err)
 	}
 }
+
+func TestUndeleteProject(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		server = serverWithPurgeWindow(t)
+		spec   = &rpc.ApiSpec{
+			Name: "projects/my-project/locations/global/apis/my-api/versions/v1/specs/my-spec",
+		}
+		artifact = &rpc.Artifact{
+			Name: "projects/my-project/locations/global/artifacts/my-artifact",
+		}
+	)
+	if err := seeder.SeedRegistry(ctx, server, spec, artifact); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+
+	name := "projects/my-project"
+	if _, err := server.DeleteProject(ctx, &rpc.DeleteProjectRequest{Name: name, Force: true}); err != nil {
+		t.Fatalf("DeleteProject(%q) returned error: %s", name, err)
+	}
+	if _, err := server.GetApiSpec(ctx, &rpc.GetApiSpecRequest{Name: spec.GetName()}); status.Code(err) != codes.NotFound {
+		t.Fatalf("GetApiSpec(%q) returned status code %q, want %q: %s", spec.GetName(), status.Code(err), codes.NotFound, err)
+	}
+
+	t.Run("ListProjects", func(t *testing.T) {
+		got, err := server.ListProjects(ctx, &rpc.ListProjectsRequest{})
+		if err != nil {
+			t.Fatalf("ListProjects() returned error: %s", err)
+		}
+		if len(got.GetProjects()) != 0 {
+			t.Errorf("ListProjects() returned deleted projects: %v", got.GetProjects())
+		}
+
+		got, err = server.ListProjects(ctx, &rpc.ListProjectsRequest{ShowDeleted: true})
+		if err != nil {
+			t.Fatalf("ListProjects(show_deleted) returned error: %s", err)
+		}
+		if len(got.GetProjects()) != 1 || got.GetProjects()[0].GetDeleteTime() == nil {
+			t.Errorf("ListProjects(show_deleted) returned %v, want %q with a delete_time", got.GetProjects(), name)
+		}
+	})
+
+	got, err := server.UndeleteProject(ctx, &rpc.UndeleteProjectRequest{Name: name})
+	if err != nil {
+		t.Fatalf("UndeleteProject(%q) returned error: %s", name, err)
+	}
+	if got.GetName() != name || got.GetDeleteTime() != nil {
+		t.Errorf("UndeleteProject(%q) returned %v, want an undeleted project", name, got)
+	}
+	if _, err := server.GetApiSpec(ctx, &rpc.GetApiSpecRequest{Name: spec.GetName()}); err != nil {
+		t.Errorf("GetApiSpec(%q) returned error: %s", spec.GetName(), err)
+	}
+	if _, err := server.GetArtifact(ctx, &rpc.GetArtifactRequest{Name: artifact.GetName()}); err != nil {
+		t.Errorf("GetArtifact(%q) returned error: %s", artifact.GetName(), err)
+	}
+}
+
+func TestUndeleteProjectResponseCodes(t *testing.T) {
+	tests := []struct {
+		desc   string
+		delete bool
+		req    *rpc.UndeleteProjectRequest
+		want   codes.Code
+	}{
+		{
+			desc: "resource not found",
+			req: &rpc.UndeleteProjectRequest{
+				Name: "projects/doesnt-exist",
+			},
+			want: codes.NotFound,
+		},
+		{
+			desc: "resource not deleted",
+			req: &rpc.UndeleteProjectRequest{
+				Name: "projects/my-project",
+			},
+			want: codes.AlreadyExists,
+		},
+		{
+			desc: "invalid name",
+			req: &rpc.UndeleteProjectRequest{
+				Name: "invalid",
+			},
+			want: codes.InvalidArgument,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			ctx := context.Background()
+			server := serverWithPurgeWindow(t)
+			if err := seeder.SeedProjects(ctx, server, &rpc.Project{Name: "projects/my-project"}); err != nil {
+				t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+			}
+
+			if _, err := server.UndeleteProject(ctx, test.req); status.Code(err) != test.want {
+				t.Errorf("UndeleteProject(%+v) returned status code %q, want %q: %v", test.req, status.Code(err), test.want, err)
+			}
+		})
+	}
+}
+
+func TestCreateProjectAfterDelete(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		server = serverWithPurgeWindow(t)
+		spec   = &rpc.ApiSpec{
+			Name: "projects/my-project/locations/global/apis/my-api/versions/v1/specs/my-spec",
+		}
+	)
+	if err := seeder.SeedSpecs(ctx, server, spec); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+	name := "projects/my-project"
+	if _, err := server.DeleteProject(ctx, &rpc.DeleteProjectRequest{Name: name, Force: true}); err != nil {
+		t.Fatalf("DeleteProject(%q) returned error: %s", name, err)
+	}
+
+	req := &rpc.CreateProjectRequest{ProjectId: "my-project", Project: &rpc.Project{}}
+	if _, err := server.CreateProject(ctx, req); err != nil {
+		t.Fatalf("CreateProject(%+v) returned error: %s", req, err)
+	}
+	list := &rpc.ListApisRequest{Parent: name + "/locations/global", ShowDeleted: true}
+	if got, err := server.ListApis(ctx, list); err != nil {
+		t.Fatalf("ListApis(%+v) returned error: %s", list, err)
+	} else if len(got.GetApis()) != 0 {
+		t.Errorf("ListApis(%+v) returned %v, want the deleted APIs to be purged", list, got.GetApis())
+	}
+	if _, err := server.UndeleteProject(ctx, &rpc.UndeleteProjectRequest{Name: name}); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("UndeleteProject(%q) returned status code %q, want %q: %v", name, status.Code(err), codes.AlreadyExists, err)
+	}
+}
+
+func TestDeleteProjectWithoutPurgeWindow(t *testing.T) {
+	if adminServiceUnavailable() {
+		t.Skip(testRequiresAdminService)
+	}
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	project := &rpc.Project{Name: "projects/my-project"}
+	if err := seeder.SeedProjects(ctx, server, project); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+
+	if _, err := server.DeleteProject(ctx, &rpc.DeleteProjectRequest{Name: project.GetName()}); err != nil {
+		t.Fatalf("DeleteProject(%q) returned error: %s", project.GetName(), err)
+	}
+	if _, err := server.UndeleteProject(ctx, &rpc.UndeleteProjectRequest{Name: project.GetName()}); status.Code(err) != codes.NotFound {
+		t.Errorf("UndeleteProject(%q) returned status code %q, want %q: %v", project.GetName(), status.Code(err), codes.NotFound, err)
+	}
+	// Deleted projects are purged immediately, so their names can be reused.
+	if err := seeder.SeedProjects(ctx, server, project); err != nil {
+		t.Errorf("CreateProject(%q) returned error: %s", project.GetName(), err)
+	}
+}
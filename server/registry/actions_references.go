@@ -0,0 +1,132 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/storage"
+	"github.com/apigee/registry/server/registry/names"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxReportedReferrers is the largest number of referrers described in the error
+// returned when a delete is blocked by references.
+const maxReportedReferrers = 10
+
+// ListReferences handles the corresponding API request.
+func (s *RegistryServer) ListReferences(ctx context.Context, req *rpc.ListReferencesRequest) (*rpc.ListReferencesResponse, error) {
+	db, err := s.getStorageClient(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	opts, err := referencePageOptions(req.GetName(), req.GetPageSize(), req.GetPageToken(), req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	listing, err := db.ListReferences(ctx, req.GetName(), opts)
+	if err != nil {
+		return nil, err
+	}
+	return &rpc.ListReferencesResponse{
+		References:    referenceMessages(listing),
+		NextPageToken: listing.Token,
+	}, nil
+}
+
+// ListReferrers handles the corresponding API request.
+func (s *RegistryServer) ListReferrers(ctx context.Context, req *rpc.ListReferrersRequest) (*rpc.ListReferrersResponse, error) {
+	db, err := s.getStorageClient(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	opts, err := referencePageOptions(req.GetName(), req.GetPageSize(), req.GetPageToken(), req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	listing, err := db.ListReferrers(ctx, req.GetName(), opts)
+	if err != nil {
+		return nil, err
+	}
+	return &rpc.ListReferrersResponse{
+		References:    referenceMessages(listing),
+		NextPageToken: listing.Token,
+	}, nil
+}
+
+func referencePageOptions(name string, size int32, token, filter string) (storage.PageOptions, error) {
+	if _, err := names.ParseResourceEntity(name); err != nil {
+		if _, err := names.ParseArtifactRevision(name); err != nil {
+			return storage.PageOptions{}, status.Errorf(codes.InvalidArgument, "invalid name %q: must be the name of a resource", name)
+		}
+	}
+	if size < 0 {
+		return storage.PageOptions{}, status.Errorf(codes.InvalidArgument, "invalid page_size %d: must not be negative", size)
+	} else if size > 1000 {
+		size = 1000
+	} else if size == 0 {
+		size = 50
+	}
+	return storage.PageOptions{
+		Size:   size,
+		Token:  token,
+		Filter: filter,
+		// order_by is disallowed by AIP-162: https://google.aip.dev/162
+	}, nil
+}
+
+func referenceMessages(listing storage.ReferenceList) []*rpc.Reference {
+	messages := make([]*rpc.Reference, len(listing.References))
+	for i := range listing.References {
+		messages[i] = listing.References[i].Message()
+	}
+	return messages
+}
+
+// checkReferrers returns an error if the server protects references and a resource
+// is referenced by resources that would remain after it is deleted.
+func (s *RegistryServer) checkReferrers(ctx context.Context, db *storage.Client, name string) error {
+	if !s.protectReferences {
+		return nil
+	}
+	refs, err := db.ExternalReferrers(ctx, name, maxReportedReferrers+1)
+	if err != nil || len(refs) == 0 {
+		return err
+	}
+	message := fmt.Sprintf("%s is referenced by %s in %s", name, refs[0].Source, refs[0].Field)
+	if len(refs) > 1 {
+		message += " and other resources"
+	}
+	st := status.New(codes.FailedPrecondition, message)
+	failure := &errdetails.PreconditionFailure{}
+	for i, ref := range refs {
+		if i == maxReportedReferrers {
+			break
+		}
+		failure.Violations = append(failure.Violations, &errdetails.PreconditionFailure_Violation{
+			Type:        "REFERENCE",
+			Subject:     ref.Source,
+			Description: fmt.Sprintf("%s refers to %s", ref.Field, ref.Target),
+		})
+	}
+	if detailed, err := st.WithDetails(failure); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
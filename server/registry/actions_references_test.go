@@ -0,0 +1,238 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/test/seeder"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+const (
+	testReferencesApi        = "projects/my-project/locations/global/apis/a"
+	testReferencesVersion    = testReferencesApi + "/versions/v1"
+	testReferencesSpec       = testReferencesVersion + "/specs/s"
+	testReferencesDeployment = testReferencesApi + "/deployments/d"
+	testReferencesArtifact   = "projects/my-project/locations/global/artifacts/refs"
+)
+
+// seedReferences creates resources that refer to each other: a version with a
+// primary spec, a deployment of that spec and an artifact that lists the API.
+func seedReferences(ctx context.Context, t *testing.T, server TestServer) {
+	t.Helper()
+	list, err := proto.Marshal(&rpc.ReferenceList{
+		References: []*rpc.ReferenceList_Reference{
+			{Id: "api", Resource: testReferencesApi},
+			{Id: "docs", Uri: "https://example.com/docs"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Setup: failed to marshal reference list: %s", err)
+	}
+	if err := seeder.SeedRegistry(ctx, server,
+		&rpc.ApiSpec{Name: testReferencesSpec},
+		&rpc.ApiDeployment{Name: testReferencesDeployment, ApiSpecRevision: testReferencesSpec},
+		&rpc.Artifact{
+			Name:     testReferencesArtifact,
+			MimeType: "application/octet-stream;type=google.cloud.apigeeregistry.v1.apihub.ReferenceList",
+			Contents: list,
+		},
+	); err != nil {
+		t.Fatalf("Setup: failed to seed registry: %s", err)
+	}
+	if _, err := server.UpdateApiVersion(ctx, &rpc.UpdateApiVersionRequest{
+		ApiVersion: &rpc.ApiVersion{Name: testReferencesVersion, PrimarySpec: testReferencesSpec},
+	}); err != nil {
+		t.Fatalf("Setup: failed to update version: %s", err)
+	}
+}
+
+func TestListReferences(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	seedReferences(ctx, t, server)
+
+	tests := []struct {
+		desc string
+		list func() ([]*rpc.Reference, error)
+		want []*rpc.Reference
+	}{
+		{
+			desc: "references from version",
+			list: func() ([]*rpc.Reference, error) {
+				resp, err := server.ListReferences(ctx, &rpc.ListReferencesRequest{Name: testReferencesVersion})
+				return resp.GetReferences(), err
+			},
+			want: []*rpc.Reference{
+				{Source: testReferencesVersion, Field: "primary_spec", Target: testReferencesSpec},
+			},
+		},
+		{
+			desc: "references from artifact",
+			list: func() ([]*rpc.Reference, error) {
+				resp, err := server.ListReferences(ctx, &rpc.ListReferencesRequest{Name: testReferencesArtifact})
+				return resp.GetReferences(), err
+			},
+			want: []*rpc.Reference{
+				{Source: testReferencesArtifact, Field: "references.resource", Target: testReferencesApi},
+			},
+		},
+		{
+			desc: "referrers of spec",
+			list: func() ([]*rpc.Reference, error) {
+				resp, err := server.ListReferrers(ctx, &rpc.ListReferrersRequest{Name: testReferencesSpec})
+				return resp.GetReferences(), err
+			},
+			want: []*rpc.Reference{
+				{Source: testReferencesDeployment, Field: "api_spec_revision", Target: testReferencesSpec},
+				{Source: testReferencesVersion, Field: "primary_spec", Target: testReferencesSpec},
+			},
+		},
+		{
+			desc: "referrers of api include referrers of children",
+			list: func() ([]*rpc.Reference, error) {
+				resp, err := server.ListReferrers(ctx, &rpc.ListReferrersRequest{Name: testReferencesApi})
+				return resp.GetReferences(), err
+			},
+			want: []*rpc.Reference{
+				{Source: testReferencesDeployment, Field: "api_spec_revision", Target: testReferencesSpec},
+				{Source: testReferencesVersion, Field: "primary_spec", Target: testReferencesSpec},
+				{Source: testReferencesArtifact, Field: "references.resource", Target: testReferencesApi},
+			},
+		},
+		{
+			desc: "filtered referrers of api",
+			list: func() ([]*rpc.Reference, error) {
+				resp, err := server.ListReferrers(ctx, &rpc.ListReferrersRequest{Name: testReferencesApi, Filter: "field == 'primary_spec'"})
+				return resp.GetReferences(), err
+			},
+			want: []*rpc.Reference{
+				{Source: testReferencesVersion, Field: "primary_spec", Target: testReferencesSpec},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			got, err := test.list()
+			if err != nil {
+				t.Fatalf("returned error: %s", err)
+			}
+			if diff := cmp.Diff(test.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("returned unexpected references (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	// References are removed with the resources that contain them.
+	if _, err := server.DeleteApiDeployment(ctx, &rpc.DeleteApiDeploymentRequest{Name: testReferencesDeployment}); err != nil {
+		t.Fatalf("DeleteApiDeployment(%q) returned error: %s", testReferencesDeployment, err)
+	}
+	resp, err := server.ListReferrers(ctx, &rpc.ListReferrersRequest{Name: testReferencesSpec})
+	if err != nil {
+		t.Fatalf("ListReferrers(%q) returned error: %s", testReferencesSpec, err)
+	}
+	want := []*rpc.Reference{
+		{Source: testReferencesVersion, Field: "primary_spec", Target: testReferencesSpec},
+	}
+	if diff := cmp.Diff(want, resp.GetReferences(), protocmp.Transform()); diff != "" {
+		t.Errorf("ListReferrers(%q) after delete returned unexpected references (-want +got):\n%s", testReferencesSpec, diff)
+	}
+}
+
+func TestListReferencesPagination(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	seedReferences(ctx, t, server)
+
+	var got []*rpc.Reference
+	req := &rpc.ListReferrersRequest{Name: testReferencesApi, PageSize: 1}
+	for {
+		resp, err := server.ListReferrers(ctx, req)
+		if err != nil {
+			t.Fatalf("ListReferrers(%+v) returned error: %s", req, err)
+		}
+		got = append(got, resp.GetReferences()...)
+		if resp.GetNextPageToken() == "" {
+			break
+		}
+		req.PageToken = resp.GetNextPageToken()
+	}
+	if len(got) != 3 {
+		t.Errorf("ListReferrers(%q) returned %d references in pages, want 3", testReferencesApi, len(got))
+	}
+}
+
+func TestListReferencesErrors(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+
+	if _, err := server.ListReferences(ctx, &rpc.ListReferencesRequest{Name: "invalid"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ListReferences() with invalid name returned status code %q, want %q", status.Code(err), codes.InvalidArgument)
+	}
+	if _, err := server.ListReferrers(ctx, &rpc.ListReferrersRequest{Name: testReferencesApi, PageSize: -1}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ListReferrers() with negative page size returned status code %q, want %q", status.Code(err), codes.InvalidArgument)
+	}
+	if _, err := server.ListReferrers(ctx, &rpc.ListReferrersRequest{Name: testReferencesApi, Filter: "unknown == 'x'"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ListReferrers() with invalid filter returned status code %q, want %q", status.Code(err), codes.InvalidArgument)
+	}
+}
+
+func TestProtectReferences(t *testing.T) {
+	ctx := context.Background()
+	server, err := New(Config{
+		Database:          "sqlite3",
+		DBConfig:          fmt.Sprintf("%s/registry.db", t.TempDir()),
+		ProtectReferences: true,
+	})
+	if err != nil {
+		t.Fatalf("Setup: failed to get server with SQLite: %s", err)
+	}
+	t.Cleanup(server.Close)
+	seedReferences(ctx, t, server)
+
+	// The spec is referenced by the deployment.
+	_, err = server.DeleteApiSpec(ctx, &rpc.DeleteApiSpecRequest{Name: testReferencesSpec, Force: true})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("DeleteApiSpec(%q) returned status code %q, want %q", testReferencesSpec, status.Code(err), codes.FailedPrecondition)
+	}
+	// The API is referenced by the artifact.
+	_, err = server.DeleteApi(ctx, &rpc.DeleteApiRequest{Name: testReferencesApi, Force: true})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("DeleteApi(%q) returned status code %q, want %q", testReferencesApi, status.Code(err), codes.FailedPrecondition)
+	}
+
+	// References between children of a deleted resource don't block the delete.
+	if _, err := server.DeleteArtifact(ctx, &rpc.DeleteArtifactRequest{Name: testReferencesArtifact}); err != nil {
+		t.Fatalf("DeleteArtifact(%q) returned error: %s", testReferencesArtifact, err)
+	}
+	if _, err := server.DeleteApi(ctx, &rpc.DeleteApiRequest{Name: testReferencesApi, Force: true}); err != nil {
+		t.Fatalf("DeleteApi(%q) returned error: %s", testReferencesApi, err)
+	}
+	resp, err := server.ListReferrers(ctx, &rpc.ListReferrersRequest{Name: testReferencesApi})
+	if err != nil {
+		t.Fatalf("ListReferrers(%q) returned error: %s", testReferencesApi, err)
+	}
+	if n := len(resp.GetReferences()); n != 0 {
+		t.Errorf("ListReferrers(%q) after delete returned %d references, want none", testReferencesApi, n)
+	}
+}
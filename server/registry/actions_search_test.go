@@ -0,0 +1,342 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/test/seeder"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	testSearchParent = "projects/my-project/locations/global"
+	testSearchApi    = testSearchParent + "/apis/petstore"
+	testSearchSpec   = testSearchApi + "/versions/v1/specs/openapi"
+)
+
+// seedSearch creates resources with searchable text in their metadata and in
+// the contents of a spec.
+func seedSearch(ctx context.Context, t *testing.T, server TestServer) {
+	t.Helper()
+	if err := seeder.SeedRegistry(ctx, server,
+		&rpc.Api{
+			Name:        testSearchApi,
+			DisplayName: "Pet Store",
+			Description: "Sells pets to pet owners.",
+			Labels:      map[string]string{"team": "animals"},
+		},
+		&rpc.Api{
+			Name:        testSearchParent + "/apis/bookstore",
+			DisplayName: "Book Store",
+			Description: "Sells books, including books about pets.",
+		},
+		&rpc.ApiSpec{
+			Name:     testSearchSpec,
+			Filename: "openapi.yaml",
+			MimeType: "application/x.openapi+gzip;version=3",
+			Contents: gzipped(t, "openapi: 3.0.0\npaths:\n  /kennels:\n    get:\n      operationId: listKennels\n"),
+		},
+		&rpc.ApiDeployment{
+			Name:        testSearchApi + "/deployments/prod",
+			DisplayName: "Production",
+			Annotations: map[string]string{"region": "antarctica"},
+		},
+		&rpc.Api{Name: "projects/other-project/locations/global/apis/pets"},
+	); err != nil {
+		t.Fatalf("Setup: failed to seed registry: %s", err)
+	}
+}
+
+// requireSearch skips a test if the database of a server can't be searched.
+// SQLite databases can only be searched by servers built with the sqlite_fts5 tag.
+func requireSearch(ctx context.Context, t *testing.T, server TestServer) {
+	t.Helper()
+	_, err := server.SearchResources(ctx, &rpc.SearchResourcesRequest{Parent: testSearchParent, Query: "pets"})
+	if status.Code(err) == codes.FailedPrecondition {
+		t.Skipf("Search is unavailable: %s", err)
+	}
+}
+
+func gzipped(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := gZippedBytes([]byte(s))
+	if err != nil {
+		t.Fatalf("Setup: failed to compress contents: %s", err)
+	}
+	return b
+}
+
+func searchNames(resp *rpc.SearchResourcesResponse) []string {
+	names := make([]string, len(resp.GetResults()))
+	for i, r := range resp.GetResults() {
+		names[i] = r.GetName()
+	}
+	return names
+}
+
+func TestSearchResources(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	requireSearch(ctx, t, server)
+	seedSearch(ctx, t, server)
+
+	tests := []struct {
+		desc   string
+		parent string
+		query  string
+		want   []string
+		// Some results are ranked differently by each database.
+		unordered bool
+	}{
+		{
+			desc:  "display names rank above descriptions",
+			query: "pet",
+			want:  []string{testSearchApi, testSearchParent + "/apis/bookstore"},
+		},
+		{
+			desc:  "all terms must match",
+			query: "books pets",
+			want:  []string{testSearchParent + "/apis/bookstore"},
+		},
+		{
+			desc:  "labels",
+			query: "animals",
+			want:  []string{testSearchApi},
+		},
+		{
+			desc:  "annotations",
+			query: "Antarctica",
+			want:  []string{testSearchApi + "/deployments/prod"},
+		},
+		{
+			desc:  "spec contents",
+			query: "listKennels",
+			want:  []string{testSearchSpec},
+		},
+		{
+			desc:  "no matches",
+			query: "unicorns",
+			want:  []string{},
+		},
+		{
+			desc:      "all projects",
+			parent:    "projects/-/locations/global",
+			query:     "pets",
+			want:      []string{testSearchApi, "projects/other-project/locations/global/apis/pets", testSearchParent + "/apis/bookstore"},
+			unordered: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			parent := test.parent
+			if parent == "" {
+				parent = testSearchParent
+			}
+			resp, err := server.SearchResources(ctx, &rpc.SearchResourcesRequest{Parent: parent, Query: test.query})
+			if err != nil {
+				t.Fatalf("SearchResources(%q) returned error: %s", test.query, err)
+			}
+			var opts []cmp.Option
+			if test.unordered {
+				opts = append(opts, cmpopts.SortSlices(func(a, b string) bool { return a < b }))
+			}
+			if diff := cmp.Diff(test.want, searchNames(resp), opts...); diff != "" {
+				t.Errorf("SearchResources(%q) returned unexpected results (-want +got):\n%s", test.query, diff)
+			}
+		})
+	}
+}
+
+func TestSearchResourcesResults(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	requireSearch(ctx, t, server)
+	seedSearch(ctx, t, server)
+
+	resp, err := server.SearchResources(ctx, &rpc.SearchResourcesRequest{Parent: testSearchParent, Query: "kennels"})
+	if err != nil {
+		t.Fatalf("SearchResources() returned error: %s", err)
+	}
+	if len(resp.GetResults()) != 1 {
+		t.Fatalf("SearchResources() returned %d results, want 1", len(resp.GetResults()))
+	}
+	result := resp.GetResults()[0]
+	if result.GetDisplayName() != "openapi.yaml" {
+		t.Errorf("SearchResources() returned display name %q, want %q", result.GetDisplayName(), "openapi.yaml")
+	}
+	if result.GetScore() <= 0 {
+		t.Errorf("SearchResources() returned score %f, want a positive score", result.GetScore())
+	}
+	if !strings.Contains(result.GetSnippet(), "<b>kennels</b>") {
+		t.Errorf("SearchResources() returned snippet %q, want highlighted match", result.GetSnippet())
+	}
+}
+
+func TestSearchResourcesEscapesSnippets(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	requireSearch(ctx, t, server)
+	seedSearch(ctx, t, server)
+
+	if _, err := server.UpdateApiSpec(ctx, &rpc.UpdateApiSpecRequest{
+		ApiSpec: &rpc.ApiSpec{Name: testSearchSpec, Contents: gzipped(t, "description: <script>alert('kennels')</script>\n")},
+	}); err != nil {
+		t.Fatalf("UpdateApiSpec() returned error: %s", err)
+	}
+	resp, err := server.SearchResources(ctx, &rpc.SearchResourcesRequest{Parent: testSearchParent, Query: "kennels"})
+	if err != nil {
+		t.Fatalf("SearchResources() returned error: %s", err)
+	}
+	if len(resp.GetResults()) != 1 {
+		t.Fatalf("SearchResources() returned %d results, want 1", len(resp.GetResults()))
+	}
+	want := "&lt;script&gt;alert(&#39;<b>kennels</b>&#39;)&lt;/script&gt;"
+	if got := resp.GetResults()[0].GetSnippet(); !strings.Contains(got, want) {
+		t.Errorf("SearchResources() returned snippet %q, want it to contain %q", got, want)
+	}
+}
+
+func TestSearchResourcesChanges(t *testing.T) {
+	ctx := context.Background()
+	server := serverWithPurgeWindow(t)
+	requireSearch(ctx, t, server)
+	seedSearch(ctx, t, server)
+
+	search := func(query string) []string {
+		t.Helper()
+		resp, err := server.SearchResources(ctx, &rpc.SearchResourcesRequest{Parent: testSearchParent, Query: query})
+		if err != nil {
+			t.Fatalf("SearchResources(%q) returned error: %s", query, err)
+		}
+		return searchNames(resp)
+	}
+
+	// Updates replace the indexed text.
+	if _, err := server.UpdateApi(ctx, &rpc.UpdateApiRequest{
+		Api: &rpc.Api{Name: testSearchApi, DisplayName: "Kennel Club"},
+	}); err != nil {
+		t.Fatalf("UpdateApi() returned error: %s", err)
+	}
+	if diff := cmp.Diff([]string{testSearchApi, testSearchSpec}, search("kennel")); diff != "" {
+		t.Errorf("SearchResources() after update returned unexpected results (-want +got):\n%s", diff)
+	}
+
+	// New spec revisions replace the indexed contents.
+	if _, err := server.UpdateApiSpec(ctx, &rpc.UpdateApiSpecRequest{
+		ApiSpec: &rpc.ApiSpec{Name: testSearchSpec, Contents: gzipped(t, "openapi: 3.0.0\npaths:\n  /cages: {}\n")},
+	}); err != nil {
+		t.Fatalf("UpdateApiSpec() returned error: %s", err)
+	}
+	if diff := cmp.Diff([]string{testSearchSpec}, search("cages")); diff != "" {
+		t.Errorf("SearchResources() after new revision returned unexpected results (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{testSearchApi}, search("kennel")); diff != "" {
+		t.Errorf("SearchResources() after new revision returned unexpected results (-want +got):\n%s", diff)
+	}
+
+	// Deleted resources aren't found, and undeleted resources are found again.
+	if _, err := server.DeleteApi(ctx, &rpc.DeleteApiRequest{Name: testSearchApi, Force: true}); err != nil {
+		t.Fatalf("DeleteApi() returned error: %s", err)
+	}
+	if diff := cmp.Diff([]string{}, search("kennel cages")); diff != "" {
+		t.Errorf("SearchResources() after delete returned unexpected results (-want +got):\n%s", diff)
+	}
+	if _, err := server.UndeleteApi(ctx, &rpc.UndeleteApiRequest{Name: testSearchApi}); err != nil {
+		t.Fatalf("UndeleteApi() returned error: %s", err)
+	}
+	if diff := cmp.Diff([]string{testSearchApi}, search("kennel")); diff != "" {
+		t.Errorf("SearchResources() after undelete returned unexpected results (-want +got):\n%s", diff)
+	}
+}
+
+func TestSearchResourcesPagination(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	requireSearch(ctx, t, server)
+	seedSearch(ctx, t, server)
+
+	var got []string
+	req := &rpc.SearchResourcesRequest{Parent: testSearchParent, Query: "pet", PageSize: 1}
+	for {
+		resp, err := server.SearchResources(ctx, req)
+		if err != nil {
+			t.Fatalf("SearchResources(%+v) returned error: %s", req, err)
+		}
+		got = append(got, searchNames(resp)...)
+		if resp.GetNextPageToken() == "" {
+			break
+		}
+		req.PageToken = resp.GetNextPageToken()
+	}
+	want := []string{testSearchApi, testSearchParent + "/apis/bookstore"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("SearchResources() returned unexpected results in pages (-want +got):\n%s", diff)
+	}
+
+	// Page tokens can't be used with other queries.
+	resp, err := server.SearchResources(ctx, &rpc.SearchResourcesRequest{Parent: testSearchParent, Query: "pet", PageSize: 1})
+	if err != nil {
+		t.Fatalf("SearchResources() returned error: %s", err)
+	}
+	req = &rpc.SearchResourcesRequest{Parent: testSearchParent, Query: "books", PageToken: resp.GetNextPageToken()}
+	if _, err := server.SearchResources(ctx, req); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("SearchResources(%+v) returned status code %q, want %q", req, status.Code(err), codes.InvalidArgument)
+	}
+}
+
+func TestSearchResourcesErrors(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+
+	tests := []struct {
+		desc string
+		req  *rpc.SearchResourcesRequest
+	}{
+		{
+			desc: "invalid parent",
+			req:  &rpc.SearchResourcesRequest{Parent: "invalid", Query: "pets"},
+		},
+		{
+			desc: "empty query",
+			req:  &rpc.SearchResourcesRequest{Parent: testSearchParent},
+		},
+		{
+			desc: "query without terms",
+			req:  &rpc.SearchResourcesRequest{Parent: testSearchParent, Query: "* -"},
+		},
+		{
+			desc: "negative page size",
+			req:  &rpc.SearchResourcesRequest{Parent: testSearchParent, Query: "pets", PageSize: -1},
+		},
+		{
+			desc: "invalid page token",
+			req:  &rpc.SearchResourcesRequest{Parent: testSearchParent, Query: "pets", PageToken: "invalid"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			if _, err := server.SearchResources(ctx, test.req); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("SearchResources(%+v) returned status code %q, want %q: %v", test.req, status.Code(err), codes.InvalidArgument, err)
+			}
+		})
+	}
+}
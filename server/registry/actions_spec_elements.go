@@ -0,0 +1,83 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/storage"
+	"github.com/apigee/registry/server/registry/names"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListSpecElements handles the corresponding API request.
+func (s *RegistryServer) ListSpecElements(ctx context.Context, req *rpc.ListSpecElementsRequest) (*rpc.ListSpecElementsResponse, error) {
+	db, err := s.getStorageClient(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+
+	if req.GetPageSize() < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid page_size %d: must not be negative", req.GetPageSize())
+	} else if req.GetPageSize() > 1000 {
+		req.PageSize = 1000
+	} else if req.GetPageSize() == 0 {
+		req.PageSize = 50
+	}
+
+	parent, err := specElementsParent(req.GetParent())
+	if err != nil {
+		return nil, err
+	}
+
+	listing, err := db.ListSpecElements(ctx, parent, storage.PageOptions{
+		Size:   req.GetPageSize(),
+		Token:  req.GetPageToken(),
+		Filter: req.GetFilter(),
+		// order_by is disallowed by AIP-162: https://google.aip.dev/162
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := &rpc.ListSpecElementsResponse{
+		Elements:      make([]*rpc.SpecElement, len(listing.Elements)),
+		NextPageToken: listing.Token,
+	}
+	for i := range listing.Elements {
+		response.Elements[i] = listing.Elements[i].Message()
+	}
+	return response, nil
+}
+
+// specElementsParent returns the specs whose elements are listed under a parent,
+// which may be a project location, an api, a version or a spec.
+func specElementsParent(name string) (names.Spec, error) {
+	if spec, err := names.ParseSpec(name); err == nil {
+		return spec, nil
+	}
+	if version, err := names.ParseVersion(name); err == nil {
+		return version.Spec("-"), nil
+	}
+	if api, err := names.ParseApi(name); err == nil {
+		return api.Version("-").Spec("-"), nil
+	}
+	if project, err := names.ParseProjectWithLocation(name); err == nil {
+		return project.Api("-").Version("-").Spec("-"), nil
+	}
+	return names.Spec{}, status.Errorf(codes.InvalidArgument, "invalid parent %q: must be a project location, api, version or spec", name)
+}
@@ -0,0 +1,212 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/test/seeder"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+const (
+	testElementsApi     = "projects/my-project/locations/global/apis/a"
+	testElementsOrders  = testElementsApi + "/versions/v1/specs/orders"
+	testElementsLibrary = testElementsApi + "/versions/v2/specs/library"
+)
+
+// seedSpecElements creates two specs with index artifacts and returns the names
+// of their current revisions.
+func seedSpecElements(ctx context.Context, t *testing.T, server TestServer) (string, string) {
+	t.Helper()
+	orders := &rpc.SpecIndex{
+		Operations: []*rpc.SpecIndex_Operation{
+			{Id: "listOrders", Method: "GET", Path: "/v1/orders"},
+			{Id: "createOrder", Method: "POST", Path: "/v1/orders"},
+		},
+		Schemas: []string{"Order"},
+	}
+	library := &rpc.SpecIndex{
+		Operations: []*rpc.SpecIndex_Operation{
+			{Id: "library.Library.CreateBook", Method: "POST", Path: "/v2/books", Service: "library.Library"},
+		},
+		Messages: []string{"library.Book"},
+		Services: []string{"library.Library"},
+	}
+	if err := seeder.SeedRegistry(ctx, server,
+		&rpc.ApiSpec{Name: testElementsOrders, Contents: []byte("orders")},
+		&rpc.ApiSpec{Name: testElementsLibrary, Contents: []byte("library")},
+		specIndexArtifact(t, testElementsOrders, orders),
+		specIndexArtifact(t, testElementsLibrary, library),
+	); err != nil {
+		t.Fatalf("Setup: failed to seed registry: %s", err)
+	}
+	return specRevisionName(ctx, t, server, testElementsOrders), specRevisionName(ctx, t, server, testElementsLibrary)
+}
+
+func specIndexArtifact(t *testing.T, spec string, index *rpc.SpecIndex) *rpc.Artifact {
+	t.Helper()
+	contents, err := proto.Marshal(index)
+	if err != nil {
+		t.Fatalf("Setup: failed to marshal spec index: %s", err)
+	}
+	return &rpc.Artifact{
+		Name:     spec + "/artifacts/index",
+		MimeType: "application/octet-stream;type=google.cloud.apigeeregistry.v1.apihub.SpecIndex",
+		Contents: contents,
+	}
+}
+
+func specRevisionName(ctx context.Context, t *testing.T, server TestServer, spec string) string {
+	t.Helper()
+	s, err := server.GetApiSpec(ctx, &rpc.GetApiSpecRequest{Name: spec})
+	if err != nil {
+		t.Fatalf("Setup: GetApiSpec(%q) returned error: %s", spec, err)
+	}
+	return spec + "@" + s.GetRevisionId()
+}
+
+func TestListSpecElements(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	orders, library := seedSpecElements(ctx, t, server)
+
+	tests := []struct {
+		desc string
+		req  *rpc.ListSpecElementsRequest
+		want []*rpc.SpecElement
+	}{
+		{
+			desc: "elements of spec",
+			req:  &rpc.ListSpecElementsRequest{Parent: testElementsOrders},
+			want: []*rpc.SpecElement{
+				{Spec: orders, Kind: "operation", Name: "listOrders", Method: "GET", Path: "/v1/orders"},
+				{Spec: orders, Kind: "operation", Name: "createOrder", Method: "POST", Path: "/v1/orders"},
+				{Spec: orders, Kind: "schema", Name: "Order"},
+			},
+		},
+		{
+			desc: "operations by method and path",
+			req: &rpc.ListSpecElementsRequest{
+				Parent: "projects/my-project/locations/global",
+				Filter: "kind == 'operation' && method == 'POST' && path.startsWith('/v2/')",
+			},
+			want: []*rpc.SpecElement{
+				{Spec: library, Kind: "operation", Name: "library.Library.CreateBook", Method: "POST", Path: "/v2/books", Service: "library.Library"},
+			},
+		},
+		{
+			desc: "messages by name",
+			req:  &rpc.ListSpecElementsRequest{Parent: testElementsApi, Filter: "kind == 'message' && name == 'library.Book'"},
+			want: []*rpc.SpecElement{
+				{Spec: library, Kind: "message", Name: "library.Book"},
+			},
+		},
+		{
+			desc: "services of version",
+			req:  &rpc.ListSpecElementsRequest{Parent: testElementsApi + "/versions/v2", Filter: "kind == 'service'"},
+			want: []*rpc.SpecElement{
+				{Spec: library, Kind: "service", Name: "library.Library"},
+			},
+		},
+		{
+			desc: "no matches",
+			req:  &rpc.ListSpecElementsRequest{Parent: testElementsApi + "/versions/v1", Filter: "kind == 'service'"},
+			want: []*rpc.SpecElement{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			resp, err := server.ListSpecElements(ctx, test.req)
+			if err != nil {
+				t.Fatalf("ListSpecElements(%+v) returned error: %s", test.req, err)
+			}
+			if diff := cmp.Diff(test.want, resp.GetElements(), protocmp.Transform(), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("ListSpecElements(%+v) returned unexpected elements (-want +got):\n%s", test.req, diff)
+			}
+		})
+	}
+
+	// Elements of earlier revisions aren't listed once a spec is revised.
+	if _, err := server.UpdateApiSpec(ctx, &rpc.UpdateApiSpecRequest{
+		ApiSpec: &rpc.ApiSpec{Name: testElementsOrders, Contents: []byte("revised orders")},
+	}); err != nil {
+		t.Fatalf("UpdateApiSpec(%q) returned error: %s", testElementsOrders, err)
+	}
+	resp, err := server.ListSpecElements(ctx, &rpc.ListSpecElementsRequest{Parent: testElementsOrders})
+	if err != nil {
+		t.Fatalf("ListSpecElements(%q) returned error: %s", testElementsOrders, err)
+	}
+	if n := len(resp.GetElements()); n != 0 {
+		t.Errorf("ListSpecElements(%q) after revision returned %d elements, want none", testElementsOrders, n)
+	}
+
+	// Elements are removed with the artifacts that list them.
+	if _, err := server.DeleteArtifact(ctx, &rpc.DeleteArtifactRequest{Name: testElementsLibrary + "/artifacts/index"}); err != nil {
+		t.Fatalf("DeleteArtifact() returned error: %s", err)
+	}
+	resp, err = server.ListSpecElements(ctx, &rpc.ListSpecElementsRequest{Parent: testElementsLibrary})
+	if err != nil {
+		t.Fatalf("ListSpecElements(%q) returned error: %s", testElementsLibrary, err)
+	}
+	if n := len(resp.GetElements()); n != 0 {
+		t.Errorf("ListSpecElements(%q) after delete returned %d elements, want none", testElementsLibrary, n)
+	}
+}
+
+func TestListSpecElementsPagination(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	seedSpecElements(ctx, t, server)
+
+	var got []*rpc.SpecElement
+	req := &rpc.ListSpecElementsRequest{Parent: testElementsApi, PageSize: 2}
+	for {
+		resp, err := server.ListSpecElements(ctx, req)
+		if err != nil {
+			t.Fatalf("ListSpecElements(%+v) returned error: %s", req, err)
+		}
+		got = append(got, resp.GetElements()...)
+		if resp.GetNextPageToken() == "" {
+			break
+		}
+		req.PageToken = resp.GetNextPageToken()
+	}
+	if len(got) != 6 {
+		t.Errorf("ListSpecElements(%q) returned %d elements in pages, want 6", testElementsApi, len(got))
+	}
+}
+
+func TestListSpecElementsErrors(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+
+	if _, err := server.ListSpecElements(ctx, &rpc.ListSpecElementsRequest{Parent: "invalid"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ListSpecElements() with invalid parent returned status code %q, want %q", status.Code(err), codes.InvalidArgument)
+	}
+	if _, err := server.ListSpecElements(ctx, &rpc.ListSpecElementsRequest{Parent: testElementsApi, PageSize: -1}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ListSpecElements() with negative page size returned status code %q, want %q", status.Code(err), codes.InvalidArgument)
+	}
+	if _, err := server.ListSpecElements(ctx, &rpc.ListSpecElementsRequest{Parent: testElementsApi, Filter: "unknown == 'x'"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ListSpecElements() with invalid filter returned status code %q, want %q", status.Code(err), codes.InvalidArgument)
+	}
+}
@@ -79,9 +79,17 @@ func (s *RegistryServer) DeleteApiSpecRevision(ctx context.Context, req *rpc.Del
 	}
 	var response *rpc.ApiSpec
 	if err := s.runInTransaction(ctx, func(ctx context.Context, db *storage.Client) error {
+		if err := s.checkReferrers(ctx, db.LockSpec(ctx, name.Spec()), name.String()); err != nil {
+			return err
+		}
 		if err := db.DeleteSpecRevision(ctx, name); err != nil {
 			return err
 		}
+		if err := s.purgeDeleted(ctx, db); err != nil {
+			return err
+		}
+		s.notify(ctx, rpc.Notification_DELETED, name.String())
+		s.audit(ctx, "DeleteApiSpecRevision", name.String(), nil, nil)
 		return nil
 	}); err != nil {
 		return nil, err
@@ -92,7 +100,6 @@ func (s *RegistryServer) DeleteApiSpecRevision(ctx context.Context, req *rpc.Del
 		// The get will fail if we are deleting the only revision.
 		return nil, status.Error(codes.Internal, err.Error())
 	}
-	s.notify(ctx, rpc.Notification_DELETED, name.String())
 	return response, nil
 }
 
@@ -116,10 +123,9 @@ func (s *RegistryServer) TagApiSpecRevision(ctx context.Context, req *rpc.TagApi
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 	var response *rpc.ApiSpec
-	var revisionName string
 	if err := s.runInTransaction(ctx, func(ctx context.Context, db *storage.Client) error {
 		// The revision to be tagged must exist.
-		revision, err := db.GetSpecRevision(ctx, name)
+		revision, err := db.LockSpec(ctx, name.Spec()).GetSpecRevision(ctx, name)
 		if err != nil {
 			return err
 		}
@@ -137,12 +143,12 @@ func (s *RegistryServer) TagApiSpecRevision(ctx context.Context, req *rpc.TagApi
 		if err != nil {
 			return err
 		}
-		revisionName = name.String()
+		s.notify(ctx, rpc.Notification_UPDATED, name.String())
+		s.audit(ctx, "TagApiSpecRevision", tag.String(), nil, nil)
 		return nil
 	}); err != nil {
 		return nil, err
 	}
-	s.notify(ctx, rpc.Notification_UPDATED, revisionName)
 	return response, nil
 }
 
@@ -158,11 +164,10 @@ func (s *RegistryServer) RollbackApiSpec(ctx context.Context, req *rpc.RollbackA
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 	var response *rpc.ApiSpec
-	var revisionName string
 	if err := s.runInTransaction(ctx, func(ctx context.Context, db *storage.Client) error {
 		// Get the target spec revision to use as a base for the new rollback revision.
 		name := parent.Revision(req.GetRevisionId())
-		target, err := db.GetSpecRevision(ctx, name)
+		target, err := db.LockSpec(ctx, parent).GetSpecRevision(ctx, name)
 		if err != nil {
 			return err
 		}
@@ -184,11 +189,11 @@ func (s *RegistryServer) RollbackApiSpec(ctx context.Context, req *rpc.RollbackA
 		if err != nil {
 			return err
 		}
-		revisionName = rollback.RevisionName()
+		s.notify(ctx, rpc.Notification_CREATED, rollback.RevisionName())
+		s.audit(ctx, "RollbackApiSpec", rollback.RevisionName(), nil, response)
 		return nil
 	}); err != nil {
 		return nil, err
 	}
-	s.notify(ctx, rpc.Notification_CREATED, revisionName)
 	return response, nil
 }
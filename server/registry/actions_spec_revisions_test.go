@@ -258,7 +258,7 @@ func TestRollbackApiSpec(t *testing.T) {
 
 	opts := cmp.Options{
 		protocmp.Transform(),
-		protocmp.IgnoreFields(new(rpc.ApiSpec), "revision_id", "revision_create_time", "revision_update_time"),
+		protocmp.IgnoreFields(new(rpc.ApiSpec), "revision_id", "revision_create_time", "revision_update_time", "etag"),
 	}
 
 	if !cmp.Equal(want, rollback, opts) {
@@ -332,6 +332,64 @@ func TestDeleteApiSpecRevision(t *testing.T) {
 	})
 }
 
+func TestUndeleteApiSpecRevision(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		server = serverWithPurgeWindow(t)
+		spec   = &rpc.ApiSpec{
+			Name:     "projects/my-project/locations/global/apis/my-api/versions/v1/specs/my-spec",
+			Contents: []byte("first"),
+		}
+	)
+	if err := seeder.SeedSpecs(ctx, server, spec); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+	first, err := server.GetApiSpec(ctx, &rpc.GetApiSpecRequest{Name: spec.GetName()})
+	if err != nil {
+		t.Fatalf("Setup: GetApiSpec(%q) returned error: %s", spec.GetName(), err)
+	}
+	update := &rpc.UpdateApiSpecRequest{
+		ApiSpec: &rpc.ApiSpec{Name: spec.GetName(), Contents: []byte("second")},
+	}
+	second, err := server.UpdateApiSpec(ctx, update)
+	if err != nil {
+		t.Fatalf("Setup: UpdateApiSpec(%+v) returned error: %s", update, err)
+	}
+	name := spec.GetName() + "@" + second.GetRevisionId()
+	if _, err := server.DeleteApiSpecRevision(ctx, &rpc.DeleteApiSpecRevisionRequest{Name: name}); err != nil {
+		t.Fatalf("DeleteApiSpecRevision(%q) returned error: %s", name, err)
+	}
+
+	t.Run("ListApiSpecs", func(t *testing.T) {
+		req := &rpc.ListApiSpecsRequest{Parent: "projects/my-project/locations/global/apis/my-api/versions/v1", ShowDeleted: true}
+		got, err := server.ListApiSpecs(ctx, req)
+		if err != nil {
+			t.Fatalf("ListApiSpecs(%+v) returned error: %s", req, err)
+		}
+		if len(got.GetApiSpecs()) != 1 || got.GetApiSpecs()[0].GetRevisionId() != first.GetRevisionId() || got.GetApiSpecs()[0].GetDeleteTime() != nil {
+			t.Errorf("ListApiSpecs(%+v) returned %v, want the live revision %q", req, got.GetApiSpecs(), first.GetRevisionId())
+		}
+	})
+
+	if _, err := server.UndeleteApiSpec(ctx, &rpc.UndeleteApiSpecRequest{Name: spec.GetName()}); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("UndeleteApiSpec(%q) returned status code %q, want %q: %v", spec.GetName(), status.Code(err), codes.AlreadyExists, err)
+	}
+	got, err := server.UndeleteApiSpec(ctx, &rpc.UndeleteApiSpecRequest{Name: name})
+	if err != nil {
+		t.Fatalf("UndeleteApiSpec(%q) returned error: %s", name, err)
+	}
+	if got.GetRevisionId() != second.GetRevisionId() {
+		t.Errorf("UndeleteApiSpec(%q) returned revision %q, want %q", name, got.GetRevisionId(), second.GetRevisionId())
+	}
+	contents, err := server.GetApiSpecContents(ctx, &rpc.GetApiSpecContentsRequest{Name: spec.GetName()})
+	if err != nil {
+		t.Fatalf("GetApiSpecContents(%q) returned error: %s", spec.GetName(), err)
+	}
+	if string(contents.GetData()) != "second" {
+		t.Errorf("GetApiSpecContents(%q) returned %q, want %q", spec.GetName(), contents.GetData(), "second")
+	}
+}
+
 func TestListApiSpecRevisions(t *testing.T) {
 	tests := []struct {
 		admin     bool
@@ -506,7 +564,7 @@ func TestListApiSpecRevisions(t *testing.T) {
 			opts := cmp.Options{
 				protocmp.Transform(),
 				protocmp.IgnoreFields(new(rpc.ListApiSpecRevisionsResponse), "next_page_token"),
-				protocmp.IgnoreFields(new(rpc.ApiSpec), "name", "revision_id", "create_time", "revision_create_time", "revision_update_time"),
+				protocmp.IgnoreFields(new(rpc.ApiSpec), "name", "revision_id", "create_time", "revision_create_time", "revision_update_time", "etag"),
 			}
 
 			if !cmp.Equal(test.want, got, opts) {
@@ -556,6 +614,7 @@ func TestListApiSpecRevisionsSequence(t *testing.T) {
 		RevisionCreateTime: firstRevision.GetRevisionCreateTime(),
 		RevisionUpdateTime: firstRevision.GetRevisionUpdateTime(),
 		RevisionId:         firstRevision.GetRevisionId(),
+		Etag:               firstRevision.GetEtag(),
 	}
 
 	updateReq := &rpc.UpdateApiSpecRequest{
@@ -580,6 +639,7 @@ func TestListApiSpecRevisionsSequence(t *testing.T) {
 		RevisionCreateTime: secondRevision.GetRevisionCreateTime(),
 		RevisionUpdateTime: secondRevision.GetRevisionUpdateTime(),
 		RevisionId:         secondRevision.GetRevisionId(),
+		Etag:               secondRevision.GetEtag(),
 	}
 
 	opts := cmp.Options{
@@ -668,13 +728,14 @@ func TestUpdateApiSpecRevisions(t *testing.T) {
 
 	opts := cmp.Options{
 		protocmp.Transform(),
-		protocmp.IgnoreFields(new(rpc.ApiSpec), "revision_id", "create_time", "revision_create_time", "revision_update_time"),
+		protocmp.IgnoreFields(new(rpc.ApiSpec), "revision_id", "create_time", "revision_create_time", "revision_update_time", "etag"),
 	}
 
 	t.Run("modify revision without content changes", func(t *testing.T) {
 		req := &rpc.UpdateApiSpecRequest{
 			ApiSpec: &rpc.ApiSpec{
-				Name: created.GetName(),
+				Name:        created.GetName(),
+				Description: "Modified First Revision",
 			},
 		}
 
@@ -690,6 +751,25 @@ func TestUpdateApiSpecRevisions(t *testing.T) {
 		if ct, ut := got.GetRevisionCreateTime().AsTime(), got.GetRevisionUpdateTime().AsTime(); !ct.Before(ut) {
 			t.Errorf("UpdateApiSpec(%+v) returned unexpected timestamps, expected revision_update_time %v > revision_create_time %v", req, ut, ct)
 		}
+		created = got
+	})
+
+	t.Run("modify revision without changes", func(t *testing.T) {
+		req := &rpc.UpdateApiSpecRequest{
+			ApiSpec: &rpc.ApiSpec{
+				Name:        created.GetName(),
+				Description: created.GetDescription(),
+			},
+		}
+
+		got, err := server.UpdateApiSpec(ctx, req)
+		if err != nil {
+			t.Fatalf("UpdateApiSpec(%+v) returned error: %s", req, err)
+		}
+
+		if !cmp.Equal(created, got, protocmp.Transform()) {
+			t.Errorf("UpdateApiSpec(%+v) changed the revision, expected no change (-want +got):\n%s", req, cmp.Diff(created, got, protocmp.Transform()))
+		}
 	})
 
 	t.Run("modify revision with content changes", func(t *testing.T) {
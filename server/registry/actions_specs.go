@@ -48,13 +48,18 @@ func (s *RegistryServer) CreateApiSpec(ctx context.Context, req *rpc.CreateApiSp
 	}
 	var response *rpc.ApiSpec
 	if err := s.runInTransaction(ctx, func(ctx context.Context, db *storage.Client) error {
+		db.LockSpec(ctx, name)
 		var err error
 		response, err = s.createSpec(ctx, db, name, req.GetApiSpec())
-		return err
+		if err != nil {
+			return err
+		}
+		s.notify(ctx, rpc.Notification_CREATED, response.GetName())
+		s.audit(ctx, "CreateApiSpec", response.GetName(), nil, response)
+		return nil
 	}); err != nil {
 		return nil, err
 	}
-	s.notify(ctx, rpc.Notification_CREATED, response.GetName())
 	return response, nil
 }
 
@@ -91,18 +96,93 @@ func (s *RegistryServer) DeleteApiSpec(ctx context.Context, req *rpc.DeleteApiSp
 	}
 
 	if err := s.runInTransaction(ctx, func(ctx context.Context, db *storage.Client) error {
-		return db.LockSpecs(ctx).DeleteSpec(ctx, name, req.GetForce())
+		db.LockSpec(ctx, name)
+		if req.GetEtag() != "" {
+			spec, err := db.GetSpec(ctx, name)
+			if err != nil {
+				return err
+			}
+			if err := checkEtag(req.GetName(), req.GetEtag(), spec.Etag()); err != nil {
+				return err
+			}
+		}
+		if err := s.checkReferrers(ctx, db, name.String()); err != nil {
+			return err
+		}
+		if err := db.DeleteSpec(ctx, name, req.GetForce()); err != nil {
+			return err
+		}
+		if err := s.purgeDeleted(ctx, db); err != nil {
+			return err
+		}
+		s.notify(ctx, rpc.Notification_DELETED, req.GetName())
+		s.audit(ctx, "DeleteApiSpec", req.GetName(), nil, nil)
+		return nil
 	}); err != nil {
 		return nil, err
 	}
-	s.notify(ctx, rpc.Notification_DELETED, req.GetName())
 	return &emptypb.Empty{}, nil
 }
 
+// UndeleteApiSpec handles the corresponding API request.
+func (s *RegistryServer) UndeleteApiSpec(ctx context.Context, req *rpc.UndeleteApiSpecRequest) (*rpc.ApiSpec, error) {
+	if name, err := names.ParseSpec(req.GetName()); err == nil {
+		return s.undeleteApiSpec(ctx, name)
+	} else if name, err := names.ParseSpecRevision(req.GetName()); err == nil {
+		return s.undeleteApiSpecRevision(ctx, name)
+	}
+
+	return nil, status.Errorf(codes.InvalidArgument, "invalid resource name %q, must be an API spec or revision", req.GetName())
+}
+
+func (s *RegistryServer) undeleteApiSpec(ctx context.Context, name names.Spec) (*rpc.ApiSpec, error) {
+	var response *rpc.ApiSpec
+	if err := s.runInTransaction(ctx, func(ctx context.Context, db *storage.Client) error {
+		if err := db.LockSpec(ctx, name).UndeleteSpec(ctx, name); err != nil {
+			return err
+		}
+		spec, err := db.GetSpec(ctx, name)
+		if err != nil {
+			return err
+		}
+		response, err = spec.BasicMessage(name.String())
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		s.notify(ctx, rpc.Notification_CREATED, response.GetName())
+		s.audit(ctx, "UndeleteApiSpec", response.GetName(), nil, response)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s *RegistryServer) undeleteApiSpecRevision(ctx context.Context, name names.SpecRevision) (*rpc.ApiSpec, error) {
+	var response *rpc.ApiSpec
+	if err := s.runInTransaction(ctx, func(ctx context.Context, db *storage.Client) error {
+		if err := db.LockSpec(ctx, name.Spec()).UndeleteSpecRevision(ctx, name); err != nil {
+			return err
+		}
+		revision, err := db.GetSpecRevision(ctx, name)
+		if err != nil {
+			return err
+		}
+		response, err = revision.BasicMessage(name.String())
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		s.notify(ctx, rpc.Notification_CREATED, response.GetName())
+		s.audit(ctx, "UndeleteApiSpec", response.GetName(), nil, response)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
 // GetApiSpec handles the corresponding API request.
 func (s *RegistryServer) GetApiSpec(ctx context.Context, req *rpc.GetApiSpecRequest) (*rpc.ApiSpec, error) {
-	s.begin()
-	defer s.end()
 	if name, err := names.ParseSpec(req.GetName()); err == nil {
 		return s.getApiSpec(ctx, name)
 	} else if name, err := names.ParseSpecRevision(req.GetName()); err == nil {
@@ -215,10 +295,11 @@ func (s *RegistryServer) ListApiSpecs(ctx context.Context, req *rpc.ListApiSpecs
 	}
 
 	listing, err := db.ListSpecs(ctx, parent, storage.PageOptions{
-		Size:   req.GetPageSize(),
-		Filter: req.GetFilter(),
-		Order:  req.GetOrderBy(),
-		Token:  req.GetPageToken(),
+		Size:        req.GetPageSize(),
+		Filter:      req.GetFilter(),
+		Order:       req.GetOrderBy(),
+		Token:       req.GetPageToken(),
+		ShowDeleted: req.GetShowDeleted(),
 	})
 	if err != nil {
 		return nil, err
@@ -256,38 +337,60 @@ func (s *RegistryServer) UpdateApiSpec(ctx context.Context, req *rpc.UpdateApiSp
 	}
 	var response *rpc.ApiSpec
 	if err = s.runInTransaction(ctx, func(ctx context.Context, db *storage.Client) error {
+		db.LockSpec(ctx, name)
+		var before *rpc.ApiSpec
 		spec, err := db.GetSpec(ctx, name)
 		if err == nil {
+			if before, err = spec.BasicMessage(name.String()); err != nil {
+				return err
+			}
+			if err := checkEtag(name.String(), req.GetApiSpec().GetEtag(), spec.Etag()); err != nil {
+				return err
+			}
 			// Apply the update to the spec - possibly changing the revision ID.
 			maskExpansion := models.ExpandMask(req.GetApiSpec(), req.GetUpdateMask())
 			if err := spec.Update(req.GetApiSpec(), maskExpansion); err != nil {
 				return err
 			}
+			after, err := spec.BasicMessage(name.String())
+			if err != nil {
+				return err
+			}
+			// Updates that don't change the spec return the current revision.
+			if unchanged(before, after) {
+				response = before
+				return nil
+			}
 			// Save the updated/current spec. This creates a new revision or updates the previous one.
 			if err := db.SaveSpecRevision(ctx, spec); err != nil {
 				return err
 			}
-			// If the spec contents were updated, save a new blob.
-			if len(fieldmaskpb.Intersect(maskExpansion, &fieldmaskpb.FieldMask{Paths: []string{"contents"}}).GetPaths()) > 0 {
+			// If the spec contents were changed, save a new blob.
+			if len(fieldmaskpb.Intersect(maskExpansion, &fieldmaskpb.FieldMask{Paths: []string{"contents"}}).GetPaths()) > 0 &&
+				(after.GetHash() != before.GetHash() || after.GetMimeType() != before.GetMimeType()) {
 				if err := db.SaveSpecRevisionContents(ctx, spec, req.ApiSpec.GetContents()); err != nil {
 					return err
 				}
 			}
-			response, err = spec.BasicMessage(name.String())
-			return err
+			response = after
 		} else if status.Code(err) == codes.NotFound && req.GetAllowMissing() {
+			if err := missingEtag(name.String(), req.GetApiSpec().GetEtag()); err != nil {
+				return err
+			}
 			response, err = s.createSpec(ctx, db, name, req.GetApiSpec())
 			if status.Code(err) == codes.AlreadyExists {
 				err = status.Error(codes.Aborted, err.Error())
 			}
-			return err
-		} else {
+		}
+		if err != nil {
 			return err
 		}
+		s.notify(ctx, rpc.Notification_UPDATED, response.GetName())
+		s.audit(ctx, "UpdateApiSpec", response.GetName(), before, response)
+		return nil
 	}); err != nil {
 		return nil, err
 	}
-	s.notify(ctx, rpc.Notification_UPDATED, response.GetName())
 	return response, nil
 }
 
@@ -103,7 +103,7 @@ func TestCreateApiSpec(t *testing.T) {
 
 			opts := cmp.Options{
 				protocmp.Transform(),
-				protocmp.IgnoreFields(new(rpc.ApiSpec), "revision_id", "create_time", "revision_create_time", "revision_update_time"),
+				protocmp.IgnoreFields(new(rpc.ApiSpec), "revision_id", "create_time", "revision_create_time", "revision_update_time", "etag"),
 			}
 
 			if !cmp.Equal(test.want, created, opts) {
@@ -371,7 +371,7 @@ func TestGetApiSpec(t *testing.T) {
 
 			opts := cmp.Options{
 				protocmp.Transform(),
-				protocmp.IgnoreFields(new(rpc.ApiSpec), "revision_id", "create_time", "revision_create_time", "revision_update_time"),
+				protocmp.IgnoreFields(new(rpc.ApiSpec), "revision_id", "create_time", "revision_create_time", "revision_update_time", "etag"),
 			}
 
 			if !cmp.Equal(test.want, got, opts) {
@@ -963,7 +963,7 @@ func TestListApiSpecs(t *testing.T) {
 			opts := cmp.Options{
 				protocmp.Transform(),
 				protocmp.IgnoreFields(new(rpc.ListApiSpecsResponse), "next_page_token"),
-				protocmp.IgnoreFields(new(rpc.ApiSpec), "revision_id", "create_time", "revision_create_time", "revision_update_time"),
+				protocmp.IgnoreFields(new(rpc.ApiSpec), "revision_id", "create_time", "revision_create_time", "revision_update_time", "etag"),
 				test.extraOpts,
 			}
 
@@ -1186,7 +1186,7 @@ func TestListApiSpecsSequence(t *testing.T) {
 
 	opts := cmp.Options{
 		protocmp.Transform(),
-		protocmp.IgnoreFields(new(rpc.ApiSpec), "revision_id", "create_time", "revision_create_time", "revision_update_time"),
+		protocmp.IgnoreFields(new(rpc.ApiSpec), "revision_id", "create_time", "revision_create_time", "revision_update_time", "etag"),
 		cmpopts.SortSlices(func(a, b *rpc.ApiSpec) bool {
 			return a.GetName() < b.GetName()
 		}),
@@ -1373,7 +1373,7 @@ func TestUpdateApiSpec(t *testing.T) {
 
 			opts := cmp.Options{
 				protocmp.Transform(),
-				protocmp.IgnoreFields(new(rpc.ApiSpec), "revision_id", "create_time", "revision_create_time", "revision_update_time"),
+				protocmp.IgnoreFields(new(rpc.ApiSpec), "revision_id", "create_time", "revision_create_time", "revision_update_time", "etag"),
 			}
 
 			if !cmp.Equal(test.want, updated, opts) {
@@ -1515,7 +1515,8 @@ func TestUpdateApiSpecSequence(t *testing.T) {
 			desc: "update existing resource with allow_missing=true",
 			req: &rpc.UpdateApiSpecRequest{
 				ApiSpec: &rpc.ApiSpec{
-					Name: "projects/my-project/locations/global/apis/a/versions/v/specs/s",
+					Name:        "projects/my-project/locations/global/apis/a/versions/v/specs/s",
+					Description: "First update",
 				},
 				AllowMissing: true,
 			},
@@ -1525,7 +1526,8 @@ func TestUpdateApiSpecSequence(t *testing.T) {
 			desc: "update existing resource with allow_missing=false",
 			req: &rpc.UpdateApiSpecRequest{
 				ApiSpec: &rpc.ApiSpec{
-					Name: "projects/my-project/locations/global/apis/a/versions/v/specs/s",
+					Name:        "projects/my-project/locations/global/apis/a/versions/v/specs/s",
+					Description: "Second update",
 				},
 				AllowMissing: false,
 			},
@@ -1693,3 +1695,89 @@ func TestDeleteApiSpecCascading(t *testing.T) {
 		t.Errorf("GetArtifact(%q) returned status code %q, want %q: %s", artifact.GetName(), status.Code(err), codes.NotFound, err)
 	}
 }
+
+func TestUndeleteApiSpec(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		server = serverWithPurgeWindow(t)
+		spec   = &rpc.ApiSpec{
+			Name: "projects/my-project/locations/global/apis/my-api/versions/v1/specs/my-spec",
+		}
+	)
+	if err := seeder.SeedSpecs(ctx, server, spec); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+	update := &rpc.UpdateApiSpecRequest{
+		ApiSpec: &rpc.ApiSpec{
+			Name:     spec.GetName(),
+			Contents: []byte("new contents"),
+		},
+	}
+	if _, err := server.UpdateApiSpec(ctx, update); err != nil {
+		t.Fatalf("Setup: UpdateApiSpec(%+v) returned error: %s", update, err)
+	}
+
+	if _, err := server.DeleteApiSpec(ctx, &rpc.DeleteApiSpecRequest{Name: spec.GetName()}); err != nil {
+		t.Fatalf("DeleteApiSpec(%q) returned error: %s", spec.GetName(), err)
+	}
+	list := &rpc.ListApiSpecsRequest{
+		Parent:      "projects/my-project/locations/global/apis/my-api/versions/v1",
+		ShowDeleted: true,
+	}
+	if got, err := server.ListApiSpecs(ctx, list); err != nil {
+		t.Fatalf("ListApiSpecs(%+v) returned error: %s", list, err)
+	} else if len(got.GetApiSpecs()) != 1 || got.GetApiSpecs()[0].GetDeleteTime() == nil {
+		t.Errorf("ListApiSpecs(%+v) returned %v, want %q with a delete_time", list, got.GetApiSpecs(), spec.GetName())
+	}
+
+	if _, err := server.UndeleteApiSpec(ctx, &rpc.UndeleteApiSpecRequest{Name: spec.GetName()}); err != nil {
+		t.Fatalf("UndeleteApiSpec(%q) returned error: %s", spec.GetName(), err)
+	}
+	revisions := &rpc.ListApiSpecRevisionsRequest{Name: spec.GetName() + "@-"}
+	got, err := server.ListApiSpecRevisions(ctx, revisions)
+	if err != nil {
+		t.Fatalf("ListApiSpecRevisions(%+v) returned error: %s", revisions, err)
+	}
+	if len(got.GetApiSpecs()) != 2 {
+		t.Errorf("ListApiSpecRevisions(%+v) returned %d revisions, want 2", revisions, len(got.GetApiSpecs()))
+	}
+}
+
+func TestCreateApiSpecAfterDelete(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		server = serverWithPurgeWindow(t)
+		spec   = &rpc.ApiSpec{
+			Name:     "projects/my-project/locations/global/apis/my-api/versions/v1/specs/my-spec",
+			Contents: []byte("old contents"),
+		}
+	)
+	if err := seeder.SeedSpecs(ctx, server, spec); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+	if _, err := server.DeleteApiSpec(ctx, &rpc.DeleteApiSpecRequest{Name: spec.GetName()}); err != nil {
+		t.Fatalf("DeleteApiSpec(%q) returned error: %s", spec.GetName(), err)
+	}
+
+	req := &rpc.CreateApiSpecRequest{
+		Parent:    "projects/my-project/locations/global/apis/my-api/versions/v1",
+		ApiSpecId: "my-spec",
+		ApiSpec:   &rpc.ApiSpec{Contents: []byte("new contents")},
+	}
+	if _, err := server.CreateApiSpec(ctx, req); err != nil {
+		t.Fatalf("CreateApiSpec(%+v) returned error: %s", req, err)
+	}
+	revisions := &rpc.ListApiSpecRevisionsRequest{Name: spec.GetName() + "@-"}
+	if got, err := server.ListApiSpecRevisions(ctx, revisions); err != nil {
+		t.Fatalf("ListApiSpecRevisions(%+v) returned error: %s", revisions, err)
+	} else if len(got.GetApiSpecs()) != 1 {
+		t.Errorf("ListApiSpecRevisions(%+v) returned %d revisions, want 1", revisions, len(got.GetApiSpecs()))
+	}
+	contents, err := server.GetApiSpecContents(ctx, &rpc.GetApiSpecContentsRequest{Name: spec.GetName()})
+	if err != nil {
+		t.Fatalf("GetApiSpecContents(%q) returned error: %s", spec.GetName(), err)
+	}
+	if string(contents.GetData()) != "new contents" {
+		t.Errorf("GetApiSpecContents(%q) returned %q, want %q", spec.GetName(), contents.GetData(), "new contents")
+	}
+}
@@ -16,6 +16,7 @@ package registry
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -39,9 +40,13 @@ func TestGetStorage(t *testing.T) {
 
 	// Ensure that we get the set of tables that we expect.
 	// Tables should be returned in alphabetical order.
-	want := []string{"apis", "artifacts", "blobs", "deployment_revision_tags", "deployments", "projects", "spec_revision_tags", "specs", "versions"}
+	want := []string{"apis", "archives", "artifact_revision_tags", "artifact_revisions", "artifacts", "audit_events", "blobs", "deployment_revision_tags", "deployments", "notification_events", "operations", "projects", "resource_references", "schema_migrations", "search_documents", "shared_contents", "spec_elements", "spec_revision_tags", "specs", "unused_contents", "versions"}
 	got := make([]string, 0)
 	for _, c := range resp.Collections {
+		// The tables of the SQLite search index depend on how the server was built.
+		if strings.HasPrefix(c.Name, "search_index") {
+			continue
+		}
 		got = append(got, c.Name)
 	}
 	if !cmp.Equal(want, got) {
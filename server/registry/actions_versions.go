@@ -44,13 +44,18 @@ func (s *RegistryServer) CreateApiVersion(ctx context.Context, req *rpc.CreateAp
 	}
 	var response *rpc.ApiVersion
 	if err := s.runInTransaction(ctx, func(ctx context.Context, db *storage.Client) error {
+		db.LockVersion(ctx, name)
 		var err error
 		response, err = s.createApiVersion(ctx, db, name, req.GetApiVersion())
-		return err
+		if err != nil {
+			return err
+		}
+		s.notify(ctx, rpc.Notification_CREATED, response.GetName())
+		s.audit(ctx, "CreateApiVersion", response.GetName(), nil, response)
+		return nil
 	}); err != nil {
 		return nil, err
 	}
-	s.notify(ctx, rpc.Notification_CREATED, response.GetName())
 	return response, nil
 }
 
@@ -75,14 +80,64 @@ func (s *RegistryServer) DeleteApiVersion(ctx context.Context, req *rpc.DeleteAp
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 	if err := s.runInTransaction(ctx, func(ctx context.Context, db *storage.Client) error {
-		return db.LockVersions(ctx).DeleteVersion(ctx, name, req.GetForce())
+		db.LockVersion(ctx, name)
+		if req.GetEtag() != "" {
+			version, err := db.GetVersion(ctx, name)
+			if err != nil {
+				return err
+			}
+			if err := checkEtag(req.GetName(), req.GetEtag(), version.Etag()); err != nil {
+				return err
+			}
+		}
+		if err := s.checkReferrers(ctx, db, name.String()); err != nil {
+			return err
+		}
+		if err := db.DeleteVersion(ctx, name, req.GetForce()); err != nil {
+			return err
+		}
+		if err := s.purgeDeleted(ctx, db); err != nil {
+			return err
+		}
+		s.notify(ctx, rpc.Notification_DELETED, req.GetName())
+		s.audit(ctx, "DeleteApiVersion", req.GetName(), nil, nil)
+		return nil
 	}); err != nil {
 		return nil, err
 	}
-	s.notify(ctx, rpc.Notification_DELETED, req.GetName())
 	return &emptypb.Empty{}, nil
 }
 
+// UndeleteApiVersion handles the corresponding API request.
+func (s *RegistryServer) UndeleteApiVersion(ctx context.Context, req *rpc.UndeleteApiVersionRequest) (*rpc.ApiVersion, error) {
+	// Version name must be valid.
+	name, err := names.ParseVersion(req.GetName())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var response *rpc.ApiVersion
+	if err := s.runInTransaction(ctx, func(ctx context.Context, db *storage.Client) error {
+		if err := db.LockVersion(ctx, name).UndeleteVersion(ctx, name); err != nil {
+			return err
+		}
+		version, err := db.GetVersion(ctx, name)
+		if err != nil {
+			return err
+		}
+		response, err = version.Message()
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		s.notify(ctx, rpc.Notification_CREATED, response.GetName())
+		s.audit(ctx, "UndeleteApiVersion", response.GetName(), nil, response)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
 // GetApiVersion handles the corresponding API request.
 func (s *RegistryServer) GetApiVersion(ctx context.Context, req *rpc.GetApiVersionRequest) (*rpc.ApiVersion, error) {
 	db, err := s.getStorageClient(ctx)
@@ -129,10 +184,11 @@ func (s *RegistryServer) ListApiVersions(ctx context.Context, req *rpc.ListApiVe
 	}
 
 	listing, err := db.ListVersions(ctx, parent, storage.PageOptions{
-		Size:   req.GetPageSize(),
-		Filter: req.GetFilter(),
-		Order:  req.GetOrderBy(),
-		Token:  req.GetPageToken(),
+		Size:        req.GetPageSize(),
+		Filter:      req.GetFilter(),
+		Order:       req.GetOrderBy(),
+		Token:       req.GetPageToken(),
+		ShowDeleted: req.GetShowDeleted(),
 	})
 	if err != nil {
 		return nil, err
@@ -170,9 +226,16 @@ func (s *RegistryServer) UpdateApiVersion(ctx context.Context, req *rpc.UpdateAp
 	}
 	var response *rpc.ApiVersion
 	if err = s.runInTransaction(ctx, func(ctx context.Context, db *storage.Client) error {
-		db.LockVersions(ctx)
+		db.LockVersion(ctx, name)
+		var before *rpc.ApiVersion
 		version, err := db.GetVersion(ctx, name)
 		if err == nil {
+			if before, err = version.Message(); err != nil {
+				return err
+			}
+			if err := checkEtag(name.String(), req.GetApiVersion().GetEtag(), version.Etag()); err != nil {
+				return err
+			}
 			if err := version.Update(req.GetApiVersion(), models.ExpandMask(req.GetApiVersion(), req.GetUpdateMask())); err != nil {
 				return status.Error(codes.Internal, err.Error())
 			}
@@ -180,16 +243,20 @@ func (s *RegistryServer) UpdateApiVersion(ctx context.Context, req *rpc.UpdateAp
 				return err
 			}
 			response, err = version.Message()
-			return err
 		} else if status.Code(err) == codes.NotFound && req.GetAllowMissing() {
+			if err := missingEtag(name.String(), req.GetApiVersion().GetEtag()); err != nil {
+				return err
+			}
 			response, err = s.createApiVersion(ctx, db, name, req.GetApiVersion())
-			return err
-		} else {
+		}
+		if err != nil {
 			return err
 		}
+		s.notify(ctx, rpc.Notification_UPDATED, response.GetName())
+		s.audit(ctx, "UpdateApiVersion", response.GetName(), before, response)
+		return nil
 	}); err != nil {
 		return nil, err
 	}
-	s.notify(ctx, rpc.Notification_UPDATED, response.GetName())
 	return response, nil
 }
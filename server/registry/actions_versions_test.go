@@ -89,7 +89,7 @@ func TestCreateApiVersion(t *testing.T) {
 
 			opts := cmp.Options{
 				protocmp.Transform(),
-				protocmp.IgnoreFields(new(rpc.ApiVersion), "create_time", "update_time"),
+				protocmp.IgnoreFields(new(rpc.ApiVersion), "create_time", "update_time", "etag"),
 			}
 
 			if !cmp.Equal(test.want, created, opts) {
@@ -318,7 +318,7 @@ func TestGetApiVersion(t *testing.T) {
 
 			opts := cmp.Options{
 				protocmp.Transform(),
-				protocmp.IgnoreFields(new(rpc.ApiVersion), "create_time", "update_time"),
+				protocmp.IgnoreFields(new(rpc.ApiVersion), "create_time", "update_time", "etag"),
 			}
 
 			if !cmp.Equal(test.want, got, opts) {
@@ -642,7 +642,7 @@ func TestListApiVersions(t *testing.T) {
 			opts := cmp.Options{
 				protocmp.Transform(),
 				protocmp.IgnoreFields(new(rpc.ListApiVersionsResponse), "next_page_token"),
-				protocmp.IgnoreFields(new(rpc.ApiVersion), "create_time", "update_time"),
+				protocmp.IgnoreFields(new(rpc.ApiVersion), "create_time", "update_time", "etag"),
 				test.extraOpts,
 			}
 
@@ -858,7 +858,7 @@ func TestListApiVersionsSequence(t *testing.T) {
 
 	opts := cmp.Options{
 		protocmp.Transform(),
-		protocmp.IgnoreFields(new(rpc.ApiVersion), "create_time", "update_time"),
+		protocmp.IgnoreFields(new(rpc.ApiVersion), "create_time", "update_time", "etag"),
 		cmpopts.SortSlices(func(a, b *rpc.ApiVersion) bool {
 			return a.GetName() < b.GetName()
 		}),
@@ -1043,7 +1043,7 @@ func TestUpdateApiVersion(t *testing.T) {
 
 			opts := cmp.Options{
 				protocmp.Transform(),
-				protocmp.IgnoreFields(new(rpc.ApiVersion), "create_time", "update_time"),
+				protocmp.IgnoreFields(new(rpc.ApiVersion), "create_time", "update_time", "etag"),
 			}
 
 			if !cmp.Equal(test.want, updated, opts) {
@@ -1331,3 +1331,62 @@ func TestDeleteApiVersionCascading(t *testing.T) {
 		t.Errorf("GetArtifact(%q) returned status code %q, want %q: %s", artifact.GetName(), status.Code(err), codes.NotFound, err)
 	}
 }
+
+func TestUndeleteApiVersion(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		server = serverWithPurgeWindow(t)
+		spec   = &rpc.ApiSpec{
+			Name: "projects/my-project/locations/global/apis/my-api/versions/v1/specs/my-spec",
+		}
+		name = "projects/my-project/locations/global/apis/my-api/versions/v1"
+	)
+	if err := seeder.SeedSpecs(ctx, server, spec); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+
+	if _, err := server.DeleteApiVersion(ctx, &rpc.DeleteApiVersionRequest{Name: name, Force: true}); err != nil {
+		t.Fatalf("DeleteApiVersion(%q) returned error: %s", name, err)
+	}
+	if _, err := server.UndeleteApiVersion(ctx, &rpc.UndeleteApiVersionRequest{Name: name}); err != nil {
+		t.Fatalf("UndeleteApiVersion(%q) returned error: %s", name, err)
+	}
+	if _, err := server.GetApiSpec(ctx, &rpc.GetApiSpecRequest{Name: spec.GetName()}); err != nil {
+		t.Errorf("GetApiSpec(%q) returned error: %s", spec.GetName(), err)
+	}
+	if _, err := server.UndeleteApiVersion(ctx, &rpc.UndeleteApiVersionRequest{Name: name}); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("UndeleteApiVersion(%q) returned status code %q, want %q: %v", name, status.Code(err), codes.AlreadyExists, err)
+	}
+}
+
+func TestCreateApiVersionAfterDelete(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		server = serverWithPurgeWindow(t)
+		spec   = &rpc.ApiSpec{
+			Name: "projects/my-project/locations/global/apis/my-api/versions/v1/specs/my-spec",
+		}
+		name = "projects/my-project/locations/global/apis/my-api/versions/v1"
+	)
+	if err := seeder.SeedSpecs(ctx, server, spec); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+	if _, err := server.DeleteApiVersion(ctx, &rpc.DeleteApiVersionRequest{Name: name, Force: true}); err != nil {
+		t.Fatalf("DeleteApiVersion(%q) returned error: %s", name, err)
+	}
+
+	req := &rpc.CreateApiVersionRequest{
+		Parent:       "projects/my-project/locations/global/apis/my-api",
+		ApiVersionId: "v1",
+		ApiVersion:   &rpc.ApiVersion{},
+	}
+	if _, err := server.CreateApiVersion(ctx, req); err != nil {
+		t.Fatalf("CreateApiVersion(%+v) returned error: %s", req, err)
+	}
+	list := &rpc.ListApiSpecsRequest{Parent: name, ShowDeleted: true}
+	if got, err := server.ListApiSpecs(ctx, list); err != nil {
+		t.Fatalf("ListApiSpecs(%+v) returned error: %s", list, err)
+	} else if len(got.GetApiSpecs()) != 0 {
+		t.Errorf("ListApiSpecs(%+v) returned %v, want the deleted specs to be purged", list, got.GetApiSpecs())
+	}
+}
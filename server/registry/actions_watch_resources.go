@@ -0,0 +1,64 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/notify"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// WatchResources handles the corresponding API request.
+func (s *RegistryServer) WatchResources(req *rpc.WatchResourcesRequest, stream rpc.Admin_WatchResourcesServer) error {
+	// Parent name must be valid.
+	if req.GetParent() == "" {
+		return status.Errorf(codes.InvalidArgument, "invalid parent %q", req.GetParent())
+	}
+	filter, err := notify.NewFilter(req.GetParent(), req.GetFilter())
+	if err != nil {
+		return err
+	}
+
+	sub := s.watchers.subscribe()
+	defer s.watchers.unsubscribe(sub)
+
+	// Sending headers lets clients know that the watch is established,
+	// so changes made after this point will be delivered.
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case n, ok := <-sub.ch:
+			if !ok {
+				return sub.err
+			}
+			if match, err := filter.Matches(n); err != nil {
+				return err
+			} else if !match {
+				continue
+			}
+			if err := stream.Send(n); err != nil {
+				return err
+			}
+		}
+	}
+}
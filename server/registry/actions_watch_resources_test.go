@@ -0,0 +1,287 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/test/seeder"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// watchResourcesStream collects the notifications sent by WatchResources.
+type watchResourcesStream struct {
+	grpc.ServerStream
+	ctx   context.Context
+	ready chan struct{}
+	sent  chan *rpc.Notification
+}
+
+func newWatchResourcesStream(ctx context.Context) *watchResourcesStream {
+	return &watchResourcesStream{
+		ctx:   ctx,
+		ready: make(chan struct{}),
+		sent:  make(chan *rpc.Notification, 100),
+	}
+}
+
+func (s *watchResourcesStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *watchResourcesStream) SendHeader(metadata.MD) error {
+	close(s.ready)
+	return nil
+}
+
+func (s *watchResourcesStream) Send(n *rpc.Notification) error {
+	s.sent <- n
+	return nil
+}
+
+type change struct {
+	Change   rpc.Notification_Change
+	Resource string
+}
+
+func TestWatchResources(t *testing.T) {
+	if adminServiceUnavailable() {
+		t.Skip(testRequiresAdminService)
+	}
+	tests := []struct {
+		desc   string
+		req    *rpc.WatchResourcesRequest
+		action func(context.Context, TestServer) error
+		want   []change
+	}{
+		{
+			desc: "project parent",
+			req: &rpc.WatchResourcesRequest{
+				Parent: "projects/my-project",
+			},
+			action: func(ctx context.Context, server TestServer) error {
+				if _, err := server.CreateApi(ctx, &rpc.CreateApiRequest{
+					Parent: "projects/other-project/locations/global",
+					ApiId:  "my-api",
+					Api:    &rpc.Api{},
+				}); err != nil {
+					return err
+				}
+				_, err := server.CreateApi(ctx, &rpc.CreateApiRequest{
+					Parent: "projects/my-project/locations/global",
+					ApiId:  "my-api",
+					Api:    &rpc.Api{},
+				})
+				return err
+			},
+			want: []change{
+				{rpc.Notification_CREATED, "projects/my-project/locations/global/apis/my-api"},
+			},
+		},
+		{
+			desc: "wildcard parent with filter",
+			req: &rpc.WatchResourcesRequest{
+				Parent: "projects/-/locations/global/apis/my-api",
+				Filter: `change == "UPDATED"`,
+			},
+			action: func(ctx context.Context, server TestServer) error {
+				for _, project := range []string{"my-project", "other-project"} {
+					api, err := server.CreateApi(ctx, &rpc.CreateApiRequest{
+						Parent: "projects/" + project + "/locations/global",
+						ApiId:  "my-api",
+						Api:    &rpc.Api{},
+					})
+					if err != nil {
+						return err
+					}
+					api.DisplayName = "updated"
+					if _, err := server.UpdateApi(ctx, &rpc.UpdateApiRequest{Api: api}); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+			want: []change{
+				{rpc.Notification_UPDATED, "projects/my-project/locations/global/apis/my-api"},
+				{rpc.Notification_UPDATED, "projects/other-project/locations/global/apis/my-api"},
+			},
+		},
+		{
+			desc: "spec deletion",
+			req: &rpc.WatchResourcesRequest{
+				Parent: "projects/my-project/locations/global/apis/my-api/versions/v1/specs/my-spec",
+				Filter: `change == "DELETED"`,
+			},
+			action: func(ctx context.Context, server TestServer) error {
+				if _, err := server.CreateApi(ctx, &rpc.CreateApiRequest{
+					Parent: "projects/my-project/locations/global",
+					ApiId:  "my-api",
+					Api:    &rpc.Api{},
+				}); err != nil {
+					return err
+				}
+				if _, err := server.CreateApiVersion(ctx, &rpc.CreateApiVersionRequest{
+					Parent:       "projects/my-project/locations/global/apis/my-api",
+					ApiVersionId: "v1",
+					ApiVersion:   &rpc.ApiVersion{},
+				}); err != nil {
+					return err
+				}
+				spec, err := server.CreateApiSpec(ctx, &rpc.CreateApiSpecRequest{
+					Parent:    "projects/my-project/locations/global/apis/my-api/versions/v1",
+					ApiSpecId: "my-spec",
+					ApiSpec:   &rpc.ApiSpec{},
+				})
+				if err != nil {
+					return err
+				}
+				_, err = server.DeleteApiSpec(ctx, &rpc.DeleteApiSpecRequest{
+					Name: spec.GetName(),
+				})
+				return err
+			},
+			want: []change{
+				{rpc.Notification_DELETED, "projects/my-project/locations/global/apis/my-api/versions/v1/specs/my-spec"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			ctx := context.Background()
+			server := defaultTestServer(t)
+			if err := seeder.SeedProjects(ctx, server,
+				&rpc.Project{Name: "projects/my-project"},
+				&rpc.Project{Name: "projects/other-project"},
+			); err != nil {
+				t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+			}
+
+			watchCtx, cancel := context.WithCancel(ctx)
+			stream := newWatchResourcesStream(watchCtx)
+			done := make(chan error, 1)
+			go func() {
+				done <- server.WatchResources(test.req, stream)
+			}()
+
+			select {
+			case <-stream.ready:
+			case err := <-done:
+				t.Fatalf("WatchResources(%+v) returned error: %s", test.req, err)
+			}
+
+			if err := test.action(ctx, server); err != nil {
+				t.Fatalf("Setup: Failed to make changes: %s", err)
+			}
+
+			got := make([]change, 0)
+			for range test.want {
+				select {
+				case n := <-stream.sent:
+					got = append(got, change{n.GetChange(), n.GetResource()})
+				case <-time.After(5 * time.Second):
+					t.Fatalf("Timed out waiting for notifications, got %v", got)
+				}
+			}
+
+			cancel()
+			if err := <-done; status.Code(err) != codes.Canceled {
+				t.Errorf("WatchResources(%+v) returned status code %q, want %q: %v", test.req, status.Code(err), codes.Canceled, err)
+			}
+			for len(stream.sent) > 0 {
+				n := <-stream.sent
+				got = append(got, change{n.GetChange(), n.GetResource()})
+			}
+
+			if !cmp.Equal(test.want, got) {
+				t.Errorf("WatchResources(%+v) returned unexpected diff (-want +got):\n%s", test.req, cmp.Diff(test.want, got))
+			}
+		})
+	}
+}
+
+func TestWatchResourcesErrors(t *testing.T) {
+	if adminServiceUnavailable() {
+		t.Skip(testRequiresAdminService)
+	}
+	tests := []struct {
+		desc string
+		req  *rpc.WatchResourcesRequest
+		want codes.Code
+	}{
+		{
+			desc: "missing parent",
+			req:  &rpc.WatchResourcesRequest{},
+			want: codes.InvalidArgument,
+		},
+		{
+			desc: "invalid parent",
+			req:  &rpc.WatchResourcesRequest{Parent: "invalid"},
+			want: codes.InvalidArgument,
+		},
+		{
+			desc: "invalid filter",
+			req: &rpc.WatchResourcesRequest{
+				Parent: "projects/my-project",
+				Filter: "invalid filter",
+			},
+			want: codes.InvalidArgument,
+		},
+		{
+			desc: "unknown filter field",
+			req: &rpc.WatchResourcesRequest{
+				Parent: "projects/my-project",
+				Filter: `name == "x"`,
+			},
+			want: codes.InvalidArgument,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			server := defaultTestServer(t)
+			if err := server.WatchResources(test.req, newWatchResourcesStream(ctx)); status.Code(err) != test.want {
+				t.Errorf("WatchResources(%+v) returned status code %q, want %q: %v", test.req, status.Code(err), test.want, err)
+			}
+		})
+	}
+}
+
+func TestWatchResourcesClosed(t *testing.T) {
+	server, err := serverWithSQLite(t)
+	if err != nil {
+		t.Fatalf("Setup: failed to get server with SQLite: %s", err)
+	}
+	stream := newWatchResourcesStream(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- server.WatchResources(&rpc.WatchResourcesRequest{Parent: "projects/-"}, stream)
+	}()
+	<-stream.ready
+
+	server.watchers.close()
+	if err := <-done; status.Code(err) != codes.Unavailable {
+		t.Errorf("WatchResources() returned status code %q, want %q: %v", status.Code(err), codes.Unavailable, err)
+	}
+}
@@ -0,0 +1,750 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"hash"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/storage"
+	"github.com/apigee/registry/server/registry/internal/storage/models"
+	"github.com/apigee/registry/server/registry/names"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// archiveFormatVersion is the version of the archive format that is written.
+	// Archives of earlier versions, which lack artifact revisions, can also be read.
+	archiveFormatVersion = 2
+	// archiveLifetime is the time that archives are kept before they are removed.
+	archiveLifetime = 7 * 24 * time.Hour
+	// archiveChunkSize is the size of the chunks that archives are read in.
+	archiveChunkSize = 1 << 20
+	// maxArchiveEntrySize is the size of the largest entry that can be read from an archive.
+	maxArchiveEntrySize = 1 << 30
+	// archiveProgressInterval is the number of entries exported or imported between progress reports.
+	archiveProgressInterval = 100
+	// defaultMaxArchiveSize is the size of the largest archive that can be exported or written by default.
+	defaultMaxArchiveSize = 4 << 30
+)
+
+// archiveFile holds the contents of an archive in a temporary file while they
+// are exported or uploaded, so that archives are never held in memory.
+type archiveFile struct {
+	f    *os.File
+	hash hash.Hash
+	size int64
+	max  int64
+}
+
+// newArchiveFile creates an empty archive file that can hold up to max bytes.
+func newArchiveFile(max int64) (*archiveFile, error) {
+	f, err := os.CreateTemp("", "registry-archive-*")
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create archive file: %s", err)
+	}
+	return &archiveFile{f: f, hash: sha256.New(), max: max}, nil
+}
+
+// Write appends contents to the archive. Archives that would grow
+// larger than their maximum size are exhausted resources.
+func (a *archiveFile) Write(p []byte) (int, error) {
+	if a.size+int64(len(p)) > a.max {
+		return 0, status.Errorf(codes.ResourceExhausted, "archive is larger than the maximum of %d bytes", a.max)
+	}
+	n, err := a.f.Write(p)
+	a.hash.Write(p[:n])
+	a.size += int64(n)
+	if err != nil {
+		return n, status.Errorf(codes.Internal, "failed to write archive file: %s", err)
+	}
+	return n, nil
+}
+
+// sum returns the hash of the contents, which is formatted like the hashes of specs and artifacts.
+func (a *archiveFile) sum() string {
+	return hex.EncodeToString(a.hash.Sum(nil))
+}
+
+// rewind returns a reader of the contents from their start.
+func (a *archiveFile) rewind() (io.ReadSeeker, error) {
+	if _, err := a.f.Seek(0, io.SeekStart); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to read archive file: %s", err)
+	}
+	return a.f, nil
+}
+
+// remove closes and removes the temporary file.
+func (a *archiveFile) remove() {
+	a.f.Close()
+	os.Remove(a.f.Name())
+}
+
+// archiveWriter writes the entries of an archive. Archives are gzip-compressed
+// sequences of entries, each preceded by its size as a varint.
+type archiveWriter struct {
+	zw *gzip.Writer
+}
+
+func newArchiveWriter(w io.Writer) *archiveWriter {
+	return &archiveWriter{zw: gzip.NewWriter(w)}
+}
+
+func (w *archiveWriter) write(entry *rpc.ArchiveEntry) error {
+	b, err := proto.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	size := make([]byte, binary.MaxVarintLen64)
+	size = size[:binary.PutUvarint(size, uint64(len(b)))]
+	if _, err := w.zw.Write(size); err != nil {
+		return err
+	}
+	_, err = w.zw.Write(b)
+	return err
+}
+
+// close finishes the archive.
+func (w *archiveWriter) close() error {
+	return w.zw.Close()
+}
+
+// archiveReader reads the entries of an archive, starting with its header.
+type archiveReader struct {
+	r      *bufio.Reader
+	header *rpc.ArchiveEntry_Header
+}
+
+// newArchiveReader reads the header of an archive. Archives that can't be read are invalid arguments.
+func newArchiveReader(contents io.Reader) (*archiveReader, error) {
+	zr, err := gzip.NewReader(contents)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid archive: %s", err)
+	}
+	r := &archiveReader{r: bufio.NewReader(zr)}
+	entry, err := r.next()
+	if err == io.EOF {
+		return nil, status.Error(codes.InvalidArgument, "invalid archive: no header")
+	} else if err != nil {
+		return nil, err
+	}
+	r.header = entry.GetHeader()
+	if r.header == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid archive: no header")
+	}
+	if v := r.header.GetFormatVersion(); v < 1 || v > archiveFormatVersion {
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported archive format version %d, want at most %d", v, archiveFormatVersion)
+	}
+	if _, err := names.ParseProject(r.header.GetProject()); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid archive: %s", err)
+	}
+	return r, nil
+}
+
+// next returns the next entry of the archive or io.EOF after the last entry.
+func (r *archiveReader) next() (*rpc.ArchiveEntry, error) {
+	size, err := binary.ReadUvarint(r.r)
+	if err == io.EOF {
+		return nil, io.EOF
+	} else if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid archive: %s", err)
+	}
+	if size > maxArchiveEntrySize {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid archive: entry of %d bytes is too large", size)
+	}
+	b := make([]byte, size)
+	if _, err := io.ReadFull(r.r, b); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid archive: %s", err)
+	}
+	entry := &rpc.ArchiveEntry{}
+	if err := proto.Unmarshal(b, entry); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid archive: %s", err)
+	}
+	return entry, nil
+}
+
+// archiveNode is an entry of an archive with the entries of its children.
+type archiveNode struct {
+	entry    *rpc.ArchiveEntry
+	children []*archiveNode
+}
+
+// archiveEntries returns the entries of an archive of the resources of a
+// project, without the header. Each resource follows its parent, and each
+// revision is followed by its tags and artifacts.
+func archiveEntries(r *storage.ProjectResources) ([]*rpc.ArchiveEntry, error) {
+	root := &archiveNode{entry: &rpc.ArchiveEntry{Entry: &rpc.ArchiveEntry_Project{Project: r.Project.Message()}}}
+	nodes := map[string]*archiveNode{r.Project.Name(): root}
+	// Resources are added after their parents, and resources
+	// whose parents are missing are left out of the archive.
+	add := func(parent, name string, entry *rpc.ArchiveEntry) {
+		p, ok := nodes[parent]
+		if !ok {
+			return
+		}
+		n := &archiveNode{entry: entry}
+		p.children = append(p.children, n)
+		nodes[name] = n
+	}
+
+	for _, v := range r.Apis {
+		m, err := v.Message()
+		if err != nil {
+			return nil, err
+		}
+		add(r.Project.Name(), v.Name(), &rpc.ArchiveEntry{Entry: &rpc.ArchiveEntry_Api{Api: m}})
+	}
+	for _, v := range r.Versions {
+		m, err := v.Message()
+		if err != nil {
+			return nil, err
+		}
+		add(names.Api{ProjectID: v.ProjectID, ApiID: v.ApiID}.String(), v.Name(), &rpc.ArchiveEntry{Entry: &rpc.ArchiveEntry_Version{Version: m}})
+	}
+	for _, v := range r.Specs {
+		m, err := v.BasicMessage(v.RevisionName())
+		if err != nil {
+			return nil, err
+		}
+		parent := names.Version{ProjectID: v.ProjectID, ApiID: v.ApiID, VersionID: v.VersionID}.String()
+		add(parent, v.RevisionName(), &rpc.ArchiveEntry{Entry: &rpc.ArchiveEntry_Spec{Spec: m}})
+	}
+	for _, v := range r.SpecRevisionTags {
+		revision := v.ParentSpecKey
+		add(revision, v.String(), &rpc.ArchiveEntry{Entry: &rpc.ArchiveEntry_Tag{Tag: revisionTagMessage(revision, v.Tag, v.CreateTime, v.UpdateTime)}})
+	}
+	for _, v := range r.Deployments {
+		m, err := v.BasicMessage(v.RevisionName())
+		if err != nil {
+			return nil, err
+		}
+		add(names.Api{ProjectID: v.ProjectID, ApiID: v.ApiID}.String(), v.RevisionName(), &rpc.ArchiveEntry{Entry: &rpc.ArchiveEntry_Deployment{Deployment: m}})
+	}
+	for _, v := range r.DeploymentRevisionTags {
+		revision := v.ParentDeploymentKey
+		add(revision, v.String(), &rpc.ArchiveEntry{Entry: &rpc.ArchiveEntry_Tag{Tag: revisionTagMessage(revision, v.Tag, v.CreateTime, v.UpdateTime)}})
+	}
+	for _, v := range r.Artifacts {
+		m, err := v.Message()
+		if err != nil {
+			return nil, err
+		}
+		// Artifacts of projects are named in their location.
+		parent := strings.TrimSuffix(m.GetName(), "/artifacts/"+v.ArtifactID)
+		parent = strings.TrimSuffix(parent, "/locations/"+names.Location)
+		add(parent, m.GetName(), &rpc.ArchiveEntry{Entry: &rpc.ArchiveEntry_Artifact{Artifact: m}})
+		// Tags of the current revision follow the artifact.
+		if n, ok := nodes[m.GetName()]; ok {
+			nodes[v.RevisionName()] = n
+		}
+	}
+	for _, v := range r.ArtifactRevisions {
+		if _, ok := nodes[v.RevisionName()]; ok {
+			continue // The current revision is the artifact.
+		}
+		m, err := v.Message()
+		if err != nil {
+			return nil, err
+		}
+		m.Name = v.RevisionName()
+		add(v.Name(), v.RevisionName(), &rpc.ArchiveEntry{Entry: &rpc.ArchiveEntry_ArtifactRevision{ArtifactRevision: m}})
+	}
+	for _, v := range r.ArtifactRevisionTags {
+		artifact := models.Artifact{
+			ProjectID:          v.ProjectID,
+			ApiID:              v.ApiID,
+			VersionID:          v.VersionID,
+			SpecID:             v.SpecID,
+			RevisionID:         v.RevisionID,
+			DeploymentID:       v.DeploymentID,
+			ArtifactID:         v.ArtifactID,
+			ArtifactRevisionID: v.ArtifactRevisionID,
+		}
+		revision := artifact.RevisionName()
+		add(revision, v.String(), &rpc.ArchiveEntry{Entry: &rpc.ArchiveEntry_Tag{Tag: revisionTagMessage(revision, v.Tag, v.CreateTime, v.UpdateTime)}})
+	}
+
+	var entries []*rpc.ArchiveEntry
+	var walk func(n *archiveNode)
+	walk = func(n *archiveNode) {
+		clearEtag(n.entry)
+		entries = append(entries, n.entry)
+		for _, c := range n.children {
+			walk(c)
+		}
+	}
+	walk(root)
+	return entries, nil
+}
+
+// clearEtag removes the etag of an entry. Etags depend on resource names,
+// so they are recomputed when archives are imported.
+func clearEtag(entry *rpc.ArchiveEntry) {
+	switch e := entry.Entry.(type) {
+	case *rpc.ArchiveEntry_Api:
+		e.Api.Etag = ""
+	case *rpc.ArchiveEntry_Version:
+		e.Version.Etag = ""
+	case *rpc.ArchiveEntry_Spec:
+		e.Spec.Etag = ""
+	case *rpc.ArchiveEntry_Deployment:
+		e.Deployment.Etag = ""
+	case *rpc.ArchiveEntry_Artifact:
+		e.Artifact.Etag = ""
+	case *rpc.ArchiveEntry_ArtifactRevision:
+		e.ArtifactRevision.Etag = ""
+	}
+}
+
+func revisionTagMessage(revision, tag string, createTime, updateTime time.Time) *rpc.ArchiveEntry_RevisionTag {
+	return &rpc.ArchiveEntry_RevisionTag{
+		Revision:   revision,
+		Tag:        tag,
+		CreateTime: timestamppb.New(createTime),
+		UpdateTime: timestamppb.New(updateTime),
+	}
+}
+
+// readEntryContents adds the contents of specs and artifacts to their entries.
+func readEntryContents(ctx context.Context, db *storage.Client, entry *rpc.ArchiveEntry) error {
+	switch e := entry.Entry.(type) {
+	case *rpc.ArchiveEntry_Spec:
+		if e.Spec.GetHash() == "" {
+			return nil
+		}
+		revision, err := names.ParseSpecRevision(e.Spec.GetName())
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		blob, err := db.GetSpecRevisionContents(ctx, revision)
+		if err != nil {
+			return err
+		}
+		e.Spec.Contents = blob.Contents
+	case *rpc.ArchiveEntry_Artifact:
+		if e.Artifact.GetHash() == "" {
+			return nil
+		}
+		artifact, err := names.ParseArtifact(e.Artifact.GetName())
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		blob, err := db.GetArtifactContents(ctx, artifact)
+		if err != nil {
+			return err
+		}
+		e.Artifact.Contents = blob.Contents
+	case *rpc.ArchiveEntry_ArtifactRevision:
+		if e.ArtifactRevision.GetHash() == "" {
+			return nil
+		}
+		revision, err := names.ParseArtifactRevision(e.ArtifactRevision.GetName())
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		blob, err := db.GetArtifactRevisionContents(ctx, revision)
+		if err != nil {
+			return err
+		}
+		e.ArtifactRevision.Contents = blob.Contents
+	}
+	return nil
+}
+
+// clearEntryContents removes the contents of specs and artifacts from their entries.
+func clearEntryContents(entry *rpc.ArchiveEntry) {
+	switch e := entry.Entry.(type) {
+	case *rpc.ArchiveEntry_Spec:
+		e.Spec.Contents = nil
+	case *rpc.ArchiveEntry_Artifact:
+		e.Artifact.Contents = nil
+	case *rpc.ArchiveEntry_ArtifactRevision:
+		e.ArtifactRevision.Contents = nil
+	}
+}
+
+// exportProject writes an archive of a project to a file. It reports the
+// number of entries written as it goes and returns the number of resources
+// in the archive.
+func exportProject(ctx context.Context, db *storage.Client, name names.Project, f *archiveFile, progress func(completed, total int)) (int, error) {
+	resources, err := db.GetProjectResources(ctx, name)
+	if err != nil {
+		return 0, err
+	}
+	entries, err := archiveEntries(resources)
+	if err != nil {
+		return 0, status.Error(codes.Internal, err.Error())
+	}
+
+	w := newArchiveWriter(f)
+	if err := w.write(&rpc.ArchiveEntry{Entry: &rpc.ArchiveEntry_Header_{Header: &rpc.ArchiveEntry_Header{
+		FormatVersion: archiveFormatVersion,
+		Project:       name.String(),
+		ResourceCount: int32(len(entries)),
+		CreateTime:    timestamppb.Now(),
+	}}}); err != nil {
+		return 0, archiveWriteError(err)
+	}
+	for i, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		// Contents are read as they are written so that they aren't all held at once.
+		if err := readEntryContents(ctx, db, entry); err != nil {
+			return 0, err
+		}
+		if err := w.write(entry); err != nil {
+			return 0, archiveWriteError(err)
+		}
+		clearEntryContents(entry)
+		if (i+1)%archiveProgressInterval == 0 {
+			progress(i+1, len(entries))
+		}
+	}
+	if err := w.close(); err != nil {
+		return 0, archiveWriteError(err)
+	}
+	return len(entries), nil
+}
+
+// archiveWriteError returns an error for a failed write of an archive.
+// Errors of archive files already have statuses, which are kept.
+func archiveWriteError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+// importProject imports the resources of an archive into a project. Resources
+// are saved with the names, revision IDs and timestamps that they had when they
+// were exported, so importing an archive again has no further effect. Each
+// resource is saved in its own transaction, and an import that fails can be
+// completed by importing the archive again. It reports the number of entries
+// imported as it goes and returns the number of resources imported.
+func (s *RegistryServer) importProject(ctx context.Context, r *archiveReader, project names.Project, progress func(completed, total int)) (int, error) {
+	from, to := r.header.GetProject(), project.String()
+	rename := func(name string) (string, error) {
+		if name != from && !strings.HasPrefix(name, from+"/") {
+			return "", status.Errorf(codes.InvalidArgument, "invalid archive: %s is not in %s", name, from)
+		}
+		return to + strings.TrimPrefix(name, from), nil
+	}
+
+	total := int(r.header.GetResourceCount())
+	n := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return n, err
+		}
+		entry, err := r.next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return n, err
+		}
+		if err := s.runInTransaction(ctx, func(ctx context.Context, db *storage.Client) error {
+			return s.importEntry(ctx, db, entry, rename, "ImportProject", rpc.Notification_UPDATED)
+		}); err != nil {
+			return n, err
+		}
+		n++
+		if n%archiveProgressInterval == 0 {
+			progress(n, total)
+		}
+	}
+	if n != total {
+		return n, status.Errorf(codes.InvalidArgument, "invalid archive: found %d entries, want %d", n, total)
+	}
+	return n, nil
+}
+
+// importEntry saves the resource of an archive entry with a name and
+// references changed by rename. The change is notified and audited as a
+// change of a specified kind made by a specified method.
+func (s *RegistryServer) importEntry(ctx context.Context, db *storage.Client, entry *rpc.ArchiveEntry, rename func(string) (string, error), method string, change rpc.Notification_Change) (err error) {
+	// References to other resources of the project are renamed with the resources.
+	renameRef := func(ref *string) {
+		if *ref != "" && err == nil {
+			*ref, err = rename(*ref)
+		}
+	}
+	var name string
+	var message proto.Message
+	switch e := entry.Entry.(type) {
+	case *rpc.ArchiveEntry_Project:
+		m := proto.Clone(e.Project).(*rpc.Project)
+		renameRef(&m.Name)
+		if err != nil {
+			return err
+		}
+		name, message = m.GetName(), m
+		err = s.importProjectEntry(ctx, db, m)
+	case *rpc.ArchiveEntry_Api:
+		m := proto.Clone(e.Api).(*rpc.Api)
+		renameRef(&m.Name)
+		renameRef(&m.RecommendedVersion)
+		renameRef(&m.RecommendedDeployment)
+		if err != nil {
+			return err
+		}
+		name, message = m.GetName(), m
+		err = importApi(ctx, db, m)
+	case *rpc.ArchiveEntry_Version:
+		m := proto.Clone(e.Version).(*rpc.ApiVersion)
+		renameRef(&m.Name)
+		renameRef(&m.PrimarySpec)
+		if err != nil {
+			return err
+		}
+		name, message = m.GetName(), m
+		err = importVersion(ctx, db, m)
+	case *rpc.ArchiveEntry_Spec:
+		m := proto.Clone(e.Spec).(*rpc.ApiSpec)
+		renameRef(&m.Name)
+		if err != nil {
+			return err
+		}
+		name, message = m.GetName(), m
+		err = importSpecRevision(ctx, db, m)
+	case *rpc.ArchiveEntry_Deployment:
+		m := proto.Clone(e.Deployment).(*rpc.ApiDeployment)
+		renameRef(&m.Name)
+		renameRef(&m.ApiSpecRevision)
+		if err != nil {
+			return err
+		}
+		name, message = m.GetName(), m
+		err = importDeploymentRevision(ctx, db, m)
+	case *rpc.ArchiveEntry_Artifact:
+		m := proto.Clone(e.Artifact).(*rpc.Artifact)
+		renameRef(&m.Name)
+		if err != nil {
+			return err
+		}
+		name, message = m.GetName(), m
+		err = importArtifact(ctx, db, m)
+	case *rpc.ArchiveEntry_ArtifactRevision:
+		m := proto.Clone(e.ArtifactRevision).(*rpc.Artifact)
+		renameRef(&m.Name)
+		if err != nil {
+			return err
+		}
+		name, message = m.GetName(), m
+		err = importArtifactRevision(ctx, db, m)
+	case *rpc.ArchiveEntry_Tag:
+		m := proto.Clone(e.Tag).(*rpc.ArchiveEntry_RevisionTag)
+		renameRef(&m.Revision)
+		if err != nil {
+			return err
+		}
+		name, message = m.GetRevision(), m
+		err = importRevisionTag(ctx, db, m)
+	default:
+		return status.Errorf(codes.InvalidArgument, "invalid archive: unexpected entry %v", entry)
+	}
+	if err != nil {
+		return err
+	}
+	// Content is omitted from audit events.
+	switch m := message.(type) {
+	case *rpc.ApiSpec:
+		m.Contents = nil
+	case *rpc.Artifact:
+		m.Contents = nil
+	}
+	s.notify(ctx, change, name)
+	s.audit(ctx, method, name, nil, message)
+	return nil
+}
+
+func (s *RegistryServer) importProjectEntry(ctx context.Context, db *storage.Client, m *rpc.Project) error {
+	name, err := names.ParseProject(m.GetName())
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid archive: %s", err)
+	}
+	v := models.NewProject(name, m)
+	v.CreateTime, v.UpdateTime = m.GetCreateTime().AsTime(), m.GetUpdateTime().AsTime()
+	return db.LockProject(ctx, name).SaveProject(ctx, v)
+}
+
+func importApi(ctx context.Context, db *storage.Client, m *rpc.Api) error {
+	name, err := names.ParseApi(m.GetName())
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid archive: %s", err)
+	}
+	v, err := models.NewApi(name, m)
+	if err != nil {
+		return err
+	}
+	v.CreateTime, v.UpdateTime = m.GetCreateTime().AsTime(), m.GetUpdateTime().AsTime()
+	return db.LockApi(ctx, name).SaveApi(ctx, v)
+}
+
+func importVersion(ctx context.Context, db *storage.Client, m *rpc.ApiVersion) error {
+	name, err := names.ParseVersion(m.GetName())
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid archive: %s", err)
+	}
+	v, err := models.NewVersion(name, m)
+	if err != nil {
+		return err
+	}
+	v.CreateTime, v.UpdateTime = m.GetCreateTime().AsTime(), m.GetUpdateTime().AsTime()
+	return db.LockVersion(ctx, name).SaveVersion(ctx, v)
+}
+
+// checkHash returns an error if the hash or size that a model computed from
+// imported contents differs from the one in the archive. Contents are stored
+// by their hash, so contents with another hash could replace those of other
+// resources.
+func checkHash(name, hash string, size int32, m interface {
+	GetHash() string
+	GetSizeBytes() int32
+}) error {
+	if hash != m.GetHash() || size != m.GetSizeBytes() {
+		return status.Errorf(codes.InvalidArgument, "invalid archive: the hash and size of %q don't match its contents", name)
+	}
+	return nil
+}
+
+func importSpecRevision(ctx context.Context, db *storage.Client, m *rpc.ApiSpec) error {
+	name, err := names.ParseSpecRevision(m.GetName())
+	if err != nil || name.RevisionID == "" {
+		return status.Errorf(codes.InvalidArgument, "invalid archive: %q is not a spec revision", m.GetName())
+	}
+	v, err := models.NewSpec(name.Spec(), m)
+	if err != nil {
+		return err
+	}
+	v.RevisionID = name.RevisionID
+	if len(m.GetContents()) == 0 {
+		v.Hash, v.SizeInBytes = m.GetHash(), m.GetSizeBytes()
+	} else if err := checkHash(m.GetName(), v.Hash, v.SizeInBytes, m); err != nil {
+		return err
+	}
+	v.CreateTime = m.GetCreateTime().AsTime()
+	v.RevisionCreateTime, v.RevisionUpdateTime = m.GetRevisionCreateTime().AsTime(), m.GetRevisionUpdateTime().AsTime()
+	db = db.LockSpec(ctx, name.Spec())
+	if err := db.SaveSpecRevision(ctx, v); err != nil {
+		return err
+	}
+	if len(m.GetContents()) == 0 {
+		return nil
+	}
+	return db.SaveSpecRevisionContents(ctx, v, m.GetContents())
+}
+
+func importDeploymentRevision(ctx context.Context, db *storage.Client, m *rpc.ApiDeployment) error {
+	name, err := names.ParseDeploymentRevision(m.GetName())
+	if err != nil || name.RevisionID == "" {
+		return status.Errorf(codes.InvalidArgument, "invalid archive: %q is not a deployment revision", m.GetName())
+	}
+	v, err := models.NewDeployment(name.Deployment(), m)
+	if err != nil {
+		return err
+	}
+	v.RevisionID = name.RevisionID
+	v.CreateTime = m.GetCreateTime().AsTime()
+	v.RevisionCreateTime, v.RevisionUpdateTime = m.GetRevisionCreateTime().AsTime(), m.GetRevisionUpdateTime().AsTime()
+	return db.LockDeployment(ctx, name.Deployment()).SaveDeploymentRevision(ctx, v)
+}
+
+func importArtifact(ctx context.Context, db *storage.Client, m *rpc.Artifact) error {
+	name, err := names.ParseArtifact(m.GetName())
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid archive: %s", err)
+	}
+	v, err := models.NewArtifact(name, m)
+	if err != nil {
+		return err
+	}
+	if len(m.GetContents()) == 0 {
+		v.Hash, v.SizeInBytes = m.GetHash(), m.GetSizeBytes()
+	} else if err := checkHash(m.GetName(), v.Hash, v.SizeInBytes, m); err != nil {
+		return err
+	}
+	v.CreateTime, v.UpdateTime = m.GetCreateTime().AsTime(), m.GetUpdateTime().AsTime()
+	if id := m.GetRevisionId(); id != "" {
+		v.ArtifactRevisionID = id
+	}
+	db = db.LockArtifact(ctx, name)
+	if err := db.SaveArtifact(ctx, v); err != nil {
+		return err
+	}
+	if len(m.GetContents()) == 0 {
+		return db.SaveArtifactRevision(ctx, models.NewArtifactRevision(v, ""))
+	}
+	return db.SaveArtifactContents(ctx, v, m.GetContents())
+}
+
+// importArtifactRevision saves an earlier revision of an artifact, which
+// doesn't change the current revision that is saved with the artifact.
+func importArtifactRevision(ctx context.Context, db *storage.Client, m *rpc.Artifact) error {
+	name, err := names.ParseArtifactRevision(m.GetName())
+	if err != nil || name.RevisionID == "" {
+		return status.Errorf(codes.InvalidArgument, "invalid archive: %q is not an artifact revision", m.GetName())
+	}
+	v, err := models.NewArtifact(name.Artifact(), m)
+	if err != nil {
+		return err
+	}
+	v.ArtifactRevisionID = name.RevisionID
+	if len(m.GetContents()) == 0 {
+		v.Hash, v.SizeInBytes = m.GetHash(), m.GetSizeBytes()
+	} else if err := checkHash(m.GetName(), v.Hash, v.SizeInBytes, m); err != nil {
+		return err
+	}
+	v.CreateTime, v.UpdateTime = m.GetCreateTime().AsTime(), m.GetUpdateTime().AsTime()
+	return db.LockArtifact(ctx, name.Artifact()).SaveArtifactRevisionContents(ctx, v, m.GetContents())
+}
+
+func importRevisionTag(ctx context.Context, db *storage.Client, m *rpc.ArchiveEntry_RevisionTag) error {
+	createTime, updateTime := m.GetCreateTime().AsTime(), m.GetUpdateTime().AsTime()
+	if name, err := names.ParseSpecRevision(m.GetRevision()); err == nil && name.RevisionID != "" {
+		v := models.NewSpecRevisionTag(name, m.GetTag())
+		v.CreateTime, v.UpdateTime = createTime, updateTime
+		return db.LockSpec(ctx, name.Spec()).SaveSpecRevisionTag(ctx, v)
+	}
+	if name, err := names.ParseDeploymentRevision(m.GetRevision()); err == nil && name.RevisionID != "" {
+		v := models.NewDeploymentRevisionTag(name, m.GetTag())
+		v.CreateTime, v.UpdateTime = createTime, updateTime
+		return db.LockDeployment(ctx, name.Deployment()).SaveDeploymentRevisionTag(ctx, v)
+	}
+	if name, err := names.ParseArtifactRevision(m.GetRevision()); err == nil && name.RevisionID != "" {
+		artifact, err := models.NewArtifact(name.Artifact(), &rpc.Artifact{})
+		if err != nil {
+			return err
+		}
+		artifact.ArtifactRevisionID = name.RevisionID
+		v := models.NewArtifactRevisionTag(artifact, m.GetTag())
+		v.CreateTime, v.UpdateTime = createTime, updateTime
+		return db.LockArtifact(ctx, name.Artifact()).SaveArtifactRevisionTag(ctx, v)
+	}
+	return status.Errorf(codes.InvalidArgument, "invalid archive: %q is not a revision", m.GetRevision())
+}
@@ -0,0 +1,232 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/schema"
+	"github.com/apigee/registry/server/registry/internal/storage"
+	"github.com/apigee/registry/server/registry/internal/storage/models"
+	"github.com/apigee/registry/server/registry/names"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+const (
+	// messageMimeTypePrefix begins the MIME types of artifacts that contain
+	// serialized Protocol Buffer messages, e.g.
+	// "application/octet-stream;type=google.cloud.apigeeregistry.v1.scoring.ScoreDefinition".
+	messageMimeTypePrefix = "application/octet-stream;type="
+	// maxReportedViolations is the largest number of violations described in an error.
+	maxReportedViolations = 100
+)
+
+// schemaMimeTypePrefixes begin the MIME types of project artifacts that
+// register JSON Schemas for artifacts of a type, e.g. "application/schema+json;type=Owners".
+var schemaMimeTypePrefixes = []string{
+	"application/schema+json;type=",
+	"application/schema+yaml;type=",
+}
+
+// documentMimeTypePrefixes begin the MIME types of artifacts that are
+// validated with the schemas registered in their projects, e.g. "application/yaml;type=Owners".
+var documentMimeTypePrefixes = []string{
+	"application/json;type=",
+	"application/yaml;type=",
+}
+
+// validateArtifactContents returns an INVALID_ARGUMENT error if the contents
+// of an artifact don't match its MIME type. Contents of registered Protocol
+// Buffer message types must be valid messages, contents of schema artifacts
+// must be valid schemas, and JSON and YAML contents must match the schema
+// registered for their type in the project, if there is one. Contents of
+// other types aren't checked.
+func validateArtifactContents(ctx context.Context, db *storage.Client, name names.Artifact, artifact *rpc.Artifact) error {
+	contents, err := uncompressedContents(artifact.GetMimeType(), artifact.GetContents())
+	if err != nil {
+		return invalidContents(name, err.Error(), nil)
+	}
+	mimeType := strings.ReplaceAll(artifact.GetMimeType(), "+gzip", "")
+
+	if messageType := strings.TrimPrefix(mimeType, messageMimeTypePrefix); messageType != mimeType {
+		mt, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(messageType))
+		if err != nil {
+			return nil // Unknown message types can't be checked.
+		}
+		m := mt.New()
+		if err := proto.Unmarshal(contents, m.Interface()); err != nil {
+			return invalidContents(name, fmt.Sprintf("contents are not a valid %s", messageType), []schema.Violation{{Description: err.Error()}})
+		}
+		// Many byte strings, including JSON and YAML text, parse as fields that the type doesn't have.
+		if field := unknownField(m, ""); field != "" {
+			return invalidContents(name, fmt.Sprintf("contents are not a valid %s", messageType), []schema.Violation{{Description: fmt.Sprintf("%s has unknown fields", field)}})
+		}
+		return nil
+	}
+
+	if typeParameter(mimeType, schemaMimeTypePrefixes) != "" {
+		if _, err := schema.Parse(contents); err != nil {
+			return invalidContents(name, "contents are not a valid schema", []schema.Violation{{Description: err.Error()}})
+		}
+		return nil
+	}
+
+	if kind := typeParameter(mimeType, documentMimeTypePrefixes); kind != "" {
+		s, err := registeredSchema(ctx, db, name.ProjectID(), kind)
+		if err != nil || s == nil {
+			return err
+		}
+		violations, err := s.Validate(contents)
+		if err != nil {
+			return invalidContents(name, fmt.Sprintf("contents can't be parsed as %s", kind), []schema.Violation{{Description: err.Error()}})
+		}
+		if len(violations) > 0 {
+			return invalidContents(name, fmt.Sprintf("contents don't match the schema registered for %s", kind), violations)
+		}
+	}
+	return nil
+}
+
+// unknownField returns the path of the first message in m, which is at path,
+// that has unknown fields, or "" if there are none.
+func unknownField(m protoreflect.Message, path string) string {
+	if len(m.GetUnknown()) > 0 {
+		if path == "" {
+			return string(m.Descriptor().FullName())
+		}
+		return path
+	}
+	found := ""
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		p := string(fd.Name())
+		if path != "" {
+			p = path + "." + p
+		}
+		switch {
+		case fd.IsList() && fd.Message() != nil:
+			for i := 0; i < v.List().Len() && found == ""; i++ {
+				found = unknownField(v.List().Get(i).Message(), fmt.Sprintf("%s[%d]", p, i))
+			}
+		case fd.IsMap() && fd.MapValue().Message() != nil:
+			v.Map().Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+				found = unknownField(v.Message(), fmt.Sprintf("%s[%v]", p, k.Interface()))
+				return found == ""
+			})
+		case !fd.IsList() && !fd.IsMap() && fd.Message() != nil:
+			found = unknownField(v.Message(), p)
+		}
+		return found == ""
+	})
+	return found
+}
+
+// registeredSchema returns the schema registered for a type in a project, or nil if there is none.
+// If more than one schema is registered for a type, the first by name is used.
+func registeredSchema(ctx context.Context, db *storage.Client, projectID, kind string) (*schema.Schema, error) {
+	var filters []string
+	for _, prefix := range schemaMimeTypePrefixes {
+		filters = append(filters, "mime_type == "+strconv.Quote(prefix+kind))
+	}
+	list, err := db.ListProjectArtifacts(ctx, names.Project{ProjectID: projectID}, storage.PageOptions{
+		Size:   1,
+		Filter: strings.Join(filters, " || "),
+		Order:  "name",
+	})
+	if err != nil || len(list.Artifacts) == 0 {
+		return nil, err
+	}
+	registered := list.Artifacts[0]
+	name, err := names.ParseArtifact(registered.Name())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	blob, err := db.GetArtifactContents(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	contents, err := uncompressedContents(registered.MimeType, blob.Contents)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "schema %s can't be read: %s", name, err)
+	}
+	s, err := schema.Parse(contents)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "schema %s is invalid: %s", name, err)
+	}
+	return s, nil
+}
+
+// typeParameter returns the type parameter of a MIME type that begins with one of a list of prefixes.
+func typeParameter(mimeType string, prefixes []string) string {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(mimeType, prefix) {
+			return strings.TrimPrefix(mimeType, prefix)
+		}
+	}
+	return ""
+}
+
+func uncompressedContents(mimeType string, contents []byte) ([]byte, error) {
+	if strings.Contains(mimeType, "+gzip") && len(contents) > 0 {
+		return models.GUnzippedBytes(contents)
+	}
+	return contents, nil
+}
+
+// invalidContents returns an INVALID_ARGUMENT error for the contents of an
+// artifact, with details that describe the violations in its fields.
+func invalidContents(name names.Artifact, description string, violations []schema.Violation) error {
+	message := fmt.Sprintf("invalid contents of %s: %s", name, description)
+	if len(violations) > 0 {
+		message += ": " + describeViolation(violations[0])
+		if n := len(violations) - 1; n > 0 {
+			message += fmt.Sprintf(" (and %d more)", n)
+		}
+	}
+	st := status.New(codes.InvalidArgument, message)
+	request := &errdetails.BadRequest{}
+	for i, v := range violations {
+		if i == maxReportedViolations {
+			break
+		}
+		field := "artifact.contents"
+		if v.Field != "" {
+			field += "." + v.Field
+		}
+		request.FieldViolations = append(request.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       field,
+			Description: v.Description,
+		})
+	}
+	if detailed, err := st.WithDetails(request); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+func describeViolation(v schema.Violation) string {
+	if v.Field == "" {
+		return v.Description
+	}
+	return v.Field + " " + v.Description
+}
@@ -0,0 +1,167 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/test/seeder"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestArtifactContentsValidation(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	if err := seeder.SeedProjects(ctx, server, &rpc.Project{Name: "projects/my-project"}); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+	const parent = "projects/my-project/locations/global"
+	definition, err := proto.Marshal(&rpc.ScoreDefinition{Id: "score"})
+	if err != nil {
+		t.Fatalf("Setup: Failed to marshal score definition: %s", err)
+	}
+	zipped, err := gZippedBytes(definition)
+	if err != nil {
+		t.Fatalf("Setup: Failed to compress score definition: %s", err)
+	}
+	// Schemas are registered as project artifacts.
+	if _, err := server.CreateArtifact(ctx, &rpc.CreateArtifactRequest{
+		Parent:     parent,
+		ArtifactId: "owners-schema",
+		Artifact: &rpc.Artifact{
+			MimeType: "application/schema+yaml;type=Owners",
+			Contents: []byte("type: object\nrequired: [team]\nproperties:\n  team:\n    type: string\n"),
+		},
+	}); err != nil {
+		t.Fatalf("Setup: CreateArtifact() of schema returned error: %s", err)
+	}
+
+	tests := []struct {
+		desc       string
+		artifact   *rpc.Artifact
+		want       codes.Code
+		violations []*errdetails.BadRequest_FieldViolation
+	}{
+		{
+			desc:     "valid message",
+			artifact: &rpc.Artifact{MimeType: "application/octet-stream;type=google.cloud.apigeeregistry.v1.scoring.ScoreDefinition", Contents: definition},
+			want:     codes.OK,
+		},
+		{
+			desc:     "valid compressed message",
+			artifact: &rpc.Artifact{MimeType: "application/octet-stream;type=google.cloud.apigeeregistry.v1.scoring.ScoreDefinition+gzip", Contents: zipped},
+			want:     codes.OK,
+		},
+		{
+			desc:     "invalid message",
+			artifact: &rpc.Artifact{MimeType: "application/octet-stream;type=google.cloud.apigeeregistry.v1.scoring.ScoreDefinition", Contents: []byte("not a score definition")},
+			want:     codes.InvalidArgument,
+		},
+		{
+			desc:     "YAML text that parses as a message",
+			artifact: &rpc.Artifact{MimeType: "application/octet-stream;type=google.cloud.apigeeregistry.v1.scoring.ScoreDefinition", Contents: []byte("x: 1")},
+			want:     codes.InvalidArgument,
+		},
+		{
+			desc:     "message with unknown fields",
+			artifact: &rpc.Artifact{MimeType: "application/octet-stream;type=google.cloud.apigeeregistry.v1.scoring.ScoreDefinition", Contents: append(definition, 0xf8, 0x06, 0x01)},
+			want:     codes.InvalidArgument,
+			violations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "artifact.contents", Description: "google.cloud.apigeeregistry.v1.scoring.ScoreDefinition has unknown fields"},
+			},
+		},
+		{
+			desc:     "unknown message type",
+			artifact: &rpc.Artifact{MimeType: "application/octet-stream;type=example.Unknown", Contents: []byte("anything")},
+			want:     codes.OK,
+		},
+		{
+			desc:     "invalid schema",
+			artifact: &rpc.Artifact{MimeType: "application/schema+json;type=Teams", Contents: []byte(`{"type": "thing"}`)},
+			want:     codes.InvalidArgument,
+		},
+		{
+			desc:     "document matching schema",
+			artifact: &rpc.Artifact{MimeType: "application/yaml;type=Owners", Contents: []byte("team: payments\n")},
+			want:     codes.OK,
+		},
+		{
+			desc:     "document not matching schema",
+			artifact: &rpc.Artifact{MimeType: "application/json;type=Owners", Contents: []byte(`{"team": 7}`)},
+			want:     codes.InvalidArgument,
+			violations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "artifact.contents.team", Description: "must be of type string, not integer"},
+			},
+		},
+		{
+			desc:     "document missing required field",
+			artifact: &rpc.Artifact{MimeType: "application/yaml;type=Owners", Contents: []byte("{}")},
+			want:     codes.InvalidArgument,
+			violations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "artifact.contents", Description: `missing required property "team"`},
+			},
+		},
+		{
+			desc:     "document without schema",
+			artifact: &rpc.Artifact{MimeType: "application/yaml;type=Teams", Contents: []byte("team: 7\n")},
+			want:     codes.OK,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			req := &rpc.CreateArtifactRequest{Parent: parent, ArtifactId: "artifact", Artifact: test.artifact}
+			_, err := server.CreateArtifact(ctx, req)
+			if status.Code(err) != test.want {
+				t.Fatalf("CreateArtifact(%+v) returned status code %q, want %q: %v", req, status.Code(err), test.want, err)
+			}
+			if err == nil {
+				if _, err := server.DeleteArtifact(ctx, &rpc.DeleteArtifactRequest{Name: parent + "/artifacts/artifact"}); err != nil {
+					t.Fatalf("DeleteArtifact() returned error: %s", err)
+				}
+				return
+			}
+			if test.violations == nil {
+				return
+			}
+			var got []*errdetails.BadRequest_FieldViolation
+			for _, d := range status.Convert(err).Details() {
+				if request, ok := d.(*errdetails.BadRequest); ok {
+					got = append(got, request.GetFieldViolations()...)
+				}
+			}
+			if diff := cmp.Diff(test.violations, got, cmp.Comparer(proto.Equal)); diff != "" {
+				t.Errorf("CreateArtifact(%+v) returned unexpected violations (-want +got):\n%s", req, diff)
+			}
+		})
+	}
+
+	// Replacements are validated too.
+	req := &rpc.ReplaceArtifactRequest{
+		Artifact: &rpc.Artifact{
+			Name:     parent + "/artifacts/owners-schema",
+			MimeType: "application/schema+yaml;type=Owners",
+			Contents: []byte("type: [object"),
+		},
+	}
+	if _, err := server.ReplaceArtifact(ctx, req); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ReplaceArtifact(%+v) returned status code %q, want %q: %v", req, status.Code(err), codes.InvalidArgument, err)
+	}
+}
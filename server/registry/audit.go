@@ -0,0 +1,130 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+
+	"github.com/apigee/registry/log"
+	"github.com/apigee/registry/server/registry/auth"
+	"github.com/apigee/registry/server/registry/internal/storage/models"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// callerMetadataKeys are metadata keys that identify callers, in order of preference.
+// They are set by authenticating proxies in front of the server, and are only trusted
+// when the server is configured to run behind such a proxy.
+var callerMetadataKeys = []string{
+	"x-goog-authenticated-user-email",
+	"x-forwarded-email",
+	"x-forwarded-user",
+}
+
+// unauthenticatedCaller identifies callers that didn't authenticate.
+const unauthenticatedCaller = "unauthenticated"
+
+// auditIgnoredFields are fields that change with every update and are omitted from audit events.
+var auditIgnoredFields = map[protoreflect.Name]bool{
+	"create_time":          true,
+	"update_time":          true,
+	"revision_create_time": true,
+	"revision_update_time": true,
+}
+
+// audit records a change made by a method in the current transaction.
+// The resource is described before and after the change; before is nil
+// for created resources and both are nil for deleted resources.
+func (s *RegistryServer) audit(ctx context.Context, method, resource string, before, after proto.Message) {
+	c, ok := ctx.Value(changesKey{}).(*changes)
+	if !ok {
+		log.FromContext(ctx).Errorf("Dropped audit event for change to %s made outside of a transaction.", resource)
+		return
+	}
+	c.auditEvents = append(c.auditEvents, models.NewAuditEvent(resource, method, s.caller(ctx), changedFields(before, after)))
+}
+
+// caller returns the identity of the caller that made a request. Callers are
+// identified by the principals that they authenticated as or, behind a trusted
+// proxy, by the metadata that the proxy sets. Other callers are unauthenticated
+// and are identified by their addresses, if known.
+func (s *RegistryServer) caller(ctx context.Context) string {
+	if principal, ok := auth.FromContext(ctx); ok {
+		return principal
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok && s.trustedProxy {
+		for _, key := range callerMetadataKeys {
+			if v := md.Get(key); len(v) > 0 && v[0] != "" {
+				return v[0]
+			}
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return unauthenticatedCaller + ":" + p.Addr.String()
+	}
+	return unauthenticatedCaller
+}
+
+// changedFields returns the top-level fields that differ between two messages.
+func changedFields(before, after proto.Message) *fieldmaskpb.FieldMask {
+	mask := &fieldmaskpb.FieldMask{}
+	if after == nil {
+		return mask
+	}
+	a := after.ProtoReflect()
+	b := a.New()
+	if before != nil {
+		b = before.ProtoReflect()
+	}
+	fields := a.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if auditIgnoredFields[fd.Name()] {
+			continue
+		}
+		if !fieldEqual(b, a, fd) {
+			mask.Paths = append(mask.Paths, string(fd.Name()))
+		}
+	}
+	return mask
+}
+
+// unchanged returns true if an update left a resource as it was before, apart
+// from the times and etag that change with every update.
+func unchanged(before, after proto.Message) bool {
+	if before == nil {
+		return false
+	}
+	for _, path := range changedFields(before, after).GetPaths() {
+		if path != "etag" {
+			return false
+		}
+	}
+	return true
+}
+
+func fieldEqual(a, b protoreflect.Message, fd protoreflect.FieldDescriptor) bool {
+	x, y := a.New(), b.New()
+	if a.Has(fd) {
+		x.Set(fd, a.Get(fd))
+	}
+	if b.Has(fd) {
+		y.Set(fd, b.Get(fd))
+	}
+	return proto.Equal(x.Interface(), y.Interface())
+}
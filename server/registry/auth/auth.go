@@ -0,0 +1,322 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package auth authenticates and authorizes requests to the registry server.
+//
+// Callers present bearer tokens that are either static API keys or JSON Web
+// Tokens signed by keys in a JSON Web Key Set. Authenticated principals are
+// granted roles in projects, and each method requires a role in the project
+// of the resource that it reads or changes.
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Role is a set of permissions granted to a principal.
+// Each role includes the permissions of the roles that precede it.
+type Role int
+
+const (
+	// None is the role of every authenticated principal.
+	None Role = iota
+	// Viewer can read resources.
+	Viewer
+	// Editor can read and change resources.
+	Editor
+	// Admin can also create, update and delete projects and manage the server.
+	Admin
+)
+
+// ParseRole returns the role with the specified name.
+func ParseRole(name string) (Role, error) {
+	switch name {
+	case "viewer":
+		return Viewer, nil
+	case "editor":
+		return Editor, nil
+	case "admin":
+		return Admin, nil
+	default:
+		return None, fmt.Errorf("invalid role %q: must be one of [viewer, editor, admin]", name)
+	}
+}
+
+func (r Role) String() string {
+	switch r {
+	case Viewer:
+		return "viewer"
+	case Editor:
+		return "editor"
+	case Admin:
+		return "admin"
+	default:
+		return "none"
+	}
+}
+
+// Config configures authentication and authorization.
+type Config struct {
+	// JWKS is the path of a JSON Web Key Set file with the public keys that verify JWTs.
+	// If empty, JWTs are not accepted.
+	JWKS string
+	// Issuer, if set, must match the "iss" claim of JWTs.
+	Issuer string
+	// Audience, if set, must be one of the values of the "aud" claim of JWTs.
+	Audience string
+	// Claim identifies principals in JWTs. Defaults to "sub".
+	Claim string
+	// APIKeys are static tokens that identify principals.
+	APIKeys []APIKey
+	// Bindings grant roles to principals.
+	Bindings []Binding
+}
+
+// APIKey is a static bearer token.
+type APIKey struct {
+	Key       string
+	Principal string
+}
+
+// Binding grants a role to a principal in a set of projects.
+type Binding struct {
+	// Principal is an authenticated identity, or "*" for all authenticated principals.
+	Principal string
+	Role      Role
+	// Projects are project IDs, or "*" for all projects.
+	Projects []string
+}
+
+// clockSkew is the tolerance allowed when checking JWT expiration and activation times.
+const clockSkew = time.Minute
+
+// Authorizer authenticates and authorizes requests.
+type Authorizer struct {
+	config Config
+	keys   *keySet
+	now    func() time.Time
+}
+
+// New returns an authorizer with the specified configuration.
+func New(config Config) (*Authorizer, error) {
+	if config.JWKS == "" && len(config.APIKeys) == 0 {
+		return nil, fmt.Errorf("a JWKS file or API keys must be configured")
+	}
+	if config.Claim == "" {
+		config.Claim = "sub"
+	}
+	for i, k := range config.APIKeys {
+		if k.Key == "" || k.Principal == "" {
+			return nil, fmt.Errorf("invalid API key %d: key and principal must be set", i)
+		}
+	}
+	for i, b := range config.Bindings {
+		if b.Principal == "" {
+			return nil, fmt.Errorf("invalid binding %d: principal must be set", i)
+		}
+		if b.Role == None {
+			return nil, fmt.Errorf("invalid binding %d: role must be set", i)
+		}
+	}
+
+	a := &Authorizer{config: config, now: time.Now}
+	if config.JWKS != "" {
+		keys, err := loadKeySet(config.JWKS)
+		if err != nil {
+			return nil, err
+		}
+		a.keys = keys
+	}
+	return a, nil
+}
+
+type principalKey struct{}
+
+// NewContext returns a context that carries an authenticated principal.
+func NewContext(ctx context.Context, principal string) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// FromContext returns the authenticated principal of a request, if any.
+func FromContext(ctx context.Context) (string, bool) {
+	principal, ok := ctx.Value(principalKey{}).(string)
+	return principal, ok
+}
+
+// UnaryInterceptor returns an interceptor that rejects unauthenticated and unauthorized requests.
+func (a *Authorizer) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		principal, err := a.authenticate(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err := a.authorize(principal, info.FullMethod, req); err != nil {
+			return nil, err
+		}
+		return handler(NewContext(ctx, principal), req)
+	}
+}
+
+// StreamInterceptor returns an interceptor that rejects unauthenticated and unauthorized streams.
+// Streams are authorized when their first request is received.
+func (a *Authorizer) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		principal, err := a.authenticate(ss.Context())
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authorizedStream{
+			ServerStream: ss,
+			ctx:          NewContext(ss.Context(), principal),
+			authorize: func(req interface{}) error {
+				return a.authorize(principal, info.FullMethod, req)
+			},
+		})
+	}
+}
+
+type authorizedStream struct {
+	grpc.ServerStream
+	ctx        context.Context
+	authorize  func(req interface{}) error
+	authorized bool
+}
+
+func (s *authorizedStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *authorizedStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if !s.authorized {
+		if err := s.authorize(m); err != nil {
+			return err
+		}
+		s.authorized = true
+	}
+	return nil
+}
+
+// authenticate returns the principal identified by the bearer token of a request.
+func (a *Authorizer) authenticate(ctx context.Context) (string, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return "", status.Error(codes.Unauthenticated, "missing bearer token")
+	}
+	scheme, token, ok := strings.Cut(values[0], " ")
+	if !ok || !strings.EqualFold(scheme, "bearer") || token == "" {
+		return "", status.Error(codes.Unauthenticated, "invalid authorization header: must be a bearer token")
+	}
+
+	for _, k := range a.config.APIKeys {
+		if subtle.ConstantTimeCompare([]byte(k.Key), []byte(token)) == 1 {
+			return k.Principal, nil
+		}
+	}
+	if a.keys == nil {
+		return "", status.Error(codes.Unauthenticated, "invalid bearer token")
+	}
+	claims, err := a.keys.verify(token)
+	if err != nil {
+		return "", status.Errorf(codes.Unauthenticated, "invalid bearer token: %s", err)
+	}
+	principal, err := a.validate(claims)
+	if err != nil {
+		return "", status.Errorf(codes.Unauthenticated, "invalid bearer token: %s", err)
+	}
+	return principal, nil
+}
+
+// validate checks the registered claims of a JWT and returns its principal.
+func (a *Authorizer) validate(claims map[string]interface{}) (string, error) {
+	now := a.now()
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return "", fmt.Errorf("missing exp claim")
+	}
+	if now.After(time.Unix(int64(exp), 0).Add(clockSkew)) {
+		return "", fmt.Errorf("token is expired")
+	}
+	if nbf, ok := claims["nbf"].(float64); ok && now.Add(clockSkew).Before(time.Unix(int64(nbf), 0)) {
+		return "", fmt.Errorf("token is not valid yet")
+	}
+	if a.config.Issuer != "" && claims["iss"] != a.config.Issuer {
+		return "", fmt.Errorf("unexpected issuer %v", claims["iss"])
+	}
+	if a.config.Audience != "" && !hasAudience(claims["aud"], a.config.Audience) {
+		return "", fmt.Errorf("unexpected audience %v", claims["aud"])
+	}
+	principal, ok := claims[a.config.Claim].(string)
+	if !ok || principal == "" {
+		return "", fmt.Errorf("missing %s claim", a.config.Claim)
+	}
+	return principal, nil
+}
+
+// hasAudience returns true if an "aud" claim, which can be a string or a list, includes an audience.
+func hasAudience(aud interface{}, audience string) bool {
+	switch v := aud.(type) {
+	case string:
+		return v == audience
+	case []interface{}:
+		for _, a := range v {
+			if a == audience {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// authorize returns an error if a principal does not have the role required to make a request.
+func (a *Authorizer) authorize(principal, method string, req interface{}) error {
+	role, project := requirement(method, req)
+	if a.role(principal, project) >= role {
+		return nil
+	}
+	if project == "" {
+		return status.Errorf(codes.PermissionDenied, "%s requires the %s role in all projects", principal, role)
+	}
+	return status.Errorf(codes.PermissionDenied, "%s requires the %s role in project %q", principal, role, project)
+}
+
+// role returns the highest role of a principal in a project.
+// An empty project refers to all projects.
+func (a *Authorizer) role(principal, project string) Role {
+	role := None
+	for _, b := range a.config.Bindings {
+		if b.Role <= role || (b.Principal != principal && b.Principal != "*") {
+			continue
+		}
+		for _, p := range b.Projects {
+			if p == "*" || (project != "" && p == project) {
+				role = b.Role
+				break
+			}
+		}
+	}
+	return role
+}
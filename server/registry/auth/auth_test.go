@@ -0,0 +1,519 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apigee/registry/rpc"
+	"google.golang.org/genproto/googleapis/longrunning"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+var (
+	rsaKey256, _ = rsa.GenerateKey(rand.Reader, 2048)
+	ecKey256, _  = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+)
+
+func encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// writeKeySet writes a JWKS file with the public test keys.
+func writeKeySet(t *testing.T) string {
+	t.Helper()
+	size := (ecKey256.Curve.Params().BitSize + 7) / 8
+	jwks := map[string]interface{}{
+		"keys": []map[string]string{
+			{
+				"kty": "RSA",
+				"kid": "rsa",
+				"alg": "RS256",
+				"use": "sig",
+				"n":   encode(rsaKey256.N.Bytes()),
+				"e":   encode(big.NewInt(int64(rsaKey256.E)).Bytes()),
+			},
+			{
+				"kty": "EC",
+				"kid": "ec",
+				"crv": "P-256",
+				"x":   encode(ecKey256.X.FillBytes(make([]byte, size))),
+				"y":   encode(ecKey256.Y.FillBytes(make([]byte, size))),
+			},
+			{
+				"kty": "oct",
+				"kid": "ignored",
+				"k":   encode([]byte("secret")),
+			},
+		},
+	}
+	b, err := json.Marshal(jwks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	filename := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(filename, b, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return filename
+}
+
+// sign returns a JWT with the specified claims signed by a test key.
+func sign(t *testing.T, alg, kid string, claims map[string]interface{}) string {
+	t.Helper()
+	header, _ := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	input := encode(header) + "." + encode(payload)
+	digest := sha256.Sum256([]byte(input))
+
+	var sig []byte
+	switch alg {
+	case "RS256":
+		var err error
+		sig, err = rsa.SignPKCS1v15(rand.Reader, rsaKey256, crypto.SHA256, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+	case "ES256":
+		r, s, err := ecdsa.Sign(rand.Reader, ecKey256, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	}
+	return input + "." + encode(sig)
+}
+
+func withToken(token string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+}
+
+func TestAuthenticate(t *testing.T) {
+	a, err := New(Config{
+		JWKS:     writeKeySet(t),
+		Issuer:   "https://issuer.example.com",
+		Audience: "registry",
+		Claim:    "email",
+		APIKeys:  []APIKey{{Key: "my-api-key", Principal: "robot@example.com"}},
+	})
+	if err != nil {
+		t.Fatalf("New() returned error: %s", err)
+	}
+	now := time.Now()
+	a.now = func() time.Time { return now }
+
+	claims := func(changes map[string]interface{}) map[string]interface{} {
+		c := map[string]interface{}{
+			"iss":   "https://issuer.example.com",
+			"aud":   []string{"other", "registry"},
+			"sub":   "1234",
+			"email": "user@example.com",
+			"exp":   now.Add(time.Hour).Unix(),
+			"nbf":   now.Add(-time.Hour).Unix(),
+		}
+		for k, v := range changes {
+			if v == nil {
+				delete(c, k)
+			} else {
+				c[k] = v
+			}
+		}
+		return c
+	}
+	valid := sign(t, "RS256", "rsa", claims(nil))
+
+	tests := []struct {
+		desc string
+		ctx  context.Context
+		want string
+		code codes.Code
+	}{
+		{
+			desc: "RSA token",
+			ctx:  withToken(valid),
+			want: "user@example.com",
+		},
+		{
+			desc: "EC token",
+			ctx:  withToken(sign(t, "ES256", "ec", claims(nil))),
+			want: "user@example.com",
+		},
+		{
+			desc: "token without key ID",
+			ctx:  withToken(sign(t, "ES256", "", claims(nil))),
+			want: "user@example.com",
+		},
+		{
+			desc: "single audience",
+			ctx:  withToken(sign(t, "RS256", "rsa", claims(map[string]interface{}{"aud": "registry"}))),
+			want: "user@example.com",
+		},
+		{
+			desc: "API key",
+			ctx:  withToken("my-api-key"),
+			want: "robot@example.com",
+		},
+		{
+			desc: "lowercase scheme",
+			ctx:  metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "bearer my-api-key")),
+			want: "robot@example.com",
+		},
+		{
+			desc: "missing metadata",
+			ctx:  context.Background(),
+			code: codes.Unauthenticated,
+		},
+		{
+			desc: "basic authorization",
+			ctx:  metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Basic dXNlcjpwYXNz")),
+			code: codes.Unauthenticated,
+		},
+		{
+			desc: "unknown API key",
+			ctx:  withToken("other-api-key"),
+			code: codes.Unauthenticated,
+		},
+		{
+			desc: "tampered token",
+			ctx:  withToken(valid[:len(valid)-4] + "AAAA"),
+			code: codes.Unauthenticated,
+		},
+		{
+			desc: "unknown key ID",
+			ctx:  withToken(sign(t, "RS256", "other", claims(nil))),
+			code: codes.Unauthenticated,
+		},
+		{
+			desc: "mismatched algorithm",
+			ctx:  withToken(sign(t, "ES256", "rsa", claims(nil))),
+			code: codes.Unauthenticated,
+		},
+		{
+			desc: "unsigned token",
+			ctx:  withToken(encode([]byte(`{"alg":"none"}`)) + "." + encode([]byte(`{"email":"user@example.com"}`)) + "."),
+			code: codes.Unauthenticated,
+		},
+		{
+			desc: "expired token",
+			ctx:  withToken(sign(t, "RS256", "rsa", claims(map[string]interface{}{"exp": now.Add(-time.Hour).Unix()}))),
+			code: codes.Unauthenticated,
+		},
+		{
+			desc: "token without expiration",
+			ctx:  withToken(sign(t, "RS256", "rsa", claims(map[string]interface{}{"exp": nil}))),
+			code: codes.Unauthenticated,
+		},
+		{
+			desc: "future token",
+			ctx:  withToken(sign(t, "RS256", "rsa", claims(map[string]interface{}{"nbf": now.Add(time.Hour).Unix()}))),
+			code: codes.Unauthenticated,
+		},
+		{
+			desc: "wrong issuer",
+			ctx:  withToken(sign(t, "RS256", "rsa", claims(map[string]interface{}{"iss": "https://other.example.com"}))),
+			code: codes.Unauthenticated,
+		},
+		{
+			desc: "wrong audience",
+			ctx:  withToken(sign(t, "RS256", "rsa", claims(map[string]interface{}{"aud": "other"}))),
+			code: codes.Unauthenticated,
+		},
+		{
+			desc: "missing principal",
+			ctx:  withToken(sign(t, "RS256", "rsa", claims(map[string]interface{}{"email": nil}))),
+			code: codes.Unauthenticated,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			got, err := a.authenticate(test.ctx)
+			if status.Code(err) != test.code {
+				t.Fatalf("authenticate() returned status code %q, want %q: %v", status.Code(err), test.code, err)
+			}
+			if got != test.want {
+				t.Errorf("authenticate() returned %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestUnaryInterceptor(t *testing.T) {
+	a, err := New(Config{
+		APIKeys: []APIKey{
+			{Key: "viewer-key", Principal: "viewer@example.com"},
+			{Key: "editor-key", Principal: "editor@example.com"},
+			{Key: "admin-key", Principal: "admin@example.com"},
+			{Key: "root-key", Principal: "root@example.com"},
+			{Key: "other-key", Principal: "other@example.com"},
+		},
+		Bindings: []Binding{
+			{Principal: "viewer@example.com", Role: Viewer, Projects: []string{"my-project"}},
+			{Principal: "editor@example.com", Role: Editor, Projects: []string{"my-project"}},
+			{Principal: "admin@example.com", Role: Admin, Projects: []string{"my-project"}},
+			{Principal: "root@example.com", Role: Admin, Projects: []string{"*"}},
+			{Principal: "*", Role: Viewer, Projects: []string{"public"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("New() returned error: %s", err)
+	}
+	interceptor := a.UnaryInterceptor()
+
+	const (
+		api     = "projects/my-project/locations/global/apis/a"
+		project = "projects/my-project"
+	)
+	tests := []struct {
+		method string
+		req    interface{}
+		// allowed lists the keys that can make the request.
+		allowed []string
+	}{
+		{"Admin/GetStatus", &emptypb.Empty{}, []string{"viewer-key", "editor-key", "admin-key", "root-key", "other-key"}},
+		{"Admin/GetStorage", &emptypb.Empty{}, []string{"root-key"}},
+		{"Admin/MigrateDatabase", &rpc.MigrateDatabaseRequest{}, []string{"root-key"}},
+		{"Admin/CompactRevisions", &rpc.CompactRevisionsRequest{Parent: project}, []string{"root-key"}},
+		{"/google.longrunning.Operations/GetOperation", &longrunning.GetOperationRequest{Name: "operations/x"}, []string{"root-key"}},
+		{"Admin/ListAuditEvents", &rpc.ListAuditEventsRequest{}, []string{"root-key"}},
+		{"Admin/CloneProject", &rpc.CloneProjectRequest{Name: project, ProjectId: "my-project-copy"}, []string{"root-key"}},
+		{"Admin/CopyApi", &rpc.CopyApiRequest{Name: api, Parent: project + "/locations/global", ApiId: "b"}, []string{"root-key"}},
+		{"Admin/ExportProject", &rpc.ExportProjectRequest{Name: project}, []string{"root-key"}},
+		{"Admin/ImportProject", &rpc.ImportProjectRequest{Archive: "archives/x", ProjectId: "my-project"}, []string{"root-key"}},
+		{"Admin/ReadArchive", &rpc.ReadArchiveRequest{Name: "archives/x"}, []string{"root-key"}},
+		{"Admin/AggregateResources", &rpc.AggregateResourcesRequest{Collection: project + "/locations/global/apis"}, []string{"viewer-key", "editor-key", "admin-key", "root-key"}},
+		{"Admin/AggregateResources", &rpc.AggregateResourcesRequest{Collection: "projects/-/locations/global/apis"}, []string{"root-key"}},
+		{"Admin/ListProjects", &rpc.ListProjectsRequest{}, []string{"root-key"}},
+		{"Admin/GetProject", &rpc.GetProjectRequest{Name: project}, []string{"viewer-key", "editor-key", "admin-key", "root-key"}},
+		{"Admin/GetProject", &rpc.GetProjectRequest{Name: "projects/public"}, []string{"viewer-key", "editor-key", "admin-key", "root-key", "other-key"}},
+		{"Admin/CreateProject", &rpc.CreateProjectRequest{ProjectId: "my-project"}, []string{"admin-key", "root-key"}},
+		{"Admin/CreateProject", &rpc.CreateProjectRequest{ProjectId: "new-project"}, []string{"root-key"}},
+		{"Admin/UpdateProject", &rpc.UpdateProjectRequest{Project: &rpc.Project{Name: project}}, []string{"admin-key", "root-key"}},
+		{"Admin/DeleteProject", &rpc.DeleteProjectRequest{Name: project}, []string{"admin-key", "root-key"}},
+		{"Registry/ListApis", &rpc.ListApisRequest{Parent: project + "/locations/global"}, []string{"viewer-key", "editor-key", "admin-key", "root-key"}},
+		{"Registry/ListApis", &rpc.ListApisRequest{Parent: "projects/-/locations/global"}, []string{"root-key"}},
+		{"Registry/GetApi", &rpc.GetApiRequest{Name: api}, []string{"viewer-key", "editor-key", "admin-key", "root-key"}},
+		{"Registry/CreateApi", &rpc.CreateApiRequest{Parent: project + "/locations/global"}, []string{"editor-key", "admin-key", "root-key"}},
+		{"Registry/UpdateApi", &rpc.UpdateApiRequest{Api: &rpc.Api{Name: api}}, []string{"editor-key", "admin-key", "root-key"}},
+		{"Registry/UpdateApi", &rpc.UpdateApiRequest{}, []string{"root-key"}},
+		{"Registry/DeleteApi", &rpc.DeleteApiRequest{Name: api}, []string{"editor-key", "admin-key", "root-key"}},
+		{"Registry/ReplaceArtifact", &rpc.ReplaceArtifactRequest{Artifact: &rpc.Artifact{Name: api + "/artifacts/x"}}, []string{"editor-key", "admin-key", "root-key"}},
+		{"Registry/ListArtifactRevisions", &rpc.ListArtifactRevisionsRequest{Name: api + "/artifacts/x@-"}, []string{"viewer-key", "editor-key", "admin-key", "root-key"}},
+		{"Registry/RollbackArtifact", &rpc.RollbackArtifactRequest{Name: api + "/artifacts/x", RevisionId: "abc"}, []string{"editor-key", "admin-key", "root-key"}},
+		{"Registry/ListReferrers", &rpc.ListReferrersRequest{Name: api}, []string{"viewer-key", "editor-key", "admin-key", "root-key"}},
+		{"Registry/SearchResources", &rpc.SearchResourcesRequest{Parent: project + "/locations/global", Query: "petstore"}, []string{"viewer-key", "editor-key", "admin-key", "root-key"}},
+		{"Registry/ListSpecElements", &rpc.ListSpecElementsRequest{Parent: api}, []string{"viewer-key", "editor-key", "admin-key", "root-key"}},
+		{"Registry/CreateApi", &rpc.CreateApiRequest{Parent: "projects/public/locations/global"}, []string{"root-key"}},
+		{"Registry/BatchCreateApis", &rpc.BatchCreateApisRequest{Parent: project + "/locations/global"}, []string{"editor-key", "admin-key", "root-key"}},
+		{"Registry/BatchDeleteArtifacts", &rpc.BatchDeleteArtifactsRequest{Parent: "projects/public/locations/global"}, []string{"root-key"}},
+	}
+	keys := []string{"viewer-key", "editor-key", "admin-key", "root-key", "other-key"}
+	for _, test := range tests {
+		allowed := make(map[string]bool)
+		for _, k := range test.allowed {
+			allowed[k] = true
+		}
+		for _, key := range keys {
+			t.Run(fmt.Sprintf("%s %v %s", test.method, test.req, key), func(t *testing.T) {
+				info := &grpc.UnaryServerInfo{FullMethod: "/google.cloud.apigeeregistry.v1." + test.method}
+				if strings.HasPrefix(test.method, "/") {
+					info.FullMethod = test.method
+				}
+				var principal string
+				_, err := interceptor(withToken(key), test.req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+					principal, _ = FromContext(ctx)
+					return nil, nil
+				})
+				want := codes.PermissionDenied
+				if allowed[key] {
+					want = codes.OK
+				}
+				if status.Code(err) != want {
+					t.Fatalf("interceptor returned status code %q, want %q: %v", status.Code(err), want, err)
+				}
+				if err == nil && principal == "" {
+					t.Errorf("handler context is missing the principal")
+				}
+			})
+		}
+	}
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		info := &grpc.UnaryServerInfo{FullMethod: "/google.cloud.apigeeregistry.v1.Admin/GetStatus"}
+		_, err := interceptor(context.Background(), &emptypb.Empty{}, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			t.Fatal("handler was called")
+			return nil, nil
+		})
+		if status.Code(err) != codes.Unauthenticated {
+			t.Errorf("interceptor returned status code %q, want %q: %v", status.Code(err), codes.Unauthenticated, err)
+		}
+	})
+}
+
+type testStream struct {
+	grpc.ServerStream
+	ctx context.Context
+	req *rpc.WatchResourcesRequest
+}
+
+func (s *testStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *testStream) RecvMsg(m interface{}) error {
+	*m.(*rpc.WatchResourcesRequest) = rpc.WatchResourcesRequest{Parent: s.req.GetParent()}
+	return nil
+}
+
+func TestStreamInterceptor(t *testing.T) {
+	a, err := New(Config{
+		APIKeys:  []APIKey{{Key: "viewer-key", Principal: "viewer@example.com"}},
+		Bindings: []Binding{{Principal: "viewer@example.com", Role: Viewer, Projects: []string{"my-project"}}},
+	})
+	if err != nil {
+		t.Fatalf("New() returned error: %s", err)
+	}
+	interceptor := a.StreamInterceptor()
+	info := &grpc.StreamServerInfo{FullMethod: "/google.cloud.apigeeregistry.v1.Admin/WatchResources", IsServerStream: true}
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		if _, ok := FromContext(ss.Context()); !ok {
+			t.Errorf("stream context is missing the principal")
+		}
+		req := &rpc.WatchResourcesRequest{}
+		return ss.RecvMsg(req)
+	}
+
+	tests := []struct {
+		desc   string
+		ctx    context.Context
+		parent string
+		want   codes.Code
+	}{
+		{
+			desc:   "allowed",
+			ctx:    withToken("viewer-key"),
+			parent: "projects/my-project",
+			want:   codes.OK,
+		},
+		{
+			desc:   "denied",
+			ctx:    withToken("viewer-key"),
+			parent: "projects/other-project",
+			want:   codes.PermissionDenied,
+		},
+		{
+			desc:   "unauthenticated",
+			ctx:    context.Background(),
+			parent: "projects/my-project",
+			want:   codes.Unauthenticated,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			ss := &testStream{ctx: test.ctx, req: &rpc.WatchResourcesRequest{Parent: test.parent}}
+			if err := interceptor(nil, ss, info, handler); status.Code(err) != test.want {
+				t.Errorf("interceptor returned status code %q, want %q: %v", status.Code(err), test.want, err)
+			}
+		})
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, contents string) string {
+		filename := filepath.Join(dir, name)
+		if err := os.WriteFile(filename, []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return filename
+	}
+
+	tests := []struct {
+		desc   string
+		config Config
+	}{
+		{
+			desc:   "no credentials",
+			config: Config{},
+		},
+		{
+			desc:   "missing JWKS file",
+			config: Config{JWKS: filepath.Join(dir, "missing.json")},
+		},
+		{
+			desc:   "invalid JWKS file",
+			config: Config{JWKS: write("invalid.json", "{")},
+		},
+		{
+			desc:   "JWKS file without signing keys",
+			config: Config{JWKS: write("empty.json", `{"keys":[{"kty":"RSA","use":"enc","n":"AQAB","e":"AQAB"}]}`)},
+		},
+		{
+			desc:   "invalid EC key",
+			config: Config{JWKS: write("ec.json", `{"keys":[{"kty":"EC","crv":"P-256","x":"AQAB","y":"AQAB"}]}`)},
+		},
+		{
+			desc:   "empty API key",
+			config: Config{APIKeys: []APIKey{{Principal: "user@example.com"}}},
+		},
+		{
+			desc: "binding without role",
+			config: Config{
+				APIKeys:  []APIKey{{Key: "key", Principal: "user@example.com"}},
+				Bindings: []Binding{{Principal: "user@example.com", Projects: []string{"*"}}},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			if _, err := New(test.config); err == nil {
+				t.Errorf("New(%+v) succeeded, want error", test.config)
+			}
+		})
+	}
+}
+
+func TestParseRole(t *testing.T) {
+	for _, want := range []Role{Viewer, Editor, Admin} {
+		got, err := ParseRole(want.String())
+		if err != nil {
+			t.Fatalf("ParseRole(%q) returned error: %s", want, err)
+		}
+		if got != want {
+			t.Errorf("ParseRole(%q) returned %s", want, got)
+		}
+	}
+	if _, err := ParseRole("owner"); err == nil {
+		t.Errorf("ParseRole(%q) succeeded, want error", "owner")
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"path"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	registryService = "/google.cloud.apigeeregistry.v1.Registry/"
+	adminService    = "/google.cloud.apigeeregistry.v1.Admin/"
+	// Long-running operations can be server-wide, such as migrations, so they require the admin role in all projects.
+	operationsService = "/google.longrunning.Operations/"
+)
+
+// serverMethods are Admin methods that apply to the whole server rather than
+// to a project, with the roles that they require in all projects.
+var serverMethods = map[string]Role{
+	"GetStatus":                None,
+	"GetStorage":               Admin,
+	"MigrateDatabase":          Admin,
+	"CompactRevisions":         Admin,
+	"ListNotificationEvents":   Admin,
+	"ReplayNotificationEvents": Admin,
+	"ListAuditEvents":          Admin,
+	// Copies read one project and write another.
+	"CloneProject": Admin,
+	"CopyApi":      Admin,
+	// Archives aren't kept in projects, so only server admins can use them.
+	"ExportProject": Admin,
+	"ImportProject": Admin,
+	"ReadArchive":   Admin,
+	"WriteArchive":  Admin,
+}
+
+// readPrefixes identify methods that only read resources.
+var readPrefixes = []string{"Get", "List", "Watch", "Aggregate", "Search"}
+
+// requirement returns the role required to make a request and the project
+// that the role is required in. An empty project refers to all projects.
+// Methods of other services, such as reflection, require authentication only.
+func requirement(fullMethod string, req interface{}) (Role, string) {
+	service, method := path.Split(fullMethod)
+	if service == operationsService {
+		return Admin, ""
+	}
+	if service != registryService && service != adminService {
+		return None, ""
+	}
+	if role, ok := serverMethods[method]; ok && service == adminService {
+		return role, ""
+	}
+
+	resource := resourceName(req)
+	project := projectID(resource)
+	for _, prefix := range readPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return Viewer, project
+		}
+	}
+	if strings.Count(resource, "/") == 1 {
+		// Changes to projects themselves require the admin role.
+		return Admin, project
+	}
+	return Editor, project
+}
+
+// resourceName returns the name of the resource or collection that a request refers to.
+func resourceName(req interface{}) string {
+	switch r := req.(type) {
+	case interface{ GetName() string }:
+		return r.GetName()
+	case interface{ GetParent() string }:
+		return r.GetParent()
+	case interface{ GetCollection() string }:
+		return r.GetCollection()
+	case interface{ GetProjectId() string }:
+		return "projects/" + r.GetProjectId()
+	}
+
+	// Update requests contain the resource that they update.
+	m, ok := req.(proto.Message)
+	if !ok {
+		return ""
+	}
+	msg := m.ProtoReflect()
+	fields := msg.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if fd.Kind() != protoreflect.MessageKind || fd.IsList() || fd.IsMap() || !msg.Has(fd) {
+			continue
+		}
+		if r, ok := msg.Get(fd).Message().Interface().(interface{ GetName() string }); ok {
+			return r.GetName()
+		}
+	}
+	return ""
+}
+
+// projectID returns the ID of the project of a resource, or an empty string
+// if the resource is not in a single project.
+func projectID(resource string) string {
+	if !strings.HasPrefix(resource, "projects/") {
+		return ""
+	}
+	id := strings.SplitN(strings.TrimPrefix(resource, "projects/"), "/", 2)[0]
+	if id == "-" {
+		return ""
+	}
+	return id
+}
@@ -0,0 +1,68 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"fmt"
+
+	"github.com/apigee/registry/server/registry/internal/storage/blobstore"
+)
+
+// BlobStoreConfig configures storage of spec and artifact contents outside of the database.
+type BlobStoreConfig struct {
+	// Type of the store: "database", "filesystem" or "s3". Defaults to "database",
+	// which stores contents in the database.
+	Type string
+	// Directory of a filesystem store.
+	Path string
+	// Endpoint of an S3-compatible service, e.g. "http://localhost:9000".
+	Endpoint string
+	// Region of an S3 bucket. Defaults to "us-east-1".
+	Region string
+	// Bucket that holds contents in an S3-compatible service.
+	Bucket string
+	// Prefix of the names of S3 objects, which must not be used by others. Optional.
+	Prefix string
+	// Credentials of an S3-compatible service.
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// newBlobStore returns the configured blob store, or nil if contents are stored in the database.
+func newBlobStore(config BlobStoreConfig) (blobstore.Store, error) {
+	var store blobstore.Store
+	var err error
+	switch config.Type {
+	case "", "database":
+		return nil, nil
+	case "filesystem":
+		store, err = blobstore.NewFilesystem(config.Path)
+	case "s3":
+		store, err = blobstore.NewS3(blobstore.S3Options{
+			Endpoint:        config.Endpoint,
+			Region:          config.Region,
+			Bucket:          config.Bucket,
+			Prefix:          config.Prefix,
+			AccessKeyID:     config.AccessKeyID,
+			SecretAccessKey: config.SecretAccessKey,
+		})
+	default:
+		return nil, fmt.Errorf("unsupported blob store %q", config.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid blob store: %s", err)
+	}
+	return store, nil
+}
@@ -0,0 +1,242 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/storage/blobstore"
+	"github.com/apigee/registry/server/registry/test/seeder"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestFilesystemBlobStore(t *testing.T) {
+	ctx := context.Background()
+	dbConfig := fmt.Sprintf("%s/registry.db", t.TempDir())
+	dir := t.TempDir()
+	server, err := New(Config{
+		Database:  "sqlite3",
+		DBConfig:  dbConfig,
+		BlobStore: BlobStoreConfig{Type: "filesystem", Path: dir},
+	})
+	if err != nil {
+		t.Fatalf("Setup: failed to get server with a blob store: %s", err)
+	}
+	t.Cleanup(server.Close)
+	store, err := blobstore.NewFilesystem(dir)
+	if err != nil {
+		t.Fatalf("Setup: failed to open blob store: %s", err)
+	}
+
+	version := &rpc.ApiVersion{Name: "projects/my-project/locations/global/apis/a/versions/v"}
+	contents := []byte("openapi: 3.0.0")
+	zipped, err := gZippedBytes(contents)
+	if err != nil {
+		t.Fatalf("Setup: failed to compress contents: %s", err)
+	}
+	specs := []*rpc.ApiSpec{
+		{Name: version.Name + "/specs/s1", MimeType: "application/x.openapi", Contents: contents},
+		{Name: version.Name + "/specs/s2", MimeType: "application/x.openapi", Contents: contents},
+		{Name: version.Name + "/specs/s3", MimeType: "application/x.openapi+gzip", Contents: zipped},
+	}
+	if err := seeder.SeedSpecs(ctx, server, specs...); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+	if _, err := server.CreateArtifact(ctx, &rpc.CreateArtifactRequest{
+		Parent:     version.Name,
+		ArtifactId: "x",
+		Artifact:   &rpc.Artifact{MimeType: "text/plain", Contents: contents},
+	}); err != nil {
+		t.Fatalf("CreateArtifact() returned error: %s", err)
+	}
+
+	// Identical contents are stored once, separately from compressed copies.
+	hash := sha256hash(contents)
+	listKeys := func() []string {
+		t.Helper()
+		keys, err := store.List(ctx)
+		if err != nil {
+			t.Fatalf("List() returned error: %s", err)
+		}
+		sort.Strings(keys)
+		return keys
+	}
+	if diff := cmp.Diff([]string{hash, hash + ".gz"}, listKeys()); diff != "" {
+		t.Errorf("Blob store has unexpected keys (-want +got):\n%s", diff)
+	}
+
+	for _, spec := range specs {
+		got, err := server.GetApiSpecContents(ctx, &rpc.GetApiSpecContentsRequest{Name: spec.Name})
+		if err != nil {
+			t.Fatalf("GetApiSpecContents(%s) returned error: %s", spec.Name, err)
+		}
+		if string(got.GetData()) != string(contents) {
+			t.Errorf("GetApiSpecContents(%s) returned %q, want %q", spec.Name, got.GetData(), contents)
+		}
+	}
+	got, err := server.GetArtifactContents(ctx, &rpc.GetArtifactContentsRequest{Name: version.Name + "/artifacts/x"})
+	if err != nil {
+		t.Fatalf("GetArtifactContents() returned error: %s", err)
+	}
+	if string(got.GetData()) != string(contents) {
+		t.Errorf("GetArtifactContents() returned %q, want %q", got.GetData(), contents)
+	}
+
+	// Rollbacks share the contents of the revisions that they restore.
+	first, err := server.GetApiSpec(ctx, &rpc.GetApiSpecRequest{Name: specs[0].Name})
+	if err != nil {
+		t.Fatalf("GetApiSpec() returned error: %s", err)
+	}
+	if _, err := server.UpdateApiSpec(ctx, &rpc.UpdateApiSpecRequest{
+		ApiSpec: &rpc.ApiSpec{Name: specs[0].Name, Contents: []byte("openapi: 3.1.0")},
+	}); err != nil {
+		t.Fatalf("UpdateApiSpec() returned error: %s", err)
+	}
+	if _, err := server.RollbackApiSpec(ctx, &rpc.RollbackApiSpecRequest{Name: specs[0].Name, RevisionId: first.GetRevisionId()}); err != nil {
+		t.Fatalf("RollbackApiSpec() returned error: %s", err)
+	}
+	got, err = server.GetApiSpecContents(ctx, &rpc.GetApiSpecContentsRequest{Name: specs[0].Name})
+	if err != nil {
+		t.Fatalf("GetApiSpecContents() returned error: %s", err)
+	}
+	if string(got.GetData()) != string(contents) {
+		t.Errorf("GetApiSpecContents() after rollback returned %q, want %q", got.GetData(), contents)
+	}
+	if n := len(listKeys()); n != 3 {
+		t.Errorf("Blob store has %d keys after update, want 3", n)
+	}
+
+	// The database doesn't contain the contents.
+	dbOnly, err := New(Config{Database: "sqlite3", DBConfig: dbConfig})
+	if err != nil {
+		t.Fatalf("Setup: failed to get server without a blob store: %s", err)
+	}
+	if _, err := dbOnly.GetApiSpecContents(ctx, &rpc.GetApiSpecContentsRequest{Name: specs[1].Name}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("GetApiSpecContents() without a blob store returned status code %q, want %q: %v", status.Code(err), codes.FailedPrecondition, err)
+	}
+	// Its background purges would compete with the purges below.
+	dbOnly.Close()
+
+	// Contents are removed when they are no longer used,
+	// but other objects in the blob store are left alone.
+	if err := store.Put(ctx, "unrelated", []byte("not registry contents")); err != nil {
+		t.Fatalf("Put() returned error: %s", err)
+	}
+	if n, err := server.purger.purgeContents(ctx); err != nil {
+		t.Fatalf("purgeContents() returned error: %s", err)
+	} else if n != 0 {
+		t.Errorf("purgeContents() removed %d used contents, want 0", n)
+	}
+	for _, spec := range specs[:2] {
+		if _, err := server.DeleteApiSpec(ctx, &rpc.DeleteApiSpecRequest{Name: spec.Name, Force: true}); err != nil {
+			t.Fatalf("DeleteApiSpec(%s) returned error: %s", spec.Name, err)
+		}
+	}
+	if n, err := server.purger.purgeContents(ctx); err != nil {
+		t.Fatalf("purgeContents() returned error: %s", err)
+	} else if n != 1 {
+		t.Errorf("purgeContents() removed %d contents, want 1", n)
+	}
+	if diff := cmp.Diff([]string{hash, hash + ".gz", "unrelated"}, listKeys()); diff != "" {
+		t.Errorf("Blob store has unexpected keys after purge (-want +got):\n%s", diff)
+	}
+
+	if err := store.Delete(ctx, hash); err != nil {
+		t.Fatalf("Delete() returned error: %s", err)
+	}
+	if _, err := server.GetArtifactContents(ctx, &rpc.GetArtifactContentsRequest{Name: version.Name + "/artifacts/x"}); status.Code(err) != codes.DataLoss {
+		t.Errorf("GetArtifactContents() of missing contents returned status code %q, want %q: %v", status.Code(err), codes.DataLoss, err)
+	}
+}
+
+func TestInvalidBlobStore(t *testing.T) {
+	for _, config := range []BlobStoreConfig{
+		{Type: "tape"},
+		{Type: "filesystem"},
+		{Type: "s3", Endpoint: "http://localhost:9000"},
+	} {
+		if _, err := New(Config{
+			Database:  "sqlite3",
+			DBConfig:  fmt.Sprintf("%s/registry.db", t.TempDir()),
+			BlobStore: config,
+		}); err == nil {
+			t.Errorf("New() with blob store %+v succeeded, want error", config)
+		}
+	}
+}
+
+func TestDatabaseSharedContents(t *testing.T) {
+	ctx := context.Background()
+	server, err := serverWithSQLite(t)
+	if err != nil {
+		t.Fatalf("Setup: failed to get server with SQLite: %s", err)
+	}
+
+	version := &rpc.ApiVersion{Name: "projects/my-project/locations/global/apis/a/versions/v"}
+	contents := []byte("openapi: 3.0.0")
+	specs := []*rpc.ApiSpec{
+		{Name: version.Name + "/specs/s1", MimeType: "application/x.openapi", Contents: contents},
+		{Name: version.Name + "/specs/s2", MimeType: "application/x.openapi", Contents: contents},
+	}
+	if err := seeder.SeedSpecs(ctx, server, specs...); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+	for _, spec := range specs {
+		got, err := server.GetApiSpecContents(ctx, &rpc.GetApiSpecContentsRequest{Name: spec.Name})
+		if err != nil {
+			t.Fatalf("GetApiSpecContents(%s) returned error: %s", spec.Name, err)
+		}
+		if string(got.GetData()) != string(contents) {
+			t.Errorf("GetApiSpecContents(%s) returned %q, want %q", spec.Name, got.GetData(), contents)
+		}
+	}
+
+	// Updates with unchanged contents keep the current revision.
+	before, err := server.GetApiSpec(ctx, &rpc.GetApiSpecRequest{Name: specs[1].Name})
+	if err != nil {
+		t.Fatalf("GetApiSpec() returned error: %s", err)
+	}
+	after, err := server.UpdateApiSpec(ctx, &rpc.UpdateApiSpecRequest{
+		ApiSpec: &rpc.ApiSpec{Name: specs[1].Name, Contents: contents},
+	})
+	if err != nil {
+		t.Fatalf("UpdateApiSpec() returned error: %s", err)
+	}
+	if after.GetRevisionId() != before.GetRevisionId() || after.GetEtag() != before.GetEtag() {
+		t.Errorf("UpdateApiSpec() with unchanged contents returned revision %q with etag %q, want %q with etag %q",
+			after.GetRevisionId(), after.GetEtag(), before.GetRevisionId(), before.GetEtag())
+	}
+	if n := countSpecRevisions(ctx, t, server, specs[1].Name); n != 1 {
+		t.Errorf("UpdateApiSpec() with unchanged contents left %d revisions, want 1", n)
+	}
+
+	// Shared contents are removed when no spec uses them.
+	for i, spec := range specs {
+		if _, err := server.DeleteApiSpec(ctx, &rpc.DeleteApiSpecRequest{Name: spec.Name, Force: true}); err != nil {
+			t.Fatalf("DeleteApiSpec(%s) returned error: %s", spec.Name, err)
+		}
+		if n, err := server.purger.purgeContents(ctx); err != nil {
+			t.Fatalf("purgeContents() returned error: %s", err)
+		} else if n != i {
+			t.Errorf("purgeContents() removed %d contents after deleting %d specs, want %d", n, i+1, i)
+		}
+	}
+}
@@ -0,0 +1,239 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"time"
+
+	"github.com/apigee/registry/log"
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/storage"
+	"github.com/apigee/registry/server/registry/names"
+)
+
+// defaultCompactionInterval is the default time between compactions of revisions.
+const defaultCompactionInterval = time.Hour
+
+// RetentionConfig configures the removal of old revisions of specs, deployments and artifacts.
+// A revision is kept if any rule keeps it. The latest revision, tagged revisions
+// and revisions that are referenced by other resources are always kept.
+// If no rules are set, all revisions are kept.
+type RetentionConfig struct {
+	// KeepRevisions is the number of latest revisions of each spec, deployment and artifact that are kept.
+	KeepRevisions int
+	// KeepDuration is the time that revisions are kept after they are created.
+	KeepDuration time.Duration
+	// Interval is the time between compactions. Defaults to one hour.
+	Interval time.Duration
+}
+
+func (c RetentionConfig) enabled() bool {
+	return c.KeepRevisions > 0 || c.KeepDuration > 0
+}
+
+// compactor removes revisions that are not kept by the retention policy in the background.
+type compactor struct {
+	server *RegistryServer
+	config RetentionConfig
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func newCompactor(s *RegistryServer, config RetentionConfig) *compactor {
+	if config.Interval <= 0 {
+		config.Interval = defaultCompactionInterval
+	}
+	return &compactor{
+		server: s,
+		config: config,
+		done:   make(chan struct{}),
+	}
+}
+
+// start begins compacting revisions.
+func (c *compactor) start(ctx context.Context) {
+	ctx, c.cancel = context.WithCancel(ctx)
+	go c.run(ctx)
+}
+
+// stop waits for the compaction in progress to finish and stops compacting.
+func (c *compactor) stop() {
+	if c.cancel == nil {
+		return
+	}
+	c.cancel()
+	<-c.done
+}
+
+func (c *compactor) run(ctx context.Context) {
+	defer close(c.done)
+	for {
+		if removed, err := c.server.compactRevisions(ctx, names.Project{ProjectID: "-"}, c.config, time.Now(), false); ctx.Err() != nil {
+			return
+		} else if err != nil {
+			log.FromContext(ctx).WithError(err).Error("Failed to compact revisions.")
+		} else if len(removed) > 0 {
+			log.FromContext(ctx).Infof("Removed %d revisions that are not kept by the retention policy.", len(removed))
+		}
+
+		timer := time.NewTimer(c.config.Interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// compactRevisions removes the revisions of specs, deployments and artifacts in a project
+// that are not kept by a retention policy at a specified time, and returns them.
+// If dryRun is true, the revisions are returned without being removed.
+// Each spec, deployment and artifact is compacted in its own transaction, which
+// finds the revisions that are referred to, and kept, after the resource is locked.
+func (s *RegistryServer) compactRevisions(ctx context.Context, parent names.Project, config RetentionConfig, now time.Time, dryRun bool) ([]storage.Revision, error) {
+	if !config.enabled() {
+		return nil, nil
+	}
+	db, err := s.getStorageClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	policy := storage.RetentionPolicy{
+		KeepRevisions: config.KeepRevisions,
+	}
+	if config.KeepDuration > 0 {
+		policy.KeepAfter = now.Add(-config.KeepDuration)
+	}
+	// referencedPolicy returns the policy for a resource, which also keeps its
+	// revisions that other resources refer to.
+	referencedPolicy := func(ctx context.Context, db *storage.Client, name string) (storage.RetentionPolicy, error) {
+		refs, err := db.ReferencedRevisions(ctx, name)
+		if err != nil {
+			return storage.RetentionPolicy{}, err
+		}
+		p := policy
+		p.Referenced = refs
+		return p, nil
+	}
+	// Resources with no more revisions than are always kept are skipped.
+	more := config.KeepRevisions
+	if more < 1 {
+		more = 1
+	}
+
+	var removed []storage.Revision
+	specs, err := db.ListSpecsWithRevisions(ctx, parent, more)
+	if err != nil {
+		return nil, err
+	}
+	for _, spec := range specs {
+		revisions, err := s.compact(ctx, dryRun, func(ctx context.Context, db *storage.Client) ([]storage.Revision, error) {
+			db = db.LockSpec(ctx, spec)
+			policy, err := referencedPolicy(ctx, db, spec.String())
+			if err != nil {
+				return nil, err
+			}
+			revisions, err := db.ExpiredSpecRevisions(ctx, spec, policy)
+			if err != nil || dryRun || len(revisions) == 0 {
+				return revisions, err
+			}
+			return revisions, db.RemoveSpecRevisions(ctx, spec, revisionIDs(revisions))
+		})
+		if err != nil {
+			return removed, err
+		}
+		removed = append(removed, revisions...)
+	}
+
+	deployments, err := db.ListDeploymentsWithRevisions(ctx, parent, more)
+	if err != nil {
+		return removed, err
+	}
+	for _, deployment := range deployments {
+		revisions, err := s.compact(ctx, dryRun, func(ctx context.Context, db *storage.Client) ([]storage.Revision, error) {
+			db = db.LockDeployment(ctx, deployment)
+			policy, err := referencedPolicy(ctx, db, deployment.String())
+			if err != nil {
+				return nil, err
+			}
+			revisions, err := db.ExpiredDeploymentRevisions(ctx, deployment, policy)
+			if err != nil || dryRun || len(revisions) == 0 {
+				return revisions, err
+			}
+			return revisions, db.RemoveDeploymentRevisions(ctx, deployment, revisionIDs(revisions))
+		})
+		if err != nil {
+			return removed, err
+		}
+		removed = append(removed, revisions...)
+	}
+
+	artifacts, err := db.ListArtifactsWithRevisions(ctx, parent, more)
+	if err != nil {
+		return removed, err
+	}
+	for _, artifact := range artifacts {
+		revisions, err := s.compact(ctx, dryRun, func(ctx context.Context, db *storage.Client) ([]storage.Revision, error) {
+			db = db.LockArtifact(ctx, artifact)
+			revisions, err := db.ExpiredArtifactRevisions(ctx, artifact, policy)
+			if err != nil || dryRun || len(revisions) == 0 {
+				return revisions, err
+			}
+			return revisions, db.RemoveArtifactRevisions(ctx, artifact, revisionIDs(revisions))
+		})
+		if err != nil {
+			return removed, err
+		}
+		removed = append(removed, revisions...)
+	}
+	return removed, nil
+}
+
+// compact runs fn to find and remove the revisions of a resource. Removals are
+// made in a transaction that also records their notifications and audit events.
+// Dry runs only read the database, so they don't use a transaction.
+func (s *RegistryServer) compact(ctx context.Context, dryRun bool, fn func(context.Context, *storage.Client) ([]storage.Revision, error)) ([]storage.Revision, error) {
+	if dryRun {
+		db, err := s.getStorageClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return fn(ctx, db)
+	}
+	var revisions []storage.Revision
+	err := s.runInTransaction(ctx, func(ctx context.Context, db *storage.Client) (err error) {
+		revisions, err = fn(ctx, db)
+		if err != nil {
+			return err
+		}
+		for _, r := range revisions {
+			s.notify(ctx, rpc.Notification_DELETED, r.Name)
+			s.audit(ctx, "CompactRevisions", r.Name, nil, nil)
+		}
+		return nil
+	})
+	return revisions, err
+}
+
+func revisionIDs(revisions []storage.Revision) []string {
+	ids := make([]string, len(revisions))
+	for i, r := range revisions {
+		ids[i] = r.RevisionID
+	}
+	return ids
+}
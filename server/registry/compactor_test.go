@@ -0,0 +1,74 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/names"
+	"github.com/apigee/registry/server/registry/test/seeder"
+)
+
+func TestCompactor(t *testing.T) {
+	ctx := context.Background()
+	server := serverWithRetention(t, RetentionConfig{KeepDuration: time.Hour})
+	if server.compactor == nil {
+		t.Fatal("New() didn't start a compactor for a retention policy")
+	}
+	const specName = "projects/my-project/locations/global/apis/my-api/versions/v1/specs/my-spec"
+	if err := seeder.SeedSpecs(ctx, server, &rpc.ApiSpec{Name: specName}); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+	for i := 1; i < 3; i++ {
+		req := &rpc.UpdateApiSpecRequest{
+			ApiSpec: &rpc.ApiSpec{
+				Name:     specName,
+				Contents: []byte(fmt.Sprintf("contents of revision %d", i)),
+			},
+		}
+		if _, err := server.UpdateApiSpec(ctx, req); err != nil {
+			t.Fatalf("Setup: UpdateApiSpec(%+v) returned error: %s", req, err)
+		}
+	}
+
+	all := names.Project{ProjectID: "-"}
+	if removed, err := server.compactRevisions(ctx, all, server.retention, time.Now(), false); err != nil {
+		t.Fatalf("compactRevisions() returned error: %s", err)
+	} else if len(removed) != 0 {
+		t.Errorf("compactRevisions() removed %d revisions inside the retention window, want 0", len(removed))
+	}
+
+	// The configured policy is applied when a request has no policy.
+	got, err := server.CompactRevisions(ctx, &rpc.CompactRevisionsRequest{ValidateOnly: true})
+	if err != nil {
+		t.Fatalf("CompactRevisions() returned error: %s", err)
+	} else if got.GetRevisionCount() != 0 {
+		t.Errorf("CompactRevisions() returned %d revisions inside the retention window, want 0", got.GetRevisionCount())
+	}
+
+	// The latest revision is kept after the retention window.
+	if removed, err := server.compactRevisions(ctx, all, server.retention, time.Now().Add(2*time.Hour), false); err != nil {
+		t.Fatalf("compactRevisions() returned error: %s", err)
+	} else if len(removed) != 2 {
+		t.Errorf("compactRevisions() removed %d revisions after the retention window, want 2", len(removed))
+	}
+	if n := countSpecRevisions(ctx, t, server, specName); n != 1 {
+		t.Errorf("compactRevisions() left %d revisions of %s, want 1", n, specName)
+	}
+}
@@ -16,10 +16,13 @@ package registry
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/storage"
 	"github.com/apigee/registry/server/registry/test/seeder"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -207,3 +210,74 @@ func TestConcurrentApiDeploymentUpdates(t *testing.T) {
 		wg.Wait()
 	})
 }
+
+func TestConcurrentApiSpecUpdatesInDifferentApis(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	versions := make([]*rpc.ApiVersion, concurrency)
+	for i := range versions {
+		versions[i] = &rpc.ApiVersion{Name: fmt.Sprintf("projects/my-project/locations/global/apis/a%d/versions/v", i)}
+	}
+	if err := seeder.SeedVersions(ctx, server, versions...); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+
+	// Changes to specs of different APIs don't wait for each other, but each spec
+	// must still get one revision per change.
+	const updates = 3
+	var wg sync.WaitGroup
+	wg.Add(concurrency)
+	for i := 0; i < concurrency; i++ {
+		go func(i int) {
+			defer wg.Done()
+			name := versions[i].GetName() + "/specs/s"
+			for j := 0; j < updates; j++ {
+				req := &rpc.UpdateApiSpecRequest{
+					ApiSpec:      &rpc.ApiSpec{Name: name, Contents: []byte(fmt.Sprintf("contents %d", j))},
+					AllowMissing: true,
+				}
+				if _, err := server.UpdateApiSpec(ctx, req); err != nil {
+					t.Errorf("UpdateApiSpec(%+v) returned error: %s", req, err)
+					return
+				}
+			}
+			revisions, err := server.ListApiSpecRevisions(ctx, &rpc.ListApiSpecRevisionsRequest{Name: name + "@-"})
+			if err != nil {
+				t.Errorf("ListApiSpecRevisions(%q) returned error: %s", name, err)
+			} else if n := len(revisions.GetApiSpecs()); n != updates {
+				t.Errorf("ListApiSpecRevisions(%q) returned %d revisions, want %d", name, n, updates)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestReadsDuringTransaction(t *testing.T) {
+	ctx := context.Background()
+	server, err := serverWithSQLite(t)
+	if err != nil {
+		t.Fatalf("Setup: failed to get server with SQLite: %s", err)
+	}
+	api := &rpc.Api{Name: "projects/my-project/locations/global/apis/a"}
+	if err := seeder.SeedApis(ctx, server, api); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+
+	// Reads use their own connections, so they don't wait for changes to finish.
+	started, done := make(chan struct{}), make(chan struct{})
+	go func() {
+		_ = server.runInTransaction(ctx, func(ctx context.Context, db *storage.Client) error {
+			close(started)
+			<-done
+			return nil
+		})
+	}()
+	<-started
+	defer close(done)
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if _, err := server.GetApi(ctx, &rpc.GetApiRequest{Name: api.GetName()}); err != nil {
+		t.Errorf("GetApi(%q) returned error during a transaction: %s", api.GetName(), err)
+	}
+}
@@ -0,0 +1,250 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/apigee/registry/log"
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/notify"
+	"github.com/apigee/registry/server/registry/internal/storage"
+	"github.com/apigee/registry/server/registry/internal/storage/models"
+)
+
+const (
+	// dispatchInterval is the maximum time between checks for due events.
+	dispatchInterval = 5 * time.Second
+	// dispatchLease is the time that a claimed event is hidden from other dispatchers.
+	// Deliveries that outlast it may be repeated.
+	dispatchLease = 5 * time.Minute
+	// dispatchBatchSize is the number of events claimed at a time.
+	dispatchBatchSize = 20
+	// maxDispatchBackoff limits the delay between delivery attempts.
+	maxDispatchBackoff = 10 * time.Minute
+)
+
+// dispatcher delivers stored notification events to their sinks in the background.
+// Delivery is at-least-once: events are deleted only after their sinks accept them,
+// and failed deliveries are retried with exponential backoff until the maximum
+// number of attempts is reached. Events are then marked as failed and kept until
+// they are replayed with ReplayNotificationEvents. Sinks are delivered to concurrently,
+// so that a slow or unavailable sink doesn't delay deliveries to the others.
+type dispatcher struct {
+	server   *RegistryServer
+	sinks    map[string]notify.Notifier
+	attempts int32
+	backoff  time.Duration
+
+	wakeup chan struct{}
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func newDispatcher(s *RegistryServer, attempts int, backoff time.Duration) *dispatcher {
+	if attempts <= 0 {
+		attempts = 10
+	}
+	if backoff <= 0 {
+		backoff = time.Second
+	}
+	d := &dispatcher{
+		server:   s,
+		sinks:    make(map[string]notify.Notifier),
+		attempts: int32(attempts),
+		backoff:  backoff,
+		wakeup:   make(chan struct{}, 1),
+		done:     make(chan struct{}),
+	}
+	for _, sink := range s.sinks {
+		d.sinks[sink.name] = sink.notifier
+	}
+	return d
+}
+
+// start begins delivering events.
+func (d *dispatcher) start(ctx context.Context) {
+	ctx, d.cancel = context.WithCancel(ctx)
+	go d.run(ctx)
+}
+
+// stop waits for the delivery in progress to finish and stops delivering events.
+// Undelivered events remain stored and are delivered when the server restarts.
+func (d *dispatcher) stop() {
+	if d.cancel == nil {
+		return
+	}
+	d.cancel()
+	<-d.done
+}
+
+// wake prompts the dispatcher to check for new events.
+func (d *dispatcher) wake() {
+	select {
+	case d.wakeup <- struct{}{}:
+	default:
+	}
+}
+
+func (d *dispatcher) run(ctx context.Context) {
+	defer close(d.done)
+	var retry time.Time // the earliest scheduled retry
+	for {
+		if !retry.IsZero() && !time.Now().Before(retry) {
+			retry = time.Time{}
+		}
+		n, next, err := d.dispatch(ctx)
+		if !next.IsZero() && (retry.IsZero() || next.Before(retry)) {
+			retry = next
+		}
+		if ctx.Err() != nil {
+			return
+		} else if err != nil {
+			log.FromContext(ctx).WithError(err).Error("Failed to dispatch notifications.")
+		} else if n == dispatchBatchSize {
+			continue
+		}
+
+		wait := dispatchInterval
+		if !retry.IsZero() && time.Until(retry) < wait {
+			wait = time.Until(retry)
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-d.wakeup:
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
+
+// dispatch delivers a batch of due events. It returns the number of events
+// claimed and the earliest time that one of them should be retried.
+func (d *dispatcher) dispatch(ctx context.Context) (int, time.Time, error) {
+	var events []models.NotificationEvent
+	if err := d.server.runInTransaction(ctx, func(ctx context.Context, db *storage.Client) (err error) {
+		events, err = db.ClaimNotificationEvents(ctx, time.Now(), dispatchLease, dispatchBatchSize)
+		return err
+	}); err != nil {
+		return 0, time.Time{}, err
+	}
+
+	bySink := make(map[string][]*models.NotificationEvent)
+	for i := range events {
+		bySink[events[i].Sink] = append(bySink[events[i].Sink], &events[i])
+	}
+
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		retry time.Time
+		first error
+	)
+	for _, queue := range bySink {
+		wg.Add(1)
+		go func(queue []*models.NotificationEvent) {
+			defer wg.Done()
+			next, err := d.deliverAll(ctx, queue)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil && first == nil {
+				first = err
+			}
+			if !next.IsZero() && (retry.IsZero() || next.Before(retry)) {
+				retry = next
+			}
+		}(queue)
+	}
+	wg.Wait()
+	return len(events), retry, first
+}
+
+// deliverAll delivers events to a sink in order and returns the earliest time that
+// one of them should be retried. Once a delivery fails, the remaining events are
+// postponed until it is retried without counting an attempt, so an unavailable sink
+// is tried once in each pass.
+func (d *dispatcher) deliverAll(ctx context.Context, events []*models.NotificationEvent) (time.Time, error) {
+	for i, e := range events {
+		delivered, err := d.deliver(ctx, e)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if delivered || e.State != rpc.NotificationEvent_PENDING.String() {
+			continue
+		}
+		rest := events[i+1:]
+		ids := make([]int64, len(rest))
+		for j, v := range rest {
+			ids[j] = v.ID
+		}
+		return e.NextAttemptTime, d.server.runInTransaction(ctx, func(ctx context.Context, db *storage.Client) error {
+			return db.PostponeNotificationEvents(ctx, ids, e.NextAttemptTime)
+		})
+	}
+	return time.Time{}, nil
+}
+
+// deliver sends an event to its sink, records the outcome and reports whether
+// the event was delivered.
+func (d *dispatcher) deliver(ctx context.Context, e *models.NotificationEvent) (bool, error) {
+	notifier, ok := d.sinks[e.Sink]
+	var err error
+	if ok {
+		err = notifier.Notify(ctx, e.Notification())
+	} else {
+		err = fmt.Errorf("sink %q is not configured", e.Sink)
+	}
+	if ctx.Err() != nil {
+		// The event will be delivered again when its lease expires.
+		return false, ctx.Err()
+	}
+
+	if err == nil {
+		return true, d.server.runInTransaction(ctx, func(ctx context.Context, db *storage.Client) error {
+			return db.DeleteNotificationEvent(ctx, e.ID)
+		})
+	}
+
+	e.Attempts++
+	e.LastError = err.Error()
+	if !ok || e.Attempts >= d.attempts {
+		e.State = rpc.NotificationEvent_FAILED.String()
+		log.FromContext(ctx).WithError(err).Errorf("Failed to deliver notification %d to %s after %d attempts.", e.ID, e.Sink, e.Attempts)
+	} else {
+		e.NextAttemptTime = time.Now().Add(d.delay(e.Attempts))
+		log.FromContext(ctx).WithError(err).Debugf("Failed to deliver notification %d to %s, retrying at %s.", e.ID, e.Sink, e.NextAttemptTime)
+	}
+	return false, d.server.runInTransaction(ctx, func(ctx context.Context, db *storage.Client) error {
+		return db.SaveNotificationEvent(ctx, e)
+	})
+}
+
+// delay returns the time to wait after a number of failed attempts.
+func (d *dispatcher) delay(attempts int32) time.Duration {
+	delay := d.backoff
+	for i := int32(1); i < attempts && delay < maxDispatchBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxDispatchBackoff {
+		delay = maxDispatchBackoff
+	}
+	return delay
+}
@@ -0,0 +1,37 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// checkEtag returns an ABORTED error if a client supplied an etag that does not
+// match the current etag of a resource. An empty etag matches any value.
+func checkEtag(name, etag, current string) error {
+	if etag == "" || etag == current {
+		return nil
+	}
+	return status.Errorf(codes.Aborted, "etag %q does not match the current etag of %s", etag, name)
+}
+
+// missingEtag returns an ABORTED error if a client supplied an etag for a resource that does not exist.
+func missingEtag(name, etag string) error {
+	if etag == "" {
+		return nil
+	}
+	return status.Errorf(codes.Aborted, "etag %q was provided but %s does not exist", etag, name)
+}
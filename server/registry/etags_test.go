@@ -0,0 +1,228 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/test/seeder"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const staleEtag = "0123456789abcdef"
+
+func TestUpdateApiEtag(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	api := &rpc.Api{Name: "projects/my-project/locations/global/apis/my-api"}
+	if err := seeder.SeedApis(ctx, server, api); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+	current, err := server.GetApi(ctx, &rpc.GetApiRequest{Name: api.GetName()})
+	if err != nil {
+		t.Fatalf("Setup: GetApi(%q) returned error: %s", api.GetName(), err)
+	}
+	if current.GetEtag() == "" {
+		t.Fatalf("GetApi(%q) returned an empty etag", api.GetName())
+	}
+
+	req := &rpc.UpdateApiRequest{
+		Api:        &rpc.Api{Name: api.GetName(), DisplayName: "Updated", Etag: current.GetEtag()},
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"display_name"}},
+	}
+	updated, err := server.UpdateApi(ctx, req)
+	if err != nil {
+		t.Fatalf("UpdateApi(%+v) returned error: %s", req, err)
+	}
+	if updated.GetEtag() == current.GetEtag() {
+		t.Errorf("UpdateApi(%+v) returned unchanged etag %q", req, updated.GetEtag())
+	}
+	if got, err := server.GetApi(ctx, &rpc.GetApiRequest{Name: api.GetName()}); err != nil {
+		t.Fatalf("GetApi(%q) returned error: %s", api.GetName(), err)
+	} else if got.GetEtag() != updated.GetEtag() {
+		t.Errorf("GetApi(%q) returned etag %q, want %q", api.GetName(), got.GetEtag(), updated.GetEtag())
+	}
+
+	// The etag that was read before the update is now stale.
+	if _, err := server.UpdateApi(ctx, req); status.Code(err) != codes.Aborted {
+		t.Errorf("UpdateApi(%+v) returned status code %q, want %q: %v", req, status.Code(err), codes.Aborted, err)
+	}
+
+	missing := &rpc.UpdateApiRequest{
+		Api:          &rpc.Api{Name: "projects/my-project/locations/global/apis/missing", Etag: current.GetEtag()},
+		AllowMissing: true,
+	}
+	if _, err := server.UpdateApi(ctx, missing); status.Code(err) != codes.Aborted {
+		t.Errorf("UpdateApi(%+v) returned status code %q, want %q: %v", missing, status.Code(err), codes.Aborted, err)
+	}
+}
+
+func TestUpdateApiSpecEtag(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	spec := &rpc.ApiSpec{Name: "projects/my-project/locations/global/apis/my-api/versions/v1/specs/my-spec"}
+	if err := seeder.SeedSpecs(ctx, server, spec); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+	current, err := server.GetApiSpec(ctx, &rpc.GetApiSpecRequest{Name: spec.GetName()})
+	if err != nil {
+		t.Fatalf("Setup: GetApiSpec(%q) returned error: %s", spec.GetName(), err)
+	}
+
+	// Changing the contents creates a new revision, which has a new etag.
+	req := &rpc.UpdateApiSpecRequest{
+		ApiSpec:    &rpc.ApiSpec{Name: spec.GetName(), Contents: []byte("openapi: 3.0.0"), Etag: current.GetEtag()},
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"contents"}},
+	}
+	updated, err := server.UpdateApiSpec(ctx, req)
+	if err != nil {
+		t.Fatalf("UpdateApiSpec(%+v) returned error: %s", req, err)
+	}
+	if updated.GetEtag() == current.GetEtag() {
+		t.Errorf("UpdateApiSpec(%+v) returned unchanged etag %q", req, updated.GetEtag())
+	}
+	if _, err := server.UpdateApiSpec(ctx, req); status.Code(err) != codes.Aborted {
+		t.Errorf("UpdateApiSpec(%+v) returned status code %q, want %q: %v", req, status.Code(err), codes.Aborted, err)
+	}
+}
+
+func TestReplaceArtifactEtag(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	artifact := &rpc.Artifact{Name: "projects/my-project/locations/global/artifacts/my-artifact"}
+	if err := seeder.SeedArtifacts(ctx, server, artifact); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+
+	req := &rpc.ReplaceArtifactRequest{
+		Artifact: &rpc.Artifact{Name: artifact.GetName(), Contents: []byte("new"), Etag: staleEtag},
+	}
+	if _, err := server.ReplaceArtifact(ctx, req); status.Code(err) != codes.Aborted {
+		t.Errorf("ReplaceArtifact(%+v) returned status code %q, want %q: %v", req, status.Code(err), codes.Aborted, err)
+	}
+
+	current, err := server.GetArtifact(ctx, &rpc.GetArtifactRequest{Name: artifact.GetName()})
+	if err != nil {
+		t.Fatalf("Setup: GetArtifact(%q) returned error: %s", artifact.GetName(), err)
+	}
+	req.Artifact.Etag = current.GetEtag()
+	if _, err := server.ReplaceArtifact(ctx, req); err != nil {
+		t.Errorf("ReplaceArtifact(%+v) returned error: %s", req, err)
+	}
+}
+
+func TestDeleteEtags(t *testing.T) {
+	tests := []struct {
+		desc   string
+		seed   func(ctx context.Context, s TestServer) error
+		etag   func(ctx context.Context, s TestServer) (string, error)
+		delete func(ctx context.Context, s TestServer, etag string) error
+	}{
+		{
+			desc: "api",
+			seed: func(ctx context.Context, s TestServer) error {
+				return seeder.SeedApis(ctx, s, &rpc.Api{Name: "projects/my-project/locations/global/apis/my-api"})
+			},
+			etag: func(ctx context.Context, s TestServer) (string, error) {
+				api, err := s.GetApi(ctx, &rpc.GetApiRequest{Name: "projects/my-project/locations/global/apis/my-api"})
+				return api.GetEtag(), err
+			},
+			delete: func(ctx context.Context, s TestServer, etag string) error {
+				_, err := s.DeleteApi(ctx, &rpc.DeleteApiRequest{Name: "projects/my-project/locations/global/apis/my-api", Etag: etag})
+				return err
+			},
+		},
+		{
+			desc: "version",
+			seed: func(ctx context.Context, s TestServer) error {
+				return seeder.SeedVersions(ctx, s, &rpc.ApiVersion{Name: "projects/my-project/locations/global/apis/my-api/versions/v1"})
+			},
+			etag: func(ctx context.Context, s TestServer) (string, error) {
+				version, err := s.GetApiVersion(ctx, &rpc.GetApiVersionRequest{Name: "projects/my-project/locations/global/apis/my-api/versions/v1"})
+				return version.GetEtag(), err
+			},
+			delete: func(ctx context.Context, s TestServer, etag string) error {
+				_, err := s.DeleteApiVersion(ctx, &rpc.DeleteApiVersionRequest{Name: "projects/my-project/locations/global/apis/my-api/versions/v1", Etag: etag})
+				return err
+			},
+		},
+		{
+			desc: "spec",
+			seed: func(ctx context.Context, s TestServer) error {
+				return seeder.SeedSpecs(ctx, s, &rpc.ApiSpec{Name: "projects/my-project/locations/global/apis/my-api/versions/v1/specs/my-spec"})
+			},
+			etag: func(ctx context.Context, s TestServer) (string, error) {
+				spec, err := s.GetApiSpec(ctx, &rpc.GetApiSpecRequest{Name: "projects/my-project/locations/global/apis/my-api/versions/v1/specs/my-spec"})
+				return spec.GetEtag(), err
+			},
+			delete: func(ctx context.Context, s TestServer, etag string) error {
+				_, err := s.DeleteApiSpec(ctx, &rpc.DeleteApiSpecRequest{Name: "projects/my-project/locations/global/apis/my-api/versions/v1/specs/my-spec", Etag: etag})
+				return err
+			},
+		},
+		{
+			desc: "deployment",
+			seed: func(ctx context.Context, s TestServer) error {
+				return seeder.SeedDeployments(ctx, s, &rpc.ApiDeployment{Name: "projects/my-project/locations/global/apis/my-api/deployments/my-deployment"})
+			},
+			etag: func(ctx context.Context, s TestServer) (string, error) {
+				deployment, err := s.GetApiDeployment(ctx, &rpc.GetApiDeploymentRequest{Name: "projects/my-project/locations/global/apis/my-api/deployments/my-deployment"})
+				return deployment.GetEtag(), err
+			},
+			delete: func(ctx context.Context, s TestServer, etag string) error {
+				_, err := s.DeleteApiDeployment(ctx, &rpc.DeleteApiDeploymentRequest{Name: "projects/my-project/locations/global/apis/my-api/deployments/my-deployment", Etag: etag})
+				return err
+			},
+		},
+		{
+			desc: "artifact",
+			seed: func(ctx context.Context, s TestServer) error {
+				return seeder.SeedArtifacts(ctx, s, &rpc.Artifact{Name: "projects/my-project/locations/global/artifacts/my-artifact"})
+			},
+			etag: func(ctx context.Context, s TestServer) (string, error) {
+				artifact, err := s.GetArtifact(ctx, &rpc.GetArtifactRequest{Name: "projects/my-project/locations/global/artifacts/my-artifact"})
+				return artifact.GetEtag(), err
+			},
+			delete: func(ctx context.Context, s TestServer, etag string) error {
+				_, err := s.DeleteArtifact(ctx, &rpc.DeleteArtifactRequest{Name: "projects/my-project/locations/global/artifacts/my-artifact", Etag: etag})
+				return err
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			ctx := context.Background()
+			server := defaultTestServer(t)
+			if err := test.seed(ctx, server); err != nil {
+				t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+			}
+			if err := test.delete(ctx, server, staleEtag); status.Code(err) != codes.Aborted {
+				t.Errorf("Delete with stale etag returned status code %q, want %q: %v", status.Code(err), codes.Aborted, err)
+			}
+			etag, err := test.etag(ctx, server)
+			if err != nil {
+				t.Fatalf("Get returned error: %s", err)
+			}
+			if err := test.delete(ctx, server, etag); err != nil {
+				t.Errorf("Delete with current etag returned error: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/apigee/registry/rpc"
+)
+
+type natsMessage struct {
+	subject string
+	payload string
+}
+
+// fakeNATS implements enough of the NATS server protocol to receive publications.
+type fakeNATS struct {
+	listener net.Listener
+	connects chan string
+	messages chan natsMessage
+	reject   int32 // if nonzero, reply to publications with -ERR
+}
+
+func newFakeNATS(t *testing.T) *fakeNATS {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &fakeNATS{
+		listener: l,
+		connects: make(chan string, 10),
+		messages: make(chan natsMessage, 10),
+	}
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	t.Cleanup(func() { l.Close() })
+	return s
+}
+
+func (s *fakeNATS) url(userinfo string) string {
+	return fmt.Sprintf("nats://%s%s", userinfo, s.listener.Addr())
+}
+
+func (s *fakeNATS) serve(conn net.Conn) {
+	defer conn.Close()
+	fmt.Fprint(conn, "INFO {\"server_id\":\"fake\"}\r\n")
+	r := bufio.NewReader(conn)
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		switch fields[0] {
+		case "CONNECT":
+			s.connects <- strings.TrimSpace(strings.TrimPrefix(line, "CONNECT"))
+		case "PUB":
+			n, _ := strconv.Atoi(fields[len(fields)-1])
+			payload := make([]byte, n+2)
+			if _, err := io.ReadFull(r, payload); err != nil {
+				return
+			}
+			if atomic.LoadInt32(&s.reject) != 0 {
+				fmt.Fprint(conn, "-ERR 'Permissions Violation'\r\n")
+				return
+			}
+			s.messages <- natsMessage{subject: fields[1], payload: string(payload[:n])}
+		case "PING":
+			fmt.Fprint(conn, "PONG\r\n")
+		}
+	}
+}
+
+func TestNATS(t *testing.T) {
+	server := newFakeNATS(t)
+	b, err := NewNATS(NATSOptions{URL: server.url("user:password@"), Subject: "registry.events"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.Close()
+
+	ctx := context.Background()
+	for _, change := range []rpc.Notification_Change{rpc.Notification_CREATED, rpc.Notification_DELETED} {
+		if err := b.Notify(ctx, &rpc.Notification{Change: change, Resource: "projects/p"}); err != nil {
+			t.Fatalf("Notify() returned error: %s", err)
+		}
+	}
+
+	if connect := <-server.connects; !strings.Contains(connect, `"user":"user"`) || !strings.Contains(connect, `"pass":"password"`) {
+		t.Errorf("CONNECT is missing credentials: %s", connect)
+	}
+	if len(server.connects) != 0 {
+		t.Errorf("Expected a single connection, got %d more", len(server.connects))
+	}
+	for _, subject := range []string{"registry.events.CREATED", "registry.events.DELETED"} {
+		msg := <-server.messages
+		if msg.subject != subject {
+			t.Errorf("Published to subject %q, want %q", msg.subject, subject)
+		}
+		if !strings.Contains(msg.payload, `"resource":"projects/p"`) {
+			t.Errorf("Unexpected payload %s", msg.payload)
+		}
+	}
+}
+
+func TestNATSErrors(t *testing.T) {
+	server := newFakeNATS(t)
+	atomic.StoreInt32(&server.reject, 1)
+	b, err := NewNATS(NATSOptions{URL: server.url(""), Subject: "registry"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.Close()
+
+	if err := b.Notify(context.Background(), &rpc.Notification{}); err == nil {
+		t.Error("Notify() succeeded, expected error")
+	}
+
+	// A failed connection is replaced on the next attempt.
+	atomic.StoreInt32(&server.reject, 0)
+	if err := b.Notify(context.Background(), &rpc.Notification{}); err != nil {
+		t.Errorf("Notify() returned error: %s", err)
+	}
+}
+
+func TestNATSOptions(t *testing.T) {
+	tests := []NATSOptions{
+		{URL: "http://localhost:4222", Subject: "registry"},
+		{URL: "nats://localhost:4222"},
+		{URL: "nats://localhost:4222", Subject: "registry events"},
+	}
+	for _, opts := range tests {
+		if _, err := NewNATS(opts); err == nil {
+			t.Errorf("NewNATS(%+v) succeeded, expected error", opts)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package notify delivers registry change notifications to external sinks.
+package notify
+
+import (
+	"context"
+	"strings"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/storage/filtering"
+	"github.com/apigee/registry/server/registry/names"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// A Notifier delivers notifications to a single sink.
+type Notifier interface {
+	// Notify delivers a notification, returning an error if delivery failed.
+	Notify(ctx context.Context, n *rpc.Notification) error
+	// Close releases any resources held by the notifier.
+	Close() error
+}
+
+var fields = map[string]filtering.FieldType{
+	"change":      filtering.String,
+	"resource":    filtering.String,
+	"change_time": filtering.Timestamp,
+}
+
+// Filter selects notifications by resource parent and CEL expression.
+type Filter struct {
+	parent string
+	filter filtering.Filter
+}
+
+// NewFilter returns a filter that matches notifications for the parent
+// resource and its descendants that also satisfy the CEL expression.
+// An empty parent or expression matches everything.
+func NewFilter(parent, filter string) (*Filter, error) {
+	if parent != "" {
+		if _, err := names.ParseResourceEntity(parent); err != nil {
+			if _, err := names.ParseProjectWithLocation(parent); err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid parent %q", parent)
+			}
+		}
+	}
+	f, err := filtering.NewFilter(filter, fields)
+	if err != nil {
+		return nil, err
+	}
+	return &Filter{parent: parent, filter: f}, nil
+}
+
+// Matches returns true if the notification is selected by the filter.
+func (f *Filter) Matches(n *rpc.Notification) (bool, error) {
+	if f == nil {
+		return true, nil
+	}
+	if f.parent != "" && !descendant(f.parent, n.GetResource()) {
+		return false, nil
+	}
+	return f.filter.Matches(map[string]interface{}{
+		"change":      n.GetChange().String(),
+		"resource":    n.GetResource(),
+		"change_time": n.GetChangeTime().AsTime(),
+	})
+}
+
+// descendant returns true if resource is the parent or one of its descendants.
+// Parent IDs of "-" match any ID, and resource revision IDs are ignored
+// unless the parent specifies one.
+func descendant(parent, resource string) bool {
+	p := strings.Split(parent, "/")
+	r := strings.Split(resource, "/")
+	if len(r) < len(p) {
+		return false
+	}
+	for i := range p {
+		if p[i] == "-" {
+			continue
+		}
+		id := r[i]
+		if !strings.Contains(p[i], "@") {
+			id, _, _ = strings.Cut(id, "@")
+		}
+		if p[i] != id {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,106 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"testing"
+
+	"github.com/apigee/registry/rpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestDescendant(t *testing.T) {
+	tests := []struct {
+		parent   string
+		resource string
+		want     bool
+	}{
+		{"projects/p", "projects/p", true},
+		{"projects/p", "projects/p/locations/global/apis/a", true},
+		{"projects/p", "projects/q/locations/global/apis/a", false},
+		{"projects/p", "projects/pp", false},
+		{"projects/-", "projects/q", true},
+		{"projects/p/locations/global/apis/a", "projects/p", false},
+		{"projects/p/locations/global/apis/-/versions/v1", "projects/p/locations/global/apis/a/versions/v1/specs/s", true},
+		{"projects/p/locations/global/apis/-/versions/v1", "projects/p/locations/global/apis/a/versions/v2/specs/s", false},
+		{"projects/p/locations/global/apis/a/deployments/d", "projects/p/locations/global/apis/a/deployments/d@123", true},
+		{"projects/p/locations/global/apis/a/deployments/d@123", "projects/p/locations/global/apis/a/deployments/d@123", true},
+		{"projects/p/locations/global/apis/a/deployments/d@123", "projects/p/locations/global/apis/a/deployments/d@456", false},
+	}
+	for _, test := range tests {
+		if got := descendant(test.parent, test.resource); got != test.want {
+			t.Errorf("descendant(%q, %q) returned %t, want %t", test.parent, test.resource, got, test.want)
+		}
+	}
+}
+
+func TestFilter(t *testing.T) {
+	n := &rpc.Notification{
+		Change:     rpc.Notification_UPDATED,
+		Resource:   "projects/p/locations/global/apis/a",
+		ChangeTime: timestamppb.Now(),
+	}
+	tests := []struct {
+		desc   string
+		parent string
+		filter string
+		want   bool
+	}{
+		{"empty", "", "", true},
+		{"matching parent", "projects/p", "", true},
+		{"other parent", "projects/q", "", false},
+		{"matching change", "", `change == "UPDATED"`, true},
+		{"other change", "", `change == "DELETED"`, false},
+		{"resource function", "projects/-", `resource.endsWith("/a")`, true},
+		{"change time", "", `change_time > timestamp("2000-01-01T00:00:00Z")`, true},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			f, err := NewFilter(test.parent, test.filter)
+			if err != nil {
+				t.Fatalf("NewFilter(%q, %q) returned error: %s", test.parent, test.filter, err)
+			}
+			got, err := f.Matches(n)
+			if err != nil {
+				t.Fatalf("Matches(%v) returned error: %s", n, err)
+			}
+			if got != test.want {
+				t.Errorf("Matches(%v) returned %t, want %t", n, got, test.want)
+			}
+		})
+	}
+}
+
+func TestFilterErrors(t *testing.T) {
+	tests := []struct {
+		desc   string
+		parent string
+		filter string
+	}{
+		{"invalid parent", "invalid", ""},
+		{"invalid filter", "", "invalid filter"},
+		{"unknown field", "", `name == "x"`},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			_, err := NewFilter(test.parent, test.filter)
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("NewFilter(%q, %q) returned status code %q, want %q: %v", test.parent, test.filter, status.Code(err), codes.InvalidArgument, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/pubsub"
+	"github.com/apigee/registry/log"
+	"github.com/apigee/registry/rpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// PubSub publishes notifications to a Google Cloud Pub/Sub topic.
+type PubSub struct {
+	client *pubsub.Client
+	topic  *pubsub.Topic
+}
+
+// NewPubSub returns a notifier that publishes to the named topic in a
+// Google Cloud project, creating the topic if it does not exist.
+func NewPubSub(ctx context.Context, projectID, topic string) (*PubSub, error) {
+	if projectID == "" {
+		return nil, fmt.Errorf("pubsub requires a project ID")
+	}
+	client, err := pubsub.NewClient(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := client.CreateTopic(ctx, topic); err != nil && status.Code(err) != codes.AlreadyExists {
+		client.Close()
+		return nil, err
+	}
+	return &PubSub{client: client, topic: client.Topic(topic)}, nil
+}
+
+func (p *PubSub) Notify(ctx context.Context, n *rpc.Notification) error {
+	msg, err := protojson.Marshal(n)
+	if err != nil {
+		return err
+	}
+
+	result := p.topic.Publish(ctx, &pubsub.Message{
+		Data: msg,
+	})
+
+	id, err := result.Get(ctx)
+	if err != nil {
+		return err
+	}
+
+	log.FromContext(ctx).Infof("Published notification with message ID: %s", id)
+	return nil
+}
+
+func (p *PubSub) Close() error {
+	p.topic.Stop()
+	return p.client.Close()
+}
@@ -0,0 +1,150 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/apigee/registry/log"
+	"github.com/apigee/registry/rpc"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	// SignatureHeader carries the hex-encoded HMAC-SHA256 of the request body,
+	// prefixed with "sha256=", when a webhook secret is configured.
+	SignatureHeader = "X-Registry-Signature"
+	// ChangeHeader carries the type of change, e.g. "CREATED".
+	ChangeHeader = "X-Registry-Change"
+)
+
+// WebhookOptions configures a webhook.
+type WebhookOptions struct {
+	// URL receives notifications as HTTP POST requests with JSON bodies.
+	URL string
+	// Secret, if set, is used to sign request bodies.
+	Secret string
+	// Attempts is the maximum number of delivery attempts. Defaults to 3.
+	Attempts int
+	// Backoff is the delay before the first retry, doubled for each retry. Defaults to 1s.
+	Backoff time.Duration
+	// Timeout limits the duration of each attempt. Defaults to 10s.
+	Timeout time.Duration
+}
+
+// Webhook delivers notifications to an HTTP endpoint.
+type Webhook struct {
+	opts   WebhookOptions
+	client *http.Client
+}
+
+// NewWebhook returns a notifier that posts notifications to a URL.
+func NewWebhook(opts WebhookOptions) (*Webhook, error) {
+	u, err := url.Parse(opts.URL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid webhook url %q: scheme must be http or https", opts.URL)
+	}
+	if opts.Attempts <= 0 {
+		opts.Attempts = 3
+	}
+	if opts.Backoff <= 0 {
+		opts.Backoff = time.Second
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 10 * time.Second
+	}
+	return &Webhook{
+		opts:   opts,
+		client: &http.Client{Timeout: opts.Timeout},
+	}, nil
+}
+
+// Sign returns the signature of a request body computed with a secret.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (w *Webhook) Notify(ctx context.Context, n *rpc.Notification) error {
+	body, err := protojson.Marshal(n)
+	if err != nil {
+		return err
+	}
+
+	backoff := w.opts.Backoff
+	for attempt := 1; ; attempt++ {
+		retry, err := w.post(ctx, n, body)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt == w.opts.Attempts {
+			return fmt.Errorf("webhook %s: %w", w.opts.URL, err)
+		}
+		log.FromContext(ctx).WithError(err).Debugf("Webhook delivery attempt %d failed, retrying in %s.", attempt, backoff)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("webhook %s: %w", w.opts.URL, ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// post makes a single delivery attempt and reports whether a failure should be retried.
+func (w *Webhook) post(ctx context.Context, n *rpc.Notification, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.opts.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(ChangeHeader, n.GetChange().String())
+	if w.opts.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(w.opts.Secret, body))
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return true, fmt.Errorf("unexpected response %q", resp.Status)
+	default:
+		return false, fmt.Errorf("unexpected response %q", resp.Status)
+	}
+}
+
+func (w *Webhook) Close() error {
+	w.client.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,142 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestWebhook(t *testing.T) {
+	want := &rpc.Notification{
+		Change:   rpc.Notification_CREATED,
+		Resource: "projects/p/locations/global/apis/a",
+	}
+
+	var got *rpc.Notification
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("Failed to read body: %s", err)
+		}
+		if sig := r.Header.Get(SignatureHeader); sig != Sign("secret", body) {
+			t.Errorf("Unexpected signature %q", sig)
+		}
+		if change := r.Header.Get(ChangeHeader); change != "CREATED" {
+			t.Errorf("Unexpected change %q", change)
+		}
+		got = &rpc.Notification{}
+		if err := protojson.Unmarshal(body, got); err != nil {
+			t.Errorf("Failed to unmarshal body: %s", err)
+		}
+	}))
+	defer hook.Close()
+
+	w, err := NewWebhook(WebhookOptions{URL: hook.URL, Secret: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	if err := w.Notify(context.Background(), want); err != nil {
+		t.Fatalf("Notify() returned error: %s", err)
+	}
+	if !cmp.Equal(want, got, protocmp.Transform()) {
+		t.Errorf("Notify() delivered unexpected diff (-want +got):\n%s", cmp.Diff(want, got, protocmp.Transform()))
+	}
+}
+
+func TestSign(t *testing.T) {
+	// Computed with: printf 'body' | openssl dgst -sha256 -hmac secret
+	want := "sha256=dc46983557fea127b43af721467eb9b3fde2338fe3e14f51952aa8478c13d355"
+	if got := Sign("secret", []byte("body")); got != want {
+		t.Errorf("Sign() returned %q, want %q", got, want)
+	}
+}
+
+func TestWebhookRetries(t *testing.T) {
+	tests := []struct {
+		desc     string
+		statuses []int
+		attempts int
+		wantErr  bool
+		wantReqs int32
+	}{
+		{
+			desc:     "success",
+			statuses: []int{http.StatusOK},
+			attempts: 3,
+			wantReqs: 1,
+		},
+		{
+			desc:     "retry server errors",
+			statuses: []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusNoContent},
+			attempts: 3,
+			wantReqs: 3,
+		},
+		{
+			desc:     "attempts exhausted",
+			statuses: []int{http.StatusInternalServerError, http.StatusInternalServerError, http.StatusOK},
+			attempts: 2,
+			wantErr:  true,
+			wantReqs: 2,
+		},
+		{
+			desc:     "client errors are not retried",
+			statuses: []int{http.StatusBadRequest, http.StatusOK},
+			attempts: 3,
+			wantErr:  true,
+			wantReqs: 1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			var reqs int32
+			hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				i := atomic.AddInt32(&reqs, 1) - 1
+				w.WriteHeader(test.statuses[i])
+			}))
+			defer hook.Close()
+
+			w, err := NewWebhook(WebhookOptions{
+				URL:      hook.URL,
+				Attempts: test.attempts,
+				Backoff:  time.Millisecond,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer w.Close()
+
+			err = w.Notify(context.Background(), &rpc.Notification{})
+			if test.wantErr != (err != nil) {
+				t.Errorf("Notify() returned error %v, want error: %t", err, test.wantErr)
+			}
+			if reqs != test.wantReqs {
+				t.Errorf("Notify() made %d requests, want %d", reqs, test.wantReqs)
+			}
+		})
+	}
+}
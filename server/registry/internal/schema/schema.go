@@ -0,0 +1,360 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package schema validates JSON and YAML documents with JSON Schemas.
+//
+// Schemas can be written in JSON or YAML. The validation keywords type, enum,
+// properties, required, additionalProperties, items, minItems, maxItems,
+// minLength, maxLength, pattern, minimum and maximum are supported. Schemas
+// that use other validation keywords, such as $ref, allOf, anyOf and oneOf,
+// are rejected. Annotations such as title and description are ignored.
+package schema
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Schema is a parsed JSON Schema.
+type Schema struct {
+	Type                 typeList           `yaml:"type"`
+	Enum                 []interface{}      `yaml:"enum"`
+	Properties           map[string]*Schema `yaml:"properties"`
+	Required             []string           `yaml:"required"`
+	AdditionalProperties *additional        `yaml:"additionalProperties"`
+	Items                *Schema            `yaml:"items"`
+	MinItems             *int               `yaml:"minItems"`
+	MaxItems             *int               `yaml:"maxItems"`
+	MinLength            *int               `yaml:"minLength"`
+	MaxLength            *int               `yaml:"maxLength"`
+	Pattern              string             `yaml:"pattern"`
+	Minimum              *float64           `yaml:"minimum"`
+	Maximum              *float64           `yaml:"maximum"`
+
+	pattern *regexp.Regexp
+	// unsupported are the unsupported keywords used by the schema.
+	unsupported []string
+}
+
+// unsupportedKeywords are the validation keywords that aren't supported.
+// Schemas that use them are rejected rather than validating documents
+// that they wouldn't match.
+var unsupportedKeywords = map[string]bool{
+	"$ref":              true,
+	"$dynamicRef":       true,
+	"$recursiveRef":     true,
+	"allOf":             true,
+	"anyOf":             true,
+	"oneOf":             true,
+	"not":               true,
+	"if":                true,
+	"then":              true,
+	"else":              true,
+	"const":             true,
+	"dependencies":      true,
+	"dependentRequired": true,
+	"dependentSchemas":  true,
+	"patternProperties": true,
+	"propertyNames":     true,
+	"minProperties":     true,
+	"maxProperties":     true,
+	"prefixItems":       true,
+	"additionalItems":   true,
+	"contains":          true,
+	"minContains":       true,
+	"maxContains":       true,
+	"uniqueItems":       true,
+	"exclusiveMinimum":  true,
+	"exclusiveMaximum":  true,
+	"multipleOf":        true,
+}
+
+func (s *Schema) UnmarshalYAML(node *yaml.Node) error {
+	type plain Schema
+	if err := node.Decode((*plain)(s)); err != nil {
+		return err
+	}
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i < len(node.Content); i += 2 {
+			if k := node.Content[i].Value; unsupportedKeywords[k] {
+				s.unsupported = append(s.unsupported, k)
+			}
+		}
+	}
+	return nil
+}
+
+// typeList holds the value of the type keyword, which is a name or a list of names.
+type typeList []string
+
+func (t *typeList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*t = typeList{node.Value}
+		return nil
+	}
+	var names []string
+	if err := node.Decode(&names); err != nil {
+		return err
+	}
+	*t = names
+	return nil
+}
+
+// additional holds the value of the additionalProperties keyword, which is a boolean or a schema.
+type additional struct {
+	allowed bool
+	schema  *Schema
+}
+
+func (a *additional) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		return node.Decode(&a.allowed)
+	}
+	a.allowed = true
+	return node.Decode(&a.schema)
+}
+
+// knownTypes are the values of the type keyword.
+var knownTypes = map[string]bool{
+	"object":  true,
+	"array":   true,
+	"string":  true,
+	"number":  true,
+	"integer": true,
+	"boolean": true,
+	"null":    true,
+}
+
+// Parse parses a schema written in JSON or YAML.
+func Parse(b []byte) (*Schema, error) {
+	s := new(Schema)
+	if err := yaml.Unmarshal(b, s); err != nil {
+		return nil, err
+	}
+	if err := s.compile(""); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// compile checks the keywords of a schema and its subschemas.
+func (s *Schema) compile(path string) error {
+	if s == nil {
+		return nil
+	}
+	if len(s.unsupported) > 0 {
+		return fmt.Errorf("%s: unsupported keyword %q", location(path), s.unsupported[0])
+	}
+	for _, t := range s.Type {
+		if !knownTypes[t] {
+			return fmt.Errorf("%s: unknown type %q", location(path), t)
+		}
+	}
+	if s.Pattern != "" {
+		var err error
+		if s.pattern, err = regexp.Compile(s.Pattern); err != nil {
+			return fmt.Errorf("%s: invalid pattern: %s", location(path), err)
+		}
+	}
+	for name, p := range s.Properties {
+		if err := p.compile(join(path, "properties."+name)); err != nil {
+			return err
+		}
+	}
+	if s.AdditionalProperties != nil {
+		if err := s.AdditionalProperties.schema.compile(join(path, "additionalProperties")); err != nil {
+			return err
+		}
+	}
+	return s.Items.compile(join(path, "items"))
+}
+
+// Violation describes a value in a document that doesn't match its schema.
+type Violation struct {
+	// Field is the path of the value in the document, such as "metadata.labels[0]".
+	// It is empty for the top-level value.
+	Field string
+	// Description explains how the value doesn't match the schema.
+	Description string
+}
+
+// Validate parses a JSON or YAML document and returns the ways that it doesn't match a schema.
+func (s *Schema) Validate(b []byte) ([]Violation, error) {
+	var doc interface{}
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+	var violations []Violation
+	s.validate("", doc, &violations)
+	return violations, nil
+}
+
+func (s *Schema) validate(path string, v interface{}, violations *[]Violation) {
+	if s == nil {
+		return
+	}
+	report := func(format string, args ...interface{}) {
+		*violations = append(*violations, Violation{Field: path, Description: fmt.Sprintf(format, args...)})
+	}
+
+	if len(s.Type) > 0 && !s.Type.matches(v) {
+		report("must be of type %s, not %s", strings.Join(s.Type, " or "), typeOf(v))
+		return
+	}
+	if len(s.Enum) > 0 {
+		found := false
+		for _, e := range s.Enum {
+			if equal(e, v) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			report("must be one of %v", s.Enum)
+		}
+	}
+
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				report("missing required property %q", name)
+			}
+		}
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if p, ok := s.Properties[name]; ok {
+				p.validate(join(path, name), v[name], violations)
+			} else if a := s.AdditionalProperties; a != nil {
+				if !a.allowed {
+					*violations = append(*violations, Violation{Field: join(path, name), Description: "is not an allowed property"})
+				} else {
+					a.schema.validate(join(path, name), v[name], violations)
+				}
+			}
+		}
+	case []interface{}:
+		if s.MinItems != nil && len(v) < *s.MinItems {
+			report("must have at least %d items", *s.MinItems)
+		}
+		if s.MaxItems != nil && len(v) > *s.MaxItems {
+			report("must have at most %d items", *s.MaxItems)
+		}
+		for i, item := range v {
+			s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item, violations)
+		}
+	case string:
+		n := len([]rune(v))
+		if s.MinLength != nil && n < *s.MinLength {
+			report("must be at least %d characters long", *s.MinLength)
+		}
+		if s.MaxLength != nil && n > *s.MaxLength {
+			report("must be at most %d characters long", *s.MaxLength)
+		}
+		if s.pattern != nil && !s.pattern.MatchString(v) {
+			report("must match pattern %q", s.Pattern)
+		}
+	default:
+		if n, ok := number(v); ok {
+			if s.Minimum != nil && n < *s.Minimum {
+				report("must be at least %v", *s.Minimum)
+			}
+			if s.Maximum != nil && n > *s.Maximum {
+				report("must be at most %v", *s.Maximum)
+			}
+		}
+	}
+}
+
+// matches returns true if a value has one of the types in a list.
+func (t typeList) matches(v interface{}) bool {
+	for _, name := range t {
+		switch actual := typeOf(v); {
+		case name == actual:
+			return true
+		case name == "number" && actual == "integer":
+			return true
+		}
+	}
+	return false
+}
+
+// typeOf returns the JSON Schema type of a decoded value.
+// Numbers with no fractional part are integers.
+func typeOf(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	}
+	if n, ok := number(v); ok {
+		if n == math.Trunc(n) {
+			return "integer"
+		}
+		return "number"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+func number(v interface{}) (float64, bool) {
+	switch v := v.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
+func equal(a, b interface{}) bool {
+	if x, ok := number(a); ok {
+		y, ok := number(b)
+		return ok && x == y
+	}
+	return fmt.Sprintf("%#v", a) == fmt.Sprintf("%#v", b)
+}
+
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func location(path string) string {
+	if path == "" {
+		return "schema"
+	}
+	return path
+}
@@ -0,0 +1,123 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const ownersSchema = `
+title: Owners
+description: The owners of an API.
+type: object
+required: [team]
+additionalProperties: false
+properties:
+  team:
+    type: string
+    pattern: "^[a-z-]+$"
+  tier:
+    enum: [gold, silver]
+  oncall:
+    type: array
+    minItems: 1
+    items:
+      type: string
+      minLength: 3
+  priority:
+    type: integer
+    minimum: 0
+    maximum: 5
+`
+
+func TestValidate(t *testing.T) {
+	s, err := Parse([]byte(ownersSchema))
+	if err != nil {
+		t.Fatalf("Parse() returned error: %s", err)
+	}
+	tests := []struct {
+		desc string
+		doc  string
+		want []Violation
+	}{
+		{
+			desc: "valid YAML",
+			doc:  "team: payments\ntier: gold\noncall: [alice, bob]\npriority: 2\n",
+		},
+		{
+			desc: "valid JSON",
+			doc:  `{"team": "payments", "priority": 0}`,
+		},
+		{
+			desc: "wrong top-level type",
+			doc:  "- team",
+			want: []Violation{{Description: "must be of type object, not array"}},
+		},
+		{
+			desc: "missing required property",
+			doc:  "tier: gold",
+			want: []Violation{{Description: `missing required property "team"`}},
+		},
+		{
+			desc: "invalid properties",
+			doc:  "team: Payments\ntier: bronze\noncall: [al]\npriority: 2.5\nowner: carol\n",
+			want: []Violation{
+				{Field: "oncall[0]", Description: "must be at least 3 characters long"},
+				{Field: "owner", Description: "is not an allowed property"},
+				{Field: "priority", Description: "must be of type integer, not number"},
+				{Field: "team", Description: `must match pattern "^[a-z-]+$"`},
+				{Field: "tier", Description: "must be one of [gold silver]"},
+			},
+		},
+		{
+			desc: "out of range",
+			doc:  "team: payments\noncall: []\npriority: 6\n",
+			want: []Violation{
+				{Field: "oncall", Description: "must have at least 1 items"},
+				{Field: "priority", Description: "must be at most 5"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			got, err := s.Validate([]byte(test.doc))
+			if err != nil {
+				t.Fatalf("Validate() returned error: %s", err)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("Validate() returned unexpected violations (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, s := range []string{
+		"type: [object",
+		"type: thing",
+		"properties:\n  name:\n    pattern: \"[\"",
+		"items:\n  type: [string, date]",
+		"$ref: '#/definitions/owner'",
+		"properties:\n  team:\n    oneOf: [{type: string}, {type: integer}]",
+		"items:\n  allOf: [{type: string}]",
+		"additionalProperties:\n  anyOf: [{type: string}]",
+	} {
+		if _, err := Parse([]byte(s)); err == nil {
+			t.Errorf("Parse(%q) succeeded, want error", s)
+		}
+	}
+}
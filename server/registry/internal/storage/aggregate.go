@@ -0,0 +1,345 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/apigee/registry/server/registry/internal/storage/filtering"
+	"github.com/apigee/registry/server/registry/internal/storage/models"
+	"github.com/apigee/registry/server/registry/names"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// AggregateOptions contains custom arguments for aggregation requests.
+type AggregateOptions struct {
+	// Filter selects the resources to count, as described at https://google.aip.dev/160.
+	Filter string
+	// GroupBy is the field that resources are grouped by, e.g. "mime_type".
+	// Values of labels and annotations are named like "labels.owner".
+	// If unspecified, resources are counted without grouping.
+	GroupBy string
+}
+
+// Group is the number of resources that share a value of the grouped field.
+// Resources without a value of a label or annotation are grouped under an empty value.
+type Group struct {
+	Value string
+	Count int64
+}
+
+// Aggregate contains the number of resources in a collection.
+type Aggregate struct {
+	Count int64
+	// Groups are sorted by value.
+	Groups []Group
+}
+
+func (c *Client) AggregateProjects(ctx context.Context, opts AggregateOptions) (Aggregate, error) {
+	op := c.db.WithContext(ctx).Model(&models.Project{})
+	return c.aggregate(op, "projects", opts, func(rows *sql.Rows) (map[string]interface{}, error) {
+		var v models.Project
+		if err := c.db.ScanRows(rows, &v); err != nil {
+			return nil, err
+		}
+		return projectMap(v), nil
+	})
+}
+
+func (c *Client) AggregateApis(ctx context.Context, parent names.Project, opts AggregateOptions) (Aggregate, error) {
+	op := c.db.WithContext(ctx).Model(&models.Api{})
+	if id := parent.ProjectID; id != "-" {
+		op = op.Where("apis.project_id = ?", id)
+	}
+	return c.aggregate(op, "apis", opts, func(rows *sql.Rows) (map[string]interface{}, error) {
+		var v models.Api
+		if err := c.db.ScanRows(rows, &v); err != nil {
+			return nil, err
+		}
+		return apiMap(v)
+	})
+}
+
+func (c *Client) AggregateVersions(ctx context.Context, parent names.Api, opts AggregateOptions) (Aggregate, error) {
+	op := c.db.WithContext(ctx).Model(&models.Version{})
+	if id := parent.ProjectID; id != "-" {
+		op = op.Where("versions.project_id = ?", id)
+	}
+	if id := parent.ApiID; id != "-" {
+		op = op.Where("versions.api_id = ?", id)
+	}
+	return c.aggregate(op, "versions", opts, func(rows *sql.Rows) (map[string]interface{}, error) {
+		var v models.Version
+		if err := c.db.ScanRows(rows, &v); err != nil {
+			return nil, err
+		}
+		return versionMap(v)
+	})
+}
+
+// AggregateSpecs counts the latest revisions of specs.
+func (c *Client) AggregateSpecs(ctx context.Context, parent names.Version, opts AggregateOptions) (Aggregate, error) {
+	op := c.db.WithContext(ctx).Model(&models.Spec{}).Select("specs.*").
+		// select latest spec revision
+		Joins(`join (?) latest
+		ON specs.project_id = latest.project_id
+		AND specs.api_id = latest.api_id
+		AND specs.version_id = latest.version_id
+		AND specs.spec_id = latest.spec_id
+		AND specs.revision_id = latest.revision_id`, c.latestSpecRevisionsQuery(ctx))
+	return c.aggregateSpecs(op, parent.Spec("-").Revision("-"), opts)
+}
+
+// AggregateSpecRevisions counts all revisions of specs.
+func (c *Client) AggregateSpecRevisions(ctx context.Context, parent names.SpecRevision, opts AggregateOptions) (Aggregate, error) {
+	return c.aggregateSpecs(c.db.WithContext(ctx).Model(&models.Spec{}), parent, opts)
+}
+
+func (c *Client) aggregateSpecs(op *gorm.DB, parent names.SpecRevision, opts AggregateOptions) (Aggregate, error) {
+	if id := parent.ProjectID; id != "-" {
+		op = op.Where("specs.project_id = ?", id)
+	}
+	if id := parent.ApiID; id != "-" {
+		op = op.Where("specs.api_id = ?", id)
+	}
+	if id := parent.VersionID; id != "-" {
+		op = op.Where("specs.version_id = ?", id)
+	}
+	if id := parent.SpecID; id != "-" {
+		op = op.Where("specs.spec_id = ?", id)
+	}
+	if id := parent.RevisionID; id != "-" {
+		op = op.Where("specs.revision_id = ?", id)
+	}
+	return c.aggregate(op, "specs", opts, func(rows *sql.Rows) (map[string]interface{}, error) {
+		var v models.Spec
+		if err := c.db.ScanRows(rows, &v); err != nil {
+			return nil, err
+		}
+		return specMap(v)
+	})
+}
+
+// AggregateDeployments counts the latest revisions of deployments.
+func (c *Client) AggregateDeployments(ctx context.Context, parent names.Api, opts AggregateOptions) (Aggregate, error) {
+	op := c.db.WithContext(ctx).Model(&models.Deployment{}).Select("deployments.*").
+		// select latest deployment revision
+		Joins(`join (?) latest
+		ON deployments.project_id = latest.project_id
+		AND deployments.api_id = latest.api_id
+		AND deployments.deployment_id = latest.deployment_id
+		AND deployments.revision_id = latest.revision_id`, c.latestDeploymentRevisionsQuery(ctx))
+	return c.aggregateDeployments(op, parent.Deployment("-").Revision("-"), opts)
+}
+
+// AggregateDeploymentRevisions counts all revisions of deployments.
+func (c *Client) AggregateDeploymentRevisions(ctx context.Context, parent names.DeploymentRevision, opts AggregateOptions) (Aggregate, error) {
+	return c.aggregateDeployments(c.db.WithContext(ctx).Model(&models.Deployment{}), parent, opts)
+}
+
+func (c *Client) aggregateDeployments(op *gorm.DB, parent names.DeploymentRevision, opts AggregateOptions) (Aggregate, error) {
+	if id := parent.ProjectID; id != "-" {
+		op = op.Where("deployments.project_id = ?", id)
+	}
+	if id := parent.ApiID; id != "-" {
+		op = op.Where("deployments.api_id = ?", id)
+	}
+	if id := parent.DeploymentID; id != "-" {
+		op = op.Where("deployments.deployment_id = ?", id)
+	}
+	if id := parent.RevisionID; id != "-" {
+		op = op.Where("deployments.revision_id = ?", id)
+	}
+	return c.aggregate(op, "deployments", opts, func(rows *sql.Rows) (map[string]interface{}, error) {
+		var v models.Deployment
+		if err := c.db.ScanRows(rows, &v); err != nil {
+			return nil, err
+		}
+		return deploymentMap(v)
+	})
+}
+
+// AggregateArtifacts counts the artifacts in a collection, which is named like
+// an artifact with an empty artifact ID. Artifacts of specs and deployments
+// are counted for their latest revisions unless the collection names a revision.
+func (c *Client) AggregateArtifacts(ctx context.Context, collection names.Artifact, opts AggregateOptions) (Aggregate, error) {
+	op := c.db.WithContext(ctx).Model(&models.Artifact{}).Select("artifacts.*")
+	for _, v := range []struct {
+		column, id string
+	}{
+		{"project_id", collection.ProjectID()},
+		{"api_id", collection.ApiID()},
+		{"version_id", collection.VersionID()},
+		{"spec_id", collection.SpecID()},
+		{"deployment_id", collection.DeploymentID()},
+		{"revision_id", collection.RevisionID()},
+	} {
+		// Empty IDs are those of resources that aren't parents of the collection,
+		// except for revisions, which are selected below.
+		if v.id != "-" && !(v.column == "revision_id" && v.id == "") {
+			op = op.Where("artifacts."+v.column+" = ?", v.id)
+		}
+	}
+
+	if collection.RevisionID() == "" && collection.SpecID() != "" {
+		op = op.Joins(`join (?) latest
+			ON artifacts.project_id = latest.project_id
+			AND artifacts.api_id = latest.api_id
+			AND artifacts.version_id = latest.version_id
+			AND artifacts.spec_id = latest.spec_id
+			AND artifacts.revision_id = latest.revision_id`, c.latestSpecRevisionsQuery(ctx))
+	} else if collection.RevisionID() == "" && collection.DeploymentID() != "" {
+		op = op.Joins(`join (?) latest
+			ON artifacts.project_id = latest.project_id
+			AND artifacts.api_id = latest.api_id
+			AND artifacts.deployment_id = latest.deployment_id
+			AND artifacts.revision_id = latest.revision_id`, c.latestDeploymentRevisionsQuery(ctx))
+	}
+
+	return c.aggregate(op, "artifacts", opts, func(rows *sql.Rows) (map[string]interface{}, error) {
+		var v models.Artifact
+		if err := c.db.ScanRows(rows, &v); err != nil {
+			return nil, err
+		}
+		return artifactMap(v)
+	})
+}
+
+// aggregate counts the rows selected by a query of a table. When the database
+// can't evaluate the filter or the grouping, rows are read and counted in memory,
+// with scan converting each row to the fields that filters refer to.
+func (c *Client) aggregate(op *gorm.DB, table string, opts AggregateOptions, scan func(*sql.Rows) (map[string]interface{}, error)) (Aggregate, error) {
+	ctx := op.Statement.Context
+	filter, err := c.newListFilter(opts.Filter, table)
+	if err != nil {
+		return Aggregate{}, err
+	}
+	group, err := newGrouping(opts.GroupBy, table)
+	if err != nil {
+		return Aggregate{}, err
+	}
+	op = filter.where(op)
+
+	counts := make(map[string]int64)
+	if value, args, ok := group.sql(c.db.Dialector.Name()); filter.exact && ok {
+		var groups []struct {
+			GroupValue sql.NullString
+			GroupCount int64
+		}
+		if group.field == "" {
+			op = op.Select("COUNT(*) AS group_count")
+		} else {
+			op = op.Select(value+" AS group_value, COUNT(*) AS group_count", args...).Group("group_value")
+		}
+		if err := op.Scan(&groups).Error; err != nil {
+			return Aggregate{}, grpcErrorForDBError(ctx, errors.Wrapf(err, "aggregate %s", table))
+		}
+		for _, g := range groups {
+			counts[g.GroupValue.String] += g.GroupCount
+		}
+	} else {
+		rows, err := op.Rows()
+		if err != nil {
+			return Aggregate{}, grpcErrorForDBError(ctx, errors.Wrapf(err, "aggregate %s", table))
+		}
+		defer rows.Close()
+		for rows.Next() {
+			m, err := scan(rows)
+			if err != nil {
+				return Aggregate{}, status.Error(codes.Internal, err.Error())
+			}
+			if match, err := filter.Matches(m); err != nil {
+				return Aggregate{}, err
+			} else if match {
+				counts[group.value(m)]++
+			}
+		}
+		if err := rows.Err(); err != nil {
+			return Aggregate{}, grpcErrorForDBError(ctx, errors.Wrapf(err, "aggregate %s", table))
+		}
+	}
+
+	response := Aggregate{}
+	for value, count := range counts {
+		response.Count += count
+		if group.field != "" {
+			response.Groups = append(response.Groups, Group{Value: value, Count: count})
+		}
+	}
+	sort.Slice(response.Groups, func(i, j int) bool {
+		return response.Groups[i].Value < response.Groups[j].Value
+	})
+	return response, nil
+}
+
+// grouping is the field that resources are grouped by.
+type grouping struct {
+	table string
+	field string
+	key   string // Key of a map field.
+}
+
+// newGrouping parses a user-specified group_by field of a table.
+// An empty field puts all resources in a single group.
+func newGrouping(groupBy, table string) (grouping, error) {
+	g := grouping{table: table}
+	if groupBy == "" {
+		return g, nil
+	}
+	g.field = groupBy
+	if i := strings.Index(groupBy, "."); i >= 0 {
+		g.field, g.key = groupBy[:i], groupBy[i+1:]
+	}
+
+	switch kind, ok := tableFieldsLookup[table][g.field]; {
+	case !ok:
+		return grouping{}, status.Errorf(codes.InvalidArgument, "unknown field name %q in %q", g.field, table)
+	case kind == filtering.StringMap && g.key == "":
+		return grouping{}, status.Errorf(codes.InvalidArgument, "invalid group_by field %q: missing key of %q", groupBy, g.field)
+	case kind != filtering.StringMap && g.key != "":
+		return grouping{}, status.Errorf(codes.InvalidArgument, "invalid group_by field %q: %q has no keys", groupBy, g.field)
+	case kind == filtering.Timestamp:
+		return grouping{}, status.Errorf(codes.InvalidArgument, "invalid group_by field %q: timestamps can't be grouped", groupBy)
+	}
+	return g, nil
+}
+
+// sql returns the SQL expression of the grouped values, if it can be computed by the database.
+func (g grouping) sql(dialect string) (string, []interface{}, bool) {
+	if g.field == "" {
+		return "", nil, true
+	}
+	return filtering.ValueSQL(dialect, tableFieldsLookup[g.table], filterColumns(g.table), g.field, g.key)
+}
+
+// value returns the grouped value of a resource, given the values of its fields.
+func (g grouping) value(fields map[string]interface{}) string {
+	if g.field == "" {
+		return ""
+	}
+	v := fields[g.field]
+	if g.key != "" {
+		m, _ := v.(map[string]string)
+		return m[g.key]
+	}
+	return fmt.Sprint(v)
+}
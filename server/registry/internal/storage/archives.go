@@ -0,0 +1,167 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/apigee/registry/server/registry/internal/storage/blobstore"
+	"github.com/apigee/registry/server/registry/internal/storage/models"
+	"github.com/apigee/registry/server/registry/names"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// maxDatabaseArchiveSize is the size of the largest archive that is stored in
+// the database. Larger archives require a blob store.
+const maxDatabaseArchiveSize = 64 << 20
+
+// CreateArchive stores a new archive with contents read from r. The contents
+// are streamed to the blob store if there is one and are otherwise stored in
+// the database, unless they are larger than maxDatabaseArchiveSize.
+func (c *Client) CreateArchive(ctx context.Context, v *models.Archive, r io.ReadSeeker) error {
+	if c.blobs == nil {
+		if v.SizeInBytes > maxDatabaseArchiveSize {
+			return status.Errorf(codes.FailedPrecondition, "archive of %d bytes is larger than %d bytes, the largest archive that can be stored without a blob store", v.SizeInBytes, maxDatabaseArchiveSize)
+		}
+		contents, err := io.ReadAll(r)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to read contents of %s: %s", v.Key, err)
+		}
+		v.Contents = contents
+		return c.create(ctx, v)
+	}
+
+	key := v.Hash + ".archive"
+	if err := c.lockContents(ctx, key); err != nil {
+		return grpcErrorForDBError(ctx, err)
+	}
+	if err := c.blobs.PutReader(ctx, key, r); err != nil {
+		return status.Errorf(codes.Unavailable, "failed to store contents of %s: %s", v.Key, err)
+	}
+	v.Location = key
+	return c.create(ctx, v)
+}
+
+// GetArchive returns an archive that has not expired.
+func (c *Client) GetArchive(ctx context.Context, name string) (*models.Archive, error) {
+	v := new(models.Archive)
+	if err := c.db.WithContext(ctx).Take(v, "key = ? AND expire_time > ?", name, time.Now()).Error; err == gorm.ErrRecordNotFound {
+		return nil, status.Errorf(codes.NotFound, "%q not found in database", name)
+	} else if err != nil {
+		return nil, grpcErrorForDBError(ctx, err)
+	}
+	return v, nil
+}
+
+// OpenArchive returns a reader of the contents of an archive, which are
+// streamed from the blob store if they are stored there.
+func (c *Client) OpenArchive(ctx context.Context, v *models.Archive) (io.ReadCloser, error) {
+	if v.Location == "" {
+		return io.NopCloser(bytes.NewReader(v.Contents)), nil
+	}
+	if c.blobs == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "contents of %s are in a blob store, but no blob store is configured", v.Key)
+	}
+	r, err := c.blobs.Open(ctx, v.Location)
+	if errors.Is(err, blobstore.ErrNotFound) {
+		return nil, status.Errorf(codes.DataLoss, "contents of %s are missing from the blob store", v.Key)
+	} else if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "failed to read contents of %s: %s", v.Key, err)
+	}
+	return r, nil
+}
+
+// PurgeArchives removes archives that expired before a specified time and
+// returns the number removed. Their contents are removed from the blob store
+// with other unused contents.
+func (c *Client) PurgeArchives(ctx context.Context, now time.Time) (int64, error) {
+	expired := func(db *gorm.DB) *gorm.DB {
+		return db.Where("expire_time <= ?", now)
+	}
+	if err := c.releaseContents(ctx, &models.Archive{}, expired); err != nil {
+		return 0, err
+	}
+	op := c.db.WithContext(ctx).Scopes(expired).Delete(&models.Archive{})
+	return op.RowsAffected, grpcErrorForDBError(ctx, op.Error)
+}
+
+// ProjectResources holds the resources of a project or of one of its APIs.
+// Contents of specs and artifacts are not included.
+type ProjectResources struct {
+	Project                *models.Project
+	Apis                   []*models.Api
+	Versions               []*models.Version
+	Specs                  []*models.Spec // All revisions, oldest first.
+	SpecRevisionTags       []*models.SpecRevisionTag
+	Deployments            []*models.Deployment // All revisions, oldest first.
+	DeploymentRevisionTags []*models.DeploymentRevisionTag
+	Artifacts              []*models.Artifact
+	ArtifactRevisions      []*models.ArtifactRevision // All revisions, oldest first.
+	ArtifactRevisionTags   []*models.ArtifactRevisionTag
+}
+
+// GetProjectResources returns all of the resources of a project.
+func (c *Client) GetProjectResources(ctx context.Context, name names.Project) (*ProjectResources, error) {
+	project, err := c.GetProject(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	op := c.db.WithContext(ctx).Where("project_id = ?", name.ProjectID)
+	return c.getResources(ctx, project, op)
+}
+
+// GetApiResources returns the project of an API with the API and all of its
+// resources. Other resources of the project are not included.
+func (c *Client) GetApiResources(ctx context.Context, name names.Api) (*ProjectResources, error) {
+	project, err := c.GetProject(ctx, name.Project())
+	if err != nil {
+		return nil, err
+	}
+	if _, err := c.GetApi(ctx, name); err != nil {
+		return nil, err
+	}
+	op := c.db.WithContext(ctx).Where("project_id = ? AND api_id = ?", name.ProjectID, name.ApiID)
+	return c.getResources(ctx, project, op)
+}
+
+func (c *Client) getResources(ctx context.Context, project *models.Project, op *gorm.DB) (*ProjectResources, error) {
+	r := &ProjectResources{Project: project}
+	op = op.Session(&gorm.Session{})
+	for _, q := range []struct {
+		dest  interface{}
+		order string
+	}{
+		{&r.Apis, "api_id"},
+		{&r.Versions, "api_id, version_id"},
+		{&r.Specs, "api_id, version_id, spec_id, revision_create_time, revision_id"},
+		{&r.SpecRevisionTags, "key"},
+		{&r.Deployments, "api_id, deployment_id, revision_create_time, revision_id"},
+		{&r.DeploymentRevisionTags, "key"},
+		{&r.Artifacts, "key"},
+		{&r.ArtifactRevisions, "api_id, version_id, spec_id, revision_id, deployment_id, artifact_id, update_time, artifact_revision_id"},
+		{&r.ArtifactRevisionTags, "key"},
+	} {
+		if err := op.Order(q.order).Find(q.dest).Error; err != nil {
+			return nil, grpcErrorForDBError(ctx, err)
+		}
+	}
+	return r, nil
+}
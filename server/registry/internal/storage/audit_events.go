@@ -0,0 +1,82 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+
+	"github.com/apigee/registry/server/registry/internal/storage/filtering"
+	"github.com/apigee/registry/server/registry/internal/storage/models"
+	"gorm.io/gorm"
+)
+
+var auditEventFields = map[string]filtering.FieldType{
+	"id":          filtering.Int,
+	"resource":    filtering.String,
+	"method":      filtering.String,
+	"caller":      filtering.String,
+	"update_mask": filtering.String,
+	"create_time": filtering.Timestamp,
+}
+
+func auditEventMap(e models.AuditEvent) map[string]interface{} {
+	return map[string]interface{}{
+		"id":          e.ID,
+		"resource":    e.Resource,
+		"method":      e.Method,
+		"caller":      e.Caller,
+		"update_mask": e.UpdateMask,
+		"create_time": e.CreateTime,
+	}
+}
+
+// CreateAuditEvents stores records of changes.
+func (c *Client) CreateAuditEvents(ctx context.Context, v []*models.AuditEvent) error {
+	if len(v) == 0 {
+		return nil
+	}
+	return c.create(ctx, v)
+}
+
+// AuditEventList contains a page of audit events.
+type AuditEventList struct {
+	AuditEvents []models.AuditEvent
+	Token       string
+}
+
+// ListAuditEvents lists stored audit events.
+func (c *Client) ListAuditEvents(ctx context.Context, opts PageOptions) (AuditEventList, error) {
+	response := AuditEventList{
+		AuditEvents: make([]models.AuditEvent, 0, opts.Size),
+	}
+	var page []models.AuditEvent
+	var err error
+	response.Token, err = c.listRows(ctx, "audit_events", c.db.WithContext(ctx), opts,
+		func(query *gorm.DB) (int, error) {
+			page = nil
+			err := query.Find(&page).Error
+			return len(page), err
+		},
+		func(i int) listRow {
+			return listRow{key: page[i].ID, fields: auditEventMap(page[i])}
+		},
+		func(i int) {
+			response.AuditEvents = append(response.AuditEvents, page[i])
+		})
+	if err != nil {
+		return AuditEventList{}, err
+	}
+	return response, nil
+}
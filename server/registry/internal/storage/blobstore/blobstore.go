@@ -0,0 +1,56 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package blobstore stores the contents of specs and artifacts outside of the
+// database. Contents are addressed by their hashes, so keys are immutable:
+// contents stored with a key are never changed, only removed when they are no
+// longer used.
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ErrNotFound is returned when a store has no contents with a key.
+var ErrNotFound = errors.New("not found")
+
+// A Store holds contents addressed by key.
+type Store interface {
+	// Put stores contents with a key. Since keys identify contents,
+	// storing contents with an existing key has no effect.
+	Put(ctx context.Context, key string, contents []byte) error
+	// PutReader stores contents read from r with a key without holding them
+	// in memory. Stores may read r more than once.
+	PutReader(ctx context.Context, key string, r io.ReadSeeker) error
+	// Get returns the contents stored with a key or ErrNotFound.
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Open returns a reader of the contents stored with a key or ErrNotFound.
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the contents stored with a key, if there are any.
+	Delete(ctx context.Context, key string) error
+	// List returns the keys of all stored contents.
+	List(ctx context.Context) ([]string, error)
+}
+
+// validateKey returns an error if a key can't be used as an object name or a file name.
+func validateKey(key string) error {
+	if len(key) < 2 || strings.HasPrefix(key, ".") || strings.ContainsAny(key, `/\`) {
+		return fmt.Errorf("invalid key %q", key)
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// testStore checks the behavior that all stores share.
+func testStore(t *testing.T, s Store) {
+	t.Helper()
+	ctx := context.Background()
+
+	if _, err := s.Get(ctx, "abcd"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get(abcd) returned error %v, want %v", err, ErrNotFound)
+	}
+	if err := s.Put(ctx, "abcd", []byte("one")); err != nil {
+		t.Fatalf("Put(abcd) returned error: %s", err)
+	}
+	// Keys identify contents, so contents are not replaced.
+	if err := s.Put(ctx, "abcd", []byte("one")); err != nil {
+		t.Fatalf("Put(abcd) returned error: %s", err)
+	}
+	if err := s.Put(ctx, "abef.gz", []byte("two")); err != nil {
+		t.Fatalf("Put(abef.gz) returned error: %s", err)
+	}
+	if err := s.Put(ctx, "1234", []byte{}); err != nil {
+		t.Fatalf("Put(1234) returned error: %s", err)
+	}
+	if err := s.PutReader(ctx, "cdef", strings.NewReader("three")); err != nil {
+		t.Fatalf("PutReader(cdef) returned error: %s", err)
+	}
+	for key, want := range map[string]string{"abcd": "one", "abef.gz": "two", "1234": "", "cdef": "three"} {
+		got, err := s.Get(ctx, key)
+		if err != nil {
+			t.Fatalf("Get(%s) returned error: %s", key, err)
+		}
+		if string(got) != want {
+			t.Errorf("Get(%s) returned %q, want %q", key, got, want)
+		}
+		r, err := s.Open(ctx, key)
+		if err != nil {
+			t.Fatalf("Open(%s) returned error: %s", key, err)
+		}
+		got, err = io.ReadAll(r)
+		r.Close()
+		if err != nil {
+			t.Fatalf("Open(%s) returned unreadable contents: %s", key, err)
+		}
+		if string(got) != want {
+			t.Errorf("Open(%s) read %q, want %q", key, got, want)
+		}
+	}
+	if _, err := s.Open(ctx, "abcd0"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Open(abcd0) returned error %v, want %v", err, ErrNotFound)
+	}
+
+	keys, err := s.List(ctx)
+	if err != nil {
+		t.Fatalf("List() returned error: %s", err)
+	}
+	sort.Strings(keys)
+	if diff := cmp.Diff([]string{"1234", "abcd", "abef.gz", "cdef"}, keys); diff != "" {
+		t.Errorf("List() returned unexpected diff (-want +got):\n%s", diff)
+	}
+
+	if err := s.Delete(ctx, "abcd"); err != nil {
+		t.Fatalf("Delete(abcd) returned error: %s", err)
+	}
+	if err := s.Delete(ctx, "abcd"); err != nil {
+		t.Errorf("Delete(abcd) of missing contents returned error: %s", err)
+	}
+	if _, err := s.Get(ctx, "abcd"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get(abcd) after Delete returned error %v, want %v", err, ErrNotFound)
+	}
+
+	for _, key := range []string{"", "a", "../abcd", "ab/cd", ".upload-1"} {
+		if err := s.Put(ctx, key, []byte("x")); err == nil {
+			t.Errorf("Put(%q) succeeded, want error", key)
+		}
+	}
+}
+
+func TestFilesystem(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewFilesystem(filepath.Join(dir, "blobs"))
+	if err != nil {
+		t.Fatalf("NewFilesystem() returned error: %s", err)
+	}
+	testStore(t, s)
+
+	if _, err := os.Stat(filepath.Join(dir, "blobs", "ab", "abef.gz")); err != nil {
+		t.Errorf("Contents were not stored in the expected file: %s", err)
+	}
+	// Temporary files of interrupted uploads are not listed.
+	if err := os.WriteFile(filepath.Join(dir, "blobs", "ab", ".upload-1"), []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	keys, err := s.List(context.Background())
+	if err != nil {
+		t.Fatalf("List() returned error: %s", err)
+	}
+	sort.Strings(keys)
+	if diff := cmp.Diff([]string{"1234", "abef.gz", "cdef"}, keys); diff != "" {
+		t.Errorf("List() returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestNewFilesystemRequiresDirectory(t *testing.T) {
+	if _, err := NewFilesystem(""); err == nil {
+		t.Error("NewFilesystem(\"\") succeeded, want error")
+	}
+}
@@ -17,8 +17,11 @@ package storage
 import (
 	"context"
 	"fmt"
+	"runtime"
+	"strings"
 
 	_ "github.com/GoogleCloudPlatform/cloudsql-proxy/proxy/dialers/postgres"
+	"github.com/apigee/registry/server/registry/internal/storage/blobstore"
 	"github.com/apigee/registry/server/registry/internal/storage/models"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
@@ -40,11 +43,42 @@ var entities = []interface{}{
 	&models.DeploymentRevisionTag{},
 	&models.Artifact{},
 	&models.Blob{},
+	&models.NotificationEvent{},
+	&models.AuditEvent{},
 }
 
 // Client represents a connection to a storage provider.
 type Client struct {
 	db *gorm.DB
+	// reader, if set, is a separate pool of connections that only read from the database.
+	reader *gorm.DB
+	// blobs, if set, holds the contents of specs and artifacts instead of the database.
+	blobs blobstore.Store
+}
+
+// sqliteParams are applied to every SQLite connection. Unlike PRAGMA statements,
+// which only affect the connection that runs them, connection parameters are
+// applied by the driver to each connection that it opens.
+var sqliteParams = []string{
+	"_foreign_keys=1",
+	// Write-ahead logging lets readers proceed while a change is being written.
+	"_journal_mode=WAL",
+	"_busy_timeout=5000",
+}
+
+// sqliteDSN adds connection parameters to a SQLite DSN.
+func sqliteDSN(dsn string, params ...string) string {
+	sep := "?"
+	if strings.Contains(dsn, "?") {
+		sep = "&"
+	}
+	return dsn + sep + strings.Join(params, "&")
+}
+
+// sqliteInMemory returns true if a SQLite DSN refers to an in-memory database,
+// which can't be shared by separate connection pools.
+func sqliteInMemory(dsn string) bool {
+	return strings.Contains(dsn, ":memory:") || strings.Contains(dsn, "mode=memory")
 }
 
 // NewClient creates a new database session using the provided driver and data source name.
@@ -55,7 +89,7 @@ type Client struct {
 func NewClient(ctx context.Context, driver, dsn string) (*Client, error) {
 	switch driver {
 	case "sqlite3":
-		db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{
+		db, err := gorm.Open(sqlite.Open(sqliteDSN(dsn, sqliteParams...)), &gorm.Config{
 			Logger:      NewGormLogger(ctx),
 			PrepareStmt: true,
 		})
@@ -64,19 +98,29 @@ func NewClient(ctx context.Context, driver, dsn string) (*Client, error) {
 			c.close()
 			return nil, grpcErrorForDBError(ctx, err)
 		}
-		// Sets to 1 to disallow multiple connections on SQLite.
-		// Any automatically opened connections, such a connection pool
-		// or SetConnMaxLifetime() will not apply post-connect
-		// PRAGMA commands (eg. "foreign_keys = ON").
+		// SQLite allows one writer at a time, so changes are made with a single connection.
 		if err := applyConnectionLimits(db, 1); err != nil {
 			c := &Client{db: db}
 			c.close()
 			return nil, grpcErrorForDBError(ctx, err)
 		}
-		if err := db.Exec("PRAGMA foreign_keys = ON").Error; err != nil {
+		c := &Client{db: db}
+		if sqliteInMemory(dsn) {
+			return c, nil
+		}
+		// Reads use a pool of read-only connections, which don't wait for the writer.
+		c.reader, err = gorm.Open(sqlite.Open(sqliteDSN(dsn, append(sqliteParams, "_query_only=1")...)), &gorm.Config{
+			Logger:      NewGormLogger(ctx),
+			PrepareStmt: true,
+		})
+		if err == nil {
+			err = applyConnectionLimits(c.reader, readerConnections())
+		}
+		if err != nil {
+			c.close()
 			return nil, grpcErrorForDBError(ctx, err)
 		}
-		return &Client{db: db}, nil
+		return c, nil
 	case "postgres", "cloudsqlpostgres":
 		db, err := gorm.Open(postgres.New(postgres.Config{
 			DriverName: driver,
@@ -101,6 +145,16 @@ func NewClient(ctx context.Context, driver, dsn string) (*Client, error) {
 	}
 }
 
+// readerConnections returns the number of read-only connections to open to a SQLite database.
+// Reads scale with cores, but reads that wait for I/O benefit from a few more connections.
+func readerConnections() int {
+	n := runtime.NumCPU()
+	if n < 4 {
+		n = 4
+	}
+	return n
+}
+
 // Applies limits to concurrent connections.
 func applyConnectionLimits(db *gorm.DB, n int) error {
 	sqlDB, err := db.DB()
@@ -118,8 +172,23 @@ func (c *Client) Close() {
 }
 
 func (c *Client) close() {
-	sqlDB, _ := c.db.DB()
-	sqlDB.Close()
+	for _, db := range []*gorm.DB{c.db, c.reader} {
+		if db == nil {
+			continue
+		}
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	}
+}
+
+// ReadOnly returns a client that reads from the database using the pool of
+// read-only connections, if there is one. Changes should be made with Transaction.
+func (c *Client) ReadOnly() *Client {
+	if c.reader == nil {
+		return c
+	}
+	return &Client{db: c.reader, blobs: c.blobs}
 }
 
 func (c *Client) ensureTable(ctx context.Context, v interface{}) error {
@@ -131,30 +200,18 @@ func (c *Client) ensureTable(ctx context.Context, v interface{}) error {
 	return nil
 }
 
-// EnsureTables ensures that all necessary tables exist in the database.
+// EnsureTables migrates the database to the latest schema version. Databases
+// created before schema versions were recorded have version 0, so all
+// migrations are applied to them.
 func (c *Client) EnsureTables(ctx context.Context) error {
-	for _, entity := range entities {
-		if err := c.ensureTable(ctx, entity); err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
-func (c *Client) Migrate(ctx context.Context) error {
-	if err := c.db.WithContext(ctx).AutoMigrate(entities...); err != nil {
-		return grpcErrorForDBError(ctx, err)
-	}
-
-	if err := c.ensureForeignKeys(ctx); err != nil {
-		return grpcErrorForDBError(ctx, err)
+	// Operations aren't part of the versioned schema because migrations run as operations.
+	if err := c.ensureTable(ctx, &models.Operation{}); err != nil {
+		return err
 	}
-
-	if err := c.migrateArtifactsToRevisions(ctx); err != nil {
-		return grpcErrorForDBError(ctx, err)
+	if _, err := c.Migrate(ctx, MigrateOptions{Version: LatestSchemaVersion()}); err != nil {
+		return err
 	}
-
-	return nil
+	return c.ensureSearchIndex(ctx)
 }
 
 func (c *Client) ensureForeignKeys(ctx context.Context) (err error) {
@@ -224,6 +281,114 @@ func (c *Client) migrateArtifactsToRevisions(ctx context.Context) (err error) {
 					AND artifacts.revision_id = latest.revision_id`, c.latestSpecRevisionsQuery(ctx)).Error
 }
 
+// migrateMapsToJSON rewrites labels and annotations that were serialized
+// before maps were stored as JSON, which filters can query.
+func (c *Client) migrateMapsToJSON(ctx context.Context) error {
+	// Serialized maps start with the tag of their first entry.
+	legacy := "substr(%[1]s, 1, 1) = X'0A'"
+	if c.db.Name() == "postgres" {
+		legacy = "substring(%[1]s from 1 for 1) = decode('0a', 'hex')"
+	}
+	for _, table := range []string{"apis", "versions", "specs", "deployments", "artifacts"} {
+		for _, column := range []string{"labels", "annotations"} {
+			for {
+				var rows []struct {
+					Key   string
+					Value []byte
+				}
+				err := c.db.WithContext(ctx).Table(table).
+					Select(fmt.Sprintf("key, %s AS value", column)).
+					Where(fmt.Sprintf(legacy, column)).
+					Limit(1000).Find(&rows).Error
+				if err != nil {
+					return err
+				} else if len(rows) == 0 {
+					break
+				}
+
+				for _, row := range rows {
+					value, err := models.JSONForMapBytes(row.Value)
+					if err != nil {
+						return errors.Wrapf(err, "convert %s of %s", column, row.Key)
+					}
+					if err := c.db.WithContext(ctx).Table(table).
+						Where("key = ?", row.Key).Update(column, value).Error; err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// migrateArtifactRevisions creates the tables of artifact revisions and
+// records the current revision of each artifact that was stored before
+// artifacts had revisions.
+func (c *Client) migrateArtifactRevisions(ctx context.Context) error {
+	for _, model := range []interface{}{&models.ArtifactRevision{}, &models.ArtifactRevisionTag{}} {
+		if err := c.ensureTable(ctx, model); err != nil {
+			return err
+		}
+	}
+	if !c.db.Migrator().HasColumn(&models.Artifact{}, "ArtifactRevisionID") {
+		if err := c.db.WithContext(ctx).Migrator().AddColumn(&models.Artifact{}, "ArtifactRevisionID"); err != nil {
+			return err
+		}
+	}
+
+	for {
+		var artifacts []models.Artifact
+		err := c.db.WithContext(ctx).Unscoped().
+			Where("artifact_revision_id IS NULL OR artifact_revision_id = ''").
+			Limit(1000).Find(&artifacts).Error
+		if err != nil {
+			return err
+		} else if len(artifacts) == 0 {
+			break
+		}
+		for _, v := range artifacts {
+			if err := c.db.WithContext(ctx).Unscoped().Model(&models.Artifact{}).
+				Where("key = ?", v.Key).
+				Update("artifact_revision_id", v.NewRevision().ArtifactRevisionID).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	revisions := c.db.Model(&models.ArtifactRevision{}).Select("1").
+		Where("artifact_revisions.key = artifacts.key || '@' || artifacts.artifact_revision_id")
+	for last := ""; ; {
+		var artifacts []models.Artifact
+		err := c.db.WithContext(ctx).Unscoped().
+			Where("key > ? AND NOT EXISTS (?)", last, revisions).
+			Order("key").Limit(1000).Find(&artifacts).Error
+		if err != nil {
+			return err
+		} else if len(artifacts) == 0 {
+			break
+		}
+		last = artifacts[len(artifacts)-1].Key
+		for i := range artifacts {
+			v := &artifacts[i]
+			// Contents that are stored in blobs are shared with the revision,
+			// so that they are kept when the artifact is replaced.
+			blob := new(models.Blob)
+			if err := c.db.WithContext(ctx).Unscoped().Take(blob, "key = ?", v.Key).Error; err != nil && err != gorm.ErrRecordNotFound {
+				return err
+			} else if err == nil && blob.Location == "" {
+				if err := c.putContents(ctx, blob, v.MimeType); err != nil {
+					return err
+				}
+			}
+			if err := c.SaveArtifactRevision(ctx, models.NewArtifactRevision(v, blob.Location)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func (c *Client) DatabaseName(ctx context.Context) string {
 	return c.db.WithContext(ctx).Name()
 }
@@ -253,7 +418,7 @@ func (c *Client) RowCount(ctx context.Context, tableName string) (int64, error)
 
 func (c *Client) Transaction(ctx context.Context, fn func(context.Context, *Client) error) error {
 	err := c.db.Transaction(func(tx *gorm.DB) error {
-		return fn(ctx, &Client{db: tx})
+		return fn(ctx, &Client{db: tx, blobs: c.blobs})
 	})
 	return grpcErrorForDBError(ctx, err)
 }
@@ -0,0 +1,71 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/apigee/registry/server/registry/internal/storage/models"
+)
+
+func TestSQLiteConnections(t *testing.T) {
+	ctx := context.Background()
+	c, err := NewClient(ctx, "sqlite3", fmt.Sprintf("%s/registry.db", t.TempDir()))
+	if err != nil {
+		t.Fatalf("NewClient() returned error: %s", err)
+	}
+	t.Cleanup(c.Close)
+	if err := c.EnsureTables(ctx); err != nil {
+		t.Fatalf("EnsureTables() returned error: %s", err)
+	}
+
+	var mode string
+	if err := c.db.Raw("PRAGMA journal_mode").Scan(&mode).Error; err != nil {
+		t.Fatalf("PRAGMA journal_mode returned error: %s", err)
+	}
+	if mode != "wal" {
+		t.Errorf("journal_mode is %q, want %q", mode, "wal")
+	}
+
+	// Hold several reader connections open at once to check that each is configured.
+	r := c.ReadOnly()
+	sqlDB, err := r.db.DB()
+	if err != nil {
+		t.Fatalf("DB() returned error: %s", err)
+	}
+	for i := 0; i < 3; i++ {
+		conn, err := sqlDB.Conn(ctx)
+		if err != nil {
+			t.Fatalf("Conn() returned error: %s", err)
+		}
+		defer conn.Close()
+		var enabled int
+		if err := conn.QueryRowContext(ctx, "PRAGMA foreign_keys").Scan(&enabled); err != nil {
+			t.Fatalf("PRAGMA foreign_keys returned error: %s", err)
+		}
+		if enabled != 1 {
+			t.Errorf("foreign_keys is %d on reader connection %d, want 1", enabled, i)
+		}
+	}
+
+	if err := r.db.Create(&models.Project{Key: "projects/p", ProjectID: "p"}).Error; err == nil {
+		t.Errorf("Create() with a read-only client succeeded, want error")
+	}
+	if err := c.db.Create(&models.Project{Key: "projects/p", ProjectID: "p"}).Error; err != nil {
+		t.Errorf("Create() returned error: %s", err)
+	}
+}
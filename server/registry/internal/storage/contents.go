@@ -0,0 +1,259 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/apigee/registry/server/registry/internal/storage/blobstore"
+	"github.com/apigee/registry/server/registry/internal/storage/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// UseBlobStore stores the contents of specs and artifacts that are saved
+// after it is called in a blob store. Contents that are already in the
+// database are left there and can still be read.
+func (c *Client) UseBlobStore(store blobstore.Store) {
+	c.blobs = store
+}
+
+// contentsKey returns the key of a blob's contents in a blob store.
+// Contents are addressed by the hash of their uncompressed bytes, so
+// compressed contents are kept separately from uncompressed ones.
+func contentsKey(v *models.Blob, mimeType string) string {
+	if strings.Contains(mimeType, "+gzip") {
+		return v.Hash + ".gz"
+	}
+	return v.Hash
+}
+
+// sharedContentsPrefix begins the locations of contents that are shared in the
+// database, which distinguishes them from the keys of contents in a blob store.
+const sharedContentsPrefix = "db:"
+
+// putContents moves the contents of a blob to the blob store if there is one,
+// or otherwise to the shared contents of the database. Blobs with the same
+// contents share a single copy.
+func (c *Client) putContents(ctx context.Context, v *models.Blob, mimeType string) error {
+	if v.Hash == "" {
+		return nil
+	}
+	key := contentsKey(v, mimeType)
+	if c.blobs == nil {
+		key = sharedContentsPrefix + key
+	}
+	if err := c.lockContents(ctx, key); err != nil {
+		return grpcErrorForDBError(ctx, err)
+	}
+	if c.blobs == nil {
+		shared := &models.SharedContents{
+			Key:        key,
+			Contents:   v.Contents,
+			CreateTime: time.Now().Round(time.Microsecond),
+		}
+		if err := c.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(shared).Error; err != nil {
+			return grpcErrorForDBError(ctx, err)
+		}
+	} else if err := c.blobs.Put(ctx, key, v.Contents); err != nil {
+		return status.Errorf(codes.Unavailable, "failed to store contents of %s: %s", v.Key, err)
+	}
+	v.Location = key
+	v.Contents = nil
+	return nil
+}
+
+// getContents reads the contents of a blob from the database or the blob store
+// if they are shared.
+func (c *Client) getContents(ctx context.Context, v *models.Blob) error {
+	if v.Location == "" {
+		return nil
+	}
+	if strings.HasPrefix(v.Location, sharedContentsPrefix) {
+		shared := new(models.SharedContents)
+		if err := c.db.WithContext(ctx).Take(shared, "key = ?", v.Location).Error; err == gorm.ErrRecordNotFound {
+			return status.Errorf(codes.DataLoss, "contents of %s are missing from the database", v.Key)
+		} else if err != nil {
+			return grpcErrorForDBError(ctx, err)
+		}
+		v.Contents = shared.Contents
+		return nil
+	}
+	if c.blobs == nil {
+		return status.Errorf(codes.FailedPrecondition, "contents of %s are in a blob store, but no blob store is configured", v.Key)
+	}
+	contents, err := c.blobs.Get(ctx, v.Location)
+	if errors.Is(err, blobstore.ErrNotFound) {
+		return status.Errorf(codes.DataLoss, "contents of %s are missing from the blob store", v.Key)
+	} else if err != nil {
+		return status.Errorf(codes.Unavailable, "failed to read contents of %s: %s", v.Key, err)
+	}
+	v.Contents = contents
+	return nil
+}
+
+// unshareContents copies shared contents in the database back to the blobs that use them.
+func (c *Client) unshareContents(ctx context.Context) error {
+	shared := c.db.Model(&models.SharedContents{}).Select("contents").
+		Where("shared_contents.key = blobs.location")
+	err := c.db.WithContext(ctx).Unscoped().Model(&models.Blob{}).
+		Where("location LIKE ?", sharedContentsPrefix+"%").
+		Updates(map[string]interface{}{"contents": shared, "location": ""}).Error
+	return grpcErrorForDBError(ctx, err)
+}
+
+// contentsTables lists the tables whose rows refer to stored contents by location.
+var contentsTables = []interface{}{&models.Blob{}, &models.ArtifactRevision{}, &models.Archive{}}
+
+// hasContents returns true if rows of a model refer to stored contents.
+func hasContents(model interface{}) bool {
+	switch model.(type) {
+	case *models.Blob, models.Blob, *models.ArtifactRevision, models.ArtifactRevision, *models.Archive, models.Archive:
+		return true
+	}
+	return false
+}
+
+// releaseContents records the contents that the rows of a table matching a query
+// refer to as possibly unused. It is called before the rows are removed or
+// changed to refer to other contents, so that PurgeContents can find contents
+// that are no longer used without scanning every location.
+func (c *Client) releaseContents(ctx context.Context, model interface{}, query func(*gorm.DB) *gorm.DB) error {
+	if !hasContents(model) {
+		return nil
+	}
+	var keys []string
+	if err := c.db.WithContext(ctx).Unscoped().Model(model).Scopes(query).
+		Where("location != ''").Distinct().Pluck("location", &keys).Error; err != nil {
+		return grpcErrorForDBError(ctx, err)
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	now := time.Now().Round(time.Microsecond)
+	unused := make([]*models.UnusedContents, len(keys))
+	for i, key := range keys {
+		unused[i] = &models.UnusedContents{Key: key, CreateTime: now}
+	}
+	err := c.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(unused, 500).Error
+	return grpcErrorForDBError(ctx, err)
+}
+
+// migrateUnusedContents creates the table of unused contents and indexes the
+// locations of contents. Contents that were shared in the database before the
+// table was created are recorded so that they are checked once.
+func (c *Client) migrateUnusedContents(ctx context.Context) error {
+	if c.db.Migrator().HasTable(&models.UnusedContents{}) {
+		return nil
+	}
+	if err := c.ensureTable(ctx, &models.UnusedContents{}); err != nil {
+		return err
+	}
+	for _, model := range contentsTables {
+		if !c.db.Migrator().HasIndex(model, "Location") {
+			if err := c.db.Migrator().CreateIndex(model, "Location"); err != nil {
+				return grpcErrorForDBError(ctx, err)
+			}
+		}
+	}
+	err := c.db.WithContext(ctx).Exec("INSERT INTO unused_contents (key, create_time) SELECT key, create_time FROM shared_contents").Error
+	return grpcErrorForDBError(ctx, err)
+}
+
+// dropUnusedContents reverts migrateUnusedContents.
+func (c *Client) dropUnusedContents(ctx context.Context) error {
+	for _, model := range contentsTables {
+		if c.db.Migrator().HasIndex(model, "Location") {
+			if err := c.db.Migrator().DropIndex(model, "Location"); err != nil {
+				return grpcErrorForDBError(ctx, err)
+			}
+		}
+	}
+	return c.db.WithContext(ctx).Migrator().DropTable(&models.UnusedContents{})
+}
+
+// PurgeContents removes contents from the database and the blob store that were
+// recorded as unused when rows that referred to them were removed, unless
+// other blobs, artifact revisions or archives still refer to them, including
+// deleted blobs that can still be restored. It returns the number of contents
+// removed. Contents that the registry didn't record, such as other objects
+// under the prefix of a blob store, are never removed.
+func (c *Client) PurgeContents(ctx context.Context) (int, error) {
+	// Unused contents are checked in batches, and each one is removed from the table once it is checked.
+	const batch = 1000
+	n := 0
+	for {
+		op := c.db.WithContext(ctx).Model(&models.UnusedContents{})
+		if c.blobs == nil {
+			// Contents in a blob store are kept until a blob store is configured.
+			op = op.Where("key LIKE ?", sharedContentsPrefix+"%")
+		}
+		var keys []string
+		if err := op.Order("create_time").Limit(batch).Pluck("key", &keys).Error; err != nil {
+			return n, grpcErrorForDBError(ctx, err)
+		}
+		for _, key := range keys {
+			removed, err := c.purgeUnusedContents(ctx, key)
+			if err != nil {
+				return n, err
+			}
+			if removed {
+				n++
+			}
+		}
+		if len(keys) < batch {
+			return n, nil
+		}
+	}
+}
+
+// purgeUnusedContents removes contents recorded as unused if nothing refers to them.
+func (c *Client) purgeUnusedContents(ctx context.Context, key string) (removed bool, err error) {
+	shared := strings.HasPrefix(key, sharedContentsPrefix)
+	// Contents may be used again until they are locked.
+	err = c.Transaction(ctx, func(ctx context.Context, tx *Client) error {
+		if err := tx.lockContents(ctx, key); err != nil {
+			return err
+		}
+		used := false
+		for _, model := range contentsTables {
+			var count int64
+			if err := tx.db.WithContext(ctx).Unscoped().Model(model).
+				Where("location = ?", key).Count(&count).Error; err != nil {
+				return grpcErrorForDBError(ctx, err)
+			}
+			used = used || count > 0
+		}
+		if !used {
+			if shared {
+				if err := tx.db.WithContext(ctx).Delete(&models.SharedContents{}, "key = ?", key).Error; err != nil {
+					return grpcErrorForDBError(ctx, err)
+				}
+			} else if err := tx.blobs.Delete(ctx, key); err != nil {
+				return status.Errorf(codes.Unavailable, "failed to remove contents %s: %s", key, err)
+			}
+			removed = true
+		}
+		// Contents that are still used are recorded again when their last user is removed.
+		err := tx.db.WithContext(ctx).Delete(&models.UnusedContents{}, "key = ?", key).Error
+		return grpcErrorForDBError(ctx, err)
+	})
+	return removed, err
+}
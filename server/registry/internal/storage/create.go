@@ -19,39 +19,196 @@ import (
 
 	"github.com/apigee/registry/server/registry/internal/storage/models"
 	"github.com/pkg/errors"
+	"gorm.io/gorm"
 )
 
+// Deleted resources keep their rows until they are purged, so the rows of a
+// deleted resource and of its children are purged before a resource with the
+// same name is created. Deleted resources can't be undeleted after that.
+
 func (c *Client) CreateProject(ctx context.Context, v *models.Project) error {
 	v.Key = v.Name()
+	query := func(db *gorm.DB) *gorm.DB {
+		return db.Where("project_id = ?", v.ProjectID)
+	}
+	if _, err := c.purge(ctx, query,
+		&models.Blob{},
+		&models.ResourceReference{},
+		&models.SearchDocument{},
+		&models.SpecElement{},
+		&models.ArtifactRevisionTag{},
+		&models.ArtifactRevision{},
+		&models.Artifact{},
+		&models.SpecRevisionTag{},
+		&models.DeploymentRevisionTag{},
+		&models.Spec{},
+		&models.Deployment{},
+		&models.Version{},
+		&models.Api{},
+		&models.Project{},
+	); err != nil {
+		return err
+	}
 	return c.create(ctx, v)
 }
 
 func (c *Client) CreateApi(ctx context.Context, v *models.Api) error {
 	v.Key = v.Name()
-	return c.create(ctx, v)
+	query := func(db *gorm.DB) *gorm.DB {
+		return db.Where("project_id = ?", v.ProjectID).
+			Where("api_id = ?", v.ApiID)
+	}
+	if _, err := c.purge(ctx, query,
+		&models.Blob{},
+		&models.ResourceReference{},
+		&models.SearchDocument{},
+		&models.SpecElement{},
+		&models.ArtifactRevisionTag{},
+		&models.ArtifactRevision{},
+		&models.Artifact{},
+		&models.SpecRevisionTag{},
+		&models.DeploymentRevisionTag{},
+		&models.Spec{},
+		&models.Deployment{},
+		&models.Version{},
+		&models.Api{},
+	); err != nil {
+		return err
+	}
+	if err := c.create(ctx, v); err != nil {
+		return err
+	}
+	if err := c.saveSearchDocument(ctx, models.NewApiSearchDocument(v)); err != nil {
+		return err
+	}
+	return c.SaveReferences(ctx, v.Name(), models.NewApiReferences(v))
 }
 
 func (c *Client) CreateVersion(ctx context.Context, v *models.Version) error {
 	v.Key = v.Name()
-	return c.create(ctx, v)
+	query := func(db *gorm.DB) *gorm.DB {
+		return db.Where("project_id = ?", v.ProjectID).
+			Where("api_id = ?", v.ApiID).
+			Where("version_id = ?", v.VersionID)
+	}
+	if _, err := c.purge(ctx, query,
+		&models.Blob{},
+		&models.ResourceReference{},
+		&models.SearchDocument{},
+		&models.SpecElement{},
+		&models.ArtifactRevisionTag{},
+		&models.ArtifactRevision{},
+		&models.Artifact{},
+		&models.SpecRevisionTag{},
+		&models.Spec{},
+		&models.Version{},
+	); err != nil {
+		return err
+	}
+	if err := c.create(ctx, v); err != nil {
+		return err
+	}
+	if err := c.saveSearchDocument(ctx, models.NewVersionSearchDocument(v)); err != nil {
+		return err
+	}
+	return c.SaveReferences(ctx, v.Name(), models.NewVersionReferences(v))
 }
 
 func (c *Client) CreateArtifact(ctx context.Context, v *models.Artifact) error {
 	v.Key = v.Name()
+	if err := c.purgeArtifact(ctx, v); err != nil {
+		return err
+	}
 	return c.create(ctx, v)
 }
 
+// CreateDeploymentRevision creates the first revision of a deployment.
 func (c *Client) CreateDeploymentRevision(ctx context.Context, v *models.Deployment) error {
 	v.Key = v.RevisionName()
-	return c.create(ctx, v)
+	query := func(db *gorm.DB) *gorm.DB {
+		return db.Where("project_id = ?", v.ProjectID).
+			Where("api_id = ?", v.ApiID).
+			Where("deployment_id = ?", v.DeploymentID)
+	}
+	if _, err := c.purge(ctx, query,
+		&models.Blob{},
+		&models.ResourceReference{},
+		&models.SearchDocument{},
+		&models.ArtifactRevisionTag{},
+		&models.ArtifactRevision{},
+		&models.Artifact{},
+		&models.DeploymentRevisionTag{},
+		&models.Deployment{},
+	); err != nil {
+		return err
+	}
+	if err := c.create(ctx, v); err != nil {
+		return err
+	}
+	return c.indexDeployment(ctx, v)
 }
 
+// CreateSpecRevision creates the first revision of a spec.
 func (c *Client) CreateSpecRevision(ctx context.Context, v *models.Spec) error {
 	v.Key = v.RevisionName()
-	return c.create(ctx, v)
+	query := func(db *gorm.DB) *gorm.DB {
+		return db.Where("project_id = ?", v.ProjectID).
+			Where("api_id = ?", v.ApiID).
+			Where("version_id = ?", v.VersionID).
+			Where("spec_id = ?", v.SpecID)
+	}
+	if _, err := c.purge(ctx, query,
+		&models.Blob{},
+		&models.ResourceReference{},
+		&models.SearchDocument{},
+		&models.SpecElement{},
+		&models.ArtifactRevisionTag{},
+		&models.ArtifactRevision{},
+		&models.Artifact{},
+		&models.SpecRevisionTag{},
+		&models.Spec{},
+	); err != nil {
+		return err
+	}
+	if err := c.create(ctx, v); err != nil {
+		return err
+	}
+	return c.saveSpecSearchDocument(ctx, v, nil)
 }
 
 func (c *Client) create(ctx context.Context, v interface{}) error {
 	return grpcErrorForDBError(ctx,
 		errors.Wrapf(c.db.WithContext(ctx).Create(v).Error, "create %#v", v))
 }
+
+// purgeArtifact removes the rows of a deleted artifact with the name of an artifact.
+func (c *Client) purgeArtifact(ctx context.Context, v *models.Artifact) error {
+	// Only artifacts of specs list spec elements.
+	if v.SpecID != "" {
+		if _, err := c.purge(ctx, func(db *gorm.DB) *gorm.DB {
+			return db.Where("project_id = ?", v.ProjectID).
+				Where("api_id = ?", v.ApiID).
+				Where("version_id = ?", v.VersionID).
+				Where("spec_id = ?", v.SpecID).
+				Where("artifact_id = ?", v.ArtifactID)
+		}, &models.SpecElement{}); err != nil {
+			return err
+		}
+	}
+	query := func(db *gorm.DB) *gorm.DB {
+		return db.Where("project_id = ?", v.ProjectID).
+			Where("api_id = ?", v.ApiID).
+			Where("version_id = ?", v.VersionID).
+			Where("spec_id = ?", v.SpecID).
+			Where("deployment_id = ?", v.DeploymentID).
+			Where("artifact_id = ?", v.ArtifactID)
+	}
+	_, err := c.purge(ctx, query,
+		&models.Blob{},
+		&models.ResourceReference{},
+		&models.ArtifactRevisionTag{},
+		&models.ArtifactRevision{},
+		&models.Artifact{},
+	)
+	return err
+}
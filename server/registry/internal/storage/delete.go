@@ -17,6 +17,7 @@ package storage
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/apigee/registry/server/registry/internal/storage/models"
 	"github.com/apigee/registry/server/registry/names"
@@ -44,22 +45,40 @@ func reason(counts []int64, tables []interface{}) string {
 	return r
 }
 
+// deletionTime returns the time recorded on the rows deleted by a request.
+// All rows deleted together share a deletion time so that they can be undeleted together.
+func deletionTime() time.Time {
+	return time.Now().UTC().Round(time.Microsecond)
+}
+
+// markDeleted sets the deletion time of the live rows of a table that match a query
+// and returns the number of rows that were marked.
+func (c *Client) markDeleted(ctx context.Context, model interface{}, t time.Time, query func(*gorm.DB) *gorm.DB) (int64, error) {
+	op := c.db.WithContext(ctx).Model(model).Scopes(query).Update("deleted_at", t)
+	return op.RowsAffected, op.Error
+}
+
 func (c *Client) DeleteProject(ctx context.Context, name names.Project, cascade bool) error {
+	query := func(db *gorm.DB) *gorm.DB {
+		return db.Where("project_id = ?", name.ProjectID)
+	}
+	t := deletionTime()
+
 	tables := []interface{}{
-		models.Project{},
-		models.Blob{},
-		models.Artifact{},
+		&models.Project{},
+		&models.Blob{},
+		&models.Artifact{},
 	}
 	counts := make([]int64, len(tables))
 	for i, model := range tables {
-		op := c.db.WithContext(ctx).Where("project_id = ?", name.ProjectID)
-		if err := op.Delete(model).Error; err != nil {
+		n, err := c.markDeleted(ctx, model, t, query)
+		if err != nil {
 			return err
 		}
-		if _, ok := model.(models.Project); ok && op.RowsAffected == 0 {
+		if _, ok := model.(*models.Project); ok && n == 0 {
 			return status.Errorf(codes.NotFound, "%q not found in database", name)
 		}
-		counts[i] = op.RowsAffected
+		counts[i] = n
 	}
 
 	if sum(counts) > 1 && !cascade {
@@ -67,12 +86,21 @@ func (c *Client) DeleteProject(ctx context.Context, name names.Project, cascade
 	}
 
 	// Tags aren't API resources, so they do not block non-cascading deletes.
+	// Other resources of the project are owned by it and are deleted with it.
 	for _, model := range []interface{}{
-		models.DeploymentRevisionTag{},
-		models.SpecRevisionTag{},
+		&models.Api{},
+		&models.Version{},
+		&models.Spec{},
+		&models.Deployment{},
+		&models.DeploymentRevisionTag{},
+		&models.SpecRevisionTag{},
+		&models.ArtifactRevision{},
+		&models.ArtifactRevisionTag{},
+		&models.ResourceReference{},
+		&models.SearchDocument{},
+		&models.SpecElement{},
 	} {
-		op := c.db.WithContext(ctx).Where("project_id = ?", name.ProjectID)
-		if err := op.Delete(model).Error; err != nil {
+		if _, err := c.markDeleted(ctx, model, t, query); err != nil {
 			return err
 		}
 	}
@@ -81,23 +109,27 @@ func (c *Client) DeleteProject(ctx context.Context, name names.Project, cascade
 }
 
 func (c *Client) DeleteApi(ctx context.Context, name names.Api, cascade bool) error {
-	tables := []interface{}{
-		models.Api{},
-		models.Blob{},
-		models.Artifact{},
+	query := func(db *gorm.DB) *gorm.DB {
+		return db.Where("project_id = ?", name.ProjectID).
+			Where("api_id = ?", name.ApiID)
 	}
+	t := deletionTime()
 
+	tables := []interface{}{
+		&models.Api{},
+		&models.Blob{},
+		&models.Artifact{},
+	}
 	counts := make([]int64, len(tables))
 	for i, model := range tables {
-		op := c.db.WithContext(ctx).Where("project_id = ?", name.ProjectID).
-			Where("api_id = ?", name.ApiID)
-		if err := op.Delete(model).Error; err != nil {
+		n, err := c.markDeleted(ctx, model, t, query)
+		if err != nil {
 			return err
 		}
-		if _, ok := model.(models.Api); ok && op.RowsAffected == 0 {
+		if _, ok := model.(*models.Api); ok && n == 0 {
 			return status.Errorf(codes.NotFound, "%q not found in database", name)
 		}
-		counts[i] = op.RowsAffected
+		counts[i] = n
 	}
 
 	if sum(counts) > 1 && !cascade {
@@ -105,12 +137,18 @@ func (c *Client) DeleteApi(ctx context.Context, name names.Api, cascade bool) er
 	}
 
 	for _, model := range []interface{}{
-		models.DeploymentRevisionTag{},
-		models.SpecRevisionTag{},
+		&models.Version{},
+		&models.Spec{},
+		&models.Deployment{},
+		&models.DeploymentRevisionTag{},
+		&models.SpecRevisionTag{},
+		&models.ArtifactRevision{},
+		&models.ArtifactRevisionTag{},
+		&models.ResourceReference{},
+		&models.SearchDocument{},
+		&models.SpecElement{},
 	} {
-		op := c.db.WithContext(ctx).Where("project_id = ?", name.ProjectID).
-			Where("api_id = ?", name.ApiID)
-		if err := op.Delete(model).Error; err != nil {
+		if _, err := c.markDeleted(ctx, model, t, query); err != nil {
 			return err
 		}
 	}
@@ -119,23 +157,28 @@ func (c *Client) DeleteApi(ctx context.Context, name names.Api, cascade bool) er
 }
 
 func (c *Client) DeleteVersion(ctx context.Context, name names.Version, cascade bool) error {
+	query := func(db *gorm.DB) *gorm.DB {
+		return db.Where("project_id = ?", name.ProjectID).
+			Where("api_id = ?", name.ApiID).
+			Where("version_id = ?", name.VersionID)
+	}
+	t := deletionTime()
+
 	tables := []interface{}{
-		models.Version{},
-		models.Blob{},
-		models.Artifact{},
+		&models.Version{},
+		&models.Blob{},
+		&models.Artifact{},
 	}
 	counts := make([]int64, len(tables))
 	for i, model := range tables {
-		op := c.db.WithContext(ctx).Where("project_id = ?", name.ProjectID).
-			Where("api_id = ?", name.ApiID).
-			Where("version_id = ?", name.VersionID)
-		if err := op.Delete(model).Error; err != nil {
+		n, err := c.markDeleted(ctx, model, t, query)
+		if err != nil {
 			return err
 		}
-		if _, ok := model.(models.Version); ok && op.RowsAffected == 0 {
+		if _, ok := model.(*models.Version); ok && n == 0 {
 			return status.Errorf(codes.NotFound, "%q not found in database", name)
 		}
-		counts[i] = op.RowsAffected
+		counts[i] = n
 	}
 
 	if sum(counts) > 1 && !cascade {
@@ -143,12 +186,15 @@ func (c *Client) DeleteVersion(ctx context.Context, name names.Version, cascade
 	}
 
 	for _, model := range []interface{}{
-		models.SpecRevisionTag{},
+		&models.Spec{},
+		&models.SpecRevisionTag{},
+		&models.ArtifactRevision{},
+		&models.ArtifactRevisionTag{},
+		&models.ResourceReference{},
+		&models.SearchDocument{},
+		&models.SpecElement{},
 	} {
-		op := c.db.WithContext(ctx).Where("project_id = ?", name.ProjectID).
-			Where("api_id = ?", name.ApiID).
-			Where("version_id = ?", name.VersionID)
-		if err := op.Delete(model).Error; err != nil {
+		if _, err := c.markDeleted(ctx, model, t, query); err != nil {
 			return err
 		}
 	}
@@ -157,37 +203,44 @@ func (c *Client) DeleteVersion(ctx context.Context, name names.Version, cascade
 }
 
 func (c *Client) DeleteSpec(ctx context.Context, name names.Spec, cascade bool) error {
-	for _, model := range []interface{}{
-		models.Spec{},
-		models.SpecRevisionTag{},
-		models.Blob{},
-	} {
-		op := c.db.WithContext(ctx).Where("project_id = ?", name.ProjectID).
+	query := func(db *gorm.DB) *gorm.DB {
+		return db.Where("project_id = ?", name.ProjectID).
 			Where("api_id = ?", name.ApiID).
 			Where("version_id = ?", name.VersionID).
 			Where("spec_id = ?", name.SpecID)
-		if err := op.Delete(model).Error; err != nil {
+	}
+	t := deletionTime()
+
+	for _, model := range []interface{}{
+		&models.Spec{},
+		&models.SpecRevisionTag{},
+		&models.Blob{},
+		&models.ArtifactRevision{},
+		&models.ArtifactRevisionTag{},
+		&models.ResourceReference{},
+		&models.SearchDocument{},
+		&models.SpecElement{},
+	} {
+		n, err := c.markDeleted(ctx, model, t, query)
+		if err != nil {
 			return err
 		}
-		if _, ok := model.(models.Spec); ok && op.RowsAffected == 0 {
+		if _, ok := model.(*models.Spec); ok && n == 0 {
 			return status.Errorf(codes.NotFound, "%q not found in database", name)
 		}
 	}
 
 	tables := []interface{}{
-		models.Artifact{},
-		models.Blob{},
+		&models.Artifact{},
+		&models.Blob{},
 	}
 	counts := make([]int64, len(tables))
 	for i, model := range tables {
-		op := c.db.WithContext(ctx).Where("project_id = ?", name.ProjectID).
-			Where("api_id = ?", name.ApiID).
-			Where("version_id = ?", name.VersionID).
-			Where("spec_id = ?", name.SpecID)
-		if err := op.Delete(model).Error; err != nil {
+		n, err := c.markDeleted(ctx, model, t, query)
+		if err != nil {
 			return err
 		}
-		counts[i] = op.RowsAffected
+		counts[i] = n
 	}
 
 	if sum(counts) > 0 && !cascade {
@@ -197,26 +250,37 @@ func (c *Client) DeleteSpec(ctx context.Context, name names.Spec, cascade bool)
 	return nil
 }
 
+// DeleteSpecRevision deletes a revision of a live spec with the artifacts of the revision.
+// The revision can be undeleted while the spec is live.
 func (c *Client) DeleteSpecRevision(ctx context.Context, name names.SpecRevision) error {
 	name, err := c.unwrapSpecRevisionTag(ctx, name)
 	if err != nil {
 		return err
 	}
-
-	for _, model := range []interface{}{
-		models.Spec{},
-		models.SpecRevisionTag{},
-	} {
-		op := c.db.WithContext(ctx).
-			Where("project_id = ?", name.ProjectID).
+	query := func(db *gorm.DB) *gorm.DB {
+		return db.Where("project_id = ?", name.ProjectID).
 			Where("api_id = ?", name.ApiID).
 			Where("version_id = ?", name.VersionID).
 			Where("spec_id = ?", name.SpecID).
 			Where("revision_id = ?", name.RevisionID)
-		if err := op.Delete(model).Error; err != nil {
-			return grpcErrorForDBError(ctx, errors.Wrapf(err, "delete %s", name))
+	}
+	t := deletionTime()
+
+	for _, model := range []interface{}{
+		&models.Spec{},
+		&models.SpecRevisionTag{},
+		&models.Blob{},
+		&models.Artifact{},
+		&models.ArtifactRevision{},
+		&models.ArtifactRevisionTag{},
+		&models.ResourceReference{},
+		&models.SpecElement{},
+	} {
+		n, err := c.markDeleted(ctx, model, t, query)
+		if err != nil {
+			return err
 		}
-		if _, ok := model.(models.Spec); ok && op.RowsAffected == 0 {
+		if _, ok := model.(*models.Spec); ok && n == 0 {
 			return status.Errorf(codes.NotFound, "%q not found in database", name)
 		}
 	}
@@ -235,39 +299,46 @@ func (c *Client) DeleteSpecRevision(ctx context.Context, name names.SpecRevision
 		return grpcErrorForDBError(ctx, errors.Wrapf(err, "delete %s", name))
 	}
 
-	return nil
+	// the remaining latest revision is now the current one
+	return c.indexSpecRevision(ctx, v)
 }
 
 func (c *Client) DeleteDeployment(ctx context.Context, name names.Deployment, cascade bool) error {
-	for _, model := range []interface{}{
-		models.Deployment{},
-		models.DeploymentRevisionTag{},
-	} {
-		op := c.db.WithContext(ctx).Where("project_id = ?", name.ProjectID).
+	query := func(db *gorm.DB) *gorm.DB {
+		return db.Where("project_id = ?", name.ProjectID).
 			Where("api_id = ?", name.ApiID).
 			Where("deployment_id = ?", name.DeploymentID)
-		if err := op.Delete(model).Error; err != nil {
+	}
+	t := deletionTime()
+
+	for _, model := range []interface{}{
+		&models.Deployment{},
+		&models.DeploymentRevisionTag{},
+		&models.ArtifactRevision{},
+		&models.ArtifactRevisionTag{},
+		&models.ResourceReference{},
+		&models.SearchDocument{},
+	} {
+		n, err := c.markDeleted(ctx, model, t, query)
+		if err != nil {
 			return err
 		}
-		if _, ok := model.(models.Deployment); ok && op.RowsAffected == 0 {
+		if _, ok := model.(*models.Deployment); ok && n == 0 {
 			return status.Errorf(codes.NotFound, "%q not found in database", name)
 		}
 	}
 
 	tables := []interface{}{
-		models.Artifact{},
-		models.Blob{},
+		&models.Artifact{},
+		&models.Blob{},
 	}
 	counts := make([]int64, len(tables))
 	for i, model := range tables {
-		op := c.db.WithContext(ctx).Where("project_id = ?", name.ProjectID).
-			Where("api_id = ?", name.ApiID).
-			Where("deployment_id = ?", name.DeploymentID)
-		if err := op.Delete(model).Error; err != nil {
+		n, err := c.markDeleted(ctx, model, t, query)
+		if err != nil {
 			return err
 		}
-
-		counts[i] += op.RowsAffected
+		counts[i] += n
 	}
 
 	if sum(counts) > 0 && !cascade {
@@ -277,25 +348,35 @@ func (c *Client) DeleteDeployment(ctx context.Context, name names.Deployment, ca
 	return nil
 }
 
+// DeleteDeploymentRevision deletes a revision of a live deployment with the artifacts of the revision.
+// The revision can be undeleted while the deployment is live.
 func (c *Client) DeleteDeploymentRevision(ctx context.Context, name names.DeploymentRevision) error {
 	name, err := c.unwrapDeploymentRevisionTag(ctx, name)
 	if err != nil {
 		return err
 	}
-
-	for _, model := range []interface{}{
-		models.Deployment{},
-		models.DeploymentRevisionTag{},
-	} {
-		op := c.db.WithContext(ctx).
-			Where("project_id = ?", name.ProjectID).
+	query := func(db *gorm.DB) *gorm.DB {
+		return db.Where("project_id = ?", name.ProjectID).
 			Where("api_id = ?", name.ApiID).
 			Where("deployment_id = ?", name.DeploymentID).
 			Where("revision_id = ?", name.RevisionID)
-		if err := op.Delete(model).Error; err != nil {
-			return grpcErrorForDBError(ctx, errors.Wrapf(err, "delete %s", name))
+	}
+	t := deletionTime()
+
+	for _, model := range []interface{}{
+		&models.Deployment{},
+		&models.DeploymentRevisionTag{},
+		&models.Blob{},
+		&models.Artifact{},
+		&models.ArtifactRevision{},
+		&models.ArtifactRevisionTag{},
+		&models.ResourceReference{},
+	} {
+		n, err := c.markDeleted(ctx, model, t, query)
+		if err != nil {
+			return err
 		}
-		if _, ok := model.(models.Deployment); ok && op.RowsAffected == 0 {
+		if _, ok := model.(*models.Deployment); ok && n == 0 {
 			return status.Errorf(codes.NotFound, "%q not found in database", name)
 		}
 	}
@@ -313,28 +394,158 @@ func (c *Client) DeleteDeploymentRevision(ctx context.Context, name names.Deploy
 		return grpcErrorForDBError(ctx, errors.Wrapf(err, "delete %s", name))
 	}
 
-	return nil
+	// the remaining latest revision is now the current one
+	return c.indexDeployment(ctx, v)
 }
 
+// DeleteArtifact deletes an artifact of a live resource.
+// The artifact can be undeleted while its parent is live.
 func (c *Client) DeleteArtifact(ctx context.Context, name names.Artifact) error {
-	for _, model := range []interface{}{
-		models.Artifact{},
-		models.Blob{},
-	} {
-		op := c.db.WithContext(ctx).
-			Where("project_id = ?", name.ProjectID()).
+	query := func(db *gorm.DB) *gorm.DB {
+		return db.Where("project_id = ?", name.ProjectID()).
 			Where("api_id = ?", name.ApiID()).
 			Where("version_id = ?", name.VersionID()).
 			Where("spec_id = ?", name.SpecID()).
 			Where("deployment_id = ?", name.DeploymentID()).
 			Where("artifact_id = ?", name.ArtifactID())
-		if err := op.Delete(model).Error; err != nil {
-			return grpcErrorForDBError(ctx, errors.Wrapf(err, "delete %s", name))
+	}
+	t := deletionTime()
+
+	for _, model := range []interface{}{
+		&models.Artifact{},
+		&models.Blob{},
+		&models.ArtifactRevision{},
+		&models.ArtifactRevisionTag{},
+		&models.ResourceReference{},
+	} {
+		n, err := c.markDeleted(ctx, model, t, query)
+		if err != nil {
+			return err
 		}
-		if _, ok := model.(models.Artifact); ok && op.RowsAffected == 0 {
+		if _, ok := model.(*models.Artifact); ok && n == 0 {
 			return status.Errorf(codes.NotFound, "%q not found in database", name)
 		}
 	}
 
+	// Only artifacts of specs list spec elements.
+	if name.SpecID() != "" {
+		if _, err := c.markDeleted(ctx, &models.SpecElement{}, t, specElementsOf(name)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// specElementsOf returns a query for the spec elements listed by an artifact of a spec.
+func specElementsOf(name names.Artifact) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("project_id = ?", name.ProjectID()).
+			Where("api_id = ?", name.ApiID()).
+			Where("version_id = ?", name.VersionID()).
+			Where("spec_id = ?", name.SpecID()).
+			Where("artifact_id = ?", name.ArtifactID())
+	}
+}
+
+// PurgeDeleted permanently removes the rows of resources that were deleted at
+// or before a specified time and returns the number of rows removed.
+func (c *Client) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	query := func(db *gorm.DB) *gorm.DB {
+		return db.Where("deleted_at <= ?", before.UTC())
+	}
+	// Children are removed before their parents to satisfy foreign key constraints.
+	return c.purge(ctx, query,
+		&models.Blob{},
+		&models.ResourceReference{},
+		&models.SearchDocument{},
+		&models.SpecElement{},
+		&models.ArtifactRevisionTag{},
+		&models.ArtifactRevision{},
+		&models.Artifact{},
+		&models.SpecRevisionTag{},
+		&models.DeploymentRevisionTag{},
+		&models.Spec{},
+		&models.Deployment{},
+		&models.Version{},
+		&models.Api{},
+		&models.Project{},
+	)
+}
+
+// purge permanently removes the deleted rows of each table that match a query
+// and returns the number of rows removed. Tables are purged in the order listed.
+// The contents that purged rows refer to are recorded as possibly unused.
+func (c *Client) purge(ctx context.Context, query func(*gorm.DB) *gorm.DB, tables ...interface{}) (int64, error) {
+	deleted := func(db *gorm.DB) *gorm.DB {
+		return query(db).Where("deleted_at IS NOT NULL")
+	}
+	var n int64
+	for _, model := range tables {
+		if err := c.releaseContents(ctx, model, deleted); err != nil {
+			return n, err
+		}
+		op := c.db.WithContext(ctx).Unscoped().Scopes(deleted).Delete(model)
+		if err := op.Error; err != nil {
+			return n, grpcErrorForDBError(ctx, errors.Wrapf(err, "purge %T", model))
+		}
+		n += op.RowsAffected
+	}
+	return n, nil
+}
+
+// deletionTables lists the tables that existed before resources were soft
+// deleted, children before their parents. Tables created since then have
+// deletion times when they are created.
+var deletionTables = []interface{}{
+	&models.Blob{},
+	&models.Artifact{},
+	&models.SpecRevisionTag{},
+	&models.DeploymentRevisionTag{},
+	&models.Spec{},
+	&models.Deployment{},
+	&models.Version{},
+	&models.Api{},
+	&models.Project{},
+}
+
+// migrateDeletionTimes adds the indexed columns that record when rows were deleted.
+func (c *Client) migrateDeletionTimes(ctx context.Context) error {
+	m := c.db.WithContext(ctx).Migrator()
+	for _, model := range deletionTables {
+		if !m.HasColumn(model, "DeletedAt") {
+			if err := m.AddColumn(model, "DeletedAt"); err != nil {
+				return err
+			}
+		}
+		if !m.HasIndex(model, "DeletedAt") {
+			if err := m.CreateIndex(model, "DeletedAt"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// dropDeletionTimes removes deleted rows, which earlier schema versions can't
+// distinguish from live ones, and the columns that recorded their deletion.
+func (c *Client) dropDeletionTimes(ctx context.Context) error {
+	for _, model := range deletionTables {
+		if err := c.db.WithContext(ctx).Unscoped().Where("deleted_at IS NOT NULL").Delete(model).Error; err != nil {
+			return err
+		}
+	}
+	m := c.db.WithContext(ctx).Migrator()
+	for _, model := range deletionTables {
+		// SQLite loses the indexes of tables whose other columns were dropped.
+		if m.HasIndex(model, "DeletedAt") {
+			if err := m.DropIndex(model, "DeletedAt"); err != nil {
+				return err
+			}
+		}
+		if err := m.DropColumn(model, "DeletedAt"); err != nil {
+			return err
+		}
+	}
 	return nil
 }
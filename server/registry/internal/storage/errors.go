@@ -78,6 +78,8 @@ func grpcErrorForDBError(ctx context.Context, err error) error {
 			return status.Error(codes.Canceled, cause.Error())
 		} else if v.Code.Name() == "foreign_key_violation" {
 			return status.Error(codes.NotFound, cause.Error())
+		} else if v.Code.Name() == "deadlock_detected" {
+			return status.Error(codes.Aborted, cause.Error())
 		}
 		log.Infof(ctx, "Unhandled %T %+v code=%s name=%s", v, v, v.Code, v.Code.Name())
 	case *net.OpError:
@@ -31,10 +31,13 @@ const (
 	Int       FieldType = iota
 	Timestamp FieldType = iota
 	StringMap FieldType = iota
+	Bool      FieldType = iota
 )
 
 type Filter struct {
 	program cel.Program
+	expr    *exprpb.Expr
+	fields  map[string]FieldType
 }
 
 func (f *Filter) Matches(model map[string]interface{}) (bool, error) {
@@ -71,6 +74,8 @@ func NewFilter(filter string, fields map[string]FieldType) (Filter, error) {
 			declarations = append(declarations, decls.NewConst(name, decls.Timestamp, nil))
 		case StringMap:
 			declarations = append(declarations, decls.NewConst(name, decls.NewMapType(decls.String, decls.String), nil))
+		case Bool:
+			declarations = append(declarations, decls.NewConst(name, decls.Bool, nil))
 		default:
 			return Filter{}, status.Errorf(codes.InvalidArgument, "unknown filter argument type")
 		}
@@ -91,5 +96,5 @@ func NewFilter(filter string, fields map[string]FieldType) (Filter, error) {
 		return Filter{}, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	return Filter{program: prg}, nil
+	return Filter{program: prg, expr: ast.Expr(), fields: fields}, nil
 }
@@ -0,0 +1,440 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filtering
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/cel-go/common/operators"
+	"github.com/google/cel-go/common/overloads"
+
+	exprpb "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+)
+
+// SQL dialects that filters can be translated to, named like gorm dialectors.
+const (
+	SQLite   = "sqlite"
+	Postgres = "postgres"
+)
+
+// SQL translates the filter into a condition for a SQL WHERE clause.
+// Columns maps field names to SQL expressions that compute their values,
+// which must not be NULL. StringMap fields are stored as JSON objects.
+//
+// Parts of the filter that can't be translated are left to Matches: unless
+// exact is true, the condition selects a superset of the matching rows, which
+// must still be checked. An empty condition selects all rows.
+func (f *Filter) SQL(dialect string, columns map[string]string) (cond string, args []interface{}, exact bool) {
+	if f.expr == nil {
+		return "", nil, true
+	}
+	if dialect != SQLite && dialect != Postgres {
+		return "", nil, false
+	}
+	t := &translator{dialect: dialect, columns: columns, fields: f.fields, exact: true}
+	c := t.condition(f.expr, true)
+	if c.sql == sqlTrue.sql {
+		return "", nil, t.exact
+	}
+	return c.sql, c.args, t.exact
+}
+
+// ValueSQL translates a field, or the value of a key of a StringMap field
+// if key isn't empty, into a SQL expression. Values of missing keys are NULL.
+// ok is false if the value can't be translated.
+func ValueSQL(dialect string, fields map[string]FieldType, columns map[string]string, field, key string) (sql string, args []interface{}, ok bool) {
+	t := &translator{dialect: dialect, columns: columns, fields: fields, exact: true}
+	e := &exprpb.Expr{ExprKind: &exprpb.Expr_IdentExpr{IdentExpr: &exprpb.Expr_Ident{Name: field}}}
+	var v operand
+	if key != "" {
+		v, ok = t.mapValue(e, key)
+	} else {
+		v, ok = t.value(e)
+	}
+	return v.sql, v.args, ok
+}
+
+// sqlExpr is a fragment of SQL and the values of its parameters.
+type sqlExpr struct {
+	sql  string
+	args []interface{}
+}
+
+var (
+	sqlTrue  = sqlExpr{sql: "TRUE"}
+	sqlFalse = sqlExpr{sql: "FALSE"}
+)
+
+// sqlf formats fragments into a fragment. Each verb of the format must
+// be %s, and the fragments must be given in the order that they appear.
+func sqlf(format string, exprs ...sqlExpr) sqlExpr {
+	sqls := make([]interface{}, len(exprs))
+	var args []interface{}
+	for i, e := range exprs {
+		sqls[i] = e.sql
+		args = append(args, e.args...)
+	}
+	return sqlExpr{sql: fmt.Sprintf(format, sqls...), args: args}
+}
+
+func and(a, b sqlExpr) sqlExpr {
+	switch {
+	case a.sql == sqlFalse.sql || b.sql == sqlFalse.sql:
+		return sqlFalse
+	case a.sql == sqlTrue.sql:
+		return b
+	case b.sql == sqlTrue.sql:
+		return a
+	}
+	return sqlf("(%s AND %s)", a, b)
+}
+
+func or(a, b sqlExpr) sqlExpr {
+	switch {
+	case a.sql == sqlTrue.sql || b.sql == sqlTrue.sql:
+		return sqlTrue
+	case a.sql == sqlFalse.sql:
+		return b
+	case b.sql == sqlFalse.sql:
+		return a
+	}
+	return sqlf("(%s OR %s)", a, b)
+}
+
+func not(a sqlExpr) sqlExpr {
+	switch a.sql {
+	case sqlTrue.sql:
+		return sqlFalse
+	case sqlFalse.sql:
+		return sqlTrue
+	}
+	return sqlf("NOT (%s)", a)
+}
+
+// operand is a value computed by SQL.
+type operand struct {
+	sqlExpr
+	kind FieldType
+	// nullable is true for map values, which are NULL if their key is missing.
+	nullable bool
+	// rounded is true if the database stores the value with less precision than the filter.
+	rounded bool
+}
+
+// translator translates the expressions of a filter into SQL.
+type translator struct {
+	dialect string
+	columns map[string]string
+	fields  map[string]FieldType
+	// exact is cleared when a condition doesn't select exactly the rows that match.
+	exact bool
+}
+
+// unsupported returns the condition of an expression that can't be translated.
+func (t *translator) unsupported(widen bool) sqlExpr {
+	t.exact = false
+	if widen {
+		return sqlTrue
+	}
+	return sqlFalse
+}
+
+// condition translates a boolean expression. If widen is true, the condition is true
+// for every row where the expression may be true. Otherwise, it is true only for rows
+// where the expression is certainly true. Conditions are never NULL, so that they can
+// be negated.
+func (t *translator) condition(e *exprpb.Expr, widen bool) sqlExpr {
+	switch e.GetExprKind().(type) {
+	case *exprpb.Expr_ConstExpr:
+		if v, ok := e.GetConstExpr().GetConstantKind().(*exprpb.Constant_BoolValue); ok {
+			if v.BoolValue {
+				return sqlTrue
+			}
+			return sqlFalse
+		}
+	case *exprpb.Expr_SelectExpr:
+		// has(m.k)
+		if s := e.GetSelectExpr(); s.GetTestOnly() {
+			if v, ok := t.mapValue(s.GetOperand(), s.GetField()); ok {
+				return sqlf("%s IS NOT NULL", v.sqlExpr)
+			}
+		}
+	case *exprpb.Expr_CallExpr:
+		call := e.GetCallExpr()
+		args := call.GetArgs()
+		switch fn := call.GetFunction(); fn {
+		case operators.LogicalAnd:
+			return and(t.condition(args[0], widen), t.condition(args[1], widen))
+		case operators.LogicalOr:
+			return or(t.condition(args[0], widen), t.condition(args[1], widen))
+		case operators.LogicalNot:
+			return not(t.condition(args[0], !widen))
+		case operators.Equals, operators.NotEquals, operators.Less, operators.LessEquals, operators.Greater, operators.GreaterEquals:
+			return t.comparison(fn, args[0], args[1], widen)
+		case operators.In:
+			return t.in(args[0], args[1], widen)
+		case overloads.Contains, overloads.StartsWith, overloads.EndsWith:
+			if call.GetTarget() != nil && len(args) == 1 {
+				return t.stringFunction(fn, call.GetTarget(), args[0], widen)
+			}
+		}
+	}
+	return t.unsupported(widen)
+}
+
+var sqlOperators = map[string]string{
+	operators.Equals:        "=",
+	operators.NotEquals:     "<>",
+	operators.Less:          "<",
+	operators.LessEquals:    "<=",
+	operators.Greater:       ">",
+	operators.GreaterEquals: ">=",
+}
+
+// roundedOperators replace the operators of comparisons of rounded values.
+// Rounding can make different values equal but never reverses their order,
+// so a widened condition also selects values that are rounded to be equal,
+// and a narrowed one excludes them.
+var roundedOperators = map[bool]map[string]string{
+	true: {
+		operators.Equals:        "=",
+		operators.NotEquals:     "",
+		operators.Less:          "<=",
+		operators.LessEquals:    "<=",
+		operators.Greater:       ">=",
+		operators.GreaterEquals: ">=",
+	},
+	false: {
+		operators.Equals:        "",
+		operators.NotEquals:     "<>",
+		operators.Less:          "<",
+		operators.LessEquals:    "<",
+		operators.Greater:       ">",
+		operators.GreaterEquals: ">",
+	},
+}
+
+func (t *translator) comparison(fn string, a, b *exprpb.Expr, widen bool) sqlExpr {
+	l, ok := t.value(a)
+	if !ok {
+		return t.unsupported(widen)
+	}
+	r, ok := t.value(b)
+	if !ok || l.kind != r.kind {
+		return t.unsupported(widen)
+	}
+
+	op := sqlOperators[fn]
+	if l.kind == Timestamp && (t.dialect == SQLite || l.rounded || r.rounded) {
+		t.exact = false
+		op = roundedOperators[widen][fn]
+		if op == "" {
+			return t.unsupported(widen)
+		}
+	}
+	if l.kind == String && t.dialect == Postgres && fn != operators.Equals && fn != operators.NotEquals {
+		// Filters order strings by their bytes.
+		op = `COLLATE "C" ` + op
+	}
+	return t.guard(sqlf("%s "+op+" %s", l.sqlExpr, r.sqlExpr), widen, l, r)
+}
+
+func (t *translator) in(a, b *exprpb.Expr, widen bool) sqlExpr {
+	// "k" in m
+	if k, ok := a.GetConstExpr().GetConstantKind().(*exprpb.Constant_StringValue); ok {
+		if v, ok := t.mapValue(b, k.StringValue); ok {
+			return sqlf("%s IS NOT NULL", v.sqlExpr)
+		}
+	}
+	// v in [x, y, z]
+	if list := b.GetListExpr(); list != nil {
+		cond := sqlFalse
+		for _, element := range list.GetElements() {
+			cond = or(cond, t.comparison(operators.Equals, a, element, widen))
+		}
+		return cond
+	}
+	return t.unsupported(widen)
+}
+
+func (t *translator) stringFunction(fn string, target, arg *exprpb.Expr, widen bool) sqlExpr {
+	s, ok := t.value(target)
+	if !ok || s.kind != String {
+		return t.unsupported(widen)
+	}
+	x, ok := t.value(arg)
+	if !ok || x.kind != String {
+		return t.unsupported(widen)
+	}
+
+	var cond sqlExpr
+	switch fn {
+	case overloads.Contains:
+		if t.dialect == Postgres {
+			cond = sqlf("strpos(%s, %s) > 0", s.sqlExpr, x.sqlExpr)
+		} else {
+			cond = sqlf("instr(%s, %s) > 0", s.sqlExpr, x.sqlExpr)
+		}
+	case overloads.StartsWith:
+		cond = sqlf("substr(%s, 1, length(%s)) = %s", s.sqlExpr, x.sqlExpr, x.sqlExpr)
+	case overloads.EndsWith:
+		// SQLite counts negative positions from the end of a string,
+		// so longer suffixes are excluded first.
+		cond = sqlf("(length(%s) >= length(%s) AND substr(%s, length(%s) - length(%s) + 1) = %s)",
+			s.sqlExpr, x.sqlExpr, s.sqlExpr, s.sqlExpr, x.sqlExpr, x.sqlExpr)
+	}
+	return t.guard(cond, widen, s, x)
+}
+
+// guard returns a condition that is never NULL for a condition on operands that may be NULL.
+// Filters fail to evaluate missing map values, so rows with them are left to Matches.
+func (t *translator) guard(cond sqlExpr, widen bool, values ...operand) sqlExpr {
+	for _, v := range values {
+		if !v.nullable {
+			continue
+		}
+		t.exact = false
+		if widen {
+			cond = or(sqlf("%s IS NULL", v.sqlExpr), cond)
+		} else {
+			cond = and(sqlf("%s IS NOT NULL", v.sqlExpr), cond)
+		}
+	}
+	return cond
+}
+
+// value translates an expression that computes a string, integer or timestamp.
+func (t *translator) value(e *exprpb.Expr) (operand, bool) {
+	switch e.GetExprKind().(type) {
+	case *exprpb.Expr_IdentExpr:
+		name := e.GetIdentExpr().GetName()
+		kind, ok := t.fields[name]
+		// Booleans are left to Matches.
+		if !ok || kind == StringMap || kind == Bool {
+			return operand{}, false
+		}
+		column, ok := t.columns[name]
+		if !ok {
+			return operand{}, false
+		}
+		v := operand{sqlExpr: sqlExpr{sql: column}, kind: kind}
+		if kind == Timestamp && t.dialect == SQLite {
+			v.sqlExpr = t.sqliteTime(v.sqlExpr)
+			v.rounded = true
+		}
+		return v, true
+	case *exprpb.Expr_ConstExpr:
+		switch c := e.GetConstExpr().GetConstantKind().(type) {
+		case *exprpb.Constant_StringValue:
+			return t.stringLiteral(c.StringValue), true
+		case *exprpb.Constant_Int64Value:
+			return operand{sqlExpr: sqlExpr{sql: "?", args: []interface{}{c.Int64Value}}, kind: Int}, true
+		}
+	case *exprpb.Expr_SelectExpr:
+		// m.k
+		if s := e.GetSelectExpr(); !s.GetTestOnly() {
+			return t.mapValue(s.GetOperand(), s.GetField())
+		}
+	case *exprpb.Expr_CallExpr:
+		call := e.GetCallExpr()
+		args := call.GetArgs()
+		switch call.GetFunction() {
+		case operators.Index:
+			// m["k"]
+			if k, ok := args[1].GetConstExpr().GetConstantKind().(*exprpb.Constant_StringValue); ok {
+				return t.mapValue(args[0], k.StringValue)
+			}
+		case overloads.TypeConvertTimestamp:
+			// timestamp("2006-01-02T15:04:05Z")
+			if len(args) != 1 || call.GetTarget() != nil {
+				break
+			}
+			s, ok := args[0].GetConstExpr().GetConstantKind().(*exprpb.Constant_StringValue)
+			if !ok {
+				break
+			}
+			v, err := time.Parse(time.RFC3339, s.StringValue)
+			if err != nil {
+				break
+			}
+			return t.timestampLiteral(v), true
+		}
+	}
+	return operand{}, false
+}
+
+func (t *translator) stringLiteral(s string) operand {
+	v := operand{sqlExpr: sqlExpr{sql: "?", args: []interface{}{s}}, kind: String}
+	if t.dialect == Postgres {
+		// Parameters of functions with several overloads need a type.
+		v.sql = "?::text"
+	}
+	return v
+}
+
+func (t *translator) timestampLiteral(v time.Time) operand {
+	if t.dialect == SQLite {
+		return operand{
+			sqlExpr: t.sqliteTime(sqlExpr{sql: "?", args: []interface{}{v.Format(time.RFC3339Nano)}}),
+			kind:    Timestamp,
+			rounded: true,
+		}
+	}
+	// PostgreSQL stores timestamps with microsecond precision.
+	return operand{
+		sqlExpr: sqlExpr{sql: "?", args: []interface{}{v}},
+		kind:    Timestamp,
+		rounded: v.Nanosecond()%int(time.Microsecond) != 0,
+	}
+}
+
+// sqliteTime converts a timestamp in SQLite, which stores timestamps as strings
+// with varying time zones, to a string that orders like the timestamp. SQLite
+// only keeps milliseconds when it converts timestamps.
+func (t *translator) sqliteTime(v sqlExpr) sqlExpr {
+	return sqlExpr{sql: "strftime('%Y-%m-%d %H:%M:%f', " + v.sql + ")", args: v.args}
+}
+
+// mapValue translates the lookup of a key in a map, which is NULL if the key is missing.
+// Maps are JSON objects. Other encodings are treated like empty maps.
+func (t *translator) mapValue(e *exprpb.Expr, key string) (operand, bool) {
+	name := e.GetIdentExpr().GetName()
+	if t.fields[name] != StringMap {
+		return operand{}, false
+	}
+	column, ok := t.columns[name]
+	if !ok {
+		return operand{}, false
+	}
+
+	v := operand{kind: String, nullable: true}
+	switch t.dialect {
+	case SQLite:
+		// SQLite paths can't contain quoted quotes.
+		if strings.ContainsAny(key, `"\`) {
+			return operand{}, false
+		}
+		v.sql = "json_extract(CASE WHEN CAST(" + column + " AS TEXT) LIKE '{%' THEN CAST(" + column + " AS TEXT) END, ?)"
+		v.args = []interface{}{`$."` + key + `"`}
+	case Postgres:
+		v.sql = "(CASE WHEN substring(" + column + " from 1 for 1) = decode('7b', 'hex') THEN convert_from(" + column + ", 'UTF8')::jsonb END) ->> ?::text"
+		v.args = []interface{}{key}
+	default:
+		return operand{}, false
+	}
+	return v, true
+}
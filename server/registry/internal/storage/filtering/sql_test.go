@@ -0,0 +1,200 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filtering
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFilter_SQL(t *testing.T) {
+	fields := map[string]FieldType{
+		"s":      String,
+		"i":      Int,
+		"t":      Timestamp,
+		"labels": StringMap,
+		"b":      Bool,
+		"other":  String,
+	}
+	columns := map[string]string{
+		"s":      "x.s",
+		"i":      "x.i",
+		"t":      "x.t",
+		"labels": "x.labels",
+		"b":      "x.b",
+	}
+	const sqliteLabel = "json_extract(CASE WHEN CAST(x.labels AS TEXT) LIKE '{%' THEN CAST(x.labels AS TEXT) END, ?)"
+	const postgresLabel = "(CASE WHEN substring(x.labels from 1 for 1) = decode('7b', 'hex') THEN convert_from(x.labels, 'UTF8')::jsonb END) ->> ?::text"
+	newYear := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		desc    string
+		filter  string
+		dialect string
+		cond    string
+		args    []interface{}
+		exact   bool
+	}{
+		{
+			desc:    "empty",
+			filter:  ``,
+			dialect: SQLite,
+			exact:   true,
+		},
+		{
+			desc:    "comparisons",
+			filter:  `s == "a" && i > 1 || !(i <= 2)`,
+			dialect: SQLite,
+			cond:    "((x.s = ? AND x.i > ?) OR NOT (x.i <= ?))",
+			args:    []interface{}{"a", int64(1), int64(2)},
+			exact:   true,
+		},
+		{
+			desc:    "string order",
+			filter:  `s < "b"`,
+			dialect: Postgres,
+			cond:    `x.s COLLATE "C" < ?::text`,
+			args:    []interface{}{"b"},
+			exact:   true,
+		},
+		{
+			desc:    "list",
+			filter:  `s in ["a", "b"]`,
+			dialect: Postgres,
+			cond:    "(x.s = ?::text OR x.s = ?::text)",
+			args:    []interface{}{"a", "b"},
+			exact:   true,
+		},
+		{
+			desc:    "string functions",
+			filter:  `s.contains("a") && s.startsWith("b")`,
+			dialect: Postgres,
+			cond:    "(strpos(x.s, ?::text) > 0 AND substr(x.s, 1, length(?::text)) = ?::text)",
+			args:    []interface{}{"a", "b", "b"},
+			exact:   true,
+		},
+		{
+			desc:    "map keys",
+			filter:  `has(labels.a) || "b" in labels`,
+			dialect: SQLite,
+			cond:    "(" + sqliteLabel + " IS NOT NULL OR " + sqliteLabel + " IS NOT NULL)",
+			args:    []interface{}{`$."a"`, `$."b"`},
+			exact:   true,
+		},
+		{
+			desc:    "map values",
+			filter:  `labels["a"] == "1"`,
+			dialect: Postgres,
+			cond:    "(" + postgresLabel + " IS NULL OR " + postgresLabel + " = ?::text)",
+			args:    []interface{}{"a", "a", "1"},
+		},
+		{
+			desc:    "negated map values",
+			filter:  `!(labels.a == "1")`,
+			dialect: Postgres,
+			cond:    "NOT ((" + postgresLabel + " IS NOT NULL AND " + postgresLabel + " = ?::text))",
+			args:    []interface{}{"a", "a", "1"},
+		},
+		{
+			desc:    "map keys that SQLite can't look up",
+			filter:  `has(labels.a) && "\"" in labels`,
+			dialect: SQLite,
+			cond:    sqliteLabel + " IS NOT NULL",
+			args:    []interface{}{`$."a"`},
+		},
+		{
+			desc:    "timestamps",
+			filter:  `t < timestamp("2021-01-01T00:00:00Z")`,
+			dialect: Postgres,
+			cond:    "x.t < ?",
+			args:    []interface{}{newYear},
+			exact:   true,
+		},
+		{
+			desc:    "rounded timestamps",
+			filter:  `t < timestamp("2021-01-01T00:00:00.000000001Z") && !(t == timestamp("2021-01-01T00:00:00Z"))`,
+			dialect: Postgres,
+			cond:    "(x.t <= ? AND NOT (x.t = ?))",
+			args:    []interface{}{newYear.Add(time.Nanosecond), newYear},
+		},
+		{
+			desc:    "SQLite timestamps",
+			filter:  `t > timestamp("2021-01-01T00:00:00Z")`,
+			dialect: SQLite,
+			cond:    "strftime('%Y-%m-%d %H:%M:%f', x.t) >= strftime('%Y-%m-%d %H:%M:%f', ?)",
+			args:    []interface{}{"2021-01-01T00:00:00Z"},
+		},
+		{
+			desc:    "unsupported",
+			filter:  `s.lowerAscii() == "a" || s == "b"`,
+			dialect: SQLite,
+		},
+		{
+			desc:    "negated unsupported",
+			filter:  `!(s.lowerAscii() == "a" || s == "b")`,
+			dialect: SQLite,
+			cond:    "NOT (x.s = ?)",
+			args:    []interface{}{"b"},
+		},
+		{
+			desc:    "boolean",
+			filter:  `b && s == "a"`,
+			dialect: SQLite,
+			cond:    "x.s = ?",
+			args:    []interface{}{"a"},
+		},
+		{
+			desc:    "field without a column",
+			filter:  `other == "a" && s == "b"`,
+			dialect: SQLite,
+			cond:    "x.s = ?",
+			args:    []interface{}{"b"},
+		},
+		{
+			desc:    "constant",
+			filter:  `false || s == "a"`,
+			dialect: SQLite,
+			cond:    "x.s = ?",
+			args:    []interface{}{"a"},
+			exact:   true,
+		},
+		{
+			desc:    "unknown dialect",
+			filter:  `s == "a"`,
+			dialect: "mysql",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			f, err := NewFilter(test.filter, fields)
+			if err != nil {
+				t.Fatalf("NewFilter(%q) returned error: %s", test.filter, err)
+			}
+			cond, args, exact := f.SQL(test.dialect, columns)
+			if cond != test.cond {
+				t.Errorf("NewFilter(%q).SQL() returned condition %q, want %q", test.filter, cond, test.cond)
+			}
+			if diff := cmp.Diff(test.args, args); diff != "" {
+				t.Errorf("NewFilter(%q).SQL() returned unexpected args (-want +got):\n%s", test.filter, diff)
+			}
+			if exact != test.exact {
+				t.Errorf("NewFilter(%q).SQL() returned exact %t, want %t", test.filter, exact, test.exact)
+			}
+		})
+	}
+}
@@ -112,6 +112,9 @@ func (c *Client) GetSpecRevisionContents(ctx context.Context, name names.SpecRev
 		return nil, grpcErrorForDBError(ctx, errors.Wrapf(err, "get %s", name))
 	}
 
+	if err := c.getContents(ctx, v); err != nil {
+		return nil, err
+	}
 	return v, nil
 }
 
@@ -239,5 +242,63 @@ func (c *Client) GetArtifactContents(ctx context.Context, name names.Artifact) (
 		return nil, grpcErrorForDBError(ctx, errors.Wrapf(err, "get %s", name))
 	}
 
+	if err := c.getContents(ctx, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// GetArtifactRevision returns a revision of an artifact.
+// Names without revision IDs refer to the current revision.
+func (c *Client) GetArtifactRevision(ctx context.Context, name names.ArtifactRevision) (*models.Artifact, error) {
+	current, revision, err := c.getArtifactRevision(ctx, name)
+	if err != nil {
+		return nil, err
+	} else if revision == nil {
+		return current, nil
+	}
+	return &revision.Artifact, nil
+}
+
+func (c *Client) GetArtifactRevisionContents(ctx context.Context, name names.ArtifactRevision) (*models.Blob, error) {
+	_, revision, err := c.getArtifactRevision(ctx, name)
+	if err != nil {
+		return nil, err
+	} else if revision == nil {
+		return c.GetArtifactContents(ctx, name.Artifact())
+	}
+
+	v := revision.Blob()
+	if err := c.getContents(ctx, v); err != nil {
+		return nil, err
+	}
 	return v, nil
 }
+
+// getArtifactRevision returns the current revision of an artifact and, if a
+// name refers to an earlier revision, that revision.
+func (c *Client) getArtifactRevision(ctx context.Context, name names.ArtifactRevision) (*models.Artifact, *models.ArtifactRevision, error) {
+	current, err := c.GetArtifact(ctx, name.Artifact(), false)
+	if err != nil {
+		return nil, nil, err
+	}
+	if name.RevisionID == "" {
+		return current, nil, nil
+	}
+
+	id, err := c.unwrapArtifactRevisionTag(ctx, current, name.RevisionID)
+	if err != nil {
+		return nil, nil, err
+	} else if id == current.ArtifactRevisionID {
+		return current, nil, nil
+	}
+
+	v := new(models.ArtifactRevision)
+	if err := c.db.WithContext(ctx).Take(v, "key = ?", current.Name()+"@"+id).Error; err == gorm.ErrRecordNotFound {
+		return nil, nil, status.Errorf(codes.NotFound, "%q not found in database", name)
+	} else if err != nil {
+		return nil, nil, grpcErrorForDBError(ctx, errors.Wrapf(err, "get %s", name))
+	}
+
+	return current, v, nil
+}
@@ -16,7 +16,9 @@ package storage
 
 import (
 	"context"
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/apigee/registry/server/registry/internal/storage/filtering"
 	"github.com/apigee/registry/server/registry/internal/storage/models"
@@ -34,7 +36,13 @@ var tableFieldsLookup = map[string]map[string]filtering.FieldType{
 	"specs":                specFields,
 	"deployments":          deploymentFields,
 	"artifacts":            artifactFields,
+	"artifact_revisions":   artifactFields,
 	"revisioned_artifacts": revisionedArtifactFields,
+	"resource_references":  referenceFields,
+	"spec_elements":        specElementFields,
+	"notification_events":  notificationEventFields,
+	"audit_events":         auditEventFields,
+	"operations":           operationFields,
 }
 
 var defaultOrder = map[string]string{
@@ -44,7 +52,13 @@ var defaultOrder = map[string]string{
 	"versions":             "project_id, api_id, version_id",
 	"specs":                "project_id, api_id, version_id, spec_id, revision_create_time desc",
 	"artifacts":            "project_id, api_id, version_id, spec_id, deployment_id, artifact_id, create_time desc",
+	"artifact_revisions":   "update_time desc",
 	"revisioned_artifacts": "project_id, api_id, version_id, spec_id, deployment_id, revision_create_time desc, artifact_id",
+	"resource_references":  "source, field, target",
+	"spec_elements":        "spec, kind, method, path",
+	"notification_events":  "id",
+	"audit_events":         "id",
+	"operations":           "create_time",
 }
 
 var projectFields = map[string]filtering.FieldType{
@@ -150,20 +164,31 @@ var revisionedArtifactFields = map[string]filtering.FieldType{
 	"revision_update_time": filtering.Timestamp,
 }
 
-// gormOrdering accepts a user-specified order_by string and returns a gorm-compatible equivalent.
-// For example, the user-specified string `name,description` returns `key,description`.
-// An error is returned if the string is invalid or refers to a field that isn't included in the `fields` map.
-func gormOrdering(ordering, table string) (string, error) {
+// orderColumn is a column that a listing is sorted by.
+type orderColumn struct {
+	field      string // Name of the field in order_by and filters.
+	column     string // SQL expression of the column.
+	descending bool
+}
+
+// ordering is the sort order of a listing. It ends with the primary key of the
+// listed table, so that every row has a distinct position in the listing.
+type ordering []orderColumn
+
+// newOrdering accepts a user-specified order_by string and returns the equivalent ordering of a table.
+// For example, the user-specified string `name,description` sorts apis by `apis.key,apis.description,apis.key`.
+// An error is returned if the string is invalid or refers to a field that isn't included in the table's fields.
+func newOrdering(orderBy, table string) (ordering, error) {
 	fields, ok := tableFieldsLookup[table]
 	if !ok {
-		return "", status.Errorf(codes.Internal, "unknown order table: %q", table)
+		return nil, status.Errorf(codes.Internal, "unknown order table: %q", table)
 	}
-	if ordering == "" {
-		ordering = defaultOrder[table]
+	if orderBy == "" {
+		orderBy = defaultOrder[table]
 	}
 
-	clauses := make([]string, 0)
-	for _, v := range strings.Split(ordering, ",") {
+	order := make(ordering, 0)
+	for _, v := range strings.Split(orderBy, ",") {
 		v = strings.TrimSpace(v)
 
 		// Check if the field is specified in descending order and trim it from the string.
@@ -173,47 +198,270 @@ func gormOrdering(ordering, table string) (string, error) {
 		v = strings.TrimSpace(v)
 
 		if strings.Contains(v, " ") {
-			return "", status.Errorf(codes.InvalidArgument, "invalid order_by field %q: too many parts", v)
+			return nil, status.Errorf(codes.InvalidArgument, "invalid order_by field %q: too many parts", v)
 		} else if len(v) == 0 {
-			return "", status.Errorf(codes.InvalidArgument, "invalid order_by field %q: missing field name", v)
+			return nil, status.Errorf(codes.InvalidArgument, "invalid order_by field %q: missing field name", v)
 		}
 
-		// Check if the field is valid for this model type and replace it with the internal name if needed.
-		// After this point, the clause should contain an internal field name.
-		var clause string
-		for field := range fields {
-			if field == v && field == "name" {
-				clause = "key"
-			} else if field == v {
-				clause = field
-			}
+		// Check if the field is valid for this model type.
+		if _, ok := fields[v]; !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown field name %q in %q", v, table)
 		}
-		if clause == "" {
-			return ordering, status.Errorf(codes.InvalidArgument, "unknown field name %q in %q", v, table)
+
+		order = append(order, orderColumn{field: v, column: orderColumnName(table, v), descending: descending})
+	}
+
+	key := "key"
+	if table == "notification_events" || table == "audit_events" {
+		key = "id"
+	}
+	return append(order, orderColumn{field: key, column: orderColumnName(table, key)}), nil
+}
+
+// orderColumnName returns the qualified name of the column of a field,
+// which may be joined with others when it is listed.
+func orderColumnName(table, field string) string {
+	if table == "revisioned_artifacts" {
+		if field == "revision_create_time" || field == "revision_update_time" {
+			return "latest." + field
 		}
+		table = "artifacts"
+	}
+	switch field {
+	case "name":
+		field = "key"
+	case "filename":
+		field = "file_name"
+	case "size_bytes":
+		field = "size_in_bytes"
+	}
+	return table + "." + field
+}
 
-		if descending {
-			clause += " desc"
+// String returns the ordering as an ORDER BY clause.
+func (o ordering) String() string {
+	clauses := make([]string, len(o))
+	for i, c := range o {
+		clauses[i] = c.column
+		if c.descending {
+			clauses[i] += " desc"
 		}
+	}
+	return strings.Join(clauses, ",")
+}
 
-		clauses = append(clauses, clause)
+// position returns the position of a row in the listing: the values of its
+// ordering columns, given its primary key and the values of its fields.
+func (o ordering) position(key interface{}, fields map[string]interface{}) []interface{} {
+	position := make([]interface{}, len(o))
+	for i, c := range o[:len(o)-1] {
+		if c.field == "name" {
+			position[i] = key
+		} else {
+			position[i] = fields[c.field]
+		}
 	}
+	position[len(o)-1] = key
+	return position
+}
 
-	return strings.Join(clauses, ","), nil
+// after returns a condition that selects the rows that follow a position in the listing.
+func (o ordering) after(position []interface{}) (string, []interface{}) {
+	c := o[0]
+	op := ">"
+	if c.descending {
+		op = "<"
+	}
+	if len(o) == 1 {
+		return fmt.Sprintf("%s %s ?", c.column, op), position[:1]
+	}
+	cond, args := o[1:].after(position[1:])
+	return fmt.Sprintf("(%s %s ? OR (%s = ? AND %s))", c.column, op, c.column, cond),
+		append([]interface{}{position[0], position[0]}, args...)
+}
+
+// withDeleted returns a client that includes deleted resources in its results if show is true.
+func (c *Client) withDeleted(show bool) *Client {
+	if !show {
+		return c
+	}
+	return &Client{db: c.db.Unscoped().Session(&gorm.Session{}), blobs: c.blobs}
 }
 
 // limit returns the database page size to use for a listing request.
-func limit(opts PageOptions) int {
-	// Without filters, read exactly enough rows to fill the page,
-	// plus an extra row to check if another page exists.
-	if opts.Filter == "" {
+func limit(opts PageOptions, filter listFilter) int {
+	// Without filters, or when the database evaluates the entire filter, read exactly
+	// enough rows to fill the page, plus an extra row to check if another page exists.
+	if opts.Filter == "" || filter.exact {
 		return int(opts.Size) + 1
 	}
 
-	// When filters are present, read max page size
+	// When filters are evaluated in memory, read max page size
 	return 1000
 }
 
+// listFilter is the filter of a listing request. The database evaluates as much
+// of the filter as it can, and the rest is evaluated in memory with Matches.
+type listFilter struct {
+	filtering.Filter
+	cond  string
+	args  []interface{}
+	exact bool // True if the database evaluates the entire filter.
+}
+
+// newListFilter parses a filter of the rows of a table.
+func (c *Client) newListFilter(filter, table string) (listFilter, error) {
+	f, err := filtering.NewFilter(filter, tableFieldsLookup[table])
+	if err != nil {
+		return listFilter{}, err
+	}
+	cond, args, exact := f.SQL(c.db.Dialector.Name(), filterColumns(table))
+	return listFilter{Filter: f, cond: cond, args: args, exact: exact}, nil
+}
+
+// where restricts a query to the rows that may match the filter.
+func (f listFilter) where(op *gorm.DB) *gorm.DB {
+	if f.cond == "" {
+		return op
+	}
+	return op.Where(f.cond, f.args...)
+}
+
+// Matches returns true if a row selected by the database matches the filter.
+func (f listFilter) Matches(model map[string]interface{}) (bool, error) {
+	if f.exact {
+		return true, nil
+	}
+	return f.Filter.Matches(model)
+}
+
+// listRow describes a row read by listRows.
+type listRow struct {
+	key    interface{}            // Primary key of the row.
+	fields map[string]interface{} // Fields that filters can refer to.
+	order  map[string]interface{} // Fields that rows are sorted by, if they differ from the filter fields.
+}
+
+// listRows reads a page of the rows of a table that are selected by op and
+// returns the token of the next page. find reads rows into a slice and returns
+// their number, row describes the row at an index of the slice, and add is
+// called with the indexes of the rows that match the filter, in order.
+func (c *Client) listRows(ctx context.Context, table string, op *gorm.DB, opts PageOptions,
+	find func(*gorm.DB) (int, error), row func(int) listRow, add func(int)) (string, error) {
+	token, err := decodeToken(opts.Token)
+	if err != nil {
+		return "", status.Errorf(codes.InvalidArgument, "invalid page token %q: %s", opts.Token, err.Error())
+	}
+
+	if err := token.ValidateFilter(opts.Filter); err != nil {
+		return "", status.Errorf(codes.InvalidArgument, "invalid filter %q: %s", opts.Filter, err)
+	} else {
+		token.Filter = opts.Filter
+	}
+
+	if err := token.ValidateOrder(opts.Order); err != nil {
+		return "", status.Errorf(codes.InvalidArgument, "invalid order_by %q: %s", opts.Order, err)
+	} else {
+		token.Order = opts.Order
+	}
+
+	filter, err := c.newListFilter(opts.Filter, table)
+	if err != nil {
+		return "", err
+	}
+
+	order, err := newOrdering(opts.Order, table)
+	if err != nil {
+		return "", err
+	}
+	op = filter.where(op).Order(order.String()).Limit(limit(opts, filter))
+
+	added := 0
+	for {
+		query, err := token.query(op, order)
+		if err != nil {
+			return "", status.Errorf(codes.InvalidArgument, "invalid page token %q: %s", opts.Token, err)
+		}
+		n, err := find(query)
+		if err != nil {
+			return "", grpcErrorForDBError(ctx, errors.Wrapf(err, "find %#v", token))
+		} else if n == 0 {
+			break
+		}
+
+		for i := 0; i < n; i++ {
+			r := row(i)
+			if r.order == nil {
+				r.order = r.fields
+			}
+			position := order.position(r.key, r.order)
+			match, err := filter.Matches(r.fields)
+			if err != nil {
+				return "", err
+			} else if !match {
+				token.advance(position)
+				continue
+			}
+
+			if added == int(opts.Size) {
+				next, err := encodeToken(token)
+				if err != nil {
+					return "", status.Error(codes.Internal, err.Error())
+				}
+				return next, nil
+			}
+
+			token.advance(position)
+			add(i)
+			added++
+		}
+		if n < int(opts.Size) {
+			break
+		}
+	}
+
+	return "", nil
+}
+
+// filterColumns returns the SQL expressions that compute the filter fields of a table.
+// Columns are qualified by the table, which may be joined with others when it is listed.
+func filterColumns(table string) map[string]string {
+	columns := make(map[string]string)
+	for field := range tableFieldsLookup[table] {
+		column := field
+		switch field {
+		case "name":
+			column = "key"
+		case "filename":
+			column = "file_name"
+		case "size_bytes":
+			column = "size_in_bytes"
+		}
+		columns[field] = table + "." + column
+	}
+
+	switch table {
+	case "specs":
+		// Keys of specs are the names of their revisions.
+		columns["name"] = "('projects/' || specs.project_id || '/locations/" + names.Location +
+			"/apis/' || specs.api_id || '/versions/' || specs.version_id || '/specs/' || specs.spec_id)"
+	case "deployments":
+		// Keys of deployments are the names of their revisions.
+		columns["name"] = "('projects/' || deployments.project_id || '/locations/" + names.Location +
+			"/apis/' || deployments.api_id || '/deployments/' || deployments.deployment_id)"
+	case "artifact_revisions":
+		// Keys of artifact revisions are the names of revisions, so names are filtered in memory.
+		delete(columns, "name")
+	case "notification_events":
+		// Changes are stored as numbers and filtered by their names.
+		delete(columns, "change")
+	case "spec_elements":
+		// Spec elements have names that aren't their keys.
+		columns["name"] = "spec_elements.name"
+	}
+	return columns
+}
+
 // ProjectList contains a page of project resources.
 type ProjectList struct {
 	Projects []models.Project
@@ -221,6 +469,7 @@ type ProjectList struct {
 }
 
 func (c *Client) ListProjects(ctx context.Context, opts PageOptions) (ProjectList, error) {
+	c = c.withDeleted(opts.ShowDeleted)
 	token, err := decodeToken(opts.Token)
 	if err != nil {
 		return ProjectList{}, status.Errorf(codes.InvalidArgument, "invalid page token %q: %s", opts.Token, err.Error())
@@ -238,15 +487,16 @@ func (c *Client) ListProjects(ctx context.Context, opts PageOptions) (ProjectLis
 		token.Order = opts.Order
 	}
 
-	filter, err := filtering.NewFilter(opts.Filter, projectFields)
+	filter, err := c.newListFilter(opts.Filter, "projects")
 	if err != nil {
 		return ProjectList{}, err
 	}
 
-	order, err := gormOrdering(opts.Order, "projects")
+	order, err := newOrdering(opts.Order, "projects")
 	if err != nil {
 		return ProjectList{}, err
 	}
+	op := filter.where(c.db.WithContext(ctx)).Order(order.String()).Limit(limit(opts, filter))
 
 	response := ProjectList{
 		Projects: make([]models.Project, 0, opts.Size),
@@ -254,8 +504,11 @@ func (c *Client) ListProjects(ctx context.Context, opts PageOptions) (ProjectLis
 
 	for {
 		var page []models.Project
-		op := c.db.WithContext(ctx).Order(order).Limit(limit(opts))
-		err := op.Offset(token.Offset).Find(&page).Error
+		query, err := token.query(op, order)
+		if err != nil {
+			return ProjectList{}, status.Errorf(codes.InvalidArgument, "invalid page token %q: %s", opts.Token, err)
+		}
+		err = query.Find(&page).Error
 
 		if err != nil {
 			return ProjectList{}, grpcErrorForDBError(ctx, errors.Wrapf(err, "find %#v", token))
@@ -264,11 +517,13 @@ func (c *Client) ListProjects(ctx context.Context, opts PageOptions) (ProjectLis
 		}
 
 		for _, v := range page {
-			match, err := filter.Matches(projectMap(v))
+			m := projectMap(v)
+			position := order.position(v.Key, m)
+			match, err := filter.Matches(m)
 			if err != nil {
 				return ProjectList{}, err
 			} else if !match {
-				token.Offset++
+				token.advance(position)
 				continue
 			}
 
@@ -280,10 +535,10 @@ func (c *Client) ListProjects(ctx context.Context, opts PageOptions) (ProjectLis
 				return response, nil
 			}
 
-			token.Offset++
+			token.advance(position)
 			response.Projects = append(response.Projects, v)
 		}
-		if op.RowsAffected < int64(opts.Size) {
+		if query.RowsAffected < int64(opts.Size) {
 			break
 		}
 	}
@@ -309,6 +564,7 @@ type ApiList struct {
 }
 
 func (c *Client) ListApis(ctx context.Context, parent names.Project, opts PageOptions) (ApiList, error) {
+	c = c.withDeleted(opts.ShowDeleted)
 	token, err := decodeToken(opts.Token)
 	if err != nil {
 		return ApiList{}, status.Errorf(codes.InvalidArgument, "invalid page token %q: %s", opts.Token, err.Error())
@@ -326,8 +582,13 @@ func (c *Client) ListApis(ctx context.Context, parent names.Project, opts PageOp
 		token.Order = opts.Order
 	}
 
-	op := c.db.WithContext(ctx).
-		Limit(limit(opts))
+	filter, err := c.newListFilter(opts.Filter, "apis")
+	if err != nil {
+		return ApiList{}, err
+	}
+
+	op := filter.where(c.db.WithContext(ctx)).
+		Limit(limit(opts, filter))
 
 	if parent.ProjectID != "-" {
 		op = op.Where("project_id = ?", parent.ProjectID)
@@ -336,16 +597,11 @@ func (c *Client) ListApis(ctx context.Context, parent names.Project, opts PageOp
 		}
 	}
 
-	filter, err := filtering.NewFilter(opts.Filter, apiFields)
+	order, err := newOrdering(opts.Order, "apis")
 	if err != nil {
 		return ApiList{}, err
 	}
-
-	if order, err := gormOrdering(opts.Order, "apis"); err != nil {
-		return ApiList{}, err
-	} else {
-		op = op.Order(order)
-	}
+	op = op.Order(order.String())
 
 	response := ApiList{
 		Apis: make([]models.Api, 0, opts.Size),
@@ -353,7 +609,11 @@ func (c *Client) ListApis(ctx context.Context, parent names.Project, opts PageOp
 
 	for {
 		var page []models.Api
-		err := op.Offset(token.Offset).Find(&page).Error
+		query, err := token.query(op, order)
+		if err != nil {
+			return ApiList{}, status.Errorf(codes.InvalidArgument, "invalid page token %q: %s", opts.Token, err)
+		}
+		err = query.Find(&page).Error
 
 		if err != nil {
 			return ApiList{}, grpcErrorForDBError(ctx, errors.Wrapf(err, "find %#v", token))
@@ -366,12 +626,13 @@ func (c *Client) ListApis(ctx context.Context, parent names.Project, opts PageOp
 			if err != nil {
 				return ApiList{}, status.Error(codes.Internal, err.Error())
 			}
+			position := order.position(v.Key, m)
 
 			match, err := filter.Matches(m)
 			if err != nil {
 				return ApiList{}, err
 			} else if !match {
-				token.Offset++
+				token.advance(position)
 				continue
 			}
 
@@ -383,10 +644,10 @@ func (c *Client) ListApis(ctx context.Context, parent names.Project, opts PageOp
 				return response, nil
 			}
 
-			token.Offset++
+			token.advance(position)
 			response.Apis = append(response.Apis, v)
 		}
-		if op.RowsAffected < int64(opts.Size) {
+		if query.RowsAffected < int64(opts.Size) {
 			break
 		}
 	}
@@ -401,16 +662,17 @@ func apiMap(api models.Api) (map[string]interface{}, error) {
 	}
 
 	return map[string]interface{}{
-		"name":                api.Name(),
-		"project_id":          api.ProjectID,
-		"api_id":              api.ApiID,
-		"display_name":        api.DisplayName,
-		"description":         api.Description,
-		"create_time":         api.CreateTime,
-		"update_time":         api.UpdateTime,
-		"availability":        api.Availability,
-		"recommended_version": api.RecommendedVersion,
-		"labels":              labels,
+		"name":                   api.Name(),
+		"project_id":             api.ProjectID,
+		"api_id":                 api.ApiID,
+		"display_name":           api.DisplayName,
+		"description":            api.Description,
+		"create_time":            api.CreateTime,
+		"update_time":            api.UpdateTime,
+		"availability":           api.Availability,
+		"recommended_version":    api.RecommendedVersion,
+		"recommended_deployment": api.RecommendedDeployment,
+		"labels":                 labels,
 	}, nil
 }
 
@@ -421,6 +683,7 @@ type VersionList struct {
 }
 
 func (c *Client) ListVersions(ctx context.Context, parent names.Api, opts PageOptions) (VersionList, error) {
+	c = c.withDeleted(opts.ShowDeleted)
 	token, err := decodeToken(opts.Token)
 	if err != nil {
 		return VersionList{}, status.Errorf(codes.InvalidArgument, "invalid page token %q: %s", opts.Token, err.Error())
@@ -448,12 +711,12 @@ func (c *Client) ListVersions(ctx context.Context, parent names.Api, opts PageOp
 		}
 	}
 
-	filter, err := filtering.NewFilter(opts.Filter, versionFields)
+	filter, err := c.newListFilter(opts.Filter, "versions")
 	if err != nil {
 		return VersionList{}, err
 	}
 
-	op := c.db.WithContext(ctx).Limit(limit(opts))
+	op := filter.where(c.db.WithContext(ctx)).Limit(limit(opts, filter))
 	if parent.ProjectID != "-" {
 		op = op.Where("project_id = ?", parent.ProjectID)
 	}
@@ -461,11 +724,11 @@ func (c *Client) ListVersions(ctx context.Context, parent names.Api, opts PageOp
 		op = op.Where("api_id = ?", parent.ApiID)
 	}
 
-	if order, err := gormOrdering(opts.Order, "versions"); err != nil {
+	order, err := newOrdering(opts.Order, "versions")
+	if err != nil {
 		return VersionList{}, err
-	} else {
-		op = op.Order(order)
 	}
+	op = op.Order(order.String())
 
 	response := VersionList{
 		Versions: make([]models.Version, 0, opts.Size),
@@ -473,7 +736,11 @@ func (c *Client) ListVersions(ctx context.Context, parent names.Api, opts PageOp
 
 	for {
 		var page []models.Version
-		err := op.Offset(token.Offset).Find(&page).Error
+		query, err := token.query(op, order)
+		if err != nil {
+			return VersionList{}, status.Errorf(codes.InvalidArgument, "invalid page token %q: %s", opts.Token, err)
+		}
+		err = query.Find(&page).Error
 
 		if err != nil {
 			return VersionList{}, grpcErrorForDBError(ctx, errors.Wrapf(err, "find %#v", token))
@@ -486,12 +753,13 @@ func (c *Client) ListVersions(ctx context.Context, parent names.Api, opts PageOp
 			if err != nil {
 				return VersionList{}, status.Error(codes.Internal, err.Error())
 			}
+			position := order.position(v.Key, m)
 
 			match, err := filter.Matches(m)
 			if err != nil {
 				return VersionList{}, err
 			} else if !match {
-				token.Offset++
+				token.advance(position)
 				continue
 			}
 
@@ -503,10 +771,10 @@ func (c *Client) ListVersions(ctx context.Context, parent names.Api, opts PageOp
 				return response, nil
 			}
 
-			token.Offset++
+			token.advance(position)
 			response.Versions = append(response.Versions, v)
 		}
-		if op.RowsAffected < int64(opts.Size) {
+		if query.RowsAffected < int64(opts.Size) {
 			break
 		}
 	}
@@ -523,6 +791,7 @@ func versionMap(version models.Version) (map[string]interface{}, error) {
 	return map[string]interface{}{
 		"name":         version.Name(),
 		"project_id":   version.ProjectID,
+		"api_id":       version.ApiID,
 		"version_id":   version.VersionID,
 		"display_name": version.DisplayName,
 		"description":  version.Description,
@@ -541,6 +810,7 @@ type SpecList struct {
 }
 
 func (c *Client) ListSpecs(ctx context.Context, parent names.Version, opts PageOptions) (SpecList, error) {
+	c = c.withDeleted(opts.ShowDeleted)
 	token, err := decodeToken(opts.Token)
 	if err != nil {
 		return SpecList{}, status.Errorf(codes.InvalidArgument, "invalid page token %q: %s", opts.Token, err.Error())
@@ -572,12 +842,12 @@ func (c *Client) ListSpecs(ctx context.Context, parent names.Version, opts PageO
 		}
 	}
 
-	filter, err := filtering.NewFilter(opts.Filter, specFields)
+	filter, err := c.newListFilter(opts.Filter, "specs")
 	if err != nil {
 		return SpecList{}, err
 	}
 
-	op := c.db.WithContext(ctx).Select("specs.*").Table("specs").
+	op := filter.where(c.db.WithContext(ctx).Select("specs.*").Table("specs")).
 		// select latest spec revision
 		Joins(`join (?) latest
 		ON specs.project_id = latest.project_id
@@ -585,7 +855,7 @@ func (c *Client) ListSpecs(ctx context.Context, parent names.Version, opts PageO
 		AND specs.version_id = latest.version_id
 		AND specs.spec_id = latest.spec_id
 		AND specs.revision_id = latest.revision_id`, c.latestSpecRevisionsQuery(ctx)).
-		Limit(limit(opts))
+		Limit(limit(opts, filter))
 
 	if parent.ProjectID != "-" {
 		op = op.Where("specs.project_id = ?", parent.ProjectID)
@@ -597,11 +867,11 @@ func (c *Client) ListSpecs(ctx context.Context, parent names.Version, opts PageO
 		op = op.Where("specs.version_id = ?", parent.VersionID)
 	}
 
-	if order, err := gormOrdering(opts.Order, "specs"); err != nil {
+	order, err := newOrdering(opts.Order, "specs")
+	if err != nil {
 		return SpecList{}, err
-	} else {
-		op = op.Order(order)
 	}
+	op = op.Order(order.String())
 
 	response := SpecList{
 		Specs: make([]models.Spec, 0, opts.Size),
@@ -609,7 +879,11 @@ func (c *Client) ListSpecs(ctx context.Context, parent names.Version, opts PageO
 
 	for {
 		var page []models.Spec
-		err := op.Offset(token.Offset).Find(&page).Error
+		query, err := token.query(op, order)
+		if err != nil {
+			return SpecList{}, status.Errorf(codes.InvalidArgument, "invalid page token %q: %s", opts.Token, err)
+		}
+		err = query.Find(&page).Error
 
 		if err != nil {
 			return SpecList{}, grpcErrorForDBError(ctx, errors.Wrapf(err, "find %#v", token))
@@ -622,12 +896,13 @@ func (c *Client) ListSpecs(ctx context.Context, parent names.Version, opts PageO
 			if err != nil {
 				return SpecList{}, status.Error(codes.Internal, err.Error())
 			}
+			position := order.position(v.Key, m)
 
 			match, err := filter.Matches(m)
 			if err != nil {
 				return SpecList{}, err
 			} else if !match {
-				token.Offset++
+				token.advance(position)
 				continue
 			}
 
@@ -639,10 +914,10 @@ func (c *Client) ListSpecs(ctx context.Context, parent names.Version, opts PageO
 				return response, nil
 			}
 
-			token.Offset++
+			token.advance(position)
 			response.Specs = append(response.Specs, v)
 		}
-		if op.RowsAffected < int64(opts.Size) {
+		if query.RowsAffected < int64(opts.Size) {
 			break
 		}
 	}
@@ -688,6 +963,12 @@ func (c *Client) ListSpecRevisions(ctx context.Context, parent names.SpecRevisio
 		token.Filter = opts.Filter
 	}
 
+	if err := token.ValidateOrder(opts.Order); err != nil {
+		return SpecList{}, status.Errorf(codes.InvalidArgument, "invalid order_by %q: %s", opts.Order, err)
+	} else {
+		token.Order = opts.Order
+	}
+
 	// Check existence of the deepest fully specified resource in the parent name.
 	if parent.ProjectID != "-" && parent.ApiID != "-" && parent.VersionID != "-" && parent.SpecID != "-" && parent.RevisionID != "-" {
 		if _, err := c.GetSpecRevision(ctx, parent); err != nil {
@@ -711,13 +992,12 @@ func (c *Client) ListSpecRevisions(ctx context.Context, parent names.SpecRevisio
 		}
 	}
 
-	filter, err := filtering.NewFilter(opts.Filter, specFields)
+	filter, err := c.newListFilter(opts.Filter, "specs")
 	if err != nil {
 		return SpecList{}, err
 	}
 
-	op := c.db.WithContext(ctx).
-		Offset(token.Offset).
+	op := filter.where(c.db.WithContext(ctx)).
 		Limit(int(opts.Size) + 1)
 
 	if id := parent.ProjectID; id != "-" {
@@ -736,11 +1016,11 @@ func (c *Client) ListSpecRevisions(ctx context.Context, parent names.SpecRevisio
 		op = op.Where("specs.revision_id = ?", id)
 	}
 
-	if order, err := gormOrdering(opts.Order, "specs"); err != nil {
+	order, err := newOrdering(opts.Order, "specs")
+	if err != nil {
 		return SpecList{}, err
-	} else {
-		op = op.Order(order)
 	}
+	op = op.Order(order.String())
 
 	response := SpecList{
 		Specs: make([]models.Spec, 0, opts.Size),
@@ -748,7 +1028,11 @@ func (c *Client) ListSpecRevisions(ctx context.Context, parent names.SpecRevisio
 
 	for {
 		var page []models.Spec
-		err := op.Offset(token.Offset).Find(&page).Error
+		query, err := token.query(op, order)
+		if err != nil {
+			return SpecList{}, status.Errorf(codes.InvalidArgument, "invalid page token %q: %s", opts.Token, err)
+		}
+		err = query.Find(&page).Error
 
 		if err != nil {
 			return SpecList{}, grpcErrorForDBError(ctx, errors.Wrapf(err, "find %#v", token))
@@ -761,12 +1045,13 @@ func (c *Client) ListSpecRevisions(ctx context.Context, parent names.SpecRevisio
 			if err != nil {
 				return SpecList{}, status.Error(codes.Internal, err.Error())
 			}
+			position := order.position(v.Key, m)
 
 			match, err := filter.Matches(m)
 			if err != nil {
 				return SpecList{}, err
 			} else if !match {
-				token.Offset++
+				token.advance(position)
 				continue
 			}
 
@@ -778,10 +1063,10 @@ func (c *Client) ListSpecRevisions(ctx context.Context, parent names.SpecRevisio
 				return response, nil
 			}
 
-			token.Offset++
+			token.advance(position)
 			response.Specs = append(response.Specs, v)
 		}
-		if op.RowsAffected < int64(opts.Size) {
+		if query.RowsAffected < int64(opts.Size) {
 			break
 		}
 	}
@@ -796,6 +1081,7 @@ type DeploymentList struct {
 }
 
 func (c *Client) ListDeployments(ctx context.Context, parent names.Api, opts PageOptions) (DeploymentList, error) {
+	c = c.withDeleted(opts.ShowDeleted)
 	token, err := decodeToken(opts.Token)
 	if err != nil {
 		return DeploymentList{}, status.Errorf(codes.InvalidArgument, "invalid page token %q: %s", opts.Token, err.Error())
@@ -823,19 +1109,19 @@ func (c *Client) ListDeployments(ctx context.Context, parent names.Api, opts Pag
 		}
 	}
 
-	filter, err := filtering.NewFilter(opts.Filter, deploymentFields)
+	filter, err := c.newListFilter(opts.Filter, "deployments")
 	if err != nil {
 		return DeploymentList{}, err
 	}
 
-	op := c.db.WithContext(ctx).Select("deployments.*").Table("deployments").
+	op := filter.where(c.db.WithContext(ctx).Select("deployments.*").Table("deployments")).
 		// select latest deployment revision
 		Joins(`join (?) latest
 		ON deployments.project_id = latest.project_id
 		AND deployments.api_id = latest.api_id
 		AND deployments.deployment_id = latest.deployment_id
 		AND deployments.revision_id = latest.revision_id`, c.latestDeploymentRevisionsQuery(ctx)).
-		Limit(limit(opts))
+		Limit(limit(opts, filter))
 
 	if parent.ProjectID != "-" {
 		op = op.Where("deployments.project_id = ?", parent.ProjectID)
@@ -844,11 +1130,11 @@ func (c *Client) ListDeployments(ctx context.Context, parent names.Api, opts Pag
 		op = op.Where("deployments.api_id = ?", parent.ApiID)
 	}
 
-	if order, err := gormOrdering(opts.Order, "deployments"); err != nil {
+	order, err := newOrdering(opts.Order, "deployments")
+	if err != nil {
 		return DeploymentList{}, err
-	} else {
-		op = op.Order(order)
 	}
+	op = op.Order(order.String())
 
 	response := DeploymentList{
 		Deployments: make([]models.Deployment, 0, opts.Size),
@@ -856,7 +1142,11 @@ func (c *Client) ListDeployments(ctx context.Context, parent names.Api, opts Pag
 
 	for {
 		var page []models.Deployment
-		err := op.Offset(token.Offset).Find(&page).Error
+		query, err := token.query(op, order)
+		if err != nil {
+			return DeploymentList{}, status.Errorf(codes.InvalidArgument, "invalid page token %q: %s", opts.Token, err)
+		}
+		err = query.Find(&page).Error
 
 		if err != nil {
 			return DeploymentList{}, grpcErrorForDBError(ctx, errors.Wrapf(err, "find %#v", token))
@@ -869,12 +1159,13 @@ func (c *Client) ListDeployments(ctx context.Context, parent names.Api, opts Pag
 			if err != nil {
 				return DeploymentList{}, status.Error(codes.Internal, err.Error())
 			}
+			position := order.position(v.Key, m)
 
 			match, err := filter.Matches(m)
 			if err != nil {
 				return DeploymentList{}, err
 			} else if !match {
-				token.Offset++
+				token.advance(position)
 				continue
 			}
 
@@ -886,10 +1177,10 @@ func (c *Client) ListDeployments(ctx context.Context, parent names.Api, opts Pag
 				return response, nil
 			}
 
-			token.Offset++
+			token.advance(position)
 			response.Deployments = append(response.Deployments, v)
 		}
-		if op.RowsAffected < int64(opts.Size) {
+		if query.RowsAffected < int64(opts.Size) {
 			break
 		}
 	}
@@ -935,6 +1226,12 @@ func (c *Client) ListDeploymentRevisions(ctx context.Context, parent names.Deplo
 		token.Filter = opts.Filter
 	}
 
+	if err := token.ValidateOrder(opts.Order); err != nil {
+		return DeploymentList{}, status.Errorf(codes.InvalidArgument, "invalid order_by %q: %s", opts.Order, err)
+	} else {
+		token.Order = opts.Order
+	}
+
 	// Check existence of the deepest fully specified resource in the parent name.
 	if parent.ProjectID != "-" && parent.ApiID != "-" && parent.DeploymentID != "-" && parent.RevisionID != "-" {
 		if _, err := c.GetDeploymentRevision(ctx, parent); err != nil {
@@ -954,13 +1251,12 @@ func (c *Client) ListDeploymentRevisions(ctx context.Context, parent names.Deplo
 		}
 	}
 
-	filter, err := filtering.NewFilter(opts.Filter, specFields)
+	filter, err := c.newListFilter(opts.Filter, "deployments")
 	if err != nil {
 		return DeploymentList{}, err
 	}
 
-	op := c.db.WithContext(ctx).
-		Offset(token.Offset).
+	op := filter.where(c.db.WithContext(ctx)).
 		Limit(int(opts.Size) + 1)
 
 	if id := parent.ProjectID; id != "-" {
@@ -976,11 +1272,11 @@ func (c *Client) ListDeploymentRevisions(ctx context.Context, parent names.Deplo
 		op = op.Where("deployments.revision_id = ?", id)
 	}
 
-	if order, err := gormOrdering(opts.Order, "deployments"); err != nil {
+	order, err := newOrdering(opts.Order, "deployments")
+	if err != nil {
 		return DeploymentList{}, err
-	} else {
-		op = op.Order(order)
 	}
+	op = op.Order(order.String())
 
 	response := DeploymentList{
 		Deployments: make([]models.Deployment, 0, opts.Size),
@@ -988,7 +1284,11 @@ func (c *Client) ListDeploymentRevisions(ctx context.Context, parent names.Deplo
 
 	for {
 		var page []models.Deployment
-		err := op.Offset(token.Offset).Find(&page).Error
+		query, err := token.query(op, order)
+		if err != nil {
+			return DeploymentList{}, status.Errorf(codes.InvalidArgument, "invalid page token %q: %s", opts.Token, err)
+		}
+		err = query.Find(&page).Error
 
 		if err != nil {
 			return DeploymentList{}, grpcErrorForDBError(ctx, errors.Wrapf(err, "find %#v", token))
@@ -1001,12 +1301,13 @@ func (c *Client) ListDeploymentRevisions(ctx context.Context, parent names.Deplo
 			if err != nil {
 				return DeploymentList{}, status.Error(codes.Internal, err.Error())
 			}
+			position := order.position(v.Key, m)
 
 			match, err := filter.Matches(m)
 			if err != nil {
 				return DeploymentList{}, err
 			} else if !match {
-				token.Offset++
+				token.advance(position)
 				continue
 			}
 
@@ -1018,10 +1319,10 @@ func (c *Client) ListDeploymentRevisions(ctx context.Context, parent names.Deplo
 				return response, nil
 			}
 
-			token.Offset++
+			token.advance(position)
 			response.Deployments = append(response.Deployments, v)
 		}
-		if op.RowsAffected < int64(opts.Size) {
+		if query.RowsAffected < int64(opts.Size) {
 			break
 		}
 	}
@@ -1040,6 +1341,7 @@ func (c *Client) ListSpecArtifacts(ctx context.Context, parent names.Spec, opts
 }
 
 func (c *Client) ListSpecRevisionArtifacts(ctx context.Context, parent names.SpecRevision, opts PageOptions) (ArtifactList, error) {
+	c = c.withDeleted(opts.ShowDeleted)
 	token, err := decodeToken(opts.Token)
 	if err != nil {
 		return ArtifactList{}, status.Errorf(codes.InvalidArgument, "invalid page token %q: %s", opts.Token, err.Error())
@@ -1102,7 +1404,7 @@ func (c *Client) ListSpecRevisionArtifacts(ctx context.Context, parent names.Spe
 	}
 	orderTable := "artifacts"
 	if id := parent.RevisionID; id == "" { // select latest spec revision
-		op = op.Select("artifacts.*,revision_create_time").Table("artifacts").
+		op = op.Select("artifacts.*,latest.revision_create_time,latest.revision_update_time").Table("artifacts").
 			Where(`artifacts.deployment_id = ''`).
 			Joins(`join (?) latest
 			ON artifacts.project_id = latest.project_id
@@ -1113,13 +1415,13 @@ func (c *Client) ListSpecRevisionArtifacts(ctx context.Context, parent names.Spe
 		orderTable = "revisioned_artifacts"
 	}
 
-	if order, err := gormOrdering(opts.Order, orderTable); err != nil {
+	order, err := newOrdering(opts.Order, orderTable)
+	if err != nil {
 		return ArtifactList{}, err
-	} else {
-		op = op.Order(order)
 	}
+	op = op.Order(order.String())
 
-	return c.listArtifacts(ctx, op, opts, func(a *models.Artifact) bool {
+	return c.listArtifacts(ctx, op, order, opts, func(a *models.Artifact) bool {
 		return a.ProjectID != "" && a.ApiID != "" && a.VersionID != "" && a.SpecID != "" && a.RevisionID != ""
 	})
 }
@@ -1137,11 +1439,12 @@ func (c *Client) latestSpecRevisionsQuery(ctx context.Context) *gorm.DB {
 		AND s.api_id = s2.api_id
 		AND s.version_id = s2.version_id
 		AND s.spec_id = s2.spec_id
-		AND s.revision_create_time < s2.revision_create_time`).
+		AND ` + supersedes("s2", "s")).
 		Where("s2.key IS NULL")
 }
 
 func (c *Client) ListVersionArtifacts(ctx context.Context, parent names.Version, opts PageOptions) (ArtifactList, error) {
+	c = c.withDeleted(opts.ShowDeleted)
 	token, err := decodeToken(opts.Token)
 	if err != nil {
 		return ArtifactList{}, status.Errorf(codes.InvalidArgument, "invalid page token %q: %s", opts.Token, err.Error())
@@ -1186,13 +1489,13 @@ func (c *Client) ListVersionArtifacts(ctx context.Context, parent names.Version,
 		op = op.Where("version_id = ?", id)
 	}
 
-	if order, err := gormOrdering(opts.Order, "artifacts"); err != nil {
+	order, err := newOrdering(opts.Order, "artifacts")
+	if err != nil {
 		return ArtifactList{}, err
-	} else {
-		op = op.Order(order)
 	}
+	op = op.Order(order.String())
 
-	return c.listArtifacts(ctx, op, opts, func(a *models.Artifact) bool {
+	return c.listArtifacts(ctx, op, order, opts, func(a *models.Artifact) bool {
 		return a.ProjectID != "" && a.ApiID != "" && a.VersionID != ""
 	})
 }
@@ -1202,6 +1505,7 @@ func (c *Client) ListDeploymentArtifacts(ctx context.Context, parent names.Deplo
 }
 
 func (c *Client) ListDeploymentRevisionArtifacts(ctx context.Context, parent names.DeploymentRevision, opts PageOptions) (ArtifactList, error) {
+	c = c.withDeleted(opts.ShowDeleted)
 	token, err := decodeToken(opts.Token)
 	if err != nil {
 		return ArtifactList{}, status.Errorf(codes.InvalidArgument, "invalid page token %q: %s", opts.Token, err.Error())
@@ -1257,7 +1561,7 @@ func (c *Client) ListDeploymentRevisionArtifacts(ctx context.Context, parent nam
 	}
 	orderTable := "artifacts"
 	if id := parent.RevisionID; id == "" { // select latest deployment revision
-		op = op.Select("artifacts.*,revision_create_time").Table("artifacts").
+		op = op.Select("artifacts.*,latest.revision_create_time,latest.revision_update_time").Table("artifacts").
 			Where(`artifacts.spec_id = ''`).
 			Joins(`join (?) latest
 			ON artifacts.project_id = latest.project_id
@@ -1267,13 +1571,13 @@ func (c *Client) ListDeploymentRevisionArtifacts(ctx context.Context, parent nam
 		orderTable = "revisioned_artifacts"
 	}
 
-	if order, err := gormOrdering(opts.Order, orderTable); err != nil {
+	order, err := newOrdering(opts.Order, orderTable)
+	if err != nil {
 		return ArtifactList{}, err
-	} else {
-		op = op.Order(order)
 	}
+	op = op.Order(order.String())
 
-	return c.listArtifacts(ctx, op, opts, func(a *models.Artifact) bool {
+	return c.listArtifacts(ctx, op, order, opts, func(a *models.Artifact) bool {
 		return a.ProjectID != "" && a.ApiID != "" && a.DeploymentID != "" && a.RevisionID != ""
 	})
 }
@@ -1290,11 +1594,24 @@ func (c *Client) latestDeploymentRevisionsQuery(ctx context.Context) *gorm.DB {
 		ON d.project_id = d2.project_id
 		AND d.api_id = d2.api_id
 		AND d.deployment_id = d2.deployment_id
-		AND d.revision_create_time < d2.revision_create_time`).
+		AND ` + supersedes("d2", "d")).
 		Where("d2.key IS NULL")
 }
 
+// supersedes returns a condition that holds when revision a replaces revision b
+// as the current revision of their resource. Live revisions replace deleted ones,
+// so the current revision of a live resource is its latest live revision and
+// the current revision of a deleted resource is the latest revision deleted with it.
+func supersedes(a, b string) string {
+	return fmt.Sprintf(`(%[1]s.deleted_at IS NULL
+		AND (%[2]s.deleted_at IS NOT NULL OR %[2]s.revision_create_time < %[1]s.revision_create_time)
+		OR %[1]s.deleted_at IS NOT NULL AND %[2]s.deleted_at IS NOT NULL
+		AND (%[2]s.deleted_at < %[1]s.deleted_at
+		OR %[2]s.deleted_at = %[1]s.deleted_at AND %[2]s.revision_create_time < %[1]s.revision_create_time))`, a, b)
+}
+
 func (c *Client) ListApiArtifacts(ctx context.Context, parent names.Api, opts PageOptions) (ArtifactList, error) {
+	c = c.withDeleted(opts.ShowDeleted)
 	token, err := decodeToken(opts.Token)
 	if err != nil {
 		return ArtifactList{}, status.Errorf(codes.InvalidArgument, "invalid page token %q: %s", opts.Token, err.Error())
@@ -1334,18 +1651,19 @@ func (c *Client) ListApiArtifacts(ctx context.Context, parent names.Api, opts Pa
 		op = op.Where("api_id = ?", id)
 	}
 
-	if order, err := gormOrdering(opts.Order, "artifacts"); err != nil {
+	order, err := newOrdering(opts.Order, "artifacts")
+	if err != nil {
 		return ArtifactList{}, err
-	} else {
-		op = op.Order(order)
 	}
+	op = op.Order(order.String())
 
-	return c.listArtifacts(ctx, op, opts, func(a *models.Artifact) bool {
+	return c.listArtifacts(ctx, op, order, opts, func(a *models.Artifact) bool {
 		return a.ProjectID != "" && a.ApiID != ""
 	})
 }
 
 func (c *Client) ListProjectArtifacts(ctx context.Context, parent names.Project, opts PageOptions) (ArtifactList, error) {
+	c = c.withDeleted(opts.ShowDeleted)
 	token, err := decodeToken(opts.Token)
 	if err != nil {
 		return ArtifactList{}, status.Errorf(codes.InvalidArgument, "invalid page token %q: %s", opts.Token, err.Error())
@@ -1376,18 +1694,25 @@ func (c *Client) ListProjectArtifacts(ctx context.Context, parent names.Project,
 		}
 	}
 
-	if order, err := gormOrdering(opts.Order, "artifacts"); err != nil {
+	order, err := newOrdering(opts.Order, "artifacts")
+	if err != nil {
 		return ArtifactList{}, err
-	} else {
-		op = op.Order(order)
 	}
+	op = op.Order(order.String())
 
-	return c.listArtifacts(ctx, op, opts, func(a *models.Artifact) bool {
+	return c.listArtifacts(ctx, op, order, opts, func(a *models.Artifact) bool {
 		return a.ProjectID != ""
 	})
 }
 
-func (c *Client) listArtifacts(ctx context.Context, op *gorm.DB, opts PageOptions, include func(*models.Artifact) bool) (ArtifactList, error) {
+// artifactRow is an artifact that may be joined with the revision of its parent.
+type artifactRow struct {
+	models.Artifact
+	RevisionCreateTime time.Time
+	RevisionUpdateTime time.Time
+}
+
+func (c *Client) listArtifacts(ctx context.Context, op *gorm.DB, order ordering, opts PageOptions, include func(*models.Artifact) bool) (ArtifactList, error) {
 	token, err := decodeToken(opts.Token)
 	if err != nil {
 		return ArtifactList{}, status.Errorf(codes.InvalidArgument, "invalid page token %q: %s", opts.Token, err.Error())
@@ -1395,19 +1720,23 @@ func (c *Client) listArtifacts(ctx context.Context, op *gorm.DB, opts PageOption
 		token.Filter = opts.Filter
 	}
 
-	filter, err := filtering.NewFilter(opts.Filter, artifactFields)
+	filter, err := c.newListFilter(opts.Filter, "artifacts")
 	if err != nil {
 		return ArtifactList{}, err
 	}
+	op = filter.where(op)
 
 	response := ArtifactList{
 		Artifacts: make([]models.Artifact, 0, opts.Size),
 	}
 
 	for {
-		var page []models.Artifact
-		op.Limit(limit(opts))
-		err := op.Offset(token.Offset).Find(&page).Error
+		var page []artifactRow
+		query, err := token.query(op.Table("artifacts").Limit(limit(opts, filter)), order)
+		if err != nil {
+			return ArtifactList{}, status.Errorf(codes.InvalidArgument, "invalid page token %q: %s", opts.Token, err)
+		}
+		err = query.Find(&page).Error
 
 		if err != nil {
 			return ArtifactList{}, grpcErrorForDBError(ctx, errors.Wrapf(err, "find %#v", token))
@@ -1416,12 +1745,19 @@ func (c *Client) listArtifacts(ctx context.Context, op *gorm.DB, opts PageOption
 		}
 
 		for _, v := range page {
-			m := artifactMap(v)
+			m, err := artifactMap(v.Artifact)
+			if err != nil {
+				return ArtifactList{}, status.Error(codes.Internal, err.Error())
+			}
+			m["revision_create_time"] = v.RevisionCreateTime
+			m["revision_update_time"] = v.RevisionUpdateTime
+			position := order.position(v.Key, m)
+
 			match, err := filter.Matches(m)
 			if err != nil {
 				return ArtifactList{}, err
-			} else if !match || !include(&v) {
-				token.Offset++
+			} else if !match || !include(&v.Artifact) {
+				token.advance(position)
 				continue
 			}
 
@@ -1433,10 +1769,10 @@ func (c *Client) listArtifacts(ctx context.Context, op *gorm.DB, opts PageOption
 				return response, nil
 			}
 
-			token.Offset++
-			response.Artifacts = append(response.Artifacts, v)
+			token.advance(position)
+			response.Artifacts = append(response.Artifacts, v.Artifact)
 		}
-		if op.RowsAffected < int64(opts.Size) {
+		if query.RowsAffected < int64(opts.Size) {
 			break
 		}
 	}
@@ -1444,17 +1780,124 @@ func (c *Client) listArtifacts(ctx context.Context, op *gorm.DB, opts PageOption
 	return response, nil
 }
 
-func artifactMap(artifact models.Artifact) map[string]interface{} {
-	return map[string]interface{}{
-		"name":        artifact.Name(),
-		"project_id":  artifact.ProjectID,
-		"api_id":      artifact.ApiID,
-		"version_id":  artifact.VersionID,
-		"spec_id":     artifact.SpecID,
-		"artifact_id": artifact.ArtifactID,
-		"create_time": artifact.CreateTime,
-		"update_time": artifact.UpdateTime,
-		"mime_type":   artifact.MimeType,
-		"size_bytes":  artifact.SizeInBytes,
+// ListArtifactRevisions lists the revisions of an artifact, starting with the current
+// revision. Names with a revision ID select a single revision.
+func (c *Client) ListArtifactRevisions(ctx context.Context, parent names.ArtifactRevision, opts PageOptions) (ArtifactList, error) {
+	token, err := decodeToken(opts.Token)
+	if err != nil {
+		return ArtifactList{}, status.Errorf(codes.InvalidArgument, "invalid page token %q: %s", opts.Token, err.Error())
+	}
+
+	if err := token.ValidateFilter(opts.Filter); err != nil {
+		return ArtifactList{}, status.Errorf(codes.InvalidArgument, "invalid filter %q: %s", opts.Filter, err)
+	} else {
+		token.Filter = opts.Filter
+	}
+
+	// The artifact must exist, and its name is completed with the revision of its parent.
+	artifact, err := c.GetArtifact(ctx, parent.Artifact(), false)
+	if err != nil {
+		return ArtifactList{}, err
+	}
+
+	filter, err := c.newListFilter(opts.Filter, "artifact_revisions")
+	if err != nil {
+		return ArtifactList{}, err
+	}
+
+	op := filter.where(c.db.WithContext(ctx)).
+		Limit(limit(opts, filter)).
+		Where("project_id = ?", artifact.ProjectID).
+		Where("api_id = ?", artifact.ApiID).
+		Where("version_id = ?", artifact.VersionID).
+		Where("spec_id = ?", artifact.SpecID).
+		Where("revision_id = ?", artifact.RevisionID).
+		Where("deployment_id = ?", artifact.DeploymentID).
+		Where("artifact_id = ?", artifact.ArtifactID)
+	if id := parent.RevisionID; id != "-" && id != "" { // select specific artifact revision
+		id, err := c.unwrapArtifactRevisionTag(ctx, artifact, id)
+		if err != nil {
+			return ArtifactList{}, err
+		}
+		op = op.Where("artifact_revision_id = ?", id)
+	}
+
+	order, err := newOrdering("", "artifact_revisions")
+	if err != nil {
+		return ArtifactList{}, err
+	}
+	op = op.Order(order.String())
+
+	response := ArtifactList{
+		Artifacts: make([]models.Artifact, 0, opts.Size),
+	}
+
+	for {
+		var page []models.ArtifactRevision
+		query, err := token.query(op, order)
+		if err != nil {
+			return ArtifactList{}, status.Errorf(codes.InvalidArgument, "invalid page token %q: %s", opts.Token, err)
+		}
+		err = query.Find(&page).Error
+
+		if err != nil {
+			return ArtifactList{}, grpcErrorForDBError(ctx, errors.Wrapf(err, "find %#v", token))
+		} else if len(page) == 0 {
+			break
+		}
+
+		for _, v := range page {
+			m, err := artifactMap(v.Artifact)
+			if err != nil {
+				return ArtifactList{}, status.Error(codes.Internal, err.Error())
+			}
+			position := order.position(v.Key, m)
+
+			match, err := filter.Matches(m)
+			if err != nil {
+				return ArtifactList{}, err
+			} else if !match {
+				token.advance(position)
+				continue
+			}
+
+			if len(response.Artifacts) == int(opts.Size) {
+				response.Token, err = encodeToken(token)
+				if err != nil {
+					return ArtifactList{}, status.Error(codes.Internal, err.Error())
+				}
+				return response, nil
+			}
+
+			token.advance(position)
+			response.Artifacts = append(response.Artifacts, v.Artifact)
+		}
+		if query.RowsAffected < int64(opts.Size) {
+			break
+		}
+	}
+
+	return response, nil
+}
+
+func artifactMap(artifact models.Artifact) (map[string]interface{}, error) {
+	labels, err := artifact.LabelsMap()
+	if err != nil {
+		return nil, err
 	}
+
+	return map[string]interface{}{
+		"name":          artifact.Name(),
+		"project_id":    artifact.ProjectID,
+		"api_id":        artifact.ApiID,
+		"version_id":    artifact.VersionID,
+		"spec_id":       artifact.SpecID,
+		"artifact_id":   artifact.ArtifactID,
+		"deployment_id": artifact.DeploymentID,
+		"create_time":   artifact.CreateTime,
+		"update_time":   artifact.UpdateTime,
+		"mime_type":     artifact.MimeType,
+		"size_bytes":    artifact.SizeInBytes,
+		"labels":        labels,
+	}, nil
 }
@@ -0,0 +1,366 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/storage/filtering"
+	"github.com/apigee/registry/server/registry/internal/storage/models"
+	"github.com/apigee/registry/server/registry/names"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestListFilters checks that filters evaluated by the database select the
+// same resources as filters evaluated in memory.
+func TestListFilters(t *testing.T) {
+	ctx := context.Background()
+	c, err := NewClient(ctx, "sqlite3", fmt.Sprintf("%s/registry.db", t.TempDir()))
+	if err != nil {
+		t.Fatalf("NewClient() returned error: %s", err)
+	}
+	t.Cleanup(c.Close)
+	if err := c.EnsureTables(ctx); err != nil {
+		t.Fatalf("EnsureTables() returned error: %s", err)
+	}
+
+	project := names.Project{ProjectID: "p"}
+	if err := c.CreateProject(ctx, models.NewProject(project, &rpc.Project{})); err != nil {
+		t.Fatalf("CreateProject() returned error: %s", err)
+	}
+	newYear := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	apis := []struct {
+		api        *rpc.Api
+		createTime time.Time
+	}{
+		{&rpc.Api{DisplayName: "Alpha", Labels: map[string]string{"env": "prod", "team": "a"}}, newYear},
+		{&rpc.Api{DisplayName: "100%_beta", Labels: map[string]string{"env": "dev"}}, newYear.Add(500 * time.Microsecond)},
+		{&rpc.Api{DisplayName: "Gamma_x", Labels: map[string]string{"team": `"b"`}}, newYear.In(time.FixedZone("EST", -5*60*60))},
+		{&rpc.Api{DisplayName: "délta"}, newYear.Add(-time.Millisecond).In(time.FixedZone("CET", 60*60))},
+		{&rpc.Api{DisplayName: "epsilon", Availability: "GA"}, newYear.AddDate(0, 6, 0).Local()},
+	}
+	for i, v := range apis {
+		api, err := models.NewApi(project.Api(fmt.Sprintf("a%d", i+1)), v.api)
+		if err != nil {
+			t.Fatalf("NewApi() returned error: %s", err)
+		}
+		api.CreateTime = v.createTime
+		if err := c.CreateApi(ctx, api); err != nil {
+			t.Fatalf("CreateApi() returned error: %s", err)
+		}
+	}
+	all, err := c.ListApis(ctx, project.Api("-").Project(), PageOptions{Size: 100})
+	if err != nil {
+		t.Fatalf("ListApis() returned error: %s", err)
+	}
+
+	tests := []struct {
+		filter string
+		exact  bool
+	}{
+		{`api_id == "a1"`, true},
+		{`api_id != "a1"`, true},
+		{`api_id > "a2" && api_id <= "a4"`, true},
+		{`name == "projects/p/locations/global/apis/a2"`, true},
+		{`api_id in ["a1", "a3", "z"]`, true},
+		{`!(api_id in ["a1", "a3"])`, true},
+		{`display_name.startsWith("Al")`, true},
+		{`display_name.contains("%_")`, true},
+		{`display_name.contains("")`, true},
+		{`display_name.endsWith("_x") || display_name.endsWith("lta")`, true},
+		{`display_name.endsWith("a long suffix")`, true},
+		{`display_name > "d"`, true},
+		{`has(labels.env)`, true},
+		{`"team" in labels`, true},
+		{`!has(labels.team)`, true},
+		{`has(labels.env) && labels.env == "prod"`, false},
+		{`has(labels.team) && labels.team.contains("\"")`, false},
+		{`has(labels.env) && !(labels.env != "dev")`, false},
+		{`create_time == timestamp("2021-01-01T00:00:00Z")`, false},
+		{`create_time != timestamp("2021-01-01T00:00:00Z")`, false},
+		{`create_time > timestamp("2021-01-01T00:00:00Z")`, false},
+		{`create_time >= timestamp("2021-01-01T00:00:00Z")`, false},
+		{`create_time < timestamp("2021-01-01T00:00:00.0005Z")`, false},
+		{`!(create_time <= timestamp("2020-12-31T23:59:59.999Z"))`, false},
+		{`create_time < update_time`, false},
+		{`display_name.lowerAscii() == "alpha"`, false},
+		{`availability == "GA" || display_name.lowerAscii() == "alpha"`, false},
+		{`!(availability == "GA" || display_name.lowerAscii() == "alpha")`, false},
+		{`size(display_name) > 5 && api_id != "a5"`, false},
+	}
+	for _, test := range tests {
+		t.Run(test.filter, func(t *testing.T) {
+			filter, err := filtering.NewFilter(test.filter, apiFields)
+			if err != nil {
+				t.Fatalf("NewFilter() returned error: %s", err)
+			}
+			var want []string
+			for _, v := range all.Apis {
+				m, err := apiMap(v)
+				if err != nil {
+					t.Fatalf("apiMap() returned error: %s", err)
+				}
+				if match, err := filter.Matches(m); err != nil {
+					t.Fatalf("Matches() returned error: %s", err)
+				} else if match {
+					want = append(want, v.ApiID)
+				}
+			}
+
+			f, err := c.newListFilter(test.filter, "apis")
+			if err != nil {
+				t.Fatalf("newListFilter() returned error: %s", err)
+			}
+			if f.exact != test.exact {
+				t.Errorf("newListFilter() translated the filter to %q, exact %t, want %t", f.cond, f.exact, test.exact)
+			}
+
+			// Pages of one resource check that page tokens skip the rows that the database selects.
+			var got []string
+			opts := PageOptions{Size: 1, Filter: test.filter}
+			for {
+				list, err := c.ListApis(ctx, project, opts)
+				if err != nil {
+					t.Fatalf("ListApis() returned error: %s", err)
+				}
+				for _, v := range list.Apis {
+					got = append(got, v.ApiID)
+				}
+				if list.Token == "" {
+					break
+				}
+				opts.Token = list.Token
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("ListApis() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	// Filters fail for resources that don't have the labels that they compare.
+	if _, err := c.ListApis(ctx, project, PageOptions{Size: 100, Filter: `labels.env == "prod"`}); err == nil {
+		t.Errorf("ListApis() with a filter of missing labels succeeded, want error")
+	}
+}
+
+// TestMapsMigratedToJSON checks that labels serialized before maps were
+// stored as JSON are converted so that they can be filtered.
+func TestMapsMigratedToJSON(t *testing.T) {
+	ctx := context.Background()
+	c, err := NewClient(ctx, "sqlite3", fmt.Sprintf("%s/registry.db", t.TempDir()))
+	if err != nil {
+		t.Fatalf("NewClient() returned error: %s", err)
+	}
+	t.Cleanup(c.Close)
+	if err := c.EnsureTables(ctx); err != nil {
+		t.Fatalf("EnsureTables() returned error: %s", err)
+	}
+
+	project := names.Project{ProjectID: "p"}
+	if err := c.CreateProject(ctx, models.NewProject(project, &rpc.Project{})); err != nil {
+		t.Fatalf("CreateProject() returned error: %s", err)
+	}
+	api, err := models.NewApi(project.Api("a"), &rpc.Api{})
+	if err != nil {
+		t.Fatalf("NewApi() returned error: %s", err)
+	}
+	api.Labels, err = proto.Marshal(&rpc.Map{Entries: map[string]string{"env": "prod"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.CreateApi(ctx, api); err != nil {
+		t.Fatalf("CreateApi() returned error: %s", err)
+	}
+
+	// The conversion is applied again when the database is migrated from the version before it.
+	version := 0
+	for i, m := range migrations {
+		if m.description == "store labels and annotations as JSON" {
+			version = i
+		}
+	}
+	if _, err := c.Migrate(ctx, MigrateOptions{Version: version}); err != nil {
+		t.Fatalf("Migrate(%d) returned error: %s", version, err)
+	}
+	if err := c.EnsureTables(ctx); err != nil {
+		t.Fatalf("EnsureTables() returned error: %s", err)
+	}
+	list, err := c.ListApis(ctx, project, PageOptions{Size: 10, Filter: `"env" in labels`})
+	if err != nil {
+		t.Fatalf("ListApis() returned error: %s", err)
+	}
+	if len(list.Apis) != 1 {
+		t.Fatalf("ListApis() returned %d apis, want 1", len(list.Apis))
+	}
+	labels, err := list.Apis[0].LabelsMap()
+	if err != nil {
+		t.Fatalf("LabelsMap() returned error: %s", err)
+	}
+	if diff := cmp.Diff(map[string]string{"env": "prod"}, labels); diff != "" {
+		t.Errorf("LabelsMap() returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+// TestPagination checks that pages continue after the position of the last
+// resource that was listed, even if resources are created before it.
+func TestPagination(t *testing.T) {
+	ctx := context.Background()
+	c, err := NewClient(ctx, "sqlite3", fmt.Sprintf("%s/registry.db", t.TempDir()))
+	if err != nil {
+		t.Fatalf("NewClient() returned error: %s", err)
+	}
+	t.Cleanup(c.Close)
+	if err := c.EnsureTables(ctx); err != nil {
+		t.Fatalf("EnsureTables() returned error: %s", err)
+	}
+
+	project := names.Project{ProjectID: "p"}
+	if err := c.CreateProject(ctx, models.NewProject(project, &rpc.Project{})); err != nil {
+		t.Fatalf("CreateProject() returned error: %s", err)
+	}
+	createApi := func(id, displayName string) {
+		t.Helper()
+		api, err := models.NewApi(project.Api(id), &rpc.Api{DisplayName: displayName})
+		if err != nil {
+			t.Fatalf("NewApi() returned error: %s", err)
+		}
+		if err := c.CreateApi(ctx, api); err != nil {
+			t.Fatalf("CreateApi() returned error: %s", err)
+		}
+	}
+	for _, id := range []string{"b", "d", "f", "h"} {
+		createApi(id, "same")
+	}
+	listIDs := func(opts PageOptions) ([]string, string) {
+		t.Helper()
+		list, err := c.ListApis(ctx, project, opts)
+		if err != nil {
+			t.Fatalf("ListApis(%+v) returned error: %s", opts, err)
+		}
+		ids := make([]string, 0, len(list.Apis))
+		for _, v := range list.Apis {
+			ids = append(ids, v.ApiID)
+		}
+		return ids, list.Token
+	}
+
+	tests := []struct {
+		desc   string
+		order  string
+		create []string
+		want   []string
+	}{
+		{
+			desc:   "default order",
+			create: []string{"a", "e"},
+			want:   []string{"b", "d", "e", "f", "h"},
+		},
+		{
+			desc:   "descending order",
+			order:  "api_id desc",
+			create: []string{"g", "c"},
+			want:   []string{"h", "f", "e", "d", "c", "b", "a"},
+		},
+		{
+			desc:   "ties broken by name",
+			order:  "display_name",
+			create: []string{"a0"},
+			want:   []string{"a", "b", "c", "d", "e", "f", "g", "h"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			opts := PageOptions{Size: 2, Order: test.order}
+			got, token := listIDs(opts)
+			for _, id := range test.create {
+				createApi(id, "same")
+			}
+			for token != "" {
+				opts.Token = token
+				var page []string
+				page, token = listIDs(opts)
+				got = append(got, page...)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("ListApis() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	// Tokens that were issued before pages were positioned skip a number of resources.
+	legacy, err := encodeToken(token{Offset: 6})
+	if err != nil {
+		t.Fatalf("encodeToken() returned error: %s", err)
+	}
+	got, next := listIDs(PageOptions{Size: 2, Token: legacy})
+	if diff := cmp.Diff([]string{"f", "g"}, got); diff != "" {
+		t.Errorf("ListApis() with an offset token returned unexpected diff (-want +got):\n%s", diff)
+	}
+	got, _ = listIDs(PageOptions{Size: 2, Token: next})
+	if diff := cmp.Diff([]string{"h"}, got); diff != "" {
+		t.Errorf("ListApis() after an offset token returned unexpected diff (-want +got):\n%s", diff)
+	}
+
+	// Positions of artifacts include the revisions of their parents.
+	version, err := models.NewVersion(project.Api("a").Version("v"), &rpc.ApiVersion{})
+	if err != nil {
+		t.Fatalf("NewVersion() returned error: %s", err)
+	}
+	if err := c.CreateVersion(ctx, version); err != nil {
+		t.Fatalf("CreateVersion() returned error: %s", err)
+	}
+	specName := project.Api("a").Version("v").Spec("s")
+	spec, err := models.NewSpec(specName, &rpc.ApiSpec{})
+	if err != nil {
+		t.Fatalf("NewSpec() returned error: %s", err)
+	}
+	if err := c.CreateSpecRevision(ctx, spec); err != nil {
+		t.Fatalf("CreateSpecRevision() returned error: %s", err)
+	}
+	want := []string{"x", "y", "z"}
+	for _, id := range want {
+		artifact, err := models.NewArtifact(specName.Artifact(id), &rpc.Artifact{})
+		if err != nil {
+			t.Fatalf("NewArtifact() returned error: %s", err)
+		}
+		artifact.RevisionID = spec.RevisionID
+		if err := c.CreateArtifact(ctx, artifact); err != nil {
+			t.Fatalf("CreateArtifact() returned error: %s", err)
+		}
+	}
+	var artifacts []string
+	opts := PageOptions{Size: 1}
+	for {
+		list, err := c.ListSpecArtifacts(ctx, specName, opts)
+		if err != nil {
+			t.Fatalf("ListSpecArtifacts() returned error: %s", err)
+		}
+		for _, v := range list.Artifacts {
+			artifacts = append(artifacts, v.ArtifactID)
+		}
+		if list.Token == "" {
+			break
+		}
+		opts.Token = list.Token
+	}
+	if diff := cmp.Diff(want, artifacts); diff != "" {
+		t.Errorf("ListSpecArtifacts() returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
@@ -16,40 +16,81 @@ package storage
 
 import (
 	"context"
-	"fmt"
+	"hash/fnv"
+	"strings"
 
-	_ "github.com/GoogleCloudPlatform/cloudsql-proxy/proxy/dialers/postgres"
+	"github.com/apigee/registry/server/registry/names"
 )
 
-func (c *Client) lockTable(ctx context.Context, name string) *Client {
-	// The LOCK TABLE statement below is unavailable in SQLite.
-	// For SQLite, we instead lock all mutating transactions. See RegistryServer.runInTransaction().
+// Changes to resources are serialized with transaction-scoped advisory locks
+// keyed by resource name. A change takes an exclusive lock on the resource and
+// shared locks on its ancestors, so changes to unrelated resources proceed
+// concurrently while a resource cannot be deleted during a change to one of its
+// children. Locks are released when the transaction commits or rolls back.
+
+func (c *Client) lockResource(ctx context.Context, name string) *Client {
+	// Advisory locks are unavailable in SQLite, where writes are serialized
+	// because they are made with a single writer connection.
 	if c.DatabaseName(ctx) == "sqlite" {
 		return c
 	}
-	return &Client{db: c.db.Exec(fmt.Sprintf("LOCK TABLE %s IN ACCESS EXCLUSIVE MODE", name))}
+	keys := lockKeys(name)
+	for i, key := range keys {
+		// Locks are always taken from the top of the hierarchy down to avoid deadlocks.
+		query := "SELECT pg_advisory_xact_lock_shared(?)"
+		if i == len(keys)-1 {
+			query = "SELECT pg_advisory_xact_lock(?)"
+		}
+		if op := c.db.WithContext(ctx).Exec(query, key); op.Error != nil {
+			return &Client{db: op, blobs: c.blobs}
+		}
+	}
+	return c
+}
+
+// lockKeys returns the advisory lock keys of a resource and its ancestors,
+// starting with its project. Revisions share the keys of their specs and deployments.
+func lockKeys(name string) []int64 {
+	parts := strings.Split(name, "/")
+	keys := make([]int64, 0, len(parts)/2)
+	for i := 1; i < len(parts); i += 2 {
+		parts[i] = strings.SplitN(parts[i], "@", 2)[0]
+		if parts[i-1] == "locations" {
+			continue
+		}
+		h := fnv.New64a()
+		h.Write([]byte(strings.Join(parts[:i+1], "/")))
+		keys = append(keys, int64(h.Sum64()))
+	}
+	return keys
+}
+
+func (c *Client) LockProject(ctx context.Context, name names.Project) *Client {
+	return c.lockResource(ctx, name.String())
 }
 
-func (c *Client) LockProjects(ctx context.Context) *Client {
-	return c.lockTable(ctx, "projects")
+func (c *Client) LockApi(ctx context.Context, name names.Api) *Client {
+	return c.lockResource(ctx, name.String())
 }
 
-func (c *Client) LockApis(ctx context.Context) *Client {
-	return c.lockTable(ctx, "apis")
+func (c *Client) LockVersion(ctx context.Context, name names.Version) *Client {
+	return c.lockResource(ctx, name.String())
 }
 
-func (c *Client) LockVersions(ctx context.Context) *Client {
-	return c.lockTable(ctx, "versions")
+func (c *Client) LockDeployment(ctx context.Context, name names.Deployment) *Client {
+	return c.lockResource(ctx, name.String())
 }
 
-func (c *Client) LockDeployments(ctx context.Context) *Client {
-	return c.lockTable(ctx, "deployments")
+func (c *Client) LockSpec(ctx context.Context, name names.Spec) *Client {
+	return c.lockResource(ctx, name.String())
 }
 
-func (c *Client) LockSpecs(ctx context.Context) *Client {
-	return c.lockTable(ctx, "specs")
+func (c *Client) LockArtifact(ctx context.Context, name names.Artifact) *Client {
+	return c.lockResource(ctx, name.String())
 }
 
-func (c *Client) LockArtifacts(ctx context.Context) *Client {
-	return c.lockTable(ctx, "artifacts")
+// lockContents locks shared contents stored with a key,
+// so that they aren't removed while a blob that refers to them is saved.
+func (c *Client) lockContents(ctx context.Context, key string) error {
+	return c.lockResource(ctx, "blobs/"+key).db.Error
 }
@@ -0,0 +1,62 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"testing"
+)
+
+func TestLockKeys(t *testing.T) {
+	const (
+		project  = "projects/p"
+		api      = project + "/locations/global/apis/a"
+		version  = api + "/versions/v"
+		spec     = version + "/specs/s"
+		artifact = spec + "@r1/artifacts/x"
+	)
+	key := func(name string) int64 {
+		keys := lockKeys(name)
+		return keys[len(keys)-1]
+	}
+
+	tests := []struct {
+		name string
+		want []int64
+	}{
+		{project, []int64{key(project)}},
+		{api, []int64{key(project), key(api)}},
+		{spec, []int64{key(project), key(api), key(version), key(spec)}},
+		{spec + "@r1", []int64{key(project), key(api), key(version), key(spec)}},
+		{artifact, []int64{key(project), key(api), key(version), key(spec), key(spec + "/artifacts/x")}},
+		{project + "/locations/global/artifacts/x", []int64{key(project), key(project + "/locations/global/artifacts/x")}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := lockKeys(test.name)
+			if len(got) != len(test.want) {
+				t.Fatalf("lockKeys(%q) returned %d keys, want %d", test.name, len(got), len(test.want))
+			}
+			for i := range got {
+				if got[i] != test.want[i] {
+					t.Errorf("lockKeys(%q)[%d] = %d, want %d", test.name, i, got[i], test.want[i])
+				}
+			}
+		})
+	}
+
+	if key(api) == key(project+"/locations/global/apis/b") {
+		t.Errorf("lockKeys returned the same key for different apis")
+	}
+}
@@ -0,0 +1,337 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/apigee/registry/server/registry/internal/storage/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// migration is a numbered change to the database schema.
+type migration struct {
+	description string
+	up          func(ctx context.Context, c *Client) error
+	// down reverts the migration. It is nil for migrations that only change
+	// data in ways that earlier schema versions can read.
+	down func(ctx context.Context, c *Client) error
+}
+
+// migrations lists the schema migrations in the order that they are applied.
+// The version of a migration is its position in the list, starting at 1, so
+// migrations must not be removed or reordered. New migrations go at the end.
+var migrations = []migration{
+	{
+		// Tables that already exist, such as those of databases created before
+		// migrations were versioned, are changed by later migrations.
+		description: "create tables",
+		up: func(ctx context.Context, c *Client) error {
+			for _, entity := range entities {
+				if err := c.ensureTable(ctx, entity); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		down: func(ctx context.Context, c *Client) error {
+			// Tables are dropped in reverse order so that children go before their parents.
+			for i := len(entities) - 1; i >= 0; i-- {
+				if err := c.db.WithContext(ctx).Migrator().DropTable(entities[i]); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		description: "add deletion times",
+		up: func(ctx context.Context, c *Client) error {
+			return c.migrateDeletionTimes(ctx)
+		},
+		down: func(ctx context.Context, c *Client) error {
+			return c.dropDeletionTimes(ctx)
+		},
+	},
+	{
+		description: "set parent keys",
+		up: func(ctx context.Context, c *Client) error {
+			return c.ensureForeignKeys(ctx)
+		},
+	},
+	{
+		description: "link spec artifacts to revisions",
+		up: func(ctx context.Context, c *Client) error {
+			return c.migrateArtifactsToRevisions(ctx)
+		},
+	},
+	{
+		description: "store labels and annotations as JSON",
+		up: func(ctx context.Context, c *Client) error {
+			return c.migrateMapsToJSON(ctx)
+		},
+	},
+	{
+		description: "create archives table",
+		up: func(ctx context.Context, c *Client) error {
+			return c.ensureTable(ctx, &models.Archive{})
+		},
+		down: func(ctx context.Context, c *Client) error {
+			return c.db.WithContext(ctx).Migrator().DropTable(&models.Archive{})
+		},
+	},
+	{
+		description: "create shared contents table",
+		up: func(ctx context.Context, c *Client) error {
+			if !c.db.Migrator().HasColumn(&models.Blob{}, "Location") {
+				if err := c.db.WithContext(ctx).Migrator().AddColumn(&models.Blob{}, "Location"); err != nil {
+					return err
+				}
+			}
+			return c.ensureTable(ctx, &models.SharedContents{})
+		},
+		down: func(ctx context.Context, c *Client) error {
+			// Shared contents are copied back to the blobs that use them before the table is dropped.
+			if err := c.unshareContents(ctx); err != nil {
+				return err
+			}
+			return c.db.WithContext(ctx).Migrator().DropTable(&models.SharedContents{})
+		},
+	},
+	{
+		description: "create artifact revisions",
+		up: func(ctx context.Context, c *Client) error {
+			return c.migrateArtifactRevisions(ctx)
+		},
+		down: func(ctx context.Context, c *Client) error {
+			for _, model := range []interface{}{&models.ArtifactRevisionTag{}, &models.ArtifactRevision{}} {
+				if err := c.db.WithContext(ctx).Migrator().DropTable(model); err != nil {
+					return err
+				}
+			}
+			return c.db.WithContext(ctx).Migrator().DropColumn(&models.Artifact{}, "ArtifactRevisionID")
+		},
+	},
+	{
+		description: "index resource references",
+		up: func(ctx context.Context, c *Client) error {
+			return c.migrateResourceReferences(ctx)
+		},
+		down: func(ctx context.Context, c *Client) error {
+			return c.db.WithContext(ctx).Migrator().DropTable(&models.ResourceReference{})
+		},
+	},
+	{
+		description: "create search index",
+		up: func(ctx context.Context, c *Client) error {
+			return c.migrateSearchIndex(ctx)
+		},
+		down: func(ctx context.Context, c *Client) error {
+			return c.dropSearchIndex(ctx)
+		},
+	},
+	{
+		description: "index spec elements",
+		up: func(ctx context.Context, c *Client) error {
+			return c.migrateSpecElements(ctx)
+		},
+		down: func(ctx context.Context, c *Client) error {
+			return c.db.WithContext(ctx).Migrator().DropTable(&models.SpecElement{})
+		},
+	},
+	{
+		description: "track unused contents",
+		up: func(ctx context.Context, c *Client) error {
+			return c.migrateUnusedContents(ctx)
+		},
+		down: func(ctx context.Context, c *Client) error {
+			return c.dropUnusedContents(ctx)
+		},
+	},
+}
+
+// LatestSchemaVersion returns the version of the current database schema.
+func LatestSchemaVersion() int {
+	return len(migrations)
+}
+
+// Migration describes a step of a schema migration.
+type Migration struct {
+	Version     int      // Version of the migration that the step applies or reverts.
+	Description string   // Description of the migration.
+	Revert      bool     // True if the step reverts the migration.
+	Statements  []string // Statements that changed the database, or in a dry run, that would change it.
+}
+
+// MigrateOptions configure a schema migration.
+type MigrateOptions struct {
+	// Version is the schema version to migrate to. Migrations with later
+	// versions are reverted, and version 0 reverts all migrations.
+	Version int
+	// DryRun, if true, reports the statements of the migration without changing the database.
+	DryRun bool
+	// Progress, if set, is called after each step of the migration.
+	Progress func(step Migration, completed, total int)
+}
+
+// SchemaVersion returns the version of the last migration applied to the database.
+func (c *Client) SchemaVersion(ctx context.Context) (int, error) {
+	if !c.db.WithContext(ctx).Migrator().HasTable(&models.SchemaMigration{}) {
+		return 0, nil
+	}
+	var version int
+	err := c.db.WithContext(ctx).Model(&models.SchemaMigration{}).
+		Select("COALESCE(MAX(version), 0)").Scan(&version).Error
+	return version, grpcErrorForDBError(ctx, err)
+}
+
+// errDryRun rolls back the transaction of a dry run.
+var errDryRun = errors.New("dry run")
+
+// Migrate migrates the database to a schema version. Each step runs in its
+// own transaction, so a failed migration leaves the database at the version
+// of the last completed step. A dry run runs all steps in one transaction
+// that is rolled back. Migrate returns the steps that completed.
+func (c *Client) Migrate(ctx context.Context, opts MigrateOptions) ([]Migration, error) {
+	if opts.Version < 0 || opts.Version > LatestSchemaVersion() {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown schema version %d, the latest is %d", opts.Version, LatestSchemaVersion())
+	}
+	current, err := c.SchemaVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if current > LatestSchemaVersion() {
+		return nil, status.Errorf(codes.FailedPrecondition, "database schema version %d is newer than this server, which supports versions up to %d", current, LatestSchemaVersion())
+	}
+	steps := migrationSteps(current, opts.Version)
+	progress := func(i int) {
+		if opts.Progress != nil {
+			opts.Progress(steps[i], i+1, len(steps))
+		}
+	}
+
+	if opts.DryRun {
+		err := c.db.Transaction(func(tx *gorm.DB) error {
+			dry := &Client{db: tx, blobs: c.blobs}
+			for i := range steps {
+				if err := dry.runMigration(ctx, &steps[i]); err != nil {
+					return err
+				}
+			}
+			return errDryRun
+		})
+		if err != errDryRun {
+			return nil, grpcErrorForDBError(ctx, err)
+		}
+		// Progress is reported after the transaction because SQLite
+		// databases can't be changed by others while it is open.
+		for i := range steps {
+			progress(i)
+		}
+		return steps, nil
+	}
+
+	for i := range steps {
+		if err := ctx.Err(); err != nil {
+			return steps[:i], status.FromContextError(err).Err()
+		}
+		if err := c.Transaction(ctx, func(ctx context.Context, tx *Client) error {
+			return tx.runMigration(ctx, &steps[i])
+		}); err != nil {
+			return steps[:i], err
+		}
+		progress(i)
+	}
+	return steps, nil
+}
+
+// migrationSteps returns the steps that migrate a database from one schema version to another.
+func migrationSteps(from, to int) []Migration {
+	var steps []Migration
+	for v := from + 1; v <= to; v++ {
+		steps = append(steps, Migration{Version: v, Description: migrations[v-1].description})
+	}
+	for v := from; v > to; v-- {
+		steps = append(steps, Migration{Version: v, Description: migrations[v-1].description, Revert: true})
+	}
+	return steps
+}
+
+// runMigration applies or reverts a migration, records the statements that it
+// runs in the step, and records the resulting version in the schema_migrations table.
+func (c *Client) runMigration(ctx context.Context, step *Migration) error {
+	if err := c.ensureTable(ctx, &models.SchemaMigration{}); err != nil {
+		return err
+	}
+
+	m := migrations[step.Version-1]
+	recorder := &Client{
+		db: c.db.Session(&gorm.Session{
+			Logger: statementRecorder{Interface: c.db.Logger, statements: &step.Statements},
+		}),
+		blobs: c.blobs,
+	}
+	if !step.Revert {
+		if err := m.up(ctx, recorder); err != nil {
+			return err
+		}
+		return c.db.WithContext(ctx).Create(&models.SchemaMigration{
+			Version:     step.Version,
+			Description: m.description,
+			ApplyTime:   time.Now(),
+		}).Error
+	}
+
+	if m.down != nil {
+		if err := m.down(ctx, recorder); err != nil {
+			return err
+		}
+	}
+	return c.db.WithContext(ctx).Where("version = ?", step.Version).Delete(&models.SchemaMigration{}).Error
+}
+
+// statementRecorder is a logger that also records the statements that change a database.
+type statementRecorder struct {
+	logger.Interface
+	statements *[]string
+}
+
+// changeKeywords begin statements that change a database.
+var changeKeywords = []string{"CREATE", "ALTER", "DROP", "INSERT", "UPDATE", "DELETE"}
+
+func (r statementRecorder) LogMode(level logger.LogLevel) logger.Interface {
+	return statementRecorder{Interface: r.Interface.LogMode(level), statements: r.statements}
+}
+
+func (r statementRecorder) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	r.Interface.Trace(ctx, begin, fc, err)
+	if err != nil {
+		return
+	}
+	sql, _ := fc()
+	keyword := strings.ToUpper(strings.SplitN(strings.TrimSpace(sql), " ", 2)[0])
+	for _, k := range changeKeywords {
+		if keyword == k {
+			*r.statements = append(*r.statements, sql)
+			return
+		}
+	}
+}
@@ -0,0 +1,591 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/storage/models"
+	"github.com/apigee/registry/server/registry/names"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"gorm.io/gorm"
+)
+
+func TestMigrate(t *testing.T) {
+	ctx := context.Background()
+	c, err := NewClient(ctx, "sqlite3", "file::memory:")
+	if err != nil {
+		t.Fatalf("NewClient() returned error: %s", err)
+	}
+	t.Cleanup(c.Close)
+	if err := c.EnsureTables(ctx); err != nil {
+		t.Fatalf("EnsureTables() returned error: %s", err)
+	}
+
+	version := func() int {
+		t.Helper()
+		v, err := c.SchemaVersion(ctx)
+		if err != nil {
+			t.Fatalf("SchemaVersion() returned error: %s", err)
+		}
+		return v
+	}
+	contains := func(statements []string, prefix string) bool {
+		for _, s := range statements {
+			if strings.HasPrefix(s, prefix) {
+				return true
+			}
+		}
+		return false
+	}
+	if v := version(); v != LatestSchemaVersion() {
+		t.Fatalf("SchemaVersion() of a new database returned %d, want %d", v, LatestSchemaVersion())
+	}
+	if err := c.db.Create(&models.Project{Key: "projects/p", ProjectID: "p"}).Error; err != nil {
+		t.Fatalf("Create() returned error: %s", err)
+	}
+
+	// A dry run reports statements without running them.
+	steps, err := c.Migrate(ctx, MigrateOptions{Version: 0, DryRun: true})
+	if err != nil {
+		t.Fatalf("Migrate(dry run to 0) returned error: %s", err)
+	}
+	if len(steps) != LatestSchemaVersion() {
+		t.Fatalf("Migrate(dry run to 0) returned %d steps, want %d", len(steps), LatestSchemaVersion())
+	}
+	if last := steps[len(steps)-1]; last.Version != 1 || !last.Revert || !contains(last.Statements, "DROP TABLE") {
+		t.Errorf("Migrate(dry run to 0) returned unexpected last step %+v", last)
+	}
+	if v := version(); v != LatestSchemaVersion() {
+		t.Errorf("SchemaVersion() after a dry run returned %d, want %d", v, LatestSchemaVersion())
+	}
+	if !c.db.Migrator().HasTable(&models.Project{}) {
+		t.Errorf("dry run dropped the projects table")
+	}
+
+	// Reverting all migrations drops the tables.
+	if _, err := c.Migrate(ctx, MigrateOptions{Version: 0}); err != nil {
+		t.Fatalf("Migrate(0) returned error: %s", err)
+	}
+	if v := version(); v != 0 {
+		t.Errorf("SchemaVersion() after reverting all migrations returned %d, want 0", v)
+	}
+	if c.db.Migrator().HasTable(&models.Project{}) {
+		t.Errorf("reverting all migrations kept the projects table")
+	}
+
+	// Migrations can be applied one version at a time.
+	var completed []int
+	steps, err = c.Migrate(ctx, MigrateOptions{
+		Version: 1,
+		Progress: func(step Migration, n, total int) {
+			completed = append(completed, step.Version)
+		},
+	})
+	if err != nil {
+		t.Fatalf("Migrate(1) returned error: %s", err)
+	}
+	if len(steps) != 1 || !contains(steps[0].Statements, "CREATE TABLE") {
+		t.Errorf("Migrate(1) returned unexpected steps %+v", steps)
+	}
+	if len(completed) != 1 || completed[0] != 1 {
+		t.Errorf("Migrate(1) reported progress of versions %v, want [1]", completed)
+	}
+	if v := version(); v != 1 {
+		t.Errorf("SchemaVersion() after Migrate(1) returned %d, want 1", v)
+	}
+	if _, err := c.Migrate(ctx, MigrateOptions{Version: LatestSchemaVersion()}); err != nil {
+		t.Fatalf("Migrate(%d) returned error: %s", LatestSchemaVersion(), err)
+	}
+	if v := version(); v != LatestSchemaVersion() {
+		t.Errorf("SchemaVersion() after Migrate(%d) returned %d", LatestSchemaVersion(), v)
+	}
+
+	// Migrating to the current version does nothing.
+	steps, err = c.Migrate(ctx, MigrateOptions{Version: LatestSchemaVersion()})
+	if err != nil || len(steps) != 0 {
+		t.Errorf("Migrate(%d) at the latest version returned %+v, %v, want no steps", LatestSchemaVersion(), steps, err)
+	}
+
+	for _, v := range []int{-1, LatestSchemaVersion() + 1} {
+		if _, err := c.Migrate(ctx, MigrateOptions{Version: v}); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Migrate(%d) returned status code %q, want %q: %v", v, status.Code(err), codes.InvalidArgument, err)
+		}
+	}
+}
+
+// schemaOf returns the SQL that defines the tables and indexes of a SQLite database.
+func schemaOf(ctx context.Context, t *testing.T, c *Client) map[string]string {
+	t.Helper()
+	var rows []struct {
+		Name string
+		SQL  string
+	}
+	if err := c.db.WithContext(ctx).Raw("SELECT name, sql FROM sqlite_master WHERE sql IS NOT NULL").Scan(&rows).Error; err != nil {
+		t.Fatalf("failed to read schema: %s", err)
+	}
+	schema := make(map[string]string, len(rows))
+	for _, r := range rows {
+		schema[r.Name] = r.SQL
+	}
+	return schema
+}
+
+func TestMigrateUnversionedDatabase(t *testing.T) {
+	ctx := context.Background()
+	c, err := NewClient(ctx, "sqlite3", "file::memory:")
+	if err != nil {
+		t.Fatalf("NewClient() returned error: %s", err)
+	}
+	t.Cleanup(c.Close)
+	// Databases created before schema versions were recorded have tables but no versions.
+	b, err := os.ReadFile(filepath.Join("testdata", "unversioned.sql"))
+	if err != nil {
+		t.Fatalf("Setup: failed to read schema: %s", err)
+	}
+	for _, statement := range strings.Split(string(b), ";\n") {
+		if strings.TrimSpace(statement) == "" {
+			continue
+		}
+		if err := c.db.WithContext(ctx).Exec(statement).Error; err != nil {
+			t.Fatalf("Setup: failed to run %q: %s", statement, err)
+		}
+	}
+
+	if err := c.EnsureTables(ctx); err != nil {
+		t.Fatalf("EnsureTables() returned error: %s", err)
+	}
+	if v, err := c.SchemaVersion(ctx); err != nil || v != LatestSchemaVersion() {
+		t.Errorf("SchemaVersion() returned %d, %v, want %d", v, err, LatestSchemaVersion())
+	}
+
+	// Migrated databases have the columns and indexes of new databases.
+	fresh, err := NewClient(ctx, "sqlite3", "file:fresh?mode=memory")
+	if err != nil {
+		t.Fatalf("NewClient() returned error: %s", err)
+	}
+	t.Cleanup(fresh.Close)
+	if err := fresh.EnsureTables(ctx); err != nil {
+		t.Fatalf("EnsureTables() returned error: %s", err)
+	}
+	want, got := schemaOf(ctx, t, fresh), schemaOf(ctx, t, c)
+	for name := range want {
+		if _, ok := got[name]; !ok {
+			t.Errorf("migrated database lacks %q", name)
+		}
+	}
+	for _, entity := range append(entities, &models.ArtifactRevision{}, &models.SharedContents{}) {
+		stmt := &gorm.Statement{DB: c.db}
+		if err := stmt.Parse(entity); err != nil {
+			t.Fatalf("Parse(%T) returned error: %s", entity, err)
+		}
+		for _, field := range stmt.Schema.DBNames {
+			if !c.db.Migrator().HasColumn(entity, field) {
+				t.Errorf("migrated table %s lacks column %s", stmt.Schema.Table, field)
+			}
+		}
+	}
+
+	// Resources are kept and can be read.
+	api, err := c.GetApi(ctx, names.Api{ProjectID: "p", ApiID: "a"})
+	if err != nil {
+		t.Fatalf("GetApi() returned error: %s", err)
+	}
+	if labels, err := api.LabelsMap(); err != nil || labels["team"] != "pets" {
+		t.Errorf("GetApi() returned labels %v, %v, want team=pets", labels, err)
+	}
+	spec, err := c.GetSpecRevisionContents(ctx, names.SpecRevision{ProjectID: "p", ApiID: "a", VersionID: "v", SpecID: "s", RevisionID: "11111111"})
+	if err != nil {
+		t.Fatalf("GetSpecRevisionContents() returned error: %s", err)
+	}
+	if string(spec.Contents) != "spec" {
+		t.Errorf("GetSpecRevisionContents() returned %q, want %q", spec.Contents, "spec")
+	}
+	artifact, err := c.GetArtifactContents(ctx, names.Project{ProjectID: "p"}.Artifact("x"))
+	if err != nil {
+		t.Fatalf("GetArtifactContents() returned error: %s", err)
+	}
+	if string(artifact.Contents) != "artifact" {
+		t.Errorf("GetArtifactContents() returned %q, want %q", artifact.Contents, "artifact")
+	}
+	refs := 0
+	if err := c.db.WithContext(ctx).Model(&models.ResourceReference{}).Select("COUNT(*)").Scan(&refs).Error; err != nil || refs == 0 {
+		t.Errorf("migrated database has %d resource references, %v, want the reference of the deployment", refs, err)
+	}
+}
+
+func TestRevertSharedContents(t *testing.T) {
+	ctx := context.Background()
+	c, err := NewClient(ctx, "sqlite3", "file::memory:")
+	if err != nil {
+		t.Fatalf("NewClient() returned error: %s", err)
+	}
+	t.Cleanup(c.Close)
+	if err := c.EnsureTables(ctx); err != nil {
+		t.Fatalf("EnsureTables() returned error: %s", err)
+	}
+	contents := []byte("openapi: 3.0.0")
+	spec := &models.Spec{ProjectID: "p", ApiID: "a", VersionID: "v", SpecID: "s", RevisionID: "r", Hash: "h"}
+	if err := c.SaveSpecRevisionContents(ctx, spec, contents); err != nil {
+		t.Fatalf("SaveSpecRevisionContents() returned error: %s", err)
+	}
+
+	// Reverting the shared contents table copies contents back to their blobs.
+	version := 0
+	for i, m := range migrations {
+		if m.description == "create shared contents table" {
+			version = i
+		}
+	}
+	if _, err := c.Migrate(ctx, MigrateOptions{Version: version}); err != nil {
+		t.Fatalf("Migrate(%d) returned error: %s", version, err)
+	}
+	blob := new(models.Blob)
+	if err := c.db.Take(blob, "key = ?", spec.RevisionName()).Error; err != nil {
+		t.Fatalf("Take() returned error: %s", err)
+	}
+	if blob.Location != "" || string(blob.Contents) != string(contents) {
+		t.Errorf("Migrate(%d) left blob with location %q and contents %q, want contents %q", version, blob.Location, blob.Contents, contents)
+	}
+}
+
+func TestMigrateArtifactRevisions(t *testing.T) {
+	ctx := context.Background()
+	c, err := NewClient(ctx, "sqlite3", "file::memory:")
+	if err != nil {
+		t.Fatalf("NewClient() returned error: %s", err)
+	}
+	t.Cleanup(c.Close)
+	if err := c.EnsureTables(ctx); err != nil {
+		t.Fatalf("EnsureTables() returned error: %s", err)
+	}
+	version := 0
+	for i, m := range migrations {
+		if m.description == "create artifact revisions" {
+			version = i
+		}
+	}
+	if _, err := c.Migrate(ctx, MigrateOptions{Version: version}); err != nil {
+		t.Fatalf("Migrate(%d) returned error: %s", version, err)
+	}
+
+	// Artifacts saved before artifact revisions existed have no revision IDs.
+	name, err := names.ParseArtifact("projects/p/locations/global/artifacts/a")
+	if err != nil {
+		t.Fatalf("ParseArtifact() returned error: %s", err)
+	}
+	artifact, err := models.NewArtifact(name, &rpc.Artifact{MimeType: "text/plain"})
+	if err != nil {
+		t.Fatalf("NewArtifact() returned error: %s", err)
+	}
+	artifact.Key = artifact.Name()
+	contents := []byte("contents")
+	if err := c.db.Omit("ArtifactRevisionID").Create(artifact).Error; err != nil {
+		t.Fatalf("Create(%s) returned error: %s", name, err)
+	}
+	if err := c.db.Create(models.NewBlobForArtifact(artifact, contents)).Error; err != nil {
+		t.Fatalf("Create(%s) blob returned error: %s", name, err)
+	}
+
+	// Migrating gives them revisions with their contents.
+	if _, err := c.Migrate(ctx, MigrateOptions{Version: LatestSchemaVersion()}); err != nil {
+		t.Fatalf("Migrate(%d) returned error: %s", LatestSchemaVersion(), err)
+	}
+	migrated, err := c.GetArtifact(ctx, name, false)
+	if err != nil {
+		t.Fatalf("GetArtifact(%s) returned error: %s", name, err)
+	}
+	if migrated.ArtifactRevisionID == "" {
+		t.Fatalf("GetArtifact(%s) returned an artifact without a revision ID", name)
+	}
+	revision := name.Revision(migrated.ArtifactRevisionID)
+	if _, err := c.GetArtifactRevision(ctx, revision); err != nil {
+		t.Errorf("GetArtifactRevision(%s) returned error: %s", revision, err)
+	}
+	blob, err := c.GetArtifactRevisionContents(ctx, revision)
+	if err != nil {
+		t.Fatalf("GetArtifactRevisionContents(%s) returned error: %s", revision, err)
+	}
+	if string(blob.Contents) != string(contents) {
+		t.Errorf("GetArtifactRevisionContents(%s) returned %q, want %q", revision, blob.Contents, contents)
+	}
+}
+
+func TestMigrateResourceReferences(t *testing.T) {
+	ctx := context.Background()
+	c, err := NewClient(ctx, "sqlite3", "file::memory:")
+	if err != nil {
+		t.Fatalf("NewClient() returned error: %s", err)
+	}
+	t.Cleanup(c.Close)
+	if err := c.EnsureTables(ctx); err != nil {
+		t.Fatalf("EnsureTables() returned error: %s", err)
+	}
+	version := 0
+	for i, m := range migrations {
+		if m.description == "index resource references" {
+			version = i
+		}
+	}
+	if _, err := c.Migrate(ctx, MigrateOptions{Version: version}); err != nil {
+		t.Fatalf("Migrate(%d) returned error: %s", version, err)
+	}
+
+	// Resources saved before references were indexed.
+	project := &models.Project{Key: "projects/p", ProjectID: "p"}
+	api := &models.Api{
+		Key:                "projects/p/locations/global/apis/a",
+		ProjectID:          "p",
+		ApiID:              "a",
+		RecommendedVersion: "projects/p/locations/global/apis/a/versions/v1",
+		ParentProjectKey:   project.Key,
+	}
+	deleted := &models.Version{
+		Key:          "projects/p/locations/global/apis/a/versions/v2",
+		ProjectID:    "p",
+		ApiID:        "a",
+		VersionID:    "v2",
+		PrimarySpec:  "projects/p/locations/global/apis/a/versions/v2/specs/s",
+		DeletedAt:    gorm.DeletedAt{Time: time.Now(), Valid: true},
+		ParentApiKey: api.Key,
+	}
+	for _, v := range []interface{}{project, api, deleted} {
+		if err := c.db.Create(v).Error; err != nil {
+			t.Fatalf("Create(%#v) returned error: %s", v, err)
+		}
+	}
+
+	// Migrating indexes their references, including those of deleted resources.
+	if _, err := c.Migrate(ctx, MigrateOptions{Version: LatestSchemaVersion()}); err != nil {
+		t.Fatalf("Migrate(%d) returned error: %s", LatestSchemaVersion(), err)
+	}
+	listing, err := c.ListReferences(ctx, api.Name(), PageOptions{Size: 10})
+	if err != nil {
+		t.Fatalf("ListReferences(%s) returned error: %s", api.Name(), err)
+	}
+	if len(listing.References) != 1 || listing.References[0].Target != api.RecommendedVersion {
+		t.Errorf("ListReferences(%s) returned %+v, want a reference to %s", api.Name(), listing.References, api.RecommendedVersion)
+	}
+	var refs []models.ResourceReference
+	if err := c.db.Unscoped().Where("source = ?", deleted.Name()).Find(&refs).Error; err != nil {
+		t.Fatalf("Find() returned error: %s", err)
+	}
+	if len(refs) != 1 || !refs[0].DeletedAt.Valid {
+		t.Errorf("references of deleted %s are %+v, want one deleted reference", deleted.Name(), refs)
+	}
+}
+
+func TestMigrateSearchIndex(t *testing.T) {
+	ctx := context.Background()
+	c, err := NewClient(ctx, "sqlite3", "file::memory:")
+	if err != nil {
+		t.Fatalf("NewClient() returned error: %s", err)
+	}
+	t.Cleanup(c.Close)
+	if err := c.EnsureTables(ctx); err != nil {
+		t.Fatalf("EnsureTables() returned error: %s", err)
+	}
+	version := 0
+	for i, m := range migrations {
+		if m.description == "create search index" {
+			version = i
+		}
+	}
+	if _, err := c.Migrate(ctx, MigrateOptions{Version: version}); err != nil {
+		t.Fatalf("Migrate(%d) returned error: %s", version, err)
+	}
+
+	// Resources saved before the search index was created.
+	project := &models.Project{Key: "projects/p", ProjectID: "p"}
+	api := &models.Api{
+		Key:              "projects/p/locations/global/apis/a",
+		ProjectID:        "p",
+		ApiID:            "a",
+		DisplayName:      "Pet Store",
+		ParentProjectKey: project.Key,
+	}
+	deleted := &models.Version{
+		Key:          "projects/p/locations/global/apis/a/versions/v1",
+		ProjectID:    "p",
+		ApiID:        "a",
+		VersionID:    "v1",
+		DisplayName:  "Pet Store v1",
+		DeletedAt:    gorm.DeletedAt{Time: time.Now(), Valid: true},
+		ParentApiKey: api.Key,
+	}
+	for _, v := range []interface{}{project, api, deleted} {
+		if err := c.db.Create(v).Error; err != nil {
+			t.Fatalf("Create(%#v) returned error: %s", v, err)
+		}
+	}
+
+	// Migrating indexes them, and deleted resources stay deleted.
+	if _, err := c.Migrate(ctx, MigrateOptions{Version: LatestSchemaVersion()}); err != nil {
+		t.Fatalf("Migrate(%d) returned error: %s", LatestSchemaVersion(), err)
+	}
+	var docs []models.SearchDocument
+	if err := c.db.Unscoped().Where("key = ?", deleted.Name()).Find(&docs).Error; err != nil {
+		t.Fatalf("Find() returned error: %s", err)
+	}
+	if len(docs) != 1 || !docs[0].DeletedAt.Valid {
+		t.Errorf("search documents of deleted %s are %+v, want one deleted document", deleted.Name(), docs)
+	}
+	listing, err := c.SearchResources(ctx, names.Project{ProjectID: "p"}, "pet", PageOptions{Size: 10})
+	if status.Code(err) == codes.FailedPrecondition {
+		t.Skipf("Search is unavailable: %s", err)
+	} else if err != nil {
+		t.Fatalf("SearchResources() returned error: %s", err)
+	}
+	if len(listing.Results) != 1 || listing.Results[0].Name != api.Name() {
+		t.Errorf("SearchResources() returned %+v, want %s", listing.Results, api.Name())
+	}
+}
+
+func TestEnsureSearchIndex(t *testing.T) {
+	ctx := context.Background()
+	c, err := NewClient(ctx, "sqlite3", "file::memory:")
+	if err != nil {
+		t.Fatalf("NewClient() returned error: %s", err)
+	}
+	t.Cleanup(c.Close)
+	if err := c.EnsureTables(ctx); err != nil {
+		t.Fatalf("EnsureTables() returned error: %s", err)
+	}
+	if !c.db.Migrator().HasTable("search_index") {
+		t.Skip("Search is unavailable without FTS5")
+	}
+
+	// Documents saved by a server built without FTS5 are indexed by a server built with it.
+	for _, s := range []string{
+		"DROP TRIGGER search_documents_ai",
+		"DROP TRIGGER search_documents_ad",
+		"DROP TRIGGER search_documents_au",
+		"DROP TABLE search_index",
+	} {
+		if err := c.db.Exec(s).Error; err != nil {
+			t.Fatalf("Exec(%q) returned error: %s", s, err)
+		}
+	}
+	api := &models.Api{Key: "projects/p/locations/global/apis/a", ProjectID: "p", ApiID: "a", DisplayName: "Pet Store"}
+	if err := c.saveSearchDocument(ctx, models.NewApiSearchDocument(api)); err != nil {
+		t.Fatalf("saveSearchDocument() returned error: %s", err)
+	}
+	if err := c.EnsureTables(ctx); err != nil {
+		t.Fatalf("EnsureTables() returned error: %s", err)
+	}
+	listing, err := c.SearchResources(ctx, names.Project{ProjectID: "p"}, "pet", PageOptions{Size: 10})
+	if err != nil {
+		t.Fatalf("SearchResources() returned error: %s", err)
+	}
+	if len(listing.Results) != 1 || listing.Results[0].Name != api.Name() {
+		t.Errorf("SearchResources() returned %+v, want %s", listing.Results, api.Name())
+	}
+}
+
+func TestMigrateSpecElements(t *testing.T) {
+	ctx := context.Background()
+	c, err := NewClient(ctx, "sqlite3", "file::memory:")
+	if err != nil {
+		t.Fatalf("NewClient() returned error: %s", err)
+	}
+	t.Cleanup(c.Close)
+	if err := c.EnsureTables(ctx); err != nil {
+		t.Fatalf("EnsureTables() returned error: %s", err)
+	}
+	version := 0
+	for i, m := range migrations {
+		if m.description == "index spec elements" {
+			version = i
+		}
+	}
+	if _, err := c.Migrate(ctx, MigrateOptions{Version: version}); err != nil {
+		t.Fatalf("Migrate(%d) returned error: %s", version, err)
+	}
+
+	// Spec indexes saved before spec elements were indexed.
+	contents, err := proto.Marshal(&rpc.SpecIndex{
+		Operations: []*rpc.SpecIndex_Operation{{Id: "listPets", Method: "GET", Path: "/pets"}},
+	})
+	if err != nil {
+		t.Fatalf("Marshal() returned error: %s", err)
+	}
+	project := &models.Project{Key: "projects/p", ProjectID: "p"}
+	api := &models.Api{Key: "projects/p/locations/global/apis/a", ProjectID: "p", ApiID: "a", ParentProjectKey: project.Key}
+	v := &models.Version{Key: api.Key + "/versions/v", ProjectID: "p", ApiID: "a", VersionID: "v", ParentApiKey: api.Key}
+	records := []interface{}{project, api, v}
+	var artifacts []*models.Artifact
+	for _, id := range []string{"live", "deleted"} {
+		spec := &models.Spec{
+			Key:                v.Key + "/specs/" + id + "@r",
+			ProjectID:          "p",
+			ApiID:              "a",
+			VersionID:          "v",
+			SpecID:             id,
+			RevisionID:         "r",
+			RevisionCreateTime: time.Now(),
+			ParentVersionKey:   v.Key,
+		}
+		artifact := &models.Artifact{
+			Key:        spec.Key + "/artifacts/index",
+			ProjectID:  "p",
+			ApiID:      "a",
+			VersionID:  "v",
+			SpecID:     id,
+			RevisionID: "r",
+			ArtifactID: "index",
+			MimeType:   models.SpecIndexMimeType,
+		}
+		if id == "deleted" {
+			artifact.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+		}
+		blob := models.NewBlobForArtifact(artifact, contents)
+		blob.Key = artifact.Name()
+		artifacts = append(artifacts, artifact)
+		records = append(records, spec, artifact, blob)
+	}
+	for _, v := range records {
+		if err := c.db.Create(v).Error; err != nil {
+			t.Fatalf("Create(%#v) returned error: %s", v, err)
+		}
+	}
+
+	// Migrating indexes their elements, and elements of deleted artifacts stay deleted.
+	if _, err := c.Migrate(ctx, MigrateOptions{Version: LatestSchemaVersion()}); err != nil {
+		t.Fatalf("Migrate(%d) returned error: %s", LatestSchemaVersion(), err)
+	}
+	parent := names.Spec{ProjectID: "p", ApiID: "-", VersionID: "-", SpecID: "-"}
+	listing, err := c.ListSpecElements(ctx, parent, PageOptions{Size: 10})
+	if err != nil {
+		t.Fatalf("ListSpecElements() returned error: %s", err)
+	}
+	if len(listing.Elements) != 1 || listing.Elements[0].SpecID != "live" || listing.Elements[0].Path != "/pets" {
+		t.Errorf("ListSpecElements() returned %+v, want the operation of the live spec", listing.Elements)
+	}
+	var elements []models.SpecElement
+	if err := c.db.Unscoped().Where("artifact_id = ? AND spec_id = ?", "index", "deleted").Find(&elements).Error; err != nil {
+		t.Fatalf("Find() returned error: %s", err)
+	}
+	if len(elements) != 1 || !elements[0].DeletedAt.Valid {
+		t.Errorf("spec elements of deleted %s are %+v, want one deleted element", artifacts[1].Name(), elements)
+	}
+}
@@ -21,22 +21,24 @@ import (
 	"github.com/apigee/registry/server/registry/names"
 	"google.golang.org/protobuf/types/known/fieldmaskpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
 )
 
 // Api is the storage-side representation of an API.
 type Api struct {
-	Key                   string    `gorm:"primaryKey"`
-	ProjectID             string    // Uniquely identifies a project.
-	ApiID                 string    // Uniquely identifies an api within a project.
-	DisplayName           string    // A human-friendly name.
-	Description           string    // A detailed description.
-	CreateTime            time.Time // Creation time.
-	UpdateTime            time.Time // Time of last change.
-	Availability          string    // Availability of the API.
-	RecommendedVersion    string    // Recommended API version.
-	RecommendedDeployment string    // Recommended API deployment.
-	Labels                []byte    // Serialized labels.
-	Annotations           []byte    // Serialized annotations.
+	Key                   string         `gorm:"primaryKey"`
+	ProjectID             string         // Uniquely identifies a project.
+	ApiID                 string         // Uniquely identifies an api within a project.
+	DisplayName           string         // A human-friendly name.
+	Description           string         // A detailed description.
+	CreateTime            time.Time      // Creation time.
+	UpdateTime            time.Time      // Time of last change.
+	DeletedAt             gorm.DeletedAt `gorm:"index"` // Deletion time, if deleted.
+	Availability          string         // Availability of the API.
+	RecommendedVersion    string         // Recommended API version.
+	RecommendedDeployment string         // Recommended API deployment.
+	Labels                []byte         // Serialized labels.
+	Annotations           []byte         // Serialized annotations.
 	ParentProjectKey      string
 	ParentProject         *Project `gorm:"foreignKey:ParentProjectKey;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 }
@@ -89,6 +91,8 @@ func (api *Api) Message() (message *rpc.Api, err error) {
 		RecommendedDeployment: api.RecommendedDeployment,
 		CreateTime:            timestamppb.New(api.CreateTime),
 		UpdateTime:            timestamppb.New(api.UpdateTime),
+		DeleteTime:            deleteTime(api.DeletedAt),
+		Etag:                  api.Etag(),
 	}
 
 	message.Labels, err = api.LabelsMap()
@@ -139,3 +143,8 @@ func (api *Api) Update(message *rpc.Api, mask *fieldmaskpb.FieldMask) error {
 func (api *Api) LabelsMap() (map[string]string, error) {
 	return mapForBytes(api.Labels)
 }
+
+// Etag returns the etag of the API.
+func (api *Api) Etag() string {
+	return etag(api.Name(), api.UpdateTime)
+}
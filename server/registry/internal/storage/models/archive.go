@@ -0,0 +1,60 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import (
+	"time"
+
+	"github.com/apigee/registry/rpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Archive is the storage-side representation of a project archive.
+type Archive struct {
+	Key         string    `gorm:"primaryKey"` // Name of the archive.
+	ProjectID   string    // Project in the archive.
+	SizeInBytes int64     // Size of the archive contents.
+	Hash        string    // A hash of the archive contents.
+	Contents    []byte    // The archive contents, unless they are in a blob store.
+	Location    string    `gorm:"index"` // Key of the contents in a blob store, if they are stored there.
+	CreateTime  time.Time // Creation time.
+	ExpireTime  time.Time `gorm:"index"` // Time after which the archive is removed.
+}
+
+// NewArchive creates an archive with contents of a specified size and hash
+// that expires after a lifetime.
+func NewArchive(name, projectID string, size int64, hash string, lifetime time.Duration) *Archive {
+	now := time.Now().Round(time.Microsecond)
+	return &Archive{
+		Key:         name,
+		ProjectID:   projectID,
+		SizeInBytes: size,
+		Hash:        hash,
+		CreateTime:  now,
+		ExpireTime:  now.Add(lifetime),
+	}
+}
+
+// Message returns a message representing an archive.
+func (a *Archive) Message() *rpc.Archive {
+	return &rpc.Archive{
+		Name:       a.Key,
+		Project:    "projects/" + a.ProjectID,
+		SizeBytes:  a.SizeInBytes,
+		Hash:       a.Hash,
+		CreateTime: timestamppb.New(a.CreateTime),
+		ExpireTime: timestamppb.New(a.ExpireTime),
+	}
+}
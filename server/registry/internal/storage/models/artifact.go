@@ -24,25 +24,30 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
 )
 
 // Artifact is the storage-side representation of an artifact.
 type Artifact struct {
-	Key          string    `gorm:"primaryKey"`
-	ProjectID    string    // Project associated with artifact (required).
-	ApiID        string    // Api associated with artifact (if appropriate).
-	VersionID    string    // Version associated with artifact (if appropriate).
-	SpecID       string    // Spec associated with artifact (if appropriate).
-	RevisionID   string    // Revision associated with parent (if appropriate).
-	DeploymentID string    // Deployment associated with artifact (if appropriate).
-	ArtifactID   string    // Artifact identifier (required).
-	CreateTime   time.Time // Creation time.
-	UpdateTime   time.Time // Time of last change.
-	MimeType     string    // MIME type of artifact
-	SizeInBytes  int32     // Size of the spec.
-	Hash         string    // A hash of the spec.
-	Labels       []byte    // Serialized labels.
-	Annotations  []byte    // Serialized annotations.
+	Key          string         `gorm:"primaryKey"`
+	ProjectID    string         // Project associated with artifact (required).
+	ApiID        string         // Api associated with artifact (if appropriate).
+	VersionID    string         // Version associated with artifact (if appropriate).
+	SpecID       string         // Spec associated with artifact (if appropriate).
+	RevisionID   string         // Revision associated with parent (if appropriate).
+	DeploymentID string         // Deployment associated with artifact (if appropriate).
+	ArtifactID   string         // Artifact identifier (required).
+	CreateTime   time.Time      // Creation time.
+	UpdateTime   time.Time      // Time of last change.
+	DeletedAt    gorm.DeletedAt `gorm:"index"` // Deletion time, if deleted.
+	MimeType     string         // MIME type of artifact
+	SizeInBytes  int32          // Size of the spec.
+	Hash         string         // A hash of the spec.
+	Labels       []byte         // Serialized labels.
+	Annotations  []byte         // Serialized annotations.
+	// ArtifactRevisionID identifies a revision of the artifact. It is distinct
+	// from RevisionID, which identifies a revision of the artifact's parent.
+	ArtifactRevisionID string
 }
 
 // NewArtifact initializes a new resource.
@@ -59,6 +64,8 @@ func NewArtifact(name names.Artifact, body *rpc.Artifact) (artifact *Artifact, e
 		CreateTime:   now,
 		UpdateTime:   now,
 		MimeType:     body.GetMimeType(),
+
+		ArtifactRevisionID: newRevisionID(),
 	}
 
 	if body.GetContents() != nil {
@@ -110,6 +117,20 @@ func (artifact *Artifact) Name() string {
 	}
 }
 
+// RevisionName returns the resource name of the artifact revision.
+func (artifact *Artifact) RevisionName() string {
+	return artifact.Name() + "@" + artifact.ArtifactRevisionID
+}
+
+// NewRevision returns a new revision based on the artifact.
+func (artifact *Artifact) NewRevision() *Artifact {
+	revision := *artifact
+	revision.UpdateTime = time.Now().Round(time.Microsecond)
+	revision.ArtifactRevisionID = newRevisionID()
+	revision.DeletedAt = gorm.DeletedAt{}
+	return &revision
+}
+
 // Message returns an RPC message representing the artifact.
 func (artifact *Artifact) Message() (message *rpc.Artifact, err error) {
 	message = &rpc.Artifact{
@@ -119,6 +140,9 @@ func (artifact *Artifact) Message() (message *rpc.Artifact, err error) {
 		Hash:       artifact.Hash,
 		CreateTime: timestamppb.New(artifact.CreateTime),
 		UpdateTime: timestamppb.New(artifact.UpdateTime),
+		Etag:       artifact.Etag(),
+		RevisionId: artifact.ArtifactRevisionID,
+		DeleteTime: deleteTime(artifact.DeletedAt),
 	}
 
 	message.Labels, err = artifact.LabelsMap()
@@ -138,3 +162,80 @@ func (artifact *Artifact) Message() (message *rpc.Artifact, err error) {
 func (artifact *Artifact) LabelsMap() (map[string]string, error) {
 	return mapForBytes(artifact.Labels)
 }
+
+// Etag returns the etag of the artifact.
+func (artifact *Artifact) Etag() string {
+	return etag(artifact.Name(), artifact.UpdateTime)
+}
+
+// ArtifactRevision is the storage-side representation of a revision of an
+// artifact. Every revision of an artifact, including the current one, is kept
+// in its own row with the location of its contents, which revisions share.
+type ArtifactRevision struct {
+	Artifact
+	Location string `gorm:"index"` // Key of the shared contents of the revision, if it has contents.
+}
+
+// NewArtifactRevision returns the revision of an artifact with contents stored at a location.
+func NewArtifactRevision(artifact *Artifact, location string) *ArtifactRevision {
+	return &ArtifactRevision{
+		Artifact: *artifact,
+		Location: location,
+	}
+}
+
+// Blob returns a blob that refers to the contents of the revision.
+func (r *ArtifactRevision) Blob() *Blob {
+	blob := NewBlobForArtifact(&r.Artifact, nil)
+	blob.Key = r.RevisionName()
+	blob.Location = r.Location
+	return blob
+}
+
+// ArtifactRevisionTag is the storage-side representation of an artifact revision tag.
+type ArtifactRevisionTag struct {
+	Key                string         `gorm:"primaryKey"`
+	ProjectID          string         // Project associated with artifact.
+	ApiID              string         // Api associated with artifact (if appropriate).
+	VersionID          string         // Version associated with artifact (if appropriate).
+	SpecID             string         // Spec associated with artifact (if appropriate).
+	RevisionID         string         // Revision associated with parent (if appropriate).
+	DeploymentID       string         // Deployment associated with artifact (if appropriate).
+	ArtifactID         string         // Artifact identifier.
+	ArtifactRevisionID string         // Uniquely identifies a revision of the artifact.
+	Tag                string         // The tag to use for the revision.
+	CreateTime         time.Time      // Creation time.
+	UpdateTime         time.Time      // Time of last change.
+	DeletedAt          gorm.DeletedAt `gorm:"index"` // Deletion time, if deleted.
+}
+
+// NewArtifactRevisionTag initializes a new tag for a revision of an artifact.
+func NewArtifactRevisionTag(artifact *Artifact, tag string) *ArtifactRevisionTag {
+	now := time.Now().Round(time.Microsecond)
+	return &ArtifactRevisionTag{
+		ProjectID:          artifact.ProjectID,
+		ApiID:              artifact.ApiID,
+		VersionID:          artifact.VersionID,
+		SpecID:             artifact.SpecID,
+		RevisionID:         artifact.RevisionID,
+		DeploymentID:       artifact.DeploymentID,
+		ArtifactID:         artifact.ArtifactID,
+		ArtifactRevisionID: artifact.ArtifactRevisionID,
+		Tag:                tag,
+		CreateTime:         now,
+		UpdateTime:         now,
+	}
+}
+
+func (t *ArtifactRevisionTag) String() string {
+	artifact := Artifact{
+		ProjectID:    t.ProjectID,
+		ApiID:        t.ApiID,
+		VersionID:    t.VersionID,
+		SpecID:       t.SpecID,
+		RevisionID:   t.RevisionID,
+		DeploymentID: t.DeploymentID,
+		ArtifactID:   t.ArtifactID,
+	}
+	return artifact.Name() + "@" + t.Tag
+}
@@ -0,0 +1,61 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/apigee/registry/rpc"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// AuditEvent is the storage-side representation of a record of a change to a resource.
+type AuditEvent struct {
+	ID         int64     `gorm:"primaryKey;autoIncrement"`
+	Resource   string    `gorm:"index"` // Name of the changed resource.
+	Method     string    // Name of the method that made the change.
+	Caller     string    // Identity of the caller that made the change.
+	UpdateMask string    // Comma-separated names of the changed fields.
+	CreateTime time.Time // Time of the change.
+}
+
+// NewAuditEvent creates a record of a change.
+func NewAuditEvent(resource, method, caller string, mask *fieldmaskpb.FieldMask) *AuditEvent {
+	return &AuditEvent{
+		Resource:   resource,
+		Method:     method,
+		Caller:     caller,
+		UpdateMask: strings.Join(mask.GetPaths(), ","),
+		CreateTime: time.Now().Round(time.Microsecond),
+	}
+}
+
+// Message returns a message representing an audit event.
+func (e *AuditEvent) Message() *rpc.AuditEvent {
+	mask := &fieldmaskpb.FieldMask{}
+	if e.UpdateMask != "" {
+		mask.Paths = strings.Split(e.UpdateMask, ",")
+	}
+	return &rpc.AuditEvent{
+		Id:         e.ID,
+		Resource:   e.Resource,
+		Method:     e.Method,
+		Caller:     e.Caller,
+		UpdateMask: mask,
+		CreateTime: timestamppb.New(e.CreateTime),
+	}
+}
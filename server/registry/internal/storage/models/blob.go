@@ -14,23 +14,29 @@
 
 package models
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 // Blob is the storage-side representation of a blob.
 type Blob struct {
-	Key          string    `gorm:"primaryKey"`
-	ProjectID    string    // Uniquely identifies a project.
-	ApiID        string    // Uniquely identifies an API within a project.
-	VersionID    string    // Uniquely identifies a version of an API.
-	SpecID       string    // Uniquely identifies a spec of a version.
-	RevisionID   string    // Uniquely identifies a revision of a spec.
-	DeploymentID string    // Uniquely identifies a deployment of an API.
-	ArtifactID   string    // Uniquely identifies an artifact on a resource.
-	Hash         string    // Hash of the blob contents.
-	SizeInBytes  int32     // Size of the blob contents.
-	Contents     []byte    // The contents of the blob.
-	CreateTime   time.Time // Creation time.
-	UpdateTime   time.Time // Time of last change.
+	Key          string         `gorm:"primaryKey"`
+	ProjectID    string         // Uniquely identifies a project.
+	ApiID        string         // Uniquely identifies an API within a project.
+	VersionID    string         // Uniquely identifies a version of an API.
+	SpecID       string         // Uniquely identifies a spec of a version.
+	RevisionID   string         // Uniquely identifies a revision of a spec.
+	DeploymentID string         // Uniquely identifies a deployment of an API.
+	ArtifactID   string         // Uniquely identifies an artifact on a resource.
+	Hash         string         // Hash of the blob contents.
+	SizeInBytes  int32          // Size of the blob contents.
+	Contents     []byte         // The contents of the blob, unless they are in a blob store.
+	Location     string         `gorm:"index"` // Key of the contents in a blob store, if they are stored there.
+	CreateTime   time.Time      // Creation time.
+	UpdateTime   time.Time      // Time of last change.
+	DeletedAt    gorm.DeletedAt `gorm:"index"` // Deletion time, if deleted.
 }
 
 // NewBlobForSpec creates a new Blob object to store spec contents.
@@ -22,27 +22,29 @@ import (
 	"github.com/apigee/registry/server/registry/names"
 	"google.golang.org/protobuf/types/known/fieldmaskpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
 )
 
 // Deployment is the storage-side representation of a deployment.
 type Deployment struct {
-	Key                string    `gorm:"primaryKey"`
-	ProjectID          string    // Uniquely identifies a project.
-	ApiID              string    // Uniquely identifies an api within a project.
-	DeploymentID       string    // Uniquely identifies a deployment within an api.
-	RevisionID         string    // Uniquely identifies a revision of a deployment.
-	DisplayName        string    // A human-friendly name.
-	Description        string    // A detailed description.
-	CreateTime         time.Time // Creation time.
-	RevisionCreateTime time.Time // Revision creation time.
-	RevisionUpdateTime time.Time // Time of last change.
-	ApiSpecRevision    string    // The spec being served by the deployment.
-	EndpointURI        string    // The address where the deployment is serving.
-	ExternalChannelURI string    // The address of the external channel of the API.
-	IntendedAudience   string    // The intended audience of the API.
-	AccessGuidance     string    // A brief description of how to access the endpoint.
-	Labels             []byte    // Serialized labels.
-	Annotations        []byte    // Serialized annotations.
+	Key                string         `gorm:"primaryKey"`
+	ProjectID          string         // Uniquely identifies a project.
+	ApiID              string         // Uniquely identifies an api within a project.
+	DeploymentID       string         // Uniquely identifies a deployment within an api.
+	RevisionID         string         // Uniquely identifies a revision of a deployment.
+	DisplayName        string         // A human-friendly name.
+	Description        string         // A detailed description.
+	CreateTime         time.Time      // Creation time.
+	RevisionCreateTime time.Time      // Revision creation time.
+	RevisionUpdateTime time.Time      // Time of last change.
+	DeletedAt          gorm.DeletedAt `gorm:"index"` // Deletion time, if deleted.
+	ApiSpecRevision    string         // The spec being served by the deployment.
+	EndpointURI        string         // The address where the deployment is serving.
+	ExternalChannelURI string         // The address of the external channel of the API.
+	IntendedAudience   string         // The intended audience of the API.
+	AccessGuidance     string         // A brief description of how to access the endpoint.
+	Labels             []byte         // Serialized labels.
+	Annotations        []byte         // Serialized annotations.
 	ParentApiKey       string
 	ParentApi          *Api `gorm:"foreignKey:ParentApiKey;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 }
@@ -133,6 +135,8 @@ func (s *Deployment) BasicMessage(name string) (message *rpc.ApiDeployment, err
 		ExternalChannelUri: s.ExternalChannelURI,
 		IntendedAudience:   s.IntendedAudience,
 		AccessGuidance:     s.AccessGuidance,
+		DeleteTime:         deleteTime(s.DeletedAt),
+		Etag:               s.Etag(),
 	}
 
 	message.Labels, err = mapForBytes(s.Labels)
@@ -203,14 +207,15 @@ func (s *Deployment) LabelsMap() (map[string]string, error) {
 
 // DeploymentRevisionTag is the storage-side representation of a deployment revision tag.
 type DeploymentRevisionTag struct {
-	Key                 string    `gorm:"primaryKey"`
-	ProjectID           string    // Uniquely identifies a project.
-	ApiID               string    // Uniquely identifies an api within a project.
-	DeploymentID        string    // Uniquely identifies a deployment within an api.
-	RevisionID          string    // Uniquely identifies a revision of a deployment.
-	Tag                 string    // The tag to use for the revision.
-	CreateTime          time.Time // Creation time.
-	UpdateTime          time.Time // Time of last change.
+	Key                 string         `gorm:"primaryKey"`
+	ProjectID           string         // Uniquely identifies a project.
+	ApiID               string         // Uniquely identifies an api within a project.
+	DeploymentID        string         // Uniquely identifies a deployment within an api.
+	RevisionID          string         // Uniquely identifies a revision of a deployment.
+	Tag                 string         // The tag to use for the revision.
+	CreateTime          time.Time      // Creation time.
+	UpdateTime          time.Time      // Time of last change.
+	DeletedAt           gorm.DeletedAt `gorm:"index"` // Deletion time, if deleted.
 	ParentDeploymentKey string
 	ParentDeployment    *Deployment `gorm:"foreignKey:ParentDeploymentKey;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 }
@@ -234,3 +239,8 @@ func (t *DeploymentRevisionTag) String() string {
 	return fmt.Sprintf("projects/%s/locations/%s/apis/%s/deployments/%s@%s",
 		t.ProjectID, names.Location, t.ApiID, t.DeploymentID, t.Tag)
 }
+
+// Etag returns the etag of the deployment revision.
+func (s *Deployment) Etag() string {
+	return etag(s.RevisionName(), s.RevisionUpdateTime)
+}
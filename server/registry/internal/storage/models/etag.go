@@ -0,0 +1,29 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// etag returns a checksum of the stored state of a resource, as described at https://google.aip.dev/154.
+// Every change to a resource sets its update time, so the name and update time identify its state.
+func etag(name string, updateTime time.Time) string {
+	h := sha256.Sum256([]byte(fmt.Sprintf("%s %d", name, updateTime.UnixNano())))
+	return hex.EncodeToString(h[:8])
+}
@@ -0,0 +1,71 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import (
+	"time"
+
+	"github.com/apigee/registry/rpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// NotificationEvent is the storage-side representation of a notification
+// waiting to be delivered to a sink.
+type NotificationEvent struct {
+	ID              int64     `gorm:"primaryKey;autoIncrement"`
+	Sink            string    `gorm:"index"` // Name of the sink that receives the notification.
+	Change          int32     // Type of change, an rpc.Notification_Change value.
+	Resource        string    // Name of the changed resource.
+	ChangeTime      time.Time // Time of the change.
+	State           string    // Delivery state, an rpc.NotificationEvent_State name.
+	Attempts        int32     // Number of failed delivery attempts.
+	NextAttemptTime time.Time `gorm:"index"` // Earliest time of the next delivery attempt.
+	LastError       string    // Error returned by the last failed attempt.
+}
+
+// NewNotificationEvent creates a pending event that delivers a notification to a sink.
+func NewNotificationEvent(sink string, n *rpc.Notification) *NotificationEvent {
+	t := n.GetChangeTime().AsTime().Round(time.Microsecond)
+	return &NotificationEvent{
+		Sink:            sink,
+		Change:          int32(n.GetChange()),
+		Resource:        n.GetResource(),
+		ChangeTime:      t,
+		State:           rpc.NotificationEvent_PENDING.String(),
+		NextAttemptTime: t,
+	}
+}
+
+// Notification returns the notification carried by an event.
+func (e *NotificationEvent) Notification() *rpc.Notification {
+	return &rpc.Notification{
+		Change:     rpc.Notification_Change(e.Change),
+		Resource:   e.Resource,
+		ChangeTime: timestamppb.New(e.ChangeTime),
+	}
+}
+
+// Message returns a message representing an event.
+func (e *NotificationEvent) Message() *rpc.NotificationEvent {
+	return &rpc.NotificationEvent{
+		Id:              e.ID,
+		Notification:    e.Notification(),
+		Sink:            e.Sink,
+		State:           rpc.NotificationEvent_State(rpc.NotificationEvent_State_value[e.State]),
+		Attempts:        e.Attempts,
+		NextAttemptTime: timestamppb.New(e.NextAttemptTime),
+		LastError:       e.LastError,
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import (
+	"time"
+
+	"google.golang.org/genproto/googleapis/longrunning"
+	"google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// Operation is the storage-side representation of a long-running operation.
+type Operation struct {
+	Key             string    `gorm:"primaryKey"` // Name of the operation.
+	Method          string    // Name of the method that started the operation.
+	Done            bool      `gorm:"index"` // True if the operation has finished.
+	CancelRequested bool      // True if the operation has been asked to stop.
+	Metadata        []byte    // Serialized metadata describing the progress of the operation.
+	Response        []byte    // Serialized response of an operation that succeeded.
+	Error           []byte    // Serialized status of an operation that failed.
+	LeaseTime       time.Time // Time after which a running operation is considered abandoned.
+	CreateTime      time.Time // Creation time.
+	UpdateTime      time.Time // Time of last change.
+}
+
+// NewOperation creates a running operation.
+func NewOperation(name, method string, metadata *anypb.Any, lease time.Duration) (*Operation, error) {
+	m, err := proto.Marshal(metadata)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().Round(time.Microsecond)
+	return &Operation{
+		Key:        name,
+		Method:     method,
+		Metadata:   m,
+		LeaseTime:  now.Add(lease).UTC(),
+		CreateTime: now,
+		UpdateTime: now,
+	}, nil
+}
+
+// Message returns a message representing an operation.
+func (o *Operation) Message() (*longrunning.Operation, error) {
+	op := &longrunning.Operation{
+		Name: o.Key,
+		Done: o.Done,
+	}
+	if len(o.Metadata) > 0 {
+		op.Metadata = new(anypb.Any)
+		if err := proto.Unmarshal(o.Metadata, op.Metadata); err != nil {
+			return nil, err
+		}
+	}
+	if len(o.Error) > 0 {
+		s := new(status.Status)
+		if err := proto.Unmarshal(o.Error, s); err != nil {
+			return nil, err
+		}
+		op.Result = &longrunning.Operation_Error{Error: s}
+	} else if len(o.Response) > 0 {
+		r := new(anypb.Any)
+		if err := proto.Unmarshal(o.Response, r); err != nil {
+			return nil, err
+		}
+		op.Result = &longrunning.Operation_Response{Response: r}
+	}
+	return op, nil
+}
+
+// Finish records the result of an operation. Operations that fail have a non-nil status.
+func (o *Operation) Finish(response *anypb.Any, s *status.Status) error {
+	var err error
+	if s != nil {
+		o.Error, err = proto.Marshal(s)
+	} else {
+		o.Response, err = proto.Marshal(response)
+	}
+	if err != nil {
+		return err
+	}
+	o.Done = true
+	o.UpdateTime = time.Now().Round(time.Microsecond)
+	return nil
+}
@@ -21,16 +21,18 @@ import (
 	"github.com/apigee/registry/server/registry/names"
 	"google.golang.org/protobuf/types/known/fieldmaskpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
 )
 
 // Project is the storage-side representation of a project.
 type Project struct {
-	Key         string    `gorm:"primaryKey"`
-	ProjectID   string    // Uniquely identifies a project.
-	DisplayName string    // A human-friendly name.
-	Description string    // A detailed description.
-	CreateTime  time.Time // Creation time.
-	UpdateTime  time.Time // Time of last change.
+	Key         string         `gorm:"primaryKey"`
+	ProjectID   string         // Uniquely identifies a project.
+	DisplayName string         // A human-friendly name.
+	Description string         // A detailed description.
+	CreateTime  time.Time      // Creation time.
+	UpdateTime  time.Time      // Time of last change.
+	DeletedAt   gorm.DeletedAt `gorm:"index"` // Deletion time, if deleted.
 }
 
 // NewProject initializes a new resource.
@@ -60,6 +62,7 @@ func (p *Project) Message() *rpc.Project {
 		Description: p.Description,
 		CreateTime:  timestamppb.New(p.CreateTime),
 		UpdateTime:  timestamppb.New(p.UpdateTime),
+		DeleteTime:  deleteTime(p.DeletedAt),
 	}
 }
 
@@ -0,0 +1,165 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/names"
+	"google.golang.org/protobuf/proto"
+	"gorm.io/gorm"
+)
+
+// ResourceReference is the storage-side representation of a reference from one resource to another.
+// References are stored with the IDs of the resource that contains them, so that they are
+// deleted and undeleted with it.
+type ResourceReference struct {
+	Key          string         `gorm:"primaryKey"`
+	ProjectID    string         // Uniquely identifies a project.
+	ApiID        string         // Uniquely identifies an api within a project.
+	VersionID    string         // Uniquely identifies a version within an api.
+	SpecID       string         // Uniquely identifies a spec within a version.
+	RevisionID   string         // Uniquely identifies a revision of the parent of an artifact.
+	DeploymentID string         // Uniquely identifies a deployment within an api.
+	ArtifactID   string         // Uniquely identifies an artifact on a resource.
+	Source       string         `gorm:"index"` // Name of the resource that contains the reference.
+	Field        string         // Field of the source that contains the reference.
+	Target       string         `gorm:"index"` // Name of the referenced resource.
+	CreateTime   time.Time      // Creation time.
+	DeletedAt    gorm.DeletedAt `gorm:"index"` // Deletion time, if deleted.
+}
+
+// Message returns a message representing a reference.
+func (r *ResourceReference) Message() *rpc.Reference {
+	return &rpc.Reference{
+		Source: r.Source,
+		Field:  r.Field,
+		Target: r.Target,
+	}
+}
+
+// references collects the references of a resource, skipping values that
+// aren't names of resources and references that were already collected.
+type references struct {
+	base ResourceReference
+	refs []*ResourceReference
+}
+
+func (r *references) add(field, target string) {
+	target, ok := referenceTarget(target)
+	if !ok {
+		return
+	}
+	key := r.base.Source + "#" + field + "#" + target
+	for _, ref := range r.refs {
+		if ref.Key == key {
+			return
+		}
+	}
+	ref := r.base
+	ref.Key = key
+	ref.Field = field
+	ref.Target = target
+	r.refs = append(r.refs, &ref)
+}
+
+// referenceTarget returns the normalized name of a referenced resource
+// and false if the value isn't the name of a single resource.
+func referenceTarget(value string) (string, bool) {
+	if value == "" || strings.Contains(value, "/-") || strings.Contains(value, "@-") {
+		return "", false
+	}
+	if rev, err := names.ParseArtifactRevision(value); err == nil {
+		return rev.String(), true
+	}
+	name, err := names.ParseResourceEntity(value)
+	if err != nil {
+		return "", false
+	}
+	return name.String(), true
+}
+
+// NewApiReferences returns the references from an API.
+func NewApiReferences(api *Api) []*ResourceReference {
+	r := &references{base: ResourceReference{
+		ProjectID:  api.ProjectID,
+		ApiID:      api.ApiID,
+		Source:     api.Name(),
+		CreateTime: time.Now().Round(time.Microsecond),
+	}}
+	r.add("recommended_version", api.RecommendedVersion)
+	r.add("recommended_deployment", api.RecommendedDeployment)
+	return r.refs
+}
+
+// NewVersionReferences returns the references from a version.
+func NewVersionReferences(version *Version) []*ResourceReference {
+	r := &references{base: ResourceReference{
+		ProjectID:  version.ProjectID,
+		ApiID:      version.ApiID,
+		VersionID:  version.VersionID,
+		Source:     version.Name(),
+		CreateTime: time.Now().Round(time.Microsecond),
+	}}
+	r.add("primary_spec", version.PrimarySpec)
+	return r.refs
+}
+
+// NewDeploymentReferences returns the references from the current revision of a deployment.
+func NewDeploymentReferences(deployment *Deployment) []*ResourceReference {
+	r := &references{base: ResourceReference{
+		ProjectID:    deployment.ProjectID,
+		ApiID:        deployment.ApiID,
+		DeploymentID: deployment.DeploymentID,
+		Source:       deployment.Name(),
+		CreateTime:   time.Now().Round(time.Microsecond),
+	}}
+	r.add("api_spec_revision", deployment.ApiSpecRevision)
+	return r.refs
+}
+
+// NewArtifactReferences returns the references in the contents of an artifact.
+// Only the contents of artifacts of known types are searched for references.
+func NewArtifactReferences(artifact *Artifact, contents []byte) []*ResourceReference {
+	r := &references{base: ResourceReference{
+		ProjectID:    artifact.ProjectID,
+		ApiID:        artifact.ApiID,
+		VersionID:    artifact.VersionID,
+		SpecID:       artifact.SpecID,
+		RevisionID:   artifact.RevisionID,
+		DeploymentID: artifact.DeploymentID,
+		ArtifactID:   artifact.ArtifactID,
+		Source:       artifact.Name(),
+		CreateTime:   time.Now().Round(time.Microsecond),
+	}}
+	if strings.HasSuffix(artifact.MimeType, "+gzip") {
+		var err error
+		if contents, err = GUnzippedBytes(contents); err != nil {
+			return r.refs
+		}
+	}
+	switch strings.TrimSuffix(artifact.MimeType, "+gzip") {
+	case "application/octet-stream;type=google.cloud.apigeeregistry.v1.apihub.ReferenceList":
+		list := new(rpc.ReferenceList)
+		if proto.Unmarshal(contents, list) == nil {
+			for _, ref := range list.GetReferences() {
+				r.add("references.resource", ref.GetResource())
+			}
+		}
+	}
+	return r.refs
+}
@@ -0,0 +1,24 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import "time"
+
+// SchemaMigration records a migration that has been applied to the database.
+type SchemaMigration struct {
+	Version     int       `gorm:"primaryKey;autoIncrement:false"` // Version of the schema after the migration.
+	Description string    // Description of the migration.
+	ApplyTime   time.Time // Time that the migration was applied.
+}
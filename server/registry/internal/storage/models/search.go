@@ -0,0 +1,188 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+)
+
+// MaxSearchableContents is the largest number of bytes of the text of a spec that are indexed for search.
+const MaxSearchableContents = 256 << 10
+
+// SearchDocument is the storage-side representation of the searchable text of a resource.
+// Documents are stored with the IDs of their resources, so that they are deleted and
+// undeleted with them. Specs and deployments are represented by their current revisions.
+type SearchDocument struct {
+	ID           int64          `gorm:"primaryKey"`  // Identifies the document in full-text indexes.
+	Key          string         `gorm:"uniqueIndex"` // Name of the resource.
+	ProjectID    string         // Uniquely identifies a project.
+	ApiID        string         // Uniquely identifies an api within a project.
+	VersionID    string         // Uniquely identifies a version within an api.
+	SpecID       string         // Uniquely identifies a spec within a version.
+	DeploymentID string         // Uniquely identifies a deployment within an api.
+	ResourceID   string         // ID of the resource.
+	DisplayName  string         // A human-friendly name.
+	Description  string         // A detailed description.
+	Labels       string         // Keys and values of labels.
+	Annotations  string         // Keys and values of annotations.
+	Contents     string         // Text of the contents of specs.
+	UpdateTime   time.Time      // Time of last change.
+	DeletedAt    gorm.DeletedAt `gorm:"index"` // Deletion time, if deleted.
+}
+
+// NewApiSearchDocument returns the searchable text of an API.
+func NewApiSearchDocument(api *Api) *SearchDocument {
+	return &SearchDocument{
+		Key:         api.Name(),
+		ProjectID:   api.ProjectID,
+		ApiID:       api.ApiID,
+		ResourceID:  api.ApiID,
+		DisplayName: api.DisplayName,
+		Description: api.Description,
+		Labels:      searchableMap(api.Labels),
+		Annotations: searchableMap(api.Annotations),
+		UpdateTime:  api.UpdateTime,
+	}
+}
+
+// NewVersionSearchDocument returns the searchable text of a version.
+func NewVersionSearchDocument(version *Version) *SearchDocument {
+	return &SearchDocument{
+		Key:         version.Name(),
+		ProjectID:   version.ProjectID,
+		ApiID:       version.ApiID,
+		VersionID:   version.VersionID,
+		ResourceID:  version.VersionID,
+		DisplayName: version.DisplayName,
+		Description: version.Description,
+		Labels:      searchableMap(version.Labels),
+		Annotations: searchableMap(version.Annotations),
+		UpdateTime:  version.UpdateTime,
+	}
+}
+
+// NewSpecSearchDocument returns the searchable text of a spec revision and its contents.
+// Specs don't have display names, so they are represented by their file names.
+func NewSpecSearchDocument(spec *Spec, contents []byte) *SearchDocument {
+	return &SearchDocument{
+		Key:         spec.Name(),
+		ProjectID:   spec.ProjectID,
+		ApiID:       spec.ApiID,
+		VersionID:   spec.VersionID,
+		SpecID:      spec.SpecID,
+		ResourceID:  spec.SpecID,
+		DisplayName: spec.FileName,
+		Description: spec.Description,
+		Labels:      searchableMap(spec.Labels),
+		Annotations: searchableMap(spec.Annotations),
+		Contents:    searchableContents(spec.MimeType, contents),
+		UpdateTime:  spec.RevisionUpdateTime,
+	}
+}
+
+// NewDeploymentSearchDocument returns the searchable text of a deployment revision.
+func NewDeploymentSearchDocument(deployment *Deployment) *SearchDocument {
+	return &SearchDocument{
+		Key:          deployment.Name(),
+		ProjectID:    deployment.ProjectID,
+		ApiID:        deployment.ApiID,
+		DeploymentID: deployment.DeploymentID,
+		ResourceID:   deployment.DeploymentID,
+		DisplayName:  deployment.DisplayName,
+		Description:  deployment.Description,
+		Labels:       searchableMap(deployment.Labels),
+		Annotations:  searchableMap(deployment.Annotations),
+		UpdateTime:   deployment.RevisionUpdateTime,
+	}
+}
+
+// searchableMap returns the keys and values of a serialized map as lines of text.
+func searchableMap(b []byte) string {
+	entries, err := mapForBytes(b)
+	if err != nil || len(entries) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(entries))
+	for k := range entries {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var text strings.Builder
+	for _, k := range keys {
+		text.WriteString(k + " " + entries[k] + "\n")
+	}
+	return text.String()
+}
+
+// searchableContents returns the text of the contents of a spec. Compressed contents
+// are expanded and the text files of zip archives, such as those of protocol buffer
+// specs, are concatenated. Contents that aren't text aren't searchable.
+func searchableContents(mimeType string, contents []byte) string {
+	if strings.Contains(mimeType, "+gzip") {
+		var err error
+		if contents, err = GUnzippedBytes(contents); err != nil {
+			return ""
+		}
+	}
+	if strings.Contains(mimeType, "+zip") {
+		return truncateText(zippedText(contents))
+	}
+	if !utf8.Valid(contents) {
+		return ""
+	}
+	return truncateText(string(contents))
+}
+
+func zippedText(contents []byte) string {
+	zr, err := zip.NewReader(bytes.NewReader(contents), int64(len(contents)))
+	if err != nil {
+		return ""
+	}
+	var text strings.Builder
+	for _, f := range zr.File {
+		if text.Len() >= MaxSearchableContents {
+			break
+		}
+		r, err := f.Open()
+		if err != nil {
+			continue
+		}
+		b, err := io.ReadAll(io.LimitReader(r, MaxSearchableContents))
+		r.Close()
+		if err != nil || !utf8.Valid(b) {
+			continue
+		}
+		text.WriteString(f.Name + "\n")
+		text.Write(b)
+		text.WriteString("\n")
+	}
+	return text.String()
+}
+
+// truncateText limits text to MaxSearchableContents bytes without splitting characters.
+func truncateText(text string) string {
+	if len(text) <= MaxSearchableContents {
+		return text
+	}
+	return strings.ToValidUTF8(text[:MaxSearchableContents], "")
+}
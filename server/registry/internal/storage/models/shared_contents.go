@@ -0,0 +1,25 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import "time"
+
+// SharedContents holds contents in the database that are shared by all blobs
+// with the same hash, when contents are not stored in a blob store.
+type SharedContents struct {
+	Key        string    `gorm:"primaryKey"` // Location of the contents, which blobs refer to.
+	Contents   []byte    // The contents.
+	CreateTime time.Time // Creation time.
+}
@@ -27,27 +27,29 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/fieldmaskpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
 )
 
 // Spec is the storage-side representation of a spec.
 type Spec struct {
-	Key                string    `gorm:"primaryKey"`
-	ProjectID          string    // Uniquely identifies a project.
-	ApiID              string    // Uniquely identifies an api within a project.
-	VersionID          string    // Uniquely identifies a version within an api.
-	SpecID             string    // Uniquely identifies a spec within a version.
-	RevisionID         string    // Uniquely identifies a revision of a spec.
-	Description        string    // A detailed description.
-	CreateTime         time.Time // Creation time.
-	RevisionCreateTime time.Time // Revision creation time.
-	RevisionUpdateTime time.Time // Time of last change.
-	MimeType           string    // Spec format.
-	SizeInBytes        int32     // Size of the spec.
-	Hash               string    // A hash of the spec.
-	FileName           string    // Name of spec file.
-	SourceURI          string    // The original source URI of the spec.
-	Labels             []byte    // Serialized labels.
-	Annotations        []byte    // Serialized annotations.
+	Key                string         `gorm:"primaryKey"`
+	ProjectID          string         // Uniquely identifies a project.
+	ApiID              string         // Uniquely identifies an api within a project.
+	VersionID          string         // Uniquely identifies a version within an api.
+	SpecID             string         // Uniquely identifies a spec within a version.
+	RevisionID         string         // Uniquely identifies a revision of a spec.
+	Description        string         // A detailed description.
+	CreateTime         time.Time      // Creation time.
+	RevisionCreateTime time.Time      // Revision creation time.
+	RevisionUpdateTime time.Time      // Time of last change.
+	DeletedAt          gorm.DeletedAt `gorm:"index"` // Deletion time, if deleted.
+	MimeType           string         // Spec format.
+	SizeInBytes        int32          // Size of the spec.
+	Hash               string         // A hash of the spec.
+	FileName           string         // Name of spec file.
+	SourceURI          string         // The original source URI of the spec.
+	Labels             []byte         // Serialized labels.
+	Annotations        []byte         // Serialized annotations.
 	ParentVersionKey   string
 	ParentVersion      *Version `gorm:"foreignKey:ParentVersionKey;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 }
@@ -149,6 +151,8 @@ func (s *Spec) BasicMessage(name string) (message *rpc.ApiSpec, err error) {
 		CreateTime:         timestamppb.New(s.CreateTime),
 		RevisionCreateTime: timestamppb.New(s.RevisionCreateTime),
 		RevisionUpdateTime: timestamppb.New(s.RevisionUpdateTime),
+		DeleteTime:         deleteTime(s.DeletedAt),
+		Etag:               s.Etag(),
 	}
 
 	message.Labels, err = mapForBytes(s.Labels)
@@ -248,15 +252,16 @@ func hashForBytes(b []byte) string {
 
 // SpecRevisionTag is the storage-side representation of a spec revision tag.
 type SpecRevisionTag struct {
-	Key           string    `gorm:"primaryKey"`
-	ProjectID     string    // Uniquely identifies a project.
-	ApiID         string    // Uniquely identifies an api within a project.
-	VersionID     string    // Uniquely identifies a version within an api.
-	SpecID        string    // Uniquely identifies a spec within a version.
-	RevisionID    string    // Uniquely identifies a revision of a spec.
-	Tag           string    // The tag to use for the revision.
-	CreateTime    time.Time // Creation time.
-	UpdateTime    time.Time // Time of last change.
+	Key           string         `gorm:"primaryKey"`
+	ProjectID     string         // Uniquely identifies a project.
+	ApiID         string         // Uniquely identifies an api within a project.
+	VersionID     string         // Uniquely identifies a version within an api.
+	SpecID        string         // Uniquely identifies a spec within a version.
+	RevisionID    string         // Uniquely identifies a revision of a spec.
+	Tag           string         // The tag to use for the revision.
+	CreateTime    time.Time      // Creation time.
+	UpdateTime    time.Time      // Time of last change.
+	DeletedAt     gorm.DeletedAt `gorm:"index"` // Deletion time, if deleted.
 	ParentSpecKey string
 	ParentSpec    *Spec `gorm:"foreignKey:ParentSpecKey;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 }
@@ -281,3 +286,8 @@ func (t *SpecRevisionTag) String() string {
 	return fmt.Sprintf("projects/%s/locations/%s/apis/%s/versions/%s/specs/%s@%s",
 		t.ProjectID, names.Location, t.ApiID, t.VersionID, t.SpecID, t.Tag)
 }
+
+// Etag returns the etag of the spec revision.
+func (s *Spec) Etag() string {
+	return etag(s.RevisionName(), s.RevisionUpdateTime)
+}
@@ -0,0 +1,126 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/names"
+	"google.golang.org/protobuf/proto"
+	"gorm.io/gorm"
+)
+
+// SpecIndexMimeType is the MIME type of artifacts that contain the indexes of specs.
+const SpecIndexMimeType = "application/octet-stream;type=google.cloud.apigeeregistry.v1.apihub.SpecIndex"
+
+// SpecElement is the storage-side representation of an operation, schema, message or service
+// listed in the index of a spec revision. Elements are stored with the IDs of the artifact that
+// contains the index, so that they are deleted and undeleted with it.
+type SpecElement struct {
+	Key        string         `gorm:"primaryKey"`
+	ProjectID  string         // Uniquely identifies a project.
+	ApiID      string         // Uniquely identifies an api within a project.
+	VersionID  string         // Uniquely identifies a version within an api.
+	SpecID     string         // Uniquely identifies a spec within a version.
+	RevisionID string         // Uniquely identifies a revision of a spec.
+	ArtifactID string         // Uniquely identifies the artifact that contains the index.
+	Spec       string         // Name of the spec revision.
+	Kind       string         // Kind of the element.
+	Name       string         `gorm:"index"` // Name of the element.
+	Method     string         // HTTP method of an operation.
+	Path       string         `gorm:"index"` // Path template of an operation.
+	Service    string         // Service of an RPC.
+	CreateTime time.Time      // Creation time.
+	DeletedAt  gorm.DeletedAt `gorm:"index"` // Deletion time, if deleted.
+}
+
+// Message returns a message representing a spec element.
+func (e *SpecElement) Message() *rpc.SpecElement {
+	return &rpc.SpecElement{
+		Spec:    e.Spec,
+		Kind:    e.Kind,
+		Name:    e.Name,
+		Method:  e.Method,
+		Path:    e.Path,
+		Service: e.Service,
+	}
+}
+
+// NewArtifactSpecElements returns the elements of the spec index in the contents of an artifact.
+// Only indexes stored in artifacts of specs have elements.
+func NewArtifactSpecElements(artifact *Artifact, contents []byte) []*SpecElement {
+	if artifact.SpecID == "" || strings.TrimSuffix(artifact.MimeType, "+gzip") != SpecIndexMimeType {
+		return nil
+	}
+	if strings.HasSuffix(artifact.MimeType, "+gzip") {
+		var err error
+		if contents, err = GUnzippedBytes(contents); err != nil {
+			return nil
+		}
+	}
+	index := new(rpc.SpecIndex)
+	if proto.Unmarshal(contents, index) != nil {
+		return nil
+	}
+
+	base := SpecElement{
+		ProjectID:  artifact.ProjectID,
+		ApiID:      artifact.ApiID,
+		VersionID:  artifact.VersionID,
+		SpecID:     artifact.SpecID,
+		RevisionID: artifact.RevisionID,
+		ArtifactID: artifact.ArtifactID,
+		Spec: names.SpecRevision{
+			ProjectID:  artifact.ProjectID,
+			ApiID:      artifact.ApiID,
+			VersionID:  artifact.VersionID,
+			SpecID:     artifact.SpecID,
+			RevisionID: artifact.RevisionID,
+		}.String(),
+		CreateTime: time.Now().Round(time.Microsecond),
+	}
+	var elements []*SpecElement
+	seen := make(map[string]bool)
+	add := func(kind, name, method, path, service string) {
+		key := artifact.Name() + "#" + kind + "#" + name + "#" + method + " " + path
+		if name == "" && path == "" || seen[key] {
+			return
+		}
+		seen[key] = true
+		e := base
+		e.Key = key
+		e.Kind = kind
+		e.Name = name
+		e.Method = method
+		e.Path = path
+		e.Service = service
+		elements = append(elements, &e)
+	}
+	for _, op := range index.GetOperations() {
+		add("operation", op.GetId(), op.GetMethod(), op.GetPath(), op.GetService())
+	}
+	for _, name := range index.GetSchemas() {
+		add("schema", name, "", "", "")
+	}
+	for _, name := range index.GetMessages() {
+		add("message", name, "", "", "")
+	}
+	for _, name := range index.GetServices() {
+		add("service", name, "", "", "")
+	}
+	return elements
+}
@@ -15,18 +15,46 @@
 package models
 
 import (
+	"bytes"
+	"encoding/json"
+
 	"github.com/apigee/registry/rpc"
 	"google.golang.org/protobuf/proto"
 )
 
+// Maps are stored as JSON objects, which databases can query. Maps that were
+// stored before are serialized rpc.Map messages, which never start with "{".
 func bytesForMap(entries map[string]string) ([]byte, error) {
-	return proto.Marshal(&rpc.Map{Entries: entries})
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(entries)
 }
 
 func mapForBytes(b []byte) (map[string]string, error) {
+	if bytes.HasPrefix(b, []byte("{")) {
+		var entries map[string]string
+		if err := json.Unmarshal(b, &entries); err != nil {
+			return nil, err
+		}
+		return entries, nil
+	}
 	m := &rpc.Map{}
 	if err := proto.Unmarshal(b, m); err != nil {
 		return nil, err
 	}
 	return m.Entries, nil
 }
+
+// JSONForMapBytes converts a serialized map to JSON if it was stored before maps were
+// stored as JSON. It returns nil if the map is already JSON or empty.
+func JSONForMapBytes(b []byte) ([]byte, error) {
+	if len(b) == 0 || bytes.HasPrefix(b, []byte("{")) {
+		return nil, nil
+	}
+	entries, err := mapForBytes(b)
+	if err != nil {
+		return nil, err
+	}
+	return bytesForMap(entries)
+}
@@ -21,22 +21,24 @@ import (
 	"github.com/apigee/registry/server/registry/names"
 	"google.golang.org/protobuf/types/known/fieldmaskpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
 )
 
 // Version is the storage-side representation of a version.
 type Version struct {
-	Key          string    `gorm:"primaryKey"`
-	ProjectID    string    // Uniquely identifies a project.
-	ApiID        string    // Uniquely identifies an api within a project.
-	VersionID    string    // Uniquely identifies a version within an api.
-	DisplayName  string    // A human-friendly name.
-	Description  string    // A detailed description.
-	CreateTime   time.Time // Creation time.
-	UpdateTime   time.Time // Time of last change.
-	State        string    // Lifecycle stage.
-	Labels       []byte    // Serialized labels.
-	Annotations  []byte    // Serialized annotations.
-	PrimarySpec  string    // Primary Spec for this version.
+	Key          string         `gorm:"primaryKey"`
+	ProjectID    string         // Uniquely identifies a project.
+	ApiID        string         // Uniquely identifies an api within a project.
+	VersionID    string         // Uniquely identifies a version within an api.
+	DisplayName  string         // A human-friendly name.
+	Description  string         // A detailed description.
+	CreateTime   time.Time      // Creation time.
+	UpdateTime   time.Time      // Time of last change.
+	DeletedAt    gorm.DeletedAt `gorm:"index"` // Deletion time, if deleted.
+	State        string         // Lifecycle stage.
+	Labels       []byte         // Serialized labels.
+	Annotations  []byte         // Serialized annotations.
+	PrimarySpec  string         // Primary Spec for this version.
 	ParentApiKey string
 	ParentApi    *Api `gorm:"foreignKey:ParentApiKey;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 }
@@ -89,6 +91,8 @@ func (v *Version) Message() (message *rpc.ApiVersion, err error) {
 		PrimarySpec: v.PrimarySpec,
 		CreateTime:  timestamppb.New(v.CreateTime),
 		UpdateTime:  timestamppb.New(v.UpdateTime),
+		DeleteTime:  deleteTime(v.DeletedAt),
+		Etag:        v.Etag(),
 	}
 
 	message.Labels, err = v.LabelsMap()
@@ -137,3 +141,8 @@ func (v *Version) Update(message *rpc.ApiVersion, mask *fieldmaskpb.FieldMask) e
 func (v *Version) LabelsMap() (map[string]string, error) {
 	return mapForBytes(v.Labels)
 }
+
+// Etag returns the etag of the version.
+func (v *Version) Etag() string {
+	return etag(v.Name(), v.UpdateTime)
+}
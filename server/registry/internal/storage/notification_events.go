@@ -0,0 +1,212 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/storage/filtering"
+	"github.com/apigee/registry/server/registry/internal/storage/models"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var notificationEventFields = map[string]filtering.FieldType{
+	"id":                filtering.Int,
+	"sink":              filtering.String,
+	"state":             filtering.String,
+	"attempts":          filtering.Int,
+	"next_attempt_time": filtering.Timestamp,
+	"last_error":        filtering.String,
+	"change":            filtering.String,
+	"resource":          filtering.String,
+	"change_time":       filtering.Timestamp,
+}
+
+func notificationEventMap(e models.NotificationEvent) map[string]interface{} {
+	return map[string]interface{}{
+		"id":                e.ID,
+		"sink":              e.Sink,
+		"state":             e.State,
+		"attempts":          e.Attempts,
+		"next_attempt_time": e.NextAttemptTime,
+		"last_error":        e.LastError,
+		"change":            rpc.Notification_Change(e.Change).String(),
+		"resource":          e.Resource,
+		"change_time":       e.ChangeTime,
+	}
+}
+
+// CreateNotificationEvents stores events for delivery.
+func (c *Client) CreateNotificationEvents(ctx context.Context, v []*models.NotificationEvent) error {
+	if len(v) == 0 {
+		return nil
+	}
+	return c.create(ctx, v)
+}
+
+// SaveNotificationEvent updates the delivery state of an event.
+func (c *Client) SaveNotificationEvent(ctx context.Context, v *models.NotificationEvent) error {
+	// Attempt times are compared in queries. They are kept in UTC because
+	// SQLite compares times as strings.
+	v.NextAttemptTime = v.NextAttemptTime.UTC()
+	return c.save(ctx, v)
+}
+
+// DeleteNotificationEvent removes a delivered event.
+func (c *Client) DeleteNotificationEvent(ctx context.Context, id int64) error {
+	err := c.db.WithContext(ctx).Delete(&models.NotificationEvent{}, id).Error
+	return grpcErrorForDBError(ctx, errors.Wrapf(err, "delete notification event %d", id))
+}
+
+// ClaimNotificationEvents returns up to n pending events that are due for delivery at
+// the specified time and postpones their next attempts until the lease expires.
+// Claimed events are ignored by other callers until their leases expire, so events
+// that are not released by a dispatcher that stops unexpectedly are retried later.
+func (c *Client) ClaimNotificationEvents(ctx context.Context, now time.Time, lease time.Duration, n int) ([]models.NotificationEvent, error) {
+	now = now.UTC()
+	op := c.db.WithContext(ctx).
+		Where("state = ? AND next_attempt_time <= ?", rpc.NotificationEvent_PENDING.String(), now).
+		Order("id").
+		Limit(n)
+	// Row locks are unavailable in SQLite, where mutating transactions are serialized
+	// by its single writer connection instead.
+	if c.DatabaseName(ctx) != "sqlite" {
+		op = op.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"})
+	}
+
+	var events []models.NotificationEvent
+	if err := op.Find(&events).Error; err != nil {
+		return nil, grpcErrorForDBError(ctx, errors.Wrap(err, "find notification events"))
+	} else if len(events) == 0 {
+		return events, nil
+	}
+
+	ids := make([]int64, len(events))
+	for i := range events {
+		ids[i] = events[i].ID
+		events[i].NextAttemptTime = now.Add(lease)
+	}
+	err := c.db.WithContext(ctx).Model(&models.NotificationEvent{}).
+		Where("id IN ?", ids).
+		Update("next_attempt_time", now.Add(lease)).Error
+	if err != nil {
+		return nil, grpcErrorForDBError(ctx, errors.Wrap(err, "claim notification events"))
+	}
+	return events, nil
+}
+
+// PostponeNotificationEvents delays the next delivery attempts of events
+// without counting attempts.
+func (c *Client) PostponeNotificationEvents(ctx context.Context, ids []int64, next time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	err := c.db.WithContext(ctx).Model(&models.NotificationEvent{}).
+		Where("id IN ?", ids).
+		Update("next_attempt_time", next.UTC()).Error
+	return grpcErrorForDBError(ctx, errors.Wrap(err, "postpone notification events"))
+}
+
+// ReplayNotificationEvents schedules every failed or due event matching
+// the filter for immediate delivery and returns the number of events replayed.
+// Pending events that aren't due are skipped, because they are either claimed
+// by a dispatcher that is delivering them or waiting to be retried.
+func (c *Client) ReplayNotificationEvents(ctx context.Context, filter string, now time.Time) (int64, error) {
+	now = now.UTC()
+	f, err := c.newListFilter(filter, "notification_events")
+	if err != nil {
+		return 0, err
+	}
+	replayable := func(op *gorm.DB) *gorm.DB {
+		return op.Where("(state = ? OR next_attempt_time <= ?)", rpc.NotificationEvent_FAILED.String(), now)
+	}
+
+	var count int64
+	var last int64
+	for {
+		var page []models.NotificationEvent
+		err := replayable(f.where(c.db.WithContext(ctx))).Where("id > ?", last).Order("id").Limit(1000).Find(&page).Error
+		if err != nil {
+			return 0, grpcErrorForDBError(ctx, errors.Wrap(err, "find notification events"))
+		} else if len(page) == 0 {
+			return count, nil
+		}
+
+		ids := make([]int64, 0, len(page))
+		for _, v := range page {
+			last = v.ID
+			if match, err := f.Matches(notificationEventMap(v)); err != nil {
+				return 0, err
+			} else if match {
+				ids = append(ids, v.ID)
+			}
+		}
+		if len(ids) == 0 {
+			continue
+		}
+
+		// Events claimed since they were found are skipped.
+		result := replayable(c.db.WithContext(ctx).Model(&models.NotificationEvent{})).
+			Where("id IN ?", ids).
+			Updates(map[string]interface{}{
+				"state":             rpc.NotificationEvent_PENDING.String(),
+				"attempts":          0,
+				"next_attempt_time": now,
+			})
+		if err := result.Error; err != nil {
+			return 0, grpcErrorForDBError(ctx, errors.Wrap(err, "replay notification events"))
+		}
+		count += result.RowsAffected
+	}
+}
+
+// NotificationEventList contains a page of notification events.
+type NotificationEventList struct {
+	NotificationEvents []models.NotificationEvent
+	Token              string
+}
+
+// ListNotificationEvents lists stored notification events.
+func (c *Client) ListNotificationEvents(ctx context.Context, opts PageOptions) (NotificationEventList, error) {
+	response := NotificationEventList{
+		NotificationEvents: make([]models.NotificationEvent, 0, opts.Size),
+	}
+	var page []models.NotificationEvent
+	var err error
+	response.Token, err = c.listRows(ctx, "notification_events", c.db.WithContext(ctx), opts,
+		func(query *gorm.DB) (int, error) {
+			page = nil
+			err := query.Find(&page).Error
+			return len(page), err
+		},
+		func(i int) listRow {
+			m := notificationEventMap(page[i])
+			// Changes are filtered by name but sorted by number.
+			order := notificationEventMap(page[i])
+			order["change"] = page[i].Change
+			return listRow{key: page[i].ID, fields: m, order: order}
+		},
+		func(i int) {
+			response.NotificationEvents = append(response.NotificationEvents, page[i])
+		})
+	if err != nil {
+		return NotificationEventList{}, err
+	}
+	return response, nil
+}
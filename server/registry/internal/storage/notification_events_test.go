@@ -0,0 +1,86 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/storage/models"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestReplayNotificationEvents(t *testing.T) {
+	ctx := context.Background()
+	c, err := NewClient(ctx, "sqlite3", "file::memory:")
+	if err != nil {
+		t.Fatalf("NewClient() returned error: %s", err)
+	}
+	t.Cleanup(c.Close)
+	if err := c.EnsureTables(ctx); err != nil {
+		t.Fatalf("EnsureTables() returned error: %s", err)
+	}
+
+	now := time.Now().UTC()
+	event := func(resource string, state rpc.NotificationEvent_State, next time.Time) *models.NotificationEvent {
+		return &models.NotificationEvent{
+			Sink:            "webhook:test",
+			Resource:        resource,
+			State:           state.String(),
+			Attempts:        2,
+			NextAttemptTime: next,
+		}
+	}
+	if err := c.CreateNotificationEvents(ctx, []*models.NotificationEvent{
+		event("failed", rpc.NotificationEvent_FAILED, now.Add(-time.Hour)),
+		event("due", rpc.NotificationEvent_PENDING, now.Add(-time.Hour)),
+		event("expired", rpc.NotificationEvent_PENDING, now.Add(-time.Hour)),
+		event("leased", rpc.NotificationEvent_PENDING, now.Add(-time.Hour)),
+		event("postponed", rpc.NotificationEvent_PENDING, now.Add(time.Hour)),
+	}); err != nil {
+		t.Fatalf("CreateNotificationEvents() returned error: %s", err)
+	}
+	// One event is claimed by a dispatcher that is still delivering it,
+	// and another by one whose lease has expired.
+	for resource, next := range map[string]time.Time{"expired": now.Add(-time.Minute), "leased": now.Add(time.Minute)} {
+		if err := c.db.Model(&models.NotificationEvent{}).Where("resource = ?", resource).
+			Update("next_attempt_time", next).Error; err != nil {
+			t.Fatalf("Update() returned error: %s", err)
+		}
+	}
+
+	count, err := c.ReplayNotificationEvents(ctx, "", now)
+	if err != nil {
+		t.Fatalf("ReplayNotificationEvents() returned error: %s", err)
+	}
+	if count != 3 {
+		t.Errorf("ReplayNotificationEvents() replayed %d events, want 3", count)
+	}
+
+	var events []models.NotificationEvent
+	if err := c.db.Order("id").Find(&events).Error; err != nil {
+		t.Fatalf("Find() returned error: %s", err)
+	}
+	got := make(map[string]int32)
+	for _, e := range events {
+		got[e.Resource] = e.Attempts
+	}
+	want := map[string]int32{"failed": 0, "due": 0, "expired": 0, "leased": 2, "postponed": 2}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("attempts after replay are unexpected (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,216 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/apigee/registry/server/registry/internal/storage/filtering"
+	"github.com/apigee/registry/server/registry/internal/storage/models"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+var operationFields = map[string]filtering.FieldType{
+	"name":        filtering.String,
+	"method":      filtering.String,
+	"done":        filtering.Bool,
+	"create_time": filtering.Timestamp,
+	"update_time": filtering.Timestamp,
+}
+
+func operationMap(o models.Operation) map[string]interface{} {
+	return map[string]interface{}{
+		"name":        o.Key,
+		"method":      o.Method,
+		"done":        o.Done,
+		"create_time": o.CreateTime,
+		"update_time": o.UpdateTime,
+	}
+}
+
+// CreateOperation stores a new operation.
+func (c *Client) CreateOperation(ctx context.Context, v *models.Operation) error {
+	return c.create(ctx, v)
+}
+
+// GetOperation returns an operation.
+func (c *Client) GetOperation(ctx context.Context, name string) (*models.Operation, error) {
+	v := new(models.Operation)
+	if err := c.db.WithContext(ctx).Take(v, "key = ?", name).Error; err == gorm.ErrRecordNotFound {
+		return nil, status.Errorf(codes.NotFound, "%q not found in database", name)
+	} else if err != nil {
+		return nil, grpcErrorForDBError(ctx, errors.Wrapf(err, "get %s", name))
+	}
+	return v, nil
+}
+
+// SaveOperation stores the result of an operation.
+func (c *Client) SaveOperation(ctx context.Context, v *models.Operation) error {
+	return c.save(ctx, v)
+}
+
+// UpdateOperationMetadata stores the progress of a running operation.
+func (c *Client) UpdateOperationMetadata(ctx context.Context, name string, metadata []byte) error {
+	err := c.db.WithContext(ctx).Model(&models.Operation{}).
+		Where("key = ? AND NOT done", name).
+		Updates(map[string]interface{}{
+			"metadata":    metadata,
+			"update_time": time.Now().Round(time.Microsecond),
+		}).Error
+	return grpcErrorForDBError(ctx, errors.Wrapf(err, "update %s", name))
+}
+
+// CancelOperation asks a running operation to stop. Operations that are done are unchanged.
+func (c *Client) CancelOperation(ctx context.Context, name string) error {
+	if _, err := c.GetOperation(ctx, name); err != nil {
+		return err
+	}
+	err := c.db.WithContext(ctx).Model(&models.Operation{}).
+		Where("key = ? AND NOT done", name).
+		Update("cancel_requested", true).Error
+	return grpcErrorForDBError(ctx, errors.Wrapf(err, "cancel %s", name))
+}
+
+// DeleteOperation removes an operation.
+func (c *Client) DeleteOperation(ctx context.Context, name string) error {
+	op := c.db.WithContext(ctx).Delete(&models.Operation{}, "key = ?", name)
+	if err := op.Error; err != nil {
+		return grpcErrorForDBError(ctx, errors.Wrapf(err, "delete %s", name))
+	} else if op.RowsAffected == 0 {
+		return status.Errorf(codes.NotFound, "%q not found in database", name)
+	}
+	return nil
+}
+
+// RenewOperationLeases extends the leases of running operations until the specified time.
+// It returns the names of the operations that have been asked to stop.
+func (c *Client) RenewOperationLeases(ctx context.Context, names []string, until time.Time) ([]string, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	err := c.db.WithContext(ctx).Model(&models.Operation{}).
+		Where("key IN ? AND NOT done", names).
+		Update("lease_time", until.UTC()).Error
+	if err != nil {
+		return nil, grpcErrorForDBError(ctx, errors.Wrap(err, "renew operation leases"))
+	}
+	var canceled []string
+	err = c.db.WithContext(ctx).Model(&models.Operation{}).
+		Where("key IN ? AND cancel_requested", names).
+		Pluck("key", &canceled).Error
+	return canceled, grpcErrorForDBError(ctx, errors.Wrap(err, "find canceled operations"))
+}
+
+// AbandonOperations marks running operations with leases that expired before
+// the specified time as failed with an error status, which must be serialized.
+// Leases expire when the servers running the operations stop unexpectedly.
+// It returns the number of operations abandoned.
+func (c *Client) AbandonOperations(ctx context.Context, now time.Time, errorStatus []byte) (int64, error) {
+	op := c.db.WithContext(ctx).Model(&models.Operation{}).
+		Where("NOT done AND lease_time < ?", now.UTC()).
+		Updates(map[string]interface{}{
+			"done":        true,
+			"error":       errorStatus,
+			"update_time": now.Round(time.Microsecond),
+		})
+	return op.RowsAffected, grpcErrorForDBError(ctx, errors.Wrap(op.Error, "abandon operations"))
+}
+
+// OperationList contains a page of operations.
+type OperationList struct {
+	Operations []models.Operation
+	Token      string
+}
+
+func (c *Client) ListOperations(ctx context.Context, opts PageOptions) (OperationList, error) {
+	token, err := decodeToken(opts.Token)
+	if err != nil {
+		return OperationList{}, status.Errorf(codes.InvalidArgument, "invalid page token %q: %s", opts.Token, err.Error())
+	}
+
+	if err := token.ValidateFilter(opts.Filter); err != nil {
+		return OperationList{}, status.Errorf(codes.InvalidArgument, "invalid filter %q: %s", opts.Filter, err)
+	} else {
+		token.Filter = opts.Filter
+	}
+
+	if err := token.ValidateOrder(opts.Order); err != nil {
+		return OperationList{}, status.Errorf(codes.InvalidArgument, "invalid order_by %q: %s", opts.Order, err)
+	} else {
+		token.Order = opts.Order
+	}
+
+	filter, err := c.newListFilter(opts.Filter, "operations")
+	if err != nil {
+		return OperationList{}, err
+	}
+
+	order, err := newOrdering(opts.Order, "operations")
+	if err != nil {
+		return OperationList{}, err
+	}
+	op := filter.where(c.db.WithContext(ctx)).Order(order.String()).Limit(limit(opts, filter))
+
+	response := OperationList{
+		Operations: make([]models.Operation, 0, opts.Size),
+	}
+
+	for {
+		var page []models.Operation
+		query, err := token.query(op, order)
+		if err != nil {
+			return OperationList{}, status.Errorf(codes.InvalidArgument, "invalid page token %q: %s", opts.Token, err)
+		}
+		err = query.Find(&page).Error
+
+		if err != nil {
+			return OperationList{}, grpcErrorForDBError(ctx, errors.Wrapf(err, "find %#v", token))
+		} else if len(page) == 0 {
+			break
+		}
+
+		for _, v := range page {
+			m := operationMap(v)
+			position := order.position(v.Key, m)
+			match, err := filter.Matches(m)
+			if err != nil {
+				return OperationList{}, err
+			} else if !match {
+				token.advance(position)
+				continue
+			}
+
+			if len(response.Operations) == int(opts.Size) {
+				response.Token, err = encodeToken(token)
+				if err != nil {
+					return OperationList{}, status.Error(codes.Internal, err.Error())
+				}
+				return response, nil
+			}
+
+			token.advance(position)
+			response.Operations = append(response.Operations, v)
+		}
+		if query.RowsAffected < int64(opts.Size) {
+			break
+		}
+	}
+
+	return response, nil
+}
@@ -19,8 +19,16 @@ import (
 	"encoding/base64"
 	"encoding/gob"
 	"fmt"
+	"time"
+
+	"gorm.io/gorm"
 )
 
+func init() {
+	// Positions in listings can contain timestamps.
+	gob.Register(time.Time{})
+}
+
 // PageOptions contains custom arguments for listing requests.
 type PageOptions struct {
 	// Size is the maximum number of resources to include in the response.
@@ -34,13 +42,19 @@ type PageOptions struct {
 	// If specified, listing will continue from the end of the previous page. Otherwise,
 	// the first page in a listing series will be returned.
 	Token string
+	// ShowDeleted includes deleted resources in the listing, as described at https://google.aip.dev/164.
+	ShowDeleted bool
 }
 
 // token contains information to share between sequential page iterators.
 type token struct {
 	// Offset is the number of resources that should be skipped before the page begins.
-	// It should be set to the number of resources already returned.
+	// It is only set in tokens that were issued before listings were paginated by
+	// position, and is replaced by a position when the listing continues.
 	Offset int
+	// After is the position of the last resource that was read, as returned by ordering.position.
+	// The page begins with the resource that follows it.
+	After []interface{}
 	// Filter is the filter string for this listing request. It should be consistent between sequential pages.
 	Filter string
 	// Order is the sorting order for this listing request. It should be consistent between sequential pages.
@@ -50,7 +64,7 @@ type token struct {
 // ValidateFilter returns an error if the new filter doesn't match the token's encoded filter.
 // When the token represents the first page, any filter is valid and no error will be returned.
 func (t token) ValidateFilter(newFilter string) error {
-	if t.Offset > 0 && newFilter != t.Filter {
+	if t.continues() && newFilter != t.Filter {
 		return fmt.Errorf("new filter does not match previous filter %q", t.Filter)
 	}
 
@@ -61,13 +75,39 @@ func (t token) ValidateFilter(newFilter string) error {
 // if the format of the ordering string is invalid.
 // When the token represents the first page, any order is valid and no error will be returned.
 func (t token) ValidateOrder(newOrder string) error {
-	if t.Offset > 0 && newOrder != t.Order {
+	if t.continues() && newOrder != t.Order {
 		return fmt.Errorf("new order does not match previous order %q", t.Order)
 	}
 
 	return nil
 }
 
+// continues returns true if the token doesn't represent the first page.
+func (t token) continues() bool {
+	return t.Offset > 0 || len(t.After) > 0
+}
+
+// advance moves the token past a resource at a position in the listing.
+func (t *token) advance(position []interface{}) {
+	t.Offset = 0
+	t.After = position
+}
+
+// query restricts a listing query to the resources that follow the token.
+// Rows are selected by their position, so pages don't depend on the number
+// of rows before them, which can change between requests.
+func (t token) query(op *gorm.DB, order ordering) (*gorm.DB, error) {
+	op = op.Session(&gorm.Session{})
+	if len(t.After) == 0 {
+		return op.Offset(t.Offset), nil
+	}
+	if len(t.After) != len(order) {
+		return nil, fmt.Errorf("position has %d values, want %d", len(t.After), len(order))
+	}
+	cond, args := order.after(t.After)
+	return op.Where(cond, args...), nil
+}
+
 // encodeToken converts a token struct into an opaque string that can be converted back into struct form using decodeToken().
 func encodeToken(o token) (string, error) {
 	var encoding bytes.Buffer
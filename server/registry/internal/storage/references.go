@@ -0,0 +1,239 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+
+	"github.com/apigee/registry/server/registry/internal/storage/filtering"
+	"github.com/apigee/registry/server/registry/internal/storage/models"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+var referenceFields = map[string]filtering.FieldType{
+	"source": filtering.String,
+	"field":  filtering.String,
+	"target": filtering.String,
+}
+
+func referenceMap(r models.ResourceReference) map[string]interface{} {
+	return map[string]interface{}{
+		"source": r.Source,
+		"field":  r.Field,
+		"target": r.Target,
+	}
+}
+
+// SaveReferences replaces the references from a resource.
+func (c *Client) SaveReferences(ctx context.Context, source string, refs []*models.ResourceReference) error {
+	op := c.db.WithContext(ctx).Unscoped().Where("source = ?", source).Delete(&models.ResourceReference{})
+	if err := op.Error; err != nil {
+		return grpcErrorForDBError(ctx, errors.Wrapf(err, "delete references from %s", source))
+	}
+	if len(refs) == 0 {
+		return nil
+	}
+	return c.create(ctx, refs)
+}
+
+// ReferenceList contains a page of references.
+type ReferenceList struct {
+	References []models.ResourceReference
+	Token      string
+}
+
+// ListReferences lists the references from a resource and from its artifacts,
+// including the artifacts of its revisions.
+func (c *Client) ListReferences(ctx context.Context, name string, opts PageOptions) (ReferenceList, error) {
+	return c.listReferences(ctx, opts, func(db *gorm.DB) *gorm.DB {
+		return db.Where("source = ? OR source LIKE ? OR source LIKE ?", name, name+"/artifacts/%", name+"@%/artifacts/%")
+	})
+}
+
+// ListReferrers lists the references to a resource, to its revisions and to its children.
+func (c *Client) ListReferrers(ctx context.Context, name string, opts PageOptions) (ReferenceList, error) {
+	return c.listReferences(ctx, opts, func(db *gorm.DB) *gorm.DB {
+		return db.Where("target = ? OR target LIKE ? OR target LIKE ?", name, name+"@%", name+"/%")
+	})
+}
+
+// ExternalReferrers returns up to limit references to a resource, its revisions or its children
+// from resources that aren't the resource or its children. These references are left without
+// targets when the resource is deleted.
+func (c *Client) ExternalReferrers(ctx context.Context, name string, limit int) ([]models.ResourceReference, error) {
+	var refs []models.ResourceReference
+	err := c.db.WithContext(ctx).
+		Where("target = ? OR target LIKE ? OR target LIKE ?", name, name+"@%", name+"/%").
+		Where("source <> ? AND source NOT LIKE ? AND source NOT LIKE ?", name, name+"@%", name+"/%").
+		Order("source, field, target").
+		Limit(limit).
+		Find(&refs).Error
+	if err != nil {
+		return nil, grpcErrorForDBError(ctx, errors.Wrapf(err, "referrers of %s", name))
+	}
+	return refs, nil
+}
+
+func (c *Client) listReferences(ctx context.Context, opts PageOptions, scope func(*gorm.DB) *gorm.DB) (ReferenceList, error) {
+	token, err := decodeToken(opts.Token)
+	if err != nil {
+		return ReferenceList{}, status.Errorf(codes.InvalidArgument, "invalid page token %q: %s", opts.Token, err.Error())
+	}
+
+	if err := token.ValidateFilter(opts.Filter); err != nil {
+		return ReferenceList{}, status.Errorf(codes.InvalidArgument, "invalid filter %q: %s", opts.Filter, err)
+	} else {
+		token.Filter = opts.Filter
+	}
+
+	filter, err := c.newListFilter(opts.Filter, "resource_references")
+	if err != nil {
+		return ReferenceList{}, err
+	}
+
+	order, err := newOrdering("", "resource_references")
+	if err != nil {
+		return ReferenceList{}, err
+	}
+	op := filter.where(c.db.WithContext(ctx).Scopes(scope)).Order(order.String()).Limit(limit(opts, filter))
+
+	response := ReferenceList{
+		References: make([]models.ResourceReference, 0, opts.Size),
+	}
+
+	for {
+		var page []models.ResourceReference
+		query, err := token.query(op, order)
+		if err != nil {
+			return ReferenceList{}, status.Errorf(codes.InvalidArgument, "invalid page token %q: %s", opts.Token, err)
+		}
+		err = query.Find(&page).Error
+
+		if err != nil {
+			return ReferenceList{}, grpcErrorForDBError(ctx, errors.Wrapf(err, "find %#v", token))
+		} else if len(page) == 0 {
+			break
+		}
+
+		for _, v := range page {
+			m := referenceMap(v)
+			position := order.position(v.Key, m)
+			match, err := filter.Matches(m)
+			if err != nil {
+				return ReferenceList{}, err
+			} else if !match {
+				token.advance(position)
+				continue
+			}
+
+			if len(response.References) == int(opts.Size) {
+				response.Token, err = encodeToken(token)
+				if err != nil {
+					return ReferenceList{}, status.Error(codes.Internal, err.Error())
+				}
+				return response, nil
+			}
+
+			token.advance(position)
+			response.References = append(response.References, v)
+		}
+		if query.RowsAffected < int64(opts.Size) {
+			break
+		}
+	}
+
+	return response, nil
+}
+
+// migrateResourceReferences creates the table of references and indexes the
+// references from existing resources. References from deleted resources are
+// recorded as deleted with them, so that they are restored if the resources are.
+func (c *Client) migrateResourceReferences(ctx context.Context) error {
+	if c.db.Migrator().HasTable(&models.ResourceReference{}) {
+		return nil
+	}
+	if err := c.ensureTable(ctx, &models.ResourceReference{}); err != nil {
+		return err
+	}
+	save := func(source string, deletedAt gorm.DeletedAt, refs []*models.ResourceReference) error {
+		for _, ref := range refs {
+			ref.DeletedAt = deletedAt
+		}
+		return c.SaveReferences(ctx, source, refs)
+	}
+
+	var apis []models.Api
+	if err := c.db.WithContext(ctx).Unscoped().FindInBatches(&apis, 1000, func(*gorm.DB, int) error {
+		for i := range apis {
+			if err := save(apis[i].Name(), apis[i].DeletedAt, models.NewApiReferences(&apis[i])); err != nil {
+				return err
+			}
+		}
+		return nil
+	}).Error; err != nil {
+		return err
+	}
+
+	var versions []models.Version
+	if err := c.db.WithContext(ctx).Unscoped().Where("primary_spec <> ''").FindInBatches(&versions, 1000, func(*gorm.DB, int) error {
+		for i := range versions {
+			if err := save(versions[i].Name(), versions[i].DeletedAt, models.NewVersionReferences(&versions[i])); err != nil {
+				return err
+			}
+		}
+		return nil
+	}).Error; err != nil {
+		return err
+	}
+
+	// Only the current revisions of deployments are indexed.
+	var deployments []models.Deployment
+	if err := c.latestDeploymentRevisionsQuery(ctx).Unscoped().
+		Where("d.api_spec_revision <> ''").Find(&deployments).Error; err != nil {
+		return err
+	}
+	for i := range deployments {
+		if err := save(deployments[i].Name(), deployments[i].DeletedAt, models.NewDeploymentReferences(&deployments[i])); err != nil {
+			return err
+		}
+	}
+
+	var artifacts []models.Artifact
+	if err := c.db.WithContext(ctx).Unscoped().Where("mime_type LIKE ?", "%.ReferenceList%").
+		FindInBatches(&artifacts, 1000, func(*gorm.DB, int) error {
+			for i := range artifacts {
+				v := &artifacts[i]
+				blob := new(models.Blob)
+				if err := c.db.WithContext(ctx).Unscoped().Take(blob, "key = ?", v.Name()).Error; err == gorm.ErrRecordNotFound {
+					continue
+				} else if err != nil {
+					return err
+				}
+				if err := c.getContents(ctx, blob); err != nil {
+					return err
+				}
+				if err := save(v.Name(), v.DeletedAt, models.NewArtifactReferences(v, blob.Contents)); err != nil {
+					return err
+				}
+			}
+			return nil
+		}).Error; err != nil {
+		return err
+	}
+	return nil
+}
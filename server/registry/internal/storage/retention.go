@@ -0,0 +1,368 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/apigee/registry/server/registry/internal/storage/models"
+	"github.com/apigee/registry/server/registry/names"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// RetentionPolicy selects the revisions of specs, deployments and artifacts that are kept.
+// A revision is kept if any rule of the policy keeps it. The latest revision
+// and tagged revisions are always kept.
+type RetentionPolicy struct {
+	// KeepRevisions is the number of latest revisions that are kept.
+	KeepRevisions int
+	// KeepAfter keeps revisions that were created after it, unless it is zero.
+	KeepAfter time.Time
+	// Referenced holds the names of revisions that are kept because other
+	// resources refer to them. See ReferencedRevisions.
+	Referenced map[string]bool
+}
+
+// kept returns the number of latest revisions that the policy keeps.
+func (p RetentionPolicy) kept() int {
+	if p.KeepRevisions < 1 {
+		return 1
+	}
+	return p.KeepRevisions
+}
+
+// Revision describes a revision that is not kept by a retention policy.
+type Revision struct {
+	Name       string
+	RevisionID string
+	CreateTime time.Time
+	// SizeBytes is the size of the contents of spec revisions.
+	SizeBytes int64
+}
+
+type revisionRow struct {
+	RevisionID         string
+	RevisionCreateTime time.Time
+	SizeInBytes        int32
+}
+
+// expired returns the revisions of a resource that are not kept by a policy.
+// Rows are sorted from the latest revision to the earliest.
+func (p RetentionPolicy) expired(rows []revisionRow, tagged map[string]bool, name func(id string) string) []Revision {
+	var expired []Revision
+	for i, row := range rows {
+		if i < p.kept() || tagged[row.RevisionID] || p.Referenced[name(row.RevisionID)] {
+			continue
+		}
+		if !p.KeepAfter.IsZero() && row.RevisionCreateTime.After(p.KeepAfter) {
+			continue
+		}
+		expired = append(expired, Revision{
+			Name:       name(row.RevisionID),
+			RevisionID: row.RevisionID,
+			CreateTime: row.RevisionCreateTime,
+			SizeBytes:  int64(row.SizeInBytes),
+		})
+	}
+	return expired
+}
+
+// ReferencedRevisions returns the names of the revisions of a spec or
+// deployment that are referred to by versions, deployments and APIs.
+// References to the latest revision are omitted.
+func (c *Client) ReferencedRevisions(ctx context.Context, name string) (map[string]bool, error) {
+	refs := make(map[string]bool)
+	for _, ref := range []struct {
+		model  interface{}
+		column string
+		parse  func(string) (string, error)
+	}{
+		{&models.Version{}, "primary_spec", parseSpecRevisionName},
+		{&models.Deployment{}, "api_spec_revision", parseSpecRevisionName},
+		{&models.Api{}, "recommended_deployment", parseDeploymentRevisionName},
+	} {
+		var values []string
+		if err := c.db.WithContext(ctx).Model(ref.model).
+			Where(ref.column+" LIKE ?", name+"@%").Distinct().Pluck(ref.column, &values).Error; err != nil {
+			return nil, grpcErrorForDBError(ctx, errors.Wrapf(err, "list references"))
+		}
+		for _, v := range values {
+			if name, err := ref.parse(v); err == nil {
+				refs[name] = true
+			}
+		}
+	}
+	return refs, nil
+}
+
+func parseSpecRevisionName(name string) (string, error) {
+	n, err := names.ParseSpecRevision(name)
+	return n.String(), err
+}
+
+func parseDeploymentRevisionName(name string) (string, error) {
+	n, err := names.ParseDeploymentRevision(name)
+	return n.String(), err
+}
+
+// ListSpecsWithRevisions returns the live specs in a project that have more
+// than a specified number of revisions. The project ID can be "-" for all projects.
+func (c *Client) ListSpecsWithRevisions(ctx context.Context, parent names.Project, more int) ([]names.Spec, error) {
+	op := c.db.WithContext(ctx).Model(&models.Spec{}).
+		Select("project_id, api_id, version_id, spec_id").
+		Group("project_id, api_id, version_id, spec_id").
+		Having("COUNT(*) > ?", more).
+		Order("project_id, api_id, version_id, spec_id")
+	if id := parent.ProjectID; id != "-" {
+		op = op.Where("project_id = ?", id)
+	}
+	var rows []struct {
+		ProjectID, ApiID, VersionID, SpecID string
+	}
+	if err := op.Scan(&rows).Error; err != nil {
+		return nil, grpcErrorForDBError(ctx, errors.Wrapf(err, "list specs"))
+	}
+	specs := make([]names.Spec, len(rows))
+	for i, r := range rows {
+		specs[i] = names.Spec{
+			ProjectID: r.ProjectID,
+			ApiID:     r.ApiID,
+			VersionID: r.VersionID,
+			SpecID:    r.SpecID,
+		}
+	}
+	return specs, nil
+}
+
+// ListDeploymentsWithRevisions returns the live deployments in a project that
+// have more than a specified number of revisions. The project ID can be "-" for all projects.
+func (c *Client) ListDeploymentsWithRevisions(ctx context.Context, parent names.Project, more int) ([]names.Deployment, error) {
+	op := c.db.WithContext(ctx).Model(&models.Deployment{}).
+		Select("project_id, api_id, deployment_id").
+		Group("project_id, api_id, deployment_id").
+		Having("COUNT(*) > ?", more).
+		Order("project_id, api_id, deployment_id")
+	if id := parent.ProjectID; id != "-" {
+		op = op.Where("project_id = ?", id)
+	}
+	var rows []struct {
+		ProjectID, ApiID, DeploymentID string
+	}
+	if err := op.Scan(&rows).Error; err != nil {
+		return nil, grpcErrorForDBError(ctx, errors.Wrapf(err, "list deployments"))
+	}
+	deployments := make([]names.Deployment, len(rows))
+	for i, r := range rows {
+		deployments[i] = names.Deployment{
+			ProjectID:    r.ProjectID,
+			ApiID:        r.ApiID,
+			DeploymentID: r.DeploymentID,
+		}
+	}
+	return deployments, nil
+}
+
+// ListArtifactsWithRevisions returns the live artifacts in a project that
+// have more than a specified number of revisions. The project ID can be "-" for all projects.
+func (c *Client) ListArtifactsWithRevisions(ctx context.Context, parent names.Project, more int) ([]names.Artifact, error) {
+	op := c.db.WithContext(ctx).Model(&models.ArtifactRevision{}).
+		Select("project_id, api_id, version_id, spec_id, revision_id, deployment_id, artifact_id").
+		Group("project_id, api_id, version_id, spec_id, revision_id, deployment_id, artifact_id").
+		Having("COUNT(*) > ?", more).
+		Order("project_id, api_id, version_id, spec_id, revision_id, deployment_id, artifact_id")
+	if id := parent.ProjectID; id != "-" {
+		op = op.Where("project_id = ?", id)
+	}
+	var rows []models.Artifact
+	if err := op.Scan(&rows).Error; err != nil {
+		return nil, grpcErrorForDBError(ctx, errors.Wrapf(err, "list artifacts"))
+	}
+	artifacts := make([]names.Artifact, 0, len(rows))
+	for _, r := range rows {
+		name, err := names.ParseArtifact(r.Name())
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		artifacts = append(artifacts, name)
+	}
+	return artifacts, nil
+}
+
+// ExpiredSpecRevisions returns the revisions of a spec that are not kept by a policy.
+func (c *Client) ExpiredSpecRevisions(ctx context.Context, name names.Spec, policy RetentionPolicy) ([]Revision, error) {
+	query := func(db *gorm.DB) *gorm.DB {
+		return db.Where("project_id = ?", name.ProjectID).
+			Where("api_id = ?", name.ApiID).
+			Where("version_id = ?", name.VersionID).
+			Where("spec_id = ?", name.SpecID)
+	}
+	var rows []revisionRow
+	if err := c.db.WithContext(ctx).Model(&models.Spec{}).Scopes(query).
+		Select("revision_id, revision_create_time, size_in_bytes").
+		Order("revision_create_time desc").Scan(&rows).Error; err != nil {
+		return nil, grpcErrorForDBError(ctx, errors.Wrapf(err, "list revisions of %s", name))
+	}
+	tagged, err := c.taggedRevisions(ctx, &models.SpecRevisionTag{}, "revision_id", query)
+	if err != nil {
+		return nil, err
+	}
+	return policy.expired(rows, tagged, func(id string) string {
+		return name.Revision(id).String()
+	}), nil
+}
+
+// ExpiredDeploymentRevisions returns the revisions of a deployment that are not kept by a policy.
+func (c *Client) ExpiredDeploymentRevisions(ctx context.Context, name names.Deployment, policy RetentionPolicy) ([]Revision, error) {
+	query := func(db *gorm.DB) *gorm.DB {
+		return db.Where("project_id = ?", name.ProjectID).
+			Where("api_id = ?", name.ApiID).
+			Where("deployment_id = ?", name.DeploymentID)
+	}
+	var rows []revisionRow
+	if err := c.db.WithContext(ctx).Model(&models.Deployment{}).Scopes(query).
+		Select("revision_id, revision_create_time").
+		Order("revision_create_time desc").Scan(&rows).Error; err != nil {
+		return nil, grpcErrorForDBError(ctx, errors.Wrapf(err, "list revisions of %s", name))
+	}
+	tagged, err := c.taggedRevisions(ctx, &models.DeploymentRevisionTag{}, "revision_id", query)
+	if err != nil {
+		return nil, err
+	}
+	return policy.expired(rows, tagged, func(id string) string {
+		return name.Revision(id).String()
+	}), nil
+}
+
+// ExpiredArtifactRevisions returns the revisions of an artifact that are not kept by a policy.
+// The name of the artifact must include the revision of its parent, if it has one.
+func (c *Client) ExpiredArtifactRevisions(ctx context.Context, name names.Artifact, policy RetentionPolicy) ([]Revision, error) {
+	query := artifactRevisionsQuery(name)
+	var rows []revisionRow
+	if err := c.db.WithContext(ctx).Model(&models.ArtifactRevision{}).Scopes(query).
+		Select("artifact_revision_id AS revision_id, update_time AS revision_create_time, size_in_bytes").
+		Order("update_time desc").Scan(&rows).Error; err != nil {
+		return nil, grpcErrorForDBError(ctx, errors.Wrapf(err, "list revisions of %s", name))
+	}
+	tagged, err := c.taggedRevisions(ctx, &models.ArtifactRevisionTag{}, "artifact_revision_id", query)
+	if err != nil {
+		return nil, err
+	}
+	return policy.expired(rows, tagged, func(id string) string {
+		return name.Revision(id).String()
+	}), nil
+}
+
+func artifactRevisionsQuery(name names.Artifact) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("project_id = ?", name.ProjectID()).
+			Where("api_id = ?", name.ApiID()).
+			Where("version_id = ?", name.VersionID()).
+			Where("spec_id = ?", name.SpecID()).
+			Where("revision_id = ?", name.RevisionID()).
+			Where("deployment_id = ?", name.DeploymentID()).
+			Where("artifact_id = ?", name.ArtifactID())
+	}
+}
+
+func (c *Client) taggedRevisions(ctx context.Context, model interface{}, column string, query func(*gorm.DB) *gorm.DB) (map[string]bool, error) {
+	var ids []string
+	if err := c.db.WithContext(ctx).Model(model).Scopes(query).
+		Distinct().Pluck(column, &ids).Error; err != nil {
+		return nil, grpcErrorForDBError(ctx, errors.Wrapf(err, "list tags"))
+	}
+	tagged := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		tagged[id] = true
+	}
+	return tagged, nil
+}
+
+// RemoveSpecRevisions permanently removes revisions of a spec with their
+// contents and artifacts. Unlike DeleteSpecRevision, it doesn't check that
+// the revisions exist or that other revisions remain.
+func (c *Client) RemoveSpecRevisions(ctx context.Context, name names.Spec, ids []string) error {
+	return c.removeRevisions(ctx, name.String(), "revision_id", ids, []interface{}{
+		models.Blob{},
+		models.ResourceReference{},
+		models.SpecElement{},
+		models.ArtifactRevisionTag{},
+		models.ArtifactRevision{},
+		models.Artifact{},
+		models.SpecRevisionTag{},
+		models.Spec{},
+	}, func(db *gorm.DB) *gorm.DB {
+		return db.Where("project_id = ?", name.ProjectID).
+			Where("api_id = ?", name.ApiID).
+			Where("version_id = ?", name.VersionID).
+			Where("spec_id = ?", name.SpecID)
+	})
+}
+
+// RemoveDeploymentRevisions permanently removes revisions of a deployment
+// with their artifacts. Unlike DeleteDeploymentRevision, it doesn't check
+// that the revisions exist or that other revisions remain.
+func (c *Client) RemoveDeploymentRevisions(ctx context.Context, name names.Deployment, ids []string) error {
+	return c.removeRevisions(ctx, name.String(), "revision_id", ids, []interface{}{
+		models.Blob{},
+		models.ResourceReference{},
+		models.ArtifactRevisionTag{},
+		models.ArtifactRevision{},
+		models.Artifact{},
+		models.DeploymentRevisionTag{},
+		models.Deployment{},
+	}, func(db *gorm.DB) *gorm.DB {
+		return db.Where("project_id = ?", name.ProjectID).
+			Where("api_id = ?", name.ApiID).
+			Where("deployment_id = ?", name.DeploymentID)
+	})
+}
+
+// RemoveArtifactRevisions permanently removes earlier revisions of an artifact
+// with their tags. The current revision of the artifact must not be removed.
+func (c *Client) RemoveArtifactRevisions(ctx context.Context, name names.Artifact, ids []string) error {
+	return c.removeRevisions(ctx, name.String(), "artifact_revision_id", ids, []interface{}{
+		models.ArtifactRevisionTag{},
+		models.ArtifactRevision{},
+	}, artifactRevisionsQuery(name))
+}
+
+func (c *Client) removeRevisions(ctx context.Context, name, column string, ids []string, tables []interface{}, query func(*gorm.DB) *gorm.DB) error {
+	// Revisions are removed in chunks to stay within the limits on query parameters.
+	const chunk = 500
+	for start := 0; start < len(ids); start += chunk {
+		end := start + chunk
+		if end > len(ids) {
+			end = len(ids)
+		}
+		// Children are removed before their parents to satisfy foreign key constraints.
+		removed := func(db *gorm.DB) *gorm.DB {
+			return query(db).Where(column+" IN ?", ids[start:end])
+		}
+		for _, model := range tables {
+			if err := c.releaseContents(ctx, model, removed); err != nil {
+				return err
+			}
+			op := c.db.WithContext(ctx).Unscoped().Scopes(removed)
+			if err := op.Delete(model).Error; err != nil {
+				return grpcErrorForDBError(ctx, errors.Wrapf(err, "remove revisions of %s", name))
+			}
+		}
+	}
+	return nil
+}
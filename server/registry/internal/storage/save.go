@@ -19,6 +19,7 @@ import (
 
 	"github.com/apigee/registry/server/registry/internal/storage/models"
 	"github.com/pkg/errors"
+	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
@@ -31,26 +32,47 @@ func (c *Client) SaveProject(ctx context.Context, v *models.Project) error {
 // SaveApi will return codes.NotFound if key not found
 func (c *Client) SaveApi(ctx context.Context, v *models.Api) error {
 	v.Key = v.Name()
-	return c.save(ctx, v)
+	if err := c.save(ctx, v); err != nil {
+		return err
+	}
+	if err := c.saveSearchDocument(ctx, models.NewApiSearchDocument(v)); err != nil {
+		return err
+	}
+	return c.SaveReferences(ctx, v.Name(), models.NewApiReferences(v))
 }
 
 // SaveVersion will return codes.NotFound if key not found
 func (c *Client) SaveVersion(ctx context.Context, v *models.Version) error {
 	v.Key = v.Name()
-	return c.save(ctx, v)
+	if err := c.save(ctx, v); err != nil {
+		return err
+	}
+	if err := c.saveSearchDocument(ctx, models.NewVersionSearchDocument(v)); err != nil {
+		return err
+	}
+	return c.SaveReferences(ctx, v.Name(), models.NewVersionReferences(v))
 }
 
 // SaveSpecRevision will upsert if key not found
 func (c *Client) SaveSpecRevision(ctx context.Context, v *models.Spec) error {
 	v.Key = v.RevisionName()
-	return c.save(ctx, v)
+	if err := c.save(ctx, v); err != nil {
+		return err
+	}
+	return c.saveSpecSearchDocument(ctx, v, nil)
 }
 
-// SaveSpecRevisionContents will create a new blob for the spec
+// SaveSpecRevisionContents will create a new blob for the spec and index its text
 func (c *Client) SaveSpecRevisionContents(ctx context.Context, spec *models.Spec, contents []byte) error {
 	v := models.NewBlobForSpec(spec, contents)
 	v.Key = spec.RevisionName()
-	return c.save(ctx, v)
+	if err := c.putContents(ctx, v, spec.MimeType); err != nil {
+		return err
+	}
+	if err := c.saveBlob(ctx, v); err != nil {
+		return err
+	}
+	return c.saveSpecSearchDocument(ctx, spec, contents)
 }
 
 // SaveSpecRevisionTag will upsert if key not found
@@ -62,7 +84,10 @@ func (c *Client) SaveSpecRevisionTag(ctx context.Context, v *models.SpecRevision
 // SaveDeploymentRevision will upsert if key not found
 func (c *Client) SaveDeploymentRevision(ctx context.Context, v *models.Deployment) error {
 	v.Key = v.RevisionName()
-	return c.save(ctx, v)
+	if err := c.save(ctx, v); err != nil {
+		return err
+	}
+	return c.indexDeployment(ctx, v)
 }
 
 // SaveDeploymentRevisionTag will upsert if key not found
@@ -77,10 +102,58 @@ func (c *Client) SaveArtifact(ctx context.Context, v *models.Artifact) error {
 	return c.save(ctx, v)
 }
 
-// SaveSpecRevisionContents will create a new blob for the artifact
+// SaveArtifactContents will create a new blob for the artifact and record
+// the artifact's current revision and the references and spec elements in its contents with it
 func (c *Client) SaveArtifactContents(ctx context.Context, artifact *models.Artifact, contents []byte) error {
 	v := models.NewBlobForArtifact(artifact, contents)
 	v.Key = artifact.Name()
+	if err := c.putContents(ctx, v, artifact.MimeType); err != nil {
+		return err
+	}
+	if err := c.saveBlob(ctx, v); err != nil {
+		return err
+	}
+	if err := c.SaveArtifactRevision(ctx, models.NewArtifactRevision(artifact, v.Location)); err != nil {
+		return err
+	}
+	if err := c.SaveReferences(ctx, artifact.Name(), models.NewArtifactReferences(artifact, contents)); err != nil {
+		return err
+	}
+	return c.SaveSpecElements(ctx, artifact, models.NewArtifactSpecElements(artifact, contents))
+}
+
+// SaveArtifactRevisionContents will record an earlier revision of an artifact
+// with its contents without changing the current revision of the artifact
+func (c *Client) SaveArtifactRevisionContents(ctx context.Context, revision *models.Artifact, contents []byte) error {
+	v := models.NewBlobForArtifact(revision, contents)
+	v.Key = revision.RevisionName()
+	if err := c.putContents(ctx, v, revision.MimeType); err != nil {
+		return err
+	}
+	return c.SaveArtifactRevision(ctx, models.NewArtifactRevision(revision, v.Location))
+}
+
+// SaveArtifactRevision will upsert if key not found
+func (c *Client) SaveArtifactRevision(ctx context.Context, v *models.ArtifactRevision) error {
+	v.Key = v.RevisionName()
+	return c.save(ctx, v)
+}
+
+// SaveArtifactRevisionTag will upsert if key not found
+func (c *Client) SaveArtifactRevisionTag(ctx context.Context, v *models.ArtifactRevisionTag) error {
+	v.Key = v.String()
+	return c.save(ctx, v)
+}
+
+// saveBlob will upsert if key not found and record the contents that the blob
+// referred to before as possibly unused
+func (c *Client) saveBlob(ctx context.Context, v *models.Blob) error {
+	replaced := func(db *gorm.DB) *gorm.DB {
+		return db.Where("key = ? AND location != ?", v.Key, v.Location)
+	}
+	if err := c.releaseContents(ctx, &models.Blob{}, replaced); err != nil {
+		return err
+	}
 	return c.save(ctx, v)
 }
 
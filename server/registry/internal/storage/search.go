@@ -0,0 +1,403 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/apigee/registry/server/registry/internal/storage/models"
+	"github.com/apigee/registry/server/registry/names"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// The full-text index of search documents is maintained by the database.
+// SQLite databases keep an external-content FTS5 table that is updated by
+// triggers. FTS5 is only compiled into the SQLite driver by the sqlite_fts5
+// build tag, so the table is created when a server that includes it starts.
+// PostgreSQL databases keep a generated tsvector column with a GIN index.
+var (
+	sqliteSearchIndex = []string{
+		`CREATE VIRTUAL TABLE IF NOT EXISTS search_index USING fts5(
+			resource_id, display_name, description, labels, annotations, contents,
+			content='search_documents', content_rowid='id', tokenize='unicode61')`,
+		// Matches are ranked by BM25 with these relative weights of the columns.
+		`INSERT INTO search_index(search_index, rank) VALUES ('rank', 'bm25(10.0, 10.0, 4.0, 2.0, 2.0, 1.0)')`,
+		`CREATE TRIGGER IF NOT EXISTS search_documents_ai AFTER INSERT ON search_documents BEGIN
+			INSERT INTO search_index(rowid, resource_id, display_name, description, labels, annotations, contents)
+			VALUES (new.id, new.resource_id, new.display_name, new.description, new.labels, new.annotations, new.contents);
+		END`,
+		`CREATE TRIGGER IF NOT EXISTS search_documents_ad AFTER DELETE ON search_documents BEGIN
+			INSERT INTO search_index(search_index, rowid, resource_id, display_name, description, labels, annotations, contents)
+			VALUES ('delete', old.id, old.resource_id, old.display_name, old.description, old.labels, old.annotations, old.contents);
+		END`,
+		`CREATE TRIGGER IF NOT EXISTS search_documents_au AFTER UPDATE OF
+			resource_id, display_name, description, labels, annotations, contents ON search_documents BEGIN
+			INSERT INTO search_index(search_index, rowid, resource_id, display_name, description, labels, annotations, contents)
+			VALUES ('delete', old.id, old.resource_id, old.display_name, old.description, old.labels, old.annotations, old.contents);
+			INSERT INTO search_index(rowid, resource_id, display_name, description, labels, annotations, contents)
+			VALUES (new.id, new.resource_id, new.display_name, new.description, new.labels, new.annotations, new.contents);
+		END`,
+		// Documents saved before the table was created are indexed.
+		`INSERT INTO search_index(search_index) VALUES ('rebuild')`,
+	}
+	postgresSearchIndex = []string{
+		`ALTER TABLE search_documents ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+			setweight(to_tsvector('simple', coalesce(resource_id, '') || ' ' || coalesce(display_name, '')), 'A') ||
+			setweight(to_tsvector('simple', coalesce(description, '')), 'B') ||
+			setweight(to_tsvector('simple', coalesce(labels, '') || ' ' || coalesce(annotations, '')), 'C') ||
+			setweight(to_tsvector('simple', coalesce(contents, '')), 'D')) STORED`,
+		`CREATE INDEX IF NOT EXISTS idx_search_documents_search_vector ON search_documents USING GIN (search_vector)`,
+	}
+)
+
+// Snippets are returned by the database with matches between these markers,
+// which are replaced by HTML tags after the text of the snippet is escaped.
+const (
+	snippetStart = "\x02"
+	snippetStop  = "\x03"
+)
+
+var snippetTags = strings.NewReplacer(snippetStart, "<b>", snippetStop, "</b>")
+
+// snippetHTML returns a snippet as HTML with its matches in bold.
+func snippetHTML(snippet string) string {
+	return snippetTags.Replace(html.EscapeString(snippet))
+}
+
+// searchTerm matches the terms of search queries.
+var searchTerm = regexp.MustCompile(`[\pL\pN]+`)
+
+// searchTerms returns the lowercase terms of a search query.
+func searchTerms(query string) []string {
+	terms := searchTerm.FindAllString(strings.ToLower(query), -1)
+	if len(terms) > 32 {
+		terms = terms[:32]
+	}
+	return terms
+}
+
+// SearchResult is a resource that matches a search query.
+type SearchResult struct {
+	Name        string
+	DisplayName string
+	Score       float64
+	Snippet     string
+}
+
+// SearchResultList contains a page of search results.
+type SearchResultList struct {
+	Results []SearchResult
+	Token   string
+}
+
+// SearchResources returns the live resources in a project that contain all of the terms of a query,
+// in order of decreasing relevance. Terms match the words that begin with them. The project ID "-"
+// searches all projects.
+func (c *Client) SearchResources(ctx context.Context, parent names.Project, query string, opts PageOptions) (SearchResultList, error) {
+	token, err := decodeToken(opts.Token)
+	if err != nil {
+		return SearchResultList{}, status.Errorf(codes.InvalidArgument, "invalid page token %q: %s", opts.Token, err.Error())
+	}
+	// The query is stored as the token's filter so that pages of other queries aren't continued.
+	if err := token.ValidateFilter(query); err != nil {
+		return SearchResultList{}, status.Errorf(codes.InvalidArgument, "invalid query %q: %s", query, err)
+	}
+	token.Filter = query
+
+	terms := searchTerms(query)
+	if len(terms) == 0 {
+		return SearchResultList{}, status.Errorf(codes.InvalidArgument, "invalid query %q: must contain at least one word", query)
+	}
+
+	var results []SearchResult
+	switch c.db.WithContext(ctx).Name() {
+	case "postgres":
+		results, err = c.searchPostgres(ctx, parent, terms, token.Offset, int(opts.Size)+1)
+	case "sqlite":
+		if !c.db.Migrator().HasTable("search_index") {
+			return SearchResultList{}, status.Error(codes.FailedPrecondition, "search requires a server built with the sqlite_fts5 tag")
+		}
+		results, err = c.searchSQLite(ctx, parent, terms, token.Offset, int(opts.Size)+1)
+	default:
+		return SearchResultList{}, status.Errorf(codes.Unimplemented, "search is unsupported by %s databases", c.db.Name())
+	}
+	if err != nil {
+		return SearchResultList{}, grpcErrorForDBError(ctx, errors.Wrapf(err, "search %s", parent))
+	}
+	for i := range results {
+		results[i].Snippet = snippetHTML(results[i].Snippet)
+	}
+
+	response := SearchResultList{Results: results}
+	if len(results) > int(opts.Size) {
+		response.Results = results[:opts.Size]
+		token.Offset += int(opts.Size)
+		response.Token, err = encodeToken(token)
+		if err != nil {
+			return SearchResultList{}, status.Error(codes.Internal, err.Error())
+		}
+	}
+	return response, nil
+}
+
+func (c *Client) searchPostgres(ctx context.Context, parent names.Project, terms []string, offset, limit int) ([]SearchResult, error) {
+	for i, t := range terms {
+		terms[i] = t + ":*"
+	}
+	args := []interface{}{"StartSel=" + snippetStart + ", StopSel=" + snippetStop + ", MinWords=5, MaxWords=20, MaxFragments=1", strings.Join(terms, " & ")}
+	project := ""
+	if id := parent.ProjectID; id != "-" {
+		project = " AND d.project_id = ?"
+		args = append(args, id)
+	}
+	args = append(args, limit, offset)
+	// Snippets are only computed for the rows of the page.
+	var results []SearchResult
+	err := c.db.WithContext(ctx).Raw(`SELECT key AS name, display_name, score,
+			ts_headline('simple', concat_ws(' ', display_name, description, labels, annotations, contents), q, ?) AS snippet
+		FROM (
+			SELECT d.key, d.display_name, d.description, d.labels, d.annotations, d.contents, q,
+				ts_rank_cd(d.search_vector, q) AS score
+			FROM search_documents d, to_tsquery('simple', ?) q
+			WHERE d.search_vector @@ q`+project+` AND d.deleted_at IS NULL
+			ORDER BY score DESC, d.key
+			LIMIT ? OFFSET ?
+		) page
+		ORDER BY score DESC, key`,
+		args...).Scan(&results).Error
+	return results, err
+}
+
+func (c *Client) searchSQLite(ctx context.Context, parent names.Project, terms []string, offset, limit int) ([]SearchResult, error) {
+	for i, t := range terms {
+		terms[i] = t + "*"
+	}
+	match := strings.Join(terms, " ")
+	args := []interface{}{snippetStart, snippetStop, match}
+	project := ""
+	if id := parent.ProjectID; id != "-" {
+		project = " AND d.project_id = ?"
+		args = append(args, id)
+	}
+	args = append(args, limit, offset, match)
+	// The rank of matches is their negated BM25 score, so better matches are first.
+	// Snippets are only computed for the rows of the page.
+	var results []SearchResult
+	err := c.db.WithContext(ctx).Raw(`SELECT page.key AS name, page.display_name, page.score,
+			snippet(search_index, -1, ?, ?, '...', 20) AS snippet
+		FROM search_index JOIN (
+			SELECT d.id, d.key, d.display_name, -search_index.rank AS score
+			FROM search_index JOIN search_documents d ON d.id = search_index.rowid
+			WHERE search_index MATCH ?`+project+` AND d.deleted_at IS NULL
+			ORDER BY search_index.rank, d.key
+			LIMIT ? OFFSET ?
+		) page ON page.id = search_index.rowid
+		WHERE search_index MATCH ?
+		ORDER BY page.score DESC, page.key`,
+		args...).Scan(&results).Error
+	return results, err
+}
+
+// saveSearchDocument inserts or updates the search document of a resource.
+// If columns are specified, only those columns of an existing document are updated.
+func (c *Client) saveSearchDocument(ctx context.Context, v *models.SearchDocument, columns ...string) error {
+	if len(columns) == 0 {
+		columns = []string{"resource_id", "display_name", "description", "labels", "annotations", "contents", "update_time", "deleted_at"}
+	}
+	err := c.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "key"}},
+		DoUpdates: clause.AssignmentColumns(columns),
+	}).Create(v).Error
+	return grpcErrorForDBError(ctx, errors.Wrapf(err, "index %s", v.Key))
+}
+
+// saveSpecSearchDocument indexes a spec if the revision is its current revision.
+// Revisions are saved before their contents, so contents without a revision aren't indexed.
+// If contents are nil, the contents of an existing document are kept.
+func (c *Client) saveSpecSearchDocument(ctx context.Context, v *models.Spec, contents []byte) error {
+	current := new(models.Spec)
+	if err := c.db.WithContext(ctx).
+		Where("project_id = ?", v.ProjectID).
+		Where("api_id = ?", v.ApiID).
+		Where("version_id = ?", v.VersionID).
+		Where("spec_id = ?", v.SpecID).
+		Order("revision_create_time desc").
+		First(current).Error; err == gorm.ErrRecordNotFound {
+		return nil
+	} else if err != nil {
+		return grpcErrorForDBError(ctx, errors.Wrapf(err, "get %s", v.Name()))
+	}
+	if current.RevisionID != v.RevisionID {
+		return nil
+	}
+	doc := models.NewSpecSearchDocument(v, contents)
+	if contents == nil {
+		return c.saveSearchDocument(ctx, doc, "resource_id", "display_name", "description", "labels", "annotations", "update_time", "deleted_at")
+	}
+	return c.saveSearchDocument(ctx, doc)
+}
+
+// indexSpecRevision indexes a spec revision with its stored contents.
+func (c *Client) indexSpecRevision(ctx context.Context, v *models.Spec) error {
+	blob := new(models.Blob)
+	if err := c.db.WithContext(ctx).Unscoped().Take(blob, "key = ?", v.RevisionName()).Error; err == gorm.ErrRecordNotFound {
+		return c.saveSpecSearchDocument(ctx, v, []byte{})
+	} else if err != nil {
+		return grpcErrorForDBError(ctx, errors.Wrapf(err, "get %s", v.RevisionName()))
+	}
+	if err := c.getContents(ctx, blob); err != nil {
+		return err
+	}
+	return c.saveSpecSearchDocument(ctx, v, blob.Contents)
+}
+
+// indexDeployment indexes the current revision of a deployment.
+func (c *Client) indexDeployment(ctx context.Context, v *models.Deployment) error {
+	current := new(models.Deployment)
+	if err := c.db.WithContext(ctx).
+		Where("project_id = ?", v.ProjectID).
+		Where("api_id = ?", v.ApiID).
+		Where("deployment_id = ?", v.DeploymentID).
+		Order("revision_create_time desc").
+		First(current).Error; err != nil {
+		return grpcErrorForDBError(ctx, errors.Wrapf(err, "get %s", v.Name()))
+	}
+	if err := c.saveSearchDocument(ctx, models.NewDeploymentSearchDocument(current)); err != nil {
+		return err
+	}
+	return c.SaveReferences(ctx, current.Name(), models.NewDeploymentReferences(current))
+}
+
+// migrateSearchIndex creates the table of search documents and its full-text index
+// and indexes existing resources. Documents of deleted resources are recorded as
+// deleted with them, so that they are restored if the resources are.
+func (c *Client) migrateSearchIndex(ctx context.Context) error {
+	if c.db.Migrator().HasTable(&models.SearchDocument{}) {
+		return nil
+	}
+	if err := c.ensureTable(ctx, &models.SearchDocument{}); err != nil {
+		return err
+	}
+	if c.db.Name() == "postgres" {
+		for _, s := range postgresSearchIndex {
+			if err := c.db.WithContext(ctx).Exec(s).Error; err != nil {
+				return err
+			}
+		}
+	} else if err := c.ensureSearchIndex(ctx); err != nil {
+		return err
+	}
+	save := func(v *models.SearchDocument, deletedAt gorm.DeletedAt) error {
+		v.DeletedAt = deletedAt
+		return c.saveSearchDocument(ctx, v)
+	}
+
+	var apis []models.Api
+	if err := c.db.WithContext(ctx).Unscoped().FindInBatches(&apis, 1000, func(*gorm.DB, int) error {
+		for i := range apis {
+			if err := save(models.NewApiSearchDocument(&apis[i]), apis[i].DeletedAt); err != nil {
+				return err
+			}
+		}
+		return nil
+	}).Error; err != nil {
+		return err
+	}
+
+	var versions []models.Version
+	if err := c.db.WithContext(ctx).Unscoped().FindInBatches(&versions, 1000, func(*gorm.DB, int) error {
+		for i := range versions {
+			if err := save(models.NewVersionSearchDocument(&versions[i]), versions[i].DeletedAt); err != nil {
+				return err
+			}
+		}
+		return nil
+	}).Error; err != nil {
+		return err
+	}
+
+	// Specs and deployments are indexed by their current revisions.
+	var specs []models.Spec
+	if err := c.latestSpecRevisionsQuery(ctx).Unscoped().Find(&specs).Error; err != nil {
+		return err
+	}
+	for i := range specs {
+		blob := new(models.Blob)
+		if err := c.db.WithContext(ctx).Unscoped().Take(blob, "key = ?", specs[i].RevisionName()).Error; err != nil && err != gorm.ErrRecordNotFound {
+			return err
+		} else if err == nil {
+			if err := c.getContents(ctx, blob); err != nil {
+				return err
+			}
+		}
+		if err := save(models.NewSpecSearchDocument(&specs[i], blob.Contents), specs[i].DeletedAt); err != nil {
+			return err
+		}
+	}
+
+	var deployments []models.Deployment
+	if err := c.latestDeploymentRevisionsQuery(ctx).Unscoped().Find(&deployments).Error; err != nil {
+		return err
+	}
+	for i := range deployments {
+		if err := save(models.NewDeploymentSearchDocument(&deployments[i]), deployments[i].DeletedAt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ensureSearchIndex creates the full-text index of search documents in SQLite
+// databases if the driver includes FTS5. The index isn't part of the versioned
+// schema because it depends on how the server was built.
+func (c *Client) ensureSearchIndex(ctx context.Context) error {
+	if c.db.Name() != "sqlite" || !c.db.Migrator().HasTable(&models.SearchDocument{}) || c.db.Migrator().HasTable("search_index") {
+		return nil
+	}
+	var fts5 bool
+	if err := c.db.WithContext(ctx).Raw("SELECT sqlite_compileoption_used('ENABLE_FTS5')").Scan(&fts5).Error; err != nil || !fts5 {
+		return err
+	}
+	for _, s := range sqliteSearchIndex {
+		if err := c.db.WithContext(ctx).Exec(s).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dropSearchIndex removes the table of search documents and its full-text index.
+func (c *Client) dropSearchIndex(ctx context.Context) error {
+	if c.db.Name() == "sqlite" {
+		for _, s := range []string{
+			"DROP TRIGGER IF EXISTS search_documents_ai",
+			"DROP TRIGGER IF EXISTS search_documents_ad",
+			"DROP TRIGGER IF EXISTS search_documents_au",
+			"DROP TABLE IF EXISTS search_index",
+		} {
+			if err := c.db.WithContext(ctx).Exec(s).Error; err != nil {
+				return err
+			}
+		}
+	}
+	return c.db.WithContext(ctx).Migrator().DropTable(&models.SearchDocument{})
+}
@@ -0,0 +1,211 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+
+	"github.com/apigee/registry/server/registry/internal/storage/filtering"
+	"github.com/apigee/registry/server/registry/internal/storage/models"
+	"github.com/apigee/registry/server/registry/names"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+var specElementFields = map[string]filtering.FieldType{
+	"spec":    filtering.String,
+	"kind":    filtering.String,
+	"name":    filtering.String,
+	"method":  filtering.String,
+	"path":    filtering.String,
+	"service": filtering.String,
+}
+
+func specElementMap(e models.SpecElement) map[string]interface{} {
+	return map[string]interface{}{
+		"spec":    e.Spec,
+		"kind":    e.Kind,
+		"name":    e.Name,
+		"method":  e.Method,
+		"path":    e.Path,
+		"service": e.Service,
+	}
+}
+
+// currentSpecRevision selects the spec elements of the current revisions of specs,
+// which are the live revisions that aren't followed by other live revisions.
+const currentSpecRevision = `EXISTS (SELECT 1 FROM specs r
+	WHERE r.project_id = spec_elements.project_id
+	AND r.api_id = spec_elements.api_id
+	AND r.version_id = spec_elements.version_id
+	AND r.spec_id = spec_elements.spec_id
+	AND r.revision_id = spec_elements.revision_id
+	AND r.deleted_at IS NULL
+	AND NOT EXISTS (SELECT 1 FROM specs s
+		WHERE s.project_id = r.project_id
+		AND s.api_id = r.api_id
+		AND s.version_id = r.version_id
+		AND s.spec_id = r.spec_id
+		AND s.revision_create_time > r.revision_create_time
+		AND s.deleted_at IS NULL))`
+
+// SaveSpecElements replaces the spec elements listed in an artifact.
+func (c *Client) SaveSpecElements(ctx context.Context, artifact *models.Artifact, elements []*models.SpecElement) error {
+	op := c.db.WithContext(ctx).Unscoped().
+		Where("project_id = ?", artifact.ProjectID).
+		Where("api_id = ?", artifact.ApiID).
+		Where("version_id = ?", artifact.VersionID).
+		Where("spec_id = ?", artifact.SpecID).
+		Where("revision_id = ?", artifact.RevisionID).
+		Where("artifact_id = ?", artifact.ArtifactID).
+		Delete(&models.SpecElement{})
+	if err := op.Error; err != nil {
+		return grpcErrorForDBError(ctx, errors.Wrapf(err, "delete spec elements of %s", artifact.Name()))
+	}
+	if len(elements) == 0 {
+		return nil
+	}
+	return c.create(ctx, elements)
+}
+
+// SpecElementList contains a page of spec elements.
+type SpecElementList struct {
+	Elements []models.SpecElement
+	Token    string
+}
+
+// ListSpecElements lists the elements of the current revisions of the specs in a parent.
+// Any of the IDs of the parent except the project ID may be "-" to list elements of all
+// matching specs.
+func (c *Client) ListSpecElements(ctx context.Context, parent names.Spec, opts PageOptions) (SpecElementList, error) {
+	token, err := decodeToken(opts.Token)
+	if err != nil {
+		return SpecElementList{}, status.Errorf(codes.InvalidArgument, "invalid page token %q: %s", opts.Token, err.Error())
+	}
+
+	if err := token.ValidateFilter(opts.Filter); err != nil {
+		return SpecElementList{}, status.Errorf(codes.InvalidArgument, "invalid filter %q: %s", opts.Filter, err)
+	} else {
+		token.Filter = opts.Filter
+	}
+
+	filter, err := c.newListFilter(opts.Filter, "spec_elements")
+	if err != nil {
+		return SpecElementList{}, err
+	}
+
+	order, err := newOrdering("", "spec_elements")
+	if err != nil {
+		return SpecElementList{}, err
+	}
+
+	op := c.db.WithContext(ctx).Where("spec_elements.project_id = ?", parent.ProjectID)
+	if parent.ApiID != "-" {
+		op = op.Where("spec_elements.api_id = ?", parent.ApiID)
+	}
+	if parent.VersionID != "-" {
+		op = op.Where("spec_elements.version_id = ?", parent.VersionID)
+	}
+	if parent.SpecID != "-" {
+		op = op.Where("spec_elements.spec_id = ?", parent.SpecID)
+	}
+	op = filter.where(op.Where(currentSpecRevision)).Order(order.String()).Limit(limit(opts, filter))
+
+	response := SpecElementList{
+		Elements: make([]models.SpecElement, 0, opts.Size),
+	}
+
+	for {
+		var page []models.SpecElement
+		query, err := token.query(op, order)
+		if err != nil {
+			return SpecElementList{}, status.Errorf(codes.InvalidArgument, "invalid page token %q: %s", opts.Token, err)
+		}
+		err = query.Find(&page).Error
+
+		if err != nil {
+			return SpecElementList{}, grpcErrorForDBError(ctx, errors.Wrapf(err, "find %#v", token))
+		} else if len(page) == 0 {
+			break
+		}
+
+		for _, v := range page {
+			m := specElementMap(v)
+			position := order.position(v.Key, m)
+			match, err := filter.Matches(m)
+			if err != nil {
+				return SpecElementList{}, err
+			} else if !match {
+				token.advance(position)
+				continue
+			}
+
+			if len(response.Elements) == int(opts.Size) {
+				response.Token, err = encodeToken(token)
+				if err != nil {
+					return SpecElementList{}, status.Error(codes.Internal, err.Error())
+				}
+				return response, nil
+			}
+
+			token.advance(position)
+			response.Elements = append(response.Elements, v)
+		}
+		if query.RowsAffected < int64(opts.Size) {
+			break
+		}
+	}
+
+	return response, nil
+}
+
+// migrateSpecElements creates the table of spec elements and indexes the elements
+// listed in existing spec index artifacts. Elements of deleted artifacts are recorded
+// as deleted with them, so that they are restored if the artifacts are.
+func (c *Client) migrateSpecElements(ctx context.Context) error {
+	if c.db.Migrator().HasTable(&models.SpecElement{}) {
+		return nil
+	}
+	if err := c.ensureTable(ctx, &models.SpecElement{}); err != nil {
+		return err
+	}
+
+	var artifacts []models.Artifact
+	return c.db.WithContext(ctx).Unscoped().Where("spec_id <> '' AND mime_type LIKE ?", "%.SpecIndex%").
+		FindInBatches(&artifacts, 1000, func(*gorm.DB, int) error {
+			for i := range artifacts {
+				v := &artifacts[i]
+				blob := new(models.Blob)
+				if err := c.db.WithContext(ctx).Unscoped().Take(blob, "key = ?", v.Name()).Error; err == gorm.ErrRecordNotFound {
+					continue
+				} else if err != nil {
+					return err
+				}
+				if err := c.getContents(ctx, blob); err != nil {
+					return err
+				}
+				elements := models.NewArtifactSpecElements(v, blob.Contents)
+				for _, e := range elements {
+					e.DeletedAt = v.DeletedAt
+				}
+				if err := c.SaveSpecElements(ctx, v, elements); err != nil {
+					return err
+				}
+			}
+			return nil
+		}).Error
+}
@@ -44,3 +44,17 @@ func (c *Client) unwrapDeploymentRevisionTag(ctx context.Context, name names.Dep
 
 	return name.Deployment().Revision(v.RevisionID), nil
 }
+
+// unwrapArtifactRevisionTag returns the ID of the revision of an artifact that a
+// tag refers to. IDs that aren't tags are returned unchanged.
+func (c *Client) unwrapArtifactRevisionTag(ctx context.Context, artifact *models.Artifact, id string) (string, error) {
+	key := artifact.Name() + "@" + id
+	v := new(models.ArtifactRevisionTag)
+	if err := c.db.WithContext(ctx).Take(v, "key = ?", key).Error; err == gorm.ErrRecordNotFound {
+		return id, nil
+	} else if err != nil {
+		return "", grpcErrorForDBError(ctx, errors.Wrapf(err, "get %s", key))
+	}
+
+	return v.ArtifactRevisionID, nil
+}
@@ -0,0 +1,19 @@
+-- Tables created by registry-server before schema migrations were versioned,
+-- with a resource of each kind.
+CREATE TABLE `projects` (`key` text,`project_id` text,`display_name` text,`description` text,`create_time` datetime,`update_time` datetime,PRIMARY KEY (`key`));
+CREATE TABLE `apis` (`key` text,`project_id` text,`api_id` text,`display_name` text,`description` text,`create_time` datetime,`update_time` datetime,`availability` text,`recommended_version` text,`recommended_deployment` text,`labels` blob,`annotations` blob,`parent_project_key` text,PRIMARY KEY (`key`),CONSTRAINT `fk_apis_parent_project` FOREIGN KEY (`parent_project_key`) REFERENCES `projects`(`key`) ON DELETE CASCADE ON UPDATE CASCADE);
+CREATE TABLE `versions` (`key` text,`project_id` text,`api_id` text,`version_id` text,`display_name` text,`description` text,`create_time` datetime,`update_time` datetime,`state` text,`labels` blob,`annotations` blob,`primary_spec` text,`parent_api_key` text,PRIMARY KEY (`key`),CONSTRAINT `fk_versions_parent_api` FOREIGN KEY (`parent_api_key`) REFERENCES `apis`(`key`) ON DELETE CASCADE ON UPDATE CASCADE);
+CREATE TABLE `specs` (`key` text,`project_id` text,`api_id` text,`version_id` text,`spec_id` text,`revision_id` text,`description` text,`create_time` datetime,`revision_create_time` datetime,`revision_update_time` datetime,`mime_type` text,`size_in_bytes` integer,`hash` text,`file_name` text,`source_uri` text,`labels` blob,`annotations` blob,`parent_version_key` text,PRIMARY KEY (`key`),CONSTRAINT `fk_specs_parent_version` FOREIGN KEY (`parent_version_key`) REFERENCES `versions`(`key`) ON DELETE CASCADE ON UPDATE CASCADE);
+CREATE TABLE `spec_revision_tags` (`key` text,`project_id` text,`api_id` text,`version_id` text,`spec_id` text,`revision_id` text,`tag` text,`create_time` datetime,`update_time` datetime,`parent_spec_key` text,PRIMARY KEY (`key`),CONSTRAINT `fk_spec_revision_tags_parent_spec` FOREIGN KEY (`parent_spec_key`) REFERENCES `specs`(`key`) ON DELETE CASCADE ON UPDATE CASCADE);
+CREATE TABLE `deployments` (`key` text,`project_id` text,`api_id` text,`deployment_id` text,`revision_id` text,`display_name` text,`description` text,`create_time` datetime,`revision_create_time` datetime,`revision_update_time` datetime,`api_spec_revision` text,`endpoint_uri` text,`external_channel_uri` text,`intended_audience` text,`access_guidance` text,`labels` blob,`annotations` blob,`parent_api_key` text,PRIMARY KEY (`key`),CONSTRAINT `fk_deployments_parent_api` FOREIGN KEY (`parent_api_key`) REFERENCES `apis`(`key`) ON DELETE CASCADE ON UPDATE CASCADE);
+CREATE TABLE `deployment_revision_tags` (`key` text,`project_id` text,`api_id` text,`deployment_id` text,`revision_id` text,`tag` text,`create_time` datetime,`update_time` datetime,`parent_deployment_key` text,PRIMARY KEY (`key`),CONSTRAINT `fk_deployment_revision_tags_parent_deployment` FOREIGN KEY (`parent_deployment_key`) REFERENCES `deployments`(`key`) ON DELETE CASCADE ON UPDATE CASCADE);
+CREATE TABLE `artifacts` (`key` text,`project_id` text,`api_id` text,`version_id` text,`spec_id` text,`revision_id` text,`deployment_id` text,`artifact_id` text,`create_time` datetime,`update_time` datetime,`mime_type` text,`size_in_bytes` integer,`hash` text,`labels` blob,`annotations` blob,PRIMARY KEY (`key`));
+CREATE TABLE `blobs` (`key` text,`project_id` text,`api_id` text,`version_id` text,`spec_id` text,`revision_id` text,`deployment_id` text,`artifact_id` text,`hash` text,`size_in_bytes` integer,`contents` blob,`create_time` datetime,`update_time` datetime,PRIMARY KEY (`key`));
+INSERT INTO `projects` VALUES ('projects/p','p','Project','','2022-01-01 00:00:00+00:00','2022-01-01 00:00:00+00:00');
+INSERT INTO `apis` VALUES ('projects/p/locations/global/apis/a','p','a','API','','2022-01-01 00:00:00+00:00','2022-01-01 00:00:00+00:00','','','',X'0A0C0A047465616D120470657473','','projects/p');
+INSERT INTO `versions` VALUES ('projects/p/locations/global/apis/a/versions/v','p','a','v','','','2022-01-01 00:00:00+00:00','2022-01-01 00:00:00+00:00','','','','','projects/p/locations/global/apis/a');
+INSERT INTO `specs` VALUES ('projects/p/locations/global/apis/a/versions/v/specs/s@11111111','p','a','v','s','11111111','','2022-01-01 00:00:00+00:00','2022-01-01 00:00:00+00:00','2022-01-01 00:00:00+00:00','text/plain',4,'hash','','','','','projects/p/locations/global/apis/a/versions/v');
+INSERT INTO `deployments` VALUES ('projects/p/locations/global/apis/a/deployments/d@22222222','p','a','d','22222222','','','2022-01-01 00:00:00+00:00','2022-01-01 00:00:00+00:00','2022-01-01 00:00:00+00:00','projects/p/locations/global/apis/a/versions/v/specs/s@11111111','','','','','','','projects/p/locations/global/apis/a');
+INSERT INTO `artifacts` VALUES ('projects/p/locations/global/artifacts/x','p','','','','','','x','2022-01-01 00:00:00+00:00','2022-01-01 00:00:00+00:00','text/plain',8,'hash','','');
+INSERT INTO `blobs` VALUES ('projects/p/locations/global/apis/a/versions/v/specs/s@11111111','p','a','v','s','11111111','','','hash',4,X'73706563','2022-01-01 00:00:00+00:00','2022-01-01 00:00:00+00:00');
+INSERT INTO `blobs` VALUES ('projects/p/locations/global/artifacts/x','p','','','','','','x','hash',8,X'6172746966616374','2022-01-01 00:00:00+00:00','2022-01-01 00:00:00+00:00');
@@ -0,0 +1,400 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/apigee/registry/server/registry/internal/storage/models"
+	"github.com/apigee/registry/server/registry/names"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// UndeleteProject restores a deleted project and the resources that were deleted with it.
+func (c *Client) UndeleteProject(ctx context.Context, name names.Project) error {
+	v := new(models.Project)
+	if err := c.db.WithContext(ctx).Unscoped().Take(v, "key = ?", name.String()).Error; err == gorm.ErrRecordNotFound {
+		return status.Errorf(codes.NotFound, "%q not found in database", name)
+	} else if err != nil {
+		return grpcErrorForDBError(ctx, errors.Wrapf(err, "undelete %s", name))
+	}
+	if !v.DeletedAt.Valid {
+		return status.Errorf(codes.AlreadyExists, "%q is not deleted", name)
+	}
+
+	query := func(db *gorm.DB) *gorm.DB {
+		return db.Where("project_id = ?", name.ProjectID)
+	}
+	return c.restore(ctx, v.DeletedAt.Time, query,
+		&models.Project{},
+		&models.Api{},
+		&models.Version{},
+		&models.Spec{},
+		&models.Deployment{},
+		&models.SpecRevisionTag{},
+		&models.DeploymentRevisionTag{},
+		&models.Artifact{},
+		&models.ArtifactRevision{},
+		&models.ArtifactRevisionTag{},
+		&models.ResourceReference{},
+		&models.SearchDocument{},
+		&models.SpecElement{},
+		&models.Blob{},
+	)
+}
+
+// UndeleteApi restores a deleted API and the resources that were deleted with it.
+func (c *Client) UndeleteApi(ctx context.Context, name names.Api) error {
+	v := new(models.Api)
+	if err := c.db.WithContext(ctx).Unscoped().Take(v, "key = ?", name.String()).Error; err == gorm.ErrRecordNotFound {
+		return status.Errorf(codes.NotFound, "%q not found in database", name)
+	} else if err != nil {
+		return grpcErrorForDBError(ctx, errors.Wrapf(err, "undelete %s", name))
+	}
+	if !v.DeletedAt.Valid {
+		return status.Errorf(codes.AlreadyExists, "%q is not deleted", name)
+	}
+	if _, err := c.GetProject(ctx, name.Project()); err != nil {
+		return parentDeleted(name, name.Project(), err)
+	}
+
+	query := func(db *gorm.DB) *gorm.DB {
+		return db.Where("project_id = ?", name.ProjectID).
+			Where("api_id = ?", name.ApiID)
+	}
+	return c.restore(ctx, v.DeletedAt.Time, query,
+		&models.Api{},
+		&models.Version{},
+		&models.Spec{},
+		&models.Deployment{},
+		&models.SpecRevisionTag{},
+		&models.DeploymentRevisionTag{},
+		&models.Artifact{},
+		&models.ArtifactRevision{},
+		&models.ArtifactRevisionTag{},
+		&models.ResourceReference{},
+		&models.SearchDocument{},
+		&models.SpecElement{},
+		&models.Blob{},
+	)
+}
+
+// UndeleteVersion restores a deleted version and the resources that were deleted with it.
+func (c *Client) UndeleteVersion(ctx context.Context, name names.Version) error {
+	v := new(models.Version)
+	if err := c.db.WithContext(ctx).Unscoped().Take(v, "key = ?", name.String()).Error; err == gorm.ErrRecordNotFound {
+		return status.Errorf(codes.NotFound, "%q not found in database", name)
+	} else if err != nil {
+		return grpcErrorForDBError(ctx, errors.Wrapf(err, "undelete %s", name))
+	}
+	if !v.DeletedAt.Valid {
+		return status.Errorf(codes.AlreadyExists, "%q is not deleted", name)
+	}
+	if _, err := c.GetApi(ctx, name.Api()); err != nil {
+		return parentDeleted(name, name.Api(), err)
+	}
+
+	query := func(db *gorm.DB) *gorm.DB {
+		return db.Where("project_id = ?", name.ProjectID).
+			Where("api_id = ?", name.ApiID).
+			Where("version_id = ?", name.VersionID)
+	}
+	return c.restore(ctx, v.DeletedAt.Time, query,
+		&models.Version{},
+		&models.Spec{},
+		&models.SpecRevisionTag{},
+		&models.Artifact{},
+		&models.ArtifactRevision{},
+		&models.ArtifactRevisionTag{},
+		&models.ResourceReference{},
+		&models.SearchDocument{},
+		&models.SpecElement{},
+		&models.Blob{},
+	)
+}
+
+// UndeleteSpec restores a deleted spec, including all of its revisions,
+// and the resources that were deleted with it.
+func (c *Client) UndeleteSpec(ctx context.Context, name names.Spec) error {
+	name = name.Normal()
+	query := func(db *gorm.DB) *gorm.DB {
+		return db.Where("project_id = ?", name.ProjectID).
+			Where("api_id = ?", name.ApiID).
+			Where("version_id = ?", name.VersionID).
+			Where("spec_id = ?", name.SpecID)
+	}
+
+	if _, err := c.GetSpec(ctx, name); err == nil {
+		return status.Errorf(codes.AlreadyExists, "%q is not deleted", name)
+	} else if status.Code(err) != codes.NotFound {
+		return err
+	}
+	// The revisions deleted with the spec were deleted last.
+	v := new(models.Spec)
+	op := c.db.WithContext(ctx).Unscoped().Scopes(query).Order("deleted_at desc")
+	if err := op.First(v).Error; err == gorm.ErrRecordNotFound {
+		return status.Errorf(codes.NotFound, "%q not found in database", name)
+	} else if err != nil {
+		return grpcErrorForDBError(ctx, errors.Wrapf(err, "undelete %s", name))
+	}
+	if _, err := c.GetVersion(ctx, name.Version()); err != nil {
+		return parentDeleted(name, name.Version(), err)
+	}
+
+	return c.restore(ctx, v.DeletedAt.Time, query,
+		&models.Spec{},
+		&models.SpecRevisionTag{},
+		&models.Artifact{},
+		&models.ArtifactRevision{},
+		&models.ArtifactRevisionTag{},
+		&models.ResourceReference{},
+		&models.SearchDocument{},
+		&models.SpecElement{},
+		&models.Blob{},
+	)
+}
+
+// UndeleteDeployment restores a deleted deployment, including all of its revisions,
+// and the resources that were deleted with it.
+func (c *Client) UndeleteDeployment(ctx context.Context, name names.Deployment) error {
+	name = name.Normal()
+	query := func(db *gorm.DB) *gorm.DB {
+		return db.Where("project_id = ?", name.ProjectID).
+			Where("api_id = ?", name.ApiID).
+			Where("deployment_id = ?", name.DeploymentID)
+	}
+
+	if _, err := c.GetDeployment(ctx, name); err == nil {
+		return status.Errorf(codes.AlreadyExists, "%q is not deleted", name)
+	} else if status.Code(err) != codes.NotFound {
+		return err
+	}
+	// The revisions deleted with the deployment were deleted last.
+	v := new(models.Deployment)
+	op := c.db.WithContext(ctx).Unscoped().Scopes(query).Order("deleted_at desc")
+	if err := op.First(v).Error; err == gorm.ErrRecordNotFound {
+		return status.Errorf(codes.NotFound, "%q not found in database", name)
+	} else if err != nil {
+		return grpcErrorForDBError(ctx, errors.Wrapf(err, "undelete %s", name))
+	}
+	if _, err := c.GetApi(ctx, name.Api()); err != nil {
+		return parentDeleted(name, name.Api(), err)
+	}
+
+	return c.restore(ctx, v.DeletedAt.Time, query,
+		&models.Deployment{},
+		&models.DeploymentRevisionTag{},
+		&models.Artifact{},
+		&models.ArtifactRevision{},
+		&models.ArtifactRevisionTag{},
+		&models.ResourceReference{},
+		&models.SearchDocument{},
+		&models.Blob{},
+	)
+}
+
+// UndeleteSpecRevision restores a revision of a live spec that was deleted on its own,
+// and the artifacts that were deleted with it.
+func (c *Client) UndeleteSpecRevision(ctx context.Context, name names.SpecRevision) error {
+	v := new(models.Spec)
+	if err := c.db.WithContext(ctx).Unscoped().Take(v, "key = ?", name.String()).Error; err == gorm.ErrRecordNotFound {
+		return status.Errorf(codes.NotFound, "%q not found in database", name)
+	} else if err != nil {
+		return grpcErrorForDBError(ctx, errors.Wrapf(err, "undelete %s", name))
+	}
+	if !v.DeletedAt.Valid {
+		return status.Errorf(codes.AlreadyExists, "%q is not deleted", name)
+	}
+	if _, err := c.GetSpec(ctx, name.Spec()); err != nil {
+		return parentDeleted(name, name.Spec(), err)
+	}
+
+	query := func(db *gorm.DB) *gorm.DB {
+		return db.Where("project_id = ?", name.ProjectID).
+			Where("api_id = ?", name.ApiID).
+			Where("version_id = ?", name.VersionID).
+			Where("spec_id = ?", name.SpecID).
+			Where("revision_id = ?", name.RevisionID)
+	}
+	if err := c.restore(ctx, v.DeletedAt.Time, query,
+		&models.Spec{},
+		&models.SpecRevisionTag{},
+		&models.Artifact{},
+		&models.ArtifactRevision{},
+		&models.ArtifactRevisionTag{},
+		&models.ResourceReference{},
+		&models.SpecElement{},
+		&models.Blob{},
+	); err != nil {
+		return err
+	}
+
+	// the restored revision may be the current one
+	current, err := c.GetSpec(ctx, name.Spec())
+	if err != nil {
+		return err
+	}
+	return c.indexSpecRevision(ctx, current)
+}
+
+// UndeleteDeploymentRevision restores a revision of a live deployment that was deleted
+// on its own, and the artifacts that were deleted with it.
+func (c *Client) UndeleteDeploymentRevision(ctx context.Context, name names.DeploymentRevision) error {
+	v := new(models.Deployment)
+	if err := c.db.WithContext(ctx).Unscoped().Take(v, "key = ?", name.String()).Error; err == gorm.ErrRecordNotFound {
+		return status.Errorf(codes.NotFound, "%q not found in database", name)
+	} else if err != nil {
+		return grpcErrorForDBError(ctx, errors.Wrapf(err, "undelete %s", name))
+	}
+	if !v.DeletedAt.Valid {
+		return status.Errorf(codes.AlreadyExists, "%q is not deleted", name)
+	}
+	if _, err := c.GetDeployment(ctx, name.Deployment()); err != nil {
+		return parentDeleted(name, name.Deployment(), err)
+	}
+
+	query := func(db *gorm.DB) *gorm.DB {
+		return db.Where("project_id = ?", name.ProjectID).
+			Where("api_id = ?", name.ApiID).
+			Where("deployment_id = ?", name.DeploymentID).
+			Where("revision_id = ?", name.RevisionID)
+	}
+	if err := c.restore(ctx, v.DeletedAt.Time, query,
+		&models.Deployment{},
+		&models.DeploymentRevisionTag{},
+		&models.Artifact{},
+		&models.ArtifactRevision{},
+		&models.ArtifactRevisionTag{},
+		&models.ResourceReference{},
+		&models.Blob{},
+	); err != nil {
+		return err
+	}
+
+	// the restored revision may be the current one
+	return c.indexDeployment(ctx, v)
+}
+
+// UndeleteArtifact restores a deleted artifact of a live resource and returns it.
+// Artifacts of specs and deployments may belong to revisions other than the current one.
+func (c *Client) UndeleteArtifact(ctx context.Context, name names.Artifact) (*models.Artifact, error) {
+	query := func(db *gorm.DB) *gorm.DB {
+		return db.Where("project_id = ?", name.ProjectID()).
+			Where("api_id = ?", name.ApiID()).
+			Where("version_id = ?", name.VersionID()).
+			Where("spec_id = ?", name.SpecID()).
+			Where("deployment_id = ?", name.DeploymentID()).
+			Where("artifact_id = ?", name.ArtifactID())
+	}
+	var live int64
+	if err := c.db.WithContext(ctx).Model(&models.Artifact{}).Scopes(query).Count(&live).Error; err != nil {
+		return nil, grpcErrorForDBError(ctx, errors.Wrapf(err, "undelete %s", name))
+	}
+	if live > 0 {
+		return nil, status.Errorf(codes.AlreadyExists, "%q is not deleted", name)
+	}
+	v := new(models.Artifact)
+	op := c.db.WithContext(ctx).Unscoped().Scopes(query).Order("deleted_at desc")
+	if err := op.First(v).Error; err == gorm.ErrRecordNotFound {
+		return nil, status.Errorf(codes.NotFound, "%q not found in database", name)
+	} else if err != nil {
+		return nil, grpcErrorForDBError(ctx, errors.Wrapf(err, "undelete %s", name))
+	}
+
+	// Artifacts of revisions are restored only if their revisions are live.
+	var err error
+	var parent interface{ String() string }
+	switch {
+	case v.DeploymentID != "":
+		revision := names.DeploymentRevision{
+			ProjectID:    v.ProjectID,
+			ApiID:        v.ApiID,
+			DeploymentID: v.DeploymentID,
+			RevisionID:   v.RevisionID,
+		}
+		parent = revision
+		_, err = c.GetDeploymentRevision(ctx, revision)
+	case v.SpecID != "":
+		revision := names.SpecRevision{
+			ProjectID:  v.ProjectID,
+			ApiID:      v.ApiID,
+			VersionID:  v.VersionID,
+			SpecID:     v.SpecID,
+			RevisionID: v.RevisionID,
+		}
+		parent = revision
+		_, err = c.GetSpecRevision(ctx, revision)
+	case v.VersionID != "":
+		version := names.Version{ProjectID: v.ProjectID, ApiID: v.ApiID, VersionID: v.VersionID}
+		parent = version
+		_, err = c.GetVersion(ctx, version)
+	case v.ApiID != "":
+		api := names.Api{ProjectID: v.ProjectID, ApiID: v.ApiID}
+		parent = api
+		_, err = c.GetApi(ctx, api)
+	default:
+		project := names.Project{ProjectID: v.ProjectID}
+		parent = project
+		_, err = c.GetProject(ctx, project)
+	}
+	if err != nil {
+		return nil, parentDeleted(name, parent, err)
+	}
+
+	if err := c.restore(ctx, v.DeletedAt.Time, query,
+		&models.Artifact{},
+		&models.ArtifactRevision{},
+		&models.ArtifactRevisionTag{},
+		&models.ResourceReference{},
+		&models.Blob{},
+	); err != nil {
+		return nil, err
+	}
+	// Only artifacts of specs list spec elements.
+	if name.SpecID() != "" {
+		if err := c.restore(ctx, v.DeletedAt.Time, specElementsOf(name), &models.SpecElement{}); err != nil {
+			return nil, err
+		}
+	}
+	v.DeletedAt = gorm.DeletedAt{}
+	return v, nil
+}
+
+// parentDeleted converts the error returned when getting the parent of a
+// resource into the error returned when undeleting the resource.
+func parentDeleted(name, parent interface{ String() string }, err error) error {
+	if status.Code(err) == codes.NotFound {
+		return status.Errorf(codes.FailedPrecondition, "cannot undelete %s: its parent %s is deleted", name, parent)
+	}
+	return err
+}
+
+// restore clears the deletion time of the rows of each table that match a
+// query and were deleted at a specified time.
+func (c *Client) restore(ctx context.Context, t time.Time, query func(*gorm.DB) *gorm.DB, tables ...interface{}) error {
+	for _, model := range tables {
+		op := c.db.WithContext(ctx).Unscoped().Model(model).Scopes(query).
+			Where("deleted_at = ?", t.UTC()).
+			Update("deleted_at", nil)
+		if err := op.Error; err != nil {
+			return grpcErrorForDBError(ctx, errors.Wrapf(err, "restore %T", model))
+		}
+	}
+	return nil
+}
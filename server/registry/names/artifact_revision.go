@@ -0,0 +1,71 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package names
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// artifactRevisionRegexp splits an artifact revision name into the name of
+// the artifact, which is parsed with ParseArtifact, and the revision ID.
+var artifactRevisionRegexp = regexp.MustCompile(fmt.Sprintf("^(.+/artifacts/%s)(?:@%s)?$", identifier, revisionTag))
+
+// ArtifactRevision represents a resource name for an artifact revision.
+type ArtifactRevision struct {
+	artifact   Artifact
+	RevisionID string
+}
+
+// Revision returns a revision of the artifact with the provided ID.
+func (a Artifact) Revision(id string) ArtifactRevision {
+	return ArtifactRevision{
+		artifact:   a,
+		RevisionID: id,
+	}
+}
+
+// Artifact returns the artifact of this revision.
+func (r ArtifactRevision) Artifact() Artifact {
+	return r.artifact
+}
+
+// Validate returns an error if the resource name is invalid.
+func (r ArtifactRevision) Validate() error {
+	return r.artifact.Validate()
+}
+
+func (r ArtifactRevision) String() string {
+	if r.RevisionID == "" { // use latest revision
+		return r.artifact.String()
+	}
+	return normalize(fmt.Sprintf("%s@%s", r.artifact, r.RevisionID))
+}
+
+// ParseArtifactRevision parses the name of an artifact revision.
+// Names without revision IDs refer to the current revision of an artifact.
+func ParseArtifactRevision(name string) (ArtifactRevision, error) {
+	m := artifactRevisionRegexp.FindStringSubmatch(name)
+	if m == nil {
+		return ArtifactRevision{}, fmt.Errorf("invalid artifact revision name %q: must match %q", name, artifactRevisionRegexp)
+	}
+
+	artifact, err := ParseArtifact(m[1])
+	if err != nil {
+		return ArtifactRevision{}, fmt.Errorf("invalid artifact revision name %q: %s", name, err)
+	}
+
+	return artifact.Revision(m[3]), nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package names
+
+import (
+	"testing"
+)
+
+func TestParseArtifactRevision(t *testing.T) {
+	tests := []struct {
+		name       string
+		artifact   string
+		revisionID string
+	}{
+		{
+			name:     "projects/p/locations/global/artifacts/x",
+			artifact: "projects/p/locations/global/artifacts/x",
+		},
+		{
+			name:       "projects/p/locations/global/artifacts/x@123",
+			artifact:   "projects/p/locations/global/artifacts/x",
+			revisionID: "123",
+		},
+		{
+			name:       "projects/p/locations/global/apis/a/artifacts/x@-",
+			artifact:   "projects/p/locations/global/apis/a/artifacts/x",
+			revisionID: "-",
+		},
+		{
+			name:       "projects/p/locations/global/apis/a/versions/v/specs/s@456/artifacts/x@tag",
+			artifact:   "projects/p/locations/global/apis/a/versions/v/specs/s@456/artifacts/x",
+			revisionID: "tag",
+		},
+		{
+			name:     "projects/p/locations/global/apis/a/deployments/d@456/artifacts/x",
+			artifact: "projects/p/locations/global/apis/a/deployments/d@456/artifacts/x",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			name, err := ParseArtifactRevision(test.name)
+			if err != nil {
+				t.Fatalf("ParseArtifactRevision(%q) returned error: %s", test.name, err)
+			}
+			if name.Artifact().String() != test.artifact {
+				t.Errorf("ParseArtifactRevision(%q) returned artifact %s, want %s", test.name, name.Artifact(), test.artifact)
+			}
+			if name.RevisionID != test.revisionID {
+				t.Errorf("ParseArtifactRevision(%q) returned revision ID %q, want %q", test.name, name.RevisionID, test.revisionID)
+			}
+			if name.String() != test.name {
+				t.Errorf("ParseArtifactRevision(%q).String() returned %q", test.name, name)
+			}
+		})
+	}
+}
+
+func TestParseInvalidArtifactRevision(t *testing.T) {
+	for _, name := range []string{
+		"projects/p/locations/global/artifacts/x@",
+		"projects/p/locations/global/artifacts/x@A",
+		"projects/p/locations/global/apis/a@1/artifacts/x@2",
+		"projects/p/locations/global/apis/a/versions/v/specs/s",
+	} {
+		if _, err := ParseArtifactRevision(name); err == nil {
+			t.Errorf("ParseArtifactRevision(%q) succeeded, want error", name)
+		}
+	}
+}
@@ -16,55 +16,162 @@ package registry
 
 import (
 	"context"
+	"fmt"
+	"net/url"
+	"time"
 
-	"cloud.google.com/go/pubsub"
 	"github.com/apigee/registry/log"
 	"github.com/apigee/registry/rpc"
-	"google.golang.org/protobuf/encoding/protojson"
+	"github.com/apigee/registry/server/registry/internal/notify"
+	"github.com/apigee/registry/server/registry/internal/storage"
+	"github.com/apigee/registry/server/registry/internal/storage/models"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 const TopicName = "registry-events"
 
-func (s *RegistryServer) notify(ctx context.Context, change rpc.Notification_Change, resource string) {
-	if !s.notifyEnabled {
-		return
-	}
+// WebhookConfig configures delivery of notifications to an HTTP endpoint.
+type WebhookConfig struct {
+	// URL that receives notifications as JSON-encoded POST requests.
+	URL string
+	// Secret used to sign requests with HMAC-SHA256. Optional.
+	Secret string
+	// Parent restricts notifications to a resource and its descendants. Optional.
+	Parent string
+	// Filter is a CEL expression that selects notifications. Optional.
+	Filter string
+	// Timeout limits the duration of each attempt.
+	Timeout time.Duration
+}
 
-	logger := log.FromContext(ctx)
-	if s.projectID == "" {
-		logger.Warn("Notifications are enabled but project ID is not set. Skipping notification.")
-		return
+// BrokerConfig configures delivery of notifications to a message broker.
+type BrokerConfig struct {
+	// Protocol used by the broker. Currently only "nats" is supported.
+	Protocol string
+	// URL of the broker.
+	URL string
+	// Subject (or topic) that notifications are published on.
+	Subject string
+	// Parent restricts notifications to a resource and its descendants. Optional.
+	Parent string
+	// Filter is a CEL expression that selects notifications. Optional.
+	Filter string
+}
+
+// A sink is a named destination for notifications. Stored notification events
+// refer to sinks by name, so names must be stable across server restarts.
+type sink struct {
+	name     string
+	filter   *notify.Filter
+	notifier notify.Notifier
+}
+
+func newSinks(ctx context.Context, config Config) ([]sink, error) {
+	sinks := make([]sink, 0)
+	fail := func(err error) ([]sink, error) {
+		for _, s := range sinks {
+			s.notifier.Close()
+		}
+		return nil, err
+	}
+	add := func(name string, filter *notify.Filter, n notify.Notifier) {
+		unique := name
+		for i := 2; containsSink(sinks, unique); i++ {
+			unique = fmt.Sprintf("%s#%d", name, i)
+		}
+		sinks = append(sinks, sink{name: unique, filter: filter, notifier: n})
 	}
 
-	client, err := s.getPubSubClient(ctx)
-	if err != nil {
-		logger.WithError(err).Error("Failed to get PubSub client.")
-		return
+	if config.Notify {
+		if config.ProjectID == "" {
+			log.FromContext(ctx).Warn("Notifications are enabled but project ID is not set. Skipping Pub/Sub notifications.")
+		} else if n, err := notify.NewPubSub(ctx, config.ProjectID, TopicName); err != nil {
+			return fail(err)
+		} else {
+			add(fmt.Sprintf("pubsub:projects/%s/topics/%s", config.ProjectID, TopicName), nil, n)
+		}
 	}
 
-	notification := &rpc.Notification{
-		Change:     change,
-		Resource:   resource,
-		ChangeTime: timestamppb.Now(),
+	for _, c := range config.Webhooks {
+		filter, err := notify.NewFilter(c.Parent, c.Filter)
+		if err != nil {
+			return fail(fmt.Errorf("invalid webhook %s: %s", c.URL, err))
+		}
+		// Failed deliveries are retried by the dispatcher, which doesn't wait for
+		// them, so each delivery makes a single attempt.
+		n, err := notify.NewWebhook(notify.WebhookOptions{
+			URL:      c.URL,
+			Secret:   c.Secret,
+			Attempts: 1,
+			Timeout:  c.Timeout,
+		})
+		if err != nil {
+			return fail(err)
+		}
+		add("webhook:"+c.URL, filter, n)
 	}
 
-	msg, err := protojson.Marshal(notification)
-	if err != nil {
-		logger.WithError(err).Errorf("Failed to serialize notification: %v", notification)
-		return
+	for _, c := range config.Brokers {
+		filter, err := notify.NewFilter(c.Parent, c.Filter)
+		if err != nil {
+			return fail(fmt.Errorf("invalid broker %s: %s", c.URL, err))
+		}
+		var n notify.Notifier
+		switch c.Protocol {
+		case "nats":
+			n, err = notify.NewNATS(notify.NATSOptions{
+				URL:     c.URL,
+				Subject: c.Subject,
+			})
+		default:
+			err = fmt.Errorf("invalid broker protocol %q: must be one of [nats]", c.Protocol)
+		}
+		if err != nil {
+			return fail(err)
+		}
+		// Broker URLs may contain credentials, which are omitted from sink names.
+		u, _ := url.Parse(c.URL)
+		add(fmt.Sprintf("%s:%s/%s", c.Protocol, u.Host, c.Subject), filter, n)
 	}
 
-	topic := client.Topic(TopicName)
-	result := topic.Publish(ctx, &pubsub.Message{
-		Data: msg,
-	})
+	return sinks, nil
+}
 
-	id, err := result.Get(ctx)
-	if err != nil {
-		logger.WithError(err).Error("Failed to publish notification.")
+func containsSink(sinks []sink, name string) bool {
+	for _, s := range sinks {
+		if s.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// notify records a change made in the current transaction. Notifications are
+// stored with the change and are delivered after the transaction commits.
+func (s *RegistryServer) notify(ctx context.Context, change rpc.Notification_Change, resource string) {
+	c, ok := ctx.Value(changesKey{}).(*changes)
+	if !ok {
+		log.FromContext(ctx).Errorf("Dropped notification of change to %s made outside of a transaction.", resource)
 		return
 	}
+	c.notifications = append(c.notifications, &rpc.Notification{
+		Change:     change,
+		Resource:   resource,
+		ChangeTime: timestamppb.Now(),
+	})
+}
 
-	logger.Infof("Published notification with message ID: %s", id)
+// recordNotifications stores an event for each sink that accepts each notification.
+func (s *RegistryServer) recordNotifications(ctx context.Context, db *storage.Client, list []*rpc.Notification) error {
+	events := make([]*models.NotificationEvent, 0)
+	for _, n := range list {
+		for _, sink := range s.sinks {
+			if match, err := sink.filter.Matches(n); err != nil {
+				log.FromContext(ctx).WithError(err).Errorf("Failed to filter notification for %s.", sink.name)
+			} else if match {
+				events = append(events, models.NewNotificationEvent(sink.name, n))
+			}
+		}
+	}
+	return db.CreateNotificationEvents(ctx, events)
 }
@@ -17,16 +17,46 @@ package registry
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"cloud.google.com/go/pubsub/pstest"
 	"github.com/apigee/registry/log"
 	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/notify"
 	"github.com/google/go-cmp/cmp"
 	"google.golang.org/genproto/googleapis/pubsub/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
 )
 
+// waitFor polls until a condition is satisfied.
+func waitFor(t *testing.T, desc string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for %s", desc)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func listNotificationEvents(ctx context.Context, t *testing.T, server *RegistryServer, filter string) []*rpc.NotificationEvent {
+	t.Helper()
+	req := &rpc.ListNotificationEventsRequest{Filter: filter}
+	resp, err := server.ListNotificationEvents(ctx, req)
+	if err != nil {
+		t.Fatalf("ListNotificationEvents(%+v) returned error: %s", req, err)
+	}
+	return resp.GetNotificationEvents()
+}
+
 func TestNotifications(t *testing.T) {
 	ctx := context.Background()
 
@@ -57,8 +87,11 @@ func TestNotifications(t *testing.T) {
 		t.Errorf("Topic %q not found", TopicName)
 	}
 
-	server.notify(ctx, rpc.Notification_CREATED, "resource")
-	pubSubTest.Wait()
+	if _, err := server.CreateProject(ctx, &rpc.CreateProjectRequest{ProjectId: "my-project", Project: &rpc.Project{}}); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "pubsub message", func() bool { return len(pubSubTest.Messages()) > 0 })
+	waitFor(t, "delivered event", func() bool { return len(listNotificationEvents(ctx, t, server, "")) == 0 })
 
 	ms := pubSubTest.Messages()
 	if len(ms) != 1 {
@@ -66,26 +99,289 @@ func TestNotifications(t *testing.T) {
 	}
 }
 
-func TestNotificationErrors(t *testing.T) {
+func TestNotificationOutsideTransaction(t *testing.T) {
 	logger, rec := log.NewWithRecorder()
 	ctx := log.NewContext(context.Background(), logger)
 
-	server := RegistryServer{
-		notifyEnabled: true,
-	}
+	server := RegistryServer{}
 	server.notify(ctx, rpc.Notification_CREATED, "resource")
 
 	entry := rec.LastEntry()
-	want := "Notifications are enabled but project ID is not set. Skipping notification."
+	want := "Dropped notification of change to resource made outside of a transaction."
 	if want != entry.Message() {
-		t.Errorf(cmp.Diff(want, entry.Message))
+		t.Errorf(cmp.Diff(want, entry.Message()))
 	}
+}
 
-	server.projectID = "id"
-	server.notify(ctx, rpc.Notification_CREATED, "resource")
-	entry = rec.LastEntry()
-	want = "Failed to get PubSub client."
-	if want != entry.Message() {
-		t.Errorf(cmp.Diff(want, entry.Message()))
+func TestWebhookNotifications(t *testing.T) {
+	ctx := context.Background()
+
+	received := make(chan *http.Request, 10)
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r
+	}))
+	defer hook.Close()
+
+	server, err := New(Config{
+		Database: "sqlite3",
+		DBConfig: fmt.Sprintf("%s/registry.db", t.TempDir()),
+		Webhooks: []WebhookConfig{{
+			URL:    hook.URL,
+			Secret: "secret",
+			Parent: "projects/my-project",
+			Filter: `change == "CREATED"`,
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	for _, id := range []string{"other-project", "my-project"} {
+		if _, err := server.CreateProject(ctx, &rpc.CreateProjectRequest{ProjectId: id, Project: &rpc.Project{}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	update := &rpc.UpdateProjectRequest{Project: &rpc.Project{Name: "projects/my-project", Description: "updated"}}
+	if _, err := server.UpdateProject(ctx, update); err != nil {
+		t.Fatal(err)
+	}
+	// Failed changes are not notified.
+	if _, err := server.CreateProject(ctx, &rpc.CreateProjectRequest{ProjectId: "my-project", Project: &rpc.Project{}}); status.Code(err) != codes.AlreadyExists {
+		t.Fatalf("CreateProject() returned status code %q, want %q: %v", status.Code(err), codes.AlreadyExists, err)
+	}
+
+	waitFor(t, "delivered events", func() bool { return len(listNotificationEvents(ctx, t, server, "")) == 0 })
+	if len(received) != 1 {
+		t.Fatalf("Expected 1 request, got %d", len(received))
+	}
+	r := <-received
+	if got := r.Header.Get(notify.ChangeHeader); got != "CREATED" {
+		t.Errorf("Expected %s header %q, got %q", notify.ChangeHeader, "CREATED", got)
+	}
+}
+
+func TestNotificationRetries(t *testing.T) {
+	ctx := context.Background()
+
+	var healthy int32
+	received := make(chan *http.Request, 10)
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&healthy) == 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		received <- r
+	}))
+	defer hook.Close()
+
+	server, err := New(Config{
+		Database: "sqlite3",
+		DBConfig: fmt.Sprintf("%s/registry.db", t.TempDir()),
+		Webhooks: []WebhookConfig{{
+			URL: hook.URL,
+		}},
+		NotificationAttempts: 3,
+		NotificationBackoff:  time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	if _, err := server.CreateProject(ctx, &rpc.CreateProjectRequest{ProjectId: "my-project", Project: &rpc.Project{}}); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "failed event", func() bool { return len(listNotificationEvents(ctx, t, server, `state == "FAILED"`)) == 1 })
+
+	events := listNotificationEvents(ctx, t, server, `resource == "projects/my-project"`)
+	if len(events) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(events))
+	}
+	want := &rpc.NotificationEvent{
+		Id:           events[0].GetId(),
+		Notification: &rpc.Notification{Change: rpc.Notification_CREATED, Resource: "projects/my-project"},
+		Sink:         "webhook:" + hook.URL,
+		State:        rpc.NotificationEvent_FAILED,
+		Attempts:     3,
+	}
+	opts := cmp.Options{
+		protocmp.Transform(),
+		protocmp.IgnoreFields(&rpc.Notification{}, "change_time"),
+		protocmp.IgnoreFields(&rpc.NotificationEvent{}, "next_attempt_time", "last_error"),
+	}
+	if !cmp.Equal(want, events[0], opts) {
+		t.Errorf("ListNotificationEvents() returned unexpected diff (-want +got):\n%s", cmp.Diff(want, events[0], opts))
+	}
+	if len(received) != 0 {
+		t.Errorf("Expected no requests, got %d", len(received))
+	}
+
+	atomic.StoreInt32(&healthy, 1)
+	replay := &rpc.ReplayNotificationEventsRequest{Filter: `change == "CREATED"`}
+	resp, err := server.ReplayNotificationEvents(ctx, replay)
+	if err != nil {
+		t.Fatalf("ReplayNotificationEvents(%+v) returned error: %s", replay, err)
+	}
+	if resp.GetReplayedCount() != 1 {
+		t.Errorf("ReplayNotificationEvents(%+v) replayed %d events, want 1", replay, resp.GetReplayedCount())
+	}
+	waitFor(t, "delivered event", func() bool { return len(listNotificationEvents(ctx, t, server, "")) == 0 })
+	if len(received) != 1 {
+		t.Errorf("Expected 1 request, got %d", len(received))
+	}
+}
+
+func TestNotificationSinksAreIndependent(t *testing.T) {
+	ctx := context.Background()
+
+	received := make(chan *http.Request, 10)
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r
+	}))
+	defer healthy.Close()
+
+	var requests int32
+	release := make(chan struct{})
+	unavailable := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			<-release
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer unavailable.Close()
+
+	server, err := New(Config{
+		Database:             "sqlite3",
+		DBConfig:             fmt.Sprintf("%s/registry.db", t.TempDir()),
+		Webhooks:             []WebhookConfig{{URL: unavailable.URL}, {URL: healthy.URL}},
+		NotificationAttempts: 2,
+		NotificationBackoff:  time.Hour,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	// A webhook that doesn't respond doesn't delay deliveries to other sinks.
+	if _, err := server.CreateProject(ctx, &rpc.CreateProjectRequest{ProjectId: "my-project", Project: &rpc.Project{}}); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "delivery to healthy webhook", func() bool { return len(received) == 1 })
+	close(release)
+
+	sink := fmt.Sprintf(`sink == "webhook:%s"`, unavailable.URL)
+	waitFor(t, "failed delivery", func() bool { return len(listNotificationEvents(ctx, t, server, sink+" && attempts == 1")) == 1 })
+
+	// After a failed delivery, the remaining events of a sink are postponed without attempts.
+	batch := &rpc.BatchCreateApisRequest{Parent: "projects/my-project/locations/global"}
+	for _, id := range []string{"a", "b", "c"} {
+		batch.Requests = append(batch.Requests, &rpc.CreateApiRequest{ApiId: id, Api: &rpc.Api{}})
+	}
+	if _, err := server.BatchCreateApis(ctx, batch); err != nil {
+		t.Fatalf("BatchCreateApis(%+v) returned error: %s", batch, err)
+	}
+	waitFor(t, "deliveries to healthy webhook", func() bool { return len(received) == 4 })
+	waitFor(t, "failed delivery", func() bool { return len(listNotificationEvents(ctx, t, server, sink+" && attempts == 1")) == 2 })
+	if n := atomic.LoadInt32(&requests); n != 2 {
+		t.Errorf("Expected 2 requests to unavailable webhook, got %d", n)
+	}
+	if events := listNotificationEvents(ctx, t, server, sink+" && attempts == 0"); len(events) != 2 {
+		t.Errorf("Expected 2 postponed events, got %d", len(events))
+	}
+}
+
+func TestNotificationEventsErrors(t *testing.T) {
+	ctx := context.Background()
+	server, err := New(Config{
+		Database: "sqlite3",
+		DBConfig: fmt.Sprintf("%s/registry.db", t.TempDir()),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	list := &rpc.ListNotificationEventsRequest{PageSize: -1}
+	if _, err := server.ListNotificationEvents(ctx, list); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ListNotificationEvents(%+v) returned status code %q, want %q: %v", list, status.Code(err), codes.InvalidArgument, err)
+	}
+	list = &rpc.ListNotificationEventsRequest{Filter: `name == "x"`}
+	if _, err := server.ListNotificationEvents(ctx, list); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ListNotificationEvents(%+v) returned status code %q, want %q: %v", list, status.Code(err), codes.InvalidArgument, err)
+	}
+	list = &rpc.ListNotificationEventsRequest{OrderBy: "name"}
+	if _, err := server.ListNotificationEvents(ctx, list); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ListNotificationEvents(%+v) returned status code %q, want %q: %v", list, status.Code(err), codes.InvalidArgument, err)
+	}
+	replay := &rpc.ReplayNotificationEventsRequest{Filter: "invalid filter"}
+	if _, err := server.ReplayNotificationEvents(ctx, replay); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ReplayNotificationEvents(%+v) returned status code %q, want %q: %v", replay, status.Code(err), codes.InvalidArgument, err)
+	}
+}
+
+func TestDispatcherDelay(t *testing.T) {
+	d := &dispatcher{backoff: time.Second}
+	tests := []struct {
+		attempts int32
+		want     time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{5, 16 * time.Second},
+		{100, maxDispatchBackoff},
+	}
+	for _, test := range tests {
+		if got := d.delay(test.attempts); got != test.want {
+			t.Errorf("delay(%d) = %s, want %s", test.attempts, got, test.want)
+		}
+	}
+}
+
+func TestNotificationConfigErrors(t *testing.T) {
+	tests := []struct {
+		desc   string
+		config Config
+	}{
+		{
+			desc: "invalid webhook url",
+			config: Config{
+				Webhooks: []WebhookConfig{{URL: "ftp://example.com"}},
+			},
+		},
+		{
+			desc: "invalid webhook parent",
+			config: Config{
+				Webhooks: []WebhookConfig{{URL: "http://example.com", Parent: "invalid"}},
+			},
+		},
+		{
+			desc: "invalid webhook filter",
+			config: Config{
+				Webhooks: []WebhookConfig{{URL: "http://example.com", Filter: "invalid filter"}},
+			},
+		},
+		{
+			desc: "invalid broker protocol",
+			config: Config{
+				Brokers: []BrokerConfig{{Protocol: "carrier-pigeon", URL: "nats://localhost:4222", Subject: "registry"}},
+			},
+		},
+		{
+			desc: "missing broker subject",
+			config: Config{
+				Brokers: []BrokerConfig{{Protocol: "nats", URL: "nats://localhost:4222"}},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			test.config.Database = "sqlite3"
+			test.config.DBConfig = fmt.Sprintf("%s/registry.db", t.TempDir())
+			if server, err := New(test.config); err == nil {
+				server.Close()
+				t.Errorf("New(%+v) succeeded, expected error", test.config)
+			}
+		})
 	}
 }
@@ -0,0 +1,299 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/apigee/registry/log"
+	"github.com/apigee/registry/server/registry/auth"
+	"github.com/apigee/registry/server/registry/internal/storage"
+	"github.com/apigee/registry/server/registry/internal/storage/models"
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/longrunning"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const (
+	// defaultOperationWorkers is the default number of operations that run at once.
+	defaultOperationWorkers = 4
+	// maxQueuedOperations is the number of operations that can wait for a worker.
+	maxQueuedOperations = 100
+	// operationLease is the time that a running operation is kept after the
+	// last renewal of its lease before it is considered abandoned.
+	operationLease = 5 * time.Minute
+)
+
+// operationFunc runs an operation. It reports progress by calling update with
+// new metadata, and returns the response of the operation.
+type operationFunc func(ctx context.Context, update func(proto.Message)) (proto.Message, error)
+
+type operationJob struct {
+	name string
+	ctx  context.Context
+	fn   operationFunc
+}
+
+// operations runs long-running operations with a pool of workers and stores
+// their state in the database, where any server that shares it can report it.
+// Servers renew the leases of the operations that they run, and operations
+// with expired leases are marked as failed, so operations that were running
+// on servers that stopped unexpectedly don't appear to run forever.
+type operations struct {
+	db      *storage.Client
+	caller  func(context.Context) string
+	workers int
+	lease   time.Duration
+	queue   chan operationJob
+
+	mu sync.Mutex
+	// active holds the cancel functions of queued and running operations.
+	active map[string]context.CancelFunc
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newOperations(db *storage.Client, caller func(context.Context) string, workers int) *operations {
+	if workers <= 0 {
+		workers = defaultOperationWorkers
+	}
+	return &operations{
+		db:      db,
+		caller:  caller,
+		workers: workers,
+		lease:   operationLease,
+		queue:   make(chan operationJob, maxQueuedOperations),
+		active:  make(map[string]context.CancelFunc),
+	}
+}
+
+// start begins running operations.
+func (o *operations) start(ctx context.Context) {
+	o.ctx, o.cancel = context.WithCancel(ctx)
+	for i := 0; i < o.workers; i++ {
+		o.wg.Add(1)
+		go o.work()
+	}
+	o.wg.Add(1)
+	go o.renew()
+}
+
+// stop cancels the operations that are running and waits for them to finish.
+// Operations that are waiting for a worker fail.
+func (o *operations) stop() {
+	if o.cancel == nil {
+		return
+	}
+	o.cancel()
+	o.wg.Wait()
+	for {
+		select {
+		case job := <-o.queue:
+			o.finish(job.name, nil, errServerStopped)
+		default:
+			return
+		}
+	}
+}
+
+var errServerStopped = status.Error(codes.Aborted, "the server stopped before the operation finished")
+
+// submit stores a new operation with the specified initial metadata and queues fn to run it.
+func (o *operations) submit(ctx context.Context, method string, metadata proto.Message, fn operationFunc) (*longrunning.Operation, error) {
+	m, err := anypb.New(metadata)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	op, err := models.NewOperation("operations/"+uuid.New().String(), method, m, o.lease)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if err := o.db.CreateOperation(ctx, op); err != nil {
+		return nil, err
+	}
+
+	job := operationJob{name: op.Key, fn: fn}
+	var cancel context.CancelFunc
+	job.ctx, cancel = context.WithCancel(o.ctx)
+	// Operations act on behalf of their callers, so changes they make are audited as the callers'.
+	job.ctx = auth.NewContext(job.ctx, o.caller(ctx))
+	o.mu.Lock()
+	o.active[op.Key] = cancel
+	o.mu.Unlock()
+	select {
+	case o.queue <- job:
+	default:
+		err := status.Error(codes.ResourceExhausted, "too many operations are waiting to run")
+		o.finish(op.Key, nil, err)
+		return nil, err
+	}
+	return op.Message()
+}
+
+// cancelOperation asks an operation to stop. Operations running on other
+// servers stop when the servers next renew their leases.
+func (o *operations) cancelOperation(ctx context.Context, name string) error {
+	if err := o.db.CancelOperation(ctx, name); err != nil {
+		return err
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if cancel, ok := o.active[name]; ok {
+		cancel()
+	}
+	return nil
+}
+
+func (o *operations) work() {
+	defer o.wg.Done()
+	for {
+		select {
+		case <-o.ctx.Done():
+			return
+		case job := <-o.queue:
+			o.run(job)
+		}
+	}
+}
+
+// run runs an operation and stores its result.
+func (o *operations) run(job operationJob) {
+	var response proto.Message
+	err := job.ctx.Err()
+	if err == nil {
+		response, err = job.fn(job.ctx, func(metadata proto.Message) {
+			o.update(job.name, metadata)
+		})
+	}
+	if err != nil && o.ctx.Err() != nil {
+		err = errServerStopped
+	} else if err != nil && job.ctx.Err() != nil {
+		err = status.Error(codes.Canceled, "the operation was canceled")
+	}
+	o.finish(job.name, response, err)
+}
+
+// update stores the metadata of a running operation.
+func (o *operations) update(name string, metadata proto.Message) {
+	logger := log.FromContext(o.ctx).WithField("operation", name)
+	m, err := anypb.New(metadata)
+	if err != nil {
+		logger.WithError(err).Error("Failed to update operation metadata.")
+		return
+	}
+	b, err := proto.Marshal(m)
+	if err != nil {
+		logger.WithError(err).Error("Failed to update operation metadata.")
+		return
+	}
+	// Progress is stored even if the operation is stopping.
+	if err := o.db.UpdateOperationMetadata(context.Background(), name, b); err != nil {
+		logger.WithError(err).Error("Failed to update operation metadata.")
+	}
+}
+
+// finish stores the result of an operation, which failed if err is not nil.
+func (o *operations) finish(name string, response proto.Message, err error) {
+	o.mu.Lock()
+	if cancel, ok := o.active[name]; ok {
+		cancel()
+		delete(o.active, name)
+	}
+	o.mu.Unlock()
+
+	// Results are stored even if the server is stopping.
+	ctx := context.Background()
+	logger := log.FromContext(o.ctx).WithField("operation", name)
+	op, err2 := o.db.GetOperation(ctx, name)
+	if err2 != nil {
+		logger.WithError(err2).Error("Failed to store operation result.")
+		return
+	}
+	var r *anypb.Any
+	if err == nil {
+		r, err = anypb.New(response)
+	}
+	if err != nil {
+		err2 = op.Finish(nil, status.Convert(err).Proto())
+	} else {
+		err2 = op.Finish(r, nil)
+	}
+	if err2 == nil {
+		err2 = o.db.SaveOperation(ctx, op)
+	}
+	if err2 != nil {
+		logger.WithError(err2).Error("Failed to store operation result.")
+	}
+}
+
+// renew periodically renews the leases of active operations, stops those
+// that were canceled by other servers, and fails those that were abandoned.
+func (o *operations) renew() {
+	defer o.wg.Done()
+	abandoned, err := proto.Marshal(status.New(codes.Aborted, "the server running the operation stopped before it finished").Proto())
+	if err != nil {
+		log.FromContext(o.ctx).WithError(err).Error("Failed to renew operation leases.")
+		return
+	}
+	for {
+		if err := o.renewLeases(o.ctx, time.Now(), abandoned); o.ctx.Err() != nil {
+			return
+		} else if err != nil {
+			log.FromContext(o.ctx).WithError(err).Error("Failed to renew operation leases.")
+		}
+
+		timer := time.NewTimer(o.lease / 5)
+		select {
+		case <-o.ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+func (o *operations) renewLeases(ctx context.Context, now time.Time, abandoned []byte) error {
+	o.mu.Lock()
+	names := make([]string, 0, len(o.active))
+	for name := range o.active {
+		names = append(names, name)
+	}
+	o.mu.Unlock()
+
+	canceled, err := o.db.RenewOperationLeases(ctx, names, now.Add(o.lease))
+	if err != nil {
+		return err
+	}
+	o.mu.Lock()
+	for _, name := range canceled {
+		if cancel, ok := o.active[name]; ok {
+			cancel()
+		}
+	}
+	o.mu.Unlock()
+
+	n, err := o.db.AbandonOperations(ctx, now, abandoned)
+	if n > 0 {
+		log.FromContext(ctx).Infof("Marked %d abandoned operations as failed.", n)
+	}
+	return err
+}
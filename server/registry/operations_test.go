@@ -0,0 +1,180 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/storage/models"
+	"google.golang.org/genproto/googleapis/longrunning"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// blockingOperation runs until it is canceled.
+func blockingOperation(ctx context.Context, update func(proto.Message)) (proto.Message, error) {
+	update(&rpc.MigrateDatabaseMetadata{CompletedSteps: 1})
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestOperations(t *testing.T) {
+	ctx := context.Background()
+	server, err := serverWithSQLite(t)
+	if err != nil {
+		t.Fatalf("Setup: failed to get server with SQLite: %s", err)
+	}
+
+	done, err := server.operations.submit(ctx, "Test", &rpc.MigrateDatabaseMetadata{}, func(ctx context.Context, update func(proto.Message)) (proto.Message, error) {
+		return &rpc.MigrateDatabaseResponse{Message: "OK"}, nil
+	})
+	if err != nil {
+		t.Fatalf("submit() returned error: %s", err)
+	}
+	done = waitForOperation(ctx, t, server, done)
+	response := &rpc.MigrateDatabaseResponse{}
+	if err := done.GetResponse().UnmarshalTo(response); err != nil || response.Message != "OK" {
+		t.Errorf("Operation returned response %v, %v, want message %q", response, err, "OK")
+	}
+
+	running, err := server.operations.submit(ctx, "Test", &rpc.MigrateDatabaseMetadata{}, blockingOperation)
+	if err != nil {
+		t.Fatalf("submit() returned error: %s", err)
+	}
+	// Progress is visible while the operation runs.
+	for deadline := time.Now().Add(10 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+		op, err := server.GetOperation(ctx, &longrunning.GetOperationRequest{Name: running.Name})
+		if err != nil {
+			t.Fatalf("GetOperation(%q) returned error: %s", running.Name, err)
+		}
+		metadata := &rpc.MigrateDatabaseMetadata{}
+		if err := op.GetMetadata().UnmarshalTo(metadata); err != nil {
+			t.Fatalf("GetOperation(%q) returned unexpected metadata: %s", running.Name, err)
+		}
+		if metadata.CompletedSteps == 1 {
+			break
+		} else if time.Now().After(deadline) {
+			t.Fatalf("GetOperation(%q) did not report progress", running.Name)
+		}
+	}
+
+	tests := []struct {
+		filter string
+		want   []string
+	}{
+		{filter: "", want: []string{done.Name, running.Name}},
+		{filter: "done", want: []string{done.Name}},
+		{filter: "!done && method == 'Test'", want: []string{running.Name}},
+		{filter: "method == 'MigrateDatabase'", want: []string{}},
+	}
+	for _, test := range tests {
+		req := &longrunning.ListOperationsRequest{Name: "operations", Filter: test.filter}
+		listing, err := server.ListOperations(ctx, req)
+		if err != nil {
+			t.Fatalf("ListOperations(%+v) returned error: %s", req, err)
+		}
+		got := []string{}
+		for _, op := range listing.Operations {
+			got = append(got, op.Name)
+		}
+		if len(got) != len(test.want) {
+			t.Errorf("ListOperations(%+v) returned %v, want %v", req, got, test.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != test.want[i] {
+				t.Errorf("ListOperations(%+v) returned %v, want %v", req, got, test.want)
+				break
+			}
+		}
+	}
+
+	if _, err := server.DeleteOperation(ctx, &longrunning.DeleteOperationRequest{Name: running.Name}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("DeleteOperation(%q) of a running operation returned status code %q, want %q: %v", running.Name, status.Code(err), codes.FailedPrecondition, err)
+	}
+	if _, err := server.CancelOperation(ctx, &longrunning.CancelOperationRequest{Name: running.Name}); err != nil {
+		t.Fatalf("CancelOperation(%q) returned error: %s", running.Name, err)
+	}
+	canceled := waitForOperation(ctx, t, server, running)
+	if code := codes.Code(canceled.GetError().GetCode()); code != codes.Canceled {
+		t.Errorf("Canceled operation returned status code %q, want %q", code, codes.Canceled)
+	}
+	if _, err := server.DeleteOperation(ctx, &longrunning.DeleteOperationRequest{Name: running.Name}); err != nil {
+		t.Errorf("DeleteOperation(%q) returned error: %s", running.Name, err)
+	}
+	if _, err := server.GetOperation(ctx, &longrunning.GetOperationRequest{Name: running.Name}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetOperation(%q) of a deleted operation returned status code %q, want %q: %v", running.Name, status.Code(err), codes.NotFound, err)
+	}
+	if _, err := server.CancelOperation(ctx, &longrunning.CancelOperationRequest{Name: "operations/missing"}); status.Code(err) != codes.NotFound {
+		t.Errorf("CancelOperation() of a missing operation returned status code %q, want %q: %v", status.Code(err), codes.NotFound, err)
+	}
+}
+
+func TestOperationLeases(t *testing.T) {
+	ctx := context.Background()
+	server, err := serverWithSQLite(t)
+	if err != nil {
+		t.Fatalf("Setup: failed to get server with SQLite: %s", err)
+	}
+	abandoned, err := proto.Marshal(status.New(codes.Aborted, "abandoned").Proto())
+	if err != nil {
+		t.Fatalf("Setup: proto.Marshal() returned error: %s", err)
+	}
+
+	// Operations canceled by other servers stop when their leases are renewed.
+	running, err := server.operations.submit(ctx, "Test", &rpc.MigrateDatabaseMetadata{}, blockingOperation)
+	if err != nil {
+		t.Fatalf("submit() returned error: %s", err)
+	}
+	if err := server.storageClient.CancelOperation(ctx, running.Name); err != nil {
+		t.Fatalf("CancelOperation(%q) returned error: %s", running.Name, err)
+	}
+	if err := server.operations.renewLeases(ctx, time.Now(), abandoned); err != nil {
+		t.Fatalf("renewLeases() returned error: %s", err)
+	}
+	if op := waitForOperation(ctx, t, server, running); codes.Code(op.GetError().GetCode()) != codes.Canceled {
+		t.Errorf("Canceled operation returned status %v, want code %q", op.GetError(), codes.Canceled)
+	}
+
+	// Operations of servers that stopped unexpectedly fail when their leases expire.
+	metadata, err := anypb.New(&rpc.MigrateDatabaseMetadata{})
+	if err != nil {
+		t.Fatalf("Setup: anypb.New() returned error: %s", err)
+	}
+	op, err := models.NewOperation("operations/abandoned", "Test", metadata, time.Minute)
+	if err != nil {
+		t.Fatalf("Setup: NewOperation() returned error: %s", err)
+	}
+	if err := server.storageClient.CreateOperation(ctx, op); err != nil {
+		t.Fatalf("Setup: CreateOperation() returned error: %s", err)
+	}
+	if err := server.operations.renewLeases(ctx, time.Now(), abandoned); err != nil {
+		t.Fatalf("renewLeases() returned error: %s", err)
+	}
+	if got, err := server.GetOperation(ctx, &longrunning.GetOperationRequest{Name: op.Key}); err != nil || got.Done {
+		t.Fatalf("GetOperation(%q) within its lease returned %v, %v, want a running operation", op.Key, got, err)
+	}
+	if err := server.operations.renewLeases(ctx, time.Now().Add(2*time.Minute), abandoned); err != nil {
+		t.Fatalf("renewLeases() returned error: %s", err)
+	}
+	if got, err := server.GetOperation(ctx, &longrunning.GetOperationRequest{Name: op.Key}); err != nil || codes.Code(got.GetError().GetCode()) != codes.Aborted {
+		t.Errorf("GetOperation(%q) after its lease expired returned %v, %v, want an aborted operation", op.Key, got, err)
+	}
+}
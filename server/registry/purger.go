@@ -0,0 +1,135 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"time"
+
+	"github.com/apigee/registry/log"
+	"github.com/apigee/registry/server/registry/internal/storage"
+)
+
+// maxPurgeInterval is the maximum time between purges of deleted resources.
+const maxPurgeInterval = time.Hour
+
+// purger permanently removes deleted resources in the background once they
+// have been deleted for longer than the purge window. It also removes expired
+// archives and shared contents that are no longer used.
+type purger struct {
+	server *RegistryServer
+	window time.Duration
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func newPurger(s *RegistryServer, window time.Duration) *purger {
+	return &purger{
+		server: s,
+		window: window,
+		done:   make(chan struct{}),
+	}
+}
+
+// start begins purging deleted resources.
+func (p *purger) start(ctx context.Context) {
+	ctx, p.cancel = context.WithCancel(ctx)
+	go p.run(ctx)
+}
+
+// stop waits for the purge in progress to finish and stops purging.
+func (p *purger) stop() {
+	if p.cancel == nil {
+		return
+	}
+	p.cancel()
+	<-p.done
+}
+
+func (p *purger) run(ctx context.Context) {
+	defer close(p.done)
+	interval := p.window
+	if interval == 0 || interval > maxPurgeInterval {
+		interval = maxPurgeInterval
+	}
+	for {
+		// Deleted resources are removed immediately when there is no purge window.
+		if p.window > 0 {
+			if n, err := p.purge(ctx, time.Now()); ctx.Err() != nil {
+				return
+			} else if err != nil {
+				log.FromContext(ctx).WithError(err).Error("Failed to purge deleted resources.")
+			} else if n > 0 {
+				log.FromContext(ctx).Infof("Purged %d deleted rows.", n)
+			}
+		}
+		if n, err := p.purgeArchives(ctx, time.Now()); ctx.Err() != nil {
+			return
+		} else if err != nil {
+			log.FromContext(ctx).WithError(err).Error("Failed to purge expired archives.")
+		} else if n > 0 {
+			log.FromContext(ctx).Infof("Purged %d expired archives.", n)
+		}
+		if n, err := p.purgeContents(ctx); ctx.Err() != nil {
+			return
+		} else if err != nil {
+			log.FromContext(ctx).WithError(err).Error("Failed to purge unused contents.")
+		} else if n > 0 {
+			log.FromContext(ctx).Infof("Purged %d unused contents.", n)
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// purge removes resources that were deleted before the purge window that ends at a specified time.
+// It returns the number of rows removed.
+func (p *purger) purge(ctx context.Context, now time.Time) (int64, error) {
+	var n int64
+	err := p.server.runInTransaction(ctx, func(ctx context.Context, db *storage.Client) (err error) {
+		n, err = db.PurgeDeleted(ctx, now.Add(-p.window))
+		return err
+	})
+	return n, err
+}
+
+// purgeArchives removes archives that expired before a specified time.
+// It returns the number of archives removed.
+func (p *purger) purgeArchives(ctx context.Context, now time.Time) (int64, error) {
+	return p.server.storageClient.PurgeArchives(ctx, now)
+}
+
+// purgeContents removes shared contents that are no longer used.
+// Contents of deleted resources are kept until the resources are purged.
+func (p *purger) purgeContents(ctx context.Context) (int, error) {
+	return p.server.storageClient.PurgeContents(ctx)
+}
+
+// purgeDeleted immediately removes the resources deleted in a transaction if
+// the server does not keep deleted resources.
+func (s *RegistryServer) purgeDeleted(ctx context.Context, db *storage.Client) error {
+	if s.purgeWindow > 0 {
+		return nil
+	}
+	_, err := db.PurgeDeleted(ctx, time.Now())
+	return err
+}
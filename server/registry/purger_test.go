@@ -0,0 +1,59 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/test/seeder"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPurger(t *testing.T) {
+	ctx := context.Background()
+	server := serverWithPurgeWindow(t)
+	api := &rpc.Api{Name: "projects/my-project/locations/global/apis/my-api"}
+	if err := seeder.SeedApis(ctx, server, api); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+	if _, err := server.DeleteProject(ctx, &rpc.DeleteProjectRequest{Name: "projects/my-project", Force: true}); err != nil {
+		t.Fatalf("DeleteProject() returned error: %s", err)
+	}
+
+	if n, err := server.purger.purge(ctx, time.Now()); err != nil {
+		t.Fatalf("purge() returned error: %s", err)
+	} else if n != 0 {
+		t.Errorf("purge() removed %d rows inside the purge window, want 0", n)
+	}
+
+	if n, err := server.purger.purge(ctx, time.Now().Add(2*time.Hour)); err != nil {
+		t.Fatalf("purge() returned error: %s", err)
+	} else if n != 3 {
+		// The project, the API and the API's search document.
+		t.Errorf("purge() removed %d rows after the purge window, want 3", n)
+	}
+
+	if _, err := server.UndeleteProject(ctx, &rpc.UndeleteProjectRequest{Name: "projects/my-project"}); status.Code(err) != codes.NotFound {
+		t.Errorf("UndeleteProject() returned status code %q, want %q: %v", status.Code(err), codes.NotFound, err)
+	}
+	// Purged names can be reused.
+	if err := seeder.SeedApis(ctx, server, api); err != nil {
+		t.Errorf("Seeding after purge returned error: %s", err)
+	}
+}
@@ -19,11 +19,12 @@ import (
 	"errors"
 	"log"
 	"net"
-	"sync"
+	"time"
 
-	"cloud.google.com/go/pubsub"
 	"github.com/apigee/registry/rpc"
 	"github.com/apigee/registry/server/registry/internal/storage"
+	"github.com/apigee/registry/server/registry/internal/storage/models"
+	"google.golang.org/genproto/googleapis/longrunning"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/reflection"
@@ -38,27 +39,83 @@ type Config struct {
 	LogFormat string
 	Notify    bool
 	ProjectID string
+	Webhooks  []WebhookConfig
+	Brokers   []BrokerConfig
+	// NotificationAttempts is the number of attempts made to deliver a
+	// notification to a sink before it is marked as failed. Defaults to 10.
+	NotificationAttempts int
+	// NotificationBackoff is the delay before a failed delivery is retried,
+	// doubled for each retry up to a maximum of ten minutes. Defaults to 1s.
+	NotificationBackoff time.Duration
+	// PurgeWindow is the time that deleted resources are kept before they are
+	// permanently removed. Deleted resources can be restored with the Undelete
+	// methods until they are removed. If zero, deleted resources are removed immediately.
+	PurgeWindow time.Duration
+	// BlobStore configures storage of spec and artifact contents outside of the database.
+	BlobStore BlobStoreConfig
+	// OperationWorkers is the number of long-running operations that run at once. Defaults to 4.
+	OperationWorkers int
+	// Retention configures the removal of old revisions of specs, deployments and artifacts.
+	Retention RetentionConfig
+	// ArtifactRevisions, if true, keeps earlier revisions of artifacts when they
+	// are replaced. Otherwise only the current and tagged revisions are kept.
+	ArtifactRevisions bool
+	// ProtectReferences, if true, rejects deletes of resources that are
+	// referenced by other resources.
+	ProtectReferences bool
+	// TrustedProxy, if true, identifies unauthenticated callers in audit events by
+	// the metadata that authenticating proxies set. It should only be set when all
+	// requests reach the server through such a proxy, because callers can set any
+	// metadata.
+	TrustedProxy bool
+	// MaxArchiveSize is the size in bytes of the largest archive of a project
+	// that can be exported or written. Defaults to 4 GiB.
+	MaxArchiveSize int64
 }
 
 // RegistryServer implements a Registry server.
 type RegistryServer struct {
 	database      string
 	dbConfig      string
-	notifyEnabled bool
-	projectID     string
 	storageClient *storage.Client
-	pubSubClient  *pubsub.Client
+	sinks         []sink
+	dispatcher    *dispatcher
+	watchers      watchers
+	purgeWindow   time.Duration
+	purger        *purger
+	retention     RetentionConfig
+	compactor     *compactor
+	// artifactRevisions is true if earlier revisions of artifacts are kept.
+	artifactRevisions bool
+	// protectReferences is true if referenced resources can't be deleted.
+	protectReferences bool
+	// trustedProxy is true if callers can be identified by proxy metadata.
+	trustedProxy bool
+	// maxArchiveSize is the size of the largest archive that can be exported or written.
+	maxArchiveSize int64
+	operations     *operations
+	// migrating is 1 while a database migration is running.
+	migrating int32
 
 	rpc.UnimplementedRegistryServer
 	rpc.UnimplementedAdminServer
+	longrunning.UnimplementedOperationsServer
 }
 
 func New(config Config) (*RegistryServer, error) {
 	s := &RegistryServer{
-		database:      config.Database,
-		dbConfig:      config.DBConfig,
-		notifyEnabled: config.Notify,
-		projectID:     config.ProjectID,
+		database:    config.Database,
+		dbConfig:    config.DBConfig,
+		purgeWindow: config.PurgeWindow,
+		retention:   config.Retention,
+
+		artifactRevisions: config.ArtifactRevisions,
+		protectReferences: config.ProtectReferences,
+		trustedProxy:      config.TrustedProxy,
+		maxArchiveSize:    config.MaxArchiveSize,
+	}
+	if s.maxArchiveSize == 0 {
+		s.maxArchiveSize = defaultMaxArchiveSize
 	}
 
 	if s.database == "" {
@@ -75,62 +132,109 @@ func New(config Config) (*RegistryServer, error) {
 	if err := s.storageClient.EnsureTables(ctx); err != nil {
 		return nil, err
 	}
+	blobs, err := newBlobStore(config.BlobStore)
+	if err != nil {
+		s.storageClient.Close()
+		return nil, err
+	}
+	if blobs != nil {
+		s.storageClient.UseBlobStore(blobs)
+	}
 
-	if s.notifyEnabled {
-		s.pubSubClient, err = pubsub.NewClient(ctx, s.projectID)
-		if err != nil {
-			return nil, err
-		}
-		if _, err := s.pubSubClient.CreateTopic(ctx, TopicName); err != nil && status.Code(err) != codes.AlreadyExists {
-			return nil, err
-		}
+	s.sinks, err = newSinks(ctx, config)
+	if err != nil {
+		s.storageClient.Close()
+		return nil, err
+	}
+	s.dispatcher = newDispatcher(s, config.NotificationAttempts, config.NotificationBackoff)
+	s.dispatcher.start(ctx)
+	s.operations = newOperations(s.storageClient, s.caller, config.OperationWorkers)
+	s.operations.start(ctx)
+	// Expired archives, deleted resources that are kept for a while, and unused
+	// shared contents are removed in the background.
+	s.purger = newPurger(s, s.purgeWindow)
+	s.purger.start(ctx)
+	if s.retention.enabled() {
+		s.compactor = newCompactor(s, s.retention)
+		s.compactor.start(ctx)
 	}
 
 	return s, nil
 }
 
+// getStorageClient returns a client that reads from storage.
+// Changes are made with runInTransaction.
 func (s *RegistryServer) getStorageClient(ctx context.Context) (*storage.Client, error) {
 	if s.storageClient == nil {
 		return nil, errors.New("no storageClient")
 	}
-	return s.storageClient, nil
+	return s.storageClient.ReadOnly(), nil
 }
 
-var mutex sync.Mutex
+type changesKey struct{}
+
+type transactionKey struct{}
 
+// changes collects the notifications and audit events of a transaction.
+type changes struct {
+	notifications []*rpc.Notification
+	auditEvents   []*models.AuditEvent
+}
+
+// runInTransaction runs fn in a database transaction. Audit events and
+// notifications of changes made by fn are stored in the same transaction,
+// and notifications are delivered after it commits. When called from within
+// fn, runInTransaction joins the enclosing transaction.
 func (s *RegistryServer) runInTransaction(ctx context.Context, fn func(ctx context.Context, db *storage.Client) error) error {
-	s.begin()
-	defer s.end()
-	db, err := s.getStorageClient(ctx)
-	if err != nil {
-		return status.Error(codes.Unavailable, err.Error())
+	if tx, ok := ctx.Value(transactionKey{}).(*storage.Client); ok {
+		return fn(ctx, tx)
 	}
-	return db.Transaction(ctx, fn)
-}
 
-func (s *RegistryServer) begin() {
-	if s.database == "sqlite3" {
-		mutex.Lock()
+	db := s.storageClient
+	if db == nil {
+		return status.Error(codes.Unavailable, "no storageClient")
 	}
-}
 
-func (s *RegistryServer) end() {
-	if s.database == "sqlite3" {
-		mutex.Unlock()
+	c := &changes{}
+	ctx = context.WithValue(ctx, changesKey{}, c)
+	if err := db.Transaction(ctx, func(ctx context.Context, db *storage.Client) error {
+		if err := fn(context.WithValue(ctx, transactionKey{}, db), db); err != nil {
+			return err
+		}
+		if err := db.CreateAuditEvents(ctx, c.auditEvents); err != nil {
+			return err
+		}
+		return s.recordNotifications(ctx, db, c.notifications)
+	}); err != nil {
+		return err
 	}
-}
 
-func (s *RegistryServer) getPubSubClient(ctx context.Context) (*pubsub.Client, error) {
-	if s.pubSubClient == nil {
-		return nil, errors.New("no pubSubClient")
+	for _, n := range c.notifications {
+		s.watchers.publish(n)
+	}
+	if len(c.notifications) > 0 && s.dispatcher != nil {
+		s.dispatcher.wake()
 	}
-	return s.pubSubClient, nil
+	return nil
 }
 
 func (s *RegistryServer) Close() {
+	if s.operations != nil {
+		s.operations.stop()
+	}
+	if s.dispatcher != nil {
+		s.dispatcher.stop()
+	}
+	if s.compactor != nil {
+		s.compactor.stop()
+	}
+	if s.purger != nil {
+		s.purger.stop()
+	}
+	s.watchers.close()
 	s.storageClient.Close()
-	if s.pubSubClient != nil {
-		s.pubSubClient.Topic(TopicName).Flush()
+	for _, sink := range s.sinks {
+		sink.notifier.Close()
 	}
 }
 
@@ -150,6 +254,7 @@ func (rs *RegistryServer) ServeGRPC(addr *net.TCPAddr, opt ...grpc.ServerOption)
 	reflection.Register(s)
 	rpc.RegisterRegistryServer(s, rs)
 	rpc.RegisterAdminServer(s, rs)
+	longrunning.RegisterOperationsServer(s, rs)
 
 	go func() {
 		if err := s.Serve(l); err != nil {
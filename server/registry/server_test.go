@@ -20,9 +20,11 @@ import (
 	"fmt"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/apigee/registry/rpc"
 	"github.com/apigee/registry/server/registry/test/remote"
+	"google.golang.org/genproto/googleapis/longrunning"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
@@ -54,6 +56,7 @@ func init() {
 type TestServer interface {
 	rpc.AdminServer
 	rpc.RegistryServer
+	longrunning.OperationsServer
 }
 
 // defaultTestServer will call server.Close() when test completes
@@ -103,6 +106,36 @@ func serverWithSQLite(t *testing.T) (*RegistryServer, error) {
 	return server, err
 }
 
+// serverWithPurgeWindow returns a server that keeps deleted resources for an hour.
+// It will call server.Close() when test completes
+func serverWithPurgeWindow(t *testing.T) *RegistryServer {
+	t.Helper()
+	server, err := New(Config{
+		Database:    "sqlite3",
+		DBConfig:    fmt.Sprintf("%s/registry.db", t.TempDir()),
+		PurgeWindow: time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("Setup: failed to get server with SQLite: %s", err)
+	}
+	t.Cleanup(server.Close)
+	return server
+}
+
+func serverWithRetention(t *testing.T, retention RetentionConfig) *RegistryServer {
+	t.Helper()
+	server, err := New(Config{
+		Database:  "sqlite3",
+		DBConfig:  fmt.Sprintf("%s/registry.db", t.TempDir()),
+		Retention: retention,
+	})
+	if err != nil {
+		t.Fatalf("Setup: failed to get server with SQLite: %s", err)
+	}
+	t.Cleanup(server.Close)
+	return server
+}
+
 // serverWithPostgres will call server.Close() when test completes
 func serverWithPostgres(t *testing.T) (*RegistryServer, error) {
 	sharedStorage.Lock()
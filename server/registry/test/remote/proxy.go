@@ -17,6 +17,7 @@ package remote
 import (
 	"context"
 	"errors"
+	"io"
 	"log"
 	"strings"
 
@@ -43,6 +44,7 @@ type Proxy struct {
 
 	rpc.UnimplementedRegistryServer
 	rpc.UnimplementedAdminServer
+	longrunning.UnimplementedOperationsServer
 }
 
 func (p *Proxy) Open(ctx context.Context) error {
@@ -120,6 +122,41 @@ func (p *Proxy) MigrateDatabase(ctx context.Context, req *rpc.MigrateDatabaseReq
 	return p.adminClient.GrpcClient().MigrateDatabase(ctx, req)
 }
 
+func (p *Proxy) CompactRevisions(ctx context.Context, req *rpc.CompactRevisionsRequest) (*rpc.CompactRevisionsResponse, error) {
+	if p.adminClient == nil {
+		return nil, ErrAdminServiceUnavailable
+	}
+	return p.adminClient.GrpcClient().CompactRevisions(ctx, req)
+}
+
+func (p *Proxy) GetOperation(ctx context.Context, req *longrunning.GetOperationRequest) (*longrunning.Operation, error) {
+	if p.adminClient == nil {
+		return nil, ErrAdminServiceUnavailable
+	}
+	return longrunning.NewOperationsClient(p.adminClient.Connection()).GetOperation(ctx, req)
+}
+
+func (p *Proxy) ListOperations(ctx context.Context, req *longrunning.ListOperationsRequest) (*longrunning.ListOperationsResponse, error) {
+	if p.adminClient == nil {
+		return nil, ErrAdminServiceUnavailable
+	}
+	return longrunning.NewOperationsClient(p.adminClient.Connection()).ListOperations(ctx, req)
+}
+
+func (p *Proxy) CancelOperation(ctx context.Context, req *longrunning.CancelOperationRequest) (*emptypb.Empty, error) {
+	if p.adminClient == nil {
+		return nil, ErrAdminServiceUnavailable
+	}
+	return longrunning.NewOperationsClient(p.adminClient.Connection()).CancelOperation(ctx, req)
+}
+
+func (p *Proxy) DeleteOperation(ctx context.Context, req *longrunning.DeleteOperationRequest) (*emptypb.Empty, error) {
+	if p.adminClient == nil {
+		return nil, ErrAdminServiceUnavailable
+	}
+	return longrunning.NewOperationsClient(p.adminClient.Connection()).DeleteOperation(ctx, req)
+}
+
 // Projects
 
 func (p *Proxy) GetProject(ctx context.Context, req *rpc.GetProjectRequest) (*rpc.Project, error) {
@@ -161,6 +198,145 @@ func (p *Proxy) DeleteProject(ctx context.Context, req *rpc.DeleteProjectRequest
 	return p.adminClient.GrpcClient().DeleteProject(ctx, req)
 }
 
+func (p *Proxy) UndeleteProject(ctx context.Context, req *rpc.UndeleteProjectRequest) (*rpc.Project, error) {
+	if p.adminClient == nil {
+		return nil, ErrAdminServiceUnavailable
+	}
+	return p.adminClient.GrpcClient().UndeleteProject(ctx, req)
+}
+
+func (p *Proxy) WatchResources(req *rpc.WatchResourcesRequest, stream rpc.Admin_WatchResourcesServer) error {
+	if p.adminClient == nil {
+		return ErrAdminServiceUnavailable
+	}
+	remote, err := p.adminClient.GrpcClient().WatchResources(stream.Context(), req)
+	if err != nil {
+		return err
+	}
+	header, err := remote.Header()
+	if err != nil {
+		return err
+	}
+	if err := stream.SendHeader(header); err != nil {
+		return err
+	}
+	for {
+		n, err := remote.Recv()
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(n); err != nil {
+			return err
+		}
+	}
+}
+
+func (p *Proxy) CloneProject(ctx context.Context, req *rpc.CloneProjectRequest) (*rpc.Project, error) {
+	if p.adminClient == nil {
+		return nil, ErrAdminServiceUnavailable
+	}
+	return p.adminClient.GrpcClient().CloneProject(ctx, req)
+}
+
+func (p *Proxy) CopyApi(ctx context.Context, req *rpc.CopyApiRequest) (*rpc.Api, error) {
+	if p.adminClient == nil {
+		return nil, ErrAdminServiceUnavailable
+	}
+	return p.adminClient.GrpcClient().CopyApi(ctx, req)
+}
+
+func (p *Proxy) ExportProject(ctx context.Context, req *rpc.ExportProjectRequest) (*longrunning.Operation, error) {
+	if p.adminClient == nil {
+		return nil, ErrAdminServiceUnavailable
+	}
+	return p.adminClient.GrpcClient().ExportProject(ctx, req)
+}
+
+func (p *Proxy) ImportProject(ctx context.Context, req *rpc.ImportProjectRequest) (*longrunning.Operation, error) {
+	if p.adminClient == nil {
+		return nil, ErrAdminServiceUnavailable
+	}
+	return p.adminClient.GrpcClient().ImportProject(ctx, req)
+}
+
+func (p *Proxy) ReadArchive(req *rpc.ReadArchiveRequest, stream rpc.Admin_ReadArchiveServer) error {
+	if p.adminClient == nil {
+		return ErrAdminServiceUnavailable
+	}
+	remote, err := p.adminClient.GrpcClient().ReadArchive(stream.Context(), req)
+	if err != nil {
+		return err
+	}
+	for {
+		chunk, err := remote.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if err := stream.Send(chunk); err != nil {
+			return err
+		}
+	}
+}
+
+func (p *Proxy) WriteArchive(stream rpc.Admin_WriteArchiveServer) error {
+	if p.adminClient == nil {
+		return ErrAdminServiceUnavailable
+	}
+	remote, err := p.adminClient.GrpcClient().WriteArchive(stream.Context())
+	if err != nil {
+		return err
+	}
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		// Sends fail with io.EOF when the server stops early, and CloseAndRecv returns its error.
+		if err := remote.Send(chunk); err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+	}
+	archive, err := remote.CloseAndRecv()
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(archive)
+}
+
+func (p *Proxy) ListNotificationEvents(ctx context.Context, req *rpc.ListNotificationEventsRequest) (*rpc.ListNotificationEventsResponse, error) {
+	if p.adminClient == nil {
+		return nil, ErrAdminServiceUnavailable
+	}
+	return p.adminClient.GrpcClient().ListNotificationEvents(ctx, req)
+}
+
+func (p *Proxy) ReplayNotificationEvents(ctx context.Context, req *rpc.ReplayNotificationEventsRequest) (*rpc.ReplayNotificationEventsResponse, error) {
+	if p.adminClient == nil {
+		return nil, ErrAdminServiceUnavailable
+	}
+	return p.adminClient.GrpcClient().ReplayNotificationEvents(ctx, req)
+}
+
+func (p *Proxy) ListAuditEvents(ctx context.Context, req *rpc.ListAuditEventsRequest) (*rpc.ListAuditEventsResponse, error) {
+	if p.adminClient == nil {
+		return nil, ErrAdminServiceUnavailable
+	}
+	return p.adminClient.GrpcClient().ListAuditEvents(ctx, req)
+}
+
+func (p *Proxy) AggregateResources(ctx context.Context, req *rpc.AggregateResourcesRequest) (*rpc.AggregateResourcesResponse, error) {
+	if p.adminClient == nil {
+		return nil, ErrAdminServiceUnavailable
+	}
+	return p.adminClient.GrpcClient().AggregateResources(ctx, req)
+}
+
 // Apis
 
 func (p *Proxy) GetApi(ctx context.Context, req *rpc.GetApiRequest) (*rpc.Api, error) {
@@ -218,6 +394,17 @@ func (p *Proxy) DeleteApi(ctx context.Context, req *rpc.DeleteApiRequest) (*empt
 	return p.registryClient.GrpcClient().DeleteApi(ctx, req)
 }
 
+func (p *Proxy) UndeleteApi(ctx context.Context, req *rpc.UndeleteApiRequest) (*rpc.Api, error) {
+	req, _ = proto.Clone(req).(*rpc.UndeleteApiRequest)
+	req.Name = p.hostedResourceName(req.Name)
+	response, err := p.registryClient.GrpcClient().UndeleteApi(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	response.Name = p.testResourceName(response.Name)
+	return response, err
+}
+
 // Versions
 
 func (p *Proxy) GetApiVersion(ctx context.Context, req *rpc.GetApiVersionRequest) (*rpc.ApiVersion, error) {
@@ -275,6 +462,17 @@ func (p *Proxy) DeleteApiVersion(ctx context.Context, req *rpc.DeleteApiVersionR
 	return p.registryClient.GrpcClient().DeleteApiVersion(ctx, req)
 }
 
+func (p *Proxy) UndeleteApiVersion(ctx context.Context, req *rpc.UndeleteApiVersionRequest) (*rpc.ApiVersion, error) {
+	req, _ = proto.Clone(req).(*rpc.UndeleteApiVersionRequest)
+	req.Name = p.hostedResourceName(req.Name)
+	response, err := p.registryClient.GrpcClient().UndeleteApiVersion(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	response.Name = p.testResourceName(response.Name)
+	return response, err
+}
+
 // Specs
 
 func (p *Proxy) GetApiSpec(ctx context.Context, req *rpc.GetApiSpecRequest) (*rpc.ApiSpec, error) {
@@ -332,6 +530,17 @@ func (p *Proxy) DeleteApiSpec(ctx context.Context, req *rpc.DeleteApiSpecRequest
 	return p.registryClient.GrpcClient().DeleteApiSpec(ctx, req)
 }
 
+func (p *Proxy) UndeleteApiSpec(ctx context.Context, req *rpc.UndeleteApiSpecRequest) (*rpc.ApiSpec, error) {
+	req, _ = proto.Clone(req).(*rpc.UndeleteApiSpecRequest)
+	req.Name = p.hostedResourceName(req.Name)
+	response, err := p.registryClient.GrpcClient().UndeleteApiSpec(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	response.Name = p.testResourceName(response.Name)
+	return response, err
+}
+
 func (p *Proxy) GetApiSpecContents(ctx context.Context, req *rpc.GetApiSpecContentsRequest) (*httpbody.HttpBody, error) {
 	req, _ = proto.Clone(req).(*rpc.GetApiSpecContentsRequest)
 	req.Name = p.hostedResourceName(req.Name)
@@ -439,6 +648,17 @@ func (p *Proxy) DeleteApiDeployment(ctx context.Context, req *rpc.DeleteApiDeplo
 	return p.registryClient.GrpcClient().DeleteApiDeployment(ctx, req)
 }
 
+func (p *Proxy) UndeleteApiDeployment(ctx context.Context, req *rpc.UndeleteApiDeploymentRequest) (*rpc.ApiDeployment, error) {
+	req, _ = proto.Clone(req).(*rpc.UndeleteApiDeploymentRequest)
+	req.Name = p.hostedResourceName(req.Name)
+	response, err := p.registryClient.GrpcClient().UndeleteApiDeployment(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	response.Name = p.testResourceName(response.Name)
+	return response, err
+}
+
 func (p *Proxy) ListApiDeploymentRevisions(ctx context.Context, req *rpc.ListApiDeploymentRevisionsRequest) (*rpc.ListApiDeploymentRevisionsResponse, error) {
 	req, _ = proto.Clone(req).(*rpc.ListApiDeploymentRevisionsRequest)
 	req.Name = p.hostedResourceName(req.Name)
@@ -536,12 +756,326 @@ func (p *Proxy) DeleteArtifact(ctx context.Context, req *rpc.DeleteArtifactReque
 	return p.registryClient.GrpcClient().DeleteArtifact(ctx, req)
 }
 
+func (p *Proxy) UndeleteArtifact(ctx context.Context, req *rpc.UndeleteArtifactRequest) (*rpc.Artifact, error) {
+	req, _ = proto.Clone(req).(*rpc.UndeleteArtifactRequest)
+	req.Name = p.hostedResourceName(req.Name)
+	response, err := p.registryClient.GrpcClient().UndeleteArtifact(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	response.Name = p.testResourceName(response.Name)
+	return response, err
+}
+
 func (p *Proxy) GetArtifactContents(ctx context.Context, req *rpc.GetArtifactContentsRequest) (*httpbody.HttpBody, error) {
 	req, _ = proto.Clone(req).(*rpc.GetArtifactContentsRequest)
 	req.Name = p.hostedResourceName(req.Name)
 	return p.registryClient.GrpcClient().GetArtifactContents(ctx, req)
 }
 
+func (p *Proxy) ListArtifactRevisions(ctx context.Context, req *rpc.ListArtifactRevisionsRequest) (*rpc.ListArtifactRevisionsResponse, error) {
+	req, _ = proto.Clone(req).(*rpc.ListArtifactRevisionsRequest)
+	req.Name = p.hostedResourceName(req.Name)
+	response, err := p.registryClient.GrpcClient().ListArtifactRevisions(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	for i := range response.Artifacts {
+		response.Artifacts[i].Name = p.testResourceName(response.Artifacts[i].Name)
+	}
+	return response, err
+}
+
+func (p *Proxy) TagArtifactRevision(ctx context.Context, req *rpc.TagArtifactRevisionRequest) (*rpc.Artifact, error) {
+	req, _ = proto.Clone(req).(*rpc.TagArtifactRevisionRequest)
+	req.Name = p.hostedResourceName(req.Name)
+	response, err := p.registryClient.GrpcClient().TagArtifactRevision(ctx, req)
+	if err == nil {
+		response.Name = p.testResourceName(response.Name)
+	}
+	return response, err
+}
+
+func (p *Proxy) RollbackArtifact(ctx context.Context, req *rpc.RollbackArtifactRequest) (*rpc.Artifact, error) {
+	req, _ = proto.Clone(req).(*rpc.RollbackArtifactRequest)
+	req.Name = p.hostedResourceName(req.Name)
+	response, err := p.registryClient.GrpcClient().RollbackArtifact(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	response.Name = p.testResourceName(response.Name)
+	return response, err
+}
+
+func (p *Proxy) BatchCreateApis(ctx context.Context, req *rpc.BatchCreateApisRequest) (*rpc.BatchCreateApisResponse, error) {
+	req, _ = proto.Clone(req).(*rpc.BatchCreateApisRequest)
+	req.Parent = p.hostedResourceName(req.Parent)
+	for _, r := range req.Requests {
+		r.Parent = p.hostedResourceName(r.Parent)
+	}
+	response, err := p.registryClient.GrpcClient().BatchCreateApis(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range response.Apis {
+		v.Name = p.testResourceName(v.Name)
+	}
+	return response, err
+}
+
+func (p *Proxy) BatchUpdateApis(ctx context.Context, req *rpc.BatchUpdateApisRequest) (*rpc.BatchUpdateApisResponse, error) {
+	req, _ = proto.Clone(req).(*rpc.BatchUpdateApisRequest)
+	req.Parent = p.hostedResourceName(req.Parent)
+	for _, r := range req.Requests {
+		if r.Api != nil {
+			r.Api.Name = p.hostedResourceName(r.Api.Name)
+		}
+	}
+	response, err := p.registryClient.GrpcClient().BatchUpdateApis(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range response.Apis {
+		v.Name = p.testResourceName(v.Name)
+	}
+	return response, err
+}
+
+func (p *Proxy) BatchDeleteApis(ctx context.Context, req *rpc.BatchDeleteApisRequest) (*rpc.BatchDeleteApisResponse, error) {
+	req, _ = proto.Clone(req).(*rpc.BatchDeleteApisRequest)
+	req.Parent = p.hostedResourceName(req.Parent)
+	for _, r := range req.Requests {
+		r.Name = p.hostedResourceName(r.Name)
+	}
+	return p.registryClient.GrpcClient().BatchDeleteApis(ctx, req)
+}
+
+func (p *Proxy) BatchCreateApiVersions(ctx context.Context, req *rpc.BatchCreateApiVersionsRequest) (*rpc.BatchCreateApiVersionsResponse, error) {
+	req, _ = proto.Clone(req).(*rpc.BatchCreateApiVersionsRequest)
+	req.Parent = p.hostedResourceName(req.Parent)
+	for _, r := range req.Requests {
+		r.Parent = p.hostedResourceName(r.Parent)
+	}
+	response, err := p.registryClient.GrpcClient().BatchCreateApiVersions(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range response.ApiVersions {
+		v.Name = p.testResourceName(v.Name)
+	}
+	return response, err
+}
+
+func (p *Proxy) BatchUpdateApiVersions(ctx context.Context, req *rpc.BatchUpdateApiVersionsRequest) (*rpc.BatchUpdateApiVersionsResponse, error) {
+	req, _ = proto.Clone(req).(*rpc.BatchUpdateApiVersionsRequest)
+	req.Parent = p.hostedResourceName(req.Parent)
+	for _, r := range req.Requests {
+		if r.ApiVersion != nil {
+			r.ApiVersion.Name = p.hostedResourceName(r.ApiVersion.Name)
+		}
+	}
+	response, err := p.registryClient.GrpcClient().BatchUpdateApiVersions(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range response.ApiVersions {
+		v.Name = p.testResourceName(v.Name)
+	}
+	return response, err
+}
+
+func (p *Proxy) BatchDeleteApiVersions(ctx context.Context, req *rpc.BatchDeleteApiVersionsRequest) (*rpc.BatchDeleteApiVersionsResponse, error) {
+	req, _ = proto.Clone(req).(*rpc.BatchDeleteApiVersionsRequest)
+	req.Parent = p.hostedResourceName(req.Parent)
+	for _, r := range req.Requests {
+		r.Name = p.hostedResourceName(r.Name)
+	}
+	return p.registryClient.GrpcClient().BatchDeleteApiVersions(ctx, req)
+}
+
+func (p *Proxy) BatchCreateApiSpecs(ctx context.Context, req *rpc.BatchCreateApiSpecsRequest) (*rpc.BatchCreateApiSpecsResponse, error) {
+	req, _ = proto.Clone(req).(*rpc.BatchCreateApiSpecsRequest)
+	req.Parent = p.hostedResourceName(req.Parent)
+	for _, r := range req.Requests {
+		r.Parent = p.hostedResourceName(r.Parent)
+	}
+	response, err := p.registryClient.GrpcClient().BatchCreateApiSpecs(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range response.ApiSpecs {
+		v.Name = p.testResourceName(v.Name)
+	}
+	return response, err
+}
+
+func (p *Proxy) BatchUpdateApiSpecs(ctx context.Context, req *rpc.BatchUpdateApiSpecsRequest) (*rpc.BatchUpdateApiSpecsResponse, error) {
+	req, _ = proto.Clone(req).(*rpc.BatchUpdateApiSpecsRequest)
+	req.Parent = p.hostedResourceName(req.Parent)
+	for _, r := range req.Requests {
+		if r.ApiSpec != nil {
+			r.ApiSpec.Name = p.hostedResourceName(r.ApiSpec.Name)
+		}
+	}
+	response, err := p.registryClient.GrpcClient().BatchUpdateApiSpecs(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range response.ApiSpecs {
+		v.Name = p.testResourceName(v.Name)
+	}
+	return response, err
+}
+
+func (p *Proxy) BatchDeleteApiSpecs(ctx context.Context, req *rpc.BatchDeleteApiSpecsRequest) (*rpc.BatchDeleteApiSpecsResponse, error) {
+	req, _ = proto.Clone(req).(*rpc.BatchDeleteApiSpecsRequest)
+	req.Parent = p.hostedResourceName(req.Parent)
+	for _, r := range req.Requests {
+		r.Name = p.hostedResourceName(r.Name)
+	}
+	return p.registryClient.GrpcClient().BatchDeleteApiSpecs(ctx, req)
+}
+
+func (p *Proxy) BatchCreateApiDeployments(ctx context.Context, req *rpc.BatchCreateApiDeploymentsRequest) (*rpc.BatchCreateApiDeploymentsResponse, error) {
+	req, _ = proto.Clone(req).(*rpc.BatchCreateApiDeploymentsRequest)
+	req.Parent = p.hostedResourceName(req.Parent)
+	for _, r := range req.Requests {
+		r.Parent = p.hostedResourceName(r.Parent)
+	}
+	response, err := p.registryClient.GrpcClient().BatchCreateApiDeployments(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range response.ApiDeployments {
+		v.Name = p.testResourceName(v.Name)
+	}
+	return response, err
+}
+
+func (p *Proxy) BatchUpdateApiDeployments(ctx context.Context, req *rpc.BatchUpdateApiDeploymentsRequest) (*rpc.BatchUpdateApiDeploymentsResponse, error) {
+	req, _ = proto.Clone(req).(*rpc.BatchUpdateApiDeploymentsRequest)
+	req.Parent = p.hostedResourceName(req.Parent)
+	for _, r := range req.Requests {
+		if r.ApiDeployment != nil {
+			r.ApiDeployment.Name = p.hostedResourceName(r.ApiDeployment.Name)
+		}
+	}
+	response, err := p.registryClient.GrpcClient().BatchUpdateApiDeployments(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range response.ApiDeployments {
+		v.Name = p.testResourceName(v.Name)
+	}
+	return response, err
+}
+
+func (p *Proxy) BatchDeleteApiDeployments(ctx context.Context, req *rpc.BatchDeleteApiDeploymentsRequest) (*rpc.BatchDeleteApiDeploymentsResponse, error) {
+	req, _ = proto.Clone(req).(*rpc.BatchDeleteApiDeploymentsRequest)
+	req.Parent = p.hostedResourceName(req.Parent)
+	for _, r := range req.Requests {
+		r.Name = p.hostedResourceName(r.Name)
+	}
+	return p.registryClient.GrpcClient().BatchDeleteApiDeployments(ctx, req)
+}
+
+func (p *Proxy) BatchCreateArtifacts(ctx context.Context, req *rpc.BatchCreateArtifactsRequest) (*rpc.BatchCreateArtifactsResponse, error) {
+	req, _ = proto.Clone(req).(*rpc.BatchCreateArtifactsRequest)
+	req.Parent = p.hostedResourceName(req.Parent)
+	for _, r := range req.Requests {
+		r.Parent = p.hostedResourceName(r.Parent)
+	}
+	response, err := p.registryClient.GrpcClient().BatchCreateArtifacts(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range response.Artifacts {
+		v.Name = p.testResourceName(v.Name)
+	}
+	return response, err
+}
+
+func (p *Proxy) BatchReplaceArtifacts(ctx context.Context, req *rpc.BatchReplaceArtifactsRequest) (*rpc.BatchReplaceArtifactsResponse, error) {
+	req, _ = proto.Clone(req).(*rpc.BatchReplaceArtifactsRequest)
+	req.Parent = p.hostedResourceName(req.Parent)
+	for _, r := range req.Requests {
+		if r.Artifact != nil {
+			r.Artifact.Name = p.hostedResourceName(r.Artifact.Name)
+		}
+	}
+	response, err := p.registryClient.GrpcClient().BatchReplaceArtifacts(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range response.Artifacts {
+		v.Name = p.testResourceName(v.Name)
+	}
+	return response, err
+}
+
+func (p *Proxy) BatchDeleteArtifacts(ctx context.Context, req *rpc.BatchDeleteArtifactsRequest) (*rpc.BatchDeleteArtifactsResponse, error) {
+	req, _ = proto.Clone(req).(*rpc.BatchDeleteArtifactsRequest)
+	req.Parent = p.hostedResourceName(req.Parent)
+	for _, r := range req.Requests {
+		r.Name = p.hostedResourceName(r.Name)
+	}
+	return p.registryClient.GrpcClient().BatchDeleteArtifacts(ctx, req)
+}
+
+func (p *Proxy) ListReferences(ctx context.Context, req *rpc.ListReferencesRequest) (*rpc.ListReferencesResponse, error) {
+	req, _ = proto.Clone(req).(*rpc.ListReferencesRequest)
+	req.Name = p.hostedResourceName(req.Name)
+	response, err := p.registryClient.GrpcClient().ListReferences(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range response.References {
+		r.Source = p.testResourceName(r.Source)
+		r.Target = p.testResourceName(r.Target)
+	}
+	return response, err
+}
+
+func (p *Proxy) ListReferrers(ctx context.Context, req *rpc.ListReferrersRequest) (*rpc.ListReferrersResponse, error) {
+	req, _ = proto.Clone(req).(*rpc.ListReferrersRequest)
+	req.Name = p.hostedResourceName(req.Name)
+	response, err := p.registryClient.GrpcClient().ListReferrers(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range response.References {
+		r.Source = p.testResourceName(r.Source)
+		r.Target = p.testResourceName(r.Target)
+	}
+	return response, err
+}
+
+func (p *Proxy) SearchResources(ctx context.Context, req *rpc.SearchResourcesRequest) (*rpc.SearchResourcesResponse, error) {
+	req, _ = proto.Clone(req).(*rpc.SearchResourcesRequest)
+	req.Parent = p.hostedResourceName(req.Parent)
+	response, err := p.registryClient.GrpcClient().SearchResources(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range response.Results {
+		r.Name = p.testResourceName(r.Name)
+	}
+	return response, err
+}
+
+func (p *Proxy) ListSpecElements(ctx context.Context, req *rpc.ListSpecElementsRequest) (*rpc.ListSpecElementsResponse, error) {
+	req, _ = proto.Clone(req).(*rpc.ListSpecElementsRequest)
+	req.Parent = p.hostedResourceName(req.Parent)
+	response, err := p.registryClient.GrpcClient().ListSpecElements(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range response.Elements {
+		e.Spec = p.testResourceName(e.Spec)
+	}
+	return response, err
+}
+
 // helpers
 
 func (p *Proxy) hostedResourceName(name string) string {
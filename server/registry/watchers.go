@@ -0,0 +1,98 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"sync"
+
+	"github.com/apigee/registry/rpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// watcherBufferSize is the number of notifications that can be queued for a
+// watcher before it is considered too slow and is disconnected.
+const watcherBufferSize = 1000
+
+var (
+	errWatcherOverflow = status.Error(codes.ResourceExhausted, "watcher fell too far behind, notifications were dropped")
+	errWatcherClosed   = status.Error(codes.Unavailable, "server is shutting down")
+)
+
+// watcher receives the notifications delivered to a single WatchResources stream.
+type watcher struct {
+	ch  chan *rpc.Notification
+	err error // set before ch is closed
+}
+
+// watchers fans out notifications to all active WatchResources streams.
+// The zero value is ready to use.
+type watchers struct {
+	mu     sync.Mutex
+	subs   map[*watcher]struct{}
+	closed bool
+}
+
+// subscribe registers a new watcher. Callers must unsubscribe when finished.
+func (w *watchers) subscribe() *watcher {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	sub := &watcher{ch: make(chan *rpc.Notification, watcherBufferSize)}
+	if w.closed {
+		sub.err = errWatcherClosed
+		close(sub.ch)
+		return sub
+	}
+	if w.subs == nil {
+		w.subs = make(map[*watcher]struct{})
+	}
+	w.subs[sub] = struct{}{}
+	return sub
+}
+
+func (w *watchers) unsubscribe(sub *watcher) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.subs, sub)
+}
+
+// publish delivers a notification to every watcher without blocking.
+// Watchers that can't keep up are disconnected rather than allowed to
+// silently miss notifications.
+func (w *watchers) publish(n *rpc.Notification) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for sub := range w.subs {
+		select {
+		case sub.ch <- n:
+		default:
+			sub.err = errWatcherOverflow
+			close(sub.ch)
+			delete(w.subs, sub)
+		}
+	}
+}
+
+// close disconnects all watchers and rejects new ones.
+func (w *watchers) close() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for sub := range w.subs {
+		sub.err = errWatcherClosed
+		close(sub.ch)
+		delete(w.subs, sub)
+	}
+	w.closed = true
+}
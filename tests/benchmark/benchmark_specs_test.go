@@ -0,0 +1,102 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/apigee/registry/pkg/connection"
+	"github.com/apigee/registry/rpc"
+)
+
+func specName(apiId string) string {
+	return fmt.Sprintf("%s/versions/v1/specs/openapi.yaml", apiName(apiId))
+}
+
+func createVersion(ctx context.Context, client connection.RegistryClient, apiId string) error {
+	_, err := client.CreateApiVersion(ctx, &rpc.CreateApiVersionRequest{
+		Parent:       apiName(apiId),
+		ApiVersionId: "v1",
+		ApiVersion:   &rpc.ApiVersion{},
+	})
+	return err
+}
+
+// uploadSpec is called from the goroutines of b.RunParallel, where b.Helper() must not be called.
+func uploadSpec(ctx context.Context, client connection.RegistryClient, apiId string, i int64) error {
+	_, err := client.UpdateApiSpec(ctx, &rpc.UpdateApiSpecRequest{
+		ApiSpec: &rpc.ApiSpec{
+			Name:     specName(apiId),
+			MimeType: "application/x.openapi;version=3.0.0",
+			Contents: []byte(fmt.Sprintf("openapi: 3.0.0\ninfo:\n  title: %s\n  version: %d\n", apiId, i)),
+		},
+		AllowMissing: true,
+	})
+	return err
+}
+
+// BenchmarkParallelUploadSpecs uploads specs of many APIs concurrently, as CI
+// pipelines do. Uploads to different APIs don't wait for each other, so this
+// scales with the parallelism of the benchmark (set with -cpu).
+func BenchmarkParallelUploadSpecs(b *testing.B) {
+	const apis = 10
+	ctx, client := setup(b)
+	for i := 1; i <= apis; i++ {
+		if err := createApi(b, ctx, client, apiId(i)); err != nil {
+			b.Fatalf("%s", err)
+		}
+		if err := createVersion(ctx, client, apiId(i)); err != nil {
+			b.Fatalf("%s", err)
+		}
+	}
+	b.Run("UploadSpecs", func(b *testing.B) {
+		var n int64
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				i := atomic.AddInt64(&n, 1)
+				if err := uploadSpec(ctx, client, apiId(int(i%apis)+1), i); err != nil {
+					b.Errorf("%s", err)
+				}
+			}
+		})
+	})
+	teardown(ctx, b, client)
+}
+
+// BenchmarkParallelUploadSpec uploads revisions of a single spec concurrently.
+// Uploads of the same spec are serialized so that revisions are saved in order.
+func BenchmarkParallelUploadSpec(b *testing.B) {
+	ctx, client := setup(b)
+	if err := createApi(b, ctx, client, apiId(1)); err != nil {
+		b.Fatalf("%s", err)
+	}
+	if err := createVersion(ctx, client, apiId(1)); err != nil {
+		b.Fatalf("%s", err)
+	}
+	b.Run("UploadSpec", func(b *testing.B) {
+		var n int64
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				if err := uploadSpec(ctx, client, apiId(1), atomic.AddInt64(&n, 1)); err != nil {
+					b.Errorf("%s", err)
+				}
+			}
+		})
+	})
+	teardown(ctx, b, client)
+}